@@ -0,0 +1,34 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package amqp
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// PublishAppEvent publishes an event to the routing key for application events of the given type
+// it will marshal the payload to json
+func (c *DefaultPublisher) PublishAppEvent(appID string, eventType types.EventType, payload interface{}) error {
+	key := ApplicationKey{appID, AppEvents, string(eventType)}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the message payload")
+	}
+	return c.publish(key.String(), msg, time.Now())
+}
+
+// PublishDeviceEvent publishes an event to the routing key for device events of the given type
+// it will marshal the payload to json
+func (c *DefaultPublisher) PublishDeviceEvent(appID string, devID string, eventType types.EventType, payload interface{}) error {
+	key := DeviceKey{appID, devID, DeviceEvents, string(eventType)}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the message payload")
+	}
+	return c.publish(key.String(), msg, time.Now())
+}
@@ -0,0 +1,33 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package amqp
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestPublishEvents(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "TestPublishEvents"), "guest", "guest", host)
+	err := c.Connect()
+	a.So(err, ShouldBeNil)
+	defer c.Disconnect()
+
+	p := c.NewPublisher("amq.topic")
+	err = p.Open()
+	a.So(err, ShouldBeNil)
+	defer p.Close()
+
+	err = p.PublishAppEvent("app", "some-event", "payload")
+	a.So(err, ShouldBeNil)
+
+	err = p.PublishDeviceEvent("app", "test", types.DownlinkAckEvent, types.DownlinkEventData{CorrelationID: "id"})
+	a.So(err, ShouldBeNil)
+
+	err = p.PublishDeviceEvent("app", "test", "some-event", func() {})
+	a.So(err, ShouldNotBeNil)
+}
@@ -10,12 +10,14 @@ import (
 	AMQP "github.com/streadway/amqp"
 )
 
-// Publisher represents a publisher for uplink messages
+// Publisher represents a publisher for uplink messages and events
 type Publisher interface {
 	ChannelClient
 
 	PublishUplink(dataUp types.UplinkMessage) error
 	PublishDownlink(dataDown types.DownlinkMessage) error
+	PublishAppEvent(appID string, eventType types.EventType, payload interface{}) error
+	PublishDeviceEvent(appID string, devID string, eventType types.EventType, payload interface{}) error
 }
 
 // DefaultPublisher represents the default AMQP publisher
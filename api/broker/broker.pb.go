@@ -10,8 +10,10 @@
 
 	It has these top-level messages:
 		DownlinkOption
+		Multicast
 		UplinkMessage
 		DownlinkMessage
+		TxAcknowledgment
 		DeviceActivationResponse
 		DeduplicatedUplinkMessage
 		DeviceActivationRequest
@@ -115,6 +117,42 @@ func (m *DownlinkOption) GetGatewayConfig() *gateway.TxConfiguration {
 	return nil
 }
 
+// Multicast contains the information that is needed to schedule a downlink message to a multicast group
+type Multicast struct {
+	// ID of the multicast group within the application
+	GroupId string `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// IDs of the gateways that should transmit the downlink message
+	GatewayIds []string `protobuf:"bytes,2,rep,name=gateway_ids,json=gatewayIds" json:"gateway_ids,omitempty"`
+	// DownlinkOptions on the selected gateways, set by the NetworkServer
+	DownlinkOptions []*DownlinkOption `protobuf:"bytes,3,rep,name=downlink_options,json=downlinkOptions" json:"downlink_options,omitempty"`
+}
+
+func (m *Multicast) Reset()                    { *m = Multicast{} }
+func (m *Multicast) String() string            { return proto.CompactTextString(m) }
+func (*Multicast) ProtoMessage()               {}
+func (*Multicast) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{1} }
+
+func (m *Multicast) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *Multicast) GetGatewayIds() []string {
+	if m != nil {
+		return m.GatewayIds
+	}
+	return nil
+}
+
+func (m *Multicast) GetDownlinkOptions() []*DownlinkOption {
+	if m != nil {
+		return m.DownlinkOptions
+	}
+	return nil
+}
+
 // received from the Router
 type UplinkMessage struct {
 	Payload          []byte                                             `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
@@ -132,7 +170,7 @@ type UplinkMessage struct {
 func (m *UplinkMessage) Reset()                    { *m = UplinkMessage{} }
 func (m *UplinkMessage) String() string            { return proto.CompactTextString(m) }
 func (*UplinkMessage) ProtoMessage()               {}
-func (*UplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{1} }
+func (*UplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{2} }
 
 func (m *UplinkMessage) GetPayload() []byte {
 	if m != nil {
@@ -199,13 +237,22 @@ type DownlinkMessage struct {
 	AppId          string                                             `protobuf:"bytes,13,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	DevId          string                                             `protobuf:"bytes,14,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
 	DownlinkOption *DownlinkOption                                    `protobuf:"bytes,21,opt,name=downlink_option,json=downlinkOption" json:"downlink_option,omitempty"`
-	Trace          *trace.Trace                                       `protobuf:"bytes,31,opt,name=trace" json:"trace,omitempty"`
+	// Multicast is set for downlink messages to a multicast group, these messages have no DevEUI and DevID
+	Multicast *Multicast `protobuf:"bytes,22,opt,name=multicast" json:"multicast,omitempty"`
+	// Retransmission is set for confirmed downlink messages that are sent again because they were not acknowledged,
+	// the NetworkServer only reuses the FCnt of the pending confirmed downlink for these messages
+	Retransmission bool `protobuf:"varint,23,opt,name=retransmission,proto3" json:"retransmission,omitempty"`
+	// The ADR algorithm and data rate of the application, the NetworkServer uses these for devices that have no
+	// ADR algorithm or fixed data rate of their own
+	AppAdrAlgorithm string       `protobuf:"bytes,24,opt,name=app_adr_algorithm,json=appAdrAlgorithm,proto3" json:"app_adr_algorithm,omitempty"`
+	AppAdrDataRate  string       `protobuf:"bytes,25,opt,name=app_adr_data_rate,json=appAdrDataRate,proto3" json:"app_adr_data_rate,omitempty"`
+	Trace           *trace.Trace `protobuf:"bytes,31,opt,name=trace" json:"trace,omitempty"`
 }
 
 func (m *DownlinkMessage) Reset()                    { *m = DownlinkMessage{} }
 func (m *DownlinkMessage) String() string            { return proto.CompactTextString(m) }
 func (*DownlinkMessage) ProtoMessage()               {}
-func (*DownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{2} }
+func (*DownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{3} }
 
 func (m *DownlinkMessage) GetPayload() []byte {
 	if m != nil {
@@ -242,6 +289,34 @@ func (m *DownlinkMessage) GetDownlinkOption() *DownlinkOption {
 	return nil
 }
 
+func (m *DownlinkMessage) GetMulticast() *Multicast {
+	if m != nil {
+		return m.Multicast
+	}
+	return nil
+}
+
+func (m *DownlinkMessage) GetRetransmission() bool {
+	if m != nil {
+		return m.Retransmission
+	}
+	return false
+}
+
+func (m *DownlinkMessage) GetAppAdrAlgorithm() string {
+	if m != nil {
+		return m.AppAdrAlgorithm
+	}
+	return ""
+}
+
+func (m *DownlinkMessage) GetAppAdrDataRate() string {
+	if m != nil {
+		return m.AppAdrDataRate
+	}
+	return ""
+}
+
 func (m *DownlinkMessage) GetTrace() *trace.Trace {
 	if m != nil {
 		return m.Trace
@@ -249,6 +324,59 @@ func (m *DownlinkMessage) GetTrace() *trace.Trace {
 	return nil
 }
 
+// received from the Router, sent to the Handler when a gateway could not transmit a DownlinkMessage
+type TxAcknowledgment struct {
+	DevEui *github_com_TheThingsNetwork_ttn_core_types.DevEUI `protobuf:"bytes,11,opt,name=dev_eui,json=devEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevEUI" json:"dev_eui,omitempty"`
+	AppEui *github_com_TheThingsNetwork_ttn_core_types.AppEUI `protobuf:"bytes,12,opt,name=app_eui,json=appEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppEUI" json:"app_eui,omitempty"`
+	AppId  string                                             `protobuf:"bytes,13,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	DevId  string                                             `protobuf:"bytes,14,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// ID of the gateway that handled the DownlinkMessage
+	GatewayId string `protobuf:"bytes,21,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// The reason why the gateway could not transmit the DownlinkMessage, empty if it was transmitted
+	Error string       `protobuf:"bytes,22,opt,name=error,proto3" json:"error,omitempty"`
+	Trace *trace.Trace `protobuf:"bytes,31,opt,name=trace" json:"trace,omitempty"`
+}
+
+func (m *TxAcknowledgment) Reset()                    { *m = TxAcknowledgment{} }
+func (m *TxAcknowledgment) String() string            { return proto.CompactTextString(m) }
+func (*TxAcknowledgment) ProtoMessage()               {}
+func (*TxAcknowledgment) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{4} }
+
+func (m *TxAcknowledgment) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *TxAcknowledgment) GetDevId() string {
+	if m != nil {
+		return m.DevId
+	}
+	return ""
+}
+
+func (m *TxAcknowledgment) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+func (m *TxAcknowledgment) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *TxAcknowledgment) GetTrace() *trace.Trace {
+	if m != nil {
+		return m.Trace
+	}
+	return nil
+}
+
 // sent to the Router, used as Template
 type DeviceActivationResponse struct {
 	Payload        []byte            `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
@@ -260,7 +388,7 @@ type DeviceActivationResponse struct {
 func (m *DeviceActivationResponse) Reset()                    { *m = DeviceActivationResponse{} }
 func (m *DeviceActivationResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeviceActivationResponse) ProtoMessage()               {}
-func (*DeviceActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{3} }
+func (*DeviceActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{5} }
 
 func (m *DeviceActivationResponse) GetPayload() []byte {
 	if m != nil {
@@ -308,7 +436,7 @@ type DeduplicatedUplinkMessage struct {
 func (m *DeduplicatedUplinkMessage) Reset()                    { *m = DeduplicatedUplinkMessage{} }
 func (m *DeduplicatedUplinkMessage) String() string            { return proto.CompactTextString(m) }
 func (*DeduplicatedUplinkMessage) ProtoMessage()               {}
-func (*DeduplicatedUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{4} }
+func (*DeduplicatedUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{6} }
 
 func (m *DeduplicatedUplinkMessage) GetPayload() []byte {
 	if m != nil {
@@ -389,7 +517,7 @@ type DeviceActivationRequest struct {
 func (m *DeviceActivationRequest) Reset()                    { *m = DeviceActivationRequest{} }
 func (m *DeviceActivationRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeviceActivationRequest) ProtoMessage()               {}
-func (*DeviceActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{5} }
+func (*DeviceActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{7} }
 
 func (m *DeviceActivationRequest) GetPayload() []byte {
 	if m != nil {
@@ -460,7 +588,7 @@ func (m *DeduplicatedDeviceActivationRequest) Reset()         { *m = Deduplicate
 func (m *DeduplicatedDeviceActivationRequest) String() string { return proto.CompactTextString(m) }
 func (*DeduplicatedDeviceActivationRequest) ProtoMessage()    {}
 func (*DeduplicatedDeviceActivationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorBroker, []int{6}
+	return fileDescriptorBroker, []int{8}
 }
 
 func (m *DeduplicatedDeviceActivationRequest) GetPayload() []byte {
@@ -545,7 +673,7 @@ type ActivationChallengeRequest struct {
 func (m *ActivationChallengeRequest) Reset()                    { *m = ActivationChallengeRequest{} }
 func (m *ActivationChallengeRequest) String() string            { return proto.CompactTextString(m) }
 func (*ActivationChallengeRequest) ProtoMessage()               {}
-func (*ActivationChallengeRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{7} }
+func (*ActivationChallengeRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{9} }
 
 func (m *ActivationChallengeRequest) GetPayload() []byte {
 	if m != nil {
@@ -584,7 +712,7 @@ func (m *ActivationChallengeResponse) Reset()         { *m = ActivationChallenge
 func (m *ActivationChallengeResponse) String() string { return proto.CompactTextString(m) }
 func (*ActivationChallengeResponse) ProtoMessage()    {}
 func (*ActivationChallengeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorBroker, []int{8}
+	return fileDescriptorBroker, []int{10}
 }
 
 func (m *ActivationChallengeResponse) GetPayload() []byte {
@@ -608,7 +736,7 @@ type SubscribeRequest struct {
 func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
 func (m *SubscribeRequest) String() string            { return proto.CompactTextString(m) }
 func (*SubscribeRequest) ProtoMessage()               {}
-func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{9} }
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{11} }
 
 // message StatusRequest is used to request the status of this Broker
 type StatusRequest struct {
@@ -617,7 +745,7 @@ type StatusRequest struct {
 func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
 func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()               {}
-func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{10} }
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{12} }
 
 type Status struct {
 	System            *api.SystemStats    `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
@@ -636,7 +764,7 @@ type Status struct {
 func (m *Status) Reset()                    { *m = Status{} }
 func (m *Status) String() string            { return proto.CompactTextString(m) }
 func (*Status) ProtoMessage()               {}
-func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{11} }
+func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{13} }
 
 func (m *Status) GetSystem() *api.SystemStats {
 	if m != nil {
@@ -717,7 +845,7 @@ func (m *ApplicationHandlerRegistration) Reset()         { *m = ApplicationHandl
 func (m *ApplicationHandlerRegistration) String() string { return proto.CompactTextString(m) }
 func (*ApplicationHandlerRegistration) ProtoMessage()    {}
 func (*ApplicationHandlerRegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptorBroker, []int{12}
+	return fileDescriptorBroker, []int{14}
 }
 
 func (m *ApplicationHandlerRegistration) GetAppId() string {
@@ -736,8 +864,10 @@ func (m *ApplicationHandlerRegistration) GetHandlerId() string {
 
 func init() {
 	proto.RegisterType((*DownlinkOption)(nil), "broker.DownlinkOption")
+	proto.RegisterType((*Multicast)(nil), "broker.Multicast")
 	proto.RegisterType((*UplinkMessage)(nil), "broker.UplinkMessage")
 	proto.RegisterType((*DownlinkMessage)(nil), "broker.DownlinkMessage")
+	proto.RegisterType((*TxAcknowledgment)(nil), "broker.TxAcknowledgment")
 	proto.RegisterType((*DeviceActivationResponse)(nil), "broker.DeviceActivationResponse")
 	proto.RegisterType((*DeduplicatedUplinkMessage)(nil), "broker.DeduplicatedUplinkMessage")
 	proto.RegisterType((*DeviceActivationRequest)(nil), "broker.DeviceActivationRequest")
@@ -769,6 +899,8 @@ type BrokerClient interface {
 	Publish(ctx context.Context, opts ...grpc.CallOption) (Broker_PublishClient, error)
 	// Router requests device activation
 	Activate(ctx context.Context, in *DeviceActivationRequest, opts ...grpc.CallOption) (*DeviceActivationResponse, error)
+	// Router reports a downlink message that could not be transmitted by the gateway
+	TxAck(ctx context.Context, in *TxAcknowledgment, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type brokerClient struct {
@@ -885,6 +1017,15 @@ func (c *brokerClient) Activate(ctx context.Context, in *DeviceActivationRequest
 	return out, nil
 }
 
+func (c *brokerClient) TxAck(ctx context.Context, in *TxAcknowledgment, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/broker.Broker/TxAck", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Broker service
 
 type BrokerServer interface {
@@ -896,6 +1037,8 @@ type BrokerServer interface {
 	Publish(Broker_PublishServer) error
 	// Router requests device activation
 	Activate(context.Context, *DeviceActivationRequest) (*DeviceActivationResponse, error)
+	// Router reports a downlink message that could not be transmitted by the gateway
+	TxAck(context.Context, *TxAcknowledgment) (*google_protobuf.Empty, error)
 }
 
 func RegisterBrokerServer(s *grpc.Server, srv BrokerServer) {
@@ -993,6 +1136,24 @@ func _Broker_Activate_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_TxAck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxAcknowledgment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).TxAck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/TxAck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).TxAck(ctx, req.(*TxAcknowledgment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Broker_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "broker.Broker",
 	HandlerType: (*BrokerServer)(nil),
@@ -1001,6 +1162,10 @@ var _Broker_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Activate",
 			Handler:    _Broker_Activate_Handler,
 		},
+		{
+			MethodName: "TxAck",
+			Handler:    _Broker_TxAck_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1186,6 +1351,57 @@ func (m *DownlinkOption) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *Multicast) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Multicast) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.GroupId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.GroupId)))
+		i += copy(dAtA[i:], m.GroupId)
+	}
+	if len(m.GatewayIds) > 0 {
+		for _, s := range m.GatewayIds {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.DownlinkOptions) > 0 {
+		for _, msg := range m.DownlinkOptions {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintBroker(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func (m *UplinkMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1377,17 +1593,135 @@ func (m *DownlinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n12
 	}
+	if m.Multicast != nil {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.Multicast.Size()))
+		n13, err := m.Multicast.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.Retransmission {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.Retransmission {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.AppAdrAlgorithm) > 0 {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.AppAdrAlgorithm)))
+		i += copy(dAtA[i:], m.AppAdrAlgorithm)
+	}
+	if len(m.AppAdrDataRate) > 0 {
+		dAtA[i] = 0xca
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.AppAdrDataRate)))
+		i += copy(dAtA[i:], m.AppAdrDataRate)
+	}
 	if m.Trace != nil {
 		dAtA[i] = 0xfa
 		i++
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Trace.Size()))
-		n13, err := m.Trace.MarshalTo(dAtA[i:])
+		n14, err := m.Trace.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n14
+	}
+	return i, nil
+}
+
+func (m *TxAcknowledgment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxAcknowledgment) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.DevEui != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.DevEui.Size()))
+		n15, err := m.DevEui.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.AppEui != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.AppEui.Size()))
+		n16, err := m.AppEui.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
+	if len(m.DevId) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.DevId)))
+		i += copy(dAtA[i:], m.DevId)
+	}
+	if len(m.GatewayId) > 0 {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.GatewayId)))
+		i += copy(dAtA[i:], m.GatewayId)
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.Trace != nil {
+		dAtA[i] = 0xfa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.Trace.Size()))
+		n17, err := m.Trace.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
 	}
 	return i, nil
 }
@@ -1417,21 +1751,21 @@ func (m *DeviceActivationResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Message.Size()))
-		n14, err := m.Message.MarshalTo(dAtA[i:])
+		n18, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n18
 	}
 	if m.DownlinkOption != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.DownlinkOption.Size()))
-		n15, err := m.DownlinkOption.MarshalTo(dAtA[i:])
+		n19, err := m.DownlinkOption.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n19
 	}
 	if m.Trace != nil {
 		dAtA[i] = 0xaa
@@ -1439,11 +1773,11 @@ func (m *DeviceActivationResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Trace.Size()))
-		n16, err := m.Trace.MarshalTo(dAtA[i:])
+		n20, err := m.Trace.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n20
 	}
 	return i, nil
 }
@@ -1473,31 +1807,31 @@ func (m *DeduplicatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Message.Size()))
-		n17, err := m.Message.MarshalTo(dAtA[i:])
+		n21, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n21
 	}
 	if m.DevEui != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.DevEui.Size()))
-		n18, err := m.DevEui.MarshalTo(dAtA[i:])
+		n22, err := m.DevEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n22
 	}
 	if m.AppEui != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.AppEui.Size()))
-		n19, err := m.AppEui.MarshalTo(dAtA[i:])
+		n23, err := m.AppEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n23
 	}
 	if len(m.AppId) > 0 {
 		dAtA[i] = 0x6a
@@ -1517,11 +1851,11 @@ func (m *DeduplicatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ProtocolMetadata.Size()))
-		n20, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
+		n24, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n24
 	}
 	if len(m.GatewayMetadata) > 0 {
 		for _, msg := range m.GatewayMetadata {
@@ -1550,11 +1884,11 @@ func (m *DeduplicatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ResponseTemplate.Size()))
-		n21, err := m.ResponseTemplate.MarshalTo(dAtA[i:])
+		n25, err := m.ResponseTemplate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n25
 	}
 	if m.Trace != nil {
 		dAtA[i] = 0xca
@@ -1562,11 +1896,11 @@ func (m *DeduplicatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Trace.Size()))
-		n22, err := m.Trace.MarshalTo(dAtA[i:])
+		n26, err := m.Trace.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n22
+		i += n26
 	}
 	return i, nil
 }
@@ -1596,31 +1930,31 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Message.Size()))
-		n23, err := m.Message.MarshalTo(dAtA[i:])
+		n27, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n23
+		i += n27
 	}
 	if m.DevEui != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.DevEui.Size()))
-		n24, err := m.DevEui.MarshalTo(dAtA[i:])
+		n28, err := m.DevEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n24
+		i += n28
 	}
 	if m.AppEui != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.AppEui.Size()))
-		n25, err := m.AppEui.MarshalTo(dAtA[i:])
+		n29, err := m.AppEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n29
 	}
 	if m.ProtocolMetadata != nil {
 		dAtA[i] = 0xaa
@@ -1628,11 +1962,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ProtocolMetadata.Size()))
-		n26, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
+		n30, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n30
 	}
 	if m.GatewayMetadata != nil {
 		dAtA[i] = 0xb2
@@ -1640,11 +1974,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.GatewayMetadata.Size()))
-		n27, err := m.GatewayMetadata.MarshalTo(dAtA[i:])
+		n31, err := m.GatewayMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n27
+		i += n31
 	}
 	if m.ActivationMetadata != nil {
 		dAtA[i] = 0xba
@@ -1652,11 +1986,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ActivationMetadata.Size()))
-		n28, err := m.ActivationMetadata.MarshalTo(dAtA[i:])
+		n32, err := m.ActivationMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n28
+		i += n32
 	}
 	if len(m.DownlinkOptions) > 0 {
 		for _, msg := range m.DownlinkOptions {
@@ -1678,11 +2012,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Trace.Size()))
-		n29, err := m.Trace.MarshalTo(dAtA[i:])
+		n33, err := m.Trace.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n29
+		i += n33
 	}
 	return i, nil
 }
@@ -1712,31 +2046,31 @@ func (m *DeduplicatedDeviceActivationRequest) MarshalTo(dAtA []byte) (int, error
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Message.Size()))
-		n30, err := m.Message.MarshalTo(dAtA[i:])
+		n34, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n30
+		i += n34
 	}
 	if m.DevEui != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.DevEui.Size()))
-		n31, err := m.DevEui.MarshalTo(dAtA[i:])
+		n35, err := m.DevEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n31
+		i += n35
 	}
 	if m.AppEui != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.AppEui.Size()))
-		n32, err := m.AppEui.MarshalTo(dAtA[i:])
+		n36, err := m.AppEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n32
+		i += n36
 	}
 	if len(m.AppId) > 0 {
 		dAtA[i] = 0x6a
@@ -1756,11 +2090,11 @@ func (m *DeduplicatedDeviceActivationRequest) MarshalTo(dAtA []byte) (int, error
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ProtocolMetadata.Size()))
-		n33, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
+		n37, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n33
+		i += n37
 	}
 	if len(m.GatewayMetadata) > 0 {
 		for _, msg := range m.GatewayMetadata {
@@ -1782,11 +2116,11 @@ func (m *DeduplicatedDeviceActivationRequest) MarshalTo(dAtA []byte) (int, error
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ActivationMetadata.Size()))
-		n34, err := m.ActivationMetadata.MarshalTo(dAtA[i:])
+		n38, err := m.ActivationMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n34
+		i += n38
 	}
 	if m.ServerTime != 0 {
 		dAtA[i] = 0xc0
@@ -1801,11 +2135,11 @@ func (m *DeduplicatedDeviceActivationRequest) MarshalTo(dAtA []byte) (int, error
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ResponseTemplate.Size()))
-		n35, err := m.ResponseTemplate.MarshalTo(dAtA[i:])
+		n39, err := m.ResponseTemplate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n35
+		i += n39
 	}
 	if m.Trace != nil {
 		dAtA[i] = 0xca
@@ -1813,11 +2147,11 @@ func (m *DeduplicatedDeviceActivationRequest) MarshalTo(dAtA []byte) (int, error
 		dAtA[i] = 0x2
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Trace.Size()))
-		n36, err := m.Trace.MarshalTo(dAtA[i:])
+		n40, err := m.Trace.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n36
+		i += n40
 	}
 	return i, nil
 }
@@ -1847,31 +2181,31 @@ func (m *ActivationChallengeRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Message.Size()))
-		n37, err := m.Message.MarshalTo(dAtA[i:])
+		n41, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n37
+		i += n41
 	}
 	if m.DevEui != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.DevEui.Size()))
-		n38, err := m.DevEui.MarshalTo(dAtA[i:])
+		n42, err := m.DevEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n38
+		i += n42
 	}
 	if m.AppEui != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.AppEui.Size()))
-		n39, err := m.AppEui.MarshalTo(dAtA[i:])
+		n43, err := m.AppEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n39
+		i += n43
 	}
 	if len(m.AppId) > 0 {
 		dAtA[i] = 0x6a
@@ -1913,11 +2247,11 @@ func (m *ActivationChallengeResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Message.Size()))
-		n40, err := m.Message.MarshalTo(dAtA[i:])
+		n44, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n40
+		i += n44
 	}
 	return i, nil
 }
@@ -1977,71 +2311,71 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.System.Size()))
-		n41, err := m.System.MarshalTo(dAtA[i:])
+		n45, err := m.System.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
+		i += n45
 	}
 	if m.Component != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Component.Size()))
-		n42, err := m.Component.MarshalTo(dAtA[i:])
+		n46, err := m.Component.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n42
+		i += n46
 	}
 	if m.Uplink != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Uplink.Size()))
-		n43, err := m.Uplink.MarshalTo(dAtA[i:])
+		n47, err := m.Uplink.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n47
 	}
 	if m.UplinkUnique != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.UplinkUnique.Size()))
-		n44, err := m.UplinkUnique.MarshalTo(dAtA[i:])
+		n48, err := m.UplinkUnique.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n44
+		i += n48
 	}
 	if m.Downlink != nil {
 		dAtA[i] = 0x6a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Downlink.Size()))
-		n45, err := m.Downlink.MarshalTo(dAtA[i:])
+		n49, err := m.Downlink.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n45
+		i += n49
 	}
 	if m.Activations != nil {
 		dAtA[i] = 0x72
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Activations.Size()))
-		n46, err := m.Activations.MarshalTo(dAtA[i:])
+		n50, err := m.Activations.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n46
+		i += n50
 	}
 	if m.ActivationsUnique != nil {
 		dAtA[i] = 0x7a
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.ActivationsUnique.Size()))
-		n47, err := m.ActivationsUnique.MarshalTo(dAtA[i:])
+		n51, err := m.ActivationsUnique.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n51
 	}
 	if m.Deduplication != nil {
 		dAtA[i] = 0x82
@@ -2049,11 +2383,11 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintBroker(dAtA, i, uint64(m.Deduplication.Size()))
-		n48, err := m.Deduplication.MarshalTo(dAtA[i:])
+		n52, err := m.Deduplication.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n48
+		i += n52
 	}
 	if m.ConnectedRouters != 0 {
 		dAtA[i] = 0xa8
@@ -2157,6 +2491,28 @@ func (m *DownlinkOption) Size() (n int) {
 	return n
 }
 
+func (m *Multicast) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.GroupId)
+	if l > 0 {
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	if len(m.GatewayIds) > 0 {
+		for _, s := range m.GatewayIds {
+			l = len(s)
+			n += 1 + l + sovBroker(uint64(l))
+		}
+	}
+	if len(m.DownlinkOptions) > 0 {
+		for _, e := range m.DownlinkOptions {
+			l = e.Size()
+			n += 1 + l + sovBroker(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *UplinkMessage) Size() (n int) {
 	var l int
 	_ = l
@@ -2236,6 +2592,55 @@ func (m *DownlinkMessage) Size() (n int) {
 		l = m.DownlinkOption.Size()
 		n += 2 + l + sovBroker(uint64(l))
 	}
+	if m.Multicast != nil {
+		l = m.Multicast.Size()
+		n += 2 + l + sovBroker(uint64(l))
+	}
+	if m.Retransmission {
+		n += 3
+	}
+	l = len(m.AppAdrAlgorithm)
+	if l > 0 {
+		n += 2 + l + sovBroker(uint64(l))
+	}
+	l = len(m.AppAdrDataRate)
+	if l > 0 {
+		n += 2 + l + sovBroker(uint64(l))
+	}
+	if m.Trace != nil {
+		l = m.Trace.Size()
+		n += 2 + l + sovBroker(uint64(l))
+	}
+	return n
+}
+
+func (m *TxAcknowledgment) Size() (n int) {
+	var l int
+	_ = l
+	if m.DevEui != nil {
+		l = m.DevEui.Size()
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	if m.AppEui != nil {
+		l = m.AppEui.Size()
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	l = len(m.GatewayId)
+	if l > 0 {
+		n += 2 + l + sovBroker(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 2 + l + sovBroker(uint64(l))
+	}
 	if m.Trace != nil {
 		l = m.Trace.Size()
 		n += 2 + l + sovBroker(uint64(l))
@@ -2754,6 +3159,145 @@ func (m *DownlinkOption) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Multicast) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBroker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Multicast: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Multicast: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayIds = append(m.GatewayIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DownlinkOptions = append(m.DownlinkOptions, &DownlinkOption{})
+			if err := m.DownlinkOptions[len(m.DownlinkOptions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBroker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBroker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *UplinkMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3368,6 +3912,380 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Multicast", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Multicast == nil {
+				m.Multicast = &Multicast{}
+			}
+			if err := m.Multicast.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retransmission", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Retransmission = bool(v != 0)
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppAdrAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppAdrAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppAdrDataRate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppAdrDataRate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBroker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBroker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxAcknowledgment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBroker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxAcknowledgment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxAcknowledgment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
+			m.DevEui = &v
+			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
+			m.AppEui = &v
+			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 31:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
@@ -5766,81 +6684,92 @@ func init() {
 }
 
 var fileDescriptorBroker = []byte{
-	// 1208 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4d, 0x8f, 0xdb, 0x44,
-	0x18, 0x96, 0xf7, 0x23, 0xdb, 0x7d, 0xb3, 0xd9, 0x24, 0xd3, 0xee, 0xae, 0x9b, 0xd2, 0x4d, 0x08,
-	0x52, 0x15, 0x28, 0x4d, 0xda, 0x20, 0xbe, 0x24, 0x44, 0x95, 0xed, 0x56, 0xb0, 0x48, 0x29, 0x95,
-	0x9b, 0x72, 0x40, 0x48, 0xd1, 0xc4, 0x7e, 0xeb, 0x8c, 0xea, 0xd8, 0xae, 0x67, 0x9c, 0x36, 0x7f,
-	0x80, 0x0b, 0x12, 0xbf, 0x01, 0xf8, 0x07, 0x1c, 0xb9, 0x70, 0x44, 0x1c, 0x39, 0x73, 0x00, 0xd4,
-	0x5f, 0x82, 0x3c, 0x9e, 0xb1, 0x93, 0xdd, 0xa6, 0xad, 0xaa, 0x8a, 0x0f, 0x75, 0x2f, 0x89, 0xe7,
-	0x79, 0x1f, 0x3f, 0x33, 0xf3, 0x7e, 0x79, 0x6c, 0x78, 0xdf, 0x65, 0x62, 0x1c, 0x8f, 0xda, 0x76,
-	0x30, 0xe9, 0x0c, 0xc6, 0x38, 0x18, 0x33, 0xdf, 0xe5, 0xb7, 0x50, 0x3c, 0x0c, 0xa2, 0xfb, 0x1d,
-	0x21, 0xfc, 0x0e, 0x0d, 0x59, 0x67, 0x14, 0x05, 0xf7, 0x31, 0x52, 0x7f, 0xed, 0x30, 0x0a, 0x44,
-	0x40, 0x0a, 0xe9, 0xa8, 0x76, 0xc1, 0x0d, 0x02, 0xd7, 0xc3, 0x8e, 0x44, 0x47, 0xf1, 0xbd, 0x0e,
-	0x4e, 0x42, 0x31, 0x4b, 0x49, 0xb5, 0x2b, 0x73, 0xea, 0x6e, 0xe0, 0x06, 0x39, 0x2b, 0x19, 0xc9,
-	0x81, 0xbc, 0x52, 0xf4, 0xaa, 0x9e, 0x90, 0x86, 0x4c, 0x41, 0x75, 0x0d, 0xc9, 0xa1, 0x1d, 0x78,
-	0xd9, 0x85, 0x22, 0x5c, 0xd4, 0x04, 0x97, 0x0a, 0x7c, 0x48, 0x67, 0xfa, 0x5f, 0x99, 0xcf, 0x6b,
-	0xb3, 0x88, 0xa8, 0x8d, 0xe9, 0x6f, 0x6a, 0x6a, 0x7e, 0xbd, 0x02, 0xdb, 0x87, 0xc1, 0x43, 0xdf,
-	0x63, 0xfe, 0xfd, 0xcf, 0x43, 0xc1, 0x02, 0x9f, 0xec, 0x03, 0x30, 0x07, 0x7d, 0xc1, 0xee, 0x31,
-	0x8c, 0x4c, 0xa3, 0x61, 0xb4, 0x36, 0xad, 0x39, 0x84, 0x5c, 0x04, 0x50, 0xf2, 0x43, 0xe6, 0x98,
-	0x2b, 0xd2, 0xbe, 0xa9, 0x90, 0x23, 0x87, 0x9c, 0x83, 0x75, 0x6e, 0x07, 0x11, 0x9a, 0xab, 0x0d,
-	0xa3, 0x55, 0xb2, 0xd2, 0x01, 0xa9, 0xc1, 0x19, 0x07, 0xa9, 0xe3, 0x31, 0x1f, 0xcd, 0xb5, 0x86,
-	0xd1, 0x5a, 0xb5, 0xb2, 0x31, 0x39, 0x80, 0xb2, 0xde, 0xcf, 0xd0, 0x0e, 0xfc, 0x7b, 0xcc, 0x35,
-	0xd7, 0x1b, 0x46, 0xab, 0xd8, 0x3d, 0xdf, 0xce, 0xf6, 0x39, 0x78, 0x74, 0x43, 0x5a, 0xe2, 0x88,
-	0x26, 0x8b, 0xb4, 0xb6, 0xb5, 0x25, 0x85, 0xc9, 0x75, 0xd8, 0xd6, 0x8b, 0x52, 0x12, 0x05, 0x29,
-	0x61, 0xb6, 0xb5, 0x2b, 0x8e, 0x2b, 0x94, 0x94, 0x21, 0x45, 0x9b, 0xdf, 0xae, 0x41, 0xe9, 0x6e,
-	0x98, 0xb8, 0xa1, 0x8f, 0x9c, 0x53, 0x17, 0x89, 0x09, 0x1b, 0x21, 0x9d, 0x79, 0x01, 0x75, 0xa4,
-	0x13, 0xb6, 0x2c, 0x3d, 0x24, 0x97, 0x61, 0x63, 0x92, 0x92, 0xe4, 0xf6, 0x8b, 0xdd, 0x6a, 0xbe,
-	0x50, 0x75, 0xb7, 0xa5, 0x19, 0xe4, 0x16, 0x6c, 0x38, 0x38, 0x1d, 0x62, 0xcc, 0xcc, 0x62, 0x22,
-	0x73, 0xf0, 0xee, 0xef, 0x7f, 0xd4, 0xaf, 0x3d, 0x2b, 0xe3, 0x12, 0xa7, 0x75, 0xc4, 0x2c, 0x44,
-	0xde, 0x3e, 0xc4, 0xe9, 0xcd, 0xbb, 0x47, 0x56, 0xc1, 0xc1, 0xe9, 0xcd, 0x98, 0x25, 0x7a, 0x34,
-	0x0c, 0xa5, 0xde, 0xd6, 0x0b, 0xe9, 0xf5, 0xc2, 0x50, 0xea, 0xd1, 0x30, 0x4c, 0xf4, 0x76, 0x20,
-	0xb9, 0x4a, 0x42, 0x59, 0x92, 0xa1, 0x5c, 0xa7, 0x61, 0x78, 0xe4, 0x24, 0x70, 0xb2, 0x6c, 0xe6,
-	0x98, 0xdb, 0x29, 0xec, 0xe0, 0xf4, 0xc8, 0x21, 0x3d, 0xa8, 0x66, 0xb1, 0x9a, 0xa0, 0xa0, 0x0e,
-	0x15, 0xd4, 0xdc, 0x91, 0x4e, 0x38, 0x97, 0x3b, 0xc1, 0x7a, 0xd4, 0x57, 0x36, 0xab, 0xa2, 0x41,
-	0x8d, 0x90, 0x8f, 0xa1, 0xa2, 0x43, 0x95, 0x29, 0xec, 0x4a, 0x85, 0xb3, 0x59, 0xb0, 0xe6, 0x04,
-	0xca, 0x0a, 0xcb, 0xee, 0xef, 0x41, 0xc5, 0x51, 0x19, 0x3b, 0x0c, 0x64, 0xca, 0x72, 0xb3, 0xde,
-	0x58, 0x6d, 0x15, 0xbb, 0xbb, 0x6d, 0x55, 0x9d, 0x8b, 0x19, 0x6d, 0x95, 0x9d, 0x85, 0x31, 0x27,
-	0x4d, 0x58, 0x97, 0x45, 0x60, 0xbe, 0x29, 0xe7, 0xdd, 0x6a, 0xa7, 0x25, 0x31, 0x48, 0x7e, 0xad,
-	0xd4, 0xd4, 0xfc, 0x66, 0x15, 0xca, 0x5a, 0xe7, 0x34, 0x25, 0x9e, 0x92, 0x12, 0xd7, 0xa1, 0x7c,
-	0x2c, 0x1e, 0x2a, 0x21, 0x96, 0x85, 0x63, 0x7b, 0x31, 0x1c, 0x79, 0x34, 0xea, 0xcb, 0xa3, 0xf1,
-	0x8b, 0x01, 0xe6, 0x21, 0x4e, 0x99, 0x8d, 0x3d, 0x5b, 0xb0, 0x69, 0x5a, 0xc2, 0xc8, 0xc3, 0xc0,
-	0xe7, 0x2f, 0x2d, 0x2c, 0x4f, 0xd8, 0x48, 0xf1, 0xc5, 0x36, 0xb2, 0xb3, 0x7c, 0x23, 0x3f, 0xaf,
-	0xc1, 0xf9, 0x43, 0x74, 0xe2, 0xd0, 0x63, 0x36, 0x15, 0xe8, 0x9c, 0xf6, 0x9c, 0x7f, 0xaf, 0xe7,
-	0xac, 0x3e, 0x77, 0xcf, 0xa9, 0x43, 0x91, 0x63, 0x34, 0xc5, 0x68, 0x28, 0xd8, 0x04, 0xcd, 0x3d,
-	0xf9, 0x04, 0x83, 0x14, 0x1a, 0xb0, 0x09, 0x92, 0x43, 0xa8, 0x46, 0x2a, 0x1d, 0x87, 0x02, 0x27,
-	0xa1, 0x47, 0x85, 0xce, 0xe7, 0xbd, 0xe3, 0xd9, 0xa3, 0xc3, 0x55, 0xd1, 0x77, 0x0c, 0xd4, 0x0d,
-	0xcf, 0xd5, 0x97, 0x7e, 0x5a, 0x83, 0xbd, 0x93, 0x95, 0xf0, 0x20, 0x46, 0x2e, 0x5e, 0x95, 0xf4,
-	0xf9, 0x0f, 0x3c, 0x84, 0xfa, 0x70, 0x96, 0x66, 0xee, 0xcf, 0x25, 0xf6, 0xa4, 0xc4, 0x6b, 0xf9,
-	0x22, 0xf2, 0x18, 0x65, 0x5a, 0x84, 0x9e, 0xc0, 0xfe, 0xa9, 0x67, 0xda, 0x77, 0xeb, 0xf0, 0xc6,
-	0x7c, 0xf3, 0x79, 0xc5, 0xf3, 0xe8, 0x7f, 0xd7, 0x86, 0x5e, 0x72, 0xd6, 0x1d, 0xeb, 0x6a, 0xe6,
-	0x89, 0xae, 0xd6, 0x5f, 0xde, 0xd5, 0x1a, 0x59, 0x5e, 0x2e, 0x79, 0x2a, 0xbf, 0x60, 0x7b, 0xfb,
-	0x71, 0x05, 0x6a, 0xb9, 0xd8, 0x8d, 0x31, 0xf5, 0x3c, 0xf4, 0x5d, 0x3c, 0xcd, 0xcc, 0xe5, 0x99,
-	0xd9, 0x74, 0xe0, 0xc2, 0x13, 0x5d, 0xf6, 0x52, 0x8f, 0x47, 0x4d, 0x02, 0x95, 0x3b, 0xf1, 0x88,
-	0xdb, 0x11, 0x1b, 0xe9, 0x70, 0x34, 0xcb, 0x50, 0xba, 0x23, 0xa8, 0x88, 0xb9, 0x06, 0xfe, 0x5c,
-	0x85, 0x42, 0x8a, 0x90, 0x16, 0x14, 0xf8, 0x8c, 0x0b, 0x9c, 0xc8, 0x59, 0x8b, 0xdd, 0x4a, 0x3b,
-	0x79, 0xa3, 0xbd, 0x23, 0xa1, 0x84, 0xc2, 0x2d, 0x65, 0x27, 0xd7, 0x60, 0xd3, 0x0e, 0x26, 0x61,
-	0xe0, 0xa3, 0x2f, 0xd4, 0x42, 0xce, 0x4a, 0xf2, 0x0d, 0x8d, 0xa6, 0xfc, 0x9c, 0x45, 0x9a, 0x50,
-	0x88, 0xe5, 0xc9, 0x49, 0x1d, 0xd1, 0x40, 0xf2, 0x2d, 0x2a, 0x90, 0x5b, 0xca, 0x42, 0x3a, 0x50,
-	0x4a, 0xaf, 0x86, 0xb1, 0xcf, 0x1e, 0xc4, 0x28, 0x43, 0xb3, 0x48, 0xdd, 0x4a, 0x09, 0x77, 0xa5,
-	0x9d, 0x5c, 0x82, 0x33, 0xba, 0xab, 0x4a, 0xbf, 0x2f, 0x72, 0x33, 0x1b, 0x79, 0x1b, 0x8a, 0x79,
-	0x35, 0x71, 0x19, 0x8b, 0x45, 0xea, 0xbc, 0x99, 0x7c, 0x08, 0x73, 0xb5, 0xc7, 0xf5, 0x5a, 0xca,
-	0x27, 0x6e, 0xaa, 0xce, 0xb1, 0xd4, 0x82, 0xde, 0x83, 0x92, 0x93, 0xb5, 0xeb, 0xe4, 0x3c, 0x5a,
-	0x99, 0xf3, 0xe4, 0x6d, 0x8c, 0xec, 0xe4, 0xa5, 0xdc, 0x43, 0x6e, 0x2d, 0xd2, 0xc8, 0x65, 0xa8,
-	0xda, 0x81, 0xef, 0xa3, 0x2d, 0xd0, 0x19, 0x46, 0x41, 0x2c, 0x30, 0xe2, 0xb2, 0x55, 0x95, 0xac,
-	0x4a, 0x66, 0xb0, 0x52, 0x9c, 0x5c, 0x01, 0x92, 0x93, 0xc7, 0xd4, 0x77, 0xbc, 0x84, 0xbd, 0x2b,
-	0xd9, 0xb9, 0xcc, 0xa7, 0xca, 0xd0, 0xfc, 0x02, 0xf6, 0x7b, 0x61, 0x36, 0x95, 0x82, 0x2d, 0x74,
-	0x19, 0x17, 0xe9, 0x9b, 0xf5, 0x5c, 0xf2, 0x1a, 0xf3, 0xc9, 0x7b, 0x11, 0x40, 0xa9, 0xcf, 0x7d,
-	0x37, 0x50, 0xc8, 0x91, 0xd3, 0xfd, 0x61, 0x05, 0x0a, 0x07, 0xb2, 0xa5, 0x90, 0xeb, 0xb0, 0xd9,
-	0xe3, 0x3c, 0xb0, 0x59, 0xd2, 0x34, 0x76, 0x74, 0xa3, 0x59, 0x38, 0x29, 0xd7, 0x96, 0x9d, 0xaa,
-	0x5a, 0xc6, 0x55, 0x83, 0x7c, 0x06, 0x9b, 0x59, 0xaa, 0x12, 0x53, 0x33, 0x8f, 0x67, 0x6f, 0xed,
-	0xf5, 0xbc, 0x87, 0x2d, 0x39, 0x90, 0x5f, 0x35, 0xc8, 0x47, 0xb0, 0x71, 0x3b, 0x1e, 0x79, 0x8c,
-	0x8f, 0xc9, 0xb2, 0x39, 0x6b, 0xbb, 0xed, 0xf4, 0x03, 0x50, 0x5b, 0x7f, 0xda, 0x69, 0xdf, 0x9c,
-	0x84, 0x62, 0xd6, 0x32, 0x48, 0x1f, 0xce, 0xa8, 0xd2, 0x44, 0x52, 0x5f, 0xde, 0x32, 0xd3, 0xf5,
-	0x3c, 0xb3, 0xa7, 0x76, 0xbf, 0x37, 0xa0, 0x94, 0x3a, 0xa9, 0x4f, 0x7d, 0xea, 0x62, 0x44, 0xbe,
-	0x82, 0x5a, 0xea, 0x7c, 0x8c, 0x4e, 0x86, 0x85, 0x5c, 0xd2, 0x8a, 0x4f, 0x0f, 0xd9, 0xb2, 0x0d,
-	0x90, 0x2e, 0x6c, 0x7e, 0x82, 0x42, 0x15, 0x74, 0x16, 0x89, 0x85, 0x92, 0xaf, 0x6d, 0x2f, 0xc2,
-	0x07, 0x1f, 0xfc, 0xfa, 0x78, 0xdf, 0xf8, 0xed, 0xf1, 0xbe, 0xf1, 0xd7, 0xe3, 0x7d, 0xe3, 0xcb,
-	0xb7, 0x9e, 0xff, 0xdb, 0xda, 0xa8, 0x20, 0x67, 0x7f, 0xe7, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x4b, 0x13, 0x77, 0x15, 0x90, 0x13, 0x00, 0x00,
+	// 1388 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x5d, 0x6f, 0xdb, 0x36,
+	0x17, 0x86, 0xe2, 0xc4, 0x89, 0x8f, 0xe3, 0x2f, 0xb6, 0x49, 0x14, 0xf7, 0x6d, 0xec, 0xd7, 0x2f,
+	0x50, 0xb8, 0xed, 0x5b, 0xbb, 0xf5, 0xb0, 0x8f, 0x02, 0xc3, 0x0a, 0xa7, 0x29, 0xb6, 0x0c, 0x70,
+	0x57, 0xa8, 0xee, 0x2e, 0x86, 0x01, 0x06, 0x2d, 0xb1, 0x32, 0x11, 0x59, 0x52, 0x45, 0xca, 0x69,
+	0xfe, 0xc0, 0x2e, 0x76, 0xb1, 0xdf, 0xb0, 0xfd, 0x84, 0x5d, 0x16, 0x03, 0x76, 0x39, 0xec, 0x72,
+	0xd7, 0x03, 0xf6, 0x81, 0xfe, 0x92, 0x41, 0x14, 0x29, 0xf9, 0x23, 0x6e, 0xd3, 0xa2, 0xd8, 0x57,
+	0x7b, 0x93, 0x88, 0xcf, 0x79, 0x78, 0x48, 0x9e, 0xf3, 0xf0, 0x90, 0x26, 0xbc, 0x6b, 0x53, 0x3e,
+	0x0a, 0x87, 0x2d, 0xd3, 0x1b, 0xb7, 0xfb, 0x23, 0xd2, 0x1f, 0x51, 0xd7, 0x66, 0x77, 0x09, 0x3f,
+	0xf6, 0x82, 0xa3, 0x36, 0xe7, 0x6e, 0x1b, 0xfb, 0xb4, 0x3d, 0x0c, 0xbc, 0x23, 0x12, 0xc8, 0x7f,
+	0x2d, 0x3f, 0xf0, 0xb8, 0x87, 0xb2, 0x71, 0xab, 0x7a, 0xc1, 0xf6, 0x3c, 0xdb, 0x21, 0x6d, 0x81,
+	0x0e, 0xc3, 0x87, 0x6d, 0x32, 0xf6, 0xf9, 0x49, 0x4c, 0xaa, 0x5e, 0x9b, 0xf2, 0x6e, 0x7b, 0xb6,
+	0x97, 0xb2, 0xa2, 0x96, 0x68, 0x88, 0x2f, 0x49, 0xaf, 0xa8, 0x01, 0xb1, 0x4f, 0x25, 0x54, 0x53,
+	0x90, 0x68, 0x9a, 0x9e, 0x93, 0x7c, 0x48, 0xc2, 0x45, 0x45, 0xb0, 0x31, 0x27, 0xc7, 0xf8, 0x44,
+	0xfd, 0x97, 0xe6, 0x5d, 0x65, 0xe6, 0x01, 0x36, 0x49, 0xfc, 0x37, 0x36, 0x35, 0xbe, 0x58, 0x81,
+	0xe2, 0x81, 0x77, 0xec, 0x3a, 0xd4, 0x3d, 0xfa, 0xc4, 0xe7, 0xd4, 0x73, 0xd1, 0x1e, 0x00, 0xb5,
+	0x88, 0xcb, 0xe9, 0x43, 0x4a, 0x02, 0x5d, 0xab, 0x6b, 0xcd, 0x9c, 0x31, 0x85, 0xa0, 0x8b, 0x00,
+	0xd2, 0xfd, 0x80, 0x5a, 0xfa, 0x8a, 0xb0, 0xe7, 0x24, 0x72, 0x68, 0xa1, 0xf3, 0xb0, 0xc6, 0x4c,
+	0x2f, 0x20, 0x7a, 0xa6, 0xae, 0x35, 0x0b, 0x46, 0xdc, 0x40, 0x55, 0xd8, 0xb0, 0x08, 0xb6, 0x1c,
+	0xea, 0x12, 0x7d, 0xb5, 0xae, 0x35, 0x33, 0x46, 0xd2, 0x46, 0xfb, 0x50, 0x52, 0xeb, 0x19, 0x98,
+	0x9e, 0xfb, 0x90, 0xda, 0xfa, 0x5a, 0x5d, 0x6b, 0xe6, 0x3b, 0xbb, 0xad, 0x64, 0x9d, 0xfd, 0xc7,
+	0xb7, 0x85, 0x25, 0x0c, 0x70, 0x34, 0x49, 0xa3, 0xa8, 0x2c, 0x31, 0x8c, 0x6e, 0x41, 0x51, 0x4d,
+	0x4a, 0xba, 0xc8, 0x0a, 0x17, 0x7a, 0x4b, 0x85, 0x62, 0xde, 0x43, 0x41, 0x1a, 0x62, 0xb4, 0xf1,
+	0xa5, 0x06, 0xb9, 0x5e, 0xe8, 0x70, 0x6a, 0x62, 0xc6, 0xd1, 0x2e, 0x6c, 0xd8, 0x81, 0x17, 0xfa,
+	0xd1, 0x0a, 0xe3, 0x08, 0xac, 0x8b, 0xf6, 0xa1, 0x85, 0x6a, 0x90, 0x4f, 0x97, 0xcf, 0xf4, 0x95,
+	0x7a, 0x26, 0x8a, 0x4f, 0xb2, 0x7e, 0x86, 0xba, 0x50, 0xb6, 0x64, 0x44, 0x07, 0x9e, 0x08, 0x29,
+	0xd3, 0x33, 0xf5, 0x4c, 0x33, 0xdf, 0xd9, 0x6e, 0x49, 0xf5, 0xcc, 0x46, 0xdc, 0x28, 0x59, 0x33,
+	0x6d, 0xd6, 0xf8, 0x6a, 0x15, 0x0a, 0x0f, 0xfc, 0x08, 0xe9, 0x11, 0xc6, 0xb0, 0x4d, 0x90, 0x0e,
+	0xeb, 0x3e, 0x3e, 0x71, 0x3c, 0x1c, 0xcf, 0x67, 0xd3, 0x50, 0x4d, 0x74, 0x15, 0xd6, 0xc7, 0x31,
+	0x49, 0xe4, 0x22, 0xdf, 0xa9, 0xa4, 0x51, 0x93, 0xbd, 0x0d, 0xc5, 0x40, 0x77, 0x61, 0xdd, 0x22,
+	0x93, 0x01, 0x09, 0xa9, 0x9e, 0x8f, 0xdc, 0xec, 0xbf, 0xfd, 0xf3, 0xaf, 0xb5, 0x1b, 0xcf, 0x93,
+	0x7f, 0x94, 0xc1, 0x36, 0x3f, 0xf1, 0x09, 0x6b, 0x1d, 0x90, 0xc9, 0x9d, 0x07, 0x87, 0x46, 0xd6,
+	0x22, 0x93, 0x3b, 0x21, 0x8d, 0xfc, 0x61, 0xdf, 0x17, 0xfe, 0x36, 0x5f, 0xca, 0x5f, 0xd7, 0xf7,
+	0x85, 0x3f, 0xec, 0xfb, 0x91, 0xbf, 0x2d, 0x88, 0xbe, 0xa2, 0xa8, 0x17, 0x44, 0xd4, 0xd7, 0xb0,
+	0x1f, 0xc5, 0x7c, 0x0b, 0xa2, 0x01, 0x23, 0xb8, 0x18, 0xc3, 0x16, 0x99, 0x1c, 0x5a, 0xa8, 0x0b,
+	0x95, 0x44, 0x38, 0x63, 0xc2, 0xb1, 0x85, 0x39, 0xd6, 0xb7, 0x44, 0x10, 0xce, 0xa7, 0x41, 0x30,
+	0x1e, 0xf7, 0xa4, 0xcd, 0x28, 0x2b, 0x50, 0x21, 0xe8, 0x03, 0x28, 0xab, 0x6c, 0x26, 0x1e, 0xb6,
+	0x85, 0x87, 0x73, 0x89, 0x72, 0xa6, 0x1c, 0x94, 0x24, 0x96, 0xf4, 0x3f, 0x2d, 0xd9, 0xb5, 0x17,
+	0x4a, 0x36, 0x6a, 0xc0, 0x9a, 0xd8, 0x91, 0xfa, 0x65, 0x31, 0xee, 0x66, 0x4b, 0xb4, 0x5a, 0xfd,
+	0xe8, 0xaf, 0x11, 0x9b, 0x1a, 0xdf, 0xad, 0x42, 0x49, 0xf9, 0x79, 0x23, 0x89, 0x67, 0x48, 0xe2,
+	0x16, 0x94, 0xe6, 0xf2, 0x21, 0x05, 0xb1, 0x2c, 0x1d, 0xc5, 0xd9, 0x74, 0xa0, 0x36, 0xe4, 0xc6,
+	0xaa, 0x0c, 0x48, 0x25, 0x54, 0x54, 0xd7, 0xa4, 0x3e, 0x18, 0x29, 0x07, 0x5d, 0x82, 0x62, 0x40,
+	0x78, 0x80, 0x5d, 0x36, 0xa6, 0x8c, 0x45, 0x03, 0xee, 0xd4, 0xb5, 0xe6, 0x86, 0x31, 0x87, 0xa2,
+	0x2b, 0x50, 0x89, 0xd6, 0x81, 0xad, 0x60, 0x80, 0x1d, 0xdb, 0x0b, 0x28, 0x1f, 0x8d, 0x75, 0x5d,
+	0xcc, 0xbd, 0x84, 0x7d, 0xbf, 0x6b, 0x05, 0x5d, 0x05, 0xa3, 0xcb, 0x29, 0x37, 0x52, 0xd9, 0x20,
+	0xc0, 0x9c, 0xe8, 0xbb, 0x82, 0x5b, 0x8c, 0xb9, 0x07, 0x91, 0x1a, 0x31, 0x27, 0xa9, 0x7a, 0x6a,
+	0xcb, 0xd5, 0xf3, 0x64, 0x05, 0xca, 0xfd, 0xc7, 0x5d, 0xf3, 0xc8, 0xf5, 0x8e, 0x1d, 0x62, 0xd9,
+	0x63, 0xe2, 0xf2, 0x7f, 0x59, 0xde, 0x67, 0x0f, 0xa5, 0xad, 0x53, 0x0e, 0x25, 0x12, 0x04, 0x5e,
+	0x20, 0x32, 0x9a, 0x33, 0xe2, 0xc6, 0x99, 0x62, 0xf7, 0x83, 0x06, 0xfa, 0x01, 0x99, 0x50, 0x93,
+	0x74, 0x4d, 0x4e, 0x27, 0xf1, 0xd9, 0x41, 0x98, 0xef, 0xb9, 0xec, 0x95, 0x6d, 0xc1, 0x53, 0x44,
+	0x9b, 0x7f, 0x21, 0xd1, 0x26, 0x0b, 0xd9, 0x5a, 0xbe, 0x90, 0xef, 0x57, 0x61, 0xf7, 0x80, 0x58,
+	0xa1, 0xef, 0x50, 0x13, 0x73, 0x62, 0xbd, 0x39, 0x5f, 0xfe, 0xba, 0xf3, 0x25, 0x73, 0xe6, 0xf3,
+	0xa5, 0x06, 0x79, 0x46, 0x82, 0x09, 0x09, 0x06, 0x9c, 0x8e, 0x89, 0x28, 0x2d, 0x19, 0x03, 0x62,
+	0xa8, 0x4f, 0xc7, 0x04, 0x1d, 0x40, 0x25, 0x90, 0x72, 0x1c, 0x70, 0x32, 0xf6, 0x1d, 0xcc, 0x95,
+	0x9e, 0x77, 0xe6, 0xd5, 0xa3, 0xd2, 0x55, 0x56, 0x3d, 0xfa, 0xb2, 0xc3, 0x99, 0xce, 0xa0, 0x27,
+	0xab, 0xb0, 0xb3, 0xb8, 0x13, 0x1e, 0x85, 0x84, 0xf1, 0xd7, 0x45, 0x3e, 0x7f, 0x83, 0x0b, 0x47,
+	0x0f, 0xce, 0xe1, 0x24, 0xfc, 0xa9, 0x8b, 0x1d, 0xe1, 0xe2, 0x3f, 0xe9, 0x24, 0xd2, 0x1c, 0x25,
+	0xbe, 0x10, 0x5e, 0xc0, 0xfe, 0xac, 0xfb, 0xcb, 0xd7, 0x6b, 0xf0, 0xbf, 0xe9, 0xe2, 0xf3, 0x9a,
+	0xeb, 0xe8, 0x1f, 0x57, 0x86, 0x5e, 0xb1, 0xea, 0xe6, 0xaa, 0x9a, 0xbe, 0x50, 0xd5, 0x7a, 0xcb,
+	0xab, 0x5a, 0x3d, 0xd1, 0xe5, 0x92, 0x53, 0xf9, 0x25, 0xcb, 0xdb, 0xb7, 0x2b, 0x50, 0x4d, 0x9d,
+	0xdd, 0x1e, 0x61, 0xc7, 0x21, 0xae, 0x4d, 0xde, 0x28, 0x73, 0xb9, 0x32, 0x1b, 0x16, 0x5c, 0x38,
+	0x35, 0x64, 0xaf, 0xf4, 0x7a, 0xd4, 0x40, 0x50, 0xbe, 0x1f, 0x0e, 0x99, 0x19, 0xd0, 0xa1, 0x4a,
+	0x47, 0xa3, 0x04, 0x85, 0xfb, 0x1c, 0xf3, 0x90, 0x29, 0xe0, 0xb7, 0x0c, 0x64, 0x63, 0x04, 0x35,
+	0x21, 0xcb, 0x4e, 0x18, 0x27, 0x63, 0x31, 0x6a, 0xbe, 0x53, 0x6e, 0x45, 0x4f, 0x29, 0xf7, 0x05,
+	0x14, 0x51, 0x98, 0x21, 0xed, 0xe8, 0x06, 0xe4, 0x4c, 0x6f, 0xec, 0x7b, 0x2e, 0x71, 0xb9, 0x9c,
+	0xc8, 0x39, 0x41, 0xbe, 0xad, 0xd0, 0x98, 0x9f, 0xb2, 0x50, 0x03, 0xb2, 0xa1, 0xb8, 0x39, 0xc9,
+	0x2b, 0x1a, 0x08, 0xbe, 0x81, 0x39, 0x61, 0x86, 0xb4, 0xa0, 0x36, 0x14, 0xe2, 0xaf, 0x41, 0xe8,
+	0xd2, 0x47, 0x21, 0xd1, 0x37, 0x17, 0xa8, 0x9b, 0x31, 0xe1, 0x81, 0xb0, 0xa3, 0x4b, 0xb0, 0xa1,
+	0xaa, 0xaa, 0x5e, 0x58, 0xe0, 0x26, 0x36, 0xf4, 0x7f, 0xc8, 0xa7, 0xbb, 0x89, 0xe9, 0xc5, 0x05,
+	0xea, 0xb4, 0x19, 0xdd, 0x84, 0xa9, 0xbd, 0xc7, 0xd4, 0x5c, 0x4a, 0x0b, 0x9d, 0x2a, 0x53, 0x2c,
+	0x39, 0xa1, 0x77, 0xa0, 0x60, 0x25, 0xe5, 0x3a, 0xba, 0x8f, 0x96, 0xa7, 0x22, 0x79, 0x8f, 0x04,
+	0x26, 0x71, 0x39, 0x75, 0x08, 0x33, 0x66, 0x69, 0xe8, 0x2a, 0x54, 0x4c, 0xcf, 0x75, 0x89, 0xc9,
+	0x89, 0x35, 0x08, 0xbc, 0x90, 0x93, 0x80, 0x89, 0x52, 0x55, 0x30, 0xca, 0x89, 0xc1, 0x88, 0x71,
+	0x74, 0x0d, 0x50, 0x4a, 0x1e, 0x61, 0xd7, 0x72, 0x22, 0xf6, 0xb6, 0x60, 0xa7, 0x6e, 0x3e, 0x92,
+	0x86, 0xc6, 0xa7, 0xb0, 0xd7, 0xf5, 0x93, 0xa1, 0x24, 0x6c, 0x10, 0x9b, 0x32, 0x1e, 0x3f, 0xe9,
+	0x4c, 0x89, 0x57, 0x9b, 0x16, 0xef, 0x45, 0x00, 0xe9, 0x7d, 0xea, 0xc1, 0x4a, 0x22, 0x87, 0x56,
+	0xe7, 0x97, 0x15, 0xc8, 0xee, 0x8b, 0x92, 0x82, 0x6e, 0x41, 0xae, 0xcb, 0x98, 0x67, 0xd2, 0xa8,
+	0x68, 0x6c, 0xa9, 0x42, 0x33, 0x73, 0x53, 0xae, 0x2e, 0xbb, 0x55, 0x35, 0xb5, 0xeb, 0x1a, 0xfa,
+	0x18, 0x72, 0x89, 0x54, 0x91, 0xae, 0x98, 0xf3, 0xea, 0xad, 0xfe, 0x37, 0xf1, 0xb1, 0xec, 0x42,
+	0x7e, 0x5d, 0x43, 0xef, 0xc3, 0xfa, 0xbd, 0x70, 0xe8, 0x50, 0x36, 0x42, 0xcb, 0xc6, 0xac, 0x6e,
+	0xb7, 0xe2, 0x97, 0xc7, 0x96, 0x7a, 0x53, 0x6c, 0xdd, 0x89, 0x5e, 0x1e, 0x9b, 0x1a, 0xea, 0xc1,
+	0x86, 0xdc, 0x9a, 0x04, 0xd5, 0x96, 0x97, 0xcc, 0x78, 0x3e, 0xcf, 0xad, 0xa9, 0xe8, 0x26, 0xac,
+	0x89, 0x5f, 0x90, 0xe9, 0xa2, 0xe6, 0x7f, 0x50, 0x2e, 0x9b, 0x4b, 0xe7, 0x1b, 0x0d, 0x0a, 0x71,
+	0x7c, 0x7b, 0xd8, 0xc5, 0x36, 0x09, 0xd0, 0xe7, 0x50, 0x8d, 0xf3, 0x46, 0x82, 0xc5, 0x8c, 0xa2,
+	0x4b, 0x6a, 0x84, 0x67, 0x67, 0x7b, 0xd9, 0x78, 0xa8, 0x03, 0xb9, 0x0f, 0x09, 0x97, 0xb5, 0x20,
+	0x49, 0xe2, 0x4c, 0xb5, 0xa8, 0x16, 0x67, 0xe1, 0xfd, 0xf7, 0x7e, 0x7c, 0xba, 0xa7, 0xfd, 0xf4,
+	0x74, 0x4f, 0xfb, 0xfd, 0xe9, 0x9e, 0xf6, 0xd9, 0x95, 0xb3, 0xbf, 0x07, 0x0f, 0xb3, 0x62, 0xf4,
+	0xb7, 0xfe, 0x18, 0x00, 0x9c, 0x13, 0x2c, 0x7c, 0x44, 0x16, 0x00, 0x00,
 }
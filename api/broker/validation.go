@@ -25,6 +25,17 @@ func (m *DownlinkOption) Validate() error {
 	return nil
 }
 
+// Validate implements the api.Validator interface
+func (m *Multicast) Validate() error {
+	if err := api.NotEmptyAndValidID(m.GroupId, "GroupId"); err != nil {
+		return err
+	}
+	if len(m.GatewayIds) == 0 {
+		return errors.NewErrInvalidArgument("GatewayIds", "can not be empty")
+	}
+	return nil
+}
+
 // Validate implements the api.Validator interface
 func (m *UplinkMessage) Validate() error {
 	if err := api.NotNilAndValid(m.ProtocolMetadata, "ProtocolMetadata"); err != nil {
@@ -43,6 +54,10 @@ func (m *UplinkMessage) Validate() error {
 
 // Validate implements the api.Validator interface
 func (m *DownlinkMessage) Validate() error {
+	if m.Multicast != nil {
+		return m.validateMulticast()
+	}
+
 	if err := api.NotEmptyAndValidID(m.DevId, "DevId"); err != nil {
 		return err
 	}
@@ -50,9 +65,37 @@ func (m *DownlinkMessage) Validate() error {
 		return err
 	}
 
-	if err := api.NotNilAndValid(m.DownlinkOption, "DownlinkOption"); err != nil {
+	// The DownlinkOption of a downlink message that is not a response to an uplink message (Class B/C)
+	// is selected by the NetworkServer
+	if m.DownlinkOption != nil {
+		if err := m.DownlinkOption.Validate(); err != nil {
+			return errors.Wrap(err, "Invalid DownlinkOption")
+		}
+	}
+	if m.Message != nil {
+		if err := m.Message.Validate(); err != nil {
+			return errors.NewErrInvalidArgument("Message", err.Error())
+		}
+	}
+	return nil
+}
+
+// validateMulticast validates a downlink message to a multicast group, its DownlinkOption is completed by the Router
+func (m *DownlinkMessage) validateMulticast() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
 		return err
 	}
+	if err := m.Multicast.Validate(); err != nil {
+		return errors.NewErrInvalidArgument("Multicast", err.Error())
+	}
+	if m.DownlinkOption != nil {
+		if m.DownlinkOption.Identifier == "" {
+			return errors.NewErrInvalidArgument("DownlinkOption Identifier", "can not be empty")
+		}
+		if m.DownlinkOption.GatewayId == "" {
+			return errors.NewErrInvalidArgument("DownlinkOption GatewayId", "can not be empty")
+		}
+	}
 	if m.Message != nil {
 		if err := m.Message.Validate(); err != nil {
 			return errors.NewErrInvalidArgument("Message", err.Error())
@@ -61,6 +104,23 @@ func (m *DownlinkMessage) Validate() error {
 	return nil
 }
 
+// Validate implements the api.Validator interface
+func (m *TxAcknowledgment) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	// The TxAcknowledgment of a multicast downlink message has no DevId
+	if m.DevId != "" {
+		if err := api.NotEmptyAndValidID(m.DevId, "DevId"); err != nil {
+			return err
+		}
+	}
+	if m.GatewayId == "" {
+		return errors.NewErrInvalidArgument("GatewayId", "can not be empty")
+	}
+	return nil
+}
+
 // Validate implements the api.Validator interface
 func (m *DeduplicatedUplinkMessage) Validate() error {
 	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/api/gateway"
 	"github.com/TheThingsNetwork/ttn/api/protocol"
 	"github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/core/types"
 )
 
@@ -291,6 +292,18 @@ func fillMAC(m *lorawan.MACPayload, f log.Fields) {
 	f["MAC"] = strings.Join(mac, ",")
 }
 
+type hasTrace interface {
+	GetTrace() *trace.Trace
+}
+
+func fillTrace(m interface{}, f log.Fields) {
+	if m, ok := m.(hasTrace); ok {
+		if v := m.GetTrace().GetCorrelationID(); v != "" {
+			f["CorrelationID"] = v
+		}
+	}
+}
+
 // Get a number of log fields for a message, if we're able to extract them
 func Get(m interface{}) log.Fields {
 	fields := log.Fields{}
@@ -299,5 +312,6 @@ func Get(m interface{}) log.Fields {
 	fillGateway(m, fields)
 	fillProtocol(m, fields)
 	fillMessage(m, fields)
+	fillTrace(m, fields)
 	return fields
 }
@@ -12,11 +12,21 @@
 		DeviceActivationResponse
 		StatusRequest
 		Status
+		IntegrationStatus
 		ApplicationIdentifier
 		Application
+		HTTPIntegration
 		DeviceIdentifier
 		Device
+		PayloadFunctions
 		DeviceList
+		QueuedDownlink
+		DownlinkQueue
+		QueuedDownlinkIdentifier
+		MulticastGroupIdentifier
+		MulticastGroup
+		MulticastGroupList
+		MulticastDownlinkMessage
 		DryDownlinkMessage
 		DryUplinkMessage
 		SimulatedUplinkMessage
@@ -31,12 +41,15 @@ import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/empty"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
+import _ "github.com/gogo/protobuf/gogoproto"
 import api "github.com/TheThingsNetwork/ttn/api"
 import broker "github.com/TheThingsNetwork/ttn/api/broker"
 import protocol "github.com/TheThingsNetwork/ttn/api/protocol"
 import lorawan1 "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 import trace "github.com/TheThingsNetwork/ttn/api/trace"
 
+import github_com_TheThingsNetwork_ttn_core_types "github.com/TheThingsNetwork/ttn/core/types"
+
 import (
 	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
@@ -114,11 +127,12 @@ func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorHandle
 
 // message Status is the response to the StatusRequest
 type Status struct {
-	System      *api.SystemStats    `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
-	Component   *api.ComponentStats `protobuf:"bytes,2,opt,name=component" json:"component,omitempty"`
-	Uplink      *api.Rates          `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
-	Downlink    *api.Rates          `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
-	Activations *api.Rates          `protobuf:"bytes,13,opt,name=activations" json:"activations,omitempty"`
+	System       *api.SystemStats     `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
+	Component    *api.ComponentStats  `protobuf:"bytes,2,opt,name=component" json:"component,omitempty"`
+	Uplink       *api.Rates           `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
+	Downlink     *api.Rates           `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
+	Activations  *api.Rates           `protobuf:"bytes,13,opt,name=activations" json:"activations,omitempty"`
+	Integrations []*IntegrationStatus `protobuf:"bytes,21,rep,name=integrations" json:"integrations,omitempty"`
 }
 
 func (m *Status) Reset()                    { *m = Status{} }
@@ -161,6 +175,62 @@ func (m *Status) GetActivations() *api.Rates {
 	return nil
 }
 
+func (m *Status) GetIntegrations() []*IntegrationStatus {
+	if m != nil {
+		return m.Integrations
+	}
+	return nil
+}
+
+// message IntegrationStatus is the status of an integration of this Handler
+type IntegrationStatus struct {
+	Name        string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Uplink      *api.Rates `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
+	Activations *api.Rates `protobuf:"bytes,12,opt,name=activations" json:"activations,omitempty"`
+	Events      *api.Rates `protobuf:"bytes,13,opt,name=events" json:"events,omitempty"`
+	Errors      *api.Rates `protobuf:"bytes,14,opt,name=errors" json:"errors,omitempty"`
+}
+
+func (m *IntegrationStatus) Reset()                    { *m = IntegrationStatus{} }
+func (m *IntegrationStatus) String() string            { return proto.CompactTextString(m) }
+func (*IntegrationStatus) ProtoMessage()               {}
+func (*IntegrationStatus) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{3} }
+
+func (m *IntegrationStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *IntegrationStatus) GetUplink() *api.Rates {
+	if m != nil {
+		return m.Uplink
+	}
+	return nil
+}
+
+func (m *IntegrationStatus) GetActivations() *api.Rates {
+	if m != nil {
+		return m.Activations
+	}
+	return nil
+}
+
+func (m *IntegrationStatus) GetEvents() *api.Rates {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *IntegrationStatus) GetErrors() *api.Rates {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 type ApplicationIdentifier struct {
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 }
@@ -168,7 +238,7 @@ type ApplicationIdentifier struct {
 func (m *ApplicationIdentifier) Reset()                    { *m = ApplicationIdentifier{} }
 func (m *ApplicationIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*ApplicationIdentifier) ProtoMessage()               {}
-func (*ApplicationIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{3} }
+func (*ApplicationIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{4} }
 
 func (m *ApplicationIdentifier) GetAppId() string {
 	if m != nil {
@@ -192,12 +262,36 @@ type Application struct {
 	Validator string `protobuf:"bytes,4,opt,name=validator,proto3" json:"validator,omitempty"`
 	// The encoder is a JavaScript function that encodes an object to a byte array.
 	Encoder string `protobuf:"bytes,5,opt,name=encoder,proto3" json:"encoder,omitempty"`
+	// The HTTP integration posts messages and events to the application. The
+	// integration is not changed if this field is not set, and it is disabled
+	// if this field is set without URLs.
+	HttpIntegration *HTTPIntegration `protobuf:"bytes,6,opt,name=http_integration,json=httpIntegration" json:"http_integration,omitempty"`
+	// The names of the integrations (for example mqtt, amqp or http) that are
+	// disabled for this application. All other integrations are enabled.
+	DisabledIntegrations []string `protobuf:"bytes,7,rep,name=disabled_integrations,json=disabledIntegrations" json:"disabled_integrations,omitempty"`
+	// The payload format of the application. If it is empty or "custom", the
+	// payload functions are used. If it is "cayennelpp", payload is encoded and
+	// decoded in the Cayenne Low Power Payload format and the payload functions
+	// are ignored.
+	PayloadFormat string `protobuf:"bytes,8,opt,name=payload_format,json=payloadFormat,proto3" json:"payload_format,omitempty"`
+	// If true, the decoder is called with the uplink message (without payload)
+	// as third argument. This gives access to the metadata of the message.
+	ExposeMetadata bool `protobuf:"varint,9,opt,name=expose_metadata,json=exposeMetadata,proto3" json:"expose_metadata,omitempty"`
+	// The ADR algorithm (default, conservative or fixed) that the network uses
+	// for devices of the application that do not have an ADR algorithm. The
+	// algorithm is not changed if this is empty.
+	AdrAlgorithm string `protobuf:"bytes,10,opt,name=adr_algorithm,json=adrAlgorithm,proto3" json:"adr_algorithm,omitempty"`
+	// The data rate (for example SF9BW125) that devices of the application that
+	// use the fixed ADR algorithm are told to use, if they do not have one. The
+	// data rate is not changed if this is empty, unless the algorithm is changed
+	// to one that is not fixed.
+	AdrDataRate string `protobuf:"bytes,11,opt,name=adr_data_rate,json=adrDataRate,proto3" json:"adr_data_rate,omitempty"`
 }
 
 func (m *Application) Reset()                    { *m = Application{} }
 func (m *Application) String() string            { return proto.CompactTextString(m) }
 func (*Application) ProtoMessage()               {}
-func (*Application) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{4} }
+func (*Application) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{5} }
 
 func (m *Application) GetAppId() string {
 	if m != nil {
@@ -234,6 +328,95 @@ func (m *Application) GetEncoder() string {
 	return ""
 }
 
+func (m *Application) GetHttpIntegration() *HTTPIntegration {
+	if m != nil {
+		return m.HttpIntegration
+	}
+	return nil
+}
+
+func (m *Application) GetDisabledIntegrations() []string {
+	if m != nil {
+		return m.DisabledIntegrations
+	}
+	return nil
+}
+
+func (m *Application) GetPayloadFormat() string {
+	if m != nil {
+		return m.PayloadFormat
+	}
+	return ""
+}
+
+func (m *Application) GetExposeMetadata() bool {
+	if m != nil {
+		return m.ExposeMetadata
+	}
+	return false
+}
+
+func (m *Application) GetAdrAlgorithm() string {
+	if m != nil {
+		return m.AdrAlgorithm
+	}
+	return ""
+}
+
+func (m *Application) GetAdrDataRate() string {
+	if m != nil {
+		return m.AdrDataRate
+	}
+	return ""
+}
+
+// The HTTP integration settings of an application
+type HTTPIntegration struct {
+	// Uplink messages are posted to this URL. The posted messages contain a
+	// downlink_url that can be used to schedule downlink for the device.
+	UplinkUrl string `protobuf:"bytes,1,opt,name=uplink_url,json=uplinkUrl,proto3" json:"uplink_url,omitempty"`
+	// Activations are posted to this URL.
+	ActivationUrl string `protobuf:"bytes,2,opt,name=activation_url,json=activationUrl,proto3" json:"activation_url,omitempty"`
+	// Downlink events (down/scheduled, down/sent, down/acks, down/nack,
+	// down/expired, down/errors) are posted to this URL.
+	EventUrl string `protobuf:"bytes,3,opt,name=event_url,json=eventUrl,proto3" json:"event_url,omitempty"`
+	// Headers that are added to every request, for example for authentication.
+	Headers map[string]string `protobuf:"bytes,4,rep,name=headers" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *HTTPIntegration) Reset()                    { *m = HTTPIntegration{} }
+func (m *HTTPIntegration) String() string            { return proto.CompactTextString(m) }
+func (*HTTPIntegration) ProtoMessage()               {}
+func (*HTTPIntegration) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{6} }
+
+func (m *HTTPIntegration) GetUplinkUrl() string {
+	if m != nil {
+		return m.UplinkUrl
+	}
+	return ""
+}
+
+func (m *HTTPIntegration) GetActivationUrl() string {
+	if m != nil {
+		return m.ActivationUrl
+	}
+	return ""
+}
+
+func (m *HTTPIntegration) GetEventUrl() string {
+	if m != nil {
+		return m.EventUrl
+	}
+	return ""
+}
+
+func (m *HTTPIntegration) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 type DeviceIdentifier struct {
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	DevId string `protobuf:"bytes,2,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
@@ -242,7 +425,7 @@ type DeviceIdentifier struct {
 func (m *DeviceIdentifier) Reset()                    { *m = DeviceIdentifier{} }
 func (m *DeviceIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*DeviceIdentifier) ProtoMessage()               {}
-func (*DeviceIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{5} }
+func (*DeviceIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{7} }
 
 func (m *DeviceIdentifier) GetAppId() string {
 	if m != nil {
@@ -271,12 +454,19 @@ type Device struct {
 	Longitude   float32         `protobuf:"fixed32,11,opt,name=longitude,proto3" json:"longitude,omitempty"`
 	Altitude    int32           `protobuf:"varint,12,opt,name=altitude,proto3" json:"altitude,omitempty"`
 	Description string          `protobuf:"bytes,20,opt,name=description,proto3" json:"description,omitempty"`
+	// Payload functions of the device. If the device has a decoder, the decoder,
+	// converter and validator of the device are used instead of the payload
+	// format and functions of the application. If the device has an encoder,
+	// it is used instead of the payload format and encoder of the application.
+	// The functions are not changed if this field is not set, and they are
+	// removed if this field is set without functions.
+	PayloadFunctions *PayloadFunctions `protobuf:"bytes,30,opt,name=payload_functions,json=payloadFunctions" json:"payload_functions,omitempty"`
 }
 
 func (m *Device) Reset()                    { *m = Device{} }
 func (m *Device) String() string            { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()               {}
-func (*Device) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{6} }
+func (*Device) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{8} }
 
 type isDevice_Device interface {
 	isDevice_Device()
@@ -346,6 +536,13 @@ func (m *Device) GetDescription() string {
 	return ""
 }
 
+func (m *Device) GetPayloadFunctions() *PayloadFunctions {
+	if m != nil {
+		return m.PayloadFunctions
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Device) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Device_OneofMarshaler, _Device_OneofUnmarshaler, _Device_OneofSizer, []interface{}{
@@ -401,6 +598,53 @@ func _Device_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// The payload functions of a device
+type PayloadFunctions struct {
+	// The decoder is a JavaScript function that decodes a byte array to an object.
+	Decoder string `protobuf:"bytes,1,opt,name=decoder,proto3" json:"decoder,omitempty"`
+	// The converter is a JavaScript function that can be used to convert values
+	// in the object returned from the decoder.
+	Converter string `protobuf:"bytes,2,opt,name=converter,proto3" json:"converter,omitempty"`
+	// The validator is a JavaScript function that checks the validity of the
+	// object returned by the decoder or converter.
+	Validator string `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
+	// The encoder is a JavaScript function that encodes an object to a byte array.
+	Encoder string `protobuf:"bytes,4,opt,name=encoder,proto3" json:"encoder,omitempty"`
+}
+
+func (m *PayloadFunctions) Reset()                    { *m = PayloadFunctions{} }
+func (m *PayloadFunctions) String() string            { return proto.CompactTextString(m) }
+func (*PayloadFunctions) ProtoMessage()               {}
+func (*PayloadFunctions) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{9} }
+
+func (m *PayloadFunctions) GetDecoder() string {
+	if m != nil {
+		return m.Decoder
+	}
+	return ""
+}
+
+func (m *PayloadFunctions) GetConverter() string {
+	if m != nil {
+		return m.Converter
+	}
+	return ""
+}
+
+func (m *PayloadFunctions) GetValidator() string {
+	if m != nil {
+		return m.Validator
+	}
+	return ""
+}
+
+func (m *PayloadFunctions) GetEncoder() string {
+	if m != nil {
+		return m.Encoder
+	}
+	return ""
+}
+
 type DeviceList struct {
 	Devices []*Device `protobuf:"bytes,1,rep,name=devices" json:"devices,omitempty"`
 }
@@ -408,7 +652,7 @@ type DeviceList struct {
 func (m *DeviceList) Reset()                    { *m = DeviceList{} }
 func (m *DeviceList) String() string            { return proto.CompactTextString(m) }
 func (*DeviceList) ProtoMessage()               {}
-func (*DeviceList) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{7} }
+func (*DeviceList) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{10} }
 
 func (m *DeviceList) GetDevices() []*Device {
 	if m != nil {
@@ -417,215 +661,515 @@ func (m *DeviceList) GetDevices() []*Device {
 	return nil
 }
 
-// DryDownlinkMessage is a simulated message to test downlink processing
-type DryDownlinkMessage struct {
-	// The binary payload to use
-	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	// JSON-encoded object with fields to encode
-	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
-	// The Application containing the payload functions that should be executed
-	App *Application `protobuf:"bytes,3,opt,name=app" json:"app,omitempty"`
-	// The port number that should be passed to the payload function
-	Port uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+// A downlink message in the queue of a device
+type QueuedDownlink struct {
+	Port      uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Confirmed bool   `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	// The binary payload, if the message was scheduled with payload_raw
+	PayloadRaw []byte `protobuf:"bytes,3,opt,name=payload_raw,json=payloadRaw,proto3" json:"payload_raw,omitempty"`
+	// JSON-encoded object with the fields, if the message was scheduled with
+	// payload_fields. These fields are encoded when the message is sent.
+	PayloadFields string `protobuf:"bytes,4,opt,name=payload_fields,json=payloadFields,proto3" json:"payload_fields,omitempty"`
+	// Time (unix nanoseconds) at which the message expires. Expired messages
+	// are dropped from the queue. Zero if the message does not expire.
+	Expires int64 `protobuf:"varint,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	// The correlation ID of the message, it is included in the downlink events
+	CorrelationId string `protobuf:"bytes,6,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+}
+
+func (m *QueuedDownlink) Reset()                    { *m = QueuedDownlink{} }
+func (m *QueuedDownlink) String() string            { return proto.CompactTextString(m) }
+func (*QueuedDownlink) ProtoMessage()               {}
+func (*QueuedDownlink) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{11} }
+
+func (m *QueuedDownlink) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
 }
 
-func (m *DryDownlinkMessage) Reset()                    { *m = DryDownlinkMessage{} }
-func (m *DryDownlinkMessage) String() string            { return proto.CompactTextString(m) }
-func (*DryDownlinkMessage) ProtoMessage()               {}
-func (*DryDownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{8} }
+func (m *QueuedDownlink) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
 
-func (m *DryDownlinkMessage) GetPayload() []byte {
+func (m *QueuedDownlink) GetPayloadRaw() []byte {
 	if m != nil {
-		return m.Payload
+		return m.PayloadRaw
 	}
 	return nil
 }
 
-func (m *DryDownlinkMessage) GetFields() string {
+func (m *QueuedDownlink) GetPayloadFields() string {
 	if m != nil {
-		return m.Fields
+		return m.PayloadFields
 	}
 	return ""
 }
 
-func (m *DryDownlinkMessage) GetApp() *Application {
+func (m *QueuedDownlink) GetExpires() int64 {
 	if m != nil {
-		return m.App
+		return m.Expires
 	}
-	return nil
+	return 0
 }
 
-func (m *DryDownlinkMessage) GetPort() uint32 {
+func (m *QueuedDownlink) GetCorrelationId() string {
 	if m != nil {
-		return m.Port
+		return m.CorrelationId
 	}
-	return 0
+	return ""
 }
 
-// DryUplinkMessage is a simulated message to test uplink processing
-type DryUplinkMessage struct {
-	// The binary payload to use
-	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	// The Application containing the payload functions that should be executed
-	App *Application `protobuf:"bytes,2,opt,name=app" json:"app,omitempty"`
-	// The port number that should be passed to the payload function
-	Port uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+// The downlink queue of a device, the first message is sent first
+type DownlinkQueue struct {
+	Downlinks []*QueuedDownlink `protobuf:"bytes,1,rep,name=downlinks" json:"downlinks,omitempty"`
+	// The confirmed downlink message that was sent and that is sent again until
+	// the device acknowledges it. It is not part of the downlinks.
+	Current *QueuedDownlink `protobuf:"bytes,2,opt,name=current" json:"current,omitempty"`
 }
 
-func (m *DryUplinkMessage) Reset()                    { *m = DryUplinkMessage{} }
-func (m *DryUplinkMessage) String() string            { return proto.CompactTextString(m) }
-func (*DryUplinkMessage) ProtoMessage()               {}
-func (*DryUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{9} }
+func (m *DownlinkQueue) Reset()                    { *m = DownlinkQueue{} }
+func (m *DownlinkQueue) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkQueue) ProtoMessage()               {}
+func (*DownlinkQueue) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{12} }
 
-func (m *DryUplinkMessage) GetPayload() []byte {
+func (m *DownlinkQueue) GetDownlinks() []*QueuedDownlink {
 	if m != nil {
-		return m.Payload
+		return m.Downlinks
 	}
 	return nil
 }
 
-func (m *DryUplinkMessage) GetApp() *Application {
+func (m *DownlinkQueue) GetCurrent() *QueuedDownlink {
 	if m != nil {
-		return m.App
+		return m.Current
 	}
 	return nil
 }
 
-func (m *DryUplinkMessage) GetPort() uint32 {
-	if m != nil {
-		return m.Port
-	}
-	return 0
-}
-
-// SimulatedUplinkMessage is a simulated uplink message
-type SimulatedUplinkMessage struct {
+type QueuedDownlinkIdentifier struct {
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	DevId string `protobuf:"bytes,2,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
-	// The binary payload to use
-	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
-	// The port number
-	Port uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	// The index of the message in the downlink queue
+	Index uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
 }
 
-func (m *SimulatedUplinkMessage) Reset()                    { *m = SimulatedUplinkMessage{} }
-func (m *SimulatedUplinkMessage) String() string            { return proto.CompactTextString(m) }
-func (*SimulatedUplinkMessage) ProtoMessage()               {}
-func (*SimulatedUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{10} }
+func (m *QueuedDownlinkIdentifier) Reset()                    { *m = QueuedDownlinkIdentifier{} }
+func (m *QueuedDownlinkIdentifier) String() string            { return proto.CompactTextString(m) }
+func (*QueuedDownlinkIdentifier) ProtoMessage()               {}
+func (*QueuedDownlinkIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{13} }
 
-func (m *SimulatedUplinkMessage) GetAppId() string {
+func (m *QueuedDownlinkIdentifier) GetAppId() string {
 	if m != nil {
 		return m.AppId
 	}
 	return ""
 }
 
-func (m *SimulatedUplinkMessage) GetDevId() string {
+func (m *QueuedDownlinkIdentifier) GetDevId() string {
 	if m != nil {
 		return m.DevId
 	}
 	return ""
 }
 
-func (m *SimulatedUplinkMessage) GetPayload() []byte {
-	if m != nil {
-		return m.Payload
-	}
-	return nil
-}
-
-func (m *SimulatedUplinkMessage) GetPort() uint32 {
+func (m *QueuedDownlinkIdentifier) GetIndex() uint32 {
 	if m != nil {
-		return m.Port
+		return m.Index
 	}
 	return 0
 }
 
-type LogEntry struct {
-	// The location where the log was created (what payload function)
-	Function string `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
-	// A list of JSON-encoded fields that were logged
-	Fields []string `protobuf:"bytes,2,rep,name=fields" json:"fields,omitempty"`
+type MulticastGroupIdentifier struct {
+	AppId   string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	GroupId string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 }
 
-func (m *LogEntry) Reset()                    { *m = LogEntry{} }
-func (m *LogEntry) String() string            { return proto.CompactTextString(m) }
-func (*LogEntry) ProtoMessage()               {}
-func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{11} }
+func (m *MulticastGroupIdentifier) Reset()                    { *m = MulticastGroupIdentifier{} }
+func (m *MulticastGroupIdentifier) String() string            { return proto.CompactTextString(m) }
+func (*MulticastGroupIdentifier) ProtoMessage()               {}
+func (*MulticastGroupIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{14} }
 
-func (m *LogEntry) GetFunction() string {
+func (m *MulticastGroupIdentifier) GetAppId() string {
 	if m != nil {
-		return m.Function
+		return m.AppId
 	}
 	return ""
 }
 
-func (m *LogEntry) GetFields() []string {
+func (m *MulticastGroupIdentifier) GetGroupId() string {
 	if m != nil {
-		return m.Fields
+		return m.GroupId
 	}
-	return nil
+	return ""
 }
 
-// DryUplinkResult is the result from an uplink simulation
-type DryUplinkResult struct {
-	// The binary payload
-	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	// The decoded fields
-	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
-	// Was validation of the message successful
-	Valid bool `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
-	// Logs that have been generated while processing
-	Logs []*LogEntry `protobuf:"bytes,4,rep,name=logs" json:"logs,omitempty"`
+// A multicast group of devices that share the same session
+type MulticastGroup struct {
+	AppId       string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	GroupId     string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The DevAddr is the 4 byte session address that is shared by the devices in the group.
+	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,4,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
+	// The NwkSKey is the 16 byte network session key that is shared by the devices in the group.
+	NwkSKey *github_com_TheThingsNetwork_ttn_core_types.NwkSKey `protobuf:"bytes,5,opt,name=nwk_s_key,json=nwkSKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkSKey" json:"nwk_s_key,omitempty"`
+	// The AppSKey is the 16 byte application session key that is shared by the devices in the group.
+	AppSKey *github_com_TheThingsNetwork_ttn_core_types.AppSKey `protobuf:"bytes,6,opt,name=app_s_key,json=appSKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppSKey" json:"app_s_key,omitempty"`
+	// FCntDown is the downlink frame counter of the group.
+	FCntDown uint32 `protobuf:"varint,7,opt,name=f_cnt_down,json=fCntDown,proto3" json:"f_cnt_down,omitempty"`
+	// The IDs of the gateways that transmit the downlink messages of the group
+	GatewayIds []string `protobuf:"bytes,8,rep,name=gateway_ids,json=gatewayIds" json:"gateway_ids,omitempty"`
+}
+
+func (m *MulticastGroup) Reset()                    { *m = MulticastGroup{} }
+func (m *MulticastGroup) String() string            { return proto.CompactTextString(m) }
+func (*MulticastGroup) ProtoMessage()               {}
+func (*MulticastGroup) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{15} }
+
+func (m *MulticastGroup) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
 }
 
-func (m *DryUplinkResult) Reset()                    { *m = DryUplinkResult{} }
-func (m *DryUplinkResult) String() string            { return proto.CompactTextString(m) }
-func (*DryUplinkResult) ProtoMessage()               {}
-func (*DryUplinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{12} }
-
-func (m *DryUplinkResult) GetPayload() []byte {
+func (m *MulticastGroup) GetGroupId() string {
 	if m != nil {
-		return m.Payload
+		return m.GroupId
 	}
-	return nil
+	return ""
 }
 
-func (m *DryUplinkResult) GetFields() string {
+func (m *MulticastGroup) GetDescription() string {
 	if m != nil {
-		return m.Fields
+		return m.Description
 	}
 	return ""
 }
 
-func (m *DryUplinkResult) GetValid() bool {
+func (m *MulticastGroup) GetFCntDown() uint32 {
 	if m != nil {
-		return m.Valid
+		return m.FCntDown
 	}
-	return false
+	return 0
 }
 
-func (m *DryUplinkResult) GetLogs() []*LogEntry {
+func (m *MulticastGroup) GetGatewayIds() []string {
 	if m != nil {
-		return m.Logs
+		return m.GatewayIds
 	}
 	return nil
 }
 
-// DryDownlinkResult is the result from a downlink simulation
-type DryDownlinkResult struct {
-	// The payload that was encoded
-	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	// Logs that have been generated while processing
-	Logs []*LogEntry `protobuf:"bytes,2,rep,name=logs" json:"logs,omitempty"`
+type MulticastGroupList struct {
+	Groups []*MulticastGroup `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty"`
 }
 
-func (m *DryDownlinkResult) Reset()                    { *m = DryDownlinkResult{} }
-func (m *DryDownlinkResult) String() string            { return proto.CompactTextString(m) }
-func (*DryDownlinkResult) ProtoMessage()               {}
-func (*DryDownlinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{13} }
+func (m *MulticastGroupList) Reset()                    { *m = MulticastGroupList{} }
+func (m *MulticastGroupList) String() string            { return proto.CompactTextString(m) }
+func (*MulticastGroupList) ProtoMessage()               {}
+func (*MulticastGroupList) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{16} }
 
-func (m *DryDownlinkResult) GetPayload() []byte {
+func (m *MulticastGroupList) GetGroups() []*MulticastGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// A downlink message to a multicast group
+type MulticastDownlinkMessage struct {
+	AppId   string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	GroupId string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Port    uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// The binary payload, if payload_fields is not set
+	PayloadRaw []byte `protobuf:"bytes,4,opt,name=payload_raw,json=payloadRaw,proto3" json:"payload_raw,omitempty"`
+	// JSON-encoded object with the fields, these are encoded with the payload
+	// format or encoder of the application
+	PayloadFields string `protobuf:"bytes,5,opt,name=payload_fields,json=payloadFields,proto3" json:"payload_fields,omitempty"`
+}
+
+func (m *MulticastDownlinkMessage) Reset()                    { *m = MulticastDownlinkMessage{} }
+func (m *MulticastDownlinkMessage) String() string            { return proto.CompactTextString(m) }
+func (*MulticastDownlinkMessage) ProtoMessage()               {}
+func (*MulticastDownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{17} }
+
+func (m *MulticastDownlinkMessage) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *MulticastDownlinkMessage) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *MulticastDownlinkMessage) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *MulticastDownlinkMessage) GetPayloadRaw() []byte {
+	if m != nil {
+		return m.PayloadRaw
+	}
+	return nil
+}
+
+func (m *MulticastDownlinkMessage) GetPayloadFields() string {
+	if m != nil {
+		return m.PayloadFields
+	}
+	return ""
+}
+
+// DryDownlinkMessage is a simulated message to test downlink processing
+type DryDownlinkMessage struct {
+	// The binary payload to use
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// JSON-encoded object with fields to encode
+	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+	// The Application containing the payload functions that should be executed
+	App *Application `protobuf:"bytes,3,opt,name=app" json:"app,omitempty"`
+	// The port number that should be passed to the payload function
+	Port uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	// The payload functions of the device that take precedence over the ones of the Application
+	DevicePayloadFunctions *PayloadFunctions `protobuf:"bytes,5,opt,name=device_payload_functions,json=devicePayloadFunctions" json:"device_payload_functions,omitempty"`
+}
+
+func (m *DryDownlinkMessage) Reset()                    { *m = DryDownlinkMessage{} }
+func (m *DryDownlinkMessage) String() string            { return proto.CompactTextString(m) }
+func (*DryDownlinkMessage) ProtoMessage()               {}
+func (*DryDownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{18} }
+
+func (m *DryDownlinkMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *DryDownlinkMessage) GetFields() string {
+	if m != nil {
+		return m.Fields
+	}
+	return ""
+}
+
+func (m *DryDownlinkMessage) GetApp() *Application {
+	if m != nil {
+		return m.App
+	}
+	return nil
+}
+
+func (m *DryDownlinkMessage) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *DryDownlinkMessage) GetDevicePayloadFunctions() *PayloadFunctions {
+	if m != nil {
+		return m.DevicePayloadFunctions
+	}
+	return nil
+}
+
+// DryUplinkMessage is a simulated message to test uplink processing
+type DryUplinkMessage struct {
+	// The binary payload to use
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The Application containing the payload functions that should be executed
+	App *Application `protobuf:"bytes,2,opt,name=app" json:"app,omitempty"`
+	// The port number that should be passed to the payload function
+	Port uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	// The payload functions of the device that take precedence over the ones of the Application
+	DevicePayloadFunctions *PayloadFunctions `protobuf:"bytes,4,opt,name=device_payload_functions,json=devicePayloadFunctions" json:"device_payload_functions,omitempty"`
+}
+
+func (m *DryUplinkMessage) Reset()                    { *m = DryUplinkMessage{} }
+func (m *DryUplinkMessage) String() string            { return proto.CompactTextString(m) }
+func (*DryUplinkMessage) ProtoMessage()               {}
+func (*DryUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{19} }
+
+func (m *DryUplinkMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *DryUplinkMessage) GetApp() *Application {
+	if m != nil {
+		return m.App
+	}
+	return nil
+}
+
+func (m *DryUplinkMessage) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *DryUplinkMessage) GetDevicePayloadFunctions() *PayloadFunctions {
+	if m != nil {
+		return m.DevicePayloadFunctions
+	}
+	return nil
+}
+
+// SimulatedUplinkMessage is a simulated uplink message
+type SimulatedUplinkMessage struct {
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	DevId string `protobuf:"bytes,2,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// The binary payload to use
+	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The port number
+	Port uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (m *SimulatedUplinkMessage) Reset()                    { *m = SimulatedUplinkMessage{} }
+func (m *SimulatedUplinkMessage) String() string            { return proto.CompactTextString(m) }
+func (*SimulatedUplinkMessage) ProtoMessage()               {}
+func (*SimulatedUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{20} }
+
+func (m *SimulatedUplinkMessage) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *SimulatedUplinkMessage) GetDevId() string {
+	if m != nil {
+		return m.DevId
+	}
+	return ""
+}
+
+func (m *SimulatedUplinkMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SimulatedUplinkMessage) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type LogEntry struct {
+	// The location where the log was created (what payload function)
+	Function string `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	// A list of JSON-encoded fields that were logged
+	Fields []string `protobuf:"bytes,2,rep,name=fields" json:"fields,omitempty"`
+}
+
+func (m *LogEntry) Reset()                    { *m = LogEntry{} }
+func (m *LogEntry) String() string            { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()               {}
+func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{21} }
+
+func (m *LogEntry) GetFunction() string {
+	if m != nil {
+		return m.Function
+	}
+	return ""
+}
+
+func (m *LogEntry) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+// DryUplinkResult is the result from an uplink simulation
+type DryUplinkResult struct {
+	// The binary payload
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The decoded fields
+	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+	// Was validation of the message successful
+	Valid bool `protobuf:"varint,3,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Logs that have been generated while processing
+	Logs []*LogEntry `protobuf:"bytes,4,rep,name=logs" json:"logs,omitempty"`
+	// JSON-encoded downlink message that was returned by the payload functions.
+	// This downlink message is not enqueued.
+	Downlink string `protobuf:"bytes,5,opt,name=downlink,proto3" json:"downlink,omitempty"`
+}
+
+func (m *DryUplinkResult) Reset()                    { *m = DryUplinkResult{} }
+func (m *DryUplinkResult) String() string            { return proto.CompactTextString(m) }
+func (*DryUplinkResult) ProtoMessage()               {}
+func (*DryUplinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{22} }
+
+func (m *DryUplinkResult) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *DryUplinkResult) GetFields() string {
+	if m != nil {
+		return m.Fields
+	}
+	return ""
+}
+
+func (m *DryUplinkResult) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *DryUplinkResult) GetLogs() []*LogEntry {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *DryUplinkResult) GetDownlink() string {
+	if m != nil {
+		return m.Downlink
+	}
+	return ""
+}
+
+// DryDownlinkResult is the result from a downlink simulation
+type DryDownlinkResult struct {
+	// The payload that was encoded
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Logs that have been generated while processing
+	Logs []*LogEntry `protobuf:"bytes,2,rep,name=logs" json:"logs,omitempty"`
+}
+
+func (m *DryDownlinkResult) Reset()                    { *m = DryDownlinkResult{} }
+func (m *DryDownlinkResult) String() string            { return proto.CompactTextString(m) }
+func (*DryDownlinkResult) ProtoMessage()               {}
+func (*DryDownlinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{23} }
+
+func (m *DryDownlinkResult) GetPayload() []byte {
 	if m != nil {
 		return m.Payload
 	}
@@ -643,11 +1187,21 @@ func init() {
 	proto.RegisterType((*DeviceActivationResponse)(nil), "handler.DeviceActivationResponse")
 	proto.RegisterType((*StatusRequest)(nil), "handler.StatusRequest")
 	proto.RegisterType((*Status)(nil), "handler.Status")
+	proto.RegisterType((*IntegrationStatus)(nil), "handler.IntegrationStatus")
 	proto.RegisterType((*ApplicationIdentifier)(nil), "handler.ApplicationIdentifier")
 	proto.RegisterType((*Application)(nil), "handler.Application")
+	proto.RegisterType((*HTTPIntegration)(nil), "handler.HTTPIntegration")
 	proto.RegisterType((*DeviceIdentifier)(nil), "handler.DeviceIdentifier")
 	proto.RegisterType((*Device)(nil), "handler.Device")
+	proto.RegisterType((*PayloadFunctions)(nil), "handler.PayloadFunctions")
 	proto.RegisterType((*DeviceList)(nil), "handler.DeviceList")
+	proto.RegisterType((*QueuedDownlink)(nil), "handler.QueuedDownlink")
+	proto.RegisterType((*DownlinkQueue)(nil), "handler.DownlinkQueue")
+	proto.RegisterType((*QueuedDownlinkIdentifier)(nil), "handler.QueuedDownlinkIdentifier")
+	proto.RegisterType((*MulticastGroupIdentifier)(nil), "handler.MulticastGroupIdentifier")
+	proto.RegisterType((*MulticastGroup)(nil), "handler.MulticastGroup")
+	proto.RegisterType((*MulticastGroupList)(nil), "handler.MulticastGroupList")
+	proto.RegisterType((*MulticastDownlinkMessage)(nil), "handler.MulticastDownlinkMessage")
 	proto.RegisterType((*DryDownlinkMessage)(nil), "handler.DryDownlinkMessage")
 	proto.RegisterType((*DryUplinkMessage)(nil), "handler.DryUplinkMessage")
 	proto.RegisterType((*SimulatedUplinkMessage)(nil), "handler.SimulatedUplinkMessage")
@@ -669,6 +1223,7 @@ const _ = grpc.SupportPackageIsVersion4
 type HandlerClient interface {
 	ActivationChallenge(ctx context.Context, in *broker.ActivationChallengeRequest, opts ...grpc.CallOption) (*broker.ActivationChallengeResponse, error)
 	Activate(ctx context.Context, in *broker.DeduplicatedDeviceActivationRequest, opts ...grpc.CallOption) (*DeviceActivationResponse, error)
+	TxAck(ctx context.Context, in *broker.TxAcknowledgment, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type handlerClient struct {
@@ -697,11 +1252,21 @@ func (c *handlerClient) Activate(ctx context.Context, in *broker.DeduplicatedDev
 	return out, nil
 }
 
-// Server API for Handler service
-
+func (c *handlerClient) TxAck(ctx context.Context, in *broker.TxAcknowledgment, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.Handler/TxAck", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Handler service
+
 type HandlerServer interface {
 	ActivationChallenge(context.Context, *broker.ActivationChallengeRequest) (*broker.ActivationChallengeResponse, error)
 	Activate(context.Context, *broker.DeduplicatedDeviceActivationRequest) (*DeviceActivationResponse, error)
+	TxAck(context.Context, *broker.TxAcknowledgment) (*google_protobuf.Empty, error)
 }
 
 func RegisterHandlerServer(s *grpc.Server, srv HandlerServer) {
@@ -744,6 +1309,24 @@ func _Handler_Activate_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Handler_TxAck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(broker.TxAcknowledgment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServer).TxAck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.Handler/TxAck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServer).TxAck(ctx, req.(*broker.TxAcknowledgment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Handler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "handler.Handler",
 	HandlerType: (*HandlerServer)(nil),
@@ -756,6 +1339,10 @@ var _Handler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Activate",
 			Handler:    _Handler_Activate_Handler,
 		},
+		{
+			MethodName: "TxAck",
+			Handler:    _Handler_TxAck_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/handler/handler.proto",
@@ -780,6 +1367,23 @@ type ApplicationManagerClient interface {
 	DeleteDevice(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 	// GetDevicesForApplication returns all devices that belong to the application with the given identifier (app_id)
 	GetDevicesForApplication(ctx context.Context, in *ApplicationIdentifier, opts ...grpc.CallOption) (*DeviceList, error)
+	// GetDownlinkQueue returns the downlink queue of the device with the given identifier (app_id and dev_id)
+	GetDownlinkQueue(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*DownlinkQueue, error)
+	// DeleteQueuedDownlink deletes the message at the given index from the downlink queue of the device
+	DeleteQueuedDownlink(ctx context.Context, in *QueuedDownlinkIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// ClearDownlinkQueue deletes all messages from the downlink queue of the device,
+	// including the confirmed downlink message that waits for an acknowledgment
+	ClearDownlinkQueue(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// GetMulticastGroup returns the multicast group with the given identifier (app_id and group_id)
+	GetMulticastGroup(ctx context.Context, in *MulticastGroupIdentifier, opts ...grpc.CallOption) (*MulticastGroup, error)
+	// SetMulticastGroup creates or updates a multicast group. All fields must be supplied.
+	SetMulticastGroup(ctx context.Context, in *MulticastGroup, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// DeleteMulticastGroup deletes the multicast group with the given identifier (app_id and group_id)
+	DeleteMulticastGroup(ctx context.Context, in *MulticastGroupIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// GetMulticastGroupsForApplication returns all multicast groups of the application with the given identifier (app_id)
+	GetMulticastGroupsForApplication(ctx context.Context, in *ApplicationIdentifier, opts ...grpc.CallOption) (*MulticastGroupList, error)
+	// SendMulticastDownlink encrypts the message once and sends it to all devices in the multicast group
+	SendMulticastDownlink(ctx context.Context, in *MulticastDownlinkMessage, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 	// DryUplink simulates processing a downlink message and returns the result
 	DryDownlink(ctx context.Context, in *DryDownlinkMessage, opts ...grpc.CallOption) (*DryDownlinkResult, error)
 	// DryUplink simulates processing an uplink message and returns the result
@@ -868,6 +1472,78 @@ func (c *applicationManagerClient) GetDevicesForApplication(ctx context.Context,
 	return out, nil
 }
 
+func (c *applicationManagerClient) GetDownlinkQueue(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*DownlinkQueue, error) {
+	out := new(DownlinkQueue)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/GetDownlinkQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) DeleteQueuedDownlink(ctx context.Context, in *QueuedDownlinkIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/DeleteQueuedDownlink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) ClearDownlinkQueue(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/ClearDownlinkQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) GetMulticastGroup(ctx context.Context, in *MulticastGroupIdentifier, opts ...grpc.CallOption) (*MulticastGroup, error) {
+	out := new(MulticastGroup)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/GetMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) SetMulticastGroup(ctx context.Context, in *MulticastGroup, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/SetMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) DeleteMulticastGroup(ctx context.Context, in *MulticastGroupIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/DeleteMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) GetMulticastGroupsForApplication(ctx context.Context, in *ApplicationIdentifier, opts ...grpc.CallOption) (*MulticastGroupList, error) {
+	out := new(MulticastGroupList)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/GetMulticastGroupsForApplication", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) SendMulticastDownlink(ctx context.Context, in *MulticastDownlinkMessage, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/SendMulticastDownlink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationManagerClient) DryDownlink(ctx context.Context, in *DryDownlinkMessage, opts ...grpc.CallOption) (*DryDownlinkResult, error) {
 	out := new(DryDownlinkResult)
 	err := grpc.Invoke(ctx, "/handler.ApplicationManager/DryDownlink", in, out, c.cc, opts...)
@@ -914,6 +1590,23 @@ type ApplicationManagerServer interface {
 	DeleteDevice(context.Context, *DeviceIdentifier) (*google_protobuf.Empty, error)
 	// GetDevicesForApplication returns all devices that belong to the application with the given identifier (app_id)
 	GetDevicesForApplication(context.Context, *ApplicationIdentifier) (*DeviceList, error)
+	// GetDownlinkQueue returns the downlink queue of the device with the given identifier (app_id and dev_id)
+	GetDownlinkQueue(context.Context, *DeviceIdentifier) (*DownlinkQueue, error)
+	// DeleteQueuedDownlink deletes the message at the given index from the downlink queue of the device
+	DeleteQueuedDownlink(context.Context, *QueuedDownlinkIdentifier) (*google_protobuf.Empty, error)
+	// ClearDownlinkQueue deletes all messages from the downlink queue of the device,
+	// including the confirmed downlink message that waits for an acknowledgment
+	ClearDownlinkQueue(context.Context, *DeviceIdentifier) (*google_protobuf.Empty, error)
+	// GetMulticastGroup returns the multicast group with the given identifier (app_id and group_id)
+	GetMulticastGroup(context.Context, *MulticastGroupIdentifier) (*MulticastGroup, error)
+	// SetMulticastGroup creates or updates a multicast group. All fields must be supplied.
+	SetMulticastGroup(context.Context, *MulticastGroup) (*google_protobuf.Empty, error)
+	// DeleteMulticastGroup deletes the multicast group with the given identifier (app_id and group_id)
+	DeleteMulticastGroup(context.Context, *MulticastGroupIdentifier) (*google_protobuf.Empty, error)
+	// GetMulticastGroupsForApplication returns all multicast groups of the application with the given identifier (app_id)
+	GetMulticastGroupsForApplication(context.Context, *ApplicationIdentifier) (*MulticastGroupList, error)
+	// SendMulticastDownlink encrypts the message once and sends it to all devices in the multicast group
+	SendMulticastDownlink(context.Context, *MulticastDownlinkMessage) (*google_protobuf.Empty, error)
 	// DryUplink simulates processing a downlink message and returns the result
 	DryDownlink(context.Context, *DryDownlinkMessage) (*DryDownlinkResult, error)
 	// DryUplink simulates processing an uplink message and returns the result
@@ -1070,6 +1763,150 @@ func _ApplicationManager_GetDevicesForApplication_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationManager_GetDownlinkQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).GetDownlinkQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/GetDownlinkQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).GetDownlinkQueue(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_DeleteQueuedDownlink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueuedDownlinkIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).DeleteQueuedDownlink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/DeleteQueuedDownlink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).DeleteQueuedDownlink(ctx, req.(*QueuedDownlinkIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_ClearDownlinkQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).ClearDownlinkQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/ClearDownlinkQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).ClearDownlinkQueue(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_GetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MulticastGroupIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).GetMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/GetMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).GetMulticastGroup(ctx, req.(*MulticastGroupIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_SetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MulticastGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).SetMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/SetMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).SetMulticastGroup(ctx, req.(*MulticastGroup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_DeleteMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MulticastGroupIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).DeleteMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/DeleteMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).DeleteMulticastGroup(ctx, req.(*MulticastGroupIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_GetMulticastGroupsForApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).GetMulticastGroupsForApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/GetMulticastGroupsForApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).GetMulticastGroupsForApplication(ctx, req.(*ApplicationIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_SendMulticastDownlink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MulticastDownlinkMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).SendMulticastDownlink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/SendMulticastDownlink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).SendMulticastDownlink(ctx, req.(*MulticastDownlinkMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationManager_DryDownlink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DryDownlinkMessage)
 	if err := dec(in); err != nil {
@@ -1160,6 +1997,38 @@ var _ApplicationManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDevicesForApplication",
 			Handler:    _ApplicationManager_GetDevicesForApplication_Handler,
 		},
+		{
+			MethodName: "GetDownlinkQueue",
+			Handler:    _ApplicationManager_GetDownlinkQueue_Handler,
+		},
+		{
+			MethodName: "DeleteQueuedDownlink",
+			Handler:    _ApplicationManager_DeleteQueuedDownlink_Handler,
+		},
+		{
+			MethodName: "ClearDownlinkQueue",
+			Handler:    _ApplicationManager_ClearDownlinkQueue_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroup",
+			Handler:    _ApplicationManager_GetMulticastGroup_Handler,
+		},
+		{
+			MethodName: "SetMulticastGroup",
+			Handler:    _ApplicationManager_SetMulticastGroup_Handler,
+		},
+		{
+			MethodName: "DeleteMulticastGroup",
+			Handler:    _ApplicationManager_DeleteMulticastGroup_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroupsForApplication",
+			Handler:    _ApplicationManager_GetMulticastGroupsForApplication_Handler,
+		},
+		{
+			MethodName: "SendMulticastDownlink",
+			Handler:    _ApplicationManager_SendMulticastDownlink_Handler,
+		},
 		{
 			MethodName: "DryDownlink",
 			Handler:    _ApplicationManager_DryDownlink_Handler,
@@ -1392,10 +2261,24 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n9
 	}
+	if len(m.Integrations) > 0 {
+		for _, msg := range m.Integrations {
+			dAtA[i] = 0xaa
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
-func (m *ApplicationIdentifier) Marshal() (dAtA []byte, err error) {
+func (m *IntegrationStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1405,21 +2288,61 @@ func (m *ApplicationIdentifier) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ApplicationIdentifier) MarshalTo(dAtA []byte) (int, error) {
+func (m *IntegrationStatus) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.AppId) > 0 {
+	if len(m.Name) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
-		i += copy(dAtA[i:], m.AppId)
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Uplink != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Uplink.Size()))
+		n10, err := m.Uplink.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.Activations != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Activations.Size()))
+		n11, err := m.Activations.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.Events != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Events.Size()))
+		n12, err := m.Events.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.Errors != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Errors.Size()))
+		n13, err := m.Errors.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
 	}
 	return i, nil
 }
 
-func (m *Application) Marshal() (dAtA []byte, err error) {
+func (m *ApplicationIdentifier) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1429,7 +2352,7 @@ func (m *Application) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Application) MarshalTo(dAtA []byte) (int, error) {
+func (m *ApplicationIdentifier) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1440,13 +2363,37 @@ func (m *Application) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
 		i += copy(dAtA[i:], m.AppId)
 	}
-	if len(m.Decoder) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Decoder)))
-		i += copy(dAtA[i:], m.Decoder)
-	}
-	if len(m.Converter) > 0 {
+	return i, nil
+}
+
+func (m *Application) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Application) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
+	if len(m.Decoder) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Decoder)))
+		i += copy(dAtA[i:], m.Decoder)
+	}
+	if len(m.Converter) > 0 {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.Converter)))
@@ -1464,6 +2411,112 @@ func (m *Application) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.Encoder)))
 		i += copy(dAtA[i:], m.Encoder)
 	}
+	if m.HttpIntegration != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.HttpIntegration.Size()))
+		n14, err := m.HttpIntegration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if len(m.DisabledIntegrations) > 0 {
+		for _, s := range m.DisabledIntegrations {
+			dAtA[i] = 0x3a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.PayloadFormat) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.PayloadFormat)))
+		i += copy(dAtA[i:], m.PayloadFormat)
+	}
+	if m.ExposeMetadata {
+		dAtA[i] = 0x48
+		i++
+		if m.ExposeMetadata {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.AdrAlgorithm) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AdrAlgorithm)))
+		i += copy(dAtA[i:], m.AdrAlgorithm)
+	}
+	if len(m.AdrDataRate) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AdrDataRate)))
+		i += copy(dAtA[i:], m.AdrDataRate)
+	}
+	return i, nil
+}
+
+func (m *HTTPIntegration) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HTTPIntegration) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.UplinkUrl) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.UplinkUrl)))
+		i += copy(dAtA[i:], m.UplinkUrl)
+	}
+	if len(m.ActivationUrl) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.ActivationUrl)))
+		i += copy(dAtA[i:], m.ActivationUrl)
+	}
+	if len(m.EventUrl) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.EventUrl)))
+		i += copy(dAtA[i:], m.EventUrl)
+	}
+	if len(m.Headers) > 0 {
+		for k, _ := range m.Headers {
+			dAtA[i] = 0x22
+			i++
+			v := m.Headers[k]
+			mapSize := 1 + len(k) + sovHandler(uint64(len(k))) + 1 + len(v) + sovHandler(uint64(len(v)))
+			i = encodeVarintHandler(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
 	return i, nil
 }
 
@@ -1525,11 +2578,11 @@ func (m *Device) MarshalTo(dAtA []byte) (int, error) {
 		i += copy(dAtA[i:], m.DevId)
 	}
 	if m.Device != nil {
-		nn10, err := m.Device.MarshalTo(dAtA[i:])
+		nn15, err := m.Device.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn10
+		i += nn15
 	}
 	if m.Latitude != 0 {
 		dAtA[i] = 0x55
@@ -1554,6 +2607,18 @@ func (m *Device) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.Description)))
 		i += copy(dAtA[i:], m.Description)
 	}
+	if m.PayloadFunctions != nil {
+		dAtA[i] = 0xf2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.PayloadFunctions.Size()))
+		n16, err := m.PayloadFunctions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
 	return i, nil
 }
 
@@ -1563,14 +2628,56 @@ func (m *Device_LorawanDevice) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintHandler(dAtA, i, uint64(m.LorawanDevice.Size()))
-		n11, err := m.LorawanDevice.MarshalTo(dAtA[i:])
+		n17, err := m.LorawanDevice.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n17
+	}
+	return i, nil
+}
+func (m *PayloadFunctions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PayloadFunctions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Decoder) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Decoder)))
+		i += copy(dAtA[i:], m.Decoder)
+	}
+	if len(m.Converter) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Converter)))
+		i += copy(dAtA[i:], m.Converter)
+	}
+	if len(m.Validator) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Validator)))
+		i += copy(dAtA[i:], m.Validator)
+	}
+	if len(m.Encoder) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Encoder)))
+		i += copy(dAtA[i:], m.Encoder)
 	}
 	return i, nil
 }
+
 func (m *DeviceList) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1601,7 +2708,7 @@ func (m *DeviceList) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *DryDownlinkMessage) Marshal() (dAtA []byte, err error) {
+func (m *QueuedDownlink) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1611,42 +2718,53 @@ func (m *DryDownlinkMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DryDownlinkMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *QueuedDownlink) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0xa
+	if m.Port != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
+		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
 	}
-	if len(m.Fields) > 0 {
-		dAtA[i] = 0x12
+	if m.Confirmed {
+		dAtA[i] = 0x10
+		i++
+		if m.Confirmed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Fields)))
-		i += copy(dAtA[i:], m.Fields)
 	}
-	if m.App != nil {
+	if len(m.PayloadRaw) > 0 {
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(m.App.Size()))
-		n12, err := m.App.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.PayloadRaw)))
+		i += copy(dAtA[i:], m.PayloadRaw)
 	}
-	if m.Port != 0 {
-		dAtA[i] = 0x20
+	if len(m.PayloadFields) > 0 {
+		dAtA[i] = 0x22
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.PayloadFields)))
+		i += copy(dAtA[i:], m.PayloadFields)
+	}
+	if m.Expires != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Expires))
+	}
+	if len(m.CorrelationId) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.CorrelationId)))
+		i += copy(dAtA[i:], m.CorrelationId)
 	}
 	return i, nil
 }
 
-func (m *DryUplinkMessage) Marshal() (dAtA []byte, err error) {
+func (m *DownlinkQueue) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1656,36 +2774,37 @@ func (m *DryUplinkMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DryUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *DownlinkQueue) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
+	if len(m.Downlinks) > 0 {
+		for _, msg := range m.Downlinks {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.App != nil {
+	if m.Current != nil {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(m.App.Size()))
-		n13, err := m.App.MarshalTo(dAtA[i:])
+		i = encodeVarintHandler(dAtA, i, uint64(m.Current.Size()))
+		n18, err := m.Current.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
-	}
-	if m.Port != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
+		i += n18
 	}
 	return i, nil
 }
 
-func (m *SimulatedUplinkMessage) Marshal() (dAtA []byte, err error) {
+func (m *QueuedDownlinkIdentifier) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1695,7 +2814,7 @@ func (m *SimulatedUplinkMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SimulatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *QueuedDownlinkIdentifier) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1712,21 +2831,15 @@ func (m *SimulatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.DevId)))
 		i += copy(dAtA[i:], m.DevId)
 	}
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
-	}
-	if m.Port != 0 {
-		dAtA[i] = 0x20
+	if m.Index != 0 {
+		dAtA[i] = 0x18
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
+		i = encodeVarintHandler(dAtA, i, uint64(m.Index))
 	}
 	return i, nil
 }
 
-func (m *LogEntry) Marshal() (dAtA []byte, err error) {
+func (m *MulticastGroupIdentifier) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1736,36 +2849,27 @@ func (m *LogEntry) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LogEntry) MarshalTo(dAtA []byte) (int, error) {
+func (m *MulticastGroupIdentifier) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Function) > 0 {
+	if len(m.AppId) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Function)))
-		i += copy(dAtA[i:], m.Function)
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	if len(m.Fields) > 0 {
-		for _, s := range m.Fields {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	if len(m.GroupId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.GroupId)))
+		i += copy(dAtA[i:], m.GroupId)
 	}
 	return i, nil
 }
 
-func (m *DryUplinkResult) Marshal() (dAtA []byte, err error) {
+func (m *MulticastGroup) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1775,49 +2879,83 @@ func (m *DryUplinkResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DryUplinkResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *MulticastGroup) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Payload) > 0 {
+	if len(m.AppId) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	if len(m.Fields) > 0 {
+	if len(m.GroupId) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Fields)))
-		i += copy(dAtA[i:], m.Fields)
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.GroupId)))
+		i += copy(dAtA[i:], m.GroupId)
 	}
-	if m.Valid {
-		dAtA[i] = 0x18
-		i++
-		if m.Valid {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x1a
 		i++
-	}
-	if len(m.Logs) > 0 {
-		for _, msg := range m.Logs {
-			dAtA[i] = 0x22
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
+	}
+	if m.DevAddr != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.DevAddr.Size()))
+		n19, err := m.DevAddr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.NwkSKey != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.NwkSKey.Size()))
+		n20, err := m.NwkSKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if m.AppSKey != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.AppSKey.Size()))
+		n21, err := m.AppSKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.FCntDown != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.FCntDown))
+	}
+	if len(m.GatewayIds) > 0 {
+		for _, s := range m.GatewayIds {
+			dAtA[i] = 0x42
 			i++
-			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
-			i += n
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
 	return i, nil
 }
 
-func (m *DryDownlinkResult) Marshal() (dAtA []byte, err error) {
+func (m *MulticastGroupList) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1827,20 +2965,14 @@ func (m *DryDownlinkResult) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DryDownlinkResult) MarshalTo(dAtA []byte) (int, error) {
+func (m *MulticastGroupList) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
-	}
-	if len(m.Logs) > 0 {
-		for _, msg := range m.Logs {
-			dAtA[i] = 0x12
+	if len(m.Groups) > 0 {
+		for _, msg := range m.Groups {
+			dAtA[i] = 0xa
 			i++
 			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -1853,283 +2985,619 @@ func (m *DryDownlinkResult) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeFixed64Handler(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Handler(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintHandler(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *MulticastDownlinkMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *DeviceActivationResponse) Size() (n int) {
+
+func (m *MulticastDownlinkMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	if m.Message != nil {
-		l = m.Message.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.GroupId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.GroupId)))
+		i += copy(dAtA[i:], m.GroupId)
 	}
-	if m.DownlinkOption != nil {
-		l = m.DownlinkOption.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if m.Port != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
 	}
-	if m.ActivationMetadata != nil {
-		l = m.ActivationMetadata.Size()
-		n += 2 + l + sovHandler(uint64(l))
+	if len(m.PayloadRaw) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.PayloadRaw)))
+		i += copy(dAtA[i:], m.PayloadRaw)
 	}
-	if m.Trace != nil {
-		l = m.Trace.Size()
-		n += 2 + l + sovHandler(uint64(l))
+	if len(m.PayloadFields) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.PayloadFields)))
+		i += copy(dAtA[i:], m.PayloadFields)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StatusRequest) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *DryDownlinkMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
-func (m *Status) Size() (n int) {
+func (m *DryDownlinkMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.System != nil {
-		l = m.System.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
 	}
-	if m.Component != nil {
-		l = m.Component.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Fields) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Fields)))
+		i += copy(dAtA[i:], m.Fields)
 	}
-	if m.Uplink != nil {
-		l = m.Uplink.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if m.App != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.App.Size()))
+		n22, err := m.App.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
 	}
-	if m.Downlink != nil {
-		l = m.Downlink.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if m.Port != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
 	}
-	if m.Activations != nil {
-		l = m.Activations.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if m.DevicePayloadFunctions != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.DevicePayloadFunctions.Size()))
+		n23, err := m.DevicePayloadFunctions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
 	}
-	return n
+	return i, nil
 }
 
-func (m *ApplicationIdentifier) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+func (m *DryUplinkMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Application) Size() (n int) {
+func (m *DryUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	l = len(m.Decoder)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
 	}
-	l = len(m.Converter)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if m.App != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.App.Size()))
+		n24, err := m.App.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
 	}
-	l = len(m.Validator)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if m.Port != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
 	}
-	l = len(m.Encoder)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if m.DevicePayloadFunctions != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.DevicePayloadFunctions.Size()))
+		n25, err := m.DevicePayloadFunctions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeviceIdentifier) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	l = len(m.DevId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+func (m *SimulatedUplinkMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Device) Size() (n int) {
+func (m *SimulatedUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	l = len(m.DevId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.DevId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.DevId)))
+		i += copy(dAtA[i:], m.DevId)
 	}
-	if m.Device != nil {
-		n += m.Device.Size()
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
 	}
-	if m.Latitude != 0 {
-		n += 5
+	if m.Port != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Port))
 	}
-	if m.Longitude != 0 {
-		n += 5
+	return i, nil
+}
+
+func (m *LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.Altitude != 0 {
-		n += 1 + sovHandler(uint64(m.Altitude))
+	return dAtA[:n], nil
+}
+
+func (m *LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Function) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Function)))
+		i += copy(dAtA[i:], m.Function)
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 2 + l + sovHandler(uint64(l))
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *Device_LorawanDevice) Size() (n int) {
+func (m *DryUplinkResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DryUplinkResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.LorawanDevice != nil {
-		l = m.LorawanDevice.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
 	}
-	return n
+	if len(m.Fields) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Fields)))
+		i += copy(dAtA[i:], m.Fields)
+	}
+	if m.Valid {
+		dAtA[i] = 0x18
+		i++
+		if m.Valid {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Logs) > 0 {
+		for _, msg := range m.Logs {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Downlink) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Downlink)))
+		i += copy(dAtA[i:], m.Downlink)
+	}
+	return i, nil
 }
-func (m *DeviceList) Size() (n int) {
+
+func (m *DryDownlinkResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DryDownlinkResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Devices) > 0 {
-		for _, e := range m.Devices {
-			l = e.Size()
-			n += 1 + l + sovHandler(uint64(l))
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if len(m.Logs) > 0 {
+		for _, msg := range m.Logs {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *DryDownlinkMessage) Size() (n int) {
+func encodeFixed64Handler(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Handler(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintHandler(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *DeviceActivationResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = len(m.Payload)
 	if l > 0 {
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.Fields)
-	if l > 0 {
+	if m.Message != nil {
+		l = m.Message.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if m.App != nil {
-		l = m.App.Size()
+	if m.DownlinkOption != nil {
+		l = m.DownlinkOption.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if m.Port != 0 {
-		n += 1 + sovHandler(uint64(m.Port))
+	if m.ActivationMetadata != nil {
+		l = m.ActivationMetadata.Size()
+		n += 2 + l + sovHandler(uint64(l))
+	}
+	if m.Trace != nil {
+		l = m.Trace.Size()
+		n += 2 + l + sovHandler(uint64(l))
 	}
 	return n
 }
 
-func (m *DryUplinkMessage) Size() (n int) {
+func (m *StatusRequest) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.App != nil {
-		l = m.App.Size()
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.Port != 0 {
-		n += 1 + sovHandler(uint64(m.Port))
-	}
 	return n
 }
 
-func (m *SimulatedUplinkMessage) Size() (n int) {
+func (m *Status) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.AppId)
-	if l > 0 {
+	if m.System != nil {
+		l = m.System.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.DevId)
-	if l > 0 {
+	if m.Component != nil {
+		l = m.Component.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.Payload)
-	if l > 0 {
+	if m.Uplink != nil {
+		l = m.Uplink.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if m.Port != 0 {
-		n += 1 + sovHandler(uint64(m.Port))
+	if m.Downlink != nil {
+		l = m.Downlink.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Activations != nil {
+		l = m.Activations.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.Integrations) > 0 {
+		for _, e := range m.Integrations {
+			l = e.Size()
+			n += 2 + l + sovHandler(uint64(l))
+		}
 	}
 	return n
 }
 
-func (m *LogEntry) Size() (n int) {
+func (m *IntegrationStatus) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Function)
+	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if len(m.Fields) > 0 {
-		for _, s := range m.Fields {
-			l = len(s)
-			n += 1 + l + sovHandler(uint64(l))
-		}
+	if m.Uplink != nil {
+		l = m.Uplink.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Activations != nil {
+		l = m.Activations.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Events != nil {
+		l = m.Events.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Errors != nil {
+		l = m.Errors.Size()
+		n += 1 + l + sovHandler(uint64(l))
 	}
 	return n
 }
 
-func (m *DryUplinkResult) Size() (n int) {
+func (m *ApplicationIdentifier) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Payload)
+	l = len(m.AppId)
 	if l > 0 {
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.Fields)
+	return n
+}
+
+func (m *Application) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
 	if l > 0 {
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if m.Valid {
+	l = len(m.Decoder)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Converter)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Validator)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Encoder)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.HttpIntegration != nil {
+		l = m.HttpIntegration.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.DisabledIntegrations) > 0 {
+		for _, s := range m.DisabledIntegrations {
+			l = len(s)
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	l = len(m.PayloadFormat)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.ExposeMetadata {
 		n += 2
 	}
-	if len(m.Logs) > 0 {
-		for _, e := range m.Logs {
+	l = len(m.AdrAlgorithm)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.AdrDataRate)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *HTTPIntegration) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UplinkUrl)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.ActivationUrl)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.EventUrl)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for k, v := range m.Headers {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovHandler(uint64(len(k))) + 1 + len(v) + sovHandler(uint64(len(v)))
+			n += mapEntrySize + 1 + sovHandler(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *DeviceIdentifier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *Device) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Device != nil {
+		n += m.Device.Size()
+	}
+	if m.Latitude != 0 {
+		n += 5
+	}
+	if m.Longitude != 0 {
+		n += 5
+	}
+	if m.Altitude != 0 {
+		n += 1 + sovHandler(uint64(m.Altitude))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 2 + l + sovHandler(uint64(l))
+	}
+	if m.PayloadFunctions != nil {
+		l = m.PayloadFunctions.Size()
+		n += 2 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *Device_LorawanDevice) Size() (n int) {
+	var l int
+	_ = l
+	if m.LorawanDevice != nil {
+		l = m.LorawanDevice.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+func (m *PayloadFunctions) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Decoder)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Converter)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Validator)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Encoder)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DeviceList) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
 			l = e.Size()
 			n += 1 + l + sovHandler(uint64(l))
 		}
@@ -2137,36 +3605,2237 @@ func (m *DryUplinkResult) Size() (n int) {
 	return n
 }
 
-func (m *DryDownlinkResult) Size() (n int) {
+func (m *QueuedDownlink) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Payload)
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	if m.Confirmed {
+		n += 2
+	}
+	l = len(m.PayloadRaw)
 	if l > 0 {
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if len(m.Logs) > 0 {
-		for _, e := range m.Logs {
+	l = len(m.PayloadFields)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Expires != 0 {
+		n += 1 + sovHandler(uint64(m.Expires))
+	}
+	l = len(m.CorrelationId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DownlinkQueue) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Downlinks) > 0 {
+		for _, e := range m.Downlinks {
 			l = e.Size()
 			n += 1 + l + sovHandler(uint64(l))
 		}
 	}
+	if m.Current != nil {
+		l = m.Current.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
 	return n
 }
 
-func sovHandler(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *QueuedDownlinkIdentifier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovHandler(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *MulticastGroupIdentifier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.GroupId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *MulticastGroup) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.GroupId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.DevAddr != nil {
+		l = m.DevAddr.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.NwkSKey != nil {
+		l = m.NwkSKey.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.AppSKey != nil {
+		l = m.AppSKey.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.FCntDown != 0 {
+		n += 1 + sovHandler(uint64(m.FCntDown))
+	}
+	if len(m.GatewayIds) > 0 {
+		for _, s := range m.GatewayIds {
+			l = len(s)
+			n += 1 + l + sovHandler(uint64(l))
 		}
 	}
 	return n
 }
-func sozHandler(x uint64) (n int) {
-	return sovHandler(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *MulticastGroupList) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Groups) > 0 {
+		for _, e := range m.Groups {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
 }
-func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
+
+func (m *MulticastDownlinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.GroupId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	l = len(m.PayloadRaw)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.PayloadFields)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DryDownlinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.App != nil {
+		l = m.App.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	if m.DevicePayloadFunctions != nil {
+		l = m.DevicePayloadFunctions.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DryUplinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.App != nil {
+		l = m.App.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	if m.DevicePayloadFunctions != nil {
+		l = m.DevicePayloadFunctions.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *SimulatedUplinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *LogEntry) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Function)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DryUplinkResult) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Valid {
+		n += 2
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	l = len(m.Downlink)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DryDownlinkResult) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovHandler(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozHandler(x uint64) (n int) {
+	return sovHandler(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceActivationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &protocol.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkOption", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DownlinkOption == nil {
+				m.DownlinkOption = &broker.DownlinkOption{}
+			}
+			if err := m.DownlinkOption.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationMetadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActivationMetadata == nil {
+				m.ActivationMetadata = &protocol.ActivationMetadata{}
+			}
+			if err := m.ActivationMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Status) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field System", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.System == nil {
+				m.System = &api.SystemStats{}
+			}
+			if err := m.System.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Component", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Component == nil {
+				m.Component = &api.ComponentStats{}
+			}
+			if err := m.Component.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Uplink == nil {
+				m.Uplink = &api.Rates{}
+			}
+			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Downlink == nil {
+				m.Downlink = &api.Rates{}
+			}
+			if err := m.Downlink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Activations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Activations == nil {
+				m.Activations = &api.Rates{}
+			}
+			if err := m.Activations.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Integrations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Integrations = append(m.Integrations, &IntegrationStatus{})
+			if err := m.Integrations[len(m.Integrations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IntegrationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IntegrationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IntegrationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Uplink == nil {
+				m.Uplink = &api.Rates{}
+			}
+			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Activations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Activations == nil {
+				m.Activations = &api.Rates{}
+			}
+			if err := m.Activations.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Events == nil {
+				m.Events = &api.Rates{}
+			}
+			if err := m.Events.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Errors == nil {
+				m.Errors = &api.Rates{}
+			}
+			if err := m.Errors.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Application) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Application: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Application: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decoder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Decoder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Converter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Converter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Encoder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HttpIntegration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HttpIntegration == nil {
+				m.HttpIntegration = &HTTPIntegration{}
+			}
+			if err := m.HttpIntegration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisabledIntegrations", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisabledIntegrations = append(m.DisabledIntegrations, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadFormat", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PayloadFormat = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExposeMetadata", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExposeMetadata = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdrAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdrAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdrDataRate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdrDataRate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HTTPIntegration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HTTPIntegration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HTTPIntegration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UplinkUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UplinkUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Headers == nil {
+				m.Headers = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowHandler
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHandler
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthHandler
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowHandler
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthHandler
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipHandler(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthHandler
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Headers[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LorawanDevice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &lorawan1.Device{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Device = &Device_LorawanDevice{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latitude", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 4
+			v = uint32(dAtA[iNdEx-4])
+			v |= uint32(dAtA[iNdEx-3]) << 8
+			v |= uint32(dAtA[iNdEx-2]) << 16
+			v |= uint32(dAtA[iNdEx-1]) << 24
+			m.Latitude = float32(math.Float32frombits(v))
+		case 11:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Longitude", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 4
+			v = uint32(dAtA[iNdEx-4])
+			v |= uint32(dAtA[iNdEx-3]) << 8
+			v |= uint32(dAtA[iNdEx-2]) << 16
+			v |= uint32(dAtA[iNdEx-1]) << 24
+			m.Longitude = float32(math.Float32frombits(v))
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Altitude", wireType)
+			}
+			m.Altitude = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Altitude |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 30:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadFunctions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PayloadFunctions == nil {
+				m.PayloadFunctions = &PayloadFunctions{}
+			}
+			if err := m.PayloadFunctions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PayloadFunctions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PayloadFunctions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PayloadFunctions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decoder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Decoder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Converter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Converter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Encoder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceList) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2189,17 +5858,17 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceActivationResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeviceList: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeviceList: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2209,28 +5878,78 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueuedDownlink) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueuedDownlink: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueuedDownlink: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2240,30 +5959,36 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Port |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Message == nil {
-				m.Message = &protocol.Message{}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Confirmed", wireType)
 			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 11:
+			m.Confirmed = bool(v != 0)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkOption", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadRaw", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2273,30 +5998,28 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DownlinkOption == nil {
-				m.DownlinkOption = &broker.DownlinkOption{}
-			}
-			if err := m.DownlinkOption.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.PayloadRaw = append(m.PayloadRaw[:0], dAtA[iNdEx:postIndex]...)
+			if m.PayloadRaw == nil {
+				m.PayloadRaw = []byte{}
 			}
 			iNdEx = postIndex
-		case 23:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationMetadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadFields", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2306,30 +6029,45 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ActivationMetadata == nil {
-				m.ActivationMetadata = &protocol.ActivationMetadata{}
+			m.PayloadFields = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
 			}
-			if err := m.ActivationMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Expires = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Expires |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 31:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CorrelationId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2339,24 +6077,20 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Trace == nil {
-				m.Trace = &trace.Trace{}
-			}
-			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.CorrelationId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2379,57 +6113,7 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StatusRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHandler
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHandler(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthHandler
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Status) Unmarshal(dAtA []byte) error {
+func (m *DownlinkQueue) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2452,15 +6136,15 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+			return fmt.Errorf("proto: DownlinkQueue: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DownlinkQueue: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field System", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlinks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2484,16 +6168,14 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.System == nil {
-				m.System = &api.SystemStats{}
-			}
-			if err := m.System.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Downlinks = append(m.Downlinks, &QueuedDownlink{})
+			if err := m.Downlinks[len(m.Downlinks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Component", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Current", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2513,22 +6195,72 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 			if msglen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Current == nil {
+				m.Current = &QueuedDownlink{}
+			}
+			if err := m.Current.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueuedDownlinkIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Component == nil {
-				m.Component = &api.ComponentStats{}
-			}
-			if err := m.Component.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 11:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueuedDownlinkIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueuedDownlinkIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2538,30 +6270,26 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Uplink == nil {
-				m.Uplink = &api.Rates{}
-			}
-			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2571,30 +6299,26 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Downlink == nil {
-				m.Downlink = &api.Rates{}
-			}
-			if err := m.Downlink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 13:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Activations", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2604,25 +6328,11 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Index |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Activations == nil {
-				m.Activations = &api.Rates{}
-			}
-			if err := m.Activations.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -2644,7 +6354,7 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
+func (m *MulticastGroupIdentifier) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2667,10 +6377,10 @@ func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ApplicationIdentifier: wiretype end group for non-group")
+			return fmt.Errorf("proto: MulticastGroupIdentifier: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ApplicationIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MulticastGroupIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2702,6 +6412,35 @@ func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
 			}
 			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -2723,7 +6462,7 @@ func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Application) Unmarshal(dAtA []byte) error {
+func (m *MulticastGroup) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2746,10 +6485,10 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Application: wiretype end group for non-group")
+			return fmt.Errorf("proto: MulticastGroup: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Application: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MulticastGroup: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2783,7 +6522,7 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Decoder", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2808,11 +6547,11 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Decoder = string(dAtA[iNdEx:postIndex])
+			m.GroupId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Converter", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2837,13 +6576,13 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Converter = string(dAtA[iNdEx:postIndex])
+			m.Description = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2853,24 +6592,110 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Validator = string(dAtA[iNdEx:postIndex])
+			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
+			m.DevAddr = &v
+			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Encoder", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NwkSKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.NwkSKey
+			m.NwkSKey = &v
+			if err := m.NwkSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppSKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppSKey
+			m.AppSKey = &v
+			if err := m.AppSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntDown", wireType)
+			}
+			m.FCntDown = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FCntDown |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2895,7 +6720,7 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Encoder = string(dAtA[iNdEx:postIndex])
+			m.GatewayIds = append(m.GatewayIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2918,7 +6743,7 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+func (m *MulticastGroupList) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2940,47 +6765,18 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 		}
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AppId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+		if wireType == 4 {
+			return fmt.Errorf("proto: MulticastGroupList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MulticastGroupList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2990,20 +6786,22 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DevId = string(dAtA[iNdEx:postIndex])
+			m.Groups = append(m.Groups, &MulticastGroup{})
+			if err := m.Groups[len(m.Groups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3026,7 +6824,7 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Device) Unmarshal(dAtA []byte) error {
+func (m *MulticastDownlinkMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3049,10 +6847,10 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+			return fmt.Errorf("proto: MulticastDownlinkMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MulticastDownlinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3086,7 +6884,7 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3111,73 +6909,13 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DevId = string(dAtA[iNdEx:postIndex])
+			m.GroupId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LorawanDevice", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &lorawan1.Device{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Device = &Device_LorawanDevice{v}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 5 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Latitude", wireType)
-			}
-			var v uint32
-			if (iNdEx + 4) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += 4
-			v = uint32(dAtA[iNdEx-4])
-			v |= uint32(dAtA[iNdEx-3]) << 8
-			v |= uint32(dAtA[iNdEx-2]) << 16
-			v |= uint32(dAtA[iNdEx-1]) << 24
-			m.Latitude = float32(math.Float32frombits(v))
-		case 11:
-			if wireType != 5 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Longitude", wireType)
-			}
-			var v uint32
-			if (iNdEx + 4) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += 4
-			v = uint32(dAtA[iNdEx-4])
-			v |= uint32(dAtA[iNdEx-3]) << 8
-			v |= uint32(dAtA[iNdEx-2]) << 16
-			v |= uint32(dAtA[iNdEx-1]) << 24
-			m.Longitude = float32(math.Float32frombits(v))
-		case 12:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Altitude", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
-			m.Altitude = 0
+			m.Port = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3187,16 +6925,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Altitude |= (int32(b) & 0x7F) << shift
+				m.Port |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 20:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadRaw", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3206,76 +6944,28 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHandler(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthHandler
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DeviceList) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHandler
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.PayloadRaw = append(m.PayloadRaw[:0], dAtA[iNdEx:postIndex]...)
+			if m.PayloadRaw == nil {
+				m.PayloadRaw = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceList: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceList: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadFields", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3285,22 +6975,20 @@ func (m *DeviceList) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Devices = append(m.Devices, &Device{})
-			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PayloadFields = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3464,6 +7152,39 @@ func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevicePayloadFunctions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DevicePayloadFunctions == nil {
+				m.DevicePayloadFunctions = &PayloadFunctions{}
+			}
+			if err := m.DevicePayloadFunctions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -3597,6 +7318,39 @@ func (m *DryUplinkMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevicePayloadFunctions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DevicePayloadFunctions == nil {
+				m.DevicePayloadFunctions = &PayloadFunctions{}
+			}
+			if err := m.DevicePayloadFunctions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -4024,6 +7778,35 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Downlink = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -4267,83 +8050,145 @@ func init() {
 }
 
 var fileDescriptorHandler = []byte{
-	// 1248 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x6e, 0xdb, 0x46,
-	0x13, 0xfe, 0xe9, 0x83, 0x2c, 0x8d, 0x6c, 0x39, 0x5e, 0x27, 0xfa, 0x19, 0x39, 0x50, 0x54, 0x06,
-	0x49, 0x1d, 0x27, 0xa0, 0x50, 0xb5, 0x40, 0x93, 0x5c, 0xa4, 0x39, 0x38, 0x07, 0x03, 0x71, 0x0b,
-	0xd0, 0xee, 0x8d, 0x2f, 0x6a, 0xac, 0xc5, 0x31, 0x45, 0x98, 0xe2, 0xb2, 0xe4, 0x4a, 0x86, 0x10,
-	0xa4, 0x28, 0xf2, 0x0a, 0x6d, 0xdf, 0xa0, 0x77, 0x7d, 0x8e, 0x02, 0xbd, 0x2c, 0xd0, 0x07, 0x68,
-	0xe1, 0xf6, 0x09, 0xfa, 0x04, 0x05, 0x77, 0x97, 0x07, 0x9d, 0x6c, 0xab, 0xe8, 0x8d, 0xa4, 0x99,
-	0xef, 0xe3, 0x9c, 0x76, 0x66, 0x96, 0x82, 0x87, 0x8e, 0xcb, 0x3b, 0xbd, 0x23, 0xb3, 0xcd, 0xba,
-	0xcd, 0xfd, 0x0e, 0xee, 0x77, 0x5c, 0xdf, 0x89, 0x3e, 0x47, 0x7e, 0xca, 0xc2, 0x93, 0x26, 0xe7,
-	0x7e, 0x93, 0x06, 0x6e, 0xb3, 0x43, 0x7d, 0xdb, 0xc3, 0x30, 0xf9, 0x36, 0x83, 0x90, 0x71, 0x46,
-	0x96, 0x94, 0x58, 0xdb, 0x70, 0x18, 0x73, 0x3c, 0x6c, 0x0a, 0xf5, 0x51, 0xef, 0xb8, 0x89, 0xdd,
-	0x80, 0x0f, 0x24, 0xab, 0x76, 0x43, 0x81, 0xb1, 0x1d, 0xea, 0xfb, 0x8c, 0x53, 0xee, 0x32, 0x3f,
-	0x52, 0xe8, 0x5a, 0xe2, 0x82, 0x06, 0xae, 0x52, 0x6d, 0x24, 0xaa, 0xa3, 0x90, 0x9d, 0x60, 0xa8,
-	0xbe, 0x14, 0x78, 0x33, 0x01, 0x85, 0xd8, 0x66, 0x5e, 0xfa, 0x43, 0x11, 0x6e, 0x8f, 0x11, 0x3c,
-	0x16, 0xd2, 0x53, 0xea, 0x37, 0x6d, 0xec, 0xbb, 0x6d, 0x54, 0xb4, 0xeb, 0x09, 0x8d, 0x87, 0xb4,
-	0x8d, 0xf2, 0x53, 0x42, 0xc6, 0x0f, 0x73, 0xa0, 0x6f, 0x0b, 0xee, 0xd3, 0x36, 0x77, 0xfb, 0x22,
-	0x5c, 0x0b, 0xa3, 0x80, 0xf9, 0x11, 0x12, 0x1d, 0x96, 0x02, 0x3a, 0xf0, 0x18, 0xb5, 0x75, 0xad,
-	0xa1, 0x6d, 0x2e, 0x5b, 0x89, 0x48, 0xee, 0xc1, 0x52, 0x17, 0xa3, 0x88, 0x3a, 0xa8, 0xcf, 0x35,
-	0xb4, 0xcd, 0x72, 0x6b, 0xcd, 0x4c, 0x43, 0xdb, 0x95, 0x80, 0x95, 0x30, 0xc8, 0x67, 0xb0, 0x6a,
-	0xb3, 0x53, 0xdf, 0x73, 0xfd, 0x93, 0x43, 0x16, 0xc4, 0x1e, 0xf4, 0xb2, 0x78, 0xa8, 0x6a, 0xaa,
-	0x74, 0xb7, 0x15, 0xfc, 0x85, 0x40, 0xad, 0x8a, 0x3d, 0x24, 0x93, 0x5d, 0x58, 0xa7, 0x69, 0x74,
-	0x87, 0x5d, 0xe4, 0xd4, 0xa6, 0x9c, 0xea, 0xff, 0x17, 0x46, 0x6e, 0x64, 0x9e, 0xb3, 0x14, 0x76,
-	0x15, 0xc7, 0x22, 0x74, 0x4c, 0x47, 0x0c, 0x58, 0x14, 0x25, 0xd0, 0x6f, 0x0a, 0x03, 0xcb, 0xa6,
-	0x2c, 0xc8, 0x7e, 0xfc, 0x69, 0x49, 0xc8, 0x58, 0x85, 0x95, 0x3d, 0x4e, 0x79, 0x2f, 0xb2, 0xf0,
-	0xeb, 0x1e, 0x46, 0xdc, 0xf8, 0x5d, 0x83, 0x82, 0xd4, 0x90, 0x4d, 0x28, 0x44, 0x83, 0x88, 0x63,
-	0x57, 0x54, 0xa5, 0xdc, 0xba, 0x62, 0xc6, 0xe7, 0xb9, 0x27, 0x54, 0x31, 0x25, 0xb2, 0x14, 0x4e,
-	0x3e, 0x82, 0x52, 0x9b, 0x75, 0x03, 0xe6, 0xa3, 0xcf, 0x55, 0xa1, 0xd6, 0x05, 0xf9, 0x79, 0xa2,
-	0x95, 0xfc, 0x8c, 0x45, 0x0c, 0x28, 0xf4, 0x82, 0x38, 0x77, 0x55, 0x23, 0x10, 0x7c, 0x8b, 0x72,
-	0x8c, 0x2c, 0x85, 0x90, 0x3b, 0x50, 0x4c, 0x2a, 0xa4, 0x2f, 0x8f, 0xb1, 0x52, 0x8c, 0xdc, 0x87,
-	0x72, 0x96, 0x7e, 0xa4, 0xaf, 0x8c, 0x51, 0xf3, 0xb0, 0x61, 0xc2, 0xb5, 0xa7, 0x41, 0xe0, 0xb9,
-	0x6d, 0x21, 0xef, 0xd8, 0xe8, 0x73, 0xf7, 0xd8, 0xc5, 0x90, 0x5c, 0x83, 0x02, 0x0d, 0x82, 0x43,
-	0x57, 0x76, 0x41, 0xc9, 0x5a, 0xa4, 0x41, 0xb0, 0x63, 0x1b, 0xdf, 0x6b, 0x50, 0xce, 0x3d, 0x30,
-	0x85, 0x16, 0x37, 0x91, 0x8d, 0x6d, 0x66, 0x63, 0x28, 0x2a, 0x50, 0xb2, 0x12, 0x91, 0xdc, 0x88,
-	0xab, 0xe3, 0xf7, 0x31, 0xe4, 0x18, 0xea, 0xf3, 0x02, 0xcb, 0x14, 0x31, 0xda, 0xa7, 0x9e, 0x6b,
-	0x53, 0xce, 0x42, 0x7d, 0x41, 0xa2, 0xa9, 0x22, 0xb6, 0x8a, 0xbe, 0xb4, 0xba, 0x28, 0xad, 0x2a,
-	0xd1, 0x78, 0x02, 0x57, 0x64, 0x43, 0x5f, 0x98, 0x41, 0xac, 0xb6, 0xb1, 0x1f, 0xab, 0x65, 0x64,
-	0x8b, 0x36, 0xf6, 0x77, 0x6c, 0xe3, 0x6f, 0x0d, 0x0a, 0xd2, 0xc4, 0x6c, 0x0f, 0x92, 0x07, 0x50,
-	0x51, 0xf3, 0x77, 0x28, 0xe7, 0x4f, 0x64, 0x55, 0x6e, 0xad, 0x9a, 0x4a, 0x6d, 0x4a, 0xb3, 0xaf,
-	0xff, 0x67, 0xad, 0x28, 0x8d, 0xf2, 0x53, 0x83, 0xa2, 0x47, 0xb9, 0xcb, 0x7b, 0x36, 0xea, 0xd0,
-	0xd0, 0x36, 0xe7, 0xac, 0x54, 0x8e, 0x0b, 0xe1, 0x31, 0xdf, 0x91, 0x60, 0x59, 0x80, 0x99, 0x22,
-	0x7e, 0x92, 0x7a, 0xea, 0xc9, 0xb8, 0x17, 0x16, 0xad, 0x54, 0x26, 0x0d, 0x28, 0xdb, 0x18, 0xb5,
-	0x43, 0x57, 0x0e, 0xdd, 0x55, 0x11, 0x6b, 0x5e, 0xf5, 0xac, 0x28, 0x12, 0x71, 0xdb, 0x68, 0x7c,
-	0x0a, 0x20, 0x63, 0x79, 0xe3, 0x46, 0x9c, 0xdc, 0x8d, 0x0f, 0x2d, 0x96, 0x22, 0x5d, 0x6b, 0xcc,
-	0x8b, 0x14, 0x92, 0x75, 0x28, 0x59, 0x56, 0x82, 0x1b, 0xef, 0x35, 0x20, 0xdb, 0xe1, 0x20, 0x19,
-	0x61, 0x35, 0xfd, 0xe7, 0xec, 0x8e, 0x2a, 0x14, 0x8e, 0x5d, 0xf4, 0xec, 0x48, 0x15, 0x4f, 0x49,
-	0xe4, 0x0e, 0xcc, 0xd3, 0x20, 0x50, 0x25, 0xbb, 0x9a, 0xfa, 0xcb, 0xb5, 0x98, 0x15, 0x13, 0x08,
-	0x81, 0x85, 0x80, 0x85, 0x5c, 0xf4, 0xc4, 0x8a, 0x25, 0x7e, 0x1b, 0x1d, 0xb8, 0xb2, 0x1d, 0x0e,
-	0xbe, 0x0c, 0x2e, 0x17, 0x81, 0xf2, 0x34, 0x77, 0x59, 0x4f, 0xf3, 0x39, 0x4f, 0x1c, 0xaa, 0x7b,
-	0x6e, 0xb7, 0xe7, 0x51, 0x8e, 0xf6, 0xb0, 0xbf, 0xd9, 0x7a, 0x25, 0x17, 0xdd, 0xfc, 0x70, 0x74,
-	0x93, 0xf2, 0x7b, 0x0c, 0xc5, 0x37, 0xcc, 0x79, 0xe1, 0xf3, 0x70, 0x10, 0x9f, 0xf8, 0x71, 0xcf,
-	0x6f, 0x8b, 0x23, 0x95, 0x9e, 0x52, 0x79, 0xa8, 0xb6, 0xf3, 0x59, 0x6d, 0x8d, 0x6f, 0x35, 0x58,
-	0x4d, 0x0b, 0x64, 0x61, 0xd4, 0xf3, 0xf8, 0xbf, 0x38, 0xa1, 0xab, 0xb0, 0x28, 0x26, 0x50, 0x44,
-	0x5c, 0xb4, 0xa4, 0x40, 0x6e, 0xc3, 0x82, 0xc7, 0x9c, 0x48, 0x5f, 0x10, 0x8d, 0xb2, 0x96, 0x96,
-	0x33, 0x09, 0xd8, 0x12, 0xb0, 0xb1, 0x0f, 0x6b, 0xb9, 0x36, 0xb9, 0x30, 0x86, 0xc4, 0xea, 0xdc,
-	0xb9, 0x56, 0x5b, 0x3f, 0x6b, 0xb0, 0xf4, 0x5a, 0x42, 0xe4, 0x2b, 0x58, 0xcf, 0x6e, 0x80, 0xe7,
-	0x1d, 0xea, 0x79, 0xe8, 0x3b, 0x48, 0x8c, 0xe4, 0x96, 0x99, 0x00, 0xaa, 0xed, 0x5e, 0xbb, 0x75,
-	0x2e, 0x47, 0x5d, 0x87, 0x07, 0x50, 0x54, 0x30, 0x92, 0x7b, 0xe9, 0xd5, 0x85, 0x76, 0x4f, 0xb6,
-	0x0d, 0xda, 0xe3, 0x17, 0xa9, 0xb4, 0xfe, 0xc1, 0xc8, 0xf0, 0x8c, 0x5f, 0xb5, 0xad, 0x3f, 0x4b,
-	0x40, 0x72, 0xfd, 0xb7, 0x4b, 0x7d, 0xea, 0x60, 0x48, 0x1c, 0x58, 0xb7, 0xd0, 0x71, 0x23, 0x8e,
-	0x61, 0x7e, 0xd5, 0xd6, 0x27, 0xf5, 0x6c, 0xb6, 0xef, 0x6a, 0x55, 0x53, 0xbe, 0x87, 0x98, 0xc9,
-	0x4b, 0x8a, 0xf9, 0x22, 0x7e, 0x49, 0x31, 0xf4, 0xf7, 0xbf, 0xfd, 0xf5, 0xdd, 0x1c, 0x31, 0x56,
-	0x9a, 0x34, 0x7b, 0x2e, 0x7a, 0xa4, 0x6d, 0x91, 0x63, 0xa8, 0xbc, 0x42, 0x3e, 0x8b, 0x8f, 0x89,
-	0x73, 0x63, 0xd4, 0x85, 0x07, 0x9d, 0x54, 0x87, 0x3c, 0x34, 0xdf, 0xca, 0xc9, 0x78, 0x47, 0xbe,
-	0x81, 0xca, 0xde, 0xb0, 0x9f, 0x89, 0x76, 0xa6, 0x66, 0xf0, 0x58, 0xd8, 0x7f, 0x60, 0x4c, 0xb1,
-	0xff, 0x48, 0xdb, 0x3a, 0xd8, 0xa8, 0x4d, 0x07, 0xc9, 0x09, 0xac, 0x6d, 0xa3, 0x87, 0x1c, 0xff,
-	0x8b, 0x72, 0xaa, 0x64, 0xb7, 0xa6, 0x25, 0xdb, 0x81, 0xd2, 0x2b, 0xe4, 0x6a, 0xc5, 0x5f, 0x1f,
-	0x69, 0x82, 0x9c, 0xfd, 0xd1, 0xe5, 0x6a, 0x34, 0x85, 0xe1, 0xbb, 0xe4, 0xc3, 0xc9, 0x86, 0xd5,
-	0xdb, 0x5d, 0xd4, 0x7c, 0x2b, 0x37, 0xcb, 0x3b, 0x72, 0xa6, 0x41, 0x69, 0x2f, 0x75, 0x35, 0x6a,
-	0x6f, 0x6a, 0x02, 0x3f, 0x69, 0xc2, 0xd1, 0x8f, 0x9a, 0x71, 0x59, 0x4f, 0x71, 0x81, 0xef, 0xd7,
-	0x66, 0x61, 0xdf, 0x32, 0xea, 0xe7, 0xb3, 0x05, 0xa9, 0x76, 0x31, 0x89, 0x84, 0xb0, 0x2c, 0xcf,
-	0xee, 0xe2, 0x8a, 0x4e, 0x4b, 0x58, 0x15, 0x76, 0xeb, 0xd2, 0x85, 0x3d, 0x05, 0x3d, 0x3d, 0xc2,
-	0xe8, 0x25, 0x9b, 0x69, 0x0a, 0xd7, 0x47, 0xe2, 0x8b, 0x6f, 0x56, 0xe3, 0x8e, 0x88, 0xa0, 0x41,
-	0x2e, 0xc8, 0x97, 0xbc, 0x84, 0x72, 0x6e, 0x5d, 0x92, 0x8d, 0xcc, 0xd6, 0xd8, 0x5d, 0x5b, 0xab,
-	0x4d, 0x02, 0xd5, 0x86, 0x7d, 0x02, 0xa5, 0x74, 0xf1, 0xe7, 0x2b, 0x36, 0x72, 0x5b, 0xd6, 0xf4,
-	0x71, 0x48, 0x59, 0xd8, 0x81, 0x4a, 0x72, 0xe3, 0x29, 0x33, 0x37, 0x53, 0xee, 0xe4, 0xab, 0x70,
-	0x5a, 0xf9, 0x5b, 0x2f, 0xa1, 0xa2, 0x96, 0x75, 0xb2, 0xe0, 0x3e, 0x11, 0x23, 0xa2, 0x5e, 0xac,
-	0xab, 0x99, 0xdd, 0xfc, 0xbb, 0x77, 0x6e, 0x3e, 0xa4, 0xfe, 0xd9, 0xc3, 0x5f, 0xce, 0xea, 0xda,
-	0xaf, 0x67, 0x75, 0xed, 0x8f, 0xb3, 0xba, 0x76, 0x70, 0x6f, 0x86, 0x7f, 0x75, 0x47, 0x05, 0x11,
-	0xd2, 0xc7, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xb0, 0x50, 0xc3, 0x0b, 0x0e, 0x00, 0x00,
+	// 2225 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x19, 0x4d, 0x6f, 0x1b, 0xc7,
+	0xb5, 0x4b, 0x4a, 0x14, 0xf9, 0x28, 0x52, 0xd2, 0xd8, 0x56, 0xd6, 0xb4, 0x2b, 0x2b, 0x63, 0xd8,
+	0x51, 0xe4, 0x98, 0x6c, 0xe4, 0x38, 0xb1, 0x0d, 0xd4, 0x8e, 0x6c, 0xf9, 0x43, 0xa8, 0x9d, 0x26,
+	0x2b, 0xf9, 0xe2, 0x43, 0x88, 0x31, 0x77, 0xb4, 0x5a, 0x68, 0xb9, 0xbb, 0x99, 0x1d, 0x4a, 0x26,
+	0x1c, 0x17, 0x45, 0x50, 0x14, 0xe8, 0xad, 0x40, 0xd0, 0x5b, 0xd1, 0x5e, 0x02, 0xb4, 0x40, 0xff,
+	0x41, 0x81, 0x1e, 0x7a, 0x2b, 0xd0, 0x4b, 0x81, 0x1e, 0x0a, 0xf4, 0x50, 0x14, 0x46, 0xff, 0x40,
+	0xff, 0x41, 0x31, 0x1f, 0xfb, 0xc1, 0x8f, 0x95, 0x48, 0xa1, 0x17, 0x69, 0xdf, 0xc7, 0xbc, 0xaf,
+	0x79, 0xef, 0xcd, 0x9b, 0x21, 0xdc, 0x76, 0x5c, 0xbe, 0xdf, 0x7b, 0xd9, 0xec, 0x04, 0xdd, 0xd6,
+	0xee, 0x3e, 0xdd, 0xdd, 0x77, 0x7d, 0x27, 0xfa, 0x8c, 0xf2, 0xa3, 0x80, 0x1d, 0xb4, 0x38, 0xf7,
+	0x5b, 0x24, 0x74, 0x5b, 0xfb, 0xc4, 0xb7, 0x3d, 0xca, 0xe2, 0xff, 0xcd, 0x90, 0x05, 0x3c, 0x40,
+	0x73, 0x1a, 0x6c, 0x5c, 0x70, 0x82, 0xc0, 0xf1, 0x68, 0x4b, 0xa2, 0x5f, 0xf6, 0xf6, 0x5a, 0xb4,
+	0x1b, 0xf2, 0xbe, 0xe2, 0x6a, 0x5c, 0xd4, 0x44, 0x21, 0x87, 0xf8, 0x7e, 0xc0, 0x09, 0x77, 0x03,
+	0x3f, 0xd2, 0xd4, 0xeb, 0x19, 0xf5, 0x4e, 0xe0, 0x04, 0xa9, 0x0c, 0x01, 0x49, 0x40, 0x7e, 0x69,
+	0xf6, 0xa5, 0xd8, 0x22, 0x12, 0xba, 0x1a, 0x75, 0x21, 0x46, 0xbd, 0x64, 0xc1, 0x01, 0x65, 0xfa,
+	0x9f, 0x26, 0x5e, 0x8a, 0x89, 0x12, 0xec, 0x04, 0x5e, 0xf2, 0xa1, 0x19, 0xae, 0x8c, 0x30, 0x78,
+	0x01, 0x23, 0x47, 0xc4, 0x6f, 0xd9, 0xf4, 0xd0, 0xed, 0x50, 0xcd, 0x76, 0x3e, 0x66, 0xe3, 0x8c,
+	0x74, 0xa8, 0xfa, 0xab, 0x48, 0xf8, 0x57, 0x05, 0x30, 0xb7, 0x24, 0xef, 0x66, 0x87, 0xbb, 0x87,
+	0xd2, 0x3b, 0x8b, 0x46, 0x61, 0xe0, 0x47, 0x14, 0x99, 0x30, 0x17, 0x92, 0xbe, 0x17, 0x10, 0xdb,
+	0x34, 0x56, 0x8d, 0xb5, 0x79, 0x2b, 0x06, 0xd1, 0x35, 0x98, 0xeb, 0xd2, 0x28, 0x22, 0x0e, 0x35,
+	0x0b, 0xab, 0xc6, 0x5a, 0x75, 0x63, 0xa9, 0x99, 0x98, 0xf6, 0x4c, 0x11, 0xac, 0x98, 0x03, 0xdd,
+	0x83, 0x05, 0x3b, 0x38, 0xf2, 0x3d, 0xd7, 0x3f, 0x68, 0x07, 0xa1, 0xd0, 0x60, 0x56, 0xe5, 0xa2,
+	0xe5, 0xa6, 0x76, 0x77, 0x4b, 0x93, 0x7f, 0x2c, 0xa9, 0x56, 0xdd, 0x1e, 0x80, 0xd1, 0x33, 0x38,
+	0x43, 0x12, 0xeb, 0xda, 0x5d, 0xca, 0x89, 0x4d, 0x38, 0x31, 0xdf, 0x91, 0x42, 0x2e, 0xa6, 0x9a,
+	0x53, 0x17, 0x9e, 0x69, 0x1e, 0x0b, 0x91, 0x11, 0x1c, 0xc2, 0x30, 0x2b, 0x43, 0x60, 0x5e, 0x92,
+	0x02, 0xe6, 0x9b, 0x12, 0x6a, 0xee, 0x8a, 0xbf, 0x96, 0x22, 0xe1, 0x05, 0xa8, 0xed, 0x70, 0xc2,
+	0x7b, 0x91, 0x45, 0xbf, 0xea, 0xd1, 0x88, 0xe3, 0xdf, 0x16, 0xa0, 0xa4, 0x30, 0x68, 0x0d, 0x4a,
+	0x51, 0x3f, 0xe2, 0xb4, 0x2b, 0xa3, 0x52, 0xdd, 0x58, 0x6c, 0x8a, 0xfd, 0xdc, 0x91, 0x28, 0xc1,
+	0x12, 0x59, 0x9a, 0x8e, 0x3e, 0x84, 0x4a, 0x27, 0xe8, 0x86, 0x81, 0x4f, 0x7d, 0xae, 0x03, 0x75,
+	0x46, 0x32, 0x3f, 0x88, 0xb1, 0x8a, 0x3f, 0xe5, 0x42, 0x18, 0x4a, 0xbd, 0x50, 0xf8, 0xae, 0x63,
+	0x04, 0x92, 0xdf, 0x22, 0x9c, 0x46, 0x96, 0xa6, 0xa0, 0xab, 0x50, 0x8e, 0x23, 0x64, 0xce, 0x8f,
+	0x70, 0x25, 0x34, 0xf4, 0x01, 0x54, 0x53, 0xf7, 0x23, 0xb3, 0x36, 0xc2, 0x9a, 0x25, 0xa3, 0xbb,
+	0x30, 0xef, 0xfa, 0x9c, 0x3a, 0x4c, 0xb3, 0x9f, 0x5b, 0x2d, 0xae, 0x55, 0x37, 0x1a, 0xcd, 0xb8,
+	0x6c, 0xb6, 0x53, 0xa2, 0x0e, 0xcd, 0x00, 0x3f, 0xfe, 0xb3, 0x01, 0x4b, 0x23, 0x3c, 0x08, 0xc1,
+	0x8c, 0x4f, 0xba, 0x54, 0x86, 0xaa, 0x62, 0xc9, 0xef, 0x89, 0x7c, 0x1c, 0xb2, 0x7d, 0xfe, 0x78,
+	0xdb, 0x31, 0x94, 0xe8, 0x21, 0xf5, 0xf9, 0x38, 0x27, 0x35, 0x45, 0xf2, 0x30, 0x16, 0xb0, 0xc8,
+	0xac, 0x8f, 0xe1, 0x91, 0x14, 0xdc, 0x84, 0x73, 0x9b, 0x61, 0xe8, 0xb9, 0x1d, 0x29, 0x77, 0xdb,
+	0xa6, 0x3e, 0x77, 0xf7, 0x5c, 0xca, 0xd0, 0x39, 0x28, 0x91, 0x30, 0x6c, 0xbb, 0xb6, 0x76, 0x64,
+	0x96, 0x84, 0xe1, 0xb6, 0x8d, 0x7f, 0x57, 0x84, 0x6a, 0x66, 0x41, 0x0e, 0x9b, 0x28, 0x24, 0x9b,
+	0x76, 0x02, 0x9b, 0x32, 0x99, 0x05, 0x15, 0x2b, 0x06, 0xd1, 0x45, 0x91, 0x21, 0xfe, 0x21, 0x65,
+	0x9c, 0x32, 0xb3, 0x28, 0x69, 0x29, 0x42, 0x50, 0x0f, 0x89, 0xe7, 0xda, 0x84, 0x07, 0xcc, 0x9c,
+	0x51, 0xd4, 0x04, 0x21, 0xa4, 0x52, 0x5f, 0x49, 0x9d, 0x55, 0x52, 0x35, 0x88, 0x1e, 0xc0, 0xe2,
+	0x3e, 0xe7, 0x61, 0x3b, 0xb3, 0x3f, 0x66, 0x49, 0x3a, 0x6d, 0x26, 0xdb, 0xf9, 0x64, 0x77, 0xf7,
+	0xf3, 0xcc, 0x76, 0x59, 0x0b, 0x62, 0x45, 0x06, 0x81, 0x6e, 0xc0, 0x39, 0xdb, 0x8d, 0xc8, 0x4b,
+	0x8f, 0xda, 0xed, 0x81, 0xc4, 0x98, 0x5b, 0x2d, 0xae, 0x55, 0xac, 0xb3, 0x31, 0x31, 0xb3, 0x26,
+	0x42, 0x57, 0xa0, 0xae, 0x7b, 0x44, 0x7b, 0x2f, 0x60, 0x5d, 0xc2, 0xcd, 0xb2, 0x34, 0xad, 0xa6,
+	0xb1, 0x8f, 0x24, 0x12, 0xbd, 0x07, 0x0b, 0xf4, 0x55, 0x18, 0x44, 0x34, 0xad, 0xe6, 0xca, 0xaa,
+	0xb1, 0x56, 0xb6, 0xea, 0x0a, 0x9d, 0xd4, 0xea, 0x65, 0xa8, 0x11, 0x9b, 0xb5, 0x89, 0xe7, 0x04,
+	0xcc, 0xe5, 0xfb, 0x5d, 0x13, 0xa4, 0xb8, 0x79, 0x62, 0xb3, 0xcd, 0x18, 0x87, 0xb0, 0x62, 0x12,
+	0x0b, 0xda, 0x8c, 0x70, 0x2a, 0xd3, 0xaa, 0x62, 0x55, 0x89, 0xcd, 0xb6, 0x44, 0x13, 0x20, 0x9c,
+	0xe2, 0xff, 0x1a, 0xb0, 0x30, 0xe4, 0x32, 0xfa, 0x3e, 0x80, 0xca, 0xb6, 0x76, 0x8f, 0x79, 0x7a,
+	0xc7, 0x2a, 0x0a, 0xf3, 0x9c, 0x79, 0xc2, 0x97, 0x4c, 0xdb, 0x11, 0x2c, 0x6a, 0xf3, 0x6a, 0x29,
+	0x56, 0xb0, 0x5d, 0x80, 0x8a, 0xcc, 0x30, 0xc9, 0xa1, 0xb6, 0xb0, 0x2c, 0x11, 0x82, 0x78, 0x0f,
+	0xe6, 0xf6, 0x29, 0xb1, 0x29, 0x8b, 0xcc, 0x19, 0x59, 0x4f, 0x57, 0xf2, 0x36, 0xa0, 0xf9, 0x44,
+	0xf1, 0x3d, 0xf4, 0x39, 0xeb, 0x5b, 0xf1, 0xaa, 0xc6, 0x1d, 0x98, 0xcf, 0x12, 0xd0, 0x22, 0x14,
+	0x0f, 0x68, 0x5f, 0x1b, 0x2b, 0x3e, 0xd1, 0x59, 0x98, 0x3d, 0x24, 0x5e, 0x8f, 0x6a, 0xeb, 0x14,
+	0x70, 0xa7, 0x70, 0xcb, 0xc0, 0x9f, 0xc2, 0xa2, 0xea, 0xed, 0x27, 0x26, 0xb2, 0x40, 0xdb, 0xf4,
+	0x50, 0xa0, 0xb5, 0x14, 0x9b, 0x1e, 0x6e, 0xdb, 0xf8, 0x8f, 0x05, 0x28, 0x29, 0x11, 0xd3, 0x2d,
+	0x44, 0xb7, 0xa0, 0xae, 0x8f, 0xa2, 0xb6, 0x3a, 0x8a, 0x64, 0x64, 0xaa, 0x1b, 0x0b, 0x4d, 0x8d,
+	0x6e, 0x2a, 0xb1, 0x4f, 0xbe, 0x67, 0xd5, 0x34, 0x46, 0xeb, 0x69, 0x40, 0xd9, 0x23, 0xdc, 0xe5,
+	0x3d, 0x9b, 0xca, 0xcd, 0x2e, 0x58, 0x09, 0x2c, 0xea, 0xc1, 0x0b, 0x7c, 0x47, 0x11, 0xab, 0x92,
+	0x98, 0x22, 0xc4, 0x4a, 0xe2, 0xe9, 0x95, 0xa2, 0x5f, 0xcc, 0x5a, 0x09, 0x8c, 0x56, 0xa1, 0x6a,
+	0xd3, 0xa8, 0xc3, 0x5c, 0x75, 0xfe, 0x9c, 0x55, 0x09, 0x92, 0x41, 0xa1, 0x47, 0xb0, 0x94, 0x64,
+	0x6e, 0xcf, 0xef, 0xa8, 0x54, 0x5f, 0x91, 0x46, 0x9f, 0x4f, 0xf6, 0xec, 0x73, 0x9d, 0xc5, 0x31,
+	0x83, 0xb5, 0x18, 0x0e, 0x61, 0xee, 0x97, 0x65, 0x40, 0xdc, 0x0e, 0xc5, 0xdf, 0x18, 0xb0, 0x38,
+	0xbc, 0x20, 0xdb, 0x0a, 0x8c, 0x63, 0x5a, 0x41, 0xe1, 0xd8, 0x56, 0x50, 0x3c, 0xa6, 0x15, 0xcc,
+	0x0c, 0xb4, 0x02, 0xfc, 0x09, 0x80, 0x0a, 0xec, 0x53, 0x37, 0xe2, 0xe8, 0x7d, 0xa1, 0x5d, 0x40,
+	0x91, 0x69, 0xc8, 0x74, 0x5c, 0x48, 0x5c, 0x53, 0x5c, 0x56, 0x4c, 0xc7, 0x7f, 0x35, 0xa0, 0xfe,
+	0x45, 0x8f, 0xf6, 0xa8, 0x1d, 0x9f, 0xce, 0xa2, 0x97, 0x87, 0x01, 0xe3, 0xd2, 0xf0, 0x9a, 0x25,
+	0xbf, 0xb5, 0xd5, 0x7b, 0x2e, 0xeb, 0x52, 0x95, 0x02, 0x65, 0x2b, 0x45, 0xa0, 0x4b, 0x50, 0x8d,
+	0x83, 0xca, 0xc8, 0x91, 0xb4, 0x7b, 0xde, 0x02, 0x8d, 0xb2, 0xc8, 0xd1, 0x40, 0xbf, 0x70, 0xa9,
+	0x67, 0x47, 0xe6, 0xcc, 0x60, 0xbf, 0x90, 0x48, 0xe9, 0xdf, 0xab, 0xd0, 0x65, 0x34, 0x92, 0xad,
+	0xae, 0x68, 0xc5, 0xa0, 0x10, 0xd0, 0x09, 0x18, 0xa3, 0x9e, 0xaa, 0x52, 0xd7, 0x96, 0x8d, 0xae,
+	0x62, 0xd5, 0x32, 0xd8, 0x6d, 0x1b, 0xf7, 0xa1, 0x16, 0xbb, 0x21, 0x9d, 0x42, 0x37, 0xa1, 0x12,
+	0x9f, 0x93, 0x71, 0x2c, 0xde, 0x49, 0x62, 0x31, 0xe8, 0xb7, 0x95, 0x72, 0xa2, 0x0f, 0x61, 0xae,
+	0xd3, 0x63, 0x2c, 0x3d, 0xcf, 0x73, 0x17, 0xc5, 0x7c, 0xf8, 0x4b, 0x30, 0x07, 0x49, 0xa7, 0xad,
+	0x46, 0x51, 0xe9, 0xae, 0x6f, 0xd3, 0x57, 0x32, 0x8e, 0x35, 0x4b, 0x01, 0xf8, 0x29, 0x98, 0xcf,
+	0x7a, 0x1e, 0x77, 0x3b, 0x24, 0xe2, 0x8f, 0x59, 0xd0, 0x0b, 0x4f, 0x96, 0x7f, 0x1e, 0xca, 0x8e,
+	0xe0, 0x4c, 0x35, 0xcc, 0x39, 0x6a, 0x25, 0xfe, 0x4d, 0x11, 0xea, 0x83, 0xe2, 0xa6, 0x17, 0x32,
+	0x5c, 0x6d, 0xc5, 0xd1, 0x6a, 0xfb, 0x02, 0xca, 0xc2, 0x43, 0x62, 0xdb, 0x2a, 0x63, 0xe7, 0xef,
+	0x7f, 0xfc, 0xcf, 0x7f, 0x5d, 0xda, 0x38, 0x69, 0x9c, 0xef, 0x04, 0x8c, 0xb6, 0x78, 0x3f, 0xa4,
+	0x91, 0xc8, 0xd7, 0x4d, 0xdb, 0x66, 0x32, 0x61, 0xc5, 0x07, 0xb2, 0xa0, 0xe2, 0x1f, 0x1d, 0xb4,
+	0xa3, 0xb6, 0xe8, 0x8f, 0xb3, 0xa7, 0x92, 0xf9, 0xd9, 0xd1, 0xc1, 0xce, 0x8f, 0x68, 0xdf, 0x9a,
+	0xf3, 0xd5, 0x87, 0x90, 0x29, 0x5c, 0x57, 0x32, 0x4b, 0xa7, 0x92, 0xb9, 0x19, 0x86, 0x4a, 0x26,
+	0x51, 0x1f, 0xe8, 0x22, 0xc0, 0x5e, 0xbb, 0xe3, 0xf3, 0xb6, 0xc8, 0x2a, 0x73, 0x4e, 0x6e, 0x65,
+	0x79, 0xef, 0x81, 0xcf, 0x45, 0x7e, 0x88, 0x8a, 0x71, 0x08, 0xa7, 0x47, 0xa4, 0xdf, 0x76, 0xed,
+	0xc8, 0x2c, 0xcb, 0xb3, 0x16, 0x34, 0x6a, 0xdb, 0x8e, 0xf0, 0x43, 0x40, 0x83, 0xfb, 0x23, 0x0b,
+	0xbb, 0x05, 0x25, 0x19, 0xfc, 0xd1, 0x5c, 0x1e, 0x64, 0xb6, 0x34, 0x1b, 0xfe, 0xbd, 0x91, 0x49,
+	0x9b, 0x38, 0x33, 0xf5, 0xe8, 0x7e, 0x8a, 0x1d, 0x8f, 0x5b, 0x43, 0x31, 0xd3, 0x1a, 0x86, 0x8a,
+	0x7f, 0x66, 0x82, 0xe2, 0x9f, 0x1d, 0x53, 0xfc, 0xf8, 0x1f, 0x06, 0xa0, 0x2d, 0xd6, 0x1f, 0x36,
+	0x32, 0xff, 0x76, 0xb2, 0x0c, 0x25, 0x2d, 0x4f, 0x59, 0xa9, 0x21, 0x74, 0x15, 0x8a, 0x24, 0x0c,
+	0xf5, 0x49, 0x74, 0x36, 0x89, 0x50, 0x66, 0x80, 0xb3, 0x04, 0x43, 0xe2, 0xcc, 0x4c, 0xc6, 0x99,
+	0x1d, 0x30, 0x55, 0x67, 0x6c, 0x8f, 0x9e, 0x12, 0xb3, 0x27, 0x9d, 0x12, 0xcb, 0x6a, 0xe9, 0x30,
+	0x1e, 0xff, 0xc9, 0x80, 0xc5, 0x2d, 0xd6, 0x7f, 0x1e, 0x4e, 0xe6, 0x97, 0xb6, 0xbf, 0x30, 0xa9,
+	0xfd, 0xc5, 0x09, 0xed, 0x9f, 0x39, 0xad, 0xfd, 0x1c, 0x96, 0x77, 0xdc, 0x6e, 0xcf, 0x23, 0x9c,
+	0xda, 0xcf, 0xc3, 0x09, 0x32, 0x28, 0xa7, 0xb1, 0x65, 0x5c, 0x2e, 0x0e, 0xba, 0x3c, 0x66, 0x2b,
+	0xf0, 0x5d, 0x28, 0x3f, 0x0d, 0x1c, 0x35, 0x0e, 0x35, 0xa0, 0x1c, 0xfb, 0xa1, 0x35, 0x25, 0xf0,
+	0x40, 0x1a, 0x14, 0xd3, 0x34, 0xc0, 0xbf, 0x36, 0x60, 0x21, 0x89, 0xba, 0x45, 0xa3, 0x9e, 0xc7,
+	0x4f, 0x91, 0x4c, 0x6a, 0xec, 0x72, 0x95, 0xc5, 0x65, 0x4b, 0x01, 0xe8, 0x0a, 0xcc, 0x78, 0x81,
+	0x13, 0x0f, 0x7b, 0x4b, 0x49, 0x48, 0x63, 0x83, 0x2d, 0x49, 0x16, 0x66, 0x27, 0x37, 0x38, 0x95,
+	0xf3, 0x09, 0x8c, 0x77, 0x61, 0x29, 0x93, 0xed, 0x27, 0xda, 0x17, 0x6b, 0x2c, 0x1c, 0xab, 0x71,
+	0xe3, 0xa7, 0x05, 0x98, 0x7b, 0xa2, 0x48, 0xe8, 0x4b, 0x38, 0x93, 0x5e, 0x95, 0x1f, 0xec, 0x13,
+	0xcf, 0xa3, 0xbe, 0x43, 0x11, 0x8e, 0xaf, 0xe3, 0x63, 0x88, 0xfa, 0x1a, 0xdc, 0xb8, 0x7c, 0x2c,
+	0x8f, 0x7e, 0x37, 0x78, 0x01, 0x65, 0x4d, 0xa6, 0xe8, 0x5a, 0x72, 0xc7, 0xa7, 0x76, 0x4f, 0xe5,
+	0x29, 0xb5, 0x47, 0x5f, 0x1c, 0x94, 0xf4, 0x77, 0x87, 0xa6, 0x91, 0x31, 0x6f, 0x12, 0xb7, 0x61,
+	0x76, 0xf7, 0xd5, 0x66, 0xe7, 0x00, 0x99, 0xb1, 0x60, 0x09, 0xfa, 0xc1, 0x91, 0x47, 0x6d, 0xa7,
+	0x4b, 0x7d, 0xde, 0x58, 0x6e, 0xaa, 0x47, 0x9b, 0x66, 0xfc, 0x1a, 0xd3, 0x7c, 0x28, 0x5e, 0x74,
+	0x36, 0xbe, 0x43, 0x80, 0x32, 0xb5, 0xf2, 0x8c, 0xf8, 0xc4, 0xa1, 0x0c, 0x39, 0x70, 0xc6, 0xa2,
+	0x8e, 0x1b, 0x71, 0xca, 0x32, 0x54, 0xb4, 0x32, 0xae, 0xbe, 0xd2, 0xa3, 0x35, 0x4f, 0x0b, 0x36,
+	0xbf, 0xf9, 0xfb, 0x7f, 0xbe, 0x2d, 0x20, 0x5c, 0x6b, 0x91, 0x74, 0x5d, 0x74, 0xc7, 0x58, 0x47,
+	0x7b, 0x50, 0x7f, 0x4c, 0xf9, 0x34, 0x3a, 0xc6, 0xd6, 0x38, 0x5e, 0x91, 0x1a, 0x4c, 0xb4, 0x3c,
+	0xa0, 0xa1, 0xf5, 0x5a, 0x15, 0xdc, 0x1b, 0xf4, 0x13, 0xa8, 0xef, 0x0c, 0xea, 0x19, 0x2b, 0x27,
+	0xd7, 0x83, 0xbb, 0x52, 0xfe, 0xad, 0x3b, 0xc6, 0xfa, 0x8b, 0x0b, 0x77, 0x8c, 0xf5, 0x46, 0x8e,
+	0x1e, 0x9c, 0xa7, 0xff, 0x00, 0x96, 0xb6, 0xa8, 0x47, 0x39, 0xfd, 0x7f, 0x84, 0x53, 0x3b, 0xbb,
+	0x9e, 0xa7, 0x6c, 0x1f, 0x2a, 0x8f, 0x29, 0xd7, 0x77, 0x87, 0xf3, 0x43, 0xf9, 0x93, 0x91, 0x3f,
+	0x3c, 0xe8, 0xe2, 0x96, 0x14, 0xfc, 0x3e, 0x7a, 0x6f, 0xbc, 0x60, 0xfd, 0x82, 0x16, 0xb5, 0x5e,
+	0xab, 0x86, 0xf5, 0x06, 0xbd, 0x35, 0xa0, 0xb2, 0x93, 0xa8, 0x1a, 0x96, 0x97, 0xeb, 0xc0, 0x1f,
+	0x0c, 0xa9, 0xe8, 0x3b, 0x43, 0xc4, 0xf3, 0x03, 0x11, 0xcf, 0x49, 0x35, 0xbe, 0xb8, 0x8c, 0x57,
+	0x8e, 0x67, 0x15, 0x22, 0x2f, 0x37, 0x4e, 0x66, 0xc2, 0x13, 0x3b, 0xc9, 0x60, 0x5e, 0xed, 0xdd,
+	0xc9, 0x11, 0xcd, 0x73, 0x58, 0x07, 0x76, 0x7d, 0x62, 0x9d, 0x47, 0x60, 0x26, 0x5b, 0x18, 0x3d,
+	0x0a, 0xa6, 0xaa, 0xc2, 0x33, 0x43, 0xf6, 0x89, 0x61, 0x08, 0x5f, 0x95, 0x16, 0xac, 0xa2, 0x13,
+	0x02, 0x83, 0xbe, 0x86, 0x45, 0xa1, 0x78, 0xe0, 0x5e, 0x70, 0xac, 0xc3, 0x09, 0x29, 0xbb, 0x04,
+	0xdf, 0x94, 0xea, 0x5a, 0xe8, 0xfa, 0x84, 0x0e, 0xb7, 0xbe, 0x92, 0x9a, 0x7e, 0x69, 0xc0, 0x59,
+	0x15, 0xeb, 0xa1, 0x6b, 0xd6, 0xbb, 0x39, 0x57, 0x8a, 0x09, 0x62, 0xff, 0x43, 0x69, 0xca, 0x27,
+	0xeb, 0x37, 0xa7, 0x32, 0xa5, 0xf5, 0x5a, 0xde, 0x24, 0x44, 0xe7, 0x40, 0x0f, 0x3c, 0x4a, 0xd8,
+	0x14, 0x21, 0x19, 0x6f, 0x87, 0x0e, 0xc9, 0xfa, 0x94, 0x21, 0xf9, 0x99, 0x01, 0x4b, 0x8f, 0x29,
+	0x1f, 0xba, 0x7f, 0xbc, 0x9b, 0x33, 0xcb, 0x66, 0xec, 0xc8, 0x1b, 0x77, 0xf1, 0x0d, 0x69, 0xc8,
+	0x75, 0x74, 0x2d, 0xc7, 0x90, 0x6e, 0xcc, 0xde, 0x7a, 0x1d, 0x8f, 0xb6, 0x6f, 0xd0, 0xd7, 0xb0,
+	0xb4, 0x33, 0x62, 0x45, 0x9e, 0x8a, 0xdc, 0x18, 0x7c, 0x2c, 0x55, 0xff, 0x00, 0x4f, 0xa3, 0x5a,
+	0x1c, 0x13, 0x3f, 0x4f, 0xf2, 0x62, 0xfa, 0x38, 0xe4, 0xd9, 0xa2, 0xc3, 0xb0, 0x3e, 0x55, 0x18,
+	0x7e, 0x61, 0xc0, 0xea, 0xc8, 0x6e, 0x4c, 0x5b, 0xa0, 0x17, 0x72, 0x8c, 0x96, 0x85, 0xba, 0x26,
+	0xcd, 0xc2, 0x68, 0xf5, 0x24, 0xb3, 0xd0, 0xb7, 0x06, 0x9c, 0xdb, 0xa1, 0xbe, 0x3d, 0x72, 0x65,
+	0x19, 0x17, 0x95, 0xa1, 0x9b, 0x42, 0x6e, 0x54, 0xee, 0x49, 0xf5, 0xb7, 0xf1, 0x47, 0x53, 0x44,
+	0xa5, 0x15, 0xcf, 0x69, 0x62, 0xab, 0x1e, 0x41, 0x35, 0x33, 0xaa, 0xa1, 0xd4, 0xd7, 0xd1, 0xeb,
+	0x4a, 0xa3, 0x31, 0x8e, 0xa8, 0xa7, 0xbb, 0x4f, 0xa1, 0x92, 0x0c, 0xa4, 0xd9, 0x72, 0x1b, 0xba,
+	0x1a, 0x34, 0xcc, 0x51, 0x92, 0x96, 0xb0, 0x0d, 0xf5, 0x78, 0x12, 0xd7, 0x62, 0x2e, 0x25, 0xbc,
+	0xe3, 0x47, 0xf4, 0xdc, 0x31, 0xe9, 0x11, 0xd4, 0xf5, 0xa0, 0x18, 0x4f, 0x48, 0x1f, 0xc9, 0x33,
+	0x56, 0x3f, 0xe8, 0xa7, 0x5d, 0x70, 0xe0, 0x07, 0x92, 0xc6, 0xc2, 0x10, 0xfe, 0xfe, 0xed, 0xbf,
+	0xbc, 0x5d, 0x31, 0xfe, 0xf6, 0x76, 0xc5, 0xf8, 0xf7, 0xdb, 0x15, 0xe3, 0xc5, 0xb5, 0x29, 0x7e,
+	0xa9, 0x7b, 0x59, 0x92, 0x26, 0xdd, 0xf8, 0xdf, 0x00, 0xf7, 0x9b, 0x20, 0xd4, 0xdf, 0x1b, 0x00,
+	0x00,
 }
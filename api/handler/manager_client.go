@@ -127,6 +127,27 @@ func (h *ManagerClient) DeleteDevice(appID string, devID string) error {
 	return errors.Wrap(errors.FromGRPCError(err), "Could not delete device from Handler")
 }
 
+// GetDownlinkQueue retrieves the downlink queue of a device from the Handler
+func (h *ManagerClient) GetDownlinkQueue(appID string, devID string) (*DownlinkQueue, error) {
+	res, err := h.applicationManagerClient.GetDownlinkQueue(h.GetContext(), &DeviceIdentifier{AppId: appID, DevId: devID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not get downlink queue from Handler")
+	}
+	return res, nil
+}
+
+// DeleteQueuedDownlink deletes the message at the given index from the downlink queue of a device
+func (h *ManagerClient) DeleteQueuedDownlink(appID string, devID string, index uint32) error {
+	_, err := h.applicationManagerClient.DeleteQueuedDownlink(h.GetContext(), &QueuedDownlinkIdentifier{AppId: appID, DevId: devID, Index: index})
+	return errors.Wrap(errors.FromGRPCError(err), "Could not delete downlink from Handler")
+}
+
+// ClearDownlinkQueue deletes all messages from the downlink queue of a device
+func (h *ManagerClient) ClearDownlinkQueue(appID string, devID string) error {
+	_, err := h.applicationManagerClient.ClearDownlinkQueue(h.GetContext(), &DeviceIdentifier{AppId: appID, DevId: devID})
+	return errors.Wrap(errors.FromGRPCError(err), "Could not clear downlink queue on Handler")
+}
+
 // GetDevicesForApplication retrieves all devices for an application from the Handler.
 // Pass a limit to indicate the maximum number of results you want to receive, and the offset to indicate how many results should be skipped.
 func (h *ManagerClient) GetDevicesForApplication(appID string, limit, offset int) (devices []*Device, err error) {
@@ -140,6 +161,43 @@ func (h *ManagerClient) GetDevicesForApplication(appID string, limit, offset int
 	return
 }
 
+// GetMulticastGroup retrieves a multicast group from the Handler
+func (h *ManagerClient) GetMulticastGroup(appID string, groupID string) (*MulticastGroup, error) {
+	res, err := h.applicationManagerClient.GetMulticastGroup(h.GetContext(), &MulticastGroupIdentifier{AppId: appID, GroupId: groupID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not get multicast group from Handler")
+	}
+	return res, nil
+}
+
+// SetMulticastGroup sets a multicast group on the Handler
+func (h *ManagerClient) SetMulticastGroup(in *MulticastGroup) error {
+	_, err := h.applicationManagerClient.SetMulticastGroup(h.GetContext(), in)
+	return errors.Wrap(errors.FromGRPCError(err), "Could not set multicast group on Handler")
+}
+
+// DeleteMulticastGroup deletes a multicast group from the Handler
+func (h *ManagerClient) DeleteMulticastGroup(appID string, groupID string) error {
+	_, err := h.applicationManagerClient.DeleteMulticastGroup(h.GetContext(), &MulticastGroupIdentifier{AppId: appID, GroupId: groupID})
+	return errors.Wrap(errors.FromGRPCError(err), "Could not delete multicast group from Handler")
+}
+
+// GetMulticastGroupsForApplication retrieves all multicast groups for an application from the Handler.
+// Pass a limit to indicate the maximum number of results you want to receive, and the offset to indicate how many results should be skipped.
+func (h *ManagerClient) GetMulticastGroupsForApplication(appID string, limit, offset int) ([]*MulticastGroup, error) {
+	res, err := h.applicationManagerClient.GetMulticastGroupsForApplication(h.GetContextWithLimitAndOffset(limit, offset), &ApplicationIdentifier{AppId: appID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not get multicast groups for application from Handler")
+	}
+	return res.Groups, nil
+}
+
+// SendMulticastDownlink sends a downlink message to all devices in a multicast group
+func (h *ManagerClient) SendMulticastDownlink(in *MulticastDownlinkMessage) error {
+	_, err := h.applicationManagerClient.SendMulticastDownlink(h.GetContext(), in)
+	return errors.Wrap(errors.FromGRPCError(err), "Could not send multicast downlink on Handler")
+}
+
 // GetDevAddr requests a random device address with the given constraints
 func (h *ManagerClient) GetDevAddr(constraints ...string) (types.DevAddr, error) {
 	devAddrManager := lorawan.NewDevAddrManagerClient(h.conn)
@@ -4,7 +4,10 @@
 package handler
 
 import (
+	"net/url"
+
 	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
 
@@ -37,6 +40,39 @@ func (m *Application) Validate() error {
 	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
 		return err
 	}
+	if m.HttpIntegration != nil {
+		if err := m.HttpIntegration.Validate(); err != nil {
+			return errors.NewErrInvalidArgument("HttpIntegration", err.Error())
+		}
+	}
+	switch m.PayloadFormat {
+	case "", "custom", "cayennelpp":
+	default:
+		return errors.NewErrInvalidArgument("PayloadFormat", "must be custom or cayennelpp")
+	}
+	if m.AdrDataRate != "" {
+		if _, err := types.ParseDataRate(m.AdrDataRate); err != nil {
+			return errors.NewErrInvalidArgument("AdrDataRate", err.Error())
+		}
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *HTTPIntegration) Validate() error {
+	for argument, value := range map[string]string{
+		"UplinkUrl":     m.UplinkUrl,
+		"ActivationUrl": m.ActivationUrl,
+		"EventUrl":      m.EventUrl,
+	} {
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.NewErrInvalidArgument(argument, "must be a valid HTTP(S) URL")
+		}
+	}
 	return nil
 }
 
@@ -51,6 +87,65 @@ func (m *DeviceIdentifier) Validate() error {
 	return nil
 }
 
+// Validate implements the api.Validator interface
+func (m *QueuedDownlinkIdentifier) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if err := api.NotEmptyAndValidID(m.DevId, "DevId"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *MulticastGroupIdentifier) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if err := api.NotEmptyAndValidID(m.GroupId, "GroupId"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *MulticastGroup) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if err := api.NotEmptyAndValidID(m.GroupId, "GroupId"); err != nil {
+		return err
+	}
+	if m.DevAddr == nil || m.DevAddr.IsEmpty() {
+		return errors.NewErrInvalidArgument("DevAddr", "can not be empty")
+	}
+	if m.NwkSKey == nil || m.NwkSKey.IsEmpty() {
+		return errors.NewErrInvalidArgument("NwkSKey", "can not be empty")
+	}
+	if m.AppSKey == nil || m.AppSKey.IsEmpty() {
+		return errors.NewErrInvalidArgument("AppSKey", "can not be empty")
+	}
+	if len(m.GatewayIds) == 0 {
+		return errors.NewErrInvalidArgument("GatewayIds", "can not be empty")
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *MulticastDownlinkMessage) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if err := api.NotEmptyAndValidID(m.GroupId, "GroupId"); err != nil {
+		return err
+	}
+	if len(m.PayloadRaw) > 0 && m.PayloadFields != "" {
+		return errors.NewErrInvalidArgument("Downlink", "Both Fields and Payload provided")
+	}
+	return nil
+}
+
 // Validate implements the api.Validator interface
 func (m *Device) Validate() error {
 	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
@@ -13,6 +13,8 @@
 		DevicesResponse
 		StatusRequest
 		Status
+		ImportSessionsResponse
+		ExportSessionsRequest
 */
 package networkserver
 
@@ -91,12 +93,15 @@ func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorNetwor
 
 // message Status is the response to the StatusRequest
 type Status struct {
-	System            *api.SystemStats    `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
-	Component         *api.ComponentStats `protobuf:"bytes,2,opt,name=component" json:"component,omitempty"`
-	Uplink            *api.Rates          `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
-	Downlink          *api.Rates          `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
-	Activations       *api.Rates          `protobuf:"bytes,13,opt,name=activations" json:"activations,omitempty"`
-	DevicesPerAddress *api.Percentiles    `protobuf:"bytes,21,opt,name=devices_per_address,json=devicesPerAddress" json:"devices_per_address,omitempty"`
+	System      *api.SystemStats    `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
+	Component   *api.ComponentStats `protobuf:"bytes,2,opt,name=component" json:"component,omitempty"`
+	Uplink      *api.Rates          `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
+	Downlink    *api.Rates          `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
+	Activations *api.Rates          `protobuf:"bytes,13,opt,name=activations" json:"activations,omitempty"`
+	// The number of devices that the Broker has to check for each uplink message
+	DevicesPerAddress *api.Percentiles `protobuf:"bytes,21,opt,name=devices_per_address,json=devicesPerAddress" json:"devices_per_address,omitempty"`
+	// The rate of DevAddrs that were allocated while they were already in use
+	DevAddrCollisions *api.Rates `protobuf:"bytes,22,opt,name=dev_addr_collisions,json=devAddrCollisions" json:"dev_addr_collisions,omitempty"`
 }
 
 func (m *Status) Reset()                    { *m = Status{} }
@@ -146,11 +151,60 @@ func (m *Status) GetDevicesPerAddress() *api.Percentiles {
 	return nil
 }
 
+func (m *Status) GetDevAddrCollisions() *api.Rates {
+	if m != nil {
+		return m.DevAddrCollisions
+	}
+	return nil
+}
+
+// message ImportSessionsResponse is the response to ImportSessions
+type ImportSessionsResponse struct {
+	// The number of imported sessions
+	Imported uint32 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+}
+
+func (m *ImportSessionsResponse) Reset()         { *m = ImportSessionsResponse{} }
+func (m *ImportSessionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportSessionsResponse) ProtoMessage()    {}
+func (*ImportSessionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorNetworkserver, []int{4}
+}
+
+func (m *ImportSessionsResponse) GetImported() uint32 {
+	if m != nil {
+		return m.Imported
+	}
+	return 0
+}
+
+// message ExportSessionsRequest is used to export the sessions of the devices in this NetworkServer
+type ExportSessionsRequest struct {
+	// Only export the sessions of the devices of this application (optional)
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+}
+
+func (m *ExportSessionsRequest) Reset()         { *m = ExportSessionsRequest{} }
+func (m *ExportSessionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportSessionsRequest) ProtoMessage()    {}
+func (*ExportSessionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorNetworkserver, []int{5}
+}
+
+func (m *ExportSessionsRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*DevicesRequest)(nil), "networkserver.DevicesRequest")
 	proto.RegisterType((*DevicesResponse)(nil), "networkserver.DevicesResponse")
 	proto.RegisterType((*StatusRequest)(nil), "networkserver.StatusRequest")
 	proto.RegisterType((*Status)(nil), "networkserver.Status")
+	proto.RegisterType((*ImportSessionsResponse)(nil), "networkserver.ImportSessionsResponse")
+	proto.RegisterType((*ExportSessionsRequest)(nil), "networkserver.ExportSessionsRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -371,6 +425,10 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 
 type NetworkServerManagerClient interface {
 	GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Status, error)
+	// Import device sessions, for example when migrating from another LoRaWAN server. Existing devices are replaced.
+	ImportSessions(ctx context.Context, opts ...grpc.CallOption) (NetworkServerManager_ImportSessionsClient, error)
+	// Export device sessions, for example for backups
+	ExportSessions(ctx context.Context, in *ExportSessionsRequest, opts ...grpc.CallOption) (NetworkServerManager_ExportSessionsClient, error)
 }
 
 type networkServerManagerClient struct {
@@ -390,10 +448,80 @@ func (c *networkServerManagerClient) GetStatus(ctx context.Context, in *StatusRe
 	return out, nil
 }
 
+func (c *networkServerManagerClient) ImportSessions(ctx context.Context, opts ...grpc.CallOption) (NetworkServerManager_ImportSessionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NetworkServerManager_serviceDesc.Streams[0], c.cc, "/networkserver.NetworkServerManager/ImportSessions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerManagerImportSessionsClient{stream}
+	return x, nil
+}
+
+type NetworkServerManager_ImportSessionsClient interface {
+	Send(*lorawan.Device) error
+	CloseAndRecv() (*ImportSessionsResponse, error)
+	grpc.ClientStream
+}
+
+type networkServerManagerImportSessionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerManagerImportSessionsClient) Send(m *lorawan.Device) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *networkServerManagerImportSessionsClient) CloseAndRecv() (*ImportSessionsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportSessionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerManagerClient) ExportSessions(ctx context.Context, in *ExportSessionsRequest, opts ...grpc.CallOption) (NetworkServerManager_ExportSessionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NetworkServerManager_serviceDesc.Streams[1], c.cc, "/networkserver.NetworkServerManager/ExportSessions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerManagerExportSessionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServerManager_ExportSessionsClient interface {
+	Recv() (*lorawan.Device, error)
+	grpc.ClientStream
+}
+
+type networkServerManagerExportSessionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerManagerExportSessionsClient) Recv() (*lorawan.Device, error) {
+	m := new(lorawan.Device)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for NetworkServerManager service
 
 type NetworkServerManagerServer interface {
 	GetStatus(context.Context, *StatusRequest) (*Status, error)
+	// Import device sessions, for example when migrating from another LoRaWAN server. Existing devices are replaced.
+	ImportSessions(NetworkServerManager_ImportSessionsServer) error
+	// Export device sessions, for example for backups
+	ExportSessions(*ExportSessionsRequest, NetworkServerManager_ExportSessionsServer) error
 }
 
 func RegisterNetworkServerManagerServer(s *grpc.Server, srv NetworkServerManagerServer) {
@@ -418,6 +546,53 @@ func _NetworkServerManager_GetStatus_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServerManager_ImportSessions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NetworkServerManagerServer).ImportSessions(&networkServerManagerImportSessionsServer{stream})
+}
+
+type NetworkServerManager_ImportSessionsServer interface {
+	SendAndClose(*ImportSessionsResponse) error
+	Recv() (*lorawan.Device, error)
+	grpc.ServerStream
+}
+
+type networkServerManagerImportSessionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerManagerImportSessionsServer) SendAndClose(m *ImportSessionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *networkServerManagerImportSessionsServer) Recv() (*lorawan.Device, error) {
+	m := new(lorawan.Device)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _NetworkServerManager_ExportSessions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSessionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerManagerServer).ExportSessions(m, &networkServerManagerExportSessionsServer{stream})
+}
+
+type NetworkServerManager_ExportSessionsServer interface {
+	Send(*lorawan.Device) error
+	grpc.ServerStream
+}
+
+type networkServerManagerExportSessionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerManagerExportSessionsServer) Send(m *lorawan.Device) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _NetworkServerManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "networkserver.NetworkServerManager",
 	HandlerType: (*NetworkServerManagerServer)(nil),
@@ -427,7 +602,18 @@ var _NetworkServerManager_serviceDesc = grpc.ServiceDesc{
 			Handler:    _NetworkServerManager_GetStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportSessions",
+			Handler:       _NetworkServerManager_ImportSessions_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportSessions",
+			Handler:       _NetworkServerManager_ExportSessions_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/networkserver/networkserver.proto",
 }
 
@@ -589,6 +775,65 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if m.DevAddrCollisions != nil {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintNetworkserver(dAtA, i, uint64(m.DevAddrCollisions.Size()))
+		n8, err := m.DevAddrCollisions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	return i, nil
+}
+
+func (m *ImportSessionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportSessionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Imported != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintNetworkserver(dAtA, i, uint64(m.Imported))
+	}
+	return i, nil
+}
+
+func (m *ExportSessionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportSessionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNetworkserver(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
 	return i, nil
 }
 
@@ -677,6 +922,29 @@ func (m *Status) Size() (n int) {
 		l = m.DevicesPerAddress.Size()
 		n += 2 + l + sovNetworkserver(uint64(l))
 	}
+	if m.DevAddrCollisions != nil {
+		l = m.DevAddrCollisions.Size()
+		n += 2 + l + sovNetworkserver(uint64(l))
+	}
+	return n
+}
+
+func (m *ImportSessionsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Imported != 0 {
+		n += 1 + sovNetworkserver(uint64(m.Imported))
+	}
+	return n
+}
+
+func (m *ExportSessionsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovNetworkserver(uint64(l))
+	}
 	return n
 }
 
@@ -1152,6 +1420,187 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddrCollisions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetworkserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetworkserver
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DevAddrCollisions == nil {
+				m.DevAddrCollisions = &api.Rates{}
+			}
+			if err := m.DevAddrCollisions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetworkserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNetworkserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportSessionsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetworkserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportSessionsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportSessionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Imported", wireType)
+			}
+			m.Imported = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetworkserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Imported |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetworkserver(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNetworkserver
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportSessionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetworkserver
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportSessionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportSessionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetworkserver
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNetworkserver
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNetworkserver(dAtA[iNdEx:])
@@ -1283,43 +1732,50 @@ func init() {
 }
 
 var fileDescriptorNetworkserver = []byte{
-	// 603 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0x5d, 0x4f, 0x13, 0x4f,
-	0x14, 0xc6, 0xb3, 0xf0, 0xff, 0x97, 0x72, 0x4a, 0x45, 0x06, 0x89, 0x4d, 0x95, 0x0a, 0x4d, 0x34,
-	0x35, 0xea, 0x6e, 0xa8, 0x89, 0x57, 0x24, 0xf2, 0x52, 0xc3, 0x85, 0x81, 0xd4, 0x05, 0x13, 0xe3,
-	0x0d, 0x99, 0xee, 0x1e, 0xda, 0x0d, 0xdb, 0x99, 0x75, 0x66, 0xb6, 0xc8, 0xc7, 0xf1, 0xda, 0x2f,
-	0xe2, 0xa5, 0xd7, 0x5e, 0x18, 0xc3, 0x27, 0x31, 0x9d, 0x97, 0xc2, 0x0a, 0xa4, 0xe1, 0x6a, 0xf7,
-	0x3c, 0xcf, 0x6f, 0x67, 0xce, 0xce, 0x73, 0x76, 0xe1, 0x5d, 0x3f, 0x51, 0x83, 0xbc, 0xe7, 0x47,
-	0x7c, 0x18, 0x1c, 0x0d, 0xf0, 0x68, 0x90, 0xb0, 0xbe, 0x3c, 0x40, 0x75, 0xc6, 0xc5, 0x69, 0xa0,
-	0x14, 0x0b, 0x68, 0x96, 0x04, 0xcc, 0xd4, 0x12, 0xc5, 0x08, 0x45, 0xb1, 0xf2, 0x33, 0xc1, 0x15,
-	0x27, 0xd5, 0x82, 0x58, 0x7f, 0x75, 0x65, 0xd5, 0x3e, 0xef, 0xf3, 0x40, 0x53, 0xbd, 0xfc, 0x44,
-	0x57, 0xba, 0xd0, 0x77, 0xe6, 0xe9, 0xfa, 0x92, 0xdb, 0x88, 0x66, 0x89, 0x95, 0x9e, 0x3a, 0x49,
-	0x97, 0x11, 0x4f, 0x83, 0x94, 0x0b, 0x7a, 0x46, 0x59, 0x10, 0xe3, 0x28, 0x89, 0xd0, 0x62, 0x8f,
-	0x1c, 0xd6, 0x13, 0xfc, 0x14, 0x85, 0xbd, 0x58, 0x73, 0xd5, 0x99, 0x03, 0xca, 0xe2, 0x14, 0x85,
-	0xbb, 0x1a, 0xbb, 0xf9, 0x15, 0xee, 0x75, 0xf4, 0x5a, 0x32, 0xc4, 0x2f, 0x39, 0x4a, 0x45, 0x3e,
-	0x40, 0x39, 0xc6, 0xd1, 0x31, 0x8d, 0x63, 0x51, 0xf3, 0xd6, 0xbc, 0xd6, 0xc2, 0xce, 0x9b, 0x5f,
-	0xbf, 0x9f, 0xb4, 0xa7, 0x1d, 0x51, 0xc4, 0x05, 0x06, 0xea, 0x3c, 0x43, 0xe9, 0x77, 0x70, 0xb4,
-	0x1d, 0xc7, 0x22, 0x9c, 0x8b, 0xcd, 0x0d, 0x59, 0x86, 0xff, 0x4f, 0x8e, 0x23, 0xa6, 0x6a, 0x33,
-	0x6b, 0x5e, 0xab, 0x1a, 0xfe, 0x77, 0xb2, 0xcb, 0x54, 0x73, 0x13, 0x16, 0x27, 0x3b, 0xcb, 0x8c,
-	0x33, 0x89, 0xe4, 0x39, 0xcc, 0x09, 0x94, 0x79, 0xaa, 0x64, 0xcd, 0x5b, 0x9b, 0x6d, 0x55, 0xda,
-	0x8b, 0xbe, 0x7d, 0x61, 0xdf, 0xa0, 0xa1, 0xf3, 0x9b, 0x8b, 0x50, 0x3d, 0x54, 0x54, 0xe5, 0xae,
-	0xed, 0xe6, 0xb7, 0x19, 0x28, 0x19, 0x85, 0xb4, 0xa0, 0x24, 0xcf, 0xa5, 0xc2, 0xa1, 0xee, 0xbf,
-	0xd2, 0xbe, 0xef, 0x8f, 0x8f, 0xf4, 0x50, 0x4b, 0x63, 0x44, 0x86, 0xd6, 0x27, 0x1b, 0x30, 0x1f,
-	0xf1, 0x61, 0xc6, 0x19, 0xda, 0xe6, 0x2a, 0xed, 0x65, 0x0d, 0xef, 0x3a, 0xd5, 0xf0, 0x97, 0x14,
-	0x69, 0x42, 0x29, 0xcf, 0xd2, 0x84, 0x9d, 0xd6, 0x2a, 0x9a, 0x07, 0xcd, 0x87, 0x54, 0xa1, 0x0c,
-	0xad, 0x43, 0x9e, 0x41, 0x39, 0xe6, 0x67, 0x4c, 0x53, 0x0b, 0xd7, 0xa8, 0x89, 0x47, 0x5e, 0x42,
-	0x85, 0x46, 0x2a, 0x19, 0x51, 0x95, 0x70, 0x26, 0x6b, 0xd5, 0x6b, 0xe8, 0x55, 0x9b, 0x6c, 0xc1,
-	0xb2, 0x89, 0x5d, 0x1e, 0x67, 0x28, 0x74, 0x40, 0x28, 0x65, 0x6d, 0xe5, 0xca, 0x3b, 0x76, 0x51,
-	0x44, 0xc8, 0x54, 0x92, 0xa2, 0x0c, 0x97, 0x2c, 0xdc, 0x45, 0xb1, 0x6d, 0xd0, 0xf6, 0xf7, 0x59,
-	0xa8, 0xda, 0xcc, 0x0e, 0xf5, 0x8c, 0x92, 0xf7, 0x00, 0x7b, 0xa8, 0x6c, 0x0e, 0x64, 0xd5, 0x2f,
-	0x8e, 0x75, 0x71, 0x32, 0xea, 0x8d, 0xdb, 0x6c, 0x1b, 0xdf, 0x10, 0x96, 0xba, 0x02, 0x33, 0x2a,
-	0x70, 0x7b, 0xd2, 0x36, 0x79, 0xe1, 0xdb, 0x71, 0xec, 0x60, 0x3c, 0x3e, 0x9e, 0x88, 0x2a, 0x8c,
-	0xcd, 0x93, 0x97, 0x94, 0xdb, 0xe1, 0x2e, 0x30, 0xe9, 0x42, 0xd9, 0x8a, 0x48, 0xd6, 0x7d, 0x37,
-	0xd6, 0xd7, 0x69, 0xd3, 0x5d, 0x7d, 0x3a, 0x42, 0x0e, 0xa0, 0xf4, 0xd1, 0x24, 0xb8, 0x7e, 0x53,
-	0x23, 0xc6, 0xdb, 0x47, 0x29, 0x69, 0x7f, 0xbc, 0xde, 0x34, 0x84, 0x6c, 0x42, 0xb9, 0xe3, 0xb2,
-	0x7e, 0x38, 0xc1, 0xad, 0xe2, 0xd6, 0xb9, 0xcd, 0x68, 0x7f, 0x82, 0x07, 0x85, 0xb0, 0xf6, 0x29,
-	0xa3, 0x7d, 0x14, 0x64, 0x0b, 0xe6, 0xf7, 0x50, 0xd9, 0x59, 0x7f, 0xfc, 0x4f, 0x26, 0x85, 0x8f,
-	0xa2, 0xbe, 0x72, 0xa3, 0xbb, 0xf3, 0xf6, 0xc7, 0x45, 0xc3, 0xfb, 0x79, 0xd1, 0xf0, 0xfe, 0x5c,
-	0x34, 0xbc, 0xcf, 0x1b, 0x77, 0xfe, 0xfb, 0xf5, 0x4a, 0xfa, 0xe7, 0xf1, 0xfa, 0x6f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x4f, 0x28, 0xd8, 0x5e, 0x39, 0x05, 0x00, 0x00,
+	// 709 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0xdd, 0x4e, 0xdb, 0x4a,
+	0x10, 0xc7, 0x65, 0x38, 0x84, 0x30, 0x21, 0x70, 0xb2, 0x9c, 0x70, 0x22, 0xb7, 0xa4, 0x10, 0x95,
+	0x2a, 0x55, 0x5b, 0xbb, 0xa4, 0x55, 0x2f, 0x2a, 0xa4, 0xf2, 0x11, 0x84, 0x68, 0x05, 0x4a, 0x1d,
+	0x7a, 0xd3, 0x9b, 0x68, 0x63, 0x0f, 0x89, 0x85, 0xe3, 0x75, 0x77, 0x37, 0x01, 0x9e, 0xa9, 0x2f,
+	0xd2, 0xcb, 0x5e, 0x57, 0x6a, 0x55, 0xf1, 0x02, 0x7d, 0x85, 0x8a, 0xf5, 0x3a, 0xe0, 0x24, 0x08,
+	0x71, 0x15, 0xcf, 0xfc, 0x7f, 0xb3, 0x33, 0xde, 0x99, 0x71, 0x60, 0xaf, 0xe3, 0xcb, 0x6e, 0xbf,
+	0x6d, 0xb9, 0xac, 0x67, 0x1f, 0x77, 0xf1, 0xb8, 0xeb, 0x87, 0x1d, 0x71, 0x84, 0xf2, 0x8c, 0xf1,
+	0x53, 0x5b, 0xca, 0xd0, 0xa6, 0x91, 0x6f, 0x87, 0xb1, 0x2d, 0x90, 0x0f, 0x90, 0xa7, 0x2d, 0x2b,
+	0xe2, 0x4c, 0x32, 0x92, 0x4f, 0x39, 0xcd, 0x17, 0x37, 0x4e, 0xed, 0xb0, 0x0e, 0xb3, 0x15, 0xd5,
+	0xee, 0x9f, 0x28, 0x4b, 0x19, 0xea, 0x29, 0x8e, 0x36, 0x0b, 0x49, 0x22, 0x1a, 0xf9, 0xda, 0xb5,
+	0x9e, 0xb8, 0x94, 0xe9, 0xb2, 0xc0, 0x0e, 0x18, 0xa7, 0x67, 0x34, 0xb4, 0x3d, 0x1c, 0xf8, 0x2e,
+	0x6a, 0xec, 0x41, 0x82, 0xb5, 0x39, 0x3b, 0x45, 0xae, 0x7f, 0xb4, 0xb8, 0x92, 0x88, 0x5d, 0x1a,
+	0x7a, 0x01, 0xf2, 0xe4, 0x37, 0x96, 0x2b, 0xe7, 0xb0, 0x50, 0x57, 0x67, 0x09, 0x07, 0xbf, 0xf4,
+	0x51, 0x48, 0xf2, 0x11, 0xb2, 0x1e, 0x0e, 0x5a, 0xd4, 0xf3, 0x78, 0xc9, 0x58, 0x35, 0xaa, 0xf3,
+	0x3b, 0x6f, 0x7e, 0xfc, 0x7a, 0x54, 0xbb, 0xeb, 0x8a, 0x5c, 0xc6, 0xd1, 0x96, 0x17, 0x11, 0x0a,
+	0xab, 0x8e, 0x83, 0x6d, 0xcf, 0xe3, 0xce, 0xac, 0x17, 0x3f, 0x90, 0x25, 0x98, 0x39, 0x69, 0xb9,
+	0xa1, 0x2c, 0x4d, 0xad, 0x1a, 0xd5, 0xbc, 0xf3, 0xcf, 0xc9, 0x6e, 0x28, 0x2b, 0x9b, 0xb0, 0x38,
+	0xcc, 0x2c, 0x22, 0x16, 0x0a, 0x24, 0x4f, 0x61, 0x96, 0xa3, 0xe8, 0x07, 0x52, 0x94, 0x8c, 0xd5,
+	0xe9, 0x6a, 0xae, 0xb6, 0x68, 0xe9, 0x17, 0xb6, 0x62, 0xd4, 0x49, 0xf4, 0xca, 0x22, 0xe4, 0x9b,
+	0x92, 0xca, 0x7e, 0x52, 0x76, 0xe5, 0xe7, 0x14, 0x64, 0x62, 0x0f, 0xa9, 0x42, 0x46, 0x5c, 0x08,
+	0x89, 0x3d, 0x55, 0x7f, 0xae, 0xf6, 0xaf, 0x75, 0x75, 0xa5, 0x4d, 0xe5, 0xba, 0x42, 0x84, 0xa3,
+	0x75, 0xb2, 0x01, 0x73, 0x2e, 0xeb, 0x45, 0x2c, 0x44, 0x5d, 0x5c, 0xae, 0xb6, 0xa4, 0xe0, 0xdd,
+	0xc4, 0x1b, 0xf3, 0xd7, 0x14, 0xa9, 0x40, 0xa6, 0x1f, 0x05, 0x7e, 0x78, 0x5a, 0xca, 0x29, 0x1e,
+	0x14, 0xef, 0x50, 0x89, 0xc2, 0xd1, 0x0a, 0x79, 0x02, 0x59, 0x8f, 0x9d, 0x85, 0x8a, 0x9a, 0x1f,
+	0xa3, 0x86, 0x1a, 0x79, 0x0e, 0x39, 0xea, 0x4a, 0x7f, 0x40, 0xa5, 0xcf, 0x42, 0x51, 0xca, 0x8f,
+	0xa1, 0x37, 0x65, 0xb2, 0x05, 0x4b, 0x71, 0xdb, 0x45, 0x2b, 0x42, 0xae, 0x1a, 0x84, 0x42, 0x94,
+	0x8a, 0x37, 0xde, 0xb1, 0x81, 0xdc, 0xc5, 0x50, 0xfa, 0x01, 0x0a, 0xa7, 0xa0, 0xe1, 0x06, 0xf2,
+	0xed, 0x18, 0x25, 0x6f, 0xd5, 0x09, 0x2a, 0xb2, 0xe5, 0xb2, 0x20, 0xf0, 0x85, 0xca, 0xbb, 0x3c,
+	0x96, 0xb7, 0xa0, 0x3b, 0xb7, 0x3b, 0x84, 0x2a, 0xaf, 0x61, 0xf9, 0xa0, 0x17, 0x31, 0x2e, 0x9b,
+	0x28, 0x94, 0x67, 0xd8, 0x35, 0x13, 0xb2, 0xbe, 0x52, 0xd0, 0x53, 0x17, 0x9e, 0x77, 0x86, 0x76,
+	0xc5, 0x82, 0xe2, 0xde, 0x79, 0x3a, 0x2a, 0x9e, 0xb2, 0x22, 0x64, 0x68, 0x14, 0xb5, 0xfc, 0x38,
+	0x64, 0xce, 0x99, 0xa1, 0x51, 0x74, 0xe0, 0xd5, 0xbe, 0x4e, 0x43, 0x5e, 0x4f, 0x55, 0x53, 0x6d,
+	0x11, 0xf9, 0x00, 0xb0, 0x8f, 0x52, 0x4f, 0x0a, 0x59, 0xb1, 0xd2, 0x8b, 0x97, 0x9e, 0x5d, 0xb3,
+	0x7c, 0x9b, 0xac, 0x4b, 0xed, 0x41, 0xa1, 0xc1, 0x31, 0xa2, 0x1c, 0xb7, 0x87, 0x17, 0x4b, 0x9e,
+	0x59, 0x7a, 0x61, 0xea, 0xe8, 0x5d, 0x35, 0xd0, 0xa5, 0x12, 0xbd, 0x38, 0xf2, 0x9a, 0x4a, 0x32,
+	0xdc, 0x07, 0x26, 0x0d, 0xc8, 0x6a, 0x27, 0x92, 0x35, 0x2b, 0x59, 0xbc, 0x71, 0x3a, 0xae, 0xce,
+	0xbc, 0x1b, 0x21, 0x47, 0x90, 0xf9, 0x14, 0xcf, 0xd8, 0xda, 0xa4, 0x42, 0x62, 0xed, 0x10, 0x85,
+	0xa0, 0x1d, 0x34, 0xef, 0x46, 0xc8, 0x26, 0x64, 0xeb, 0xc9, 0x34, 0xfe, 0x3f, 0xc4, 0xb5, 0x27,
+	0x39, 0xe7, 0x36, 0xa1, 0xf6, 0xc7, 0x80, 0xff, 0x52, 0xdd, 0x3a, 0xa4, 0x21, 0xed, 0x20, 0x27,
+	0x5b, 0x30, 0xb7, 0x8f, 0x52, 0xaf, 0xe3, 0xc3, 0x91, 0xa6, 0xa4, 0xf6, 0xd6, 0x2c, 0x4e, 0x54,
+	0xc9, 0x7b, 0x58, 0x48, 0x8f, 0x1b, 0x19, 0xfd, 0x16, 0x98, 0xeb, 0x23, 0x91, 0x93, 0xc7, 0xb3,
+	0x6a, 0x90, 0x03, 0x58, 0x48, 0x0f, 0x21, 0x79, 0x3c, 0x12, 0x3a, 0x71, 0x46, 0xcd, 0xd1, 0x8c,
+	0x2f, 0x8d, 0x9d, 0x77, 0xdf, 0x2e, 0xcb, 0xc6, 0xf7, 0xcb, 0xb2, 0xf1, 0xfb, 0xb2, 0x6c, 0x7c,
+	0xde, 0xb8, 0xf7, 0xff, 0x46, 0x3b, 0xa3, 0x3e, 0xbb, 0xaf, 0xfe, 0x0e, 0x00, 0x8d, 0x76, 0xd9,
+	0xbd, 0x73, 0x06, 0x00, 0x00,
 }
@@ -225,6 +225,38 @@ func (_mr *_MockNetworkServerManagerClientRecorder) GetStatus(arg0, arg1 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStatus", _s...)
 }
 
+func (_m *MockNetworkServerManagerClient) ImportSessions(ctx context.Context, opts ...grpc.CallOption) (NetworkServerManager_ImportSessionsClient, error) {
+	_s := []interface{}{ctx}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "ImportSessions", _s...)
+	ret0, _ := ret[0].(NetworkServerManager_ImportSessionsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkServerManagerClientRecorder) ImportSessions(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0}, arg1...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ImportSessions", _s...)
+}
+
+func (_m *MockNetworkServerManagerClient) ExportSessions(ctx context.Context, in *ExportSessionsRequest, opts ...grpc.CallOption) (NetworkServerManager_ExportSessionsClient, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "ExportSessions", _s...)
+	ret0, _ := ret[0].(NetworkServerManager_ExportSessionsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkServerManagerClientRecorder) ExportSessions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExportSessions", _s...)
+}
+
 // Mock of NetworkServerManagerServer interface
 type MockNetworkServerManagerServer struct {
 	ctrl     *gomock.Controller
@@ -256,3 +288,23 @@ func (_m *MockNetworkServerManagerServer) GetStatus(_param0 context.Context, _pa
 func (_mr *_MockNetworkServerManagerServerRecorder) GetStatus(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStatus", arg0, arg1)
 }
+
+func (_m *MockNetworkServerManagerServer) ImportSessions(_param0 NetworkServerManager_ImportSessionsServer) error {
+	ret := _m.ctrl.Call(_m, "ImportSessions", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkServerManagerServerRecorder) ImportSessions(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ImportSessions", arg0)
+}
+
+func (_m *MockNetworkServerManagerServer) ExportSessions(_param0 *ExportSessionsRequest, _param1 NetworkServerManager_ExportSessionsServer) error {
+	ret := _m.ctrl.Call(_m, "ExportSessions", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkServerManagerServerRecorder) ExportSessions(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExportSessions", arg0, arg1)
+}
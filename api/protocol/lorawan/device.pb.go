@@ -11,6 +11,10 @@
 	It has these top-level messages:
 		DeviceIdentifier
 		Device
+		ADRState
+		DeviceStatus
+		MACSettings
+		MACChannel
 */
 package lorawan
 
@@ -40,6 +44,58 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// The DeviceClass determines when a device can receive downlink messages
+type DeviceClass int32
+
+const (
+	// Class A devices only receive downlink messages in the receive windows after an uplink message
+	DeviceClass_CLASS_A DeviceClass = 0
+	// Class B devices receive downlink messages in ping slots that are synchronized with the beacons of the gateways
+	DeviceClass_CLASS_B DeviceClass = 1
+	// Class C devices continuously listen in RX2, downlink messages are sent to them immediately
+	DeviceClass_CLASS_C DeviceClass = 2
+)
+
+var DeviceClass_name = map[int32]string{
+	0: "CLASS_A",
+	1: "CLASS_B",
+	2: "CLASS_C",
+}
+var DeviceClass_value = map[string]int32{
+	"CLASS_A": 0,
+	"CLASS_B": 1,
+	"CLASS_C": 2,
+}
+
+func (x DeviceClass) String() string {
+	return proto.EnumName(DeviceClass_name, int32(x))
+}
+func (DeviceClass) EnumDescriptor() ([]byte, []int) { return fileDescriptorDevice, []int{0} }
+
+// The MACVersion is the version of the LoRaWAN MAC layer that is implemented by a device
+type MACVersion int32
+
+const (
+	// LoRaWAN 1.0.x
+	MACVersion_MAC_V1_0 MACVersion = 0
+	// LoRaWAN 1.1 devices use separate network and application keys, session keys and frame counters
+	MACVersion_MAC_V1_1 MACVersion = 1
+)
+
+var MACVersion_name = map[int32]string{
+	0: "MAC_V1_0",
+	1: "MAC_V1_1",
+}
+var MACVersion_value = map[string]int32{
+	"MAC_V1_0": 0,
+	"MAC_V1_1": 1,
+}
+
+func (x MACVersion) String() string {
+	return proto.EnumName(MACVersion_name, int32(x))
+}
+func (MACVersion) EnumDescriptor() ([]byte, []int) { return fileDescriptorDevice, []int{1} }
+
 type DeviceIdentifier struct {
 	// The AppEUI is a unique, 8 byte identifier for the application a device belongs to.
 	AppEui *github_com_TheThingsNetwork_ttn_core_types.AppEUI `protobuf:"bytes,1,opt,name=app_eui,json=appEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppEUI" json:"app_eui,omitempty"`
@@ -53,7 +109,7 @@ func (*DeviceIdentifier) ProtoMessage()               {}
 func (*DeviceIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{0} }
 
 type Device struct {
-	// The AppEUI is a unique, 8 byte identifier for the application a device belongs to.
+	// The AppEUI is a unique, 8 byte identifier for the application a device belongs to. For LoRaWAN 1.1 devices, this is the JoinEUI.
 	AppEui *github_com_TheThingsNetwork_ttn_core_types.AppEUI `protobuf:"bytes,1,opt,name=app_eui,json=appEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppEUI" json:"app_eui,omitempty"`
 	// The DevEUI is a unique, 8 byte identifier for the device.
 	DevEui *github_com_TheThingsNetwork_ttn_core_types.DevEUI `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevEUI" json:"dev_eui,omitempty"`
@@ -64,7 +120,7 @@ type Device struct {
 	// The DevAddr is a dynamic, 4 byte session address for the device.
 	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,5,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
 	// The NwkSKey is a 16 byte session key that is known by the device and the network. It is used for routing and MAC related functionality.
-	// This key is negotiated during the OTAA join procedure, or statically configured using ABP.
+	// This key is negotiated during the OTAA join procedure, or statically configured using ABP. For LoRaWAN 1.1 devices, this is the FNwkSIntKey.
 	NwkSKey *github_com_TheThingsNetwork_ttn_core_types.NwkSKey `protobuf:"bytes,6,opt,name=nwk_s_key,json=nwkSKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkSKey" json:"nwk_s_key,omitempty"`
 	// The AppSKey is a 16 byte session key that is known by the device and the application. It is used for payload encryption.
 	// This key is negotiated during the OTAA join procedure, or statically configured using ABP.
@@ -73,7 +129,7 @@ type Device struct {
 	AppKey *github_com_TheThingsNetwork_ttn_core_types.AppKey `protobuf:"bytes,8,opt,name=app_key,json=appKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppKey" json:"app_key,omitempty"`
 	// FCntUp is the uplink frame counter for a device session.
 	FCntUp uint32 `protobuf:"varint,9,opt,name=f_cnt_up,json=fCntUp,proto3" json:"f_cnt_up,omitempty"`
-	// FCntDown is the downlink frame counter for a device session.
+	// FCntDown is the downlink frame counter for a device session. For LoRaWAN 1.1 devices, this is the NFCntDown that is used for downlink without FPort or with FPort 0.
 	FCntDown uint32 `protobuf:"varint,10,opt,name=f_cnt_down,json=fCntDown,proto3" json:"f_cnt_down,omitempty"`
 	// The DisableFCntCheck option disables the frame counter check. Disabling this makes the device vulnerable to replay attacks, but makes ABP slightly easier.
 	DisableFCntCheck bool `protobuf:"varint,11,opt,name=disable_f_cnt_check,json=disableFCntCheck,proto3" json:"disable_f_cnt_check,omitempty"`
@@ -82,8 +138,36 @@ type Device struct {
 	// The ActivationContstraints are used to allocate a device address for a device (comma-separated).
 	// There are different prefixes for `otaa`, `abp`, `world`, `local`, `private`, `testing`.
 	ActivationConstraints string `protobuf:"bytes,13,opt,name=activation_constraints,json=activationConstraints,proto3" json:"activation_constraints,omitempty"`
+	// The DeviceClass of the device, the default is Class A.
+	DeviceClass DeviceClass `protobuf:"varint,14,opt,name=device_class,json=deviceClass,proto3,enum=lorawan.DeviceClass" json:"device_class,omitempty"`
+	// The beacon frequency (Hz) of a Class B device, zero for the default beacon frequency of the frequency plan.
+	BeaconFrequency uint32 `protobuf:"varint,15,opt,name=beacon_frequency,json=beaconFrequency,proto3" json:"beacon_frequency,omitempty"`
+	// The MACVersion of the device, the default is LoRaWAN 1.0.
+	MacVersion MACVersion `protobuf:"varint,16,opt,name=mac_version,json=macVersion,proto3,enum=lorawan.MACVersion" json:"mac_version,omitempty"`
+	// The NwkKey is a 16 byte static key that is known by LoRaWAN 1.1 devices and the network. It is used for negotiating network session keys (OTAA).
+	NwkKey *github_com_TheThingsNetwork_ttn_core_types.NwkKey `protobuf:"bytes,17,opt,name=nwk_key,json=nwkKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkKey" json:"nwk_key,omitempty"`
+	// The SNwkSIntKey is a 16 byte session key of LoRaWAN 1.1 devices. It is used for the MIC of downlink messages.
+	SNwkSIntKey *github_com_TheThingsNetwork_ttn_core_types.SNwkSIntKey `protobuf:"bytes,18,opt,name=s_nwk_s_int_key,json=sNwkSIntKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.SNwkSIntKey" json:"s_nwk_s_int_key,omitempty"`
+	// The NwkSEncKey is a 16 byte session key of LoRaWAN 1.1 devices. It is used for encrypting MAC commands.
+	NwkSEncKey *github_com_TheThingsNetwork_ttn_core_types.NwkSEncKey `protobuf:"bytes,19,opt,name=nwk_s_enc_key,json=nwkSEncKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkSEncKey" json:"nwk_s_enc_key,omitempty"`
+	// AFCntDown is the downlink frame counter of LoRaWAN 1.1 devices for downlink with an FPort greater than 0.
+	AFCntDown uint32 `protobuf:"varint,20,opt,name=a_f_cnt_down,json=aFCntDown,proto3" json:"a_f_cnt_down,omitempty"`
 	// When the device was last seen (Unix nanoseconds)
 	LastSeen int64 `protobuf:"varint,21,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// The ADR algorithm that the network uses for the device (default, conservative or fixed). If empty, the default ADR algorithm is used.
+	AdrAlgorithm string `protobuf:"bytes,22,opt,name=adr_algorithm,json=adrAlgorithm,proto3" json:"adr_algorithm,omitempty"`
+	// The data rate (for example SF9BW125) that devices with the fixed ADR algorithm are told to use.
+	AdrDataRate string `protobuf:"bytes,23,opt,name=adr_data_rate,json=adrDataRate,proto3" json:"adr_data_rate,omitempty"`
+	// The ADR decisions of the network for the device. This is ignored when setting a device.
+	AdrState *ADRState `protobuf:"bytes,24,opt,name=adr_state,json=adrState" json:"adr_state,omitempty"`
+	// The frequency (Hz) of the ping slots of a Class B device, zero for the default ping slot frequency of the frequency plan.
+	PingSlotFrequency uint32 `protobuf:"varint,25,opt,name=ping_slot_frequency,json=pingSlotFrequency,proto3" json:"ping_slot_frequency,omitempty"`
+	// The data rate (for example SF9BW125) of the ping slots of a Class B device, empty for the default ping slot data rate of the frequency plan.
+	PingSlotDataRate string `protobuf:"bytes,26,opt,name=ping_slot_data_rate,json=pingSlotDataRate,proto3" json:"ping_slot_data_rate,omitempty"`
+	// The last status that the device reported in a DevStatusAns. This is ignored when setting a device.
+	DeviceStatus *DeviceStatus `protobuf:"bytes,27,opt,name=device_status,json=deviceStatus" json:"device_status,omitempty"`
+	// The MACSettings that the network sends to the device with MAC commands when they are changed. They are not changed if they are not set.
+	MacSettings *MACSettings `protobuf:"bytes,28,opt,name=mac_settings,json=macSettings" json:"mac_settings,omitempty"`
 }
 
 func (m *Device) Reset()                    { *m = Device{} }
@@ -140,6 +224,34 @@ func (m *Device) GetActivationConstraints() string {
 	return ""
 }
 
+func (m *Device) GetDeviceClass() DeviceClass {
+	if m != nil {
+		return m.DeviceClass
+	}
+	return DeviceClass_CLASS_A
+}
+
+func (m *Device) GetBeaconFrequency() uint32 {
+	if m != nil {
+		return m.BeaconFrequency
+	}
+	return 0
+}
+
+func (m *Device) GetMacVersion() MACVersion {
+	if m != nil {
+		return m.MacVersion
+	}
+	return MACVersion_MAC_V1_0
+}
+
+func (m *Device) GetAFCntDown() uint32 {
+	if m != nil {
+		return m.AFCntDown
+	}
+	return 0
+}
+
 func (m *Device) GetLastSeen() int64 {
 	if m != nil {
 		return m.LastSeen
@@ -147,9 +259,315 @@ func (m *Device) GetLastSeen() int64 {
 	return 0
 }
 
+func (m *Device) GetAdrAlgorithm() string {
+	if m != nil {
+		return m.AdrAlgorithm
+	}
+	return ""
+}
+
+func (m *Device) GetAdrDataRate() string {
+	if m != nil {
+		return m.AdrDataRate
+	}
+	return ""
+}
+
+func (m *Device) GetAdrState() *ADRState {
+	if m != nil {
+		return m.AdrState
+	}
+	return nil
+}
+
+func (m *Device) GetPingSlotFrequency() uint32 {
+	if m != nil {
+		return m.PingSlotFrequency
+	}
+	return 0
+}
+
+func (m *Device) GetPingSlotDataRate() string {
+	if m != nil {
+		return m.PingSlotDataRate
+	}
+	return ""
+}
+
+func (m *Device) GetDeviceStatus() *DeviceStatus {
+	if m != nil {
+		return m.DeviceStatus
+	}
+	return nil
+}
+
+func (m *Device) GetMacSettings() *MACSettings {
+	if m != nil {
+		return m.MacSettings
+	}
+	return nil
+}
+
+// The ADRState contains the ADR decisions of the network for a device
+type ADRState struct {
+	// The ADR algorithm that is used for the device
+	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// The SNR margin (dB) that is used by the ADR algorithm
+	Margin int32 `protobuf:"varint,2,opt,name=margin,proto3" json:"margin,omitempty"`
+	// The data rate of the device, or the data rate that the device is told to use
+	DataRate string `protobuf:"bytes,3,opt,name=data_rate,json=dataRate,proto3" json:"data_rate,omitempty"`
+	// The TX power (dBm) that the device is told to use
+	TxPower int32 `protobuf:"varint,4,opt,name=tx_power,json=txPower,proto3" json:"tx_power,omitempty"`
+	// The number of transmissions of each uplink message that the device is told to use
+	NbTrans uint32 `protobuf:"varint,5,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// Indicates that the network will send a LinkADRReq to the device
+	Pending bool `protobuf:"varint,6,opt,name=pending,proto3" json:"pending,omitempty"`
+	// The number of LinkADRReqs that the device rejected
+	Failed uint32 `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (m *ADRState) Reset()                    { *m = ADRState{} }
+func (m *ADRState) String() string            { return proto.CompactTextString(m) }
+func (*ADRState) ProtoMessage()               {}
+func (*ADRState) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{2} }
+
+func (m *ADRState) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *ADRState) GetMargin() int32 {
+	if m != nil {
+		return m.Margin
+	}
+	return 0
+}
+
+func (m *ADRState) GetDataRate() string {
+	if m != nil {
+		return m.DataRate
+	}
+	return ""
+}
+
+func (m *ADRState) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *ADRState) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *ADRState) GetPending() bool {
+	if m != nil {
+		return m.Pending
+	}
+	return false
+}
+
+func (m *ADRState) GetFailed() uint32 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+// The DeviceStatus contains the status that a device reported in a DevStatusAns
+type DeviceStatus struct {
+	// The battery level of the device: 0 if the device is connected to an external power source, 1..254 for the battery level, 255 if the device could not measure the battery level
+	Battery uint32 `protobuf:"varint,1,opt,name=battery,proto3" json:"battery,omitempty"`
+	// The SNR margin (dB) of the last DevStatusReq that the device received
+	Margin int32 `protobuf:"varint,2,opt,name=margin,proto3" json:"margin,omitempty"`
+	// When the status was reported (Unix nanoseconds)
+	UpdatedAt int64 `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (m *DeviceStatus) Reset()                    { *m = DeviceStatus{} }
+func (m *DeviceStatus) String() string            { return proto.CompactTextString(m) }
+func (*DeviceStatus) ProtoMessage()               {}
+func (*DeviceStatus) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{3} }
+
+func (m *DeviceStatus) GetBattery() uint32 {
+	if m != nil {
+		return m.Battery
+	}
+	return 0
+}
+
+func (m *DeviceStatus) GetMargin() int32 {
+	if m != nil {
+		return m.Margin
+	}
+	return 0
+}
+
+func (m *DeviceStatus) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+// The MACSettings are sent to a device with MAC commands when they are changed. The network does not change its own
+// settings for the device, they are used to configure devices to the frequency plan of the network, for example ABP
+// devices that start with the defaults of LoRaWAN.
+type MACSettings struct {
+	// The maximum duty cycle of the device is 1/2^max_duty_cycle (0..15), sent in a DutyCycleReq. Zero means no limit.
+	MaxDutyCycle uint32 `protobuf:"varint,1,opt,name=max_duty_cycle,json=maxDutyCycle,proto3" json:"max_duty_cycle,omitempty"`
+	// The delay (seconds, 0..15) of the RX1 window of the device, sent in a RXTimingSetupReq. Zero means 1 second.
+	Rx1Delay uint32 `protobuf:"varint,2,opt,name=rx1_delay,json=rx1Delay,proto3" json:"rx1_delay,omitempty"`
+	// The offset (0..7) between the uplink data rate and the RX1 data rate, sent in a RXParamSetupReq.
+	Rx1DrOffset uint32 `protobuf:"varint,3,opt,name=rx1_dr_offset,json=rx1DrOffset,proto3" json:"rx1_dr_offset,omitempty"`
+	// The index (0..15) of the data rate of the RX2 window, sent in a RXParamSetupReq.
+	Rx2DataRate uint32 `protobuf:"varint,4,opt,name=rx2_data_rate,json=rx2DataRate,proto3" json:"rx2_data_rate,omitempty"`
+	// The frequency (Hz) of the RX2 window, sent in a RXParamSetupReq. The RXParamSetupReq is only sent if it is set.
+	Rx2Frequency uint32 `protobuf:"varint,5,opt,name=rx2_frequency,json=rx2Frequency,proto3" json:"rx2_frequency,omitempty"`
+	// The channels of the device, sent in NewChannelReqs and DlChannelReqs. Channels that are removed are disabled.
+	Channels []*MACChannel `protobuf:"bytes,6,rep,name=channels" json:"channels,omitempty"`
+	// The uplink and downlink dwell time limits (400 ms) and the index (0..15) of the maximum EIRP of the device, sent in a TxParamSetupReq.
+	UplinkDwellTime   bool   `protobuf:"varint,7,opt,name=uplink_dwell_time,json=uplinkDwellTime,proto3" json:"uplink_dwell_time,omitempty"`
+	DownlinkDwellTime bool   `protobuf:"varint,8,opt,name=downlink_dwell_time,json=downlinkDwellTime,proto3" json:"downlink_dwell_time,omitempty"`
+	MaxEirp           uint32 `protobuf:"varint,9,opt,name=max_eirp,json=maxEirp,proto3" json:"max_eirp,omitempty"`
+}
+
+func (m *MACSettings) Reset()                    { *m = MACSettings{} }
+func (m *MACSettings) String() string            { return proto.CompactTextString(m) }
+func (*MACSettings) ProtoMessage()               {}
+func (*MACSettings) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{4} }
+
+func (m *MACSettings) GetMaxDutyCycle() uint32 {
+	if m != nil {
+		return m.MaxDutyCycle
+	}
+	return 0
+}
+
+func (m *MACSettings) GetRx1Delay() uint32 {
+	if m != nil {
+		return m.Rx1Delay
+	}
+	return 0
+}
+
+func (m *MACSettings) GetRx1DrOffset() uint32 {
+	if m != nil {
+		return m.Rx1DrOffset
+	}
+	return 0
+}
+
+func (m *MACSettings) GetRx2DataRate() uint32 {
+	if m != nil {
+		return m.Rx2DataRate
+	}
+	return 0
+}
+
+func (m *MACSettings) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
+func (m *MACSettings) GetChannels() []*MACChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *MACSettings) GetUplinkDwellTime() bool {
+	if m != nil {
+		return m.UplinkDwellTime
+	}
+	return false
+}
+
+func (m *MACSettings) GetDownlinkDwellTime() bool {
+	if m != nil {
+		return m.DownlinkDwellTime
+	}
+	return false
+}
+
+func (m *MACSettings) GetMaxEirp() uint32 {
+	if m != nil {
+		return m.MaxEirp
+	}
+	return 0
+}
+
+// A MACChannel is a channel of a device
+type MACChannel struct {
+	// The index (0..15) of the channel
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The uplink frequency (Hz) of the channel, zero disables the channel
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// The minimum and maximum data rate index (0..15) of the channel
+	MinDataRate uint32 `protobuf:"varint,3,opt,name=min_data_rate,json=minDataRate,proto3" json:"min_data_rate,omitempty"`
+	MaxDataRate uint32 `protobuf:"varint,4,opt,name=max_data_rate,json=maxDataRate,proto3" json:"max_data_rate,omitempty"`
+	// The downlink frequency (Hz) of the RX1 window of the channel, zero for the uplink frequency
+	DownlinkFrequency uint32 `protobuf:"varint,5,opt,name=downlink_frequency,json=downlinkFrequency,proto3" json:"downlink_frequency,omitempty"`
+}
+
+func (m *MACChannel) Reset()                    { *m = MACChannel{} }
+func (m *MACChannel) String() string            { return proto.CompactTextString(m) }
+func (*MACChannel) ProtoMessage()               {}
+func (*MACChannel) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{5} }
+
+func (m *MACChannel) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *MACChannel) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *MACChannel) GetMinDataRate() uint32 {
+	if m != nil {
+		return m.MinDataRate
+	}
+	return 0
+}
+
+func (m *MACChannel) GetMaxDataRate() uint32 {
+	if m != nil {
+		return m.MaxDataRate
+	}
+	return 0
+}
+
+func (m *MACChannel) GetDownlinkFrequency() uint32 {
+	if m != nil {
+		return m.DownlinkFrequency
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*DeviceIdentifier)(nil), "lorawan.DeviceIdentifier")
 	proto.RegisterType((*Device)(nil), "lorawan.Device")
+	proto.RegisterType((*ADRState)(nil), "lorawan.ADRState")
+	proto.RegisterType((*DeviceStatus)(nil), "lorawan.DeviceStatus")
+	proto.RegisterType((*MACSettings)(nil), "lorawan.MACSettings")
+	proto.RegisterType((*MACChannel)(nil), "lorawan.MACChannel")
+	proto.RegisterEnum("lorawan.DeviceClass", DeviceClass_name, DeviceClass_value)
+	proto.RegisterEnum("lorawan.MACVersion", MACVersion_name, MACVersion_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -451,6 +869,66 @@ func (m *Device) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintDevice(dAtA, i, uint64(len(m.ActivationConstraints)))
 		i += copy(dAtA[i:], m.ActivationConstraints)
 	}
+	if m.DeviceClass != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.DeviceClass))
+	}
+	if m.BeaconFrequency != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.BeaconFrequency))
+	}
+	if m.MacVersion != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.MacVersion))
+	}
+	if m.NwkKey != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.NwkKey.Size()))
+		n9, err := m.NwkKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.SNwkSIntKey != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.SNwkSIntKey.Size()))
+		n10, err := m.SNwkSIntKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.NwkSEncKey != nil {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.NwkSEncKey.Size()))
+		n11, err := m.NwkSEncKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.AFCntDown != 0 {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.AFCntDown))
+	}
 	if m.LastSeen != 0 {
 		dAtA[i] = 0xa8
 		i++
@@ -458,26 +936,309 @@ func (m *Device) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintDevice(dAtA, i, uint64(m.LastSeen))
 	}
-	return i, nil
-}
-
-func encodeFixed64Device(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Device(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
+	if len(m.AdrAlgorithm) > 0 {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.AdrAlgorithm)))
+		i += copy(dAtA[i:], m.AdrAlgorithm)
+	}
+	if len(m.AdrDataRate) > 0 {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.AdrDataRate)))
+		i += copy(dAtA[i:], m.AdrDataRate)
+	}
+	if m.AdrState != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.AdrState.Size()))
+		n12, err := m.AdrState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.PingSlotFrequency != 0 {
+		dAtA[i] = 0xc8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.PingSlotFrequency))
+	}
+	if len(m.PingSlotDataRate) > 0 {
+		dAtA[i] = 0xd2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.PingSlotDataRate)))
+		i += copy(dAtA[i:], m.PingSlotDataRate)
+	}
+	if m.DeviceStatus != nil {
+		dAtA[i] = 0xda
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.DeviceStatus.Size()))
+		n13, err := m.DeviceStatus.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.MacSettings != nil {
+		dAtA[i] = 0xe2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.MacSettings.Size()))
+		n14, err := m.MacSettings.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	return i, nil
+}
+
+func (m *ADRState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ADRState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Algorithm) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.Algorithm)))
+		i += copy(dAtA[i:], m.Algorithm)
+	}
+	if m.Margin != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Margin))
+	}
+	if len(m.DataRate) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.DataRate)))
+		i += copy(dAtA[i:], m.DataRate)
+	}
+	if m.TxPower != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.TxPower))
+	}
+	if m.NbTrans != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.NbTrans))
+	}
+	if m.Pending {
+		dAtA[i] = 0x30
+		i++
+		if m.Pending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Failed != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Failed))
+	}
+	return i, nil
+}
+
+func (m *DeviceStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Battery != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Battery))
+	}
+	if m.Margin != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Margin))
+	}
+	if m.UpdatedAt != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.UpdatedAt))
+	}
+	return i, nil
+}
+
+func (m *MACSettings) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MACSettings) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxDutyCycle != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.MaxDutyCycle))
+	}
+	if m.Rx1Delay != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Rx1Delay))
+	}
+	if m.Rx1DrOffset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Rx1DrOffset))
+	}
+	if m.Rx2DataRate != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Rx2DataRate))
+	}
+	if m.Rx2Frequency != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Rx2Frequency))
+	}
+	if len(m.Channels) > 0 {
+		for _, msg := range m.Channels {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintDevice(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.UplinkDwellTime {
+		dAtA[i] = 0x38
+		i++
+		if m.UplinkDwellTime {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DownlinkDwellTime {
+		dAtA[i] = 0x40
+		i++
+		if m.DownlinkDwellTime {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.MaxEirp != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.MaxEirp))
+	}
+	return i, nil
+}
+
+func (m *MACChannel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MACChannel) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Index))
+	}
+	if m.Frequency != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Frequency))
+	}
+	if m.MinDataRate != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.MinDataRate))
+	}
+	if m.MaxDataRate != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.MaxDataRate))
+	}
+	if m.DownlinkFrequency != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.DownlinkFrequency))
+	}
+	return i, nil
+}
+
+func encodeFixed64Device(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Device(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
 }
 func encodeVarintDevice(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
@@ -553,47 +1314,199 @@ func (m *Device) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDevice(uint64(l))
 	}
+	if m.DeviceClass != 0 {
+		n += 1 + sovDevice(uint64(m.DeviceClass))
+	}
+	if m.BeaconFrequency != 0 {
+		n += 1 + sovDevice(uint64(m.BeaconFrequency))
+	}
+	if m.MacVersion != 0 {
+		n += 2 + sovDevice(uint64(m.MacVersion))
+	}
+	if m.NwkKey != nil {
+		l = m.NwkKey.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.SNwkSIntKey != nil {
+		l = m.SNwkSIntKey.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.NwkSEncKey != nil {
+		l = m.NwkSEncKey.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.AFCntDown != 0 {
+		n += 2 + sovDevice(uint64(m.AFCntDown))
+	}
 	if m.LastSeen != 0 {
 		n += 2 + sovDevice(uint64(m.LastSeen))
 	}
+	l = len(m.AdrAlgorithm)
+	if l > 0 {
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	l = len(m.AdrDataRate)
+	if l > 0 {
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.AdrState != nil {
+		l = m.AdrState.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.PingSlotFrequency != 0 {
+		n += 2 + sovDevice(uint64(m.PingSlotFrequency))
+	}
+	l = len(m.PingSlotDataRate)
+	if l > 0 {
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.DeviceStatus != nil {
+		l = m.DeviceStatus.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if m.MacSettings != nil {
+		l = m.MacSettings.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
 	return n
 }
 
-func sovDevice(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *ADRState) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Algorithm)
+	if l > 0 {
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.Margin != 0 {
+		n += 1 + sovDevice(uint64(m.Margin))
+	}
+	l = len(m.DataRate)
+	if l > 0 {
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.TxPower != 0 {
+		n += 1 + sovDevice(uint64(m.TxPower))
+	}
+	if m.NbTrans != 0 {
+		n += 1 + sovDevice(uint64(m.NbTrans))
+	}
+	if m.Pending {
+		n += 2
+	}
+	if m.Failed != 0 {
+		n += 1 + sovDevice(uint64(m.Failed))
 	}
 	return n
 }
-func sozDevice(x uint64) (n int) {
-	return sovDevice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *DeviceStatus) Size() (n int) {
+	var l int
+	_ = l
+	if m.Battery != 0 {
+		n += 1 + sovDevice(uint64(m.Battery))
+	}
+	if m.Margin != 0 {
+		n += 1 + sovDevice(uint64(m.Margin))
+	}
+	if m.UpdatedAt != 0 {
+		n += 1 + sovDevice(uint64(m.UpdatedAt))
+	}
+	return n
 }
-func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowDevice
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
+
+func (m *MACSettings) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxDutyCycle != 0 {
+		n += 1 + sovDevice(uint64(m.MaxDutyCycle))
+	}
+	if m.Rx1Delay != 0 {
+		n += 1 + sovDevice(uint64(m.Rx1Delay))
+	}
+	if m.Rx1DrOffset != 0 {
+		n += 1 + sovDevice(uint64(m.Rx1DrOffset))
+	}
+	if m.Rx2DataRate != 0 {
+		n += 1 + sovDevice(uint64(m.Rx2DataRate))
+	}
+	if m.Rx2Frequency != 0 {
+		n += 1 + sovDevice(uint64(m.Rx2Frequency))
+	}
+	if len(m.Channels) > 0 {
+		for _, e := range m.Channels {
+			l = e.Size()
+			n += 1 + l + sovDevice(uint64(l))
+		}
+	}
+	if m.UplinkDwellTime {
+		n += 2
+	}
+	if m.DownlinkDwellTime {
+		n += 2
+	}
+	if m.MaxEirp != 0 {
+		n += 1 + sovDevice(uint64(m.MaxEirp))
+	}
+	return n
+}
+
+func (m *MACChannel) Size() (n int) {
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovDevice(uint64(m.Index))
+	}
+	if m.Frequency != 0 {
+		n += 1 + sovDevice(uint64(m.Frequency))
+	}
+	if m.MinDataRate != 0 {
+		n += 1 + sovDevice(uint64(m.MinDataRate))
+	}
+	if m.MaxDataRate != 0 {
+		n += 1 + sovDevice(uint64(m.MaxDataRate))
+	}
+	if m.DownlinkFrequency != 0 {
+		n += 1 + sovDevice(uint64(m.DownlinkFrequency))
+	}
+	return n
+}
+
+func sovDevice(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozDevice(x uint64) (n int) {
+	return sovDevice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
 		if wireType == 4 {
 			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
 		}
@@ -632,12 +1545,1120 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
+			m.DevEui = &v
+			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
+			m.AppEui = &v
+			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
+			m.DevEui = &v
+			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
+			m.DevAddr = &v
+			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NwkSKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.NwkSKey
+			m.NwkSKey = &v
+			if err := m.NwkSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppSKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppSKey
+			m.AppSKey = &v
+			if err := m.AppSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppKey
+			m.AppKey = &v
+			if err := m.AppKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntUp", wireType)
+			}
+			m.FCntUp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FCntUp |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntDown", wireType)
+			}
+			m.FCntDown = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FCntDown |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableFCntCheck", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableFCntCheck = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uses32BitFCnt", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Uses32BitFCnt = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationConstraints", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationConstraints = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceClass", wireType)
+			}
+			m.DeviceClass = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeviceClass |= (DeviceClass(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeaconFrequency", wireType)
+			}
+			m.BeaconFrequency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BeaconFrequency |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MacVersion", wireType)
+			}
+			m.MacVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MacVersion |= (MACVersion(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NwkKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.NwkKey
+			m.NwkKey = &v
+			if err := m.NwkKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SNwkSIntKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.SNwkSIntKey
+			m.SNwkSIntKey = &v
+			if err := m.SNwkSIntKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NwkSEncKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.NwkSEncKey
+			m.NwkSEncKey = &v
+			if err := m.NwkSEncKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AFCntDown", wireType)
+			}
+			m.AFCntDown = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AFCntDown |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+			}
+			m.LastSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeen |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdrAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdrAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdrDataRate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdrDataRate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdrState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AdrState == nil {
+				m.AdrState = &ADRState{}
+			}
+			if err := m.AdrState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PingSlotFrequency", wireType)
+			}
+			m.PingSlotFrequency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PingSlotFrequency |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PingSlotDataRate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PingSlotDataRate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceStatus", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeviceStatus == nil {
+				m.DeviceStatus = &DeviceStatus{}
+			}
+			if err := m.DeviceStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MacSettings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MacSettings == nil {
+				m.MacSettings = &MACSettings{}
+			}
+			if err := m.MacSettings.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ADRState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ADRState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ADRState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Algorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Algorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Margin", wireType)
+			}
+			m.Margin = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Margin |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataRate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DataRate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxPower", wireType)
+			}
+			m.TxPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxPower |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NbTrans", wireType)
+			}
+			m.NbTrans = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NbTrans |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pending = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			m.Failed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failed |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Battery", wireType)
+			}
+			m.Battery = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Battery |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Margin", wireType)
 			}
-			var byteLen int
+			m.Margin = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -647,24 +2668,30 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Margin |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
 			}
-			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
-			m.DevEui = &v
-			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDevice(dAtA[iNdEx:])
@@ -686,7 +2713,7 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Device) Unmarshal(dAtA []byte) error {
+func (m *MACSettings) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -709,17 +2736,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+			return fmt.Errorf("proto: MACSettings: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MACSettings: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDutyCycle", wireType)
 			}
-			var byteLen int
+			m.MaxDutyCycle = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -729,29 +2756,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.MaxDutyCycle |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
-			m.AppEui = &v
-			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rx1Delay", wireType)
 			}
-			var byteLen int
+			m.Rx1Delay = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -761,29 +2775,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Rx1Delay |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
-			m.DevEui = &v
-			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rx1DrOffset", wireType)
 			}
-			var stringLen uint64
+			m.Rx1DrOffset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -793,26 +2794,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Rx1DrOffset |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AppId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rx2DataRate", wireType)
 			}
-			var stringLen uint64
+			m.Rx2DataRate = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -822,26 +2813,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Rx2DataRate |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DevId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rx2Frequency", wireType)
 			}
-			var byteLen int
+			m.Rx2Frequency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -851,29 +2832,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Rx2Frequency |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
-			m.DevAddr = &v
-			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NwkSKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Channels", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -883,29 +2851,28 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthDevice
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v github_com_TheThingsNetwork_ttn_core_types.NwkSKey
-			m.NwkSKey = &v
-			if err := m.NwkSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Channels = append(m.Channels, &MACChannel{})
+			if err := m.Channels[len(m.Channels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppSKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UplinkDwellTime", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -915,29 +2882,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppSKey
-			m.AppSKey = &v
-			if err := m.AppSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.UplinkDwellTime = bool(v != 0)
 		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkDwellTime", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -947,29 +2902,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppKey
-			m.AppKey = &v
-			if err := m.AppKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.DownlinkDwellTime = bool(v != 0)
 		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FCntUp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEirp", wireType)
 			}
-			m.FCntUp = 0
+			m.MaxEirp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -979,16 +2922,66 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FCntUp |= (uint32(b) & 0x7F) << shift
+				m.MaxEirp |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MACChannel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MACChannel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MACChannel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FCntDown", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.FCntDown = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -998,16 +2991,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FCntDown |= (uint32(b) & 0x7F) << shift
+				m.Index |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DisableFCntCheck", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Frequency", wireType)
 			}
-			var v int
+			m.Frequency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1017,17 +3010,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.Frequency |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.DisableFCntCheck = bool(v != 0)
-		case 12:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uses32BitFCnt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinDataRate", wireType)
 			}
-			var v int
+			m.MinDataRate = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1037,17 +3029,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.MinDataRate |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Uses32BitFCnt = bool(v != 0)
-		case 13:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationConstraints", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDataRate", wireType)
 			}
-			var stringLen uint64
+			m.MaxDataRate = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1057,26 +3048,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.MaxDataRate |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ActivationConstraints = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 21:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkFrequency", wireType)
 			}
-			m.LastSeen = 0
+			m.DownlinkFrequency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1086,7 +3067,7 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastSeen |= (int64(b) & 0x7F) << shift
+				m.DownlinkFrequency |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1222,42 +3203,88 @@ func init() {
 }
 
 var fileDescriptorDevice = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x94, 0x4f, 0x6f, 0xd3, 0x3e,
-	0x18, 0xc7, 0x95, 0xdf, 0x7e, 0x6b, 0x13, 0xb3, 0x8a, 0xc9, 0x68, 0x93, 0xe9, 0xd0, 0x56, 0xed,
-	0x42, 0x2f, 0x4b, 0xc4, 0xfe, 0xc0, 0xb9, 0xff, 0x40, 0x15, 0x62, 0x12, 0xd9, 0x76, 0xe1, 0x12,
-	0xb9, 0xf1, 0xd3, 0xd4, 0x6a, 0x66, 0x5b, 0x89, 0x93, 0xa8, 0x2f, 0x80, 0x37, 0xc4, 0x3b, 0xe0,
-	0xc6, 0x91, 0xf3, 0x0e, 0x13, 0xda, 0x2b, 0x41, 0x8e, 0x3b, 0x86, 0x2a, 0xa1, 0x89, 0x9e, 0xb8,
-	0x3d, 0xf9, 0x7e, 0xbf, 0xfe, 0x3c, 0x76, 0x5d, 0x3f, 0xa8, 0x97, 0x70, 0x3d, 0x2b, 0x26, 0x7e,
-	0x2c, 0xaf, 0x83, 0xcb, 0x19, 0x5c, 0xce, 0xb8, 0x48, 0xf2, 0x73, 0xd0, 0x95, 0xcc, 0xe6, 0x81,
-	0xd6, 0x22, 0xa0, 0x8a, 0x07, 0x2a, 0x93, 0x5a, 0xc6, 0x32, 0x0d, 0x52, 0x99, 0xd1, 0x8a, 0x8a,
-	0x80, 0x41, 0xc9, 0x63, 0xf0, 0x6b, 0x1d, 0x37, 0x97, 0x6a, 0x7b, 0x2f, 0x91, 0x32, 0x49, 0xc1,
-	0xc6, 0x27, 0xc5, 0x34, 0x80, 0x6b, 0xa5, 0x17, 0x36, 0xd5, 0x3e, 0xfa, 0xad, 0x51, 0x22, 0x13,
-	0xf9, 0x90, 0x32, 0x5f, 0xf5, 0x47, 0x5d, 0xd9, 0xf8, 0xe1, 0x17, 0x07, 0x6d, 0x0f, 0xeb, 0x2e,
-	0x63, 0x06, 0x42, 0xf3, 0x29, 0x87, 0x0c, 0x9f, 0xa3, 0x26, 0x55, 0x2a, 0x82, 0x82, 0x13, 0xa7,
-	0xe3, 0x74, 0xb7, 0xfa, 0x67, 0x37, 0xb7, 0x07, 0xaf, 0x1e, 0x3b, 0x41, 0x2c, 0x33, 0x08, 0xf4,
-	0x42, 0x41, 0xee, 0xf7, 0x94, 0x1a, 0x5d, 0x8d, 0xc3, 0x06, 0x55, 0x6a, 0x54, 0x70, 0xc3, 0x63,
-	0x50, 0xd6, 0xbc, 0xff, 0xd6, 0xe2, 0x0d, 0xa1, 0xac, 0x79, 0x0c, 0xca, 0x51, 0xc1, 0x0f, 0x3f,
-	0x37, 0x50, 0xc3, 0x6e, 0xfa, 0x5f, 0xdf, 0x2a, 0xde, 0x41, 0x86, 0x1c, 0x71, 0x46, 0x36, 0x3a,
-	0x4e, 0xd7, 0x0b, 0x37, 0xa9, 0x52, 0x63, 0x66, 0x64, 0xd3, 0x86, 0x33, 0xf2, 0xbf, 0x95, 0x19,
-	0x94, 0x63, 0x86, 0x3f, 0x22, 0xd7, 0xc8, 0x94, 0xb1, 0x8c, 0x6c, 0xd6, 0xed, 0x5f, 0xdf, 0xdc,
-	0x1e, 0x1c, 0xff, 0x5d, 0xfb, 0x1e, 0x63, 0x59, 0x68, 0x4e, 0x61, 0x0a, 0x1c, 0x22, 0x4f, 0x54,
-	0xf3, 0x28, 0x8f, 0xe6, 0xb0, 0x20, 0x8d, 0xb5, 0x98, 0xe7, 0xd5, 0xfc, 0xe2, 0x3d, 0x2c, 0xc2,
-	0xa6, 0xb0, 0x85, 0x61, 0x9a, 0x43, 0x59, 0x66, 0x73, 0x2d, 0x66, 0x4f, 0x29, 0xcb, 0xa4, 0xb6,
-	0xb8, 0xbf, 0x48, 0x43, 0x74, 0xd7, 0xbd, 0x48, 0x03, 0x34, 0x3f, 0xb7, 0xe1, 0x11, 0xe4, 0x4e,
-	0xa3, 0x58, 0xe8, 0xa8, 0x50, 0xc4, 0xeb, 0x38, 0xdd, 0x56, 0xd8, 0x98, 0x0e, 0x84, 0xbe, 0x52,
-	0xf8, 0x05, 0x42, 0xd6, 0x61, 0xb2, 0x12, 0x04, 0xd5, 0x9e, 0x6b, 0xbc, 0xa1, 0xac, 0x04, 0x3e,
-	0x42, 0xcf, 0x18, 0xcf, 0xe9, 0x24, 0x85, 0xc8, 0xa6, 0xe2, 0x19, 0xc4, 0x73, 0xf2, 0xa4, 0xe3,
-	0x74, 0xdd, 0x70, 0x7b, 0x69, 0xbd, 0x1d, 0x08, 0x3d, 0x30, 0x3a, 0x7e, 0x89, 0xb6, 0x8b, 0x1c,
-	0xf2, 0x93, 0xe3, 0x68, 0xc2, 0xb5, 0x5d, 0x41, 0xb6, 0xea, 0x6c, 0xcb, 0xea, 0x7d, 0xae, 0x4d,
-	0x1a, 0x9f, 0xa1, 0x5d, 0x1a, 0x6b, 0x5e, 0x52, 0xcd, 0xa5, 0x88, 0x62, 0x29, 0x72, 0x9d, 0x51,
-	0x2e, 0x74, 0x4e, 0x5a, 0xf5, 0x3f, 0x60, 0xe7, 0xc1, 0x1d, 0x3c, 0x98, 0x78, 0x0f, 0x79, 0x29,
-	0xcd, 0x75, 0x94, 0x03, 0x08, 0xb2, 0xd3, 0x71, 0xba, 0x1b, 0xa1, 0x6b, 0x84, 0x0b, 0x00, 0x71,
-	0xfc, 0xd5, 0x41, 0x2d, 0xfb, 0x0e, 0x3e, 0x50, 0x41, 0x13, 0xc8, 0xf0, 0x1b, 0xe4, 0xbd, 0x03,
-	0xbd, 0x7c, 0x1b, 0xcf, 0xfd, 0xe5, 0xc4, 0xf0, 0x57, 0x5f, 0x78, 0xfb, 0xe9, 0x8a, 0x85, 0x4f,
-	0x91, 0x77, 0xf1, 0x6b, 0xe1, 0xaa, 0xdb, 0xde, 0xf5, 0xed, 0xc8, 0xf1, 0xef, 0x87, 0x89, 0x3f,
-	0x32, 0x23, 0x07, 0xf7, 0xd0, 0xd6, 0x10, 0x52, 0xd0, 0xf0, 0x78, 0xc7, 0x3f, 0x20, 0xfa, 0xfd,
-	0x6f, 0x77, 0xfb, 0xce, 0xf7, 0xbb, 0x7d, 0xe7, 0xc7, 0xdd, 0xbe, 0xf3, 0xe9, 0x74, 0x9d, 0x31,
-	0x39, 0x69, 0xd4, 0xca, 0xc9, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x75, 0x3e, 0x90, 0x84, 0x65,
-	0x05, 0x00, 0x00,
+	// 1320 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0xcd, 0x6e, 0x1b, 0xb7,
+	0x16, 0xce, 0xc4, 0xb1, 0x34, 0xa2, 0xa4, 0x58, 0xa6, 0x7f, 0xee, 0xd8, 0xc9, 0x8d, 0x05, 0xe5,
+	0x02, 0x57, 0x0d, 0x60, 0xa9, 0x56, 0x9c, 0x04, 0xcd, 0x4e, 0x96, 0x9c, 0xc2, 0x68, 0xe3, 0xb6,
+	0x94, 0x93, 0x45, 0x51, 0x80, 0xa5, 0x86, 0x94, 0x4c, 0x68, 0xc4, 0x99, 0x72, 0x38, 0xfa, 0x79,
+	0x98, 0xbe, 0x44, 0x37, 0x5d, 0x77, 0x51, 0xa0, 0xcb, 0xae, 0xbd, 0x30, 0x8a, 0x00, 0x7d, 0x8f,
+	0x82, 0xa4, 0x46, 0xa3, 0x18, 0x08, 0x82, 0x78, 0xd5, 0x95, 0x78, 0xce, 0xf9, 0xf4, 0x9d, 0x1f,
+	0x7e, 0x73, 0x66, 0x40, 0x7b, 0xc8, 0xd5, 0x65, 0xd2, 0x6f, 0xf8, 0xe1, 0xb8, 0x79, 0x71, 0xc9,
+	0x2e, 0x2e, 0xb9, 0x18, 0xc6, 0xe7, 0x4c, 0x4d, 0x43, 0x39, 0x6a, 0x2a, 0x25, 0x9a, 0x24, 0xe2,
+	0xcd, 0x48, 0x86, 0x2a, 0xf4, 0xc3, 0xa0, 0x19, 0x84, 0x92, 0x4c, 0x89, 0x68, 0x52, 0x36, 0xe1,
+	0x3e, 0x6b, 0x18, 0x3f, 0xcc, 0x2f, 0xbc, 0xfb, 0x0f, 0x86, 0x61, 0x38, 0x0c, 0x98, 0x85, 0xf7,
+	0x93, 0x41, 0x93, 0x8d, 0x23, 0x35, 0xb7, 0xa8, 0xfd, 0xc3, 0x95, 0x44, 0xc3, 0x70, 0x18, 0x66,
+	0x28, 0x6d, 0x19, 0xc3, 0x9c, 0x2c, 0xbc, 0xf6, 0x8b, 0x03, 0x2a, 0x5d, 0x93, 0xe5, 0x8c, 0x32,
+	0xa1, 0xf8, 0x80, 0x33, 0x09, 0xcf, 0x41, 0x9e, 0x44, 0x11, 0x66, 0x09, 0xf7, 0x9c, 0xaa, 0x53,
+	0x2f, 0x9d, 0x3c, 0xbb, 0xba, 0x3e, 0x38, 0xfa, 0x58, 0x07, 0x7e, 0x28, 0x59, 0x53, 0xcd, 0x23,
+	0x16, 0x37, 0xda, 0x51, 0x74, 0xfa, 0xe6, 0x0c, 0xe5, 0x48, 0x14, 0x9d, 0x26, 0x5c, 0xf3, 0x51,
+	0x36, 0x31, 0x7c, 0x77, 0x6f, 0xc5, 0xd7, 0x65, 0x13, 0xc3, 0x47, 0xd9, 0xe4, 0x34, 0xe1, 0xb5,
+	0x9f, 0x4b, 0x20, 0x67, 0x8b, 0xfe, 0xb7, 0x97, 0x0a, 0x77, 0x80, 0x66, 0xc6, 0x9c, 0x7a, 0x6b,
+	0x55, 0xa7, 0x5e, 0x40, 0xeb, 0x24, 0x8a, 0xce, 0xa8, 0x76, 0xeb, 0x34, 0x9c, 0x7a, 0xf7, 0xac,
+	0x9b, 0xb2, 0xc9, 0x19, 0x85, 0xdf, 0x01, 0x57, 0xbb, 0x09, 0xa5, 0xd2, 0x5b, 0x37, 0xe9, 0x9f,
+	0x5f, 0x5d, 0x1f, 0xb4, 0x3e, 0x2d, 0x7d, 0x9b, 0x52, 0x89, 0xf2, 0xd4, 0x1e, 0x20, 0x02, 0x05,
+	0x31, 0x1d, 0xe1, 0x18, 0x8f, 0xd8, 0xdc, 0xcb, 0xdd, 0x8a, 0xf3, 0x7c, 0x3a, 0xea, 0x7d, 0xc5,
+	0xe6, 0x28, 0x2f, 0xec, 0x41, 0x73, 0xea, 0xa6, 0x2c, 0x67, 0xfe, 0x56, 0x9c, 0xed, 0x28, 0xb2,
+	0x9c, 0xc4, 0x1e, 0xd2, 0x8b, 0xd4, 0x8c, 0xee, 0x6d, 0x2f, 0x52, 0x13, 0xea, 0x71, 0x6b, 0x3e,
+	0x0f, 0xb8, 0x03, 0xec, 0x0b, 0x85, 0x93, 0xc8, 0x2b, 0x54, 0x9d, 0x7a, 0x19, 0xe5, 0x06, 0x1d,
+	0xa1, 0xde, 0x44, 0xf0, 0x21, 0x00, 0x36, 0x42, 0xc3, 0xa9, 0xf0, 0x80, 0x89, 0xb9, 0x3a, 0xd6,
+	0x0d, 0xa7, 0x02, 0x1e, 0x82, 0x2d, 0xca, 0x63, 0xd2, 0x0f, 0x18, 0xb6, 0x28, 0xff, 0x92, 0xf9,
+	0x23, 0xaf, 0x58, 0x75, 0xea, 0x2e, 0xaa, 0x2c, 0x42, 0xaf, 0x3a, 0x42, 0x75, 0xb4, 0x1f, 0xfe,
+	0x1f, 0x54, 0x92, 0x98, 0xc5, 0x4f, 0x5b, 0xb8, 0xcf, 0x95, 0xfd, 0x87, 0x57, 0x32, 0xd8, 0xb2,
+	0xf5, 0x9f, 0x70, 0xa5, 0xd1, 0xf0, 0x19, 0xd8, 0x25, 0xbe, 0xe2, 0x13, 0xa2, 0x78, 0x28, 0xb0,
+	0x1f, 0x8a, 0x58, 0x49, 0xc2, 0x85, 0x8a, 0xbd, 0xb2, 0x51, 0xc0, 0x4e, 0x16, 0xed, 0x64, 0x41,
+	0xf8, 0x02, 0x94, 0xec, 0x12, 0xc0, 0x7e, 0x40, 0xe2, 0xd8, 0xbb, 0x5f, 0x75, 0xea, 0xf7, 0x5b,
+	0xdb, 0x8d, 0xc5, 0x2e, 0x68, 0xd8, 0xc7, 0xa0, 0xa3, 0x63, 0xa8, 0x48, 0x33, 0x03, 0x7e, 0x06,
+	0x2a, 0x7d, 0x46, 0xfc, 0x50, 0xe0, 0x81, 0x64, 0x3f, 0x25, 0x4c, 0xf8, 0x73, 0x6f, 0xc3, 0xf4,
+	0xba, 0x61, 0xfd, 0xaf, 0x52, 0x37, 0x3c, 0x06, 0xc5, 0x31, 0xf1, 0xf1, 0x84, 0xc9, 0x98, 0x87,
+	0xc2, 0xab, 0x98, 0x14, 0x5b, 0xcb, 0x14, 0xaf, 0xdb, 0x9d, 0xb7, 0x36, 0x84, 0xc0, 0x98, 0xf8,
+	0x8b, 0xb3, 0xbe, 0x30, 0x2d, 0x2c, 0x7d, 0x61, 0x9b, 0xb7, 0xba, 0xb0, 0xf3, 0xe9, 0xc8, 0x5c,
+	0x98, 0x30, 0xbf, 0xf0, 0x47, 0xb0, 0x11, 0x63, 0x2b, 0x55, 0x2e, 0x94, 0xe1, 0x85, 0x86, 0xf7,
+	0xe5, 0xd5, 0xf5, 0xc1, 0xf3, 0x4f, 0xe0, 0xed, 0x69, 0xbd, 0x9e, 0x09, 0xa5, 0xc9, 0x8b, 0x71,
+	0x66, 0xc0, 0x1f, 0x40, 0xd9, 0xf2, 0x33, 0xe1, 0x1b, 0xfe, 0x2d, 0xc3, 0xff, 0xc5, 0xd5, 0xf5,
+	0xc1, 0xb3, 0x4f, 0x7c, 0x1c, 0x4e, 0x85, 0xaf, 0xe9, 0x81, 0x58, 0x9e, 0xe1, 0x01, 0x28, 0x11,
+	0xbc, 0x22, 0xac, 0x6d, 0x33, 0xec, 0x02, 0x79, 0x95, 0x2a, 0xeb, 0x01, 0x28, 0x04, 0x24, 0x56,
+	0x38, 0x66, 0x4c, 0x78, 0x3b, 0x55, 0xa7, 0xbe, 0x86, 0x5c, 0xed, 0xe8, 0x31, 0x26, 0xe0, 0x63,
+	0x50, 0x26, 0x54, 0x62, 0x12, 0x0c, 0x43, 0xc9, 0xd5, 0xe5, 0xd8, 0xdb, 0x35, 0xaa, 0x28, 0x11,
+	0x2a, 0xdb, 0xa9, 0x0f, 0xd6, 0x2c, 0x88, 0x12, 0x45, 0xb0, 0x24, 0x8a, 0x79, 0xff, 0x31, 0xa0,
+	0x22, 0xa1, 0xb2, 0x4b, 0x14, 0x41, 0x44, 0x31, 0xd8, 0x00, 0x05, 0x8d, 0x89, 0x95, 0x8e, 0x7b,
+	0x55, 0xa7, 0x5e, 0x6c, 0x6d, 0x2e, 0xaf, 0xb2, 0xdd, 0x45, 0x3d, 0x1d, 0x40, 0x2e, 0xa1, 0xd2,
+	0x9c, 0x60, 0x03, 0x6c, 0x45, 0x5c, 0x0c, 0x71, 0x1c, 0x84, 0x6a, 0x45, 0x2a, 0x7b, 0xa6, 0xfa,
+	0x4d, 0x1d, 0xea, 0x05, 0xa1, 0xca, 0xc4, 0x72, 0xb8, 0x8a, 0xcf, 0x2a, 0xd9, 0x37, 0x95, 0x54,
+	0x52, 0xfc, 0xb2, 0x9c, 0x97, 0xa0, 0xbc, 0xd0, 0xaf, 0xae, 0x28, 0x89, 0xbd, 0x07, 0xa6, 0xa4,
+	0x9d, 0x1b, 0x02, 0xee, 0x99, 0x20, 0x2a, 0xd1, 0x15, 0x4b, 0x6b, 0x5f, 0xeb, 0x32, 0x66, 0x4a,
+	0xe9, 0xeb, 0xf0, 0x1e, 0x9a, 0xbf, 0x6e, 0xaf, 0x0a, 0xb3, 0xb7, 0x88, 0x21, 0xad, 0xe0, 0xd4,
+	0xa8, 0xfd, 0xee, 0x00, 0x37, 0x6d, 0x15, 0x3e, 0x04, 0x85, 0x6c, 0xaa, 0x8e, 0x29, 0x33, 0x73,
+	0xc0, 0x5d, 0x90, 0x1b, 0x13, 0x39, 0xe4, 0xc2, 0xac, 0xfb, 0x75, 0xb4, 0xb0, 0xf4, 0x65, 0x65,
+	0xcd, 0xd9, 0xd5, 0xed, 0xd2, 0xb4, 0xa9, 0x3d, 0xe0, 0xaa, 0x19, 0x8e, 0xc2, 0x29, 0x93, 0x66,
+	0x7f, 0xaf, 0xa3, 0xbc, 0x9a, 0x7d, 0xab, 0x4d, 0x1d, 0x12, 0x7d, 0xac, 0x24, 0x11, 0xb1, 0xd9,
+	0xe0, 0x65, 0x94, 0x17, 0xfd, 0x0b, 0x6d, 0x42, 0x0f, 0xe4, 0x23, 0x26, 0x28, 0x17, 0x43, 0xb3,
+	0x87, 0x5d, 0x94, 0x9a, 0xba, 0x88, 0x01, 0xe1, 0x01, 0xa3, 0x5e, 0x7e, 0xb1, 0xa9, 0x8c, 0x55,
+	0xc3, 0xa0, 0xb4, 0x3a, 0x1e, 0xcd, 0xd0, 0x27, 0x4a, 0x31, 0x39, 0x37, 0x8d, 0x94, 0x51, 0x6a,
+	0x7e, 0xb0, 0x8d, 0xff, 0x02, 0x90, 0x44, 0x94, 0x28, 0x46, 0x31, 0x51, 0xa6, 0x8f, 0x35, 0x54,
+	0x58, 0x78, 0xda, 0xaa, 0xf6, 0xf7, 0x5d, 0x50, 0x5c, 0x99, 0x22, 0xfc, 0x1f, 0xb8, 0x3f, 0x26,
+	0x33, 0x4c, 0x13, 0x35, 0xc7, 0xfe, 0xdc, 0x0f, 0xd8, 0x22, 0x4f, 0x69, 0x4c, 0x66, 0xdd, 0x44,
+	0xcd, 0x3b, 0xda, 0xa7, 0x67, 0x23, 0x67, 0x47, 0x98, 0xb2, 0x80, 0xcc, 0x4d, 0xbe, 0x32, 0x72,
+	0xe5, 0xec, 0xa8, 0xab, 0x6d, 0xad, 0x51, 0x13, 0x94, 0x38, 0x1c, 0x0c, 0x62, 0x66, 0x93, 0x96,
+	0x51, 0x51, 0x03, 0xe4, 0x37, 0xc6, 0x65, 0x31, 0xad, 0x15, 0xf5, 0xdc, 0x4b, 0x31, 0xad, 0xa5,
+	0x70, 0x1e, 0x5b, 0x4c, 0xa6, 0x48, 0x3b, 0xcd, 0x92, 0x9c, 0xb5, 0x32, 0x31, 0x36, 0x81, 0xeb,
+	0x5f, 0x12, 0x21, 0x58, 0x10, 0x7b, 0xb9, 0xea, 0x5a, 0xbd, 0xf8, 0xfe, 0xda, 0xea, 0xd8, 0x18,
+	0x5a, 0x82, 0xe0, 0x13, 0xb0, 0x99, 0x44, 0x01, 0x17, 0x23, 0x4c, 0xa7, 0x2c, 0x08, 0xb0, 0xe2,
+	0x63, 0x66, 0x86, 0xee, 0xa2, 0x0d, 0x1b, 0xe8, 0x6a, 0xff, 0x05, 0x1f, 0x9b, 0x27, 0x43, 0x3f,
+	0xc8, 0x37, 0xd1, 0xae, 0x41, 0x6f, 0xa6, 0xa1, 0x0c, 0xbf, 0x07, 0x5c, 0x3d, 0x3c, 0xc6, 0x65,
+	0xfa, 0xc6, 0xc9, 0x8f, 0xc9, 0xec, 0x94, 0xcb, 0xa8, 0xf6, 0xab, 0x03, 0x40, 0x56, 0x0f, 0xdc,
+	0x06, 0xeb, 0x5c, 0x50, 0x36, 0x5b, 0x4c, 0xd7, 0x1a, 0x5a, 0xa8, 0x59, 0xb7, 0x76, 0xac, 0x99,
+	0x43, 0xcf, 0x6c, 0xcc, 0x05, 0x7e, 0x5f, 0x94, 0x65, 0x54, 0x1c, 0x73, 0xb1, 0x9c, 0x99, 0xc6,
+	0x90, 0xd9, 0x0a, 0x66, 0x31, 0x57, 0x7d, 0x7b, 0x29, 0xe6, 0x10, 0xc0, 0x65, 0x57, 0x37, 0x87,
+	0xbb, 0x6c, 0x6a, 0x39, 0xe1, 0x27, 0xc7, 0xa0, 0xb8, 0xf2, 0x8a, 0x81, 0x45, 0x90, 0xef, 0x7c,
+	0xdd, 0xee, 0xf5, 0x70, 0xbb, 0x72, 0x27, 0x33, 0x4e, 0x2a, 0x4e, 0x66, 0x74, 0x2a, 0x77, 0x9f,
+	0xd4, 0x4d, 0xbb, 0xe9, 0x9b, 0xa2, 0x04, 0xdc, 0xd7, 0xed, 0x0e, 0x7e, 0x7b, 0x84, 0x3f, 0xaf,
+	0xdc, 0x59, 0xb1, 0x8e, 0x2a, 0x4e, 0xeb, 0x37, 0x07, 0x94, 0x6d, 0x82, 0xd7, 0x44, 0x90, 0x21,
+	0x93, 0xf0, 0x05, 0x28, 0x7c, 0xc9, 0x94, 0xf5, 0xc1, 0xbd, 0x1b, 0x7b, 0x22, 0xfb, 0x48, 0xdd,
+	0xdf, 0xb8, 0x11, 0x82, 0xc7, 0xa0, 0xd0, 0x5b, 0xfe, 0xf1, 0x66, 0x74, 0x7f, 0xb7, 0x61, 0xbf,
+	0x9a, 0x1b, 0xe9, 0xf7, 0x70, 0xe3, 0x54, 0x7f, 0x35, 0xc3, 0xb6, 0x7e, 0xc6, 0x02, 0xa6, 0xd8,
+	0xc7, 0x33, 0x7e, 0x80, 0xe2, 0xe4, 0xe4, 0x8f, 0x77, 0x8f, 0x9c, 0x3f, 0xdf, 0x3d, 0x72, 0xfe,
+	0x7a, 0xf7, 0xc8, 0xf9, 0xfe, 0xf8, 0x36, 0x5f, 0xfa, 0xfd, 0x9c, 0xf1, 0x3c, 0xfd, 0x67, 0x00,
+	0xbb, 0xa3, 0xa7, 0xb4, 0x28, 0x0c, 0x00, 0x00,
 }
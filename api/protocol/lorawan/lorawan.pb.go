@@ -11,6 +11,7 @@
 	It has these top-level messages:
 		Metadata
 		TxConfiguration
+		PingSlot
 		ActivationMetadata
 		Message
 		MHDR
@@ -66,6 +67,40 @@ func (x Modulation) String() string {
 }
 func (Modulation) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{0} }
 
+// The ConfirmedDownlinkState is the state of a confirmed downlink that was sent by the NetworkServer
+type ConfirmedDownlinkState int32
+
+const (
+	// There is no confirmed downlink that was sent to the device
+	ConfirmedDownlinkState_CONFIRMED_DOWNLINK_NONE ConfirmedDownlinkState = 0
+	// The confirmed downlink was not (yet) acknowledged, retransmissions use the same FCnt
+	ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING ConfirmedDownlinkState = 1
+	// The confirmed downlink was acknowledged by the device
+	ConfirmedDownlinkState_CONFIRMED_DOWNLINK_ACK ConfirmedDownlinkState = 2
+	// The confirmed downlink was not acknowledged within the maximum number of retransmissions or the timeout
+	ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT ConfirmedDownlinkState = 3
+)
+
+var ConfirmedDownlinkState_name = map[int32]string{
+	0: "CONFIRMED_DOWNLINK_NONE",
+	1: "CONFIRMED_DOWNLINK_PENDING",
+	2: "CONFIRMED_DOWNLINK_ACK",
+	3: "CONFIRMED_DOWNLINK_TIMEOUT",
+}
+var ConfirmedDownlinkState_value = map[string]int32{
+	"CONFIRMED_DOWNLINK_NONE":    0,
+	"CONFIRMED_DOWNLINK_PENDING": 1,
+	"CONFIRMED_DOWNLINK_ACK":     2,
+	"CONFIRMED_DOWNLINK_TIMEOUT": 3,
+}
+
+func (x ConfirmedDownlinkState) String() string {
+	return proto.EnumName(ConfirmedDownlinkState_name, int32(x))
+}
+func (ConfirmedDownlinkState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorLorawan, []int{1}
+}
+
 type FrequencyPlan int32
 
 const (
@@ -109,7 +144,7 @@ var FrequencyPlan_value = map[string]int32{
 func (x FrequencyPlan) String() string {
 	return proto.EnumName(FrequencyPlan_name, int32(x))
 }
-func (FrequencyPlan) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{1} }
+func (FrequencyPlan) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{2} }
 
 type Major int32
 
@@ -127,7 +162,7 @@ var Major_value = map[string]int32{
 func (x Major) String() string {
 	return proto.EnumName(Major_name, int32(x))
 }
-func (Major) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{2} }
+func (Major) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{3} }
 
 type MType int32
 
@@ -160,7 +195,7 @@ var MType_value = map[string]int32{
 func (x MType) String() string {
 	return proto.EnumName(MType_name, int32(x))
 }
-func (MType) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{3} }
+func (MType) EnumDescriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{4} }
 
 type Metadata struct {
 	Modulation Modulation `protobuf:"varint,11,opt,name=modulation,proto3,enum=lorawan.Modulation" json:"modulation,omitempty"`
@@ -173,6 +208,12 @@ type Metadata struct {
 	// Store the full 32 bit FCnt (deprecated; do not use)
 	FCnt          uint32        `protobuf:"varint,15,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
 	FrequencyPlan FrequencyPlan `protobuf:"varint,16,opt,name=frequency_plan,json=frequencyPlan,proto3,enum=lorawan.FrequencyPlan" json:"frequency_plan,omitempty"`
+	// The state of the last confirmed downlink of the device, as seen by the NetworkServer in this uplink message
+	ConfirmedDownlink ConfirmedDownlinkState `protobuf:"varint,17,opt,name=confirmed_downlink,json=confirmedDownlink,proto3,enum=lorawan.ConfirmedDownlinkState" json:"confirmed_downlink,omitempty"`
+	// The FCnt of the last confirmed downlink of the device
+	ConfirmedFCntDown uint32 `protobuf:"varint,18,opt,name=confirmed_f_cnt_down,json=confirmedFCntDown,proto3" json:"confirmed_f_cnt_down,omitempty"`
+	// The name of the frequency plan of the gateway, which can be a custom frequency plan that is based on the frequency_plan
+	FrequencyPlanName string `protobuf:"bytes,19,opt,name=frequency_plan_name,json=frequencyPlanName,proto3" json:"frequency_plan_name,omitempty"`
 }
 
 func (m *Metadata) Reset()                    { *m = Metadata{} }
@@ -222,6 +263,27 @@ func (m *Metadata) GetFrequencyPlan() FrequencyPlan {
 	return FrequencyPlan_EU_863_870
 }
 
+func (m *Metadata) GetConfirmedDownlink() ConfirmedDownlinkState {
+	if m != nil {
+		return m.ConfirmedDownlink
+	}
+	return ConfirmedDownlinkState_CONFIRMED_DOWNLINK_NONE
+}
+
+func (m *Metadata) GetConfirmedFCntDown() uint32 {
+	if m != nil {
+		return m.ConfirmedFCntDown
+	}
+	return 0
+}
+
+func (m *Metadata) GetFrequencyPlanName() string {
+	if m != nil {
+		return m.FrequencyPlanName
+	}
+	return ""
+}
+
 type TxConfiguration struct {
 	Modulation Modulation `protobuf:"varint,11,opt,name=modulation,proto3,enum=lorawan.Modulation" json:"modulation,omitempty"`
 	// LoRa data rate - SF{spreadingfactor}BW{bandwidth}
@@ -232,6 +294,8 @@ type TxConfiguration struct {
 	CodingRate string `protobuf:"bytes,14,opt,name=coding_rate,json=codingRate,proto3" json:"coding_rate,omitempty"`
 	// Store the full 32 bit FCnt (deprecated; do not use)
 	FCnt uint32 `protobuf:"varint,15,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// The ping slots of a Class B device, if set the downlink is sent in the first available ping slot
+	PingSlot *PingSlot `protobuf:"bytes,16,opt,name=ping_slot,json=pingSlot" json:"ping_slot,omitempty"`
 }
 
 func (m *TxConfiguration) Reset()                    { *m = TxConfiguration{} }
@@ -274,22 +338,53 @@ func (m *TxConfiguration) GetFCnt() uint32 {
 	return 0
 }
 
+func (m *TxConfiguration) GetPingSlot() *PingSlot {
+	if m != nil {
+		return m.PingSlot
+	}
+	return nil
+}
+
+type PingSlot struct {
+	// The DevAddr of the device, it is used for the randomization of the ping slots
+	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
+	// The number of ping slots in a beacon period (1-128)
+	PingNb uint32 `protobuf:"varint,2,opt,name=ping_nb,json=pingNb,proto3" json:"ping_nb,omitempty"`
+}
+
+func (m *PingSlot) Reset()                    { *m = PingSlot{} }
+func (m *PingSlot) String() string            { return proto.CompactTextString(m) }
+func (*PingSlot) ProtoMessage()               {}
+func (*PingSlot) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{2} }
+
+func (m *PingSlot) GetPingNb() uint32 {
+	if m != nil {
+		return m.PingNb
+	}
+	return 0
+}
+
 type ActivationMetadata struct {
-	AppEui        *github_com_TheThingsNetwork_ttn_core_types.AppEUI  `protobuf:"bytes,1,opt,name=app_eui,json=appEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppEUI" json:"app_eui,omitempty"`
-	DevEui        *github_com_TheThingsNetwork_ttn_core_types.DevEUI  `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevEUI" json:"dev_eui,omitempty"`
-	DevAddr       *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,3,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
-	NwkSKey       *github_com_TheThingsNetwork_ttn_core_types.NwkSKey `protobuf:"bytes,4,opt,name=nwk_s_key,json=nwkSKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkSKey" json:"nwk_s_key,omitempty"`
-	Rx1DrOffset   uint32                                              `protobuf:"varint,11,opt,name=rx1_dr_offset,json=rx1DrOffset,proto3" json:"rx1_dr_offset,omitempty"`
-	Rx2Dr         uint32                                              `protobuf:"varint,12,opt,name=rx2_dr,json=rx2Dr,proto3" json:"rx2_dr,omitempty"`
-	RxDelay       uint32                                              `protobuf:"varint,13,opt,name=rx_delay,json=rxDelay,proto3" json:"rx_delay,omitempty"`
-	CfList        *CFList                                             `protobuf:"bytes,14,opt,name=cf_list,json=cfList" json:"cf_list,omitempty"`
-	FrequencyPlan FrequencyPlan                                       `protobuf:"varint,15,opt,name=frequency_plan,json=frequencyPlan,proto3,enum=lorawan.FrequencyPlan" json:"frequency_plan,omitempty"`
+	AppEui  *github_com_TheThingsNetwork_ttn_core_types.AppEUI  `protobuf:"bytes,1,opt,name=app_eui,json=appEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppEUI" json:"app_eui,omitempty"`
+	DevEui  *github_com_TheThingsNetwork_ttn_core_types.DevEUI  `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevEUI" json:"dev_eui,omitempty"`
+	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,3,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
+	NwkSKey *github_com_TheThingsNetwork_ttn_core_types.NwkSKey `protobuf:"bytes,4,opt,name=nwk_s_key,json=nwkSKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkSKey" json:"nwk_s_key,omitempty"`
+	// The session keys of LoRaWAN 1.1 devices, the NwkSKey is the FNwkSIntKey
+	SNwkSIntKey   *github_com_TheThingsNetwork_ttn_core_types.SNwkSIntKey `protobuf:"bytes,5,opt,name=s_nwk_s_int_key,json=sNwkSIntKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.SNwkSIntKey" json:"s_nwk_s_int_key,omitempty"`
+	NwkSEncKey    *github_com_TheThingsNetwork_ttn_core_types.NwkSEncKey  `protobuf:"bytes,6,opt,name=nwk_s_enc_key,json=nwkSEncKey,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.NwkSEncKey" json:"nwk_s_enc_key,omitempty"`
+	Rx1DrOffset   uint32                                                  `protobuf:"varint,11,opt,name=rx1_dr_offset,json=rx1DrOffset,proto3" json:"rx1_dr_offset,omitempty"`
+	Rx2Dr         uint32                                                  `protobuf:"varint,12,opt,name=rx2_dr,json=rx2Dr,proto3" json:"rx2_dr,omitempty"`
+	RxDelay       uint32                                                  `protobuf:"varint,13,opt,name=rx_delay,json=rxDelay,proto3" json:"rx_delay,omitempty"`
+	CfList        *CFList                                                 `protobuf:"bytes,14,opt,name=cf_list,json=cfList" json:"cf_list,omitempty"`
+	FrequencyPlan FrequencyPlan                                           `protobuf:"varint,15,opt,name=frequency_plan,json=frequencyPlan,proto3,enum=lorawan.FrequencyPlan" json:"frequency_plan,omitempty"`
+	// The name of the frequency plan of the gateway, which can be a custom frequency plan that is based on the frequency_plan
+	FrequencyPlanName string `protobuf:"bytes,16,opt,name=frequency_plan_name,json=frequencyPlanName,proto3" json:"frequency_plan_name,omitempty"`
 }
 
 func (m *ActivationMetadata) Reset()                    { *m = ActivationMetadata{} }
 func (m *ActivationMetadata) String() string            { return proto.CompactTextString(m) }
 func (*ActivationMetadata) ProtoMessage()               {}
-func (*ActivationMetadata) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{2} }
+func (*ActivationMetadata) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{3} }
 
 func (m *ActivationMetadata) GetRx1DrOffset() uint32 {
 	if m != nil {
@@ -326,6 +421,13 @@ func (m *ActivationMetadata) GetFrequencyPlan() FrequencyPlan {
 	return FrequencyPlan_EU_863_870
 }
 
+func (m *ActivationMetadata) GetFrequencyPlanName() string {
+	if m != nil {
+		return m.FrequencyPlanName
+	}
+	return ""
+}
+
 type Message struct {
 	MHDR `protobuf:"bytes,1,opt,name=m_hdr,json=mHdr,embedded=m_hdr" json:"m_hdr"`
 	Mic  []byte `protobuf:"bytes,2,opt,name=mic,proto3" json:"mic,omitempty"`
@@ -339,7 +441,7 @@ type Message struct {
 func (m *Message) Reset()                    { *m = Message{} }
 func (m *Message) String() string            { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()               {}
-func (*Message) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{3} }
+func (*Message) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{4} }
 
 type isMessage_Payload interface {
 	isMessage_Payload()
@@ -497,7 +599,7 @@ type MHDR struct {
 func (m *MHDR) Reset()                    { *m = MHDR{} }
 func (m *MHDR) String() string            { return proto.CompactTextString(m) }
 func (*MHDR) ProtoMessage()               {}
-func (*MHDR) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{4} }
+func (*MHDR) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{5} }
 
 func (m *MHDR) GetMType() MType {
 	if m != nil {
@@ -522,7 +624,7 @@ type MACPayload struct {
 func (m *MACPayload) Reset()                    { *m = MACPayload{} }
 func (m *MACPayload) String() string            { return proto.CompactTextString(m) }
 func (*MACPayload) ProtoMessage()               {}
-func (*MACPayload) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{5} }
+func (*MACPayload) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{6} }
 
 func (m *MACPayload) GetFPort() int32 {
 	if m != nil {
@@ -548,7 +650,7 @@ type FHDR struct {
 func (m *FHDR) Reset()                    { *m = FHDR{} }
 func (m *FHDR) String() string            { return proto.CompactTextString(m) }
 func (*FHDR) ProtoMessage()               {}
-func (*FHDR) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{6} }
+func (*FHDR) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{7} }
 
 func (m *FHDR) GetFCnt() uint32 {
 	if m != nil {
@@ -574,7 +676,7 @@ type FCtrl struct {
 func (m *FCtrl) Reset()                    { *m = FCtrl{} }
 func (m *FCtrl) String() string            { return proto.CompactTextString(m) }
 func (*FCtrl) ProtoMessage()               {}
-func (*FCtrl) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{7} }
+func (*FCtrl) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{8} }
 
 func (m *FCtrl) GetAdr() bool {
 	if m != nil {
@@ -612,7 +714,7 @@ type MACCommand struct {
 func (m *MACCommand) Reset()                    { *m = MACCommand{} }
 func (m *MACCommand) String() string            { return proto.CompactTextString(m) }
 func (*MACCommand) ProtoMessage()               {}
-func (*MACCommand) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{8} }
+func (*MACCommand) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{9} }
 
 func (m *MACCommand) GetCid() uint32 {
 	if m != nil {
@@ -637,7 +739,7 @@ type JoinRequestPayload struct {
 func (m *JoinRequestPayload) Reset()                    { *m = JoinRequestPayload{} }
 func (m *JoinRequestPayload) String() string            { return proto.CompactTextString(m) }
 func (*JoinRequestPayload) ProtoMessage()               {}
-func (*JoinRequestPayload) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{9} }
+func (*JoinRequestPayload) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{10} }
 
 type JoinAcceptPayload struct {
 	Encrypted  []byte                                              `protobuf:"bytes,1,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
@@ -652,7 +754,7 @@ type JoinAcceptPayload struct {
 func (m *JoinAcceptPayload) Reset()                    { *m = JoinAcceptPayload{} }
 func (m *JoinAcceptPayload) String() string            { return proto.CompactTextString(m) }
 func (*JoinAcceptPayload) ProtoMessage()               {}
-func (*JoinAcceptPayload) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{10} }
+func (*JoinAcceptPayload) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{11} }
 
 func (m *JoinAcceptPayload) GetEncrypted() []byte {
 	if m != nil {
@@ -683,7 +785,7 @@ type DLSettings struct {
 func (m *DLSettings) Reset()                    { *m = DLSettings{} }
 func (m *DLSettings) String() string            { return proto.CompactTextString(m) }
 func (*DLSettings) ProtoMessage()               {}
-func (*DLSettings) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{11} }
+func (*DLSettings) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{12} }
 
 func (m *DLSettings) GetRx1DrOffset() uint32 {
 	if m != nil {
@@ -706,7 +808,7 @@ type CFList struct {
 func (m *CFList) Reset()                    { *m = CFList{} }
 func (m *CFList) String() string            { return proto.CompactTextString(m) }
 func (*CFList) ProtoMessage()               {}
-func (*CFList) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{12} }
+func (*CFList) Descriptor() ([]byte, []int) { return fileDescriptorLorawan, []int{13} }
 
 func (m *CFList) GetFreq() []uint32 {
 	if m != nil {
@@ -718,6 +820,7 @@ func (m *CFList) GetFreq() []uint32 {
 func init() {
 	proto.RegisterType((*Metadata)(nil), "lorawan.Metadata")
 	proto.RegisterType((*TxConfiguration)(nil), "lorawan.TxConfiguration")
+	proto.RegisterType((*PingSlot)(nil), "lorawan.PingSlot")
 	proto.RegisterType((*ActivationMetadata)(nil), "lorawan.ActivationMetadata")
 	proto.RegisterType((*Message)(nil), "lorawan.Message")
 	proto.RegisterType((*MHDR)(nil), "lorawan.MHDR")
@@ -730,6 +833,7 @@ func init() {
 	proto.RegisterType((*DLSettings)(nil), "lorawan.DLSettings")
 	proto.RegisterType((*CFList)(nil), "lorawan.CFList")
 	proto.RegisterEnum("lorawan.Modulation", Modulation_name, Modulation_value)
+	proto.RegisterEnum("lorawan.ConfirmedDownlinkState", ConfirmedDownlinkState_name, ConfirmedDownlinkState_value)
 	proto.RegisterEnum("lorawan.FrequencyPlan", FrequencyPlan_name, FrequencyPlan_value)
 	proto.RegisterEnum("lorawan.Major", Major_name, Major_value)
 	proto.RegisterEnum("lorawan.MType", MType_name, MType_value)
@@ -783,6 +887,28 @@ func (m *Metadata) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.FrequencyPlan))
 	}
+	if m.ConfirmedDownlink != 0 {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.ConfirmedDownlink))
+	}
+	if m.ConfirmedFCntDown != 0 {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.ConfirmedFCntDown))
+	}
+	if len(m.FrequencyPlanName) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(len(m.FrequencyPlanName)))
+		i += copy(dAtA[i:], m.FrequencyPlanName)
+	}
 	return i, nil
 }
 
@@ -828,6 +954,51 @@ func (m *TxConfiguration) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.FCnt))
 	}
+	if m.PingSlot != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.PingSlot.Size()))
+		n1, err := m.PingSlot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	return i, nil
+}
+
+func (m *PingSlot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PingSlot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.DevAddr != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.DevAddr.Size()))
+		n2, err := m.DevAddr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.PingNb != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.PingNb))
+	}
 	return i, nil
 }
 
@@ -850,41 +1021,61 @@ func (m *ActivationMetadata) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.AppEui.Size()))
-		n1, err := m.AppEui.MarshalTo(dAtA[i:])
+		n3, err := m.AppEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n1
+		i += n3
 	}
 	if m.DevEui != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.DevEui.Size()))
-		n2, err := m.DevEui.MarshalTo(dAtA[i:])
+		n4, err := m.DevEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n2
+		i += n4
 	}
 	if m.DevAddr != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.DevAddr.Size()))
-		n3, err := m.DevAddr.MarshalTo(dAtA[i:])
+		n5, err := m.DevAddr.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n5
 	}
 	if m.NwkSKey != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.NwkSKey.Size()))
-		n4, err := m.NwkSKey.MarshalTo(dAtA[i:])
+		n6, err := m.NwkSKey.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n6
+	}
+	if m.SNwkSIntKey != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.SNwkSIntKey.Size()))
+		n7, err := m.SNwkSIntKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.NwkSEncKey != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(m.NwkSEncKey.Size()))
+		n8, err := m.NwkSEncKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
 	if m.Rx1DrOffset != 0 {
 		dAtA[i] = 0x58
@@ -905,17 +1096,25 @@ func (m *ActivationMetadata) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x72
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.CfList.Size()))
-		n5, err := m.CfList.MarshalTo(dAtA[i:])
+		n9, err := m.CfList.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n9
 	}
 	if m.FrequencyPlan != 0 {
 		dAtA[i] = 0x78
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.FrequencyPlan))
 	}
+	if len(m.FrequencyPlanName) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintLorawan(dAtA, i, uint64(len(m.FrequencyPlanName)))
+		i += copy(dAtA[i:], m.FrequencyPlanName)
+	}
 	return i, nil
 }
 
@@ -937,11 +1136,11 @@ func (m *Message) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.MHDR.Size()))
-	n6, err := m.MHDR.MarshalTo(dAtA[i:])
+	n10, err := m.MHDR.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n10
 	if len(m.Mic) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -949,11 +1148,11 @@ func (m *Message) MarshalTo(dAtA []byte) (int, error) {
 		i += copy(dAtA[i:], m.Mic)
 	}
 	if m.Payload != nil {
-		nn7, err := m.Payload.MarshalTo(dAtA[i:])
+		nn11, err := m.Payload.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn7
+		i += nn11
 	}
 	return i, nil
 }
@@ -964,11 +1163,11 @@ func (m *Message_MacPayload) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.MacPayload.Size()))
-		n8, err := m.MacPayload.MarshalTo(dAtA[i:])
+		n12, err := m.MacPayload.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n12
 	}
 	return i, nil
 }
@@ -978,11 +1177,11 @@ func (m *Message_JoinRequestPayload) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.JoinRequestPayload.Size()))
-		n9, err := m.JoinRequestPayload.MarshalTo(dAtA[i:])
+		n13, err := m.JoinRequestPayload.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n13
 	}
 	return i, nil
 }
@@ -992,11 +1191,11 @@ func (m *Message_JoinAcceptPayload) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.JoinAcceptPayload.Size()))
-		n10, err := m.JoinAcceptPayload.MarshalTo(dAtA[i:])
+		n14, err := m.JoinAcceptPayload.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n14
 	}
 	return i, nil
 }
@@ -1046,11 +1245,11 @@ func (m *MACPayload) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.FHDR.Size()))
-	n11, err := m.FHDR.MarshalTo(dAtA[i:])
+	n15, err := m.FHDR.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n11
+	i += n15
 	if m.FPort != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -1083,19 +1282,19 @@ func (m *FHDR) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.DevAddr.Size()))
-	n12, err := m.DevAddr.MarshalTo(dAtA[i:])
+	n16, err := m.DevAddr.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n12
+	i += n16
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.FCtrl.Size()))
-	n13, err := m.FCtrl.MarshalTo(dAtA[i:])
+	n17, err := m.FCtrl.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n13
+	i += n17
 	if m.FCnt != 0 {
 		dAtA[i] = 0x18
 		i++
@@ -1221,27 +1420,27 @@ func (m *JoinRequestPayload) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.AppEui.Size()))
-	n14, err := m.AppEui.MarshalTo(dAtA[i:])
+	n18, err := m.AppEui.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n14
+	i += n18
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.DevEui.Size()))
-	n15, err := m.DevEui.MarshalTo(dAtA[i:])
+	n19, err := m.DevEui.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n15
+	i += n19
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.DevNonce.Size()))
-	n16, err := m.DevNonce.MarshalTo(dAtA[i:])
+	n20, err := m.DevNonce.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n16
+	i += n20
 	return i, nil
 }
 
@@ -1269,35 +1468,35 @@ func (m *JoinAcceptPayload) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.AppNonce.Size()))
-	n17, err := m.AppNonce.MarshalTo(dAtA[i:])
+	n21, err := m.AppNonce.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n17
+	i += n21
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.NetId.Size()))
-	n18, err := m.NetId.MarshalTo(dAtA[i:])
+	n22, err := m.NetId.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n18
+	i += n22
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.DevAddr.Size()))
-	n19, err := m.DevAddr.MarshalTo(dAtA[i:])
+	n23, err := m.DevAddr.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n19
+	i += n23
 	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintLorawan(dAtA, i, uint64(m.DLSettings.Size()))
-	n20, err := m.DLSettings.MarshalTo(dAtA[i:])
+	n24, err := m.DLSettings.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n20
+	i += n24
 	if m.RxDelay != 0 {
 		dAtA[i] = 0x30
 		i++
@@ -1307,11 +1506,11 @@ func (m *JoinAcceptPayload) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintLorawan(dAtA, i, uint64(m.CfList.Size()))
-		n21, err := m.CfList.MarshalTo(dAtA[i:])
+		n25, err := m.CfList.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n25
 	}
 	return i, nil
 }
@@ -1360,21 +1559,21 @@ func (m *CFList) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Freq) > 0 {
-		dAtA23 := make([]byte, len(m.Freq)*10)
-		var j22 int
+		dAtA27 := make([]byte, len(m.Freq)*10)
+		var j26 int
 		for _, num := range m.Freq {
 			for num >= 1<<7 {
-				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA27[j26] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j22++
+				j26++
 			}
-			dAtA23[j22] = uint8(num)
-			j22++
+			dAtA27[j26] = uint8(num)
+			j26++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintLorawan(dAtA, i, uint64(j22))
-		i += copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintLorawan(dAtA, i, uint64(j26))
+		i += copy(dAtA[i:], dAtA27[:j26])
 	}
 	return i, nil
 }
@@ -1429,6 +1628,16 @@ func (m *Metadata) Size() (n int) {
 	if m.FrequencyPlan != 0 {
 		n += 2 + sovLorawan(uint64(m.FrequencyPlan))
 	}
+	if m.ConfirmedDownlink != 0 {
+		n += 2 + sovLorawan(uint64(m.ConfirmedDownlink))
+	}
+	if m.ConfirmedFCntDown != 0 {
+		n += 2 + sovLorawan(uint64(m.ConfirmedFCntDown))
+	}
+	l = len(m.FrequencyPlanName)
+	if l > 0 {
+		n += 2 + l + sovLorawan(uint64(l))
+	}
 	return n
 }
 
@@ -1452,6 +1661,23 @@ func (m *TxConfiguration) Size() (n int) {
 	if m.FCnt != 0 {
 		n += 1 + sovLorawan(uint64(m.FCnt))
 	}
+	if m.PingSlot != nil {
+		l = m.PingSlot.Size()
+		n += 2 + l + sovLorawan(uint64(l))
+	}
+	return n
+}
+
+func (m *PingSlot) Size() (n int) {
+	var l int
+	_ = l
+	if m.DevAddr != nil {
+		l = m.DevAddr.Size()
+		n += 1 + l + sovLorawan(uint64(l))
+	}
+	if m.PingNb != 0 {
+		n += 1 + sovLorawan(uint64(m.PingNb))
+	}
 	return n
 }
 
@@ -1474,6 +1700,14 @@ func (m *ActivationMetadata) Size() (n int) {
 		l = m.NwkSKey.Size()
 		n += 1 + l + sovLorawan(uint64(l))
 	}
+	if m.SNwkSIntKey != nil {
+		l = m.SNwkSIntKey.Size()
+		n += 1 + l + sovLorawan(uint64(l))
+	}
+	if m.NwkSEncKey != nil {
+		l = m.NwkSEncKey.Size()
+		n += 1 + l + sovLorawan(uint64(l))
+	}
 	if m.Rx1DrOffset != 0 {
 		n += 1 + sovLorawan(uint64(m.Rx1DrOffset))
 	}
@@ -1490,6 +1724,10 @@ func (m *ActivationMetadata) Size() (n int) {
 	if m.FrequencyPlan != 0 {
 		n += 1 + sovLorawan(uint64(m.FrequencyPlan))
 	}
+	l = len(m.FrequencyPlanName)
+	if l > 0 {
+		n += 2 + l + sovLorawan(uint64(l))
+	}
 	return n
 }
 
@@ -1850,6 +2088,73 @@ func (m *Metadata) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmedDownlink", wireType)
+			}
+			m.ConfirmedDownlink = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfirmedDownlink |= (ConfirmedDownlinkState(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmedFCntDown", wireType)
+			}
+			m.ConfirmedFCntDown = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfirmedFCntDown |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrequencyPlanName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FrequencyPlanName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLorawan(dAtA[iNdEx:])
@@ -2015,6 +2320,140 @@ func (m *TxConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PingSlot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PingSlot == nil {
+				m.PingSlot = &PingSlot{}
+			}
+			if err := m.PingSlot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLorawan(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PingSlot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLorawan
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PingSlot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PingSlot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
+			m.DevAddr = &v
+			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PingNb", wireType)
+			}
+			m.PingNb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PingNb |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLorawan(dAtA[iNdEx:])
@@ -2193,6 +2632,70 @@ func (m *ActivationMetadata) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SNwkSIntKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.SNwkSIntKey
+			m.SNwkSIntKey = &v
+			if err := m.SNwkSIntKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NwkSEncKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.NwkSEncKey
+			m.NwkSEncKey = &v
+			if err := m.NwkSEncKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 11:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Rx1DrOffset", wireType)
@@ -2302,6 +2805,35 @@ func (m *ActivationMetadata) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrequencyPlanName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLorawan
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLorawan
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FrequencyPlanName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLorawan(dAtA[iNdEx:])
@@ -3841,88 +4373,104 @@ func init() {
 }
 
 var fileDescriptorLorawan = []byte{
-	// 1327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcb, 0x52, 0x1b, 0xc7,
-	0x1a, 0x66, 0xd0, 0x8c, 0x24, 0x7e, 0x21, 0x18, 0xb7, 0xed, 0x73, 0x74, 0x6c, 0x17, 0x50, 0xaa,
-	0x73, 0xaa, 0x28, 0xea, 0x84, 0x8b, 0x64, 0x0c, 0x24, 0x65, 0x57, 0xe9, 0x46, 0x8c, 0x0d, 0x12,
-	0x6e, 0x50, 0x39, 0x95, 0x4d, 0x57, 0x33, 0xd3, 0x03, 0x83, 0x34, 0x17, 0xb7, 0x9a, 0x8b, 0xf2,
-	0x20, 0x59, 0xe4, 0x05, 0xb2, 0xc8, 0x36, 0x8b, 0x3c, 0x82, 0x97, 0xde, 0x64, 0xe3, 0x05, 0x95,
-	0xf2, 0x3a, 0x0f, 0x91, 0xea, 0x9e, 0xd1, 0x15, 0xc7, 0x29, 0x70, 0x16, 0x59, 0xcd, 0x7f, 0xfd,
-	0xfa, 0xef, 0xfe, 0x6f, 0x12, 0x94, 0x8f, 0x5d, 0x71, 0x72, 0x76, 0xb4, 0x6c, 0x05, 0xde, 0xca,
-	0xe1, 0x09, 0x3b, 0x3c, 0x71, 0xfd, 0xe3, 0x4e, 0x9d, 0x89, 0x8b, 0x80, 0xb7, 0x56, 0x84, 0xf0,
-	0x57, 0x68, 0xe8, 0xae, 0x84, 0x3c, 0x10, 0x81, 0x15, 0xb4, 0x57, 0xda, 0x01, 0xa7, 0x17, 0xd4,
-	0xef, 0x7d, 0x97, 0x95, 0x02, 0xa5, 0x62, 0xf6, 0xc1, 0x17, 0x43, 0x60, 0xc7, 0xc1, 0x71, 0x10,
-	0x39, 0x1e, 0x9d, 0x39, 0x8a, 0x53, 0x8c, 0xa2, 0x22, 0xbf, 0xfc, 0xef, 0x1a, 0xa4, 0xf7, 0x98,
-	0xa0, 0x36, 0x15, 0x14, 0x15, 0x01, 0xbc, 0xc0, 0x3e, 0x6b, 0x53, 0xe1, 0x06, 0x7e, 0x2e, 0xb3,
-	0xa0, 0x2d, 0xce, 0x14, 0xee, 0x2e, 0xf7, 0x0e, 0xda, 0xeb, 0xab, 0xf0, 0x90, 0x19, 0x7a, 0x08,
-	0x53, 0xd2, 0x99, 0x70, 0x2a, 0x58, 0x6e, 0x7a, 0x41, 0x5b, 0x9c, 0xc2, 0x69, 0x29, 0xc0, 0x54,
-	0x30, 0xf4, 0x1f, 0x48, 0x1f, 0xb9, 0x22, 0xd2, 0x65, 0x17, 0xb4, 0xc5, 0x2c, 0x4e, 0x1d, 0xb9,
-	0x42, 0xa9, 0xe6, 0x21, 0x63, 0x05, 0xb6, 0xeb, 0x1f, 0x47, 0xda, 0x19, 0xe5, 0x09, 0x91, 0x48,
-	0x19, 0xdc, 0x05, 0xc3, 0x21, 0x96, 0x2f, 0x72, 0xb3, 0xca, 0x51, 0x77, 0x2a, 0xbe, 0x40, 0x4f,
-	0x61, 0xc6, 0xe1, 0xec, 0xcd, 0x19, 0xf3, 0xad, 0x2e, 0x09, 0xdb, 0xd4, 0xcf, 0x99, 0x2a, 0xcc,
-	0x7f, 0xf5, 0xc3, 0xdc, 0xee, 0xa9, 0xf7, 0xdb, 0xd4, 0xc7, 0x59, 0x67, 0x98, 0xcd, 0xff, 0xac,
-	0xc1, 0xec, 0xe1, 0x65, 0x25, 0xf0, 0x1d, 0xf7, 0xf8, 0x8c, 0x47, 0x17, 0xf8, 0xe7, 0xdf, 0x3a,
-	0xff, 0x83, 0x0e, 0xa8, 0x64, 0x09, 0xf7, 0x5c, 0x1d, 0xde, 0xcf, 0x57, 0x1d, 0x52, 0x34, 0x0c,
-	0x09, 0x3b, 0x73, 0x73, 0xda, 0x82, 0xb6, 0x38, 0x5d, 0x5e, 0x7f, 0x7f, 0x35, 0xbf, 0xf6, 0x57,
-	0xd5, 0x64, 0x05, 0x9c, 0xad, 0x88, 0x6e, 0xc8, 0x3a, 0xcb, 0xa5, 0x30, 0xac, 0x35, 0x77, 0x70,
-	0x92, 0x86, 0x61, 0xed, 0xcc, 0x95, 0x78, 0x36, 0x3b, 0x57, 0x78, 0x93, 0xb7, 0xc2, 0xab, 0xb2,
-	0x73, 0x85, 0x67, 0xb3, 0x73, 0x89, 0xf7, 0x0a, 0xd2, 0x12, 0x8f, 0xda, 0x36, 0xcf, 0x25, 0x14,
-	0xe0, 0x93, 0xf7, 0x57, 0xf3, 0x85, 0x9b, 0x01, 0x96, 0x6c, 0x9b, 0x63, 0x19, 0x97, 0x24, 0x10,
-	0x86, 0x29, 0xff, 0xa2, 0x45, 0x3a, 0xa4, 0xc5, 0xba, 0x39, 0xfd, 0x56, 0x98, 0xf5, 0x8b, 0xd6,
-	0xc1, 0x4b, 0xd6, 0xc5, 0x29, 0x3f, 0x22, 0x50, 0x1e, 0xb2, 0xfc, 0x72, 0x8d, 0xd8, 0x9c, 0x04,
-	0x8e, 0xd3, 0x61, 0x42, 0xd5, 0x40, 0x16, 0x67, 0xf8, 0xe5, 0x5a, 0x95, 0x37, 0x94, 0x08, 0xdd,
-	0x87, 0x24, 0xbf, 0x2c, 0x10, 0x9b, 0xab, 0x64, 0x67, 0xb1, 0xc1, 0x2f, 0x0b, 0x55, 0x2e, 0x33,
-	0xcd, 0x2f, 0x89, 0xcd, 0xda, 0xb4, 0xdb, 0xcb, 0x34, 0xbf, 0xac, 0x4a, 0x16, 0x2d, 0x42, 0xca,
-	0x72, 0x48, 0xdb, 0xed, 0x08, 0x95, 0xe5, 0x4c, 0x61, 0xb6, 0x5f, 0x53, 0x95, 0xed, 0x5d, 0xb7,
-	0x23, 0x70, 0xd2, 0x72, 0xe4, 0xf7, 0x23, 0x35, 0x3d, 0x7b, 0x93, 0x9a, 0xfe, 0x69, 0x12, 0x52,
-	0x7b, 0xac, 0xd3, 0xa1, 0xc7, 0x0c, 0xfd, 0x1f, 0x0c, 0x8f, 0x9c, 0xd8, 0x5c, 0xd5, 0x43, 0xa6,
-	0x90, 0x1d, 0x94, 0xf1, 0xf3, 0x2a, 0x2e, 0xa7, 0xdf, 0x5e, 0xcd, 0x4f, 0xbc, 0xbb, 0x9a, 0xd7,
-	0xb0, 0xee, 0x3d, 0xb7, 0x39, 0x32, 0x21, 0xe1, 0xb9, 0x56, 0x94, 0x6b, 0x2c, 0x49, 0xf4, 0x04,
-	0x32, 0x1e, 0xb5, 0x48, 0x48, 0xbb, 0xed, 0x80, 0xda, 0x2a, 0x69, 0x99, 0xe1, 0x66, 0x28, 0x55,
-	0xf6, 0x23, 0xd5, 0xf3, 0x09, 0x0c, 0x1e, 0xb5, 0x62, 0x0e, 0x35, 0xe0, 0xde, 0x69, 0xe0, 0xfa,
-	0x44, 0x05, 0xd6, 0x11, 0x7d, 0x00, 0x5d, 0x01, 0x3c, 0xec, 0x03, 0xbc, 0x08, 0x5c, 0x1f, 0x47,
-	0x36, 0x03, 0x20, 0x74, 0x7a, 0x4d, 0x8a, 0x76, 0xe1, 0xae, 0x02, 0xa4, 0x96, 0xc5, 0xc2, 0x01,
-	0x9e, 0xa1, 0xf0, 0x1e, 0x8c, 0xe0, 0x95, 0x94, 0xc9, 0x00, 0xee, 0xce, 0xe9, 0xb8, 0xb0, 0x3c,
-	0x05, 0xa9, 0x98, 0xcc, 0x1f, 0x80, 0x2e, 0xdf, 0x02, 0xfd, 0x0f, 0x92, 0x1e, 0x91, 0x05, 0xa1,
-	0x9e, 0x6a, 0xa6, 0x30, 0x33, 0xb8, 0xe4, 0x61, 0x37, 0x64, 0xd8, 0xf0, 0xe4, 0x07, 0xfd, 0x17,
-	0x0c, 0x8f, 0x9e, 0x06, 0x5c, 0x3d, 0xd2, 0x88, 0x95, 0x94, 0xe2, 0x48, 0x99, 0xe7, 0x00, 0x83,
-	0xa7, 0x91, 0x49, 0x70, 0x3e, 0x9a, 0x84, 0xed, 0xb1, 0x24, 0x38, 0x32, 0x09, 0xf7, 0x21, 0xe9,
-	0x90, 0x30, 0xe0, 0x42, 0x1d, 0x61, 0x60, 0xc3, 0xd9, 0x0f, 0xb8, 0x90, 0x83, 0xc2, 0xe1, 0xde,
-	0x48, 0x26, 0xa6, 0x31, 0x38, 0xdc, 0xeb, 0x5d, 0xe4, 0x57, 0x0d, 0x74, 0x09, 0x88, 0x9a, 0x43,
-	0x5d, 0x16, 0x8d, 0x81, 0x2f, 0xe5, 0x11, 0x9f, 0xdb, 0x69, 0x2b, 0x32, 0x2e, 0x4b, 0xf0, 0xb6,
-	0x8a, 0x2b, 0x33, 0x74, 0xf5, 0xed, 0x8a, 0xe0, 0xed, 0xa1, 0x7b, 0x18, 0x8e, 0x14, 0x0c, 0x26,
-	0x57, 0x62, 0x68, 0x5e, 0xaf, 0x4a, 0x94, 0x20, 0x14, 0x9d, 0x9c, 0xbe, 0x90, 0x18, 0xaf, 0xa5,
-	0x4a, 0xe0, 0x79, 0xd4, 0xb7, 0xcb, 0xba, 0x84, 0xc2, 0x86, 0xd3, 0x08, 0x45, 0x27, 0x7f, 0x02,
-	0x86, 0x3a, 0x40, 0x56, 0x27, 0x8d, 0xaf, 0x94, 0xc6, 0x92, 0x44, 0x73, 0x90, 0xa1, 0x36, 0x27,
-	0xd4, 0x6a, 0xc9, 0x42, 0x53, 0x71, 0xa5, 0xf1, 0x14, 0xb5, 0x79, 0xc9, 0x6a, 0x61, 0xf6, 0x46,
-	0x79, 0x58, 0x2d, 0x75, 0xbe, 0xf4, 0xb0, 0x5a, 0x72, 0x4c, 0x3b, 0x24, 0x64, 0xbe, 0x1c, 0xaf,
-	0xaa, 0x18, 0xd3, 0x38, 0xed, 0xec, 0x47, 0x7c, 0x7e, 0x53, 0x65, 0x2d, 0x0e, 0x42, 0x3a, 0x5b,
-	0xae, 0xad, 0x8e, 0xcb, 0x62, 0x49, 0xa2, 0x1c, 0xa4, 0x7a, 0xcf, 0x1f, 0xb5, 0x48, 0x8f, 0xcd,
-	0x7f, 0x3f, 0x09, 0xe8, 0x7a, 0x29, 0x23, 0x3c, 0x3e, 0x8f, 0xb7, 0xe2, 0x44, 0x7c, 0xc6, 0x4c,
-	0xc6, 0xe3, 0x33, 0xf9, 0x36, 0x98, 0x63, 0x73, 0xf9, 0x1b, 0x98, 0x92, 0x98, 0x7e, 0xe0, 0x5b,
-	0x2c, 0x1e, 0xcc, 0x5f, 0xc5, 0xa8, 0xc5, 0x9b, 0xa1, 0xd6, 0x25, 0x04, 0x96, 0xf5, 0xa7, 0xa8,
-	0xfc, 0x2f, 0x09, 0xb8, 0x73, 0xad, 0x27, 0xd1, 0x23, 0x98, 0x62, 0xbe, 0xc5, 0xbb, 0xa1, 0x60,
-	0xd1, 0x03, 0x4f, 0xe3, 0x81, 0x40, 0x46, 0x23, 0x5f, 0x2d, 0x8a, 0x66, 0xf2, 0xd6, 0xd1, 0x94,
-	0xc2, 0x30, 0x8e, 0x86, 0xc6, 0x14, 0x6a, 0x40, 0xd2, 0x67, 0x82, 0xb8, 0x71, 0xfb, 0x94, 0x37,
-	0x63, 0xd8, 0xd5, 0x9b, 0x6c, 0x0b, 0x26, 0x76, 0xaa, 0xd8, 0xf0, 0x99, 0xd8, 0xb1, 0x47, 0x5a,
-	0x4d, 0xff, 0xfb, 0x5a, 0xed, 0x19, 0x64, 0xec, 0x36, 0xe9, 0x30, 0x21, 0xa4, 0x57, 0x3c, 0xe4,
-	0x06, 0x9d, 0x52, 0xdd, 0x3d, 0x88, 0x55, 0x43, 0x4d, 0x07, 0x76, 0xbb, 0x27, 0x1d, 0xd9, 0x42,
-	0xc9, 0x3f, 0xdd, 0x42, 0xa9, 0x4f, 0x6e, 0xa1, 0xfc, 0xd7, 0x00, 0x83, 0x83, 0xae, 0xef, 0x44,
-	0xed, 0x53, 0x3b, 0x71, 0x72, 0x68, 0x27, 0xe6, 0x1f, 0x41, 0x32, 0x82, 0x46, 0x08, 0x74, 0xb9,
-	0xaa, 0x72, 0xda, 0x42, 0x42, 0x0d, 0x04, 0xce, 0xde, 0x2c, 0xcd, 0x03, 0x0c, 0x7e, 0x52, 0xa1,
-	0x34, 0xe8, 0xbb, 0x0d, 0x5c, 0x32, 0x27, 0x50, 0x0a, 0x12, 0xdb, 0x07, 0x2f, 0x4d, 0x6d, 0xe9,
-	0x47, 0x0d, 0xb2, 0x23, 0xfb, 0x0e, 0xcd, 0x00, 0xd4, 0x9a, 0x64, 0xf3, 0x49, 0x91, 0x6c, 0x6e,
-	0xac, 0x9a, 0x13, 0x92, 0x6f, 0x1e, 0x90, 0xad, 0xd5, 0x02, 0xd9, 0x2a, 0x6c, 0x9a, 0x9a, 0xe4,
-	0x2b, 0x75, 0xb2, 0xb1, 0xb1, 0x45, 0x36, 0x36, 0x37, 0xcc, 0x49, 0x04, 0x90, 0xac, 0x35, 0xc9,
-	0xe3, 0x62, 0xd1, 0x4c, 0x48, 0x5d, 0xa9, 0x49, 0xb6, 0xd6, 0xd6, 0x95, 0xad, 0x1e, 0xdb, 0x3e,
-	0xde, 0x58, 0x25, 0xeb, 0x6b, 0xab, 0xa6, 0x21, 0x6d, 0x4b, 0x07, 0x64, 0xab, 0x50, 0x34, 0x93,
-	0xca, 0x56, 0xd2, 0xab, 0x8a, 0x7f, 0xda, 0xe7, 0x8b, 0x64, 0xab, 0xb0, 0x6e, 0x3e, 0x93, 0xfc,
-	0x4b, 0xdc, 0xd7, 0xa7, 0x96, 0xfe, 0x0d, 0x86, 0xda, 0x02, 0x52, 0x21, 0x6f, 0xf1, 0xba, 0x54,
-	0x27, 0x78, 0xcd, 0x9c, 0x58, 0xfa, 0x0e, 0x0c, 0xb5, 0x44, 0x90, 0x09, 0xd3, 0x2f, 0x1a, 0x3b,
-	0x75, 0x82, 0x6b, 0xaf, 0x9a, 0xb5, 0x83, 0x43, 0x73, 0x02, 0xcd, 0x42, 0x46, 0x49, 0x4a, 0x95,
-	0x4a, 0x6d, 0xff, 0xd0, 0xd4, 0x10, 0x82, 0x99, 0x66, 0xbd, 0xd2, 0xa8, 0x6f, 0xef, 0xe0, 0xbd,
-	0x5a, 0x95, 0x34, 0xf7, 0xcd, 0x49, 0x74, 0x0f, 0xcc, 0x61, 0x59, 0xb5, 0xf1, 0xba, 0x6e, 0x26,
-	0x24, 0xd8, 0x88, 0x9d, 0x2e, 0x7d, 0xc7, 0xac, 0x8c, 0x72, 0xf9, 0xed, 0x87, 0x39, 0xed, 0xdd,
-	0x87, 0x39, 0xed, 0xb7, 0x0f, 0x73, 0xda, 0xb7, 0x8f, 0x6f, 0xf3, 0xd7, 0xe2, 0x28, 0xa9, 0x24,
-	0xc5, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x5c, 0xfb, 0x13, 0x90, 0x99, 0x0c, 0x00, 0x00,
+	// 1573 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0x4f, 0x4f, 0x23, 0xc9,
+	0x15, 0xa7, 0x6d, 0xb7, 0x6d, 0x9e, 0x31, 0x34, 0xc5, 0xec, 0x8c, 0x33, 0xb3, 0x02, 0x64, 0x25,
+	0x12, 0x42, 0x09, 0x7f, 0xcc, 0x30, 0xc0, 0x46, 0xbb, 0x92, 0xb1, 0xcd, 0x8e, 0x17, 0x68, 0xb3,
+	0x65, 0xac, 0x8d, 0xa2, 0x48, 0x95, 0xa6, 0xbb, 0x1a, 0x7a, 0xec, 0xae, 0xee, 0x29, 0x17, 0xff,
+	0xf2, 0x11, 0x72, 0xcb, 0x25, 0xb7, 0x9c, 0x73, 0xc8, 0x17, 0xc8, 0x47, 0xd8, 0xe3, 0x5e, 0x72,
+	0x99, 0x03, 0x8a, 0x46, 0xf9, 0x20, 0x51, 0x55, 0xb7, 0xdd, 0xb6, 0x61, 0x36, 0x82, 0xdd, 0x4b,
+	0x4e, 0x7e, 0x7f, 0x7f, 0xfd, 0xab, 0x57, 0xf5, 0xde, 0x03, 0xd8, 0x3f, 0xf7, 0xc4, 0xc5, 0xe5,
+	0xd9, 0x9a, 0x1d, 0xf8, 0xeb, 0xa7, 0x17, 0xf4, 0xf4, 0xc2, 0x63, 0xe7, 0x7d, 0x93, 0x8a, 0xeb,
+	0x80, 0x77, 0xd7, 0x85, 0x60, 0xeb, 0x56, 0xe8, 0xad, 0x87, 0x3c, 0x10, 0x81, 0x1d, 0xf4, 0xd6,
+	0x7b, 0x01, 0xb7, 0xae, 0x2d, 0x36, 0xf8, 0x5d, 0x53, 0x0e, 0x94, 0x8b, 0xd5, 0x97, 0xbf, 0x19,
+	0x01, 0x3b, 0x0f, 0xce, 0x83, 0x28, 0xf1, 0xec, 0xd2, 0x55, 0x9a, 0x52, 0x94, 0x14, 0xe5, 0x95,
+	0xff, 0x96, 0x86, 0xfc, 0x31, 0x15, 0x96, 0x63, 0x09, 0x0b, 0x6d, 0x01, 0xf8, 0x81, 0x73, 0xd9,
+	0xb3, 0x84, 0x17, 0xb0, 0x52, 0x61, 0x59, 0x5b, 0x99, 0xad, 0x2c, 0xac, 0x0d, 0x3e, 0x74, 0x3c,
+	0x74, 0xe1, 0x91, 0x30, 0xf4, 0x0a, 0xa6, 0x65, 0x32, 0xe1, 0x96, 0xa0, 0xa5, 0x99, 0x65, 0x6d,
+	0x65, 0x1a, 0xe7, 0xa5, 0x01, 0x5b, 0x82, 0xa2, 0x5f, 0x40, 0xfe, 0xcc, 0x13, 0x91, 0xaf, 0xb8,
+	0xac, 0xad, 0x14, 0x71, 0xee, 0xcc, 0x13, 0xca, 0xb5, 0x04, 0x05, 0x3b, 0x70, 0x3c, 0x76, 0x1e,
+	0x79, 0x67, 0x55, 0x26, 0x44, 0x26, 0x15, 0xb0, 0x00, 0xba, 0x4b, 0x6c, 0x26, 0x4a, 0x73, 0x2a,
+	0x31, 0xe3, 0xd6, 0x98, 0x40, 0x5f, 0xc2, 0xac, 0xcb, 0xe9, 0xfb, 0x4b, 0xca, 0xec, 0x5b, 0x12,
+	0xf6, 0x2c, 0x56, 0x32, 0x14, 0xcd, 0xe7, 0x43, 0x9a, 0x07, 0x03, 0xf7, 0x49, 0xcf, 0x62, 0xb8,
+	0xe8, 0x8e, 0xaa, 0xc8, 0x04, 0x64, 0x07, 0xcc, 0xf5, 0xb8, 0x4f, 0x1d, 0xe2, 0x04, 0xd7, 0xac,
+	0xe7, 0xb1, 0x6e, 0x69, 0x5e, 0x41, 0x2c, 0x0d, 0x21, 0x6a, 0x83, 0x90, 0x7a, 0x1c, 0xd1, 0x16,
+	0x96, 0xa0, 0x78, 0xde, 0x9e, 0xb4, 0xa3, 0x75, 0x78, 0x96, 0xe0, 0x29, 0xb6, 0x0a, 0xb5, 0x84,
+	0x14, 0xe5, 0x24, 0xe1, 0xa0, 0xc6, 0x84, 0x4c, 0x42, 0x6b, 0xb0, 0x30, 0xce, 0x9f, 0x30, 0xcb,
+	0xa7, 0xa5, 0x05, 0x75, 0xfa, 0xf9, 0x31, 0xb2, 0xa6, 0xe5, 0xd3, 0xf2, 0x7f, 0x34, 0x98, 0x3b,
+	0xbd, 0x51, 0x84, 0xce, 0x2f, 0x79, 0x54, 0xf1, 0xff, 0x83, 0x6b, 0x5a, 0x83, 0xe9, 0x50, 0xe6,
+	0xf4, 0x7b, 0x81, 0x50, 0x37, 0x54, 0xa8, 0xcc, 0x0f, 0x19, 0x9e, 0x78, 0xec, 0xbc, 0xdd, 0x0b,
+	0x04, 0xce, 0x87, 0xb1, 0x54, 0xbe, 0x82, 0xfc, 0xc0, 0x8a, 0xbe, 0x85, 0xbc, 0x43, 0xaf, 0x88,
+	0xe5, 0x38, 0xbc, 0xa4, 0x2d, 0x6b, 0x2b, 0x33, 0xfb, 0x6f, 0x3e, 0xdc, 0x2d, 0x55, 0xfe, 0x57,
+	0x93, 0xd8, 0x01, 0xa7, 0xeb, 0xe2, 0x36, 0xa4, 0xfd, 0xb5, 0x3a, 0xbd, 0xaa, 0x3a, 0x0e, 0xc7,
+	0x39, 0x27, 0x12, 0xd0, 0x0b, 0xc8, 0x29, 0x3a, 0xec, 0xac, 0x94, 0x52, 0x2c, 0xb3, 0x52, 0x35,
+	0xcf, 0xca, 0x7f, 0xce, 0x02, 0xaa, 0xda, 0xc2, 0xbb, 0x52, 0x45, 0x1a, 0x36, 0x82, 0x09, 0x39,
+	0x2b, 0x0c, 0x09, 0xbd, 0xf4, 0x62, 0x06, 0xdb, 0x1f, 0xee, 0x96, 0x36, 0x1f, 0xc1, 0xa0, 0x1a,
+	0x86, 0x8d, 0x4e, 0x13, 0x67, 0xad, 0x30, 0x6c, 0x5c, 0x7a, 0x12, 0x4f, 0x1e, 0x49, 0xe2, 0xa5,
+	0x9e, 0x84, 0x57, 0xa7, 0x57, 0x0a, 0xcf, 0xa1, 0x57, 0x12, 0x6f, 0xb4, 0x44, 0xe9, 0x9f, 0xa7,
+	0x44, 0x18, 0xa6, 0xd9, 0x75, 0x97, 0xf4, 0x49, 0x97, 0xde, 0x96, 0x32, 0x4f, 0xc2, 0x34, 0xaf,
+	0xbb, 0xed, 0x43, 0x7a, 0x8b, 0x73, 0x2c, 0x12, 0xd0, 0x1f, 0x61, 0xae, 0x4f, 0x22, 0x54, 0x8f,
+	0x09, 0x85, 0xac, 0x2b, 0xe4, 0x2f, 0x3e, 0xdc, 0x2d, 0xbd, 0x79, 0x04, 0x72, 0x5b, 0x42, 0x37,
+	0x99, 0x90, 0xe8, 0x85, 0x7e, 0xa2, 0xa0, 0x3f, 0x40, 0x31, 0xc2, 0xa7, 0xcc, 0x56, 0xf8, 0x59,
+	0x85, 0xbf, 0xf7, 0xe1, 0x6e, 0x69, 0xfb, 0x91, 0xcc, 0x1b, 0xcc, 0x96, 0xf0, 0xc0, 0x86, 0x32,
+	0x2a, 0x43, 0x91, 0xdf, 0x6c, 0x12, 0x87, 0x93, 0xc0, 0x75, 0xfb, 0x54, 0xa8, 0x5e, 0x2b, 0xe2,
+	0x02, 0xbf, 0xd9, 0xac, 0xf3, 0x96, 0x32, 0xa1, 0xcf, 0x20, 0xcb, 0x6f, 0x2a, 0xc4, 0xe1, 0xaa,
+	0xa9, 0x8a, 0x58, 0xe7, 0x37, 0x95, 0x3a, 0x97, 0x1d, 0xc5, 0x6f, 0x88, 0x43, 0x7b, 0xd6, 0xed,
+	0xa0, 0xa3, 0xf8, 0x4d, 0x5d, 0xaa, 0x68, 0x05, 0x72, 0xb6, 0x4b, 0x7a, 0x5e, 0x5f, 0xa8, 0x6e,
+	0x2a, 0x54, 0xe6, 0x92, 0xc1, 0x73, 0x70, 0xe4, 0xf5, 0x05, 0xce, 0xda, 0xae, 0xfc, 0x7d, 0x60,
+	0xd8, 0xcd, 0x3d, 0x66, 0xd8, 0x7d, 0x62, 0xd6, 0x18, 0x9f, 0x9a, 0x35, 0xff, 0x48, 0x41, 0xee,
+	0x98, 0xf6, 0xfb, 0xd6, 0x39, 0x45, 0xbf, 0x06, 0xdd, 0x27, 0x17, 0x71, 0x07, 0x16, 0x2a, 0xc5,
+	0x64, 0xbc, 0xbc, 0xad, 0xe3, 0xfd, 0xfc, 0xf7, 0x77, 0x4b, 0x53, 0x3f, 0xdc, 0x2d, 0x69, 0x38,
+	0xe3, 0xbf, 0x75, 0x38, 0x32, 0x20, 0xed, 0x7b, 0x76, 0xf4, 0xb6, 0xb1, 0x14, 0xd1, 0x1b, 0x28,
+	0xf8, 0x96, 0x4d, 0x42, 0xeb, 0xb6, 0x17, 0x58, 0x8e, 0x7a, 0xa4, 0x85, 0xd1, 0x21, 0x55, 0xad,
+	0x9d, 0x44, 0xae, 0xb7, 0x53, 0x18, 0x7c, 0xcb, 0x8e, 0x35, 0xd4, 0x82, 0x67, 0xef, 0x02, 0x8f,
+	0x11, 0x45, 0xae, 0x2f, 0x86, 0x00, 0x19, 0x05, 0xf0, 0x6a, 0x08, 0xf0, 0x4d, 0xe0, 0x31, 0x1c,
+	0xc5, 0x24, 0x40, 0xe8, 0xdd, 0x3d, 0x2b, 0x3a, 0x82, 0x05, 0x05, 0x68, 0xd9, 0x36, 0x0d, 0x13,
+	0x3c, 0x5d, 0xe1, 0xbd, 0x1c, 0xc3, 0xab, 0xaa, 0x90, 0x04, 0x6e, 0xfe, 0xdd, 0xa4, 0x71, 0x7f,
+	0x1a, 0x72, 0xb1, 0x58, 0x6e, 0x43, 0x46, 0xd6, 0x02, 0xfd, 0x0a, 0xb2, 0x3e, 0x91, 0xcf, 0x48,
+	0x95, 0x6a, 0xb6, 0x32, 0x9b, 0x1c, 0xf2, 0xf4, 0x36, 0xa4, 0x58, 0xf7, 0xe5, 0x0f, 0xfa, 0x25,
+	0xe8, 0xbe, 0xf5, 0x2e, 0xe0, 0xa5, 0xd4, 0x64, 0x94, 0xb4, 0xe2, 0xc8, 0x59, 0xe6, 0x00, 0x49,
+	0x69, 0xe4, 0x25, 0xb8, 0x0f, 0x5e, 0xc2, 0xc1, 0xc4, 0x25, 0xb8, 0xf2, 0x12, 0x3e, 0x83, 0xac,
+	0x4b, 0xc2, 0x80, 0x0b, 0xf5, 0x09, 0x1d, 0xeb, 0xee, 0x49, 0xc0, 0x85, 0x1c, 0xe0, 0x2e, 0xf7,
+	0xc7, 0x6e, 0x62, 0x06, 0x83, 0xcb, 0xfd, 0xc1, 0x41, 0xfe, 0xa5, 0x41, 0x46, 0x02, 0xa2, 0xce,
+	0xbd, 0xc1, 0xfb, 0x85, 0xfc, 0xc4, 0x4f, 0x9d, 0x2c, 0xeb, 0x92, 0x97, 0x2d, 0x78, 0x4f, 0xf1,
+	0x2a, 0x8c, 0x1c, 0xfd, 0xa0, 0x26, 0x78, 0x6f, 0xe4, 0x1c, 0xba, 0x2b, 0x0d, 0xc9, 0x46, 0x49,
+	0x8f, 0x6c, 0x94, 0x0d, 0x89, 0x12, 0x84, 0xa2, 0x5f, 0xca, 0x2c, 0xa7, 0x27, 0xdf, 0x52, 0x2d,
+	0xf0, 0x7d, 0x8b, 0x39, 0xfb, 0x19, 0x09, 0x85, 0x75, 0xb7, 0x15, 0x8a, 0x7e, 0xf9, 0x02, 0x74,
+	0xf5, 0x01, 0xf9, 0x3a, 0xad, 0xf8, 0x48, 0x79, 0x2c, 0x45, 0xb4, 0x08, 0x05, 0xcb, 0xe1, 0xc4,
+	0xb2, 0xbb, 0xf2, 0xa1, 0x29, 0x5e, 0x79, 0x3c, 0x6d, 0x39, 0xbc, 0x6a, 0x77, 0x31, 0x7d, 0xaf,
+	0x32, 0xec, 0x6e, 0x29, 0x1d, 0x67, 0xd8, 0x5d, 0xb9, 0x3e, 0x5d, 0x12, 0x52, 0x26, 0xd7, 0x9e,
+	0x7a, 0x8c, 0x79, 0x9c, 0x77, 0x4f, 0x22, 0xbd, 0xbc, 0x0b, 0x90, 0x90, 0x90, 0xc9, 0xb6, 0xe7,
+	0xa8, 0xcf, 0x15, 0xb1, 0x14, 0x51, 0x09, 0x72, 0x83, 0xf2, 0x47, 0x2d, 0x32, 0x50, 0xcb, 0x7f,
+	0x4d, 0x01, 0xba, 0xff, 0x94, 0x11, 0x9e, 0xdc, 0x3f, 0x7b, 0xf1, 0x45, 0xfc, 0x84, 0x1d, 0x84,
+	0x27, 0x77, 0xd0, 0x53, 0x30, 0x27, 0xf6, 0xd0, 0xef, 0x60, 0x5a, 0x62, 0xb2, 0x80, 0xd9, 0x34,
+	0x5e, 0x44, 0xbf, 0x8d, 0x51, 0xb7, 0x1e, 0x87, 0x6a, 0x4a, 0x08, 0x9c, 0x77, 0x62, 0xa9, 0xfc,
+	0xcf, 0x34, 0xcc, 0xdf, 0xeb, 0x49, 0xf4, 0x39, 0x4c, 0x53, 0x66, 0xf3, 0xdb, 0x50, 0xd0, 0xa8,
+	0xc0, 0x33, 0x38, 0x31, 0x48, 0x36, 0xb2, 0x6a, 0x11, 0x9b, 0xd4, 0x93, 0xd9, 0x54, 0xc3, 0x30,
+	0x66, 0x63, 0xc5, 0x12, 0x6a, 0x41, 0x96, 0x51, 0x41, 0xbc, 0xb8, 0x7d, 0xf6, 0x77, 0x63, 0xd8,
+	0x8d, 0xc7, 0xec, 0x18, 0x2a, 0x9a, 0x75, 0xac, 0x33, 0x2a, 0x9a, 0xce, 0x58, 0xab, 0x65, 0x7e,
+	0xbe, 0x56, 0xfb, 0x0a, 0x0a, 0x4e, 0x8f, 0xf4, 0xa9, 0x10, 0x32, 0x2b, 0x1e, 0x72, 0x49, 0xa7,
+	0xd4, 0x8f, 0xda, 0xb1, 0x6b, 0xa4, 0xe9, 0xc0, 0xe9, 0x0d, 0xac, 0x63, 0x5b, 0x2b, 0xfb, 0xc9,
+	0xad, 0x95, 0xfb, 0xd1, 0xad, 0x55, 0xfe, 0x1a, 0x20, 0xf9, 0xd0, 0xfd, 0x1d, 0xaa, 0xfd, 0xd8,
+	0x0e, 0x4d, 0x8d, 0xec, 0xd0, 0xf2, 0xe7, 0x90, 0x8d, 0xa0, 0x11, 0x82, 0x8c, 0x5c, 0x57, 0x25,
+	0x6d, 0x39, 0xad, 0x06, 0x02, 0xa7, 0xef, 0x57, 0x97, 0x00, 0x92, 0x3f, 0x75, 0x51, 0x1e, 0x32,
+	0x47, 0x2d, 0x5c, 0x35, 0xa6, 0x50, 0x0e, 0xd2, 0x07, 0xed, 0x43, 0x43, 0x5b, 0xfd, 0x8b, 0x06,
+	0xcf, 0x1f, 0xfe, 0x4b, 0x1e, 0xbd, 0x82, 0x17, 0xb5, 0x96, 0x79, 0xd0, 0xc4, 0xc7, 0x8d, 0x3a,
+	0xa9, 0xb7, 0xbe, 0x33, 0x8f, 0x9a, 0xe6, 0x21, 0x31, 0x5b, 0x66, 0xc3, 0x98, 0x42, 0x8b, 0xf0,
+	0xf2, 0x01, 0xe7, 0x49, 0xc3, 0xac, 0x37, 0xcd, 0xaf, 0x0d, 0x0d, 0xbd, 0x84, 0xe7, 0x0f, 0xf8,
+	0xab, 0xb5, 0x43, 0x23, 0xf5, 0x89, 0xdc, 0xd3, 0xe6, 0x71, 0xa3, 0xd5, 0x39, 0x35, 0xd2, 0xab,
+	0x7f, 0xd7, 0xa0, 0x38, 0xb6, 0xb3, 0xd1, 0x2c, 0x40, 0xa3, 0x43, 0x76, 0xdf, 0x6c, 0x91, 0xdd,
+	0x9d, 0x0d, 0x63, 0x4a, 0xea, 0x9d, 0x36, 0xd9, 0xdb, 0xa8, 0x90, 0xbd, 0xca, 0xae, 0xa1, 0x49,
+	0xbd, 0x66, 0x92, 0x9d, 0x9d, 0x3d, 0xb2, 0xb3, 0xbb, 0x63, 0xa4, 0x10, 0x40, 0xb6, 0xd1, 0x21,
+	0xaf, 0xb7, 0xb6, 0x8c, 0xb4, 0xf4, 0x55, 0x3b, 0x64, 0x6f, 0x73, 0x5b, 0xc5, 0x66, 0xe2, 0xd8,
+	0xd7, 0x3b, 0x1b, 0x64, 0x7b, 0x73, 0xc3, 0xd0, 0x65, 0x6c, 0xb5, 0x4d, 0xf6, 0x2a, 0x5b, 0x46,
+	0x56, 0xc5, 0x4a, 0x79, 0x43, 0xe9, 0x5f, 0x0e, 0xf5, 0x2d, 0xb2, 0x57, 0xd9, 0x36, 0xbe, 0x92,
+	0xfa, 0x21, 0x1e, 0xfa, 0x73, 0xab, 0x2f, 0x40, 0x57, 0x9b, 0x49, 0x3a, 0x64, 0x65, 0xbf, 0xab,
+	0x9a, 0x04, 0x6f, 0x1a, 0x53, 0xab, 0x7f, 0x02, 0x5d, 0x2d, 0x36, 0x64, 0xc0, 0xcc, 0x37, 0xad,
+	0xa6, 0x49, 0x70, 0xe3, 0xdb, 0x4e, 0xa3, 0x7d, 0x6a, 0x4c, 0xa1, 0x39, 0x28, 0x28, 0x4b, 0xb5,
+	0x56, 0x6b, 0x9c, 0x9c, 0x1a, 0x1a, 0x42, 0x30, 0xdb, 0x31, 0x93, 0x82, 0x74, 0x4e, 0x8c, 0x14,
+	0x7a, 0x06, 0x46, 0xc7, 0x1c, 0x2f, 0x92, 0x91, 0x96, 0x60, 0x63, 0x71, 0x19, 0x99, 0x3b, 0x11,
+	0xa5, 0xef, 0xef, 0x7f, 0xff, 0x71, 0x51, 0xfb, 0xe1, 0xe3, 0xa2, 0xf6, 0xef, 0x8f, 0x8b, 0xda,
+	0xef, 0x5f, 0x3f, 0xe5, 0xff, 0xe6, 0xb3, 0xac, 0xb2, 0x6c, 0xfd, 0x77, 0x00, 0x1f, 0xba, 0x83,
+	0x53, 0x76, 0x0f, 0x00, 0x00,
 }
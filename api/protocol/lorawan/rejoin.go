@@ -0,0 +1,116 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package lorawan
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/otaa"
+)
+
+// rejoinRequestMType is the MType of a RejoinRequest of LoRaWAN 1.1. The lorawan package does not know this MType.
+const rejoinRequestMType = 6
+
+// RejoinRequest is the RejoinRequest of a LoRaWAN 1.1 device:
+// - Type 0 and 2 contain the NetID and DevEUI, and a MIC that is computed with the SNwkSIntKey. They are counted with RJcount0
+// - Type 1 contains the JoinEUI and DevEUI, and a MIC that is computed with the JSIntKey. It is counted with RJcount1
+type RejoinRequest struct {
+	RejoinType uint8
+	NetID      [3]byte // MSB-first, only for type 0 and 2
+	JoinEUI    types.AppEUI
+	DevEUI     types.DevEUI
+	RJCount    uint16
+	MIC        [4]byte
+}
+
+// IsRejoinRequest returns true if the PHYPayload contains a RejoinRequest
+func IsRejoinRequest(payload []byte) bool {
+	return len(payload) > 0 && payload[0]>>5 == rejoinRequestMType
+}
+
+// reverse is used to convert between MSB-first and LSB-first
+func reverse(in []byte) []byte {
+	out := make([]byte, len(in))
+	for i := range in {
+		out[len(in)-1-i] = in[i]
+	}
+	return out
+}
+
+// UnmarshalBinary unmarshals a RejoinRequest from a PHYPayload
+func (r *RejoinRequest) UnmarshalBinary(payload []byte) error {
+	if !IsRejoinRequest(payload) {
+		return errors.NewErrInvalidArgument("RejoinRequest", "invalid MType")
+	}
+	if len(payload) < 2 {
+		return errors.NewErrInvalidArgument("RejoinRequest", "too short")
+	}
+	r.RejoinType = payload[1]
+	switch r.RejoinType {
+	case 0, 2:
+		if len(payload) != 19 {
+			return errors.NewErrInvalidArgument("RejoinRequest", fmt.Sprintf("length of type %d must be 19", r.RejoinType))
+		}
+		copy(r.NetID[:], reverse(payload[2:5]))
+		copy(r.DevEUI[:], reverse(payload[5:13]))
+	case 1:
+		if len(payload) != 24 {
+			return errors.NewErrInvalidArgument("RejoinRequest", "length of type 1 must be 24")
+		}
+		copy(r.JoinEUI[:], reverse(payload[2:10]))
+		copy(r.DevEUI[:], reverse(payload[10:18]))
+	default:
+		return errors.NewErrInvalidArgument("RejoinRequest", fmt.Sprintf("invalid type %d", r.RejoinType))
+	}
+	r.RJCount = binary.LittleEndian.Uint16(payload[len(payload)-6:])
+	copy(r.MIC[:], payload[len(payload)-4:])
+	return nil
+}
+
+// MarshalBinary marshals the RejoinRequest to a PHYPayload
+func (r RejoinRequest) MarshalBinary() ([]byte, error) {
+	payload := []byte{rejoinRequestMType<<5 | byte(Major_LORAWAN_R1), r.RejoinType}
+	switch r.RejoinType {
+	case 0, 2:
+		payload = append(payload, reverse(r.NetID[:])...)
+	case 1:
+		payload = append(payload, reverse(r.JoinEUI[:])...)
+	default:
+		return nil, errors.NewErrInvalidArgument("RejoinRequest", fmt.Sprintf("invalid type %d", r.RejoinType))
+	}
+	payload = append(payload, reverse(r.DevEUI[:])...)
+	payload = append(payload, byte(r.RJCount), byte(r.RJCount>>8))
+	return append(payload, r.MIC[:]...), nil
+}
+
+// CalculateMIC calculates the MIC of the RejoinRequest. The key is the SNwkSIntKey for type 0 and 2, and the
+// JSIntKey for type 1.
+func (r RejoinRequest) CalculateMIC(key types.AES128Key) ([4]byte, error) {
+	payload, err := r.MarshalBinary()
+	if err != nil {
+		return [4]byte{}, err
+	}
+	return otaa.CalculateJoinRequestMIC(key, payload[:len(payload)-4]), nil
+}
+
+// SetMIC sets the MIC of the RejoinRequest
+func (r *RejoinRequest) SetMIC(key types.AES128Key) (err error) {
+	r.MIC, err = r.CalculateMIC(key)
+	return
+}
+
+// ValidateMIC validates the MIC of the RejoinRequest
+func (r RejoinRequest) ValidateMIC(key types.AES128Key) error {
+	mic, err := r.CalculateMIC(key)
+	if err != nil {
+		return err
+	}
+	if mic != r.MIC {
+		return errors.NewErrInvalidArgument("MIC", "does not match")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package lorawan
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestRejoinRequest(t *testing.T) {
+	a := New(t)
+	key := types.AES128Key{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}
+
+	a.So(IsRejoinRequest([]byte{0x00}), ShouldBeFalse)
+	a.So(IsRejoinRequest([]byte{0xC0}), ShouldBeTrue)
+
+	// Type 0
+	req := RejoinRequest{
+		RejoinType: 0,
+		NetID:      [3]byte{0x00, 0x00, 0x13},
+		DevEUI:     types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		RJCount:    0x0102,
+	}
+	a.So(req.SetMIC(key), ShouldBeNil)
+	payload, err := req.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(payload, ShouldHaveLength, 19)
+	a.So(payload[:15], ShouldResemble, []byte{0xC0, 0x00, 0x13, 0x00, 0x00, 8, 7, 6, 5, 4, 3, 2, 1, 0x02, 0x01})
+	a.So(IsRejoinRequest(payload), ShouldBeTrue)
+
+	var res RejoinRequest
+	a.So(res.UnmarshalBinary(payload), ShouldBeNil)
+	a.So(res, ShouldResemble, req)
+	a.So(res.ValidateMIC(key), ShouldBeNil)
+	a.So(res.ValidateMIC(types.AES128Key{}), ShouldNotBeNil)
+
+	// Type 1
+	req = RejoinRequest{
+		RejoinType: 1,
+		JoinEUI:    types.AppEUI{8, 7, 6, 5, 4, 3, 2, 1},
+		DevEUI:     types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		RJCount:    1,
+	}
+	a.So(req.SetMIC(key), ShouldBeNil)
+	payload, err = req.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(payload, ShouldHaveLength, 24)
+
+	res = RejoinRequest{}
+	a.So(res.UnmarshalBinary(payload), ShouldBeNil)
+	a.So(res, ShouldResemble, req)
+	a.So(res.ValidateMIC(key), ShouldBeNil)
+
+	// Invalid
+	a.So(res.UnmarshalBinary(payload[:19]), ShouldNotBeNil)
+	a.So(res.UnmarshalBinary([]byte{0xC0, 0x03}), ShouldNotBeNil)
+	a.So(res.UnmarshalBinary([]byte{0x00, 0x00}), ShouldNotBeNil)
+	_, err = RejoinRequest{RejoinType: 3}.MarshalBinary()
+	a.So(err, ShouldNotBeNil)
+}
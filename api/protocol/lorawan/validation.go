@@ -6,6 +6,7 @@ package lorawan
 import (
 	"github.com/TheThingsNetwork/ttn/api"
 	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/classb"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
 
@@ -34,6 +35,16 @@ func (m *Device) Validate() error {
 	if err := api.NotEmptyAndValidID(m.DevId, "DevId"); err != nil {
 		return err
 	}
+	if m.AdrDataRate != "" {
+		if _, err := types.ParseDataRate(m.AdrDataRate); err != nil {
+			return errors.NewErrInvalidArgument("AdrDataRate", err.Error())
+		}
+	}
+	if m.PingSlotDataRate != "" {
+		if _, err := types.ParseDataRate(m.PingSlotDataRate); err != nil {
+			return errors.NewErrInvalidArgument("PingSlotDataRate", err.Error())
+		}
+	}
 	return nil
 }
 
@@ -76,12 +87,29 @@ func (m *TxConfiguration) Validate() error {
 	if m.CodingRate == "" {
 		return errors.NewErrInvalidArgument("CodingRate", "can not be empty")
 	}
+	if m.PingSlot != nil {
+		if err := m.PingSlot.Validate(); err != nil {
+			return errors.Wrap(err, "Invalid PingSlot")
+		}
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *PingSlot) Validate() error {
+	if m.DevAddr == nil || m.DevAddr.IsEmpty() {
+		return errors.NewErrInvalidArgument("DevAddr", "can not be empty")
+	}
+	if !classb.ValidPingNb(int(m.PingNb)) {
+		return errors.NewErrInvalidArgument("PingNb", "must be a power of two between 1 and 128")
+	}
 	return nil
 }
 
 // Validate implements the api.Validator interface
 func (m *ActivationMetadata) Validate() error {
-	if m.AppEui == nil || m.AppEui.IsEmpty() {
+	// The AppEui is not known for RejoinRequests of type 0 and 2, the NetworkServer finds it by the DevEui
+	if m.AppEui != nil && m.AppEui.IsEmpty() {
 		return errors.NewErrInvalidArgument("AppEui", "can not be empty")
 	}
 	if m.DevEui == nil || m.DevEui.IsEmpty() {
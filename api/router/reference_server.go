@@ -228,3 +228,13 @@ func (s *ReferenceRouterServer) Subscribe(req *SubscribeRequest, stream Router_S
 func (s *ReferenceRouterServer) Activate(ctx context.Context, req *DeviceActivationRequest) (*DeviceActivationResponse, error) {
 	return nil, grpc.Errorf(codes.Unimplemented, "Not implemented")
 }
+
+// TxAck RPC
+func (s *ReferenceRouterServer) TxAck(ctx context.Context, req *TxAcknowledgment) (*empty.Empty, error) {
+	gatewayID, err := s.getAndAuthGateway(ctx)
+	if err != nil {
+		return nil, errors.NewErrPermissionDenied(err.Error())
+	}
+	s.ctx.WithField("GatewayID", gatewayID).WithField("ScheduleID", req.ScheduleId).Info("Received TxAcknowledgment")
+	return &empty.Empty{}, nil
+}
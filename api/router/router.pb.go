@@ -12,6 +12,7 @@
 		SubscribeRequest
 		UplinkMessage
 		DownlinkMessage
+		TxAcknowledgment
 		DeviceActivationRequest
 		DeviceActivationResponse
 		GatewayStatusRequest
@@ -113,6 +114,8 @@ type DownlinkMessage struct {
 	ProtocolConfiguration *protocol.TxConfiguration `protobuf:"bytes,11,opt,name=protocol_configuration,json=protocolConfiguration" json:"protocol_configuration,omitempty"`
 	GatewayConfiguration  *gateway.TxConfiguration  `protobuf:"bytes,12,opt,name=gateway_configuration,json=gatewayConfiguration" json:"gateway_configuration,omitempty"`
 	Trace                 *trace.Trace              `protobuf:"bytes,21,opt,name=trace" json:"trace,omitempty"`
+	// Identifier of the transmission in the schedule of the gateway, it is used in the TxAcknowledgment
+	ScheduleId string `protobuf:"bytes,31,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
 }
 
 func (m *DownlinkMessage) Reset()                    { *m = DownlinkMessage{} }
@@ -155,6 +158,48 @@ func (m *DownlinkMessage) GetTrace() *trace.Trace {
 	return nil
 }
 
+func (m *DownlinkMessage) GetScheduleId() string {
+	if m != nil {
+		return m.ScheduleId
+	}
+	return ""
+}
+
+// TxAcknowledgment is sent by the gateway after it handled a DownlinkMessage
+type TxAcknowledgment struct {
+	// The schedule_id of the DownlinkMessage
+	ScheduleId string `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	// The reason why the gateway could not transmit the DownlinkMessage, empty if it was transmitted
+	Error string       `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Trace *trace.Trace `protobuf:"bytes,11,opt,name=trace" json:"trace,omitempty"`
+}
+
+func (m *TxAcknowledgment) Reset()                    { *m = TxAcknowledgment{} }
+func (m *TxAcknowledgment) String() string            { return proto.CompactTextString(m) }
+func (*TxAcknowledgment) ProtoMessage()               {}
+func (*TxAcknowledgment) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{3} }
+
+func (m *TxAcknowledgment) GetScheduleId() string {
+	if m != nil {
+		return m.ScheduleId
+	}
+	return ""
+}
+
+func (m *TxAcknowledgment) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *TxAcknowledgment) GetTrace() *trace.Trace {
+	if m != nil {
+		return m.Trace
+	}
+	return nil
+}
+
 type DeviceActivationRequest struct {
 	Payload            []byte                                             `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Message            *protocol.Message                                  `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
@@ -169,7 +214,7 @@ type DeviceActivationRequest struct {
 func (m *DeviceActivationRequest) Reset()                    { *m = DeviceActivationRequest{} }
 func (m *DeviceActivationRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeviceActivationRequest) ProtoMessage()               {}
-func (*DeviceActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{3} }
+func (*DeviceActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{4} }
 
 func (m *DeviceActivationRequest) GetPayload() []byte {
 	if m != nil {
@@ -219,7 +264,7 @@ type DeviceActivationResponse struct {
 func (m *DeviceActivationResponse) Reset()                    { *m = DeviceActivationResponse{} }
 func (m *DeviceActivationResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeviceActivationResponse) ProtoMessage()               {}
-func (*DeviceActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{4} }
+func (*DeviceActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{5} }
 
 // message GatewayStatusRequest is used to request the status of a gateway from
 // this Router
@@ -230,7 +275,7 @@ type GatewayStatusRequest struct {
 func (m *GatewayStatusRequest) Reset()                    { *m = GatewayStatusRequest{} }
 func (m *GatewayStatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*GatewayStatusRequest) ProtoMessage()               {}
-func (*GatewayStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{5} }
+func (*GatewayStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{6} }
 
 func (m *GatewayStatusRequest) GetGatewayId() string {
 	if m != nil {
@@ -240,14 +285,15 @@ func (m *GatewayStatusRequest) GetGatewayId() string {
 }
 
 type GatewayStatusResponse struct {
-	LastSeen int64           `protobuf:"varint,1,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
-	Status   *gateway.Status `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	LastSeen         int64                                    `protobuf:"varint,1,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Status           *gateway.Status                          `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	DutyCycleBudgets []*GatewayStatusResponse_DutyCycleBudget `protobuf:"bytes,3,rep,name=duty_cycle_budgets,json=dutyCycleBudgets" json:"duty_cycle_budgets,omitempty"`
 }
 
 func (m *GatewayStatusResponse) Reset()                    { *m = GatewayStatusResponse{} }
 func (m *GatewayStatusResponse) String() string            { return proto.CompactTextString(m) }
 func (*GatewayStatusResponse) ProtoMessage()               {}
-func (*GatewayStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{6} }
+func (*GatewayStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{7} }
 
 func (m *GatewayStatusResponse) GetLastSeen() int64 {
 	if m != nil {
@@ -263,6 +309,57 @@ func (m *GatewayStatusResponse) GetStatus() *gateway.Status {
 	return nil
 }
 
+func (m *GatewayStatusResponse) GetDutyCycleBudgets() []*GatewayStatusResponse_DutyCycleBudget {
+	if m != nil {
+		return m.DutyCycleBudgets
+	}
+	return nil
+}
+
+// message DutyCycleBudget is the airtime that the gateway has left in a
+// duty-cycle limited band
+type GatewayStatusResponse_DutyCycleBudget struct {
+	MinFrequency uint64  `protobuf:"varint,1,opt,name=min_frequency,json=minFrequency,proto3" json:"min_frequency,omitempty"`
+	MaxFrequency uint64  `protobuf:"varint,2,opt,name=max_frequency,json=maxFrequency,proto3" json:"max_frequency,omitempty"`
+	DutyCycle    float32 `protobuf:"fixed32,3,opt,name=duty_cycle,json=dutyCycle,proto3" json:"duty_cycle,omitempty"`
+	Remaining    int64   `protobuf:"varint,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) Reset()         { *m = GatewayStatusResponse_DutyCycleBudget{} }
+func (m *GatewayStatusResponse_DutyCycleBudget) String() string { return proto.CompactTextString(m) }
+func (*GatewayStatusResponse_DutyCycleBudget) ProtoMessage()    {}
+func (*GatewayStatusResponse_DutyCycleBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptorRouter, []int{7, 0}
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) GetMinFrequency() uint64 {
+	if m != nil {
+		return m.MinFrequency
+	}
+	return 0
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) GetMaxFrequency() uint64 {
+	if m != nil {
+		return m.MaxFrequency
+	}
+	return 0
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) GetDutyCycle() float32 {
+	if m != nil {
+		return m.DutyCycle
+	}
+	return 0
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) GetRemaining() int64 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
 // message StatusRequest is used to request the status of this Router
 type StatusRequest struct {
 }
@@ -270,7 +367,7 @@ type StatusRequest struct {
 func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
 func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()               {}
-func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{7} }
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{8} }
 
 // message Status is the response to the StatusRequest
 type Status struct {
@@ -288,7 +385,7 @@ type Status struct {
 func (m *Status) Reset()                    { *m = Status{} }
 func (m *Status) String() string            { return proto.CompactTextString(m) }
 func (*Status) ProtoMessage()               {}
-func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{8} }
+func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{9} }
 
 func (m *Status) GetSystem() *api.SystemStats {
 	if m != nil {
@@ -350,10 +447,12 @@ func init() {
 	proto.RegisterType((*SubscribeRequest)(nil), "router.SubscribeRequest")
 	proto.RegisterType((*UplinkMessage)(nil), "router.UplinkMessage")
 	proto.RegisterType((*DownlinkMessage)(nil), "router.DownlinkMessage")
+	proto.RegisterType((*TxAcknowledgment)(nil), "router.TxAcknowledgment")
 	proto.RegisterType((*DeviceActivationRequest)(nil), "router.DeviceActivationRequest")
 	proto.RegisterType((*DeviceActivationResponse)(nil), "router.DeviceActivationResponse")
 	proto.RegisterType((*GatewayStatusRequest)(nil), "router.GatewayStatusRequest")
 	proto.RegisterType((*GatewayStatusResponse)(nil), "router.GatewayStatusResponse")
+	proto.RegisterType((*GatewayStatusResponse_DutyCycleBudget)(nil), "router.GatewayStatusResponse.DutyCycleBudget")
 	proto.RegisterType((*StatusRequest)(nil), "router.StatusRequest")
 	proto.RegisterType((*Status)(nil), "router.Status")
 }
@@ -377,6 +476,8 @@ type RouterClient interface {
 	// It is possible to open multiple subscriptions (but not recommended).
 	// If you do this, you are responsible for de-duplication of downlink messages.
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Router_SubscribeClient, error)
+	// Gateway acknowledges the transmission of a downlink message
+	TxAck(ctx context.Context, in *TxAcknowledgment, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 	// Gateway requests device activation
 	Activate(ctx context.Context, in *DeviceActivationRequest, opts ...grpc.CallOption) (*DeviceActivationResponse, error)
 }
@@ -489,6 +590,15 @@ func (x *routerSubscribeClient) Recv() (*DownlinkMessage, error) {
 	return m, nil
 }
 
+func (c *routerClient) TxAck(ctx context.Context, in *TxAcknowledgment, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/router.Router/TxAck", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routerClient) Activate(ctx context.Context, in *DeviceActivationRequest, opts ...grpc.CallOption) (*DeviceActivationResponse, error) {
 	out := new(DeviceActivationResponse)
 	err := grpc.Invoke(ctx, "/router.Router/Activate", in, out, c.cc, opts...)
@@ -509,6 +619,8 @@ type RouterServer interface {
 	// It is possible to open multiple subscriptions (but not recommended).
 	// If you do this, you are responsible for de-duplication of downlink messages.
 	Subscribe(*SubscribeRequest, Router_SubscribeServer) error
+	// Gateway acknowledges the transmission of a downlink message
+	TxAck(context.Context, *TxAcknowledgment) (*google_protobuf.Empty, error)
 	// Gateway requests device activation
 	Activate(context.Context, *DeviceActivationRequest) (*DeviceActivationResponse, error)
 }
@@ -590,6 +702,24 @@ func (x *routerSubscribeServer) Send(m *DownlinkMessage) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Router_TxAck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxAcknowledgment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).TxAck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.Router/TxAck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).TxAck(ctx, req.(*TxAcknowledgment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Router_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeviceActivationRequest)
 	if err := dec(in); err != nil {
@@ -612,6 +742,10 @@ var _Router_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "router.Router",
 	HandlerType: (*RouterServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TxAck",
+			Handler:    _Router_TxAck_Handler,
+		},
 		{
 			MethodName: "Activate",
 			Handler:    _Router_Activate_Handler,
@@ -887,6 +1021,54 @@ func (m *DownlinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n8
 	}
+	if len(m.ScheduleId) > 0 {
+		dAtA[i] = 0xfa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.ScheduleId)))
+		i += copy(dAtA[i:], m.ScheduleId)
+	}
+	return i, nil
+}
+
+func (m *TxAcknowledgment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxAcknowledgment) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ScheduleId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.ScheduleId)))
+		i += copy(dAtA[i:], m.ScheduleId)
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.Trace != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Trace.Size()))
+		n9, err := m.Trace.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
 	return i, nil
 }
 
@@ -915,31 +1097,31 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Message.Size()))
-		n9, err := m.Message.MarshalTo(dAtA[i:])
+		n10, err := m.Message.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n10
 	}
 	if m.DevEui != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.DevEui.Size()))
-		n10, err := m.DevEui.MarshalTo(dAtA[i:])
+		n11, err := m.DevEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n11
 	}
 	if m.AppEui != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.AppEui.Size()))
-		n11, err := m.AppEui.MarshalTo(dAtA[i:])
+		n12, err := m.AppEui.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n12
 	}
 	if m.ProtocolMetadata != nil {
 		dAtA[i] = 0xaa
@@ -947,11 +1129,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.ProtocolMetadata.Size()))
-		n12, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
+		n13, err := m.ProtocolMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n13
 	}
 	if m.GatewayMetadata != nil {
 		dAtA[i] = 0xb2
@@ -959,11 +1141,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.GatewayMetadata.Size()))
-		n13, err := m.GatewayMetadata.MarshalTo(dAtA[i:])
+		n14, err := m.GatewayMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n14
 	}
 	if m.ActivationMetadata != nil {
 		dAtA[i] = 0xba
@@ -971,11 +1153,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.ActivationMetadata.Size()))
-		n14, err := m.ActivationMetadata.MarshalTo(dAtA[i:])
+		n15, err := m.ActivationMetadata.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n15
 	}
 	if m.Trace != nil {
 		dAtA[i] = 0xfa
@@ -983,11 +1165,11 @@ func (m *DeviceActivationRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Trace.Size()))
-		n15, err := m.Trace.MarshalTo(dAtA[i:])
+		n16, err := m.Trace.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n16
 	}
 	return i, nil
 }
@@ -1058,11 +1240,61 @@ func (m *GatewayStatusResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Status.Size()))
-		n16, err := m.Status.MarshalTo(dAtA[i:])
+		n17, err := m.Status.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n17
+	}
+	if len(m.DutyCycleBudgets) > 0 {
+		for _, msg := range m.DutyCycleBudgets {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintRouter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MinFrequency != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.MinFrequency))
+	}
+	if m.MaxFrequency != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.MaxFrequency))
+	}
+	if m.DutyCycle != 0 {
+		dAtA[i] = 0x1d
+		i++
+		i = encodeFixed32Router(dAtA, i, uint32(math.Float32bits(float32(m.DutyCycle))))
+	}
+	if m.Remaining != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Remaining))
 	}
 	return i, nil
 }
@@ -1104,61 +1336,61 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.System.Size()))
-		n17, err := m.System.MarshalTo(dAtA[i:])
+		n18, err := m.System.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n18
 	}
 	if m.Component != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Component.Size()))
-		n18, err := m.Component.MarshalTo(dAtA[i:])
+		n19, err := m.Component.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n19
 	}
 	if m.GatewayStatus != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.GatewayStatus.Size()))
-		n19, err := m.GatewayStatus.MarshalTo(dAtA[i:])
+		n20, err := m.GatewayStatus.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n20
 	}
 	if m.Uplink != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink.Size()))
-		n20, err := m.Uplink.MarshalTo(dAtA[i:])
+		n21, err := m.Uplink.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n21
 	}
 	if m.Downlink != nil {
 		dAtA[i] = 0x6a
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Downlink.Size()))
-		n21, err := m.Downlink.MarshalTo(dAtA[i:])
+		n22, err := m.Downlink.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n22
 	}
 	if m.Activations != nil {
 		dAtA[i] = 0x72
 		i++
 		i = encodeVarintRouter(dAtA, i, uint64(m.Activations.Size()))
-		n22, err := m.Activations.MarshalTo(dAtA[i:])
+		n23, err := m.Activations.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n22
+		i += n23
 	}
 	if m.ConnectedGateways != 0 {
 		dAtA[i] = 0xa8
@@ -1259,6 +1491,28 @@ func (m *DownlinkMessage) Size() (n int) {
 		l = m.Trace.Size()
 		n += 2 + l + sovRouter(uint64(l))
 	}
+	l = len(m.ScheduleId)
+	if l > 0 {
+		n += 2 + l + sovRouter(uint64(l))
+	}
+	return n
+}
+
+func (m *TxAcknowledgment) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ScheduleId)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Trace != nil {
+		l = m.Trace.Size()
+		n += 1 + l + sovRouter(uint64(l))
+	}
 	return n
 }
 
@@ -1326,6 +1580,30 @@ func (m *GatewayStatusResponse) Size() (n int) {
 		l = m.Status.Size()
 		n += 1 + l + sovRouter(uint64(l))
 	}
+	if len(m.DutyCycleBudgets) > 0 {
+		for _, e := range m.DutyCycleBudgets {
+			l = e.Size()
+			n += 1 + l + sovRouter(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GatewayStatusResponse_DutyCycleBudget) Size() (n int) {
+	var l int
+	_ = l
+	if m.MinFrequency != 0 {
+		n += 1 + sovRouter(uint64(m.MinFrequency))
+	}
+	if m.MaxFrequency != 0 {
+		n += 1 + sovRouter(uint64(m.MaxFrequency))
+	}
+	if m.DutyCycle != 0 {
+		n += 5
+	}
+	if m.Remaining != 0 {
+		n += 1 + sovRouter(uint64(m.Remaining))
+	}
 	return n
 }
 
@@ -1839,6 +2117,176 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduleId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduleId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxAcknowledgment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxAcknowledgment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxAcknowledgment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduleId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ScheduleId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRouter(dAtA[iNdEx:])
@@ -2380,6 +2828,158 @@ func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DutyCycleBudgets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DutyCycleBudgets = append(m.DutyCycleBudgets, &GatewayStatusResponse_DutyCycleBudget{})
+			if err := m.DutyCycleBudgets[len(m.DutyCycleBudgets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GatewayStatusResponse_DutyCycleBudget) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DutyCycleBudget: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DutyCycleBudget: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinFrequency", wireType)
+			}
+			m.MinFrequency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinFrequency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxFrequency", wireType)
+			}
+			m.MaxFrequency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxFrequency |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DutyCycle", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 4
+			v = uint32(dAtA[iNdEx-4])
+			v |= uint32(dAtA[iNdEx-3]) << 8
+			v |= uint32(dAtA[iNdEx-2]) << 16
+			v |= uint32(dAtA[iNdEx-1]) << 24
+			m.DutyCycle = float32(math.Float32frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remaining", wireType)
+			}
+			m.Remaining = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Remaining |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRouter(dAtA[iNdEx:])
@@ -2847,61 +3447,72 @@ func init() {
 }
 
 var fileDescriptorRouter = []byte{
-	// 884 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcb, 0x6e, 0xdb, 0x46,
-	0x14, 0x05, 0x1d, 0x94, 0xb6, 0xae, 0x25, 0x5b, 0x1e, 0x5b, 0x36, 0xa3, 0xc4, 0x0f, 0x70, 0xd1,
-	0x1a, 0x4d, 0x43, 0xd5, 0x2a, 0x82, 0x3e, 0x16, 0x45, 0xed, 0xd8, 0x08, 0x02, 0x54, 0x41, 0x41,
-	0x3b, 0x9b, 0x02, 0x85, 0x30, 0xa2, 0x6e, 0x68, 0xc2, 0x12, 0x87, 0xe5, 0x0c, 0xe5, 0xe8, 0x2f,
-	0xda, 0x0f, 0xe8, 0xff, 0x74, 0xd9, 0x75, 0x17, 0x45, 0xe1, 0x0f, 0xe8, 0xb2, 0xbb, 0x02, 0x05,
-	0xe7, 0x41, 0x8a, 0x92, 0xdd, 0xa6, 0x8f, 0x6c, 0x44, 0xce, 0x3d, 0xe7, 0x1e, 0xce, 0x9c, 0xb9,
-	0x33, 0x57, 0xf0, 0x71, 0x18, 0x89, 0xcb, 0x6c, 0xe0, 0x05, 0x6c, 0xdc, 0xb9, 0xb8, 0xc4, 0x8b,
-	0xcb, 0x28, 0x0e, 0xf9, 0x0b, 0x14, 0xd7, 0x2c, 0xbd, 0xea, 0x08, 0x11, 0x77, 0x68, 0x12, 0x75,
-	0x52, 0x96, 0x09, 0x4c, 0xf5, 0xc3, 0x4b, 0x52, 0x26, 0x18, 0xb1, 0xd5, 0xa8, 0xfd, 0x20, 0x64,
-	0x2c, 0x1c, 0x61, 0x47, 0x46, 0x07, 0xd9, 0xab, 0x0e, 0x8e, 0x13, 0x31, 0x55, 0xa4, 0xf6, 0xe3,
-	0x19, 0xf5, 0x90, 0x85, 0xac, 0x64, 0xe5, 0x23, 0x39, 0x90, 0x6f, 0x9a, 0xbe, 0x61, 0x3e, 0x48,
-	0x93, 0x48, 0x87, 0xf6, 0x4d, 0x48, 0x0e, 0x03, 0x36, 0x2a, 0x5e, 0x34, 0x61, 0xd7, 0x10, 0x42,
-	0x2a, 0xf0, 0x9a, 0x4e, 0xcd, 0x53, 0xc3, 0xf7, 0x0d, 0x2c, 0x52, 0x1a, 0xa0, 0xfa, 0x55, 0x90,
-	0x4b, 0xa0, 0x79, 0x9e, 0x0d, 0x78, 0x90, 0x46, 0x03, 0xf4, 0xf1, 0xdb, 0x0c, 0xb9, 0x70, 0xff,
-	0xb0, 0xa0, 0xf1, 0x32, 0x19, 0x45, 0xf1, 0x55, 0x0f, 0x39, 0xa7, 0x21, 0x12, 0x07, 0x96, 0x13,
-	0x3a, 0x1d, 0x31, 0x3a, 0x74, 0xac, 0x03, 0xeb, 0xb0, 0xee, 0x9b, 0x21, 0x79, 0x04, 0xcb, 0x63,
-	0x45, 0x72, 0x96, 0x0e, 0xac, 0xc3, 0xd5, 0xee, 0x86, 0x57, 0xcc, 0x4d, 0x67, 0xfb, 0x86, 0x41,
-	0x8e, 0x61, 0xc3, 0x80, 0xfd, 0x31, 0x0a, 0x3a, 0xa4, 0x82, 0x3a, 0xab, 0x32, 0x6d, 0xab, 0x4c,
-	0xf3, 0x5f, 0xf7, 0x34, 0xe6, 0x37, 0x4d, 0xd0, 0x44, 0xc8, 0xe7, 0xd0, 0xd4, 0x6b, 0x2b, 0x15,
-	0xea, 0x52, 0x61, 0xd3, 0x33, 0x8b, 0x9e, 0x11, 0x58, 0xd7, 0xb1, 0x22, 0xdf, 0x85, 0x77, 0xe4,
-	0xf2, 0x9d, 0x96, 0x4c, 0xaa, 0x7b, 0xca, 0x8c, 0x8b, 0xfc, 0xd7, 0x57, 0x90, 0xfb, 0xc3, 0x12,
-	0xac, 0x9f, 0xb2, 0xeb, 0xf8, 0x2d, 0x38, 0xf0, 0x15, 0x6c, 0x17, 0x0e, 0x04, 0x2c, 0x7e, 0x15,
-	0x85, 0x59, 0x4a, 0x45, 0xc4, 0x62, 0x6d, 0xc3, 0xfd, 0x32, 0xf7, 0xe2, 0xf5, 0xd3, 0x59, 0x82,
-	0xdf, 0x32, 0x48, 0x25, 0x4c, 0x7a, 0xd0, 0x32, 0x86, 0x54, 0x05, 0x95, 0x2b, 0x4e, 0xe1, 0xca,
-	0xbc, 0xde, 0x96, 0x06, 0xaa, 0x72, 0x6f, 0xe2, 0xcf, 0xef, 0xf7, 0x60, 0xe7, 0x14, 0x27, 0x51,
-	0x80, 0xc7, 0x81, 0x88, 0x26, 0x4a, 0x4e, 0xd5, 0xce, 0xff, 0xe5, 0xd3, 0x0b, 0x58, 0x1e, 0xe2,
-	0xa4, 0x8f, 0x59, 0x24, 0x8d, 0xa9, 0x9f, 0x3c, 0xf9, 0xf9, 0x97, 0xfd, 0xa3, 0xbf, 0x3b, 0xa6,
-	0x01, 0x4b, 0xb1, 0x23, 0xa6, 0x09, 0x72, 0xef, 0x14, 0x27, 0x67, 0x2f, 0x9f, 0xfb, 0xf6, 0x10,
-	0x27, 0x67, 0x59, 0x94, 0xeb, 0xd1, 0x24, 0x91, 0x7a, 0xf5, 0x7f, 0xa5, 0x77, 0x9c, 0x24, 0x52,
-	0x8f, 0x26, 0x49, 0xae, 0x77, 0x6b, 0x25, 0xb7, 0xfe, 0x73, 0x25, 0x6f, 0xff, 0x83, 0x4a, 0xee,
-	0xc1, 0x26, 0x2d, 0xec, 0x2f, 0x25, 0x76, 0xa4, 0xc4, 0xc3, 0x72, 0x12, 0xe5, 0x1e, 0x15, 0x5a,
-	0x84, 0x2e, 0xc4, 0xca, 0x8d, 0xdf, 0xbf, 0x7b, 0xe3, 0xdb, 0xe0, 0x2c, 0xee, 0x3b, 0x4f, 0x58,
-	0xcc, 0xd1, 0x7d, 0x02, 0x5b, 0xcf, 0xd4, 0x0c, 0xcf, 0x05, 0x15, 0x19, 0x37, 0x05, 0xb1, 0x0b,
-	0x60, 0x96, 0x19, 0xa9, 0x9a, 0xa8, 0xf9, 0x35, 0x1d, 0x79, 0x3e, 0x74, 0xbf, 0x81, 0xd6, 0x5c,
-	0x9a, 0xd2, 0x23, 0x0f, 0xa0, 0x36, 0xa2, 0x5c, 0xf4, 0x39, 0x62, 0x2c, 0xd3, 0xee, 0xf9, 0x2b,
-	0x79, 0xe0, 0x1c, 0x31, 0x26, 0xef, 0x81, 0xcd, 0x25, 0x5d, 0x97, 0xd2, 0x7a, 0xe1, 0x98, 0x56,
-	0xd1, 0xb0, 0xbb, 0x0e, 0x8d, 0xca, 0x74, 0xdc, 0xdf, 0x96, 0xc0, 0x56, 0x11, 0x72, 0x08, 0x36,
-	0x9f, 0x72, 0x81, 0x63, 0x29, 0xbf, 0xda, 0x6d, 0x7a, 0xf9, 0x8d, 0x7b, 0x2e, 0x43, 0x39, 0x25,
-	0x57, 0x91, 0x03, 0x72, 0x04, 0xb5, 0x80, 0x8d, 0x13, 0x16, 0x63, 0x2c, 0xf4, 0x17, 0x37, 0x25,
-	0xf9, 0xa9, 0x89, 0x2a, 0x7e, 0xc9, 0x22, 0x47, 0xb0, 0x66, 0x96, 0xad, 0x67, 0xaa, 0x0e, 0x38,
-	0xc8, 0x3c, 0x9f, 0x0a, 0xe4, 0x7e, 0x23, 0x9c, 0x5d, 0x39, 0x71, 0xc1, 0xce, 0xe4, 0xad, 0xab,
-	0x8f, 0xee, 0x2c, 0x55, 0x23, 0xe4, 0x5d, 0x58, 0x19, 0xea, 0x9b, 0xc9, 0x69, 0x2c, 0xb0, 0x0a,
-	0x8c, 0x7c, 0x00, 0xab, 0xe5, 0x1e, 0x73, 0x67, 0x6d, 0x81, 0x3a, 0x0b, 0x93, 0xc7, 0x40, 0x02,
-	0x16, 0xc7, 0x18, 0x08, 0x1c, 0xf6, 0xf5, 0xa4, 0xb8, 0x2c, 0xe7, 0x86, 0xbf, 0x51, 0x20, 0x7a,
-	0x9f, 0x38, 0x79, 0x04, 0x65, 0xb0, 0x3f, 0x48, 0xd9, 0x15, 0xa6, 0x5c, 0x96, 0x6e, 0xc3, 0x6f,
-	0x16, 0xc0, 0x89, 0x8a, 0x77, 0xbf, 0x5b, 0x02, 0xdb, 0x97, 0x5d, 0x92, 0x7c, 0x06, 0x8d, 0xca,
-	0x5e, 0x93, 0xf9, 0x6d, 0x6b, 0x6f, 0x7b, 0xaa, 0x91, 0x7a, 0xa6, 0x45, 0x7a, 0x67, 0x79, 0x23,
-	0x3d, 0xb4, 0xc8, 0xa7, 0x60, 0xab, 0x96, 0x44, 0x5a, 0x9e, 0x6e, 0xc1, 0x95, 0x16, 0xf5, 0x17,
-	0xa9, 0x5f, 0x40, 0xad, 0x68, 0x71, 0xc4, 0x31, 0xd9, 0xf3, 0x5d, 0xaf, 0xbd, 0x63, 0x90, 0xb9,
-	0xab, 0xff, 0x43, 0x8b, 0xf4, 0x60, 0x45, 0x57, 0x3c, 0x92, 0xfd, 0x82, 0x76, 0xfb, 0x0d, 0xd8,
-	0x3e, 0xb8, 0x9b, 0xa0, 0x4a, 0xbb, 0xfb, 0xbd, 0x05, 0x0d, 0x65, 0x49, 0x8f, 0xc6, 0x34, 0xc4,
-	0x94, 0x7c, 0x39, 0xef, 0xcc, 0x43, 0x23, 0x72, 0xdb, 0x99, 0x6a, 0xef, 0xde, 0x81, 0xea, 0xa3,
-	0xd3, 0x85, 0xda, 0x33, 0x14, 0x5a, 0xa9, 0xb0, 0xab, 0x2a, 0xb1, 0x56, 0x0d, 0x9f, 0x7c, 0xf2,
-	0xe3, 0xcd, 0x9e, 0xf5, 0xd3, 0xcd, 0x9e, 0xf5, 0xeb, 0xcd, 0x9e, 0xf5, 0xf5, 0xfb, 0x6f, 0xfe,
-	0x87, 0x68, 0x60, 0x4b, 0xc3, 0x3f, 0xfa, 0x33, 0x00, 0x00, 0xff, 0xff, 0x83, 0xd2, 0x76, 0xdc,
-	0x45, 0x09, 0x00, 0x00,
+	// 1068 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xe3, 0x36,
+	0x17, 0x85, 0x9c, 0x19, 0x27, 0xbe, 0xb6, 0x13, 0x87, 0x89, 0x13, 0x8d, 0x27, 0x89, 0x0d, 0x7f,
+	0xc0, 0x57, 0xa3, 0xd3, 0xc8, 0x8d, 0x8b, 0x41, 0x7f, 0x16, 0x45, 0xf3, 0xd7, 0x41, 0x80, 0x7a,
+	0x50, 0x28, 0x99, 0x4d, 0xbb, 0x30, 0x68, 0xe9, 0x46, 0x11, 0x62, 0x8b, 0xaa, 0x48, 0x25, 0xf1,
+	0x63, 0x74, 0xd7, 0xa7, 0xe9, 0xba, 0x40, 0x37, 0xdd, 0x74, 0xd3, 0x45, 0x51, 0xe4, 0x01, 0xba,
+	0xec, 0xae, 0x40, 0x21, 0x8a, 0x94, 0x6c, 0x27, 0x99, 0x4e, 0xff, 0x36, 0xb6, 0x79, 0xee, 0xb9,
+	0x87, 0x97, 0x87, 0x97, 0x26, 0xe1, 0x7d, 0xcf, 0x17, 0x17, 0xf1, 0xd0, 0x72, 0xd8, 0xb8, 0x7b,
+	0x76, 0x81, 0x67, 0x17, 0x7e, 0xe0, 0xf1, 0x97, 0x28, 0xae, 0x59, 0x74, 0xd9, 0x15, 0x22, 0xe8,
+	0xd2, 0xd0, 0xef, 0x46, 0x2c, 0x16, 0x18, 0xa9, 0x2f, 0x2b, 0x8c, 0x98, 0x60, 0xa4, 0x98, 0x8e,
+	0x1a, 0x4f, 0x3d, 0xc6, 0xbc, 0x11, 0x76, 0x25, 0x3a, 0x8c, 0xcf, 0xbb, 0x38, 0x0e, 0xc5, 0x24,
+	0x25, 0x35, 0x76, 0xa7, 0xd4, 0x3d, 0xe6, 0xb1, 0x9c, 0x95, 0x8c, 0xe4, 0x40, 0xfe, 0x52, 0xf4,
+	0x55, 0x3d, 0x21, 0x0d, 0x7d, 0x05, 0x35, 0x35, 0x24, 0x87, 0x0e, 0x1b, 0x65, 0x3f, 0x14, 0x61,
+	0x5b, 0x13, 0x3c, 0x2a, 0xf0, 0x9a, 0x4e, 0xf4, 0xb7, 0x0a, 0x3f, 0xd1, 0x61, 0x11, 0x51, 0x07,
+	0xd3, 0xcf, 0x34, 0xd4, 0x26, 0x50, 0x3b, 0x8d, 0x87, 0xdc, 0x89, 0xfc, 0x21, 0xda, 0xf8, 0x55,
+	0x8c, 0x5c, 0xb4, 0x7f, 0x37, 0xa0, 0xfa, 0x2a, 0x1c, 0xf9, 0xc1, 0x65, 0x1f, 0x39, 0xa7, 0x1e,
+	0x12, 0x13, 0x16, 0x43, 0x3a, 0x19, 0x31, 0xea, 0x9a, 0x46, 0xcb, 0xe8, 0x54, 0x6c, 0x3d, 0x24,
+	0xcf, 0x60, 0x71, 0x9c, 0x92, 0xcc, 0x42, 0xcb, 0xe8, 0x94, 0x7b, 0xab, 0x56, 0x56, 0x9b, 0xca,
+	0xb6, 0x35, 0x83, 0xec, 0xc3, 0xaa, 0x0e, 0x0e, 0xc6, 0x28, 0xa8, 0x4b, 0x05, 0x35, 0xcb, 0x32,
+	0x6d, 0x3d, 0x4f, 0xb3, 0x6f, 0xfa, 0x2a, 0x66, 0xd7, 0x34, 0xa8, 0x11, 0xf2, 0x31, 0xd4, 0xd4,
+	0xda, 0x72, 0x85, 0x8a, 0x54, 0x58, 0xb3, 0xf4, 0xa2, 0xa7, 0x04, 0x56, 0x14, 0x96, 0xe5, 0xb7,
+	0xe1, 0xb1, 0x5c, 0xbe, 0x59, 0x97, 0x49, 0x15, 0x4b, 0x8e, 0xac, 0xb3, 0xe4, 0xd3, 0x4e, 0x43,
+	0xed, 0x6f, 0x0b, 0xb0, 0x72, 0xc4, 0xae, 0x83, 0xff, 0xc0, 0x81, 0xcf, 0x61, 0x23, 0x73, 0xc0,
+	0x61, 0xc1, 0xb9, 0xef, 0xc5, 0x11, 0x15, 0x3e, 0x0b, 0x94, 0x0d, 0x4f, 0xf2, 0xdc, 0xb3, 0x9b,
+	0xc3, 0x69, 0x82, 0x5d, 0xd7, 0x91, 0x19, 0x98, 0xf4, 0xa1, 0xae, 0x0d, 0x99, 0x15, 0x4c, 0x5d,
+	0x31, 0x33, 0x57, 0xe6, 0xf5, 0xd6, 0x55, 0x60, 0x56, 0xee, 0x0d, 0xfc, 0x21, 0x4d, 0x28, 0x73,
+	0xe7, 0x02, 0xdd, 0x78, 0x84, 0x03, 0xdf, 0x35, 0x9b, 0x2d, 0xa3, 0x53, 0xb2, 0x41, 0x43, 0x27,
+	0x6e, 0x7b, 0x0c, 0xb5, 0xb3, 0x9b, 0x7d, 0xe7, 0x32, 0x60, 0xd7, 0x23, 0x74, 0xbd, 0x31, 0x06,
+	0x62, 0x3e, 0xc9, 0x98, 0x4f, 0x22, 0xeb, 0xf0, 0x18, 0xa3, 0x88, 0x45, 0xd2, 0xc5, 0x92, 0x9d,
+	0x0e, 0xf2, 0x7a, 0xca, 0x0f, 0xef, 0xd7, 0x6f, 0x0b, 0xb0, 0x79, 0x84, 0x57, 0xbe, 0x83, 0xfb,
+	0x8e, 0xf0, 0xaf, 0xd2, 0xe5, 0xa5, 0xbd, 0xfc, 0x6f, 0xed, 0xdb, 0x4b, 0x58, 0x74, 0xf1, 0x6a,
+	0x80, 0xb1, 0x2f, 0x0b, 0xa9, 0x1c, 0x3c, 0xff, 0xe9, 0xe7, 0xe6, 0xde, 0x9f, 0xfd, 0x6d, 0x38,
+	0x2c, 0xc2, 0xae, 0x98, 0x84, 0xc8, 0xad, 0x23, 0xbc, 0x3a, 0x7e, 0x75, 0x62, 0x17, 0x5d, 0xbc,
+	0x3a, 0x8e, 0xfd, 0x44, 0x8f, 0x86, 0xa1, 0xd4, 0xab, 0xfc, 0x2d, 0xbd, 0xfd, 0x30, 0x94, 0x7a,
+	0x34, 0x0c, 0x13, 0xbd, 0x7b, 0x4f, 0x56, 0xfd, 0x1f, 0x9f, 0xac, 0x8d, 0xbf, 0x70, 0xb2, 0xfa,
+	0xb0, 0x46, 0x33, 0xfb, 0x73, 0x89, 0x4d, 0x29, 0xb1, 0x95, 0x17, 0x91, 0xef, 0x51, 0xa6, 0x45,
+	0xe8, 0x1d, 0x2c, 0xdf, 0xf8, 0xe6, 0xc3, 0x1b, 0xdf, 0x00, 0xf3, 0xee, 0xbe, 0xf3, 0x90, 0x05,
+	0x1c, 0xdb, 0xcf, 0x61, 0xfd, 0x45, 0x5a, 0xe1, 0xa9, 0xa0, 0x22, 0xe6, 0xba, 0x21, 0xb6, 0x01,
+	0xf4, 0x32, 0xb3, 0x36, 0x2c, 0x29, 0xe4, 0xc4, 0x6d, 0xff, 0x58, 0x80, 0xfa, 0x5c, 0x5e, 0x2a,
+	0x48, 0x9e, 0x42, 0x69, 0x44, 0xb9, 0x18, 0x70, 0xc4, 0x40, 0xe6, 0x2d, 0xd8, 0x4b, 0x09, 0x70,
+	0x8a, 0x18, 0x90, 0xb7, 0xa0, 0xc8, 0x25, 0x5d, 0xf5, 0xd2, 0x4a, 0x66, 0x99, 0x52, 0x51, 0x61,
+	0xf2, 0x25, 0x10, 0x37, 0x16, 0x93, 0x81, 0x33, 0x71, 0x46, 0x38, 0x18, 0xc6, 0xae, 0x87, 0x82,
+	0x9b, 0x0b, 0xad, 0x85, 0x4e, 0xb9, 0xb7, 0x6b, 0xa9, 0xcb, 0xe5, 0xde, 0x02, 0xac, 0xa3, 0x58,
+	0x4c, 0x0e, 0x93, 0xb4, 0x03, 0x99, 0x65, 0xd7, 0xdc, 0x59, 0x80, 0x37, 0xbe, 0x31, 0x60, 0x65,
+	0x8e, 0x45, 0xfe, 0x07, 0xd5, 0xb1, 0x1f, 0x0c, 0xce, 0xa3, 0x64, 0xfd, 0x81, 0x33, 0x91, 0xa5,
+	0x3f, 0xb2, 0x2b, 0x63, 0x3f, 0xf8, 0x54, 0x63, 0x92, 0x44, 0x6f, 0xa6, 0x48, 0x05, 0x45, 0xa2,
+	0x37, 0x39, 0x69, 0x1b, 0x20, 0x2f, 0xdd, 0x5c, 0x68, 0x19, 0x9d, 0x82, 0x5d, 0xca, 0x6a, 0x20,
+	0x5b, 0x50, 0x8a, 0x70, 0x4c, 0xfd, 0xc0, 0x0f, 0x3c, 0xf3, 0x91, 0xf4, 0x27, 0x07, 0xda, 0x2b,
+	0x50, 0x9d, 0xd9, 0x87, 0xf6, 0xaf, 0x05, 0x28, 0xa6, 0x08, 0xe9, 0x40, 0x91, 0x4f, 0xb8, 0xc0,
+	0xb1, 0xac, 0xad, 0xdc, 0xab, 0x59, 0xc9, 0xd5, 0x77, 0x2a, 0xa1, 0x84, 0x92, 0xb8, 0x27, 0x07,
+	0x64, 0x0f, 0x4a, 0x0e, 0x1b, 0x87, 0x2c, 0xc0, 0x40, 0x28, 0xa7, 0xd7, 0x24, 0xf9, 0x50, 0xa3,
+	0x29, 0x3f, 0x67, 0x91, 0x3d, 0x58, 0xd6, 0xfb, 0xad, 0x76, 0x28, 0xfd, 0x27, 0x01, 0x99, 0x67,
+	0x53, 0x81, 0xdc, 0xae, 0x7a, 0xd3, 0x86, 0x93, 0x36, 0x14, 0x63, 0x79, 0xfd, 0x99, 0x95, 0x3b,
+	0x54, 0x15, 0x21, 0xff, 0x87, 0x25, 0x57, 0x5d, 0x11, 0x66, 0xf5, 0x0e, 0x2b, 0x8b, 0x91, 0x77,
+	0xa0, 0x9c, 0x37, 0x37, 0x37, 0x97, 0xef, 0x50, 0xa7, 0xc3, 0x64, 0x17, 0x88, 0xc3, 0x82, 0x00,
+	0x1d, 0x81, 0xee, 0x40, 0x15, 0xc5, 0xe5, 0x39, 0xae, 0xda, 0xab, 0x59, 0x44, 0xb5, 0x07, 0x27,
+	0xcf, 0x20, 0x07, 0x07, 0xc3, 0x88, 0x5d, 0x62, 0xc4, 0xe5, 0x99, 0xad, 0xda, 0xb5, 0x2c, 0x70,
+	0x90, 0xe2, 0xbd, 0xef, 0x0b, 0x50, 0xb4, 0x65, 0x7f, 0x91, 0x8f, 0xa0, 0x3a, 0xd3, 0x62, 0x64,
+	0xbe, 0x5d, 0x1b, 0x1b, 0x56, 0xfa, 0xa2, 0xb1, 0xf4, 0x5b, 0xc5, 0x3a, 0x4e, 0x5e, 0x34, 0x1d,
+	0x83, 0x7c, 0x08, 0xc5, 0xf4, 0x6d, 0x40, 0xea, 0xba, 0x5d, 0x67, 0xde, 0x0a, 0xaf, 0x49, 0xfd,
+	0x04, 0x4a, 0xd9, 0x5b, 0x83, 0x98, 0x3a, 0x7b, 0xfe, 0xf9, 0xd1, 0xd8, 0xd4, 0x91, 0xb9, 0x3b,
+	0xf8, 0xdd, 0x64, 0xf2, 0xc7, 0xf2, 0x62, 0xc9, 0xb3, 0xe7, 0xef, 0x99, 0x87, 0xa6, 0x27, 0x7d,
+	0x58, 0x52, 0xff, 0x12, 0x48, 0x9a, 0xd9, 0x0c, 0xf7, 0xdf, 0x1a, 0x8d, 0xd6, 0xc3, 0x84, 0xf4,
+	0x30, 0xf6, 0xbe, 0x36, 0xa0, 0x9a, 0xba, 0xd9, 0xa7, 0x01, 0xf5, 0x30, 0x22, 0x9f, 0xcd, 0x9b,
+	0xba, 0xf5, 0xc0, 0x71, 0x4e, 0xa7, 0xd8, 0x7e, 0xed, 0x61, 0x27, 0x3d, 0x28, 0xbd, 0x40, 0xa1,
+	0x94, 0x32, 0xa7, 0x67, 0x25, 0x96, 0x67, 0xe1, 0x83, 0x0f, 0xbe, 0xbb, 0xdd, 0x31, 0x7e, 0xb8,
+	0xdd, 0x31, 0x7e, 0xb9, 0xdd, 0x31, 0xbe, 0x78, 0xfb, 0xcd, 0x1f, 0xb5, 0xc3, 0xa2, 0x34, 0xeb,
+	0xbd, 0x3f, 0x06, 0x00, 0xfe, 0xa8, 0x82, 0xdc, 0x09, 0x0b, 0x00, 0x00,
 }
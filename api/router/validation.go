@@ -40,6 +40,14 @@ func (m *DownlinkMessage) Validate() error {
 	return nil
 }
 
+// Validate implements the api.Validator interface
+func (m *TxAcknowledgment) Validate() error {
+	if m.ScheduleId == "" {
+		return errors.NewErrInvalidArgument("ScheduleId", "can not be empty")
+	}
+	return nil
+}
+
 // Validate implements the api.Validator interface
 func (m *DeviceActivationRequest) Validate() error {
 	if err := api.NotNilAndValid(m.GatewayMetadata, "GatewayMetadata"); err != nil {
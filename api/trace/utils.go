@@ -38,6 +38,25 @@ func (m *Trace) GetIDs() (uids []string) {
 	return
 }
 
+// CorrelationIDKey is the metadata key for the correlation ID of a downlink message
+const CorrelationIDKey = "correlation_id"
+
+// GetCorrelationID returns the correlation ID in the metadata of this Trace or its parents
+func (m *Trace) GetCorrelationID() string {
+	if m == nil {
+		return ""
+	}
+	if id, ok := m.Metadata[CorrelationIDKey]; ok {
+		return id
+	}
+	for _, p := range m.Parents {
+		if id := p.GetCorrelationID(); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
 // GenID generates a random ID
 func (m *Trace) GenID() {
 	id := make([]byte, 16)
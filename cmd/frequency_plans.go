@@ -0,0 +1,67 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// loadFrequencyPlans registers the custom frequency plans from the files in the configuration
+func loadFrequencyPlans(files ...string) {
+	for _, file := range append(viper.GetStringSlice("frequency-plans"), files...) {
+		names, err := band.LoadFile(file)
+		if err != nil {
+			ctx.WithError(err).WithField("File", file).Fatal("Could not load frequency plans")
+		}
+		ctx.WithFields(ttnlog.Fields{
+			"File":           file,
+			"FrequencyPlans": names,
+		}).Info("Loaded frequency plans")
+	}
+}
+
+var frequencyPlansCmd = &cobra.Command{
+	Use:   "frequency-plans [file ...]",
+	Short: "List and verify frequency plans",
+	Long:  `ttn frequency-plans loads the custom frequency plans from the configuration and the given files, verifies them and lists all frequency plans`,
+	Run: func(cmd *cobra.Command, args []string) {
+		loadFrequencyPlans(args...)
+
+		for _, name := range band.List() {
+			fp, err := band.Get(name)
+			if err != nil {
+				ctx.WithError(err).WithField("FrequencyPlan", name).Fatal("Could not get frequency plan")
+			}
+			if err := fp.Validate(); err != nil {
+				ctx.WithError(err).WithField("FrequencyPlan", name).Fatal("Invalid frequency plan")
+			}
+			fields := ttnlog.Fields{
+				"Region":           fp.Region,
+				"Custom":           band.IsCustom(name),
+				"UplinkChannels":   len(fp.UplinkChannels),
+				"DownlinkChannels": len(fp.DownlinkChannels),
+				"RX2Frequency":     fp.RX2Frequency,
+				"RX2DataRate":      fp.RX2DataRate,
+			}
+			if fp.CFList != nil {
+				fields["CFList"] = *fp.CFList
+			}
+			if fp.ADR != nil {
+				fields["ADRDataRates"] = []int{fp.ADR.MinDataRate, fp.ADR.MaxDataRate}
+				fields["ADRTXPowers"] = []int{fp.ADR.MinTXPower, fp.ADR.MaxTXPower}
+			}
+			if len(fp.SubBands) > 0 {
+				fields["SubBands"] = fp.SubBands
+			}
+			ctx.WithFields(fields).Infof("Frequency plan %s", name)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(frequencyPlansCmd)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/api/pool"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/handler"
+	"github.com/TheThingsNetwork/ttn/core/handler/functions"
 	"github.com/TheThingsNetwork/ttn/core/proxy"
 	"github.com/TheThingsNetwork/ttn/core/proxy/jsonpb"
 	"github.com/TheThingsNetwork/ttn/utils/parse"
@@ -46,6 +47,8 @@ var handlerCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx.Info("Starting")
 
+		loadFrequencyPlans()
+
 		// Redis Client
 		client := redis.NewClient(&redis.Options{
 			Addr:     viper.GetString("handler.redis-address"),
@@ -69,10 +72,22 @@ var handlerCmd = &cobra.Command{
 		}
 
 		// Handler
+		functions.DefaultQuota = functions.Quota{
+			Timeout:     viper.GetDuration("handler.functions-timeout"),
+			MaxCodeSize: viper.GetInt("handler.functions-max-code-size"),
+			MaxVMs:      viper.GetInt("handler.functions-max-vms"),
+		}
 		handler := handler.NewRedisHandler(
 			client,
 			viper.GetString("handler.broker-id"),
 		)
+		for _, str := range viper.GetStringSlice("handler.functions-quotas") {
+			appID, quota, err := functions.ParseQuota(str)
+			if err != nil {
+				ctx.WithError(err).Fatal("Invalid payload function quota")
+			}
+			handler = handler.WithFunctionQuota(appID, quota)
+		}
 		if viper.GetString("handler.mqtt-address") != "" {
 			handler = handler.WithMQTT(
 				viper.GetString("handler.mqtt-username"),
@@ -112,6 +127,7 @@ var handlerCmd = &cobra.Command{
 		} else {
 			ctx.Warn("AMQP is not enabled in your configuration")
 		}
+		handler = handler.WithHTTP(component.Identity.ApiAddress)
 		err = handler.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize handler")
@@ -144,7 +160,8 @@ var handlerCmd = &cobra.Command{
 			defer cancel()
 			pb.RegisterApplicationManagerHandler(netCtx, mux, proxyConn)
 
-			prxy := proxy.WithToken(mux)
+			prxy := handler.HTTPDownlinkHandler(mux)
+			prxy = proxy.WithToken(prxy)
 			prxy = proxy.WithPagination(prxy)
 			prxy = proxy.WithLogger(prxy, ctx)
 
@@ -179,6 +196,15 @@ func init() {
 	handlerCmd.Flags().String("broker-id", "dev", "The ID of the TTN Broker as announced in the Discovery server")
 	viper.BindPFlag("handler.broker-id", handlerCmd.Flags().Lookup("broker-id"))
 
+	handlerCmd.Flags().Duration("functions-timeout", functions.DefaultQuota.Timeout, "Maximum time that a payload function is allowed to run")
+	handlerCmd.Flags().Int("functions-max-code-size", functions.DefaultQuota.MaxCodeSize, "Maximum size (in bytes) of the payload functions that are cached for an application")
+	handlerCmd.Flags().Int("functions-max-vms", functions.DefaultQuota.MaxVMs, "Maximum number of idle payload function VMs that are kept for an application")
+	handlerCmd.Flags().StringSlice("functions-quotas", []string{}, "Payload function quotas of applications, in the format <app-id>:<timeout>:<max-code-size>:<max-vms>")
+	viper.BindPFlag("handler.functions-timeout", handlerCmd.Flags().Lookup("functions-timeout"))
+	viper.BindPFlag("handler.functions-max-code-size", handlerCmd.Flags().Lookup("functions-max-code-size"))
+	viper.BindPFlag("handler.functions-max-vms", handlerCmd.Flags().Lookup("functions-max-vms"))
+	viper.BindPFlag("handler.functions-quotas", handlerCmd.Flags().Lookup("functions-quotas"))
+
 	handlerCmd.Flags().String("mqtt-address", "", "MQTT host and port. Leave empty to disable MQTT")
 	handlerCmd.Flags().String("mqtt-address-announce", "", "MQTT address to announce (takes value of server-address-announce if empty while enabled)")
 	handlerCmd.Flags().String("mqtt-username", "", "MQTT username")
@@ -36,6 +36,8 @@ var networkserverCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx.Info("Starting")
 
+		loadFrequencyPlans()
+
 		// Redis Client
 		client := redis.NewClient(&redis.Options{
 			Addr:     viper.GetString("networkserver.redis-address"),
@@ -54,6 +56,8 @@ var networkserverCmd = &cobra.Command{
 		}
 
 		// networkserver Server
+		networkserver.ConfirmedDownlinkMaxRetries = viper.GetInt("networkserver.confirmed-downlink-retries")
+		networkserver.ConfirmedDownlinkTimeout = viper.GetDuration("networkserver.confirmed-downlink-timeout")
 		networkserver := networkserver.NewRedisNetworkServer(client, viper.GetInt("networkserver.net-id"))
 
 		// Register Prefixes
@@ -111,6 +115,11 @@ func init() {
 	networkserverCmd.Flags().Int("net-id", 19, "LoRaWAN NetID")
 	viper.BindPFlag("networkserver.net-id", networkserverCmd.Flags().Lookup("net-id"))
 
+	networkserverCmd.Flags().Int("confirmed-downlink-retries", networkserver.ConfirmedDownlinkMaxRetries, "Maximum number of times that a confirmed downlink is sent again if it is not acknowledged (negative for no maximum)")
+	networkserverCmd.Flags().Duration("confirmed-downlink-timeout", networkserver.ConfirmedDownlinkTimeout, "Time after which a confirmed downlink that is not acknowledged is dropped (0 for no timeout)")
+	viper.BindPFlag("networkserver.confirmed-downlink-retries", networkserverCmd.Flags().Lookup("confirmed-downlink-retries"))
+	viper.BindPFlag("networkserver.confirmed-downlink-timeout", networkserverCmd.Flags().Lookup("confirmed-downlink-timeout"))
+
 	viper.SetDefault("networkserver.prefixes", map[string]string{
 		"26000000/20": "otaa,abp,world,local,private,testing",
 	})
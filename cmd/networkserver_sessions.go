@@ -0,0 +1,202 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/api"
+	pb "github.com/TheThingsNetwork/ttn/api/networkserver"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/security"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
+	"google.golang.org/grpc"
+)
+
+// sessionsMaxLineLength is the maximum length of a line in a sessions file
+const sessionsMaxLineLength = 1024 * 1024
+
+// networkserverSessionsCmd represents the sessions command
+var networkserverSessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Import and export device sessions",
+	Long: `ttn networkserver sessions imports and exports the sessions of devices in a Network Server
+
+Sessions are stored in the JSON-lines format: each line of the file contains one
+JSON object with the fields of a device (lorawan.Device). A session requires the
+app_id, app_eui, dev_id, dev_eui, dev_addr and nwk_s_key fields. Frame counters,
+device options and the ADR state (adr_state) are optional:
+
+{"app_eui":"0102030405060708","dev_eui":"0102030405060708","app_id":"test","dev_id":"test","dev_addr":"26010203","nwk_s_key":"01020304050607080102030405060708","f_cnt_up":42,"f_cnt_down":7,"adr_state":{"margin":15,"data_rate":"SF7BW125","tx_power":14,"nb_trans":1}}
+
+The Network Server only accepts a token that it issued (see ttn networkserver authorize),
+for the component id (--id) that the token was issued for. If no token is given, a
+token is generated with the keypair in --key-dir.`,
+}
+
+// sessionsClient connects to the NetworkServerManager of the Network Server
+func sessionsClient(cmd *cobra.Command) (context.Context, pb.NetworkServerManagerClient, *grpc.ClientConn) {
+	id := viper.GetString("id")
+	if id == "" {
+		ctx.Fatal("No component id (--id) given")
+	}
+
+	token, _ := cmd.Flags().GetString("networkserver-token")
+	if token == "" {
+		privKey, err := security.LoadKeypair(viper.GetString("key-dir"))
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not load security keys")
+		}
+		privPEM, err := security.PrivatePEM(privKey)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not load security keys")
+		}
+		token, err = security.BuildJWT(id, time.Hour, privPEM)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not build token")
+		}
+	}
+
+	address, _ := cmd.Flags().GetString("networkserver-address")
+	var conn *grpc.ClientConn
+	var err error
+	if certFile, _ := cmd.Flags().GetString("networkserver-cert"); certFile != "" {
+		cert, readErr := ioutil.ReadFile(certFile)
+		if readErr != nil {
+			ctx.WithError(readErr).Fatal("Could not get Networkserver certificate")
+		}
+		conn, err = api.DialWithCert(address, string(cert))
+	} else {
+		conn, err = api.Dial(address)
+	}
+	if err != nil {
+		ctx.WithError(err).Fatal("Could not connect to Networkserver")
+	}
+
+	rpcCtx := api.ContextWithID(context.Background(), id)
+	rpcCtx = api.ContextWithToken(rpcCtx, token)
+	return rpcCtx, pb.NewNetworkServerManagerClient(conn), conn
+}
+
+var networkserverSessionsImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import device sessions",
+	Long:  `ttn networkserver sessions import imports device sessions from a JSON-lines file. Existing devices are replaced.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			cmd.UsageFunc()(cmd)
+			return
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not open file")
+		}
+		defer file.Close()
+
+		rpcCtx, client, conn := sessionsClient(cmd)
+		defer conn.Close()
+
+		stream, err := client.ImportSessions(rpcCtx)
+		if err != nil {
+			ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not import sessions")
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), sessionsMaxLineLength)
+		var line int
+		for scanner.Scan() {
+			line++
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			session := new(pb_lorawan.Device)
+			if err := jsonpb.UnmarshalString(scanner.Text(), session); err != nil {
+				ctx.WithError(err).WithField("Line", line).Fatal("Could not read session")
+			}
+			if err := stream.Send(session); err != nil {
+				break // The error is returned by CloseAndRecv
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			ctx.WithError(err).WithField("Line", line).Fatal("Could not read file")
+		}
+
+		res, err := stream.CloseAndRecv()
+		if err != nil {
+			ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not import sessions")
+		}
+		ctx.WithField("Imported", res.Imported).Info("Imported sessions")
+	},
+}
+
+var networkserverSessionsExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export device sessions",
+	Long:  `ttn networkserver sessions export exports device sessions to a JSON-lines file`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			cmd.UsageFunc()(cmd)
+			return
+		}
+
+		file, err := os.Create(args[0])
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not create file")
+		}
+		defer file.Close()
+
+		rpcCtx, client, conn := sessionsClient(cmd)
+		defer conn.Close()
+
+		appID, _ := cmd.Flags().GetString("app-id")
+		stream, err := client.ExportSessions(rpcCtx, &pb.ExportSessionsRequest{AppId: appID})
+		if err != nil {
+			ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not export sessions")
+		}
+
+		marshaler := &jsonpb.Marshaler{OrigName: true}
+		var exported int
+		for {
+			session, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not export sessions")
+			}
+			line, err := marshaler.MarshalToString(session)
+			if err != nil {
+				ctx.WithError(err).WithField("DevID", session.DevId).Fatal("Could not write session")
+			}
+			if _, err := fmt.Fprintln(file, line); err != nil {
+				ctx.WithError(err).Fatal("Could not write file")
+			}
+			exported++
+		}
+		ctx.WithField("Exported", exported).Info("Exported sessions")
+	},
+}
+
+func init() {
+	networkserverCmd.AddCommand(networkserverSessionsCmd)
+	networkserverSessionsCmd.PersistentFlags().String("networkserver-address", "localhost:1903", "Networkserver host and port")
+	networkserverSessionsCmd.PersistentFlags().String("networkserver-cert", "", "Networkserver certificate to use")
+	networkserverSessionsCmd.PersistentFlags().String("networkserver-token", "", "Networkserver token to use")
+
+	networkserverSessionsCmd.AddCommand(networkserverSessionsImportCmd)
+
+	networkserverSessionsCmd.AddCommand(networkserverSessionsExportCmd)
+	networkserverSessionsExportCmd.Flags().String("app-id", "", "Only export the sessions of the devices of this application")
+}
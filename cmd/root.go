@@ -141,6 +141,8 @@ func init() {
 
 	RootCmd.PersistentFlags().Int("health-port", 0, "The port number where the health server should be started")
 
+	RootCmd.PersistentFlags().StringSlice("frequency-plans", []string{}, "Files with custom frequency plans (YAML or JSON)")
+
 	viper.SetDefault("auth-servers", map[string]string{
 		"ttn-account-v2": "https://account.thethingsnetwork.org",
 	})
@@ -13,9 +13,12 @@ import (
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/router"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/mqtt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"gopkg.in/redis.v5"
 )
 
 // routerCmd represents the router command
@@ -24,14 +27,20 @@ var routerCmd = &cobra.Command{
 	Short: "The Things Network router",
 	Long:  ``,
 	PreRun: func(cmd *cobra.Command, args []string) {
-		ctx.WithFields(ttnlog.Fields{
+		fields := ttnlog.Fields{
 			"Server":   fmt.Sprintf("%s:%d", viper.GetString("router.server-address"), viper.GetInt("router.server-port")),
 			"Announce": fmt.Sprintf("%s:%d", viper.GetString("router.server-address-announce"), viper.GetInt("router.server-port")),
-		}).Info("Initializing Router")
+		}
+		if viper.GetString("router.gateway-store") == "redis" {
+			fields["Database"] = fmt.Sprintf("%s/%d", viper.GetString("router.redis-address"), viper.GetInt("router.redis-db"))
+		}
+		ctx.WithFields(fields).Info("Initializing Router")
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx.Info("Starting")
 
+		loadFrequencyPlans()
+
 		// Component
 		component, err := component.New(ttnlog.Get(), "router", fmt.Sprintf("%s:%d", viper.GetString("router.server-address-announce"), viper.GetInt("router.server-port")))
 		if err != nil {
@@ -43,7 +52,8 @@ var routerCmd = &cobra.Command{
 		}
 
 		// Router
-		router := router.NewRouter()
+		router := newRouter()
+		router.SetGatewayExpiry(viper.GetDuration("router.gateway-expiry"))
 		err = router.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize router")
@@ -62,6 +72,43 @@ var routerCmd = &cobra.Command{
 		router.RegisterManager(grpc)
 		go grpc.Serve(lis)
 
+		// MQTT gateways
+		if mqttAddress := viper.GetString("router.mqtt-address"); mqttAddress != "" {
+			client := mqtt.NewClient(ttnlog.Get(), "ttnrtr", viper.GetString("router.mqtt-username"), viper.GetString("router.mqtt-password"), fmt.Sprintf("tcp://%s", mqttAddress))
+			if err := client.Connect(); err != nil {
+				ctx.WithError(err).Fatal("Could not connect to MQTT")
+			}
+			if err := router.ServeMQTT(client); err != nil {
+				ctx.WithError(err).Fatal("Could not subscribe to MQTT gateways")
+			}
+			defer client.Disconnect()
+		}
+
+		// Semtech UDP packet forwarder
+		if udpPort := viper.GetInt("router.udp-port"); udpPort != 0 {
+			var allowedEUIs []types.EUI64
+			for _, eui := range viper.GetStringSlice("router.udp-gateways") {
+				allowedEUI, err := types.ParseEUI64(eui)
+				if err != nil {
+					ctx.WithError(err).WithField("EUI", eui).Fatal("Invalid gateway EUI")
+				}
+				allowedEUIs = append(allowedEUIs, allowedEUI)
+			}
+			udp, err := net.ListenPacket("udp", fmt.Sprintf("%s:%d", viper.GetString("router.server-address"), udpPort))
+			if err != nil {
+				ctx.WithError(err).Fatal("Could not start UDP server")
+			}
+			ctx.WithFields(ttnlog.Fields{
+				"Address":  udp.LocalAddr(),
+				"Gateways": len(allowedEUIs),
+			}).Info("Accepting gateways over UDP")
+			go func() {
+				if err := router.ServeUDP(udp, allowedEUIs...); err != nil {
+					ctx.WithError(err).Warn("UDP server stopped")
+				}
+			}()
+		}
+
 		sigChan := make(chan os.Signal)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 		ctx.WithField("signal", <-sigChan).Info("signal received")
@@ -71,16 +118,57 @@ var routerCmd = &cobra.Command{
 	},
 }
 
+// newRouter creates a Router that uses the configured gateway store
+func newRouter() router.Router {
+	switch store := viper.GetString("router.gateway-store"); store {
+	case "memory":
+		return router.NewRouter()
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     viper.GetString("router.redis-address"),
+			Password: viper.GetString("router.redis-password"),
+			DB:       viper.GetInt("router.redis-db"),
+		})
+		if err := connectRedis(client); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize database connection")
+		}
+		return router.NewRedisRouter(client)
+	default:
+		ctx.WithField("GatewayStore", store).Fatal("Invalid gateway store (use memory or redis)")
+		return nil
+	}
+}
+
 func init() {
 	RootCmd.AddCommand(routerCmd)
 	routerCmd.Flags().String("server-address", "0.0.0.0", "The IP address to listen for communication")
 	routerCmd.Flags().String("server-address-announce", "localhost", "The public IP address to announce")
 	routerCmd.Flags().Int("server-port", 1901, "The port for communication")
+	routerCmd.Flags().String("mqtt-address", "", "MQTT host and port for gateways. Leave empty to disable MQTT")
 	routerCmd.Flags().String("mqtt-address-announce", "", "MQTT address to announce")
+	routerCmd.Flags().String("mqtt-username", "", "MQTT username")
+	routerCmd.Flags().String("mqtt-password", "", "MQTT password")
 	routerCmd.Flags().Bool("skip-verify-gateway-token", false, "Skip verification of the gateway token")
+	routerCmd.Flags().Int("udp-port", 0, "The port for gateways that use the Semtech UDP protocol (0 to disable)")
+	routerCmd.Flags().StringSlice("udp-gateways", []string{}, "EUIs of the gateways that are allowed to connect over UDP (all gateways are allowed if empty, gateways are never trusted)")
+	routerCmd.Flags().String("gateway-store", "memory", "Where to store the status and utilization of gateways (memory or redis)")
+	routerCmd.Flags().Duration("gateway-expiry", 0, "Time after which gateways that are not seen are removed (0 to keep all gateways)")
+	routerCmd.Flags().String("redis-address", "localhost:6379", "Redis host and port (for the redis gateway store)")
+	routerCmd.Flags().String("redis-password", "", "Redis password")
+	routerCmd.Flags().Int("redis-db", 0, "Redis database")
 	viper.BindPFlag("router.server-address", routerCmd.Flags().Lookup("server-address"))
 	viper.BindPFlag("router.server-address-announce", routerCmd.Flags().Lookup("server-address-announce"))
 	viper.BindPFlag("router.server-port", routerCmd.Flags().Lookup("server-port"))
+	viper.BindPFlag("router.mqtt-address", routerCmd.Flags().Lookup("mqtt-address"))
 	viper.BindPFlag("router.mqtt-address-announce", routerCmd.Flags().Lookup("mqtt-address-announce"))
+	viper.BindPFlag("router.mqtt-username", routerCmd.Flags().Lookup("mqtt-username"))
+	viper.BindPFlag("router.mqtt-password", routerCmd.Flags().Lookup("mqtt-password"))
 	viper.BindPFlag("router.skip-verify-gateway-token", routerCmd.Flags().Lookup("skip-verify-gateway-token"))
+	viper.BindPFlag("router.udp-port", routerCmd.Flags().Lookup("udp-port"))
+	viper.BindPFlag("router.udp-gateways", routerCmd.Flags().Lookup("udp-gateways"))
+	viper.BindPFlag("router.gateway-store", routerCmd.Flags().Lookup("gateway-store"))
+	viper.BindPFlag("router.gateway-expiry", routerCmd.Flags().Lookup("gateway-expiry"))
+	viper.BindPFlag("router.redis-address", routerCmd.Flags().Lookup("redis-address"))
+	viper.BindPFlag("router.redis-password", routerCmd.Flags().Lookup("redis-password"))
+	viper.BindPFlag("router.redis-db", routerCmd.Flags().Lookup("redis-db"))
 }
@@ -24,15 +24,26 @@ func linkMargin(dataRate string, snr float32) float32 {
 
 // ADRConfig contains configuration for Adaptive Data Rate
 type ADRConfig struct {
-	MinDataRate int
-	MaxDataRate int
-	MinTXPower  int
-	MaxTXPower  int
+	MinDataRate int `json:"min_data_rate" yaml:"min_data_rate"`
+	MaxDataRate int `json:"max_data_rate" yaml:"max_data_rate"`
+	MinTXPower  int `json:"min_tx_power" yaml:"min_tx_power"`
+	MaxTXPower  int `json:"max_tx_power" yaml:"max_tx_power"`
 }
 
 // ErrADRUnavailable is returned when ADR is not available
 var ErrADRUnavailable = errors.New("ADR Unavailable")
 
+// supportedTxPower returns the lowest TX power of the frequency plan that is not lower than the given TX power
+func (f *FrequencyPlan) supportedTxPower(txPower int) int {
+	supported, found := txPower, false
+	for _, power := range f.TXPower {
+		if power >= txPower && (!found || power < supported) {
+			supported, found = power, true
+		}
+	}
+	return supported
+}
+
 // ADRSettings gets the ADR settings given a dataRate, txPower, SNR and device margin
 func (f *FrequencyPlan) ADRSettings(dataRate string, txPower int, snr float32, deviceMargin float32) (desiredDataRate string, desiredTxPower int, err error) {
 	if f.ADR == nil {
@@ -67,6 +78,7 @@ func (f *FrequencyPlan) ADRSettings(dataRate string, txPower int, snr float32, d
 	if txPower > f.ADR.MaxTXPower {
 		txPower = f.ADR.MaxTXPower
 	}
+	txPower = f.supportedTxPower(txPower)
 
 	desiredDataRate, err = f.GetDataRateStringForIndex(drIdx)
 	if err != nil {
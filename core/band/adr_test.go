@@ -48,7 +48,37 @@ func TestADRSettings(t *testing.T) {
 
 	us, _ := Get("US_902_928")
 	{
-		_, _, err := us.ADRSettings("SF10BW125", 14, -3, defaultMargin)
+		dr, tx, err := us.ADRSettings("SF10BW125", 20, 1, defaultMargin)
+		a.So(err, ShouldBeNil)
+		a.So(dr, ShouldEqual, "SF8BW125")
+		a.So(tx, ShouldEqual, 20)
+	}
+	{
+		// The 500 kHz data rate is not used for ADR
+		dr, tx, err := us.ADRSettings("SF7BW125", 20, 9, defaultMargin)
+		a.So(err, ShouldBeNil)
+		a.So(dr, ShouldEqual, "SF7BW125")
+		a.So(tx, ShouldEqual, 14)
+	}
+	{
+		// The TX power is rounded up to a TX power of the frequency plan
+		dr, tx, err := us.ADRSettings("SF7BW125", 20, 6, defaultMargin)
+		a.So(err, ShouldBeNil)
+		a.So(dr, ShouldEqual, "SF7BW125")
+		a.So(tx, ShouldEqual, 18)
+	}
+
+	au, _ := Get("AU_915_928")
+	{
+		dr, tx, err := au.ADRSettings("SF10BW125", 20, 4, defaultMargin)
+		a.So(err, ShouldBeNil)
+		a.So(dr, ShouldEqual, "SF7BW125")
+		a.So(tx, ShouldEqual, 20)
+	}
+
+	cn, _ := Get("CN_470_510")
+	{
+		_, _, err := cn.ADRSettings("SF10BW125", 14, -3, defaultMargin)
 		a.So(err, ShouldNotBeNil)
 	}
 
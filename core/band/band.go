@@ -4,6 +4,8 @@
 package band
 
 import (
+	"time"
+
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
@@ -14,8 +16,21 @@ import (
 // FrequencyPlan includes band configuration and CFList
 type FrequencyPlan struct {
 	lora.Band
+	// Region is the LoRaWAN frequency plan that this frequency plan is based on
+	Region string
 	ADR    *ADRConfig
 	CFList *lorawan.CFList
+
+	// SubBands are the sub-bands (0-7) that are received by the gateways, for frequency plans with sub-bands
+	SubBands []int
+
+	// TxPolicy contains the regulatory limits for transmissions, nil if there are no limits
+	TxPolicy *TxPolicy
+
+	// PingSlotFrequency and PingSlotDataRate are the default ping slot channel of Class B devices. In regions where
+	// the ping slots hop between channels the frequency is zero, and the RX2 frequency is used instead.
+	PingSlotFrequency int
+	PingSlotDataRate  int
 }
 
 func (f *FrequencyPlan) GetDataRateStringForIndex(drIdx int) (string, error) {
@@ -63,11 +78,25 @@ func Guess(frequency uint64) string {
 	return ""
 }
 
-// Get the frequency plan for the given region
+// Get the frequency plan with the given name, which can be a region or the name of a custom frequency plan
 func Get(region string) (frequencyPlan FrequencyPlan, err error) {
 	if fp, ok := frequencyPlans[region]; ok {
 		return fp, nil
 	}
+	return builtin(region)
+}
+
+// Region returns the region of the frequency plan with the given name, or an empty string if it is unknown
+func Region(name string) string {
+	if fp, ok := frequencyPlans[name]; ok {
+		return fp.Region
+	}
+	return ""
+}
+
+// builtin returns the built-in frequency plan for the given region
+func builtin(region string) (frequencyPlan FrequencyPlan, err error) {
+	frequencyPlan.Region = region
 	switch region {
 	case pb_lorawan.FrequencyPlan_EU_863_870.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.EU_863_870, false, lorawan.DwellTimeNoLimit)
@@ -88,20 +117,61 @@ func Get(region string) (frequencyPlan FrequencyPlan, err error) {
 		frequencyPlan.DownlinkChannels = frequencyPlan.UplinkChannels
 		frequencyPlan.CFList = &lorawan.CFList{867100000, 867300000, 867500000, 867700000, 867900000}
 		frequencyPlan.ADR = &ADRConfig{MinDataRate: 0, MaxDataRate: 5, MinTXPower: 2, MaxTXPower: 14}
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 869525000, 3
+		frequencyPlan.TxPolicy = &TxPolicy{DutyCycleBands: []DutyCycleBand{
+			{MinFrequency: 863000000, MaxFrequency: 868000000, DutyCycle: 0.01},  // g 863.0 – 868.0 MHz 1%
+			{MinFrequency: 868000000, MaxFrequency: 868600000, DutyCycle: 0.01},  // g1 868.0 – 868.6 MHz 1%
+			{MinFrequency: 868700000, MaxFrequency: 869200000, DutyCycle: 0.001}, // g2 868.7 – 869.2 MHz 0.1%
+			{MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 0.1},   // g3 869.4 – 869.65 MHz 10%
+			{MinFrequency: 869700000, MaxFrequency: 870000000, DutyCycle: 0.01},  // g4 869.7 – 870.0 MHz 1%
+		}}
 	case pb_lorawan.FrequencyPlan_US_902_928.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.US_902_928, false, lorawan.DwellTime400ms)
+		// TTN uses the second sub-band (channels 8-15 and 65)
+		frequencyPlan.SubBands = []int{1}
+		frequencyPlan.ADR = &ADRConfig{MinDataRate: 0, MaxDataRate: 3, MinTXPower: 10, MaxTXPower: 20}
+		frequencyPlan.PingSlotDataRate = 8
+		frequencyPlan.TxPolicy = &TxPolicy{
+			DutyCycleBands: []DutyCycleBand{{MinFrequency: 902000000, MaxFrequency: 928000000, DutyCycle: 1}},
+			MaxDwellTime:   400 * time.Millisecond,
+		}
 	case pb_lorawan.FrequencyPlan_CN_779_787.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.CN_779_787, false, lorawan.DwellTimeNoLimit)
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 785000000, 3
+		frequencyPlan.TxPolicy = &TxPolicy{DutyCycleBands: []DutyCycleBand{
+			{MinFrequency: 779000000, MaxFrequency: 787000000, DutyCycle: 0.01},
+		}}
 	case pb_lorawan.FrequencyPlan_EU_433.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.EU_433, false, lorawan.DwellTimeNoLimit)
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 434665000, 3
+		frequencyPlan.TxPolicy = &TxPolicy{DutyCycleBands: []DutyCycleBand{
+			{MinFrequency: 433050000, MaxFrequency: 434790000, DutyCycle: 0.1},
+		}}
 	case pb_lorawan.FrequencyPlan_AU_915_928.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.AU_915_928, false, lorawan.DwellTime400ms)
+		// TTN uses the second sub-band (channels 8-15 and 65)
+		frequencyPlan.SubBands = []int{1}
+		frequencyPlan.ADR = &ADRConfig{MinDataRate: 0, MaxDataRate: 5, MinTXPower: 10, MaxTXPower: 20}
+		frequencyPlan.PingSlotDataRate = 8
+		frequencyPlan.TxPolicy = &TxPolicy{
+			DutyCycleBands: []DutyCycleBand{{MinFrequency: 915000000, MaxFrequency: 928000000, DutyCycle: 1}},
+			MaxDwellTime:   400 * time.Millisecond,
+		}
 	case pb_lorawan.FrequencyPlan_CN_470_510.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.CN_470_510, false, lorawan.DwellTimeNoLimit)
+		frequencyPlan.PingSlotDataRate = 2
+		frequencyPlan.TxPolicy = &TxPolicy{DutyCycleBands: []DutyCycleBand{
+			{MinFrequency: 470000000, MaxFrequency: 510000000, DutyCycle: 1},
+		}}
 	case pb_lorawan.FrequencyPlan_AS_923.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.AS_923, false, lorawan.DwellTime400ms)
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 923400000, 3
+		frequencyPlan.TxPolicy = &TxPolicy{MaxDwellTime: 400 * time.Millisecond}
 	case pb_lorawan.FrequencyPlan_AS_920_923.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.AS_923, false, lorawan.DwellTime400ms)
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 923400000, 3
+		// Japan requires listen-before-talk
+		frequencyPlan.TxPolicy = &TxPolicy{MaxDwellTime: 400 * time.Millisecond, ListenBeforeTalk: true}
 		frequencyPlan.UplinkChannels = []lora.Channel{
 			lora.Channel{Frequency: 923200000, DataRates: []int{0, 1, 2, 3, 4, 5}},
 			lora.Channel{Frequency: 923400000, DataRates: []int{0, 1, 2, 3, 4, 5}},
@@ -118,6 +188,8 @@ func Get(region string) (frequencyPlan FrequencyPlan, err error) {
 		frequencyPlan.CFList = &lorawan.CFList{922200000, 922400000, 922600000, 922800000, 923000000}
 	case pb_lorawan.FrequencyPlan_AS_923_925.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.AS_923, false, lorawan.DwellTime400ms)
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 923400000, 3
+		frequencyPlan.TxPolicy = &TxPolicy{MaxDwellTime: 400 * time.Millisecond}
 		frequencyPlan.UplinkChannels = []lora.Channel{
 			lora.Channel{Frequency: 923200000, DataRates: []int{0, 1, 2, 3, 4, 5}},
 			lora.Channel{Frequency: 923400000, DataRates: []int{0, 1, 2, 3, 4, 5}},
@@ -134,6 +206,13 @@ func Get(region string) (frequencyPlan FrequencyPlan, err error) {
 		frequencyPlan.CFList = &lorawan.CFList{923600000, 923800000, 924000000, 924200000, 924400000}
 	case pb_lorawan.FrequencyPlan_KR_920_923.String():
 		frequencyPlan.Band, err = lora.GetConfig(lora.KR_920_923, false, lorawan.DwellTimeNoLimit)
+		frequencyPlan.PingSlotFrequency, frequencyPlan.PingSlotDataRate = 923100000, 3
+		// Korea requires listen-before-talk and limits the duration of a single transmission to 4 seconds
+		frequencyPlan.TxPolicy = &TxPolicy{
+			DutyCycleBands:   []DutyCycleBand{{MinFrequency: 920900000, MaxFrequency: 923400000, DutyCycle: 1}},
+			MaxDwellTime:     4 * time.Second,
+			ListenBeforeTalk: true,
+		}
 		// TTN frequency plan includes extra channels next to the default channels:
 		frequencyPlan.UplinkChannels = []lora.Channel{
 			lora.Channel{Frequency: 922100000, DataRates: []int{0, 1, 2, 3, 4, 5}},
@@ -155,27 +234,44 @@ func Get(region string) (frequencyPlan FrequencyPlan, err error) {
 var frequencyPlans map[string]FrequencyPlan
 var channels map[int]string
 
+var regions = []pb_lorawan.FrequencyPlan{ // ordering is important here
+	pb_lorawan.FrequencyPlan_EU_863_870,
+	pb_lorawan.FrequencyPlan_US_902_928,
+	pb_lorawan.FrequencyPlan_CN_779_787,
+	pb_lorawan.FrequencyPlan_EU_433,
+	pb_lorawan.FrequencyPlan_AS_923,
+	pb_lorawan.FrequencyPlan_AS_920_923,
+	pb_lorawan.FrequencyPlan_AS_923_925,
+	pb_lorawan.FrequencyPlan_KR_920_923,
+	pb_lorawan.FrequencyPlan_AU_915_928,
+	pb_lorawan.FrequencyPlan_CN_470_510,
+}
+
+// custom contains the names of the custom frequency plans, in the order in which they were registered
+var custom []string
+
 func init() {
 	frequencyPlans = make(map[string]FrequencyPlan)
-	channels = make(map[int]string)
-	for _, r := range []pb_lorawan.FrequencyPlan{ // ordering is important here
-		pb_lorawan.FrequencyPlan_EU_863_870,
-		pb_lorawan.FrequencyPlan_US_902_928,
-		pb_lorawan.FrequencyPlan_CN_779_787,
-		pb_lorawan.FrequencyPlan_EU_433,
-		pb_lorawan.FrequencyPlan_AS_923,
-		pb_lorawan.FrequencyPlan_AS_920_923,
-		pb_lorawan.FrequencyPlan_AS_923_925,
-		pb_lorawan.FrequencyPlan_KR_920_923,
-		pb_lorawan.FrequencyPlan_AU_915_928,
-		pb_lorawan.FrequencyPlan_CN_470_510,
-	} {
+	for _, r := range regions {
 		region := r.String()
-		frequencyPlans[region], _ = Get(region)
-		for _, ch := range frequencyPlans[region].UplinkChannels {
+		frequencyPlans[region], _ = builtin(region)
+	}
+	indexChannels()
+}
+
+// indexChannels indexes the uplink channels of the frequency plans, so that Guess can find the frequency plan of a
+// frequency. The channels of custom frequency plans take precedence over the channels of built-in frequency plans.
+func indexChannels() {
+	channels = make(map[int]string)
+	names := append([]string{}, custom...)
+	for _, r := range regions {
+		names = append(names, r.String())
+	}
+	for _, name := range names {
+		for _, ch := range frequencyPlans[name].UplinkChannels {
 			if len(ch.DataRates) > 1 { // ignore FSK channels
 				if _, ok := channels[ch.Frequency]; !ok { // ordering indicates priority
-					channels[ch.Frequency] = region
+					channels[ch.Frequency] = name
 				}
 			}
 		}
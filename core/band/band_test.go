@@ -34,13 +34,18 @@ func TestGet(t *testing.T) {
 		a.So(err, ShouldBeNil)
 		a.So(fp.CFList, ShouldNotBeNil)
 		a.So(fp.ADR, ShouldNotBeNil)
+		a.So(fp.PingSlotFrequency, ShouldEqual, 869525000)
+		a.So(fp.PingSlotDataRate, ShouldEqual, 3)
 	}
 
 	{
 		fp, err := Get("US_902_928")
 		a.So(err, ShouldBeNil)
 		a.So(fp.CFList, ShouldBeNil)
-		a.So(fp.ADR, ShouldBeNil)
+		a.So(fp.ADR, ShouldNotBeNil)
+		a.So(fp.SubBands, ShouldResemble, []int{1})
+		a.So(fp.PingSlotFrequency, ShouldEqual, 0) // Frequency hopping
+		a.So(fp.PingSlotDataRate, ShouldEqual, 8)
 	}
 
 	{
@@ -61,7 +66,8 @@ func TestGet(t *testing.T) {
 		fp, err := Get("AU_915_928")
 		a.So(err, ShouldBeNil)
 		a.So(fp.CFList, ShouldBeNil)
-		a.So(fp.ADR, ShouldBeNil)
+		a.So(fp.ADR, ShouldNotBeNil)
+		a.So(fp.SubBands, ShouldResemble, []int{1})
 	}
 
 	{
@@ -0,0 +1,283 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package band
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/brocaar/lorawan"
+	lora "github.com/brocaar/lorawan/band"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ChannelConfig is the configuration of a channel in a FrequencyPlanConfig
+type ChannelConfig struct {
+	Frequency int   `json:"frequency" yaml:"frequency"`
+	DataRates []int `json:"data_rates" yaml:"data_rates"`
+}
+
+// FrequencyPlanConfig is the configuration of a custom frequency plan. Settings that are not configured are taken
+// from the built-in frequency plan of the region.
+type FrequencyPlanConfig struct {
+	// Name of the frequency plan, as used in the gateway status. A custom frequency plan with the name of a region
+	// replaces the built-in frequency plan of that region.
+	Name string `json:"name" yaml:"name"`
+	// Region that the frequency plan is based on. The data rates, TX powers and RX1 settings of the region are used,
+	// and the region is sent as LoRaWAN frequency plan to the other components.
+	Region string `json:"region" yaml:"region"`
+
+	UplinkChannels []ChannelConfig `json:"uplink_channels,omitempty" yaml:"uplink_channels,omitempty"`
+	// DownlinkChannels are taken from the region if they are not configured, also if the UplinkChannels are
+	DownlinkChannels []ChannelConfig `json:"downlink_channels,omitempty" yaml:"downlink_channels,omitempty"`
+	RX2Frequency     int             `json:"rx2_frequency,omitempty" yaml:"rx2_frequency,omitempty"`
+	RX2DataRate      *int            `json:"rx2_data_rate,omitempty" yaml:"rx2_data_rate,omitempty"`
+	CFList           []uint32        `json:"cf_list,omitempty" yaml:"cf_list,omitempty"`
+	ADR              *ADRConfig      `json:"adr,omitempty" yaml:"adr,omitempty"`
+	SubBands         []int           `json:"sub_bands,omitempty" yaml:"sub_bands,omitempty"`
+
+	PingSlotFrequency int  `json:"ping_slot_frequency,omitempty" yaml:"ping_slot_frequency,omitempty"`
+	PingSlotDataRate  *int `json:"ping_slot_data_rate,omitempty" yaml:"ping_slot_data_rate,omitempty"`
+}
+
+func channelsFromConfig(config []ChannelConfig) (channels []lora.Channel) {
+	for _, ch := range config {
+		channels = append(channels, lora.Channel{Frequency: ch.Frequency, DataRates: ch.DataRates})
+	}
+	return
+}
+
+// FrequencyPlan builds the frequency plan from the configuration
+func (c FrequencyPlanConfig) FrequencyPlan() (frequencyPlan FrequencyPlan, err error) {
+	if c.Name == "" {
+		return frequencyPlan, errors.NewErrInvalidArgument("Frequency Plan", "name is missing")
+	}
+	if _, ok := pb_lorawan.FrequencyPlan_value[c.Region]; !ok {
+		return frequencyPlan, errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("%s has unknown region \"%s\"", c.Name, c.Region))
+	}
+	frequencyPlan, err = builtin(c.Region)
+	if err != nil {
+		return frequencyPlan, err
+	}
+	if len(c.UplinkChannels) > 0 {
+		frequencyPlan.UplinkChannels = channelsFromConfig(c.UplinkChannels)
+		frequencyPlan.CFList = nil // The CFList of the region contains the channels of the region
+	}
+	if len(c.DownlinkChannels) > 0 {
+		frequencyPlan.DownlinkChannels = channelsFromConfig(c.DownlinkChannels)
+	}
+	if c.RX2Frequency != 0 {
+		frequencyPlan.RX2Frequency = c.RX2Frequency
+	}
+	if c.RX2DataRate != nil {
+		frequencyPlan.RX2DataRate = *c.RX2DataRate
+	}
+	if len(c.CFList) > 0 {
+		if len(c.CFList) > len(lorawan.CFList{}) {
+			return frequencyPlan, errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("%s has more than %d frequencies in the CFList", c.Name, len(lorawan.CFList{})))
+		}
+		frequencyPlan.CFList = new(lorawan.CFList)
+		copy(frequencyPlan.CFList[:], c.CFList)
+	}
+	if c.ADR != nil {
+		adr := *c.ADR
+		frequencyPlan.ADR = &adr
+	}
+	if len(c.SubBands) > 0 {
+		frequencyPlan.SubBands = c.SubBands
+	}
+	if c.PingSlotFrequency != 0 {
+		frequencyPlan.PingSlotFrequency = c.PingSlotFrequency
+	}
+	if c.PingSlotDataRate != nil {
+		frequencyPlan.PingSlotDataRate = *c.PingSlotDataRate
+	}
+	return frequencyPlan, frequencyPlan.Validate()
+}
+
+// Validate the frequency plan
+func (f *FrequencyPlan) Validate() error {
+	if _, ok := pb_lorawan.FrequencyPlan_value[f.Region]; !ok {
+		return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("unknown region \"%s\"", f.Region))
+	}
+	validDataRate := func(drIdx int) bool {
+		return drIdx >= 0 && drIdx < len(f.DataRates)
+	}
+	validChannels := func(name string, channels []lora.Channel) error {
+		if len(channels) == 0 {
+			return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("no %s channels", name))
+		}
+		for i, ch := range channels {
+			if ch.Frequency <= 0 {
+				return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("%s channel %d has no frequency", name, i))
+			}
+			if len(ch.DataRates) == 0 {
+				return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("%s channel %d has no data rates", name, i))
+			}
+			for _, drIdx := range ch.DataRates {
+				if !validDataRate(drIdx) {
+					return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("%s channel %d has unknown data rate %d", name, i, drIdx))
+				}
+			}
+		}
+		return nil
+	}
+	if err := validChannels("uplink", f.UplinkChannels); err != nil {
+		return err
+	}
+	if err := validChannels("downlink", f.DownlinkChannels); err != nil {
+		return err
+	}
+
+	if f.RX2Frequency <= 0 {
+		return errors.NewErrInvalidArgument("Frequency Plan", "no RX2 frequency")
+	}
+	if !validDataRate(f.RX2DataRate) {
+		return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("unknown RX2 data rate %d", f.RX2DataRate))
+	}
+
+	if f.PingSlotFrequency < 0 {
+		return errors.NewErrInvalidArgument("Frequency Plan", "invalid ping slot frequency")
+	}
+	if !validDataRate(f.PingSlotDataRate) {
+		return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("unknown ping slot data rate %d", f.PingSlotDataRate))
+	}
+
+	if f.CFList != nil {
+		if f.HasSubBands() {
+			return errors.NewErrInvalidArgument("Frequency Plan", "CFList is not supported for frequency plans with sub-bands")
+		}
+		for _, freq := range f.CFList {
+			if freq == 0 {
+				continue
+			}
+			if freq%100 != 0 {
+				return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("CFList frequency %d is not a multiple of 100 Hz", freq))
+			}
+			var found bool
+			for _, ch := range f.UplinkChannels {
+				if ch.Frequency == int(freq) {
+					found = true
+				}
+			}
+			if !found {
+				return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("CFList frequency %d is not an uplink channel", freq))
+			}
+		}
+	}
+
+	if f.ADR != nil {
+		if !validDataRate(f.ADR.MinDataRate) || !validDataRate(f.ADR.MaxDataRate) || f.ADR.MinDataRate > f.ADR.MaxDataRate {
+			return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("invalid ADR data rates %d-%d", f.ADR.MinDataRate, f.ADR.MaxDataRate))
+		}
+		if f.ADR.MinTXPower > f.ADR.MaxTXPower {
+			return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("invalid ADR TX powers %d-%d", f.ADR.MinTXPower, f.ADR.MaxTXPower))
+		}
+		for _, txPower := range []int{f.ADR.MinTXPower, f.ADR.MaxTXPower} {
+			if _, err := f.GetTxPowerIndexFor(txPower); err != nil {
+				return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("unsupported ADR TX power %d", txPower))
+			}
+		}
+	}
+
+	if len(f.SubBands) > 0 {
+		if !f.HasSubBands() {
+			return errors.NewErrInvalidArgument("Frequency Plan", "sub-bands are not supported for this frequency plan")
+		}
+		seen := make(map[int]bool)
+		for _, subBand := range f.SubBands {
+			if subBand < 0 || subBand >= subBandCount || seen[subBand] {
+				return errors.NewErrInvalidArgument("Frequency Plan", fmt.Sprintf("invalid sub-band %d", subBand))
+			}
+			seen[subBand] = true
+		}
+	}
+
+	return nil
+}
+
+// Register a custom frequency plan. This should be called before the components are started.
+func Register(name string, frequencyPlan FrequencyPlan) error {
+	if err := frequencyPlan.Validate(); err != nil {
+		return errors.Wrapf(err, "Frequency plan %s is invalid", name)
+	}
+	if !IsCustom(name) {
+		custom = append(custom, name)
+	}
+	frequencyPlans[name] = frequencyPlan
+	indexChannels()
+	return nil
+}
+
+// List returns the names of all frequency plans, sorted by name
+func List() []string {
+	names := make([]string, 0, len(frequencyPlans))
+	for name := range frequencyPlans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsCustom returns true if the frequency plan with the given name is a custom frequency plan
+func IsCustom(name string) bool {
+	for _, existing := range custom {
+		if existing == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadFile reads the configurations of frequency plans from a YAML or JSON file. The file contains a list of
+// frequency plan configurations.
+func ReadFile(filename string) ([]FrequencyPlanConfig, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var configs []FrequencyPlanConfig
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err = json.Unmarshal(data, &configs)
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(data, &configs)
+	default:
+		return nil, errors.NewErrInvalidArgument("Frequency Plan File", fmt.Sprintf("%s is not a YAML or JSON file", filename))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not read %s", filename)
+	}
+	return configs, nil
+}
+
+// LoadFile reads the frequency plans from a YAML or JSON file, validates them and registers them. It returns the
+// names of the registered frequency plans.
+func LoadFile(filename string) (names []string, err error) {
+	configs, err := ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	plans := make([]FrequencyPlan, 0, len(configs))
+	for _, config := range configs {
+		frequencyPlan, err := config.FrequencyPlan()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Frequency plan %s in %s is invalid", config.Name, filename)
+		}
+		plans = append(plans, frequencyPlan)
+	}
+	for i, frequencyPlan := range plans {
+		if err := Register(configs[i].Name, frequencyPlan); err != nil {
+			return names, err
+		}
+		names = append(names, configs[i].Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,202 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package band
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func unregister(name string) {
+	delete(frequencyPlans, name)
+	for i, existing := range custom {
+		if existing == name {
+			custom = append(custom[:i], custom[i+1:]...)
+			break
+		}
+	}
+	indexChannels()
+}
+
+func TestValidateBuiltin(t *testing.T) {
+	a := New(t)
+	for _, region := range regions {
+		fp, err := Get(region.String())
+		a.So(err, ShouldBeNil)
+		a.So(fp.Region, ShouldEqual, region.String())
+		a.So(fp.Validate(), ShouldBeNil)
+	}
+}
+
+func TestFrequencyPlanConfig(t *testing.T) {
+	a := New(t)
+
+	_, err := FrequencyPlanConfig{Region: "EU_863_870"}.FrequencyPlan()
+	a.So(err, ShouldNotBeNil)
+
+	_, err = FrequencyPlanConfig{Name: "TEST", Region: "XX_123_456"}.FrequencyPlan()
+	a.So(err, ShouldNotBeNil)
+
+	// Settings that are not configured are taken from the region
+	fp, err := FrequencyPlanConfig{Name: "TEST", Region: "US_902_928", SubBands: []int{0, 1}}.FrequencyPlan()
+	a.So(err, ShouldBeNil)
+	a.So(fp.Region, ShouldEqual, "US_902_928")
+	a.So(fp.UplinkChannels, ShouldHaveLength, 72)
+	a.So(fp.SubBands, ShouldResemble, []int{0, 1})
+	a.So(fp.ADR, ShouldNotBeNil)
+
+	rx2DataRate := 0
+	config := FrequencyPlanConfig{
+		Name:   "TEST",
+		Region: "EU_863_870",
+		UplinkChannels: []ChannelConfig{
+			{Frequency: 868100000, DataRates: []int{0, 1, 2, 3, 4, 5}},
+			{Frequency: 868300000, DataRates: []int{0, 1, 2, 3, 4, 5}},
+			{Frequency: 868500000, DataRates: []int{0, 1, 2, 3, 4, 5}},
+			{Frequency: 869100000, DataRates: []int{0, 1, 2, 3, 4, 5}},
+		},
+		RX2Frequency: 869525000,
+		RX2DataRate:  &rx2DataRate,
+		CFList:       []uint32{869100000},
+		ADR:          &ADRConfig{MinDataRate: 0, MaxDataRate: 5, MinTXPower: 2, MaxTXPower: 14},
+	}
+	fp, err = config.FrequencyPlan()
+	a.So(err, ShouldBeNil)
+	a.So(fp.UplinkChannels, ShouldHaveLength, 4)
+	eu, _ := builtin("EU_863_870")
+	a.So(fp.DownlinkChannels, ShouldResemble, eu.DownlinkChannels)
+	a.So(fp.RX2DataRate, ShouldEqual, 0)
+	a.So(fp.CFList[0], ShouldEqual, 869100000)
+
+	// Invalid configurations
+	for _, invalid := range []func(c *FrequencyPlanConfig){
+		func(c *FrequencyPlanConfig) {
+			c.UplinkChannels = append(c.UplinkChannels, ChannelConfig{Frequency: 867100000, DataRates: []int{16}})
+		},
+		func(c *FrequencyPlanConfig) {
+			c.UplinkChannels = append(c.UplinkChannels, ChannelConfig{Frequency: 867100000})
+		},
+		func(c *FrequencyPlanConfig) { dr := 16; c.RX2DataRate = &dr },
+		func(c *FrequencyPlanConfig) { c.CFList = []uint32{867100000} },
+		func(c *FrequencyPlanConfig) { c.CFList = []uint32{1, 2, 3, 4, 5, 6} },
+		func(c *FrequencyPlanConfig) {
+			c.ADR = &ADRConfig{MinDataRate: 5, MaxDataRate: 0, MinTXPower: 2, MaxTXPower: 14}
+		},
+		func(c *FrequencyPlanConfig) {
+			c.ADR = &ADRConfig{MinDataRate: 0, MaxDataRate: 5, MinTXPower: 2, MaxTXPower: 15}
+		},
+		func(c *FrequencyPlanConfig) { c.SubBands = []int{1} },
+	} {
+		invalidConfig := config
+		invalidConfig.UplinkChannels = append([]ChannelConfig{}, config.UplinkChannels...)
+		invalid(&invalidConfig)
+		_, err = invalidConfig.FrequencyPlan()
+		a.So(err, ShouldNotBeNil)
+	}
+
+	_, err = FrequencyPlanConfig{Name: "TEST", Region: "US_902_928", SubBands: []int{8}}.FrequencyPlan()
+	a.So(err, ShouldNotBeNil)
+
+	// Downlink channels are only taken from the configuration if they are configured
+	config.DownlinkChannels = []ChannelConfig{{Frequency: 869525000, DataRates: []int{0, 1, 2, 3, 4, 5}}}
+	fp, err = config.FrequencyPlan()
+	a.So(err, ShouldBeNil)
+	a.So(fp.UplinkChannels, ShouldHaveLength, 4)
+	a.So(fp.DownlinkChannels, ShouldHaveLength, 1)
+}
+
+func TestRegister(t *testing.T) {
+	a := New(t)
+
+	fp, _ := FrequencyPlanConfig{
+		Name:           "TEST",
+		Region:         "EU_863_870",
+		UplinkChannels: []ChannelConfig{{Frequency: 869100000, DataRates: []int{0, 1, 2, 3, 4, 5}}},
+	}.FrequencyPlan()
+
+	a.So(Guess(869100000), ShouldEqual, "")
+	a.So(Register("TEST", fp), ShouldBeNil)
+	defer unregister("TEST")
+
+	a.So(IsCustom("TEST"), ShouldBeTrue)
+	a.So(IsCustom("EU_863_870"), ShouldBeFalse)
+	a.So(List(), ShouldContain, "TEST")
+	a.So(List(), ShouldContain, "EU_863_870")
+	a.So(Region("TEST"), ShouldEqual, "EU_863_870")
+	a.So(Region("XX_123_456"), ShouldEqual, "")
+	a.So(Guess(869100000), ShouldEqual, "TEST")
+	a.So(Guess(868100000), ShouldEqual, "EU_863_870")
+
+	registered, err := Get("TEST")
+	a.So(err, ShouldBeNil)
+	a.So(registered.UplinkChannels, ShouldHaveLength, 1)
+
+	fp.UplinkChannels = nil
+	a.So(Register("INVALID", fp), ShouldNotBeNil)
+	a.So(IsCustom("INVALID"), ShouldBeFalse)
+}
+
+func TestLoadFile(t *testing.T) {
+	a := New(t)
+
+	dir, err := ioutil.TempDir("", "ttn-frequency-plans")
+	a.So(err, ShouldBeNil)
+	defer os.RemoveAll(dir)
+
+	yamlFile := filepath.Join(dir, "plans.yml")
+	ioutil.WriteFile(yamlFile, []byte(`
+- name: TEST_YAML
+  region: EU_863_870
+  uplink_channels:
+  - frequency: 869100000
+    data_rates: [0, 1, 2, 3, 4, 5]
+  - frequency: 869300000
+    data_rates: [0, 1, 2, 3, 4, 5]
+  rx2_data_rate: 0
+  ping_slot_frequency: 869100000
+  ping_slot_data_rate: 0
+  cf_list: [869300000]
+  adr:
+    min_data_rate: 0
+    max_data_rate: 5
+    min_tx_power: 2
+    max_tx_power: 14
+`), 0644)
+	names, err := LoadFile(yamlFile)
+	a.So(err, ShouldBeNil)
+	a.So(names, ShouldResemble, []string{"TEST_YAML"})
+	defer unregister("TEST_YAML")
+	fp, err := Get("TEST_YAML")
+	a.So(err, ShouldBeNil)
+	a.So(fp.UplinkChannels, ShouldHaveLength, 2)
+	a.So(fp.RX2DataRate, ShouldEqual, 0)
+	a.So(fp.PingSlotFrequency, ShouldEqual, 869100000)
+	a.So(fp.PingSlotDataRate, ShouldEqual, 0)
+	a.So(fp.ADR.MaxTXPower, ShouldEqual, 14)
+	a.So(Guess(869300000), ShouldEqual, "TEST_YAML")
+
+	jsonFile := filepath.Join(dir, "plans.json")
+	ioutil.WriteFile(jsonFile, []byte(`[{"name": "TEST_JSON", "region": "US_902_928", "sub_bands": [0]}]`), 0644)
+	names, err = LoadFile(jsonFile)
+	a.So(err, ShouldBeNil)
+	a.So(names, ShouldResemble, []string{"TEST_JSON"})
+	defer unregister("TEST_JSON")
+	fp, err = Get("TEST_JSON")
+	a.So(err, ShouldBeNil)
+	a.So(fp.SubBands, ShouldResemble, []int{0})
+
+	// Nothing is registered if one of the frequency plans is invalid
+	invalidFile := filepath.Join(dir, "invalid.json")
+	ioutil.WriteFile(invalidFile, []byte(`[{"name": "TEST_VALID", "region": "EU_863_870"}, {"name": "TEST_INVALID", "region": "EU_863_870", "rx2_data_rate": 16}]`), 0644)
+	_, err = LoadFile(invalidFile)
+	a.So(err, ShouldNotBeNil)
+	a.So(IsCustom("TEST_VALID"), ShouldBeFalse)
+
+	_, err = LoadFile(filepath.Join(dir, "plans.txt"))
+	a.So(err, ShouldNotBeNil)
+}
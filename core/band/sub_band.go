@@ -0,0 +1,97 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package band
+
+import "github.com/brocaar/lorawan"
+
+// Frequency plans with sub-bands (US_902_928 and AU_915_928) have 64 125 kHz uplink channels and 8 500 kHz uplink
+// channels. Each sub-band consists of 8 consecutive 125 kHz channels and one 500 kHz channel, which is what an 8
+// channel gateway can receive.
+const (
+	subBandChannels     = 8
+	subBandCount        = 8
+	subBandUplinkCount  = subBandChannels*subBandCount + subBandCount
+	subBand500kHzOffset = subBandChannels * subBandCount
+	chMaskLength        = 16
+)
+
+// HasSubBands returns true if the uplink channels of the frequency plan are divided in sub-bands
+func (f *FrequencyPlan) HasSubBands() bool {
+	return len(f.UplinkChannels) == subBandUplinkCount
+}
+
+// SubBandChannels returns the indices of the uplink channels of the sub-band (0-7)
+func SubBandChannels(subBand int) []int {
+	channels := make([]int, 0, subBandChannels+1)
+	for i := 0; i < subBandChannels; i++ {
+		channels = append(channels, subBand*subBandChannels+i)
+	}
+	return append(channels, subBand500kHzOffset+subBand)
+}
+
+// ChMaskBlock is a ChMask together with the ChMaskCntl that indicates the channels that it applies to
+type ChMaskBlock struct {
+	ChMaskCntl uint8
+	ChMask     lorawan.ChMask
+}
+
+// ChMaskBlocks returns the ChMasks that enable the uplink channels of the frequency plan. For frequency plans with
+// sub-bands, only the channels of the sub-bands of the gateways are enabled, and each ChMaskBlock has to be sent in
+// a separate LinkADRReq of the same downlink message. For other frequency plans, only the channels that support the
+// data rate are enabled.
+func (f *FrequencyPlan) ChMaskBlocks(drIdx int) []ChMaskBlock {
+	if !f.HasSubBands() {
+		var block ChMaskBlock
+		for i, ch := range f.UplinkChannels {
+			if i >= chMaskLength {
+				break
+			}
+			for _, dr := range ch.DataRates {
+				if dr == drIdx {
+					block.ChMask[i] = true
+				}
+			}
+		}
+		return []ChMaskBlock{block}
+	}
+
+	// All 125 kHz channels on, the ChMask applies to the 500 kHz channels
+	if len(f.SubBands) == 0 {
+		block := ChMaskBlock{ChMaskCntl: 6}
+		for i := 0; i < subBandCount; i++ {
+			block.ChMask[i] = true
+		}
+		return []ChMaskBlock{block}
+	}
+
+	// All 125 kHz channels off, the ChMask applies to the 500 kHz channels
+	blocks := []ChMaskBlock{{ChMaskCntl: 7}}
+	var enabled [subBand500kHzOffset]bool
+	for _, subBand := range f.SubBands {
+		for _, ch := range SubBandChannels(subBand) {
+			if ch >= subBand500kHzOffset {
+				blocks[0].ChMask[ch-subBand500kHzOffset] = true
+			} else {
+				enabled[ch] = true
+			}
+		}
+	}
+
+	// The ChMaskCntl is the index of the block of 16 125 kHz channels that the ChMask applies to
+	for cntl := 0; cntl < subBand500kHzOffset/chMaskLength; cntl++ {
+		block := ChMaskBlock{ChMaskCntl: uint8(cntl)}
+		var hasChannels bool
+		for i := 0; i < chMaskLength; i++ {
+			if enabled[cntl*chMaskLength+i] {
+				block.ChMask[i] = true
+				hasChannels = true
+			}
+		}
+		if hasChannels {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
@@ -0,0 +1,78 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package band
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSubBandChannels(t *testing.T) {
+	a := New(t)
+	a.So(SubBandChannels(0), ShouldResemble, []int{0, 1, 2, 3, 4, 5, 6, 7, 64})
+	a.So(SubBandChannels(1), ShouldResemble, []int{8, 9, 10, 11, 12, 13, 14, 15, 65})
+	a.So(SubBandChannels(7), ShouldResemble, []int{56, 57, 58, 59, 60, 61, 62, 63, 71})
+}
+
+func TestChMaskBlocks(t *testing.T) {
+	a := New(t)
+
+	eu, _ := Get("EU_863_870")
+	a.So(eu.HasSubBands(), ShouldBeFalse)
+	blocks := eu.ChMaskBlocks(5)
+	a.So(blocks, ShouldHaveLength, 1)
+	a.So(blocks[0].ChMaskCntl, ShouldEqual, 0)
+	for i := 0; i < 8; i++ {
+		a.So(blocks[0].ChMask[i], ShouldBeTrue)
+	}
+	a.So(blocks[0].ChMask[8], ShouldBeFalse)
+
+	for _, name := range []string{"US_902_928", "AU_915_928"} {
+		fp, _ := Get(name)
+		a.So(fp.HasSubBands(), ShouldBeTrue)
+
+		// Channels 8-15 and 65
+		blocks := fp.ChMaskBlocks(2)
+		a.So(blocks, ShouldHaveLength, 2)
+		a.So(blocks[0].ChMaskCntl, ShouldEqual, 7)
+		for i := range blocks[0].ChMask {
+			a.So(blocks[0].ChMask[i], ShouldEqual, i == 1)
+		}
+		a.So(blocks[1].ChMaskCntl, ShouldEqual, 0)
+		for i := range blocks[1].ChMask {
+			a.So(blocks[1].ChMask[i], ShouldEqual, i >= 8)
+		}
+
+		// Channels 16-31 and 66-67
+		fp.SubBands = []int{2, 3}
+		blocks = fp.ChMaskBlocks(2)
+		a.So(blocks, ShouldHaveLength, 2)
+		for i := range blocks[0].ChMask {
+			a.So(blocks[0].ChMask[i], ShouldEqual, i == 2 || i == 3)
+		}
+		a.So(blocks[1].ChMaskCntl, ShouldEqual, 1)
+		for i := range blocks[1].ChMask {
+			a.So(blocks[1].ChMask[i], ShouldBeTrue)
+		}
+
+		// Channels 8-23 and 65-66 are in two blocks of 16 channels
+		fp.SubBands = []int{1, 2}
+		blocks = fp.ChMaskBlocks(2)
+		a.So(blocks, ShouldHaveLength, 3)
+		a.So(blocks[1].ChMaskCntl, ShouldEqual, 0)
+		a.So(blocks[2].ChMaskCntl, ShouldEqual, 1)
+		a.So(blocks[2].ChMask[7], ShouldBeTrue)
+		a.So(blocks[2].ChMask[8], ShouldBeFalse)
+
+		// All channels
+		fp.SubBands = nil
+		blocks = fp.ChMaskBlocks(2)
+		a.So(blocks, ShouldHaveLength, 1)
+		a.So(blocks[0].ChMaskCntl, ShouldEqual, 6)
+		for i := range blocks[0].ChMask {
+			a.So(blocks[0].ChMask[i], ShouldEqual, i < 8)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package band
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/toa"
+)
+
+// DutyCycleWindow is the sliding window in which the airtime of transmissions is limited by the duty-cycle
+const DutyCycleWindow = time.Hour
+
+// DutyCycleBand is a range of frequencies in which the airtime of transmissions is limited
+type DutyCycleBand struct {
+	MinFrequency uint64 // Inclusive
+	MaxFrequency uint64 // Exclusive
+	// DutyCycle is the fraction of the DutyCycleWindow that may be used for transmissions, 1 for no limit
+	DutyCycle float64
+}
+
+// Contains returns true if the frequency is in the band
+func (b DutyCycleBand) Contains(frequency uint64) bool {
+	return frequency >= b.MinFrequency && frequency < b.MaxFrequency
+}
+
+// Budget returns the total airtime that may be used in the band in the DutyCycleWindow
+func (b DutyCycleBand) Budget() time.Duration {
+	return time.Duration(b.DutyCycle * float64(DutyCycleWindow))
+}
+
+// TxPolicy contains the regulatory limits for transmissions in a frequency plan
+type TxPolicy struct {
+	// DutyCycleBands are the bands in which transmissions are allowed. If empty, transmissions are allowed on all
+	// frequencies without duty-cycle limit
+	DutyCycleBands []DutyCycleBand
+	// MaxDwellTime is the maximum airtime of a single transmission, 0 for no limit
+	MaxDwellTime time.Duration
+	// ListenBeforeTalk indicates that the gateway has to check that the channel is free before it transmits
+	ListenBeforeTalk bool
+}
+
+// GetDutyCycleBand returns the band of the frequency, or false if transmissions on the frequency are not allowed
+func (p *TxPolicy) GetDutyCycleBand(frequency uint64) (DutyCycleBand, bool) {
+	if len(p.DutyCycleBands) == 0 {
+		return DutyCycleBand{DutyCycle: 1}, true
+	}
+	for _, band := range p.DutyCycleBands {
+		if band.Contains(frequency) {
+			return band, true
+		}
+	}
+	return DutyCycleBand{}, false
+}
+
+// CheckDwellTime returns an error if a transmission with the given airtime exceeds the dwell time
+func (p *TxPolicy) CheckDwellTime(airtime time.Duration) error {
+	if p.MaxDwellTime != 0 && airtime > p.MaxDwellTime {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("airtime of %s exceeds the dwell time of %s", airtime, p.MaxDwellTime))
+	}
+	return nil
+}
+
+// CheckDutyCycle returns an error if a transmission on the frequency is not allowed, or if it would exceed the
+// duty-cycle given the airtime that was already used
+func (p *TxPolicy) CheckDutyCycle(frequency uint64, airtime time.Duration, used *Airtime) error {
+	band, ok := p.GetDutyCycleBand(frequency)
+	if !ok {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("transmissions on %d Hz are not allowed", frequency))
+	}
+	if band.DutyCycle >= 1 {
+		return nil
+	}
+	if remaining := band.Budget() - used.Get(band); airtime > remaining {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("airtime of %s exceeds the remaining duty-cycle budget of %s on %d Hz", airtime, remaining, frequency))
+	}
+	return nil
+}
+
+// Check returns an error if a transmission with the given airtime on the frequency exceeds the regulatory limits
+func (p *TxPolicy) Check(frequency uint64, airtime time.Duration, used *Airtime) error {
+	if err := p.CheckDwellTime(airtime); err != nil {
+		return err
+	}
+	return p.CheckDutyCycle(frequency, airtime, used)
+}
+
+// DutyCycleBudget is the airtime that remains in a duty-cycle limited band
+type DutyCycleBudget struct {
+	DutyCycleBand
+	Remaining time.Duration
+}
+
+// GetDutyCycleBudgets returns the remaining airtime in the duty-cycle limited bands
+func (p *TxPolicy) GetDutyCycleBudgets(used *Airtime) (budgets []DutyCycleBudget) {
+	for _, band := range p.DutyCycleBands {
+		if band.DutyCycle >= 1 {
+			continue
+		}
+		remaining := band.Budget() - used.Get(band)
+		if remaining < 0 {
+			remaining = 0
+		}
+		budgets = append(budgets, DutyCycleBudget{DutyCycleBand: band, Remaining: remaining})
+	}
+	return
+}
+
+// ComputeAirtime returns the airtime of a transmission with the given payload size and configuration
+func ComputeAirtime(payloadSize uint, config *pb_lorawan.TxConfiguration) (time.Duration, error) {
+	if config == nil {
+		return 0, errors.NewErrInvalidArgument("TX Configuration", "not present")
+	}
+	switch config.Modulation {
+	case pb_lorawan.Modulation_LORA:
+		return toa.ComputeLoRa(payloadSize, config.DataRate, config.CodingRate)
+	case pb_lorawan.Modulation_FSK:
+		return toa.ComputeFSK(payloadSize, int(config.BitRate))
+	}
+	return 0, errors.NewErrInvalidArgument("TX Configuration", "unknown modulation")
+}
+
+// NewAirtime returns a new Airtime that keeps track of transmissions in the DutyCycleWindow
+func NewAirtime() *Airtime {
+	return &Airtime{now: time.Now}
+}
+
+// Airtime keeps track of the airtime of transmissions in a sliding window
+type Airtime struct {
+	mu            sync.Mutex
+	transmissions []transmission
+	now           func() time.Time
+}
+
+type transmission struct {
+	frequency uint64
+	time      time.Time
+	airtime   time.Duration
+}
+
+// expire removes the transmissions that are no longer in the window, it should be called with the lock held
+func (a *Airtime) expire() {
+	windowStart := a.now().Add(-1 * DutyCycleWindow)
+	var i int
+	for i < len(a.transmissions) && a.transmissions[i].time.Before(windowStart) {
+		i++
+	}
+	a.transmissions = a.transmissions[i:]
+}
+
+// Add a transmission on the frequency with the given airtime
+func (a *Airtime) Add(frequency uint64, airtime time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire()
+	a.transmissions = append(a.transmissions, transmission{frequency: frequency, time: a.now(), airtime: airtime})
+}
+
+// Remove the last transmission on the frequency with the given airtime, for example if it was not transmitted
+func (a *Airtime) Remove(frequency uint64, airtime time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := len(a.transmissions) - 1; i >= 0; i-- {
+		if a.transmissions[i].frequency == frequency && a.transmissions[i].airtime == airtime {
+			a.transmissions = append(a.transmissions[:i], a.transmissions[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsEmpty returns true if there were no transmissions in the window
+func (a *Airtime) IsEmpty() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire()
+	return len(a.transmissions) == 0
+}
+
+// Get the airtime that was used in the band in the window
+func (a *Airtime) Get(band DutyCycleBand) (used time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expire()
+	for _, transmission := range a.transmissions {
+		if band.Contains(transmission.frequency) {
+			used += transmission.airtime
+		}
+	}
+	return
+}
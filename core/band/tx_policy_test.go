@@ -0,0 +1,104 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package band
+
+import (
+	"testing"
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestComputeAirtime(t *testing.T) {
+	a := New(t)
+
+	airtime, err := ComputeAirtime(10, &pb_lorawan.TxConfiguration{Modulation: pb_lorawan.Modulation_LORA, DataRate: "SF7BW125", CodingRate: "4/5"})
+	a.So(err, ShouldBeNil)
+	a.So(airtime, ShouldAlmostEqual, 41216*time.Microsecond)
+
+	airtime, err = ComputeAirtime(12, &pb_lorawan.TxConfiguration{Modulation: pb_lorawan.Modulation_FSK, BitRate: 50000})
+	a.So(err, ShouldBeNil)
+	a.So(airtime, ShouldBeGreaterThan, 0)
+
+	_, err = ComputeAirtime(12, nil)
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestAirtime(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+	used := NewAirtime()
+	used.now = func() time.Time { return now }
+
+	g1 := DutyCycleBand{MinFrequency: 868000000, MaxFrequency: 868600000, DutyCycle: 0.01}
+	g3 := DutyCycleBand{MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 0.1}
+
+	used.Add(868100000, time.Second)
+	used.Add(868500000, time.Second)
+	used.Add(869525000, time.Second)
+	a.So(used.Get(g1), ShouldEqual, 2*time.Second)
+	a.So(used.Get(g3), ShouldEqual, time.Second)
+
+	// Sliding window
+	now = now.Add(30 * time.Minute)
+	used.Add(868300000, time.Second)
+	a.So(used.Get(g1), ShouldEqual, 3*time.Second)
+	now = now.Add(31 * time.Minute)
+	a.So(used.Get(g1), ShouldEqual, time.Second)
+	a.So(used.Get(g3), ShouldEqual, 0)
+
+	// Remove transmissions that were not transmitted
+	used.Remove(868300000, 2*time.Second)
+	a.So(used.Get(g1), ShouldEqual, time.Second)
+	used.Remove(868300000, time.Second)
+	a.So(used.Get(g1), ShouldEqual, 0)
+	a.So(used.IsEmpty(), ShouldBeTrue)
+}
+
+func TestTxPolicy(t *testing.T) {
+	a := New(t)
+
+	eu, _ := Get("EU_863_870")
+	a.So(eu.TxPolicy, ShouldNotBeNil)
+
+	used := NewAirtime()
+
+	// Not allowed frequency
+	a.So(eu.TxPolicy.Check(869300000, time.Second, used), ShouldNotBeNil)
+
+	// The g1 band allows 36 seconds per hour
+	a.So(eu.TxPolicy.Check(868100000, 36*time.Second, used), ShouldBeNil)
+	a.So(eu.TxPolicy.Check(868100000, 37*time.Second, used), ShouldNotBeNil)
+	used.Add(868100000, 30*time.Second)
+	a.So(eu.TxPolicy.Check(868300000, 6*time.Second, used), ShouldBeNil)
+	a.So(eu.TxPolicy.Check(868300000, 7*time.Second, used), ShouldNotBeNil)
+
+	// Other bands are not affected
+	a.So(eu.TxPolicy.Check(869525000, 7*time.Second, used), ShouldBeNil)
+
+	budgets := eu.TxPolicy.GetDutyCycleBudgets(used)
+	a.So(budgets, ShouldHaveLength, 5)
+	for _, budget := range budgets {
+		if budget.Contains(868100000) {
+			a.So(budget.Remaining, ShouldEqual, 6*time.Second)
+		} else {
+			a.So(budget.Remaining, ShouldEqual, budget.Budget())
+		}
+	}
+
+	// Dwell time
+	us, _ := Get("US_902_928")
+	a.So(us.TxPolicy.Check(923300000, 300*time.Millisecond, used), ShouldBeNil)
+	a.So(us.TxPolicy.Check(923300000, 500*time.Millisecond, used), ShouldNotBeNil)
+	a.So(us.TxPolicy.GetDutyCycleBudgets(used), ShouldBeEmpty)
+
+	as, _ := Get("AS_923")
+	a.So(as.TxPolicy.Check(923200000, 500*time.Millisecond, used), ShouldNotBeNil)
+
+	kr, _ := Get("KR_920_923")
+	a.So(kr.TxPolicy.ListenBeforeTalk, ShouldBeTrue)
+	a.So(kr.TxPolicy.Check(922100000, time.Second, used), ShouldBeNil)
+}
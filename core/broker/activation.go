@@ -17,7 +17,6 @@ import (
 	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
 	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
-	"github.com/brocaar/lorawan"
 )
 
 type challengeResponseWithHandler struct {
@@ -112,18 +111,15 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 
 	ctx = ctx.WithField("NumHandlers", len(announcements))
 
-	// LoRaWAN: Unmarshal and prepare version without MIC
-	var phyPayload lorawan.PHYPayload
-	err = phyPayload.UnmarshalBinary(deduplicatedActivationRequest.Payload)
-	if err != nil {
-		return nil, err
-	}
-	correctMIC := phyPayload.MIC
-	phyPayload.MIC = [4]byte{0, 0, 0, 0}
-	phyPayloadWithoutMIC, err := phyPayload.MarshalBinary()
-	if err != nil {
-		return nil, err
+	// LoRaWAN: Prepare version without MIC. The payload is a JoinRequest, or a RejoinRequest of LoRaWAN 1.1 that
+	// can not be unmarshaled by the lorawan package
+	payload := deduplicatedActivationRequest.Payload
+	if len(payload) <= 4 {
+		return nil, errors.NewErrInvalidArgument("Activation", "payload too short")
 	}
+	correctMIC := string(payload[len(payload)-4:])
+	phyPayloadWithoutMIC := make([]byte, len(payload))
+	copy(phyPayloadWithoutMIC, payload[:len(payload)-4])
 
 	// Build Challenge
 	challenge := &pb.ActivationChallengeRequest{
@@ -170,12 +166,8 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 	var joinHandler *pb_discovery.Announcement
 	var joinHandlerClient pb_handler.HandlerClient
 	for res := range responses {
-		var phyPayload lorawan.PHYPayload
-		err = phyPayload.UnmarshalBinary(res.response.Payload)
-		if err != nil {
-			continue
-		}
-		if phyPayload.MIC != correctMIC {
+		resPayload := res.response.Payload
+		if len(resPayload) != len(payload) || string(resPayload[len(resPayload)-4:]) != correctMIC {
 			continue
 		}
 
@@ -28,6 +28,7 @@ type Broker interface {
 
 	HandleUplink(uplink *pb.UplinkMessage) error
 	HandleDownlink(downlink *pb.DownlinkMessage) error
+	HandleTxAck(ack *pb.TxAcknowledgment) error
 	HandleActivation(activation *pb.DeviceActivationRequest) (*pb.DeviceActivationResponse, error)
 
 	ActivateRouter(id string) (<-chan *pb.DownlinkMessage, error)
@@ -4,11 +4,13 @@
 package broker
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	pb "github.com/TheThingsNetwork/ttn/api/broker"
 	"github.com/TheThingsNetwork/ttn/api/fields"
+	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
 	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
@@ -44,22 +46,78 @@ func (b *broker) HandleDownlink(downlink *pb.DownlinkMessage) error {
 		return errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not handle downlink")
 	}
 
-	var routerID string
+	if downlink.Multicast != nil {
+		// Send a copy of the message to the Router of each selected gateway
+		for _, option := range downlink.Multicast.DownlinkOptions {
+			multicast := *downlink
+			multicast.DownlinkOption = option
+			if _, err = b.forwardDownlink(&multicast); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	routerID, err := b.forwardDownlink(downlink)
+	ctx = ctx.WithField("RouterID", routerID)
+	return err
+}
+
+// forwardDownlink forwards the downlink to the Router of its DownlinkOption
+func (b *broker) forwardDownlink(downlink *pb.DownlinkMessage) (routerID string, err error) {
 	if id := strings.Split(downlink.DownlinkOption.Identifier, ":"); len(id) == 2 {
 		routerID = id[0]
 	} else {
-		return errors.NewErrInvalidArgument("DownlinkOption Identifier", "invalid format")
+		return "", errors.NewErrInvalidArgument("DownlinkOption Identifier", "invalid format")
 	}
-	ctx = ctx.WithField("RouterID", routerID)
 
 	router, err := b.getRouter(routerID)
 	if err != nil {
-		return err
+		return routerID, err
 	}
 
 	downlink.Trace = downlink.Trace.WithEvent(trace.ForwardEvent, "router", routerID)
 
 	router <- downlink
 
+	return routerID, nil
+}
+
+// HandleTxAck forwards the acknowledgment of a downlink message that the gateway could not transmit to the Handler of the application
+func (b *broker) HandleTxAck(ack *pb.TxAcknowledgment) (err error) {
+	ctx := b.Ctx.WithFields(fields.Get(ack)).WithField("GatewayID", ack.GatewayId)
+	defer func() {
+		if err != nil {
+			ctx.WithError(err).Warn("Could not handle TxAcknowledgment")
+		} else {
+			ctx.WithField("Error", ack.Error).Debug("Handled TxAcknowledgment")
+		}
+	}()
+
+	ack.Trace = ack.Trace.WithEvent(trace.ReceiveEvent)
+
+	announcements, err := b.Discovery.GetAllHandlersForAppID(ack.AppId)
+	if err != nil {
+		return err
+	}
+	if len(announcements) == 0 {
+		return errors.NewErrNotFound(fmt.Sprintf("Handler for AppID %s", ack.AppId))
+	}
+	if len(announcements) > 1 {
+		return errors.NewErrInternal(fmt.Sprintf("Multiple Handlers for AppID %s", ack.AppId))
+	}
+
+	conn, err := b.getHandlerConn(announcements[0].Id)
+	if err != nil {
+		return err
+	}
+
+	ack.Trace = ack.Trace.WithEvent(trace.ForwardEvent, "handler", announcements[0].Id)
+
+	_, err = pb_handler.NewHandlerClient(conn).TxAck(b.Component.GetContext(""), ack)
+	if err != nil {
+		return errors.Wrap(errors.FromGRPCError(err), "Handler did not handle TxAcknowledgment")
+	}
+
 	return nil
 }
@@ -62,3 +62,37 @@ func TestDownlink(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(len(dlch), ShouldEqual, 1)
 }
+
+func TestMulticastDownlink(t *testing.T) {
+	a := New(t)
+
+	dlch := make(chan *pb.DownlinkMessage, 2)
+	logger := GetLogger(t, "TestMulticastDownlink")
+	b := &broker{
+		Component: &component.Component{
+			Ctx:     logger,
+			Monitor: pb_monitor.NewClient(pb_monitor.DefaultClientConfig),
+		},
+		ns: &mockNetworkServer{},
+		routers: map[string]chan *pb.DownlinkMessage{
+			"routerID": dlch,
+		},
+	}
+	b.InitStatus()
+
+	err := b.HandleDownlink(&pb.DownlinkMessage{
+		AppId: "app",
+		Multicast: &pb.Multicast{
+			GroupId:    "group",
+			GatewayIds: []string{"gtw1", "gtw2"},
+			DownlinkOptions: []*pb.DownlinkOption{
+				&pb.DownlinkOption{GatewayId: "gtw1", Identifier: "routerID:"},
+				&pb.DownlinkOption{GatewayId: "gtw2", Identifier: "routerID:"},
+			},
+		},
+	})
+	a.So(err, ShouldBeNil)
+	a.So(len(dlch), ShouldEqual, 2)
+	a.So((<-dlch).DownlinkOption.GatewayId, ShouldEqual, "gtw1")
+	a.So((<-dlch).DownlinkOption.GatewayId, ShouldEqual, "gtw2")
+}
@@ -9,6 +9,7 @@ import (
 	pb "github.com/TheThingsNetwork/ttn/api/broker"
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -122,6 +123,20 @@ func (b *brokerRPC) Activate(ctx context.Context, req *pb.DeviceActivationReques
 	return
 }
 
+func (b *brokerRPC) TxAck(ctx context.Context, req *pb.TxAcknowledgment) (*empty.Empty, error) {
+	_, err := b.broker.ValidateNetworkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid TxAcknowledgment")
+	}
+	if err := b.broker.HandleTxAck(req); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 func (b *broker) RegisterRPC(s *grpc.Server) {
 	server := &brokerRPC{broker: b}
 	server.SetLogger(b.Ctx)
@@ -20,6 +20,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/TheThingsNetwork/ttn/utils/fcnt"
+	"github.com/TheThingsNetwork/ttn/utils/frame"
 	"github.com/brocaar/lorawan"
 )
 
@@ -114,11 +115,9 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 	var micChecks int
 	originalFCnt := macPayload.FHDR.FCnt
 	for _, candidate := range getDevicesResp.Results {
-		nwkSKey := lorawan.AES128Key(*candidate.NwkSKey)
-
 		// First check with the 16 bit counter
 		micChecks++
-		ok, err = phyPayload.ValidateMIC(nwkSKey)
+		ok, err = validateMIC(phyPayload, deduplicatedUplink.Payload, candidate, devAddr, macPayload.FHDR.FCnt)
 		if err != nil {
 			return err
 		}
@@ -133,7 +132,7 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 			// If 32 bit counter has different value, perform another MIC check
 			if macPayload.FHDR.FCnt != originalFCnt {
 				micChecks++
-				ok, err = phyPayload.ValidateMIC(nwkSKey)
+				ok, err = validateMIC(phyPayload, deduplicatedUplink.Payload, candidate, devAddr, macPayload.FHDR.FCnt)
 				if err != nil {
 					return err
 				}
@@ -258,6 +257,16 @@ func selectBestDownlink(options []*pb.DownlinkOption) *pb.DownlinkOption {
 	return options[0]
 }
 
+// validateMIC validates the MIC of an uplink message for a candidate device. The NwkSKey of LoRaWAN 1.1 devices is the
+// FNwkSIntKey, so for those devices only the part of the MIC that is computed with the FNwkSIntKey is validated. The
+// NetworkServer validates the full MIC of the selected device.
+func validateMIC(phyPayload lorawan.PHYPayload, payload []byte, candidate *pb_lorawan.Device, devAddr types.DevAddr, fCnt uint32) (bool, error) {
+	if candidate.MacVersion == pb_lorawan.MACVersion_MAC_V1_1 {
+		return frame.ValidateUplinkFMIC(*candidate.NwkSKey, devAddr, fCnt, payload), nil
+	}
+	return phyPayload.ValidateMIC(lorawan.AES128Key(*candidate.NwkSKey))
+}
+
 // ByFCntUp implements sort.Interface for []*pb_lorawan.Device based on FCnt
 type ByFCntUp []*pb_lorawan.Device
 
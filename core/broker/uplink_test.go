@@ -150,6 +150,30 @@ func TestHandleUplink(t *testing.T) {
 	a.So(err, ShouldBeNil)
 }
 
+func TestValidateMIC(t *testing.T) {
+	a := New(t)
+
+	devAddr := types.DevAddr{0x49, 0xBE, 0x7D, 0xF1}
+	nwkSKey := types.NwkSKey{0x44, 0x02, 0x42, 0x41, 0xED, 0x4C, 0xE9, 0xA6, 0x8C, 0x6A, 0x8B, 0xC0, 0x55, 0x23, 0x3F, 0xD3}
+	candidate := &pb_lorawan.Device{NwkSKey: &nwkSKey}
+
+	payload := []byte{0x40, 0xF1, 0x7D, 0xBE, 0x49, 0x00, 0x02, 0x00, 0x01, 0x95, 0x43, 0x78, 0x76, 0x2B, 0x11, 0xFF, 0x0D}
+	var phyPayload lorawan.PHYPayload
+	a.So(phyPayload.UnmarshalBinary(payload), ShouldBeNil)
+	ok, err := validateMIC(phyPayload, payload, candidate, devAddr, 2)
+	a.So(err, ShouldBeNil)
+	a.So(ok, ShouldBeTrue)
+
+	// LoRaWAN 1.1: the last two bytes of the MIC are computed with the FNwkSIntKey
+	candidate.MacVersion = pb_lorawan.MACVersion_MAC_V1_1
+	ok, _ = validateMIC(phyPayload, payload, candidate, devAddr, 2)
+	a.So(ok, ShouldBeFalse)
+	payload = append(payload[:13], 0x00, 0x00, 0x2B, 0x11)
+	a.So(phyPayload.UnmarshalBinary(payload), ShouldBeNil)
+	ok, _ = validateMIC(phyPayload, payload, candidate, devAddr, 2)
+	a.So(ok, ShouldBeTrue)
+}
+
 func TestDeduplicateUplink(t *testing.T) {
 	a := New(t)
 
@@ -47,6 +47,16 @@ func (h *handler) HandleActivationChallenge(challenge *pb_broker.ActivationChall
 		return nil, err
 	}
 
+	if dev.IsLoRaWAN11() {
+		bytes, err := handleLoRaWAN11ActivationChallenge(dev, challenge.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return &pb_broker.ActivationChallengeResponse{
+			Payload: bytes,
+		}, nil
+	}
+
 	// Unmarshal LoRaWAN
 	var reqPHY lorawan.PHYPayload
 	if err = reqPHY.UnmarshalBinary(challenge.Payload); err != nil {
@@ -76,7 +86,7 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 	start := time.Now()
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.ActivationErrorEvent,
@@ -85,7 +95,7 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 					DevEUI:         *activation.DevEui,
 					ErrorEventData: types.ErrorEventData{Error: err.Error()},
 				},
-			}
+			})
 			activation.Trace = activation.Trace.WithEvent(trace.DropEvent, "reason", err)
 			ctx.WithError(err).Warn("Could not handle activation")
 		} else {
@@ -122,6 +132,11 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 		return nil, err
 	}
 
+	if dev.IsLoRaWAN11() {
+		res, err = h.handleLoRaWAN11Activation(ctx, activation, dev)
+		return res, err
+	}
+
 	// Unmarshal LoRaWAN
 	var reqPHY lorawan.PHYPayload
 	if err = reqPHY.UnmarshalBinary(activation.Payload); err != nil {
@@ -157,34 +172,14 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 	activation.Trace = activation.Trace.WithEvent(trace.AcceptEvent)
 
 	// Prepare Device Activation Response
-	var resPHY lorawan.PHYPayload
-	if err = resPHY.UnmarshalBinary(activation.ResponseTemplate.Payload); err != nil {
-		return nil, err
-	}
-	resMAC, ok := resPHY.MACPayload.(*lorawan.DataPayload)
-	if !ok {
-		err = errors.NewErrInvalidArgument("Activation ResponseTemplate", "MACPayload must be a *DataPayload")
-		return nil, err
-	}
-	joinAccept := &lorawan.JoinAcceptPayload{}
-	if err = joinAccept.UnmarshalBinary(false, resMAC.Bytes); err != nil {
+	var resPHY *lorawan.PHYPayload
+	var joinAccept *lorawan.JoinAcceptPayload
+	resPHY, joinAccept, err = unmarshalJoinAcceptTemplate(activation.ResponseTemplate.Payload)
+	if err != nil {
 		return nil, err
 	}
-	resPHY.MACPayload = joinAccept
 
-	// Publish Activation
-	mqttMetadata, _ := h.getActivationMetadata(ctx, activation, dev)
-	h.mqttEvent <- &types.DeviceEvent{
-		AppID: appID,
-		DevID: devID,
-		Event: types.ActivationEvent,
-		Data: types.ActivationEventData{
-			AppEUI:   *activation.AppEui,
-			DevEUI:   *activation.DevEui,
-			DevAddr:  types.DevAddr(joinAccept.DevAddr),
-			Metadata: mqttMetadata,
-		},
-	}
+	h.publishActivation(ctx, activation, dev, types.DevAddr(joinAccept.DevAddr))
 
 	// Generate random AppNonce
 	var appNonce device.AppNonce
@@ -216,6 +211,7 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 	dev.DevAddr = types.DevAddr(joinAccept.DevAddr)
 	dev.AppSKey = appSKey
 	dev.NwkSKey = nwkSKey
+	dev.FCntDown = 0 // The frame counters are reset in the new session
 	dev.UsedAppNonces = append(dev.UsedAppNonces, appNonce)
 	dev.UsedDevNonces = append(dev.UsedDevNonces, reqMAC.DevNonce)
 	err = h.devices.Set(dev)
@@ -248,3 +244,37 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 
 	return res, nil
 }
+
+// unmarshalJoinAcceptTemplate unmarshals the (unencrypted) join-accept in the response template of an activation
+func unmarshalJoinAcceptTemplate(payload []byte) (*lorawan.PHYPayload, *lorawan.JoinAcceptPayload, error) {
+	var resPHY lorawan.PHYPayload
+	if err := resPHY.UnmarshalBinary(payload); err != nil {
+		return nil, nil, err
+	}
+	resMAC, ok := resPHY.MACPayload.(*lorawan.DataPayload)
+	if !ok {
+		return nil, nil, errors.NewErrInvalidArgument("Activation ResponseTemplate", "MACPayload must be a *DataPayload")
+	}
+	joinAccept := &lorawan.JoinAcceptPayload{}
+	if err := joinAccept.UnmarshalBinary(false, resMAC.Bytes); err != nil {
+		return nil, nil, err
+	}
+	resPHY.MACPayload = joinAccept
+	return &resPHY, joinAccept, nil
+}
+
+// publishActivation publishes the activation event of the device
+func (h *handler) publishActivation(ctx ttnlog.Interface, activation *pb_broker.DeduplicatedDeviceActivationRequest, dev *device.Device, devAddr types.DevAddr) {
+	mqttMetadata, _ := h.getActivationMetadata(ctx, activation, dev)
+	h.publishEvent(&types.DeviceEvent{
+		AppID: dev.AppID,
+		DevID: dev.DevID,
+		Event: types.ActivationEvent,
+		Data: types.ActivationEventData{
+			AppEUI:   *activation.AppEui,
+			DevEUI:   *activation.DevEui,
+			DevAddr:  devAddr,
+			Metadata: mqttMetadata,
+		},
+	})
+}
@@ -71,7 +71,7 @@ func TestHandleActivation(t *testing.T) {
 		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-activation"),
 	}
 	h.InitStatus()
-	h.mqttEvent = make(chan *types.DeviceEvent, 10)
+	h.appEvent = make(chan *types.DeviceEvent, 10)
 	var wg WaitGroup
 
 	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
@@ -90,11 +90,12 @@ func TestHandleActivation(t *testing.T) {
 	}()
 
 	h.devices.Set(&device.Device{
-		AppID:  appID,
-		DevID:  devID,
-		AppEUI: appEUI,
-		DevEUI: devEUI,
-		AppKey: appKey,
+		AppID:    appID,
+		DevID:    devID,
+		AppEUI:   appEUI,
+		DevEUI:   devEUI,
+		AppKey:   appKey,
+		FCntDown: 42,
 	})
 	defer func() {
 		h.devices.Delete(appID, devID)
@@ -122,7 +123,7 @@ func TestHandleActivation(t *testing.T) {
 
 	wg.Add(1)
 	go func() {
-		<-h.mqttEvent
+		<-h.appEvent
 		wg.Done()
 	}()
 
@@ -138,6 +139,10 @@ func TestHandleActivation(t *testing.T) {
 
 	wg.WaitFor(50 * time.Millisecond)
 
+	// The downlink frame counter is reset
+	dev, _ := h.devices.Get(appID, devID)
+	a.So(dev.FCntDown, ShouldEqual, 0)
+
 	// Same DevNonce used twice
 	res, err = doTestHandleActivation(h,
 		appEUI,
@@ -150,7 +155,7 @@ func TestHandleActivation(t *testing.T) {
 
 	wg.Add(1)
 	go func() {
-		<-h.mqttEvent
+		<-h.appEvent
 		wg.Done()
 	}()
 
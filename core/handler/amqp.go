@@ -6,89 +6,138 @@ package handler
 import (
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	"github.com/TheThingsNetwork/ttn/amqp"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/types"
 )
 
-func (h *handler) assertAMQPExchange() error {
-	ch, err := h.amqpClient.(*amqp.DefaultClient).GetChannel()
+// AMQPIntegrationName is the name of the AMQP integration
+const AMQPIntegrationName = "amqp"
+
+// NewAMQPIntegration returns a new integration that publishes to and subscribes on the given AMQP exchange.
+// Downlink messages are consumed from downlinkQueue, if it is empty, an exclusive queue is used.
+func NewAMQPIntegration(username, password, host, exchange, downlinkQueue string) Integration {
+	return &amqpIntegration{
+		username:      username,
+		password:      password,
+		host:          host,
+		exchange:      exchange,
+		downlinkQueue: downlinkQueue,
+	}
+}
+
+type amqpIntegration struct {
+	username      string
+	password      string
+	host          string
+	exchange      string
+	downlinkQueue string
+	client        amqp.Client
+	subscriber    amqp.Subscriber
+	publisher     amqp.Publisher
+	ctx           ttnlog.Interface
+}
+
+func (h *handler) WithAMQP(username, password, host, exchange string) Handler {
+	return h.WithIntegration(NewAMQPIntegration(username, password, host, exchange, AMQPDownlinkQueue))
+}
+
+func (i *amqpIntegration) Name() string {
+	return AMQPIntegrationName
+}
+
+func (i *amqpIntegration) assertExchange() error {
+	ch, err := i.client.(*amqp.DefaultClient).GetChannel()
 	if err != nil {
 		return err
 	}
-	err = ch.ExchangeDeclarePassive(h.amqpExchange, "topic", true, false, false, false, nil)
+	err = ch.ExchangeDeclarePassive(i.exchange, "topic", true, false, false, false, nil)
 	if err != nil {
-		h.Ctx.Warnf("Could not assert presence of AMQP Exchange %s, trying to create...", h.amqpExchange)
-		ch, err := h.amqpClient.(*amqp.DefaultClient).GetChannel()
+		i.ctx.Warnf("Could not assert presence of AMQP Exchange %s, trying to create...", i.exchange)
+		ch, err := i.client.(*amqp.DefaultClient).GetChannel()
 		if err != nil {
 			return err
 		}
-		err = ch.ExchangeDeclare(h.amqpExchange, "topic", true, false, false, false, nil)
+		err = ch.ExchangeDeclare(i.exchange, "topic", true, false, false, false, nil)
 		if err != nil {
-			h.Ctx.Errorf("Could not create AMQP Exchange %s.", h.amqpExchange)
+			i.ctx.Errorf("Could not create AMQP Exchange %s.", i.exchange)
 			return err
 		}
-		h.Ctx.Infof("Created AMQP Exchange %s", h.amqpExchange)
+		i.ctx.Infof("Created AMQP Exchange %s", i.exchange)
 	}
 	return nil
 }
 
-func (h *handler) HandleAMQP(username, password, host, exchange, downlinkQueue string) error {
-	h.amqpClient = amqp.NewClient(h.Ctx, username, password, host)
+func (i *amqpIntegration) Open(handler IntegrationHandler) (err error) {
+	i.ctx = handler.Ctx().WithField("Protocol", "AMQP")
+	i.client = amqp.NewClient(handler.Ctx(), i.username, i.password, i.host)
 
-	err := h.amqpClient.Connect()
+	err = i.client.Connect()
 	if err != nil {
 		return err
 	}
 	defer func() {
 		if err != nil {
-			h.amqpClient.Disconnect()
+			i.client.Disconnect()
 		}
 	}()
 
-	if err := h.assertAMQPExchange(); err != nil {
+	if err = i.assertExchange(); err != nil {
 		return err
 	}
 
-	h.amqpUp = make(chan *types.UplinkMessage)
-
-	subscriber := h.amqpClient.NewSubscriber(h.amqpExchange, downlinkQueue, downlinkQueue != "", downlinkQueue == "")
-	err = subscriber.Open()
+	i.subscriber = i.client.NewSubscriber(i.exchange, i.downlinkQueue, i.downlinkQueue != "", i.downlinkQueue == "")
+	err = i.subscriber.Open()
 	if err != nil {
 		return err
 	}
 	defer func() {
 		if err != nil {
-			subscriber.Close()
+			i.subscriber.Close()
 		}
 	}()
-	err = subscriber.SubscribeDownlink(func(_ amqp.Subscriber, _, _ string, req types.DownlinkMessage) {
-		h.EnqueueDownlink(&req)
+	err = i.subscriber.SubscribeDownlink(func(_ amqp.Subscriber, _, _ string, req types.DownlinkMessage) {
+		handler.EnqueueDownlink(&req)
 	})
 	if err != nil {
 		return err
 	}
 
-	ctx := h.Ctx.WithField("Protocol", "AMQP")
-
-	go func() {
-		publisher := h.amqpClient.NewPublisher(h.amqpExchange)
-		err := publisher.Open()
-		if err != nil {
-			ctx.WithError(err).Error("Could not open publisher channel")
-			return
-		}
-		defer publisher.Close()
-
-		for up := range h.amqpUp {
-			ctx.WithFields(ttnlog.Fields{
-				"DevID": up.DevID,
-				"AppID": up.AppID,
-			}).Debug("Publish Uplink")
-			err := publisher.PublishUplink(*up)
-			if err != nil {
-				ctx.WithError(err).Warn("Could not publish Uplink")
-			}
-		}
-	}()
+	i.publisher = i.client.NewPublisher(i.exchange)
+	err = i.publisher.Open()
+	if err != nil {
+		i.ctx.WithError(err).Error("Could not open publisher channel")
+		return err
+	}
 
 	return nil
 }
+
+func (i *amqpIntegration) Close() {
+	i.publisher.Close()
+	i.subscriber.Close()
+	i.client.Disconnect()
+}
+
+func (i *amqpIntegration) HandleUplink(_ *application.Application, up *types.UplinkMessage) error {
+	i.ctx.WithFields(ttnlog.Fields{
+		"DevID": up.DevID,
+		"AppID": up.AppID,
+	}).Debug("Publish Uplink")
+	return i.publisher.PublishUplink(*up)
+}
+
+func (i *amqpIntegration) HandleActivation(app *application.Application, event *types.DeviceEvent) error {
+	return i.HandleEvent(app, event)
+}
+
+func (i *amqpIntegration) HandleEvent(_ *application.Application, event *types.DeviceEvent) error {
+	i.ctx.WithFields(ttnlog.Fields{
+		"DevID": event.DevID,
+		"AppID": event.AppID,
+		"Event": event.Event,
+	}).Debug("Publish Event")
+	if event.DevID == "" {
+		return i.publisher.PublishAppEvent(event.AppID, event.Event, event.Data)
+	}
+	return i.publisher.PublishDeviceEvent(event.AppID, event.DevID, event.Event, event.Data)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/TheThingsNetwork/ttn/amqp"
 	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
@@ -32,19 +33,25 @@ func TestHandleAMQP(t *testing.T) {
 	appID := "handler-amqp-app1"
 	devID := "handler-amqp-dev1"
 	h := &handler{
-		Component: &component.Component{Ctx: GetLogger(t, "TestHandleAMQP")},
-		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-amqp"),
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHandleAMQP")},
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-amqp"),
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-handle-amqp"),
 	}
-	h.WithAMQP("guest", "guest", host, "amq.topic")
+	h.applications.Set(&application.Application{
+		AppID: appID,
+	})
 	h.devices.Set(&device.Device{
 		AppID: appID,
 		DevID: devID,
 	})
 	defer func() {
+		h.applications.Delete(appID)
 		h.devices.Delete(appID, devID)
 	}()
-	err = h.HandleAMQP("guest", "guest", host, "amq.topic", "")
+	i := NewAMQPIntegration("guest", "guest", host, "amq.topic", "")
+	err = i.Open(&integrationHandler{handler: h, name: i.Name(), ctx: h.Ctx})
 	a.So(err, ShouldBeNil)
+	defer i.Close()
 
 	p := c.NewPublisher("amq.topic")
 	err = p.Open()
@@ -74,14 +81,23 @@ func TestHandleAMQP(t *testing.T) {
 	})
 	a.So(err, ShouldBeNil)
 
-	h.amqpUp <- &types.UplinkMessage{
+	err = i.HandleUplink(nil, &types.UplinkMessage{
 		DevID:      devID,
 		AppID:      appID,
 		PayloadRaw: []byte{0xAA, 0xBC},
 		PayloadFields: map[string]interface{}{
 			"field": "value",
 		},
-	}
+	})
+	a.So(err, ShouldBeNil)
 
 	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
+
+	err = i.HandleEvent(nil, &types.DeviceEvent{
+		AppID: appID,
+		DevID: devID,
+		Event: types.DownlinkAckEvent,
+		Data:  types.DownlinkEventData{CorrelationID: "id"},
+	})
+	a.So(err, ShouldBeNil)
 }
@@ -12,6 +12,14 @@ import (
 
 const currentDBVersion = "2.4.1"
 
+// Payload formats of an application
+const (
+	// PayloadFormatCustom uses the payload functions of the application
+	PayloadFormatCustom = "custom"
+	// PayloadFormatCayenneLPP uses the Cayenne Low Power Payload format
+	PayloadFormatCayenneLPP = "cayennelpp"
+)
+
 // Application contains the state of an application
 type Application struct {
 	old *Application
@@ -29,6 +37,27 @@ type Application struct {
 	// Encoder is a JavaScript function that encode the data send on Downlink messages
 	// Returns an object containing the converted values in []byte
 	Encoder string `redis:"encoder"`
+	// PayloadFormat is the format of the payload, the payload functions are
+	// only used if it is empty or PayloadFormatCustom
+	PayloadFormat string `redis:"payload_format"`
+	// ExposeMetadata indicates that the Decoder is called with the uplink message
+	// (without payload) as third argument
+	ExposeMetadata bool `redis:"expose_metadata"`
+
+	// ADRAlgorithm is the ADR algorithm that is used for devices of the
+	// application that do not have an ADR algorithm
+	ADRAlgorithm string `redis:"adr_algorithm"`
+	// ADRDataRate is the data rate for devices of the application that use
+	// the fixed ADR algorithm and do not have a data rate
+	ADRDataRate string `redis:"adr_data_rate"`
+
+	// HTTPIntegration contains the settings of the HTTP integration, it is nil
+	// if the integration is not enabled for this application
+	HTTPIntegration *HTTPIntegration `redis:"http_integration"`
+
+	// DisabledIntegrations contains the names of the integrations that are
+	// disabled for this application
+	DisabledIntegrations []string `redis:"disabled_integrations"`
 
 	CreatedAt time.Time `redis:"created_at"`
 	UpdatedAt time.Time `redis:"updated_at"`
@@ -45,6 +74,19 @@ func (a *Application) DBVersion() string {
 	return currentDBVersion
 }
 
+// IntegrationEnabled returns true if the integration with the given name is enabled for the application
+func (a *Application) IntegrationEnabled(name string) bool {
+	if a == nil {
+		return true
+	}
+	for _, disabled := range a.DisabledIntegrations {
+		if disabled == name {
+			return false
+		}
+	}
+	return true
+}
+
 // ChangedFields returns the names of the changed fields since the last call to StartUpdate
 func (a Application) ChangedFields() (changed []string) {
 	new := structs.New(a)
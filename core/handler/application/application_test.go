@@ -29,3 +29,14 @@ func TestApplicationChangedFields(t *testing.T) {
 	a.So(application.ChangedFields(), ShouldHaveLength, 1)
 	a.So(application.ChangedFields(), ShouldContain, "AppID")
 }
+
+func TestApplicationIntegrationEnabled(t *testing.T) {
+	a := New(t)
+	var application *Application
+	a.So(application.IntegrationEnabled("mqtt"), ShouldBeTrue)
+	application = &Application{
+		DisabledIntegrations: []string{"amqp"},
+	}
+	a.So(application.IntegrationEnabled("mqtt"), ShouldBeTrue)
+	a.So(application.IntegrationEnabled("amqp"), ShouldBeFalse)
+}
@@ -0,0 +1,54 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// HTTPIntegration contains the settings of the HTTP integration of an application
+type HTTPIntegration struct {
+	UplinkURL     string            `json:"uplink_url,omitempty"`     // URL that uplink messages are posted to
+	ActivationURL string            `json:"activation_url,omitempty"` // URL that activations are posted to
+	EventURL      string            `json:"event_url,omitempty"`      // URL that downlink events are posted to
+	Headers       map[string]string `json:"headers,omitempty"`        // Headers that are added to each request
+	DownlinkKey   string            `json:"downlink_key,omitempty"`   // Key that is used to sign downlink URLs
+}
+
+// GenerateDownlinkKey generates a new random key for signing downlink URLs
+func (i *HTTPIntegration) GenerateDownlinkKey() error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	i.DownlinkKey = hex.EncodeToString(key)
+	return nil
+}
+
+// DownlinkSignature returns the signature that authorizes downlink requests for the given device
+func (i HTTPIntegration) DownlinkSignature(appID, devID string) string {
+	mac := hmac.New(sha256.New, []byte(i.DownlinkKey))
+	fmt.Fprintf(mac, "%s.%s", appID, devID)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidDownlinkSignature checks if the signature authorizes downlink requests for the given device
+func (i HTTPIntegration) ValidDownlinkSignature(appID, devID, signature string) bool {
+	if i.DownlinkKey == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(i.DownlinkSignature(appID, devID))
+	if err != nil {
+		return false
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, actual)
+}
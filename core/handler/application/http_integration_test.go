@@ -0,0 +1,34 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package application
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHTTPIntegrationDownlinkSignature(t *testing.T) {
+	a := New(t)
+
+	integration := HTTPIntegration{DownlinkKey: "secret"}
+	signature := integration.DownlinkSignature("app", "dev")
+	a.So(signature, ShouldHaveLength, 64)
+	a.So(integration.DownlinkSignature("app", "dev"), ShouldEqual, signature)
+	a.So(integration.DownlinkSignature("app", "other-dev"), ShouldNotEqual, signature)
+
+	a.So(integration.ValidDownlinkSignature("app", "dev", signature), ShouldBeTrue)
+	a.So(integration.ValidDownlinkSignature("app", "other-dev", signature), ShouldBeFalse)
+	a.So(integration.ValidDownlinkSignature("app", "dev", "not-hex"), ShouldBeFalse)
+
+	other := HTTPIntegration{DownlinkKey: "other-secret"}
+	a.So(other.ValidDownlinkSignature("app", "dev", signature), ShouldBeFalse)
+
+	empty := HTTPIntegration{}
+	a.So(empty.ValidDownlinkSignature("app", "dev", empty.DownlinkSignature("app", "dev")), ShouldBeFalse)
+
+	a.So(empty.GenerateDownlinkKey(), ShouldBeNil)
+	a.So(empty.DownlinkKey, ShouldHaveLength, 64)
+	a.So(empty.ValidDownlinkSignature("app", "dev", empty.DownlinkSignature("app", "dev")), ShouldBeTrue)
+}
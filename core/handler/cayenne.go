@@ -0,0 +1,32 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/handler/cayennelpp"
+)
+
+// CayenneLPPUplink decodes uplink payload in the Cayenne Low Power Payload format
+type CayenneLPPUplink struct{}
+
+// Process decodes the specified payload, Cayenne LPP payload is always valid if it can be decoded
+func (CayenneLPPUplink) Process(payload []byte, _ uint8) (map[string]interface{}, bool, error) {
+	fields, err := cayennelpp.DecodeUplink(payload)
+	if err != nil {
+		return nil, false, err
+	}
+	return fields, true, nil
+}
+
+// CayenneLPPDownlink encodes downlink payload in the Cayenne Low Power Payload format
+type CayenneLPPDownlink struct{}
+
+// Process encodes the specified fields into Cayenne LPP payload
+func (CayenneLPPDownlink) Process(fields map[string]interface{}, _ uint8) ([]byte, bool, error) {
+	payload, err := cayennelpp.EncodeDownlink(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return payload, true, nil
+}
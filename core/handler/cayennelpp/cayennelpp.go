@@ -0,0 +1,356 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package cayennelpp implements the Cayenne Low Power Payload (LPP) format.
+//
+// Uplink payload consists of data channels that are encoded as [channel][type][value].
+// Decoded fields are named after the type and the channel, for example temperature_1.
+//
+// Downlink payload consists of actuator commands that are encoded as [channel][value],
+// where value is a signed 16 bit integer with a resolution of 0.01. Downlink fields are
+// named value_<channel>, for example value_2.
+package cayennelpp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// Cayenne LPP data types
+const (
+	DigitalInput       uint8 = 0
+	DigitalOutput      uint8 = 1
+	AnalogInput        uint8 = 2
+	AnalogOutput       uint8 = 3
+	Luminosity         uint8 = 101
+	Presence           uint8 = 102
+	Temperature        uint8 = 103
+	RelativeHumidity   uint8 = 104
+	Accelerometer      uint8 = 113
+	BarometricPressure uint8 = 115
+	Gyrometer          uint8 = 134
+	GPS                uint8 = 136
+)
+
+type dataType struct {
+	name string
+	size int
+}
+
+var dataTypes = map[uint8]dataType{
+	DigitalInput:       {"digital_in", 1},
+	DigitalOutput:      {"digital_out", 1},
+	AnalogInput:        {"analog_in", 2},
+	AnalogOutput:       {"analog_out", 2},
+	Luminosity:         {"luminosity", 2},
+	Presence:           {"presence", 1},
+	Temperature:        {"temperature", 2},
+	RelativeHumidity:   {"relative_humidity", 1},
+	Accelerometer:      {"accelerometer", 6},
+	BarometricPressure: {"barometric_pressure", 2},
+	Gyrometer:          {"gyrometer", 6},
+	GPS:                {"gps", 9},
+}
+
+func int16At(b []byte, i int) int16 {
+	return int16(binary.BigEndian.Uint16(b[i:]))
+}
+
+func uint16At(b []byte, i int) uint16 {
+	return binary.BigEndian.Uint16(b[i:])
+}
+
+func int24At(b []byte, i int) int32 {
+	v := int32(b[i])<<16 | int32(b[i+1])<<8 | int32(b[i+2])
+	if v&0x800000 != 0 {
+		v |= ^0xffffff
+	}
+	return v
+}
+
+// DecodeUplink decodes Cayenne LPP uplink payload into fields
+func DecodeUplink(payload []byte) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	for i := 0; i < len(payload); {
+		if len(payload)-i < 2 {
+			return nil, errors.NewErrInvalidArgument("Payload", "unexpected end of Cayenne LPP payload")
+		}
+		channel, typ := payload[i], payload[i+1]
+		i += 2
+		t, ok := dataTypes[typ]
+		if !ok {
+			return nil, errors.NewErrInvalidArgument("Payload", fmt.Sprintf("unknown Cayenne LPP data type %d", typ))
+		}
+		if len(payload)-i < t.size {
+			return nil, errors.NewErrInvalidArgument("Payload", fmt.Sprintf("not enough data for %s on channel %d", t.name, channel))
+		}
+		b := payload[i : i+t.size]
+		i += t.size
+
+		var value interface{}
+		switch typ {
+		case DigitalInput, DigitalOutput, Presence:
+			value = b[0]
+		case AnalogInput, AnalogOutput:
+			value = float64(int16At(b, 0)) / 100
+		case Luminosity:
+			value = uint16At(b, 0)
+		case Temperature:
+			value = float64(int16At(b, 0)) / 10
+		case RelativeHumidity:
+			value = float64(b[0]) / 2
+		case Accelerometer:
+			value = map[string]interface{}{
+				"x": float64(int16At(b, 0)) / 1000,
+				"y": float64(int16At(b, 2)) / 1000,
+				"z": float64(int16At(b, 4)) / 1000,
+			}
+		case BarometricPressure:
+			value = float64(uint16At(b, 0)) / 10
+		case Gyrometer:
+			value = map[string]interface{}{
+				"x": float64(int16At(b, 0)) / 100,
+				"y": float64(int16At(b, 2)) / 100,
+				"z": float64(int16At(b, 4)) / 100,
+			}
+		case GPS:
+			value = map[string]interface{}{
+				"latitude":  float64(int24At(b, 0)) / 10000,
+				"longitude": float64(int24At(b, 3)) / 10000,
+				"altitude":  float64(int24At(b, 6)) / 100,
+			}
+		}
+		fields[fmt.Sprintf("%s_%d", t.name, channel)] = value
+	}
+	return fields, nil
+}
+
+// fieldKey splits a field name like temperature_1 into the name and the channel
+func fieldKey(key string) (name string, channel uint8, err error) {
+	idx := strings.LastIndex(key, "_")
+	if idx < 0 {
+		return "", 0, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s should be formatted as name_channel", key))
+	}
+	ch, err := strconv.ParseUint(key[idx+1:], 10, 8)
+	if err != nil {
+		return "", 0, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s does not end with a valid channel", key))
+	}
+	return key[:idx], uint8(ch), nil
+}
+
+func toFloat(key string, v interface{}) (float64, error) {
+	switch t := v.(type) {
+	case float64:
+		return t, nil
+	case float32:
+		return float64(t), nil
+	case int:
+		return float64(t), nil
+	case int8:
+		return float64(t), nil
+	case int16:
+		return float64(t), nil
+	case int32:
+		return float64(t), nil
+	case int64:
+		return float64(t), nil
+	case uint8:
+		return float64(t), nil
+	case uint16:
+		return float64(t), nil
+	case uint32:
+		return float64(t), nil
+	case uint64:
+		return float64(t), nil
+	case bool:
+		if t {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s should be a number", key))
+}
+
+func toInt(key string, v interface{}, scale float64, min, max int64) (int64, error) {
+	f, err := toFloat(key, v)
+	if err != nil {
+		return 0, err
+	}
+	i := int64(math.Floor(f*scale + 0.5))
+	if i < min || i > max {
+		return 0, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s is out of range", key))
+	}
+	return i, nil
+}
+
+func toVector(key string, v interface{}, names ...string) ([]interface{}, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s should be an object", key))
+	}
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		if values[i], ok = m[name]; !ok {
+			return nil, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s should have %s", key, name))
+		}
+	}
+	return values, nil
+}
+
+func appendInt(b []byte, v int64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		b = append(b, byte(v>>(uint(i)*8)))
+	}
+	return b
+}
+
+// sortedKeys returns the keys of the fields ordered by channel and name, so that encoding is deterministic
+func sortedKeys(fields map[string]interface{}) ([]string, error) {
+	keys := make([]string, 0, len(fields))
+	channels := make(map[string]uint8, len(fields))
+	for key := range fields {
+		_, channel, err := fieldKey(key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		channels[key] = channel
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if channels[keys[i]] != channels[keys[j]] {
+			return channels[keys[i]] < channels[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys, nil
+}
+
+// EncodeUplink encodes fields in the format returned by DecodeUplink into Cayenne LPP uplink payload
+func EncodeUplink(fields map[string]interface{}) ([]byte, error) {
+	keys, err := sortedKeys(fields)
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]uint8, len(dataTypes))
+	for typ, t := range dataTypes {
+		types[t.name] = typ
+	}
+
+	var payload []byte
+	for _, key := range keys {
+		name, channel, _ := fieldKey(key)
+		typ, ok := types[name]
+		if !ok {
+			return nil, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("unknown Cayenne LPP data type %s", name))
+		}
+		payload = append(payload, channel, typ)
+		v := fields[key]
+		switch typ {
+		case DigitalInput, DigitalOutput, Presence:
+			i, err := toInt(key, v, 1, 0, math.MaxUint8)
+			if err != nil {
+				return nil, err
+			}
+			payload = appendInt(payload, i, 1)
+		case AnalogInput, AnalogOutput:
+			i, err := toInt(key, v, 100, math.MinInt16, math.MaxInt16)
+			if err != nil {
+				return nil, err
+			}
+			payload = appendInt(payload, i, 2)
+		case Luminosity:
+			i, err := toInt(key, v, 1, 0, math.MaxUint16)
+			if err != nil {
+				return nil, err
+			}
+			payload = appendInt(payload, i, 2)
+		case Temperature:
+			i, err := toInt(key, v, 10, math.MinInt16, math.MaxInt16)
+			if err != nil {
+				return nil, err
+			}
+			payload = appendInt(payload, i, 2)
+		case RelativeHumidity:
+			i, err := toInt(key, v, 2, 0, math.MaxUint8)
+			if err != nil {
+				return nil, err
+			}
+			payload = appendInt(payload, i, 1)
+		case BarometricPressure:
+			i, err := toInt(key, v, 10, 0, math.MaxUint16)
+			if err != nil {
+				return nil, err
+			}
+			payload = appendInt(payload, i, 2)
+		case Accelerometer, Gyrometer:
+			scale := 1000.0
+			if typ == Gyrometer {
+				scale = 100
+			}
+			values, err := toVector(key, v, "x", "y", "z")
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				i, err := toInt(key, value, scale, math.MinInt16, math.MaxInt16)
+				if err != nil {
+					return nil, err
+				}
+				payload = appendInt(payload, i, 2)
+			}
+		case GPS:
+			values, err := toVector(key, v, "latitude", "longitude", "altitude")
+			if err != nil {
+				return nil, err
+			}
+			for idx, scale := range []float64{10000, 10000, 100} {
+				i, err := toInt(key, values[idx], scale, -1<<23, 1<<23-1)
+				if err != nil {
+					return nil, err
+				}
+				payload = appendInt(payload, i, 3)
+			}
+		}
+	}
+	return payload, nil
+}
+
+// EncodeDownlink encodes fields named value_<channel> into Cayenne LPP downlink payload
+func EncodeDownlink(fields map[string]interface{}) ([]byte, error) {
+	keys, err := sortedKeys(fields)
+	if err != nil {
+		return nil, err
+	}
+	var payload []byte
+	for _, key := range keys {
+		name, channel, _ := fieldKey(key)
+		if name != "value" {
+			return nil, errors.NewErrInvalidArgument("Fields", fmt.Sprintf("%s should be named value_<channel>", key))
+		}
+		i, err := toInt(key, fields[key], 100, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, channel)
+		payload = appendInt(payload, i, 2)
+	}
+	return payload, nil
+}
+
+// DecodeDownlink decodes Cayenne LPP downlink payload into fields named value_<channel>
+func DecodeDownlink(payload []byte) (map[string]interface{}, error) {
+	if len(payload)%3 != 0 {
+		return nil, errors.NewErrInvalidArgument("Payload", "Cayenne LPP downlink payload should consist of 3 byte commands")
+	}
+	fields := make(map[string]interface{})
+	for i := 0; i < len(payload); i += 3 {
+		fields[fmt.Sprintf("value_%d", payload[i])] = float64(int16At(payload, i+1)) / 100
+	}
+	return fields, nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cayennelpp
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestDecodeUplink(t *testing.T) {
+	a := New(t)
+
+	// Examples from the Cayenne LPP documentation
+	fields, err := DecodeUplink([]byte{0x03, 0x67, 0x01, 0x10, 0x05, 0x67, 0x00, 0xff})
+	a.So(err, ShouldBeNil)
+	a.So(fields, ShouldResemble, map[string]interface{}{
+		"temperature_3": 27.2,
+		"temperature_5": 25.5,
+	})
+
+	fields, err = DecodeUplink([]byte{0x06, 0x71, 0x04, 0xd2, 0xfb, 0x2e, 0x00, 0x00})
+	a.So(err, ShouldBeNil)
+	a.So(fields, ShouldResemble, map[string]interface{}{
+		"accelerometer_6": map[string]interface{}{"x": 1.234, "y": -1.234, "z": 0.0},
+	})
+
+	fields, err = DecodeUplink([]byte{0x01, 0x88, 0x06, 0x76, 0x5f, 0xf2, 0x96, 0x0a, 0x00, 0x03, 0xe8})
+	a.So(err, ShouldBeNil)
+	a.So(fields, ShouldResemble, map[string]interface{}{
+		"gps_1": map[string]interface{}{"latitude": 42.3519, "longitude": -87.9094, "altitude": 10.0},
+	})
+
+	fields, err = DecodeUplink([]byte{0x01, 0x00, 0x01, 0x02, 0x02, 0xff, 0x38, 0x03, 0x65, 0x01, 0x2c, 0x04, 0x68, 0x61})
+	a.So(err, ShouldBeNil)
+	a.So(fields, ShouldResemble, map[string]interface{}{
+		"digital_in_1":        uint8(1),
+		"analog_in_2":         -2.0,
+		"luminosity_3":        uint16(300),
+		"relative_humidity_4": 48.5,
+	})
+
+	fields, err = DecodeUplink([]byte{})
+	a.So(err, ShouldBeNil)
+	a.So(fields, ShouldBeEmpty)
+
+	_, err = DecodeUplink([]byte{0x01})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = DecodeUplink([]byte{0x01, 0x42, 0x00})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = DecodeUplink([]byte{0x01, 0x67, 0x00})
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestEncodeUplink(t *testing.T) {
+	a := New(t)
+
+	payload, err := EncodeUplink(map[string]interface{}{
+		"temperature_5": 25.5,
+		"temperature_3": 27.2,
+	})
+	a.So(err, ShouldBeNil)
+	a.So(payload, ShouldResemble, []byte{0x03, 0x67, 0x01, 0x10, 0x05, 0x67, 0x00, 0xff})
+
+	payload, err = EncodeUplink(map[string]interface{}{
+		"gps_1": map[string]interface{}{"latitude": 42.3519, "longitude": -87.9094, "altitude": 10},
+	})
+	a.So(err, ShouldBeNil)
+	a.So(payload, ShouldResemble, []byte{0x01, 0x88, 0x06, 0x76, 0x5f, 0xf2, 0x96, 0x0a, 0x00, 0x03, 0xe8})
+
+	in := map[string]interface{}{
+		"digital_out_1":         uint8(1),
+		"analog_out_2":          -12.34,
+		"presence_3":            uint8(1),
+		"barometric_pressure_4": 1005.3,
+		"gyrometer_5":           map[string]interface{}{"x": 1.5, "y": -2.25, "z": 0.0},
+	}
+	payload, err = EncodeUplink(in)
+	a.So(err, ShouldBeNil)
+	out, err := DecodeUplink(payload)
+	a.So(err, ShouldBeNil)
+	a.So(out, ShouldResemble, in)
+
+	_, err = EncodeUplink(map[string]interface{}{"temperature": 1})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = EncodeUplink(map[string]interface{}{"unknown_1": 1})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = EncodeUplink(map[string]interface{}{"temperature_1": "hot"})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = EncodeUplink(map[string]interface{}{"digital_in_1": 256})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = EncodeUplink(map[string]interface{}{"gps_1": map[string]interface{}{"latitude": 1}})
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestEncodeDownlink(t *testing.T) {
+	a := New(t)
+
+	payload, err := EncodeDownlink(map[string]interface{}{
+		"value_2": -1,
+		"value_1": 12.5,
+	})
+	a.So(err, ShouldBeNil)
+	a.So(payload, ShouldResemble, []byte{0x01, 0x04, 0xe2, 0x02, 0xff, 0x9c})
+
+	fields, err := DecodeDownlink(payload)
+	a.So(err, ShouldBeNil)
+	a.So(fields, ShouldResemble, map[string]interface{}{
+		"value_1": 12.5,
+		"value_2": -1.0,
+	})
+
+	_, err = EncodeDownlink(map[string]interface{}{"temperature_1": 1})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = EncodeDownlink(map[string]interface{}{"value_1": 1000})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = DecodeDownlink([]byte{0x01, 0x02})
+	a.So(err, ShouldNotBeNil)
+}
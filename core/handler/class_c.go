@@ -0,0 +1,84 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/brocaar/lorawan"
+)
+
+// sendClassCDownlink immediately sends the next message in the downlink queue of a Class C device. The message
+// has no DownlinkOption, the NetworkServer selects the gateway that last received the device and sends it in RX2.
+// Class B devices are handled the same way, the Router then sends the message in the next ping slot. The payload is
+// encrypted with the FCntDown of the Handler, the NetworkServer skips its own FCntDown to it if earlier downlink
+// messages were not sent.
+func (h *handler) sendClassCDownlink(appID, devID string) (err error) {
+	ctx := h.Ctx.WithFields(ttnlog.Fields{
+		"AppID": appID,
+		"DevID": devID,
+	})
+	defer func() {
+		if err != nil {
+			ctx.WithError(err).Warn("Could not send Class B/C downlink")
+		}
+	}()
+
+	dev, err := h.devices.Get(appID, devID)
+	if err != nil {
+		return err
+	}
+	dev.StartUpdate()
+
+	// A confirmed downlink that was not acknowledged yet is sent again after the next uplink
+	if dev.CurrentDownlink != nil {
+		return nil
+	}
+
+	queue, err := h.devices.DownlinkQueue(appID, devID)
+	if err != nil {
+		return err
+	}
+	next, err := h.nextDownlink(appID, devID, queue)
+	if err != nil || next == nil {
+		return err
+	}
+
+	// Only confirmed downlink is kept, until it is acknowledged in an uplink
+	if next.Confirmed {
+		dev.SetCurrentDownlink(next)
+	}
+	if err = h.devices.Set(dev); err != nil {
+		return err
+	}
+
+	phyPayload := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr(dev.DevAddr),
+				FCnt:    dev.FCntDown,
+			},
+		},
+	}
+	payload, err := phyPayload.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	appDownlink := *next
+	appDownlink.AppID = appID
+	appDownlink.DevID = devID
+
+	return h.HandleDownlink(&appDownlink, &pb_broker.DownlinkMessage{
+		Payload: payload,
+		AppEui:  &dev.AppEUI,
+		DevEui:  &dev.DevEUI,
+		AppId:   appID,
+		DevId:   devID,
+	})
+}
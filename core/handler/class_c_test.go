@@ -0,0 +1,111 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestClassCDownlink(t *testing.T) {
+	a := New(t)
+	appID := "app-class-c"
+	devID := "dev-class-c"
+	h := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestClassCDownlink")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-class-c-downlink"),
+		downlink:  make(chan *pb_broker.DownlinkMessage, 10),
+		appEvent:  make(chan *types.DeviceEvent, 10),
+	}
+	h.InitStatus()
+
+	nwkSKey := types.NwkSKey{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	appSKey := types.AppSKey{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+	h.devices.Set(&device.Device{
+		AppID:    appID,
+		DevID:    devID,
+		AppEUI:   types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		DevEUI:   types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr:  types.DevAddr{1, 2, 3, 4},
+		NwkSKey:  nwkSKey,
+		AppSKey:  appSKey,
+		FCntDown: 42,
+		Options:  device.Options{Class: pb_lorawan.DeviceClass_CLASS_C},
+	})
+	defer func() {
+		h.devices.Delete(appID, devID)
+	}()
+
+	err := h.EnqueueDownlink(&types.DownlinkMessage{
+		AppID:         appID,
+		DevID:         devID,
+		FPort:         2,
+		PayloadRaw:    []byte{0xAA, 0xBC},
+		CorrelationID: "correlation",
+	})
+	a.So(err, ShouldBeNil)
+
+	// The downlink is sent immediately, without DownlinkOption
+	a.So(h.downlink, ShouldHaveLength, 1)
+	downlink := <-h.downlink
+	a.So(downlink.DownlinkOption, ShouldBeNil)
+	a.So(downlink.AppId, ShouldEqual, appID)
+	a.So(downlink.DevId, ShouldEqual, devID)
+	a.So(downlink.Trace.GetCorrelationID(), ShouldEqual, "correlation")
+
+	var phy lorawan.PHYPayload
+	a.So(phy.UnmarshalBinary(downlink.Payload), ShouldBeNil)
+	macPayload := phy.MACPayload.(*lorawan.MACPayload)
+	macPayload.FHDR.FCnt = 42
+	a.So(phy.DecryptFRMPayload(lorawan.AES128Key(appSKey)), ShouldBeNil)
+	a.So(macPayload.FRMPayload[0].(*lorawan.DataPayload).Bytes, ShouldResemble, []byte{0xAA, 0xBC})
+
+	evt := <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkScheduledEvent)
+	evt = <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkSentEvent)
+	a.So(evt.Data.(types.DownlinkEventData).CorrelationID, ShouldEqual, "correlation")
+
+	queue, _ := h.devices.DownlinkQueue(appID, devID)
+	qLen, _ := queue.Length()
+	a.So(qLen, ShouldEqual, 0)
+
+	dev, _ := h.devices.Get(appID, devID)
+	a.So(dev.FCntDown, ShouldEqual, 43)
+	a.So(dev.CurrentDownlink, ShouldBeNil)
+
+	// Confirmed downlink is kept until it is acknowledged
+	err = h.EnqueueDownlink(&types.DownlinkMessage{
+		AppID:      appID,
+		DevID:      devID,
+		Confirmed:  true,
+		PayloadRaw: []byte{0x01},
+	})
+	a.So(err, ShouldBeNil)
+	a.So(h.downlink, ShouldHaveLength, 1)
+	<-h.downlink
+	dev, _ = h.devices.Get(appID, devID)
+	a.So(dev.CurrentDownlink, ShouldNotBeNil)
+	a.So(dev.CurrentDownlinkSentAt.IsZero(), ShouldBeFalse)
+
+	// The next downlink waits for the acknowledgement
+	err = h.EnqueueDownlink(&types.DownlinkMessage{
+		AppID:      appID,
+		DevID:      devID,
+		PayloadRaw: []byte{0x02},
+		Schedule:   types.ScheduleLast,
+	})
+	a.So(err, ShouldBeNil)
+	a.So(h.downlink, ShouldBeEmpty)
+	qLen, _ = queue.Length()
+	a.So(qLen, ShouldEqual, 1)
+}
@@ -4,56 +4,205 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/handler/functions"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/robertkrimen/otto"
 )
 
-// ConvertFieldsUp converts the payload to fields using payload functions
-func (h *handler) ConvertFieldsUp(ctx ttnlog.Interface, _ *pb_broker.DeduplicatedUplinkMessage, appUp *types.UplinkMessage, _ *device.Device) error {
+// ConvertFieldsUp converts the payload to fields using payload functions. Downlink that is returned by the
+// payload functions is ignored, see convertFieldsUp.
+func (h *handler) ConvertFieldsUp(ctx ttnlog.Interface, ttnUp *pb_broker.DeduplicatedUplinkMessage, appUp *types.UplinkMessage, dev *device.Device) error {
+	_, err := h.convertFieldsUp(ctx, ttnUp, appUp, dev)
+	return err
+}
+
+// convertFieldsUp converts the payload to fields using payload functions and returns the downlink that is returned
+// by the payload functions (if any). The downlink should be enqueued after the device is saved, because
+// EnqueueDownlink updates the device.
+func (h *handler) convertFieldsUp(ctx ttnlog.Interface, _ *pb_broker.DeduplicatedUplinkMessage, appUp *types.UplinkMessage, dev *device.Device) (*types.DownlinkMessage, error) {
 	// Find Application
 	app, err := h.applications.Get(appUp.AppID)
 	if err != nil {
-		return nil // Do not process if application not found
+		return nil, nil // Do not process if application not found
+	}
+
+	var uplink map[string]interface{}
+	if app.ExposeMetadata {
+		uplink, err = uplinkMetadata(appUp)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	functions := &UplinkFunctions{
+	processor, err := uplinkPayloadProcessor(app.PayloadFormat, &UplinkFunctions{
 		Decoder:   app.Decoder,
 		Converter: app.Converter,
 		Validator: app.Validator,
+		Uplink:    uplink,
 		Logger:    functions.Ignore,
+		Cache:     h.functionCache,
+		AppID:     app.AppID,
+	}, dev.GetPayloadFunctions())
+	if err != nil {
+		return nil, err
 	}
 
-	fields, valid, err := functions.Process(appUp.PayloadRaw, appUp.FPort)
+	fields, valid, err := processor.Process(appUp.PayloadRaw, appUp.FPort)
 	if err != nil {
 
 		// Emit the error
-		h.mqttEvent <- &types.DeviceEvent{
+		h.publishEvent(&types.DeviceEvent{
 			AppID: appUp.AppID,
 			DevID: appUp.DevID,
 			Event: types.UplinkErrorEvent,
 			Data:  types.ErrorEventData{Error: err.Error()},
-		}
+		})
 
 		// Do not set fields if processing failed, but allow the handler to continue processing
 		// without payload functions
-		return nil
+		return nil, nil
 	}
 
 	if !valid {
-		return errors.NewErrInvalidArgument("Payload", "payload validator function returned false")
+		return nil, errors.NewErrInvalidArgument("Payload", "payload validator function returned false")
+	}
+
+	downlink, err := extractDownlink(appUp.AppID, appUp.DevID, fields)
+	if err != nil {
+		h.publishEvent(&types.DeviceEvent{
+			AppID: appUp.AppID,
+			DevID: appUp.DevID,
+			Event: types.UplinkErrorEvent,
+			Data:  types.ErrorEventData{Error: err.Error()},
+		})
 	}
 
 	appUp.PayloadFields = fields
 
-	return nil
+	return downlink, nil
+}
+
+// UplinkPayloadProcessor processes uplink payload into fields
+type UplinkPayloadProcessor interface {
+	// Process decodes the payload into fields and returns whether the fields are valid
+	Process(payload []byte, port uint8) (map[string]interface{}, bool, error)
+}
+
+// DownlinkPayloadProcessor processes downlink fields into payload
+type DownlinkPayloadProcessor interface {
+	// Process encodes the fields into payload
+	Process(fields map[string]interface{}, port uint8) ([]byte, bool, error)
+}
+
+// uplinkPayloadProcessor returns the UplinkPayloadProcessor for the payload format. The functions are used for the custom format.
+// If the device has a Decoder, the payload functions of the device are used instead.
+func uplinkPayloadProcessor(format string, functions *UplinkFunctions, dev *device.PayloadFunctions) (UplinkPayloadProcessor, error) {
+	if dev != nil && dev.Decoder != "" {
+		return &UplinkFunctions{
+			Decoder:   dev.Decoder,
+			Converter: dev.Converter,
+			Validator: dev.Validator,
+			Uplink:    functions.Uplink,
+			Logger:    functions.Logger,
+			Cache:     functions.Cache,
+			AppID:     functions.AppID,
+		}, nil
+	}
+	switch format {
+	case "", application.PayloadFormatCustom:
+		return functions, nil
+	case application.PayloadFormatCayenneLPP:
+		return CayenneLPPUplink{}, nil
+	}
+	return nil, errors.NewErrInvalidArgument("PayloadFormat", fmt.Sprintf("%s is not supported", format))
+}
+
+// downlinkPayloadProcessor returns the DownlinkPayloadProcessor for the payload format. The functions are used for the custom format.
+// If the device has an Encoder, it is used instead.
+func downlinkPayloadProcessor(format string, functions *DownlinkFunctions, dev *device.PayloadFunctions) (DownlinkPayloadProcessor, error) {
+	if dev != nil && dev.Encoder != "" {
+		return &DownlinkFunctions{
+			Encoder: dev.Encoder,
+			Logger:  functions.Logger,
+			Cache:   functions.Cache,
+			AppID:   functions.AppID,
+		}, nil
+	}
+	switch format {
+	case "", application.PayloadFormatCustom:
+		return functions, nil
+	case application.PayloadFormatCayenneLPP:
+		return CayenneLPPDownlink{}, nil
+	}
+	return nil, errors.NewErrInvalidArgument("PayloadFormat", fmt.Sprintf("%s is not supported", format))
+}
+
+// downlinkField is the field in the object returned by the payload functions that
+// contains a downlink message that should be enqueued
+const downlinkField = "_downlink"
+
+// uplinkMetadata returns the uplink message without payload as an object that is passed to the Decoder
+func uplinkMetadata(appUp *types.UplinkMessage) (map[string]interface{}, error) {
+	msg := *appUp
+	msg.PayloadRaw = nil
+	msg.PayloadFields = nil
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var uplink map[string]interface{}
+	if err := json.Unmarshal(data, &uplink); err != nil {
+		return nil, err
+	}
+	delete(uplink, "payload_raw")
+	return uplink, nil
+}
+
+// extractDownlink removes the downlink message that was returned by the payload functions from the
+// fields and returns it. The payload_raw of the downlink can be an Array of bytes or a base64 string.
+func extractDownlink(appID, devID string, fields map[string]interface{}) (*types.DownlinkMessage, error) {
+	v, ok := fields[downlinkField]
+	if !ok {
+		return nil, nil
+	}
+	delete(fields, downlinkField)
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.NewErrInvalidArgument("Downlink", "should be an object")
+	}
+	if raw, ok := m["payload_raw"]; ok {
+		if _, isBase64 := raw.(string); !isBase64 {
+			payload, err := toBytes("Downlink payload_raw", raw)
+			if err != nil {
+				return nil, err
+			}
+			m["payload_raw"] = payload
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, errors.NewErrInvalidArgument("Downlink", err.Error())
+	}
+	downlink := new(types.DownlinkMessage)
+	if err := json.Unmarshal(data, downlink); err != nil {
+		return nil, errors.NewErrInvalidArgument("Downlink", err.Error())
+	}
+	downlink.AppID = appID
+	downlink.DevID = devID
+
+	return downlink, nil
 }
 
 // UplinkFunctions decodes, converts and validates payload using JavaScript functions
@@ -68,13 +217,32 @@ type UplinkFunctions struct {
 	// Converter and returns a boolean value indicating the validity of the data
 	Validator string
 
+	// Uplink is the uplink message without payload, if it is not nil, it is
+	// passed to the Decoder as third argument
+	Uplink map[string]interface{}
+
 	// Logger is the logger that will be used to store logs
 	Logger functions.Logger
+
+	// Cache is used to run the functions, if it is nil, the functions are compiled for every run
+	Cache *functions.Cache
+	// AppID is the application that the functions belong to, it is used as key in the Cache
+	AppID string
 }
 
 // timeOut is the maximum allowed time a payload function is allowed to run
 var timeOut = 100 * time.Millisecond
 
+// runCode runs the payload function in the cache, or in a new VM if cache is nil. The value
+// can only be used until release is called.
+func runCode(cache *functions.Cache, appID, name, code string, env map[string]interface{}, logger functions.Logger) (val otto.Value, release func(), err error) {
+	if cache == nil {
+		val, err = functions.RunCode(name, code, env, timeOut, logger)
+		return val, func() {}, err
+	}
+	return cache.Run(appID, name, code, env, logger)
+}
+
 // Decode decodes the payload using the Decoder function into a map
 func (f *UplinkFunctions) Decode(payload []byte, port uint8) (map[string]interface{}, error) {
 	if f.Decoder == "" {
@@ -85,12 +253,18 @@ func (f *UplinkFunctions) Decode(payload []byte, port uint8) (map[string]interfa
 		"payload": payload,
 		"port":    port,
 	}
+	args := "payload.slice(0), port"
+	if f.Uplink != nil {
+		env["uplink"] = f.Uplink
+		args += ", uplink"
+	}
 	code := fmt.Sprintf(`
 		%s;
-		Decoder(payload.slice(0), port);
-	`, f.Decoder)
+		Decoder(%s);
+	`, f.Decoder, args)
 
-	value, err := functions.RunCode("Decoder", code, env, timeOut, f.Logger)
+	value, release, err := runCode(f.Cache, f.AppID, "Decoder", code, env, f.Logger)
+	defer release()
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +299,8 @@ func (f *UplinkFunctions) Convert(fields map[string]interface{}, port uint8) (ma
 		Converter(fields, port)
 	`, f.Converter)
 
-	value, err := functions.RunCode("Converter", code, env, timeOut, f.Logger)
+	value, release, err := runCode(f.Cache, f.AppID, "Converter", code, env, f.Logger)
+	defer release()
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +334,8 @@ func (f *UplinkFunctions) Validate(fields map[string]interface{}, port uint8) (b
 		Validator(fields, port)
 	`, f.Validator)
 
-	value, err := functions.RunCode("Validator", code, env, timeOut, f.Logger)
+	value, release, err := runCode(f.Cache, f.AppID, "Validator", code, env, f.Logger)
+	defer release()
 	if err != nil {
 		return false, err
 	}
@@ -195,6 +371,11 @@ type DownlinkFunctions struct {
 
 	// Logger is the logger that will be used to store logs
 	Logger functions.Logger
+
+	// Cache is used to run the functions, if it is nil, the functions are compiled for every run
+	Cache *functions.Cache
+	// AppID is the application that the functions belong to, it is used as key in the Cache
+	AppID string
 }
 
 // Encode encodes the map into a byte slice using the encoder payload function
@@ -213,7 +394,8 @@ func (f *DownlinkFunctions) Encode(payload map[string]interface{}, port uint8) (
 		Encoder(payload, port)
 	`, f.Encoder)
 
-	value, err := functions.RunCode("Encoder", code, env, timeOut, f.Logger)
+	value, release, err := runCode(f.Cache, f.AppID, "Encoder", code, env, f.Logger)
+	defer release()
 	if err != nil {
 		return nil, err
 	}
@@ -231,6 +413,15 @@ func (f *DownlinkFunctions) Encode(payload map[string]interface{}, port uint8) (
 		return nil, errors.NewErrInvalidArgument("Encoder", "does not return an Array")
 	}
 
+	return toBytes("Encoder", v)
+}
+
+// toBytes converts an exported JavaScript Array of integer numbers to a byte slice
+func toBytes(argument string, v interface{}) ([]byte, error) {
+	if v == nil || reflect.TypeOf(v).Kind() != reflect.Slice {
+		return nil, errors.NewErrInvalidArgument(argument, "should be an Array")
+	}
+
 	s := reflect.ValueOf(v)
 	l := s.Len()
 
@@ -264,19 +455,19 @@ func (f *DownlinkFunctions) Encode(payload map[string]interface{}, port uint8) (
 		case float32:
 			n = int64(t)
 			if float32(n) != t {
-				return nil, errors.NewErrInvalidArgument("Encoder", "should return an Array of integer numbers")
+				return nil, errors.NewErrInvalidArgument(argument, "should be an Array of integer numbers")
 			}
 		case float64:
 			n = int64(t)
 			if float64(n) != t {
-				return nil, errors.NewErrInvalidArgument("Encoder", "should return an Array of integer numbers")
+				return nil, errors.NewErrInvalidArgument(argument, "should be an Array of integer numbers")
 			}
 		default:
-			return nil, errors.NewErrInvalidArgument("Encoder", "should return an Array of integer numbers")
+			return nil, errors.NewErrInvalidArgument(argument, "should be an Array of integer numbers")
 		}
 
 		if n < 0 || n > 255 {
-			return nil, errors.NewErrInvalidArgument("Encoder Output", "Numbers in Array should be between 0 and 255")
+			return nil, errors.NewErrInvalidArgument(argument, "Numbers in Array should be between 0 and 255")
 		}
 
 		res[i] = byte(n)
@@ -296,7 +487,7 @@ func (f *DownlinkFunctions) Process(payload map[string]interface{}, port uint8)
 }
 
 // ConvertFieldsDown converts the fields into a payload
-func (h *handler) ConvertFieldsDown(ctx ttnlog.Interface, appDown *types.DownlinkMessage, ttnDown *pb_broker.DownlinkMessage, _ *device.Device) error {
+func (h *handler) ConvertFieldsDown(ctx ttnlog.Interface, appDown *types.DownlinkMessage, ttnDown *pb_broker.DownlinkMessage, dev *device.Device) error {
 	if appDown.PayloadFields == nil || len(appDown.PayloadFields) == 0 {
 		return nil
 	}
@@ -310,12 +501,17 @@ func (h *handler) ConvertFieldsDown(ctx ttnlog.Interface, appDown *types.Downlin
 		return nil
 	}
 
-	functions := &DownlinkFunctions{
+	processor, err := downlinkPayloadProcessor(app.PayloadFormat, &DownlinkFunctions{
 		Encoder: app.Encoder,
 		Logger:  functions.Ignore,
+		Cache:   h.functionCache,
+		AppID:   app.AppID,
+	}, dev.GetPayloadFunctions())
+	if err != nil {
+		return err
 	}
 
-	message, _, err := functions.Process(appDown.PayloadFields, appDown.FPort)
+	message, _, err := processor.Process(appDown.PayloadFields, appDown.FPort)
 	if err != nil {
 		return err
 	}
@@ -10,7 +10,10 @@ import (
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 
+	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/handler/functions"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
@@ -36,7 +39,7 @@ func TestConvertFieldsUp(t *testing.T) {
 
 	h := &handler{
 		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-up"),
-		mqttEvent:    make(chan *types.DeviceEvent, 1),
+		appEvent:     make(chan *types.DeviceEvent, 1),
 	}
 
 	// No functions
@@ -80,13 +83,248 @@ func TestConvertFieldsUp(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(appUp.PayloadFields, ShouldBeEmpty)
 
-	a.So(len(h.mqttEvent), ShouldEqual, 1)
-	evt := <-h.mqttEvent
+	a.So(len(h.appEvent), ShouldEqual, 1)
+	evt := <-h.appEvent
 	data, ok := evt.Data.(types.ErrorEventData)
 	a.So(ok, ShouldBeTrue)
 	fmt.Println(data.Error)
 }
 
+func TestConvertFieldsUpFunctionQuota(t *testing.T) {
+	a := New(t)
+	appID := "AppID-1"
+
+	h := &handler{
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-up-function-quota"),
+		appEvent:     make(chan *types.DeviceEvent, 1),
+	}
+	h.WithFunctionQuota(appID, functions.Quota{Timeout: 10 * time.Millisecond, MaxCodeSize: 1024, MaxVMs: 1})
+
+	app := &application.Application{
+		AppID:   appID,
+		Decoder: `function Decoder (data) { while (true) {} }`,
+	}
+	a.So(h.applications.Set(app), ShouldBeNil)
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+
+	start := time.Now()
+	ttnUp, appUp := buildConversionUplink(appID)
+	err := h.ConvertFieldsUp(GetLogger(t, "TestConvertFieldsUpFunctionQuota"), ttnUp, appUp, nil)
+	a.So(err, ShouldBeNil)
+	a.So(time.Since(start), ShouldBeLessThan, functions.DefaultQuota.Timeout)
+	a.So(appUp.PayloadFields, ShouldBeEmpty)
+	a.So(len(h.appEvent), ShouldEqual, 1)
+}
+
+func TestConvertFieldsUpCayenneLPP(t *testing.T) {
+	a := New(t)
+	appID := "AppID-1"
+
+	h := &handler{
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-up"),
+		appEvent:     make(chan *types.DeviceEvent, 1),
+	}
+
+	app := &application.Application{
+		AppID:         appID,
+		PayloadFormat: application.PayloadFormatCayenneLPP,
+		Decoder:       `function Decoder (data) { throw new Error("should not be called"); }`,
+	}
+	a.So(h.applications.Set(app), ShouldBeNil)
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+
+	ttnUp, appUp := buildConversionUplink(appID)
+	appUp.PayloadRaw = []byte{0x03, 0x67, 0x01, 0x10, 0x05, 0x67, 0x00, 0xff}
+	err := h.ConvertFieldsUp(GetLogger(t, "TestConvertFieldsUpCayenneLPP"), ttnUp, appUp, nil)
+	a.So(err, ShouldBeNil)
+	a.So(appUp.PayloadFields, ShouldResemble, map[string]interface{}{
+		"temperature_3": 27.2,
+		"temperature_5": 25.5,
+	})
+	a.So(len(h.appEvent), ShouldEqual, 0)
+
+	// Invalid payload
+	ttnUp, appUp = buildConversionUplink(appID)
+	err = h.ConvertFieldsUp(GetLogger(t, "TestConvertFieldsUpCayenneLPP"), ttnUp, appUp, nil)
+	a.So(err, ShouldBeNil)
+	a.So(appUp.PayloadFields, ShouldBeEmpty)
+	a.So(len(h.appEvent), ShouldEqual, 1)
+}
+
+func TestConvertFieldsUpDeviceFunctions(t *testing.T) {
+	a := New(t)
+	appID := "AppID-1"
+
+	h := &handler{
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-up"),
+		appEvent:     make(chan *types.DeviceEvent, 1),
+	}
+
+	app := &application.Application{
+		AppID:         appID,
+		PayloadFormat: application.PayloadFormatCayenneLPP,
+		Decoder:       `function Decoder (data) { return { application: true }; }`,
+	}
+	a.So(h.applications.Set(app), ShouldBeNil)
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+
+	dev := &device.Device{
+		AppID: appID,
+		DevID: "DevID-1",
+		PayloadFunctions: &device.PayloadFunctions{
+			Decoder:   `function Decoder (data) { return { temperature: ((data[0] << 8) | data[1]) / 100 }; }`,
+			Converter: `function Converter (data) { data.device = true; return data; }`,
+		},
+	}
+
+	ttnUp, appUp := buildConversionUplink(appID)
+	err := h.ConvertFieldsUp(GetLogger(t, "TestConvertFieldsUpDeviceFunctions"), ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(appUp.PayloadFields, ShouldResemble, map[string]interface{}{
+		"temperature": 21.6,
+		"device":      true,
+	})
+
+	// Device validator
+	dev.PayloadFunctions.Validator = `function Validator (data) { return false; }`
+	ttnUp, appUp = buildConversionUplink(appID)
+	err = h.ConvertFieldsUp(GetLogger(t, "TestConvertFieldsUpDeviceFunctions"), ttnUp, appUp, dev)
+	a.So(err, ShouldNotBeNil)
+
+	// Device without decoder uses the application
+	dev.PayloadFunctions = &device.PayloadFunctions{
+		Encoder: `function Encoder (payload) { return [1]; }`,
+	}
+	ttnUp, appUp = buildConversionUplink(appID)
+	appUp.PayloadRaw = []byte{0x01, 0x67, 0x01, 0x10}
+	err = h.ConvertFieldsUp(GetLogger(t, "TestConvertFieldsUpDeviceFunctions"), ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(appUp.PayloadFields, ShouldResemble, map[string]interface{}{
+		"temperature_1": 27.2,
+	})
+}
+
+func TestConvertFieldsUpMetadataAndDownlink(t *testing.T) {
+	a := New(t)
+	appID := "AppID-1"
+	devID := "DevID-1"
+
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestConvertFieldsUpMetadataAndDownlink")},
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-up"),
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-convert-fields-up"),
+		appEvent:     make(chan *types.DeviceEvent, 10),
+	}
+
+	app := &application.Application{
+		AppID:          appID,
+		ExposeMetadata: true,
+		Decoder: `function Decoder (bytes, port, uplink) {
+			return {
+				dev_id: uplink.dev_id,
+				rssi: uplink.metadata.gateways[0].rssi,
+				has_payload: uplink.payload_raw !== undefined,
+				_downlink: { port: 2, payload_raw: [ 1, bytes[0] ], schedule: "last" }
+			};
+		}`,
+	}
+	a.So(h.applications.Set(app), ShouldBeNil)
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+
+	dev := &device.Device{AppID: appID, DevID: devID}
+	a.So(h.devices.Set(dev), ShouldBeNil)
+	defer func() {
+		h.devices.Delete(appID, devID)
+	}()
+
+	ttnUp, appUp := buildConversionUplink(appID)
+	appUp.Metadata.Gateways = []types.GatewayMetadata{{GtwID: "gtw", RSSI: -42}}
+	downlink, err := h.convertFieldsUp(GetLogger(t, "TestConvertFieldsUpMetadataAndDownlink"), ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(appUp.PayloadFields, ShouldResemble, map[string]interface{}{
+		"dev_id":      devID,
+		"rssi":        -42.0,
+		"has_payload": false,
+	})
+	a.So(downlink, ShouldNotBeNil)
+	a.So(downlink.AppID, ShouldEqual, appID)
+	a.So(downlink.DevID, ShouldEqual, devID)
+	a.So(downlink.FPort, ShouldEqual, 2)
+	a.So(downlink.PayloadRaw, ShouldResemble, []byte{1, 0x08})
+	a.So(downlink.Schedule, ShouldEqual, types.ScheduleLast)
+
+	// The downlink is not enqueued by the conversion
+	queue, _ := h.devices.DownlinkQueue(appID, devID)
+	length, _ := queue.Length()
+	a.So(length, ShouldEqual, 0)
+
+	// Invalid downlink
+	app.StartUpdate()
+	app.ExposeMetadata = false
+	app.Decoder = `function Decoder (bytes, port, uplink) {
+		return { uplink: uplink === undefined, _downlink: { payload_raw: [ 256 ] } };
+	}`
+	a.So(h.applications.Set(app), ShouldBeNil)
+	ttnUp, appUp = buildConversionUplink(appID)
+	downlink, err = h.convertFieldsUp(GetLogger(t, "TestConvertFieldsUpMetadataAndDownlink"), ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(downlink, ShouldBeNil)
+	a.So(appUp.PayloadFields, ShouldResemble, map[string]interface{}{
+		"uplink": true,
+	})
+	evt := <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.UplinkErrorEvent)
+}
+
+func TestExtractDownlink(t *testing.T) {
+	a := New(t)
+
+	fields := map[string]interface{}{"foo": "bar"}
+	downlink, err := extractDownlink("app", "dev", fields)
+	a.So(err, ShouldBeNil)
+	a.So(downlink, ShouldBeNil)
+
+	fields[downlinkField] = map[string]interface{}{
+		"port":        float64(3),
+		"confirmed":   true,
+		"payload_raw": "AQI=",
+	}
+	downlink, err = extractDownlink("app", "dev", fields)
+	a.So(err, ShouldBeNil)
+	a.So(downlink, ShouldResemble, &types.DownlinkMessage{
+		AppID:      "app",
+		DevID:      "dev",
+		FPort:      3,
+		Confirmed:  true,
+		PayloadRaw: []byte{1, 2},
+	})
+	a.So(fields, ShouldResemble, map[string]interface{}{"foo": "bar"})
+
+	fields[downlinkField] = map[string]interface{}{
+		"port":           int64(1),
+		"payload_fields": map[string]interface{}{"led": true},
+	}
+	downlink, err = extractDownlink("app", "dev", fields)
+	a.So(err, ShouldBeNil)
+	a.So(downlink.PayloadFields, ShouldResemble, map[string]interface{}{"led": true})
+
+	fields[downlinkField] = "foo"
+	_, err = extractDownlink("app", "dev", fields)
+	a.So(err, ShouldNotBeNil)
+
+	fields[downlinkField] = map[string]interface{}{"port": "foo"}
+	_, err = extractDownlink("app", "dev", fields)
+	a.So(err, ShouldNotBeNil)
+}
+
 func TestDecode(t *testing.T) {
 	a := New(t)
 
@@ -364,6 +602,74 @@ func TestConvertFieldsDown(t *testing.T) {
 	a.So(appDown.PayloadRaw, ShouldResemble, []byte{byte(appDown.FPort), 1, 2, 3, 4, 5, 6, 7})
 }
 
+func TestConvertFieldsDownCayenneLPP(t *testing.T) {
+	a := New(t)
+	appID := "AppID-1"
+
+	h := &handler{
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-down"),
+	}
+
+	h.applications.Set(&application.Application{
+		AppID:         appID,
+		PayloadFormat: application.PayloadFormatCayenneLPP,
+	})
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+
+	ttnDown, appDown := buildConversionDownlink()
+	appDown.PayloadFields = map[string]interface{}{"value_2": 12.5}
+	err := h.ConvertFieldsDown(GetLogger(t, "TestConvertFieldsDownCayenneLPP"), appDown, ttnDown, nil)
+	a.So(err, ShouldBeNil)
+	a.So(appDown.PayloadRaw, ShouldResemble, []byte{0x02, 0x04, 0xe2})
+
+	// Fields that can not be encoded
+	ttnDown, appDown = buildConversionDownlink()
+	err = h.ConvertFieldsDown(GetLogger(t, "TestConvertFieldsDownCayenneLPP"), appDown, ttnDown, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(appDown.PayloadRaw, ShouldBeEmpty)
+}
+
+func TestConvertFieldsDownDeviceFunctions(t *testing.T) {
+	a := New(t)
+	appID := "AppID-1"
+
+	h := &handler{
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-convert-fields-down"),
+	}
+
+	h.applications.Set(&application.Application{
+		AppID:   appID,
+		Encoder: `function Encoder (payload, port) { return [ 1, 2, 3 ]; }`,
+	})
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+
+	dev := &device.Device{
+		AppID: appID,
+		DevID: "DevID-1",
+		PayloadFunctions: &device.PayloadFunctions{
+			Encoder: `function Encoder (payload, port) { return [ port, payload.temperature ]; }`,
+		},
+	}
+
+	ttnDown, appDown := buildConversionDownlink()
+	err := h.ConvertFieldsDown(GetLogger(t, "TestConvertFieldsDownDeviceFunctions"), appDown, ttnDown, dev)
+	a.So(err, ShouldBeNil)
+	a.So(appDown.PayloadRaw, ShouldResemble, []byte{1, 30})
+
+	// Device without encoder uses the application
+	dev.PayloadFunctions = &device.PayloadFunctions{
+		Decoder: `function Decoder (data) { return {}; }`,
+	}
+	ttnDown, appDown = buildConversionDownlink()
+	err = h.ConvertFieldsDown(GetLogger(t, "TestConvertFieldsDownDeviceFunctions"), appDown, ttnDown, dev)
+	a.So(err, ShouldBeNil)
+	a.So(appDown.PayloadRaw, ShouldResemble, []byte{1, 2, 3})
+}
+
 func TestConvertFieldsDownNoPort(t *testing.T) {
 	a := New(t)
 	appID := "AppID-1"
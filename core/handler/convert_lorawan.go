@@ -6,6 +6,7 @@ package handler
 import (
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
@@ -72,22 +73,55 @@ func (h *handler) ConvertFromLoRaWAN(ctx ttnlog.Interface, ttnUp *pb_broker.Dedu
 	if dev.CurrentDownlink != nil && !appUp.IsRetry {
 		// We have a downlink pending
 		if dev.CurrentDownlink.Confirmed {
-			// If it's confirmed, we can only unset it if we receive an ack.
-			if macPayload.FHDR.FCtrl.ACK {
+			// If it's confirmed, we can only unset it if we receive an ack, or if the NetworkServer dropped it
+			// after the maximum retries or timeout. The NetworkServer keeps track of the confirmed downlink that
+			// it sent, if it does not know the confirmed downlink, only the ACK of the uplink is used.
+			acknowledged, exhausted := macPayload.FHDR.FCtrl.ACK, false
+			lorawanMeta := ttnUp.GetProtocolMetadata().GetLorawan()
+			switch lorawanMeta.GetConfirmedDownlink() {
+			case pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING:
+				acknowledged = false
+			case pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_ACK:
+				acknowledged = true
+			case pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT:
+				acknowledged, exhausted = false, true
+			}
+			if lorawanMeta.GetConfirmedDownlink() > pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING && dev.FCntDown <= lorawanMeta.GetConfirmedFCntDown() {
+				// The NetworkServer incremented the FCntDown after the confirmed downlink, which may have a higher
+				// FCnt than the response template if it replaced another confirmed downlink
+				dev.FCntDown = lorawanMeta.GetConfirmedFCntDown() + 1
+			}
+
+			if acknowledged {
 				// Send event over MQTT
-				h.mqttEvent <- &types.DeviceEvent{
+				h.publishEvent(&types.DeviceEvent{
 					AppID: appUp.AppID,
 					DevID: appUp.DevID,
 					Event: types.DownlinkAckEvent,
 					Data: types.DownlinkEventData{
-						Message: dev.CurrentDownlink,
+						CorrelationID: dev.CurrentDownlink.CorrelationID,
+						Message:       dev.CurrentDownlink,
+						Retries:       dev.CurrentDownlinkRetries,
+					},
+				})
+				dev.SetCurrentDownlink(nil)
+			} else if exhausted {
+				ctx.WithField("Retries", dev.CurrentDownlinkRetries).Debug("Confirmed downlink was not acknowledged")
+				h.publishEvent(&types.DeviceEvent{
+					AppID: appUp.AppID,
+					DevID: appUp.DevID,
+					Event: types.DownlinkNackEvent,
+					Data: types.DownlinkEventData{
+						CorrelationID: dev.CurrentDownlink.CorrelationID,
+						Message:       dev.CurrentDownlink,
+						Retries:       dev.CurrentDownlinkRetries,
 					},
-				}
-				dev.CurrentDownlink = nil
+				})
+				dev.SetCurrentDownlink(nil)
 			}
 		} else {
 			// If it's unconfirmed, we can unset it.
-			dev.CurrentDownlink = nil
+			dev.SetCurrentDownlink(nil)
 		}
 	}
 
@@ -5,6 +5,7 @@ package handler
 
 import (
 	"testing"
+	"time"
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
@@ -36,7 +37,7 @@ func TestConvertFromLoRaWAN(t *testing.T) {
 	h := &handler{
 		Component: &component.Component{Ctx: GetLogger(t, "TestConvertFromLoRaWAN")},
 		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-convert-from-lorawan"),
-		mqttEvent: make(chan *types.DeviceEvent, 10),
+		appEvent:  make(chan *types.DeviceEvent, 10),
 	}
 	device := &device.Device{
 		DevID: "devid",
@@ -59,6 +60,108 @@ func TestConvertFromLoRaWAN(t *testing.T) {
 
 }
 
+func TestConvertFromLoRaWANConfirmedDownlink(t *testing.T) {
+	a := New(t)
+	h := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestConvertFromLoRaWANConfirmedDownlink")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-convert-from-lorawan"),
+		appEvent:  make(chan *types.DeviceEvent, 10),
+	}
+
+	uplink := func(ack bool) (*pb_broker.DeduplicatedUplinkMessage, *types.UplinkMessage) {
+		ttnUp, appUp := buildLorawanUplink([]byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x20, 0x01, 0x00, 0x0A, 0x46, 0x55, 0x96, 0x42, 0x92, 0xF2})
+		ttnUp.UnmarshalPayload()
+		ttnUp.Message.GetLorawan().GetMacPayload().Ack = ack
+		ttnUp.Message.GetLorawan().SetMIC(types.NwkSKey([16]byte{}))
+		ttnUp.Payload = ttnUp.Message.GetLorawan().PHYPayloadBytes()
+		return ttnUp, appUp
+	}
+
+	confirmed := &types.DownlinkMessage{PayloadRaw: []byte{0xaa}, Confirmed: true}
+
+	// Not acknowledged, the Handler does not limit the retries or time of confirmed downlink
+	dev := &device.Device{DevID: "devid", AppID: "appid"}
+	dev.SetCurrentDownlink(confirmed)
+	dev.CurrentDownlinkSentAt = time.Now().Add(-48 * time.Hour)
+	dev.CurrentDownlinkRetries = 100
+	ttnUp, appUp := uplink(false)
+	err := h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.CurrentDownlink, ShouldNotBeNil)
+
+	// The NetworkServer dropped the confirmed downlink after the maximum retries
+	ttnUp, appUp = uplink(false)
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedDownlink = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT
+	err = h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.CurrentDownlink, ShouldBeNil)
+	a.So(dev.CurrentDownlinkSentAt.IsZero(), ShouldBeTrue)
+	evt := <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkNackEvent)
+	a.So(evt.Data.(types.DownlinkEventData).Retries, ShouldEqual, 100)
+
+	// Acknowledged
+	dev = &device.Device{DevID: "devid", AppID: "appid"}
+	dev.SetCurrentDownlink(confirmed)
+	dev.CurrentDownlinkSentAt = time.Now()
+	dev.CurrentDownlinkRetries = 2
+	ttnUp, appUp = uplink(true)
+	err = h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.CurrentDownlink, ShouldBeNil)
+	evt = <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkAckEvent)
+	a.So(evt.Data.(types.DownlinkEventData).Retries, ShouldEqual, 2)
+
+	// The NetworkServer reports that the confirmed downlink is still pending, the ACK of the uplink is for another
+	// downlink
+	dev = &device.Device{DevID: "devid", AppID: "appid", FCntDown: 5}
+	dev.SetCurrentDownlink(confirmed)
+	dev.CurrentDownlinkSentAt = time.Now()
+	ttnUp, appUp = uplink(true)
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedDownlink = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING
+	err = h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.CurrentDownlink, ShouldNotBeNil)
+
+	// The NetworkServer reports that the confirmed downlink was acknowledged
+	dev.CurrentDownlinkSentAt = time.Now()
+	ttnUp, appUp = uplink(false)
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedDownlink = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_ACK
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedFCntDown = 5
+	err = h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.CurrentDownlink, ShouldBeNil)
+	a.So(dev.FCntDown, ShouldEqual, 6)
+	evt = <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkAckEvent)
+
+	// The NetworkServer reports that the confirmed downlink was not acknowledged in time
+	dev.SetCurrentDownlink(confirmed)
+	dev.CurrentDownlinkSentAt = time.Now()
+	ttnUp, appUp = uplink(true)
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedDownlink = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedFCntDown = 6
+	err = h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.CurrentDownlink, ShouldBeNil)
+	a.So(dev.FCntDown, ShouldEqual, 7)
+	evt = <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkNackEvent)
+
+	// The confirmed downlink replaced another confirmed downlink, so the NetworkServer skipped an FCnt
+	dev.SetCurrentDownlink(confirmed)
+	dev.CurrentDownlinkSentAt = time.Now()
+	ttnUp, appUp = uplink(true)
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedDownlink = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_ACK
+	ttnUp.ProtocolMetadata.GetLorawan().ConfirmedFCntDown = 8
+	err = h.ConvertFromLoRaWAN(h.Ctx, ttnUp, appUp, dev)
+	a.So(err, ShouldBeNil)
+	a.So(dev.FCntDown, ShouldEqual, 9)
+	evt = <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkAckEvent)
+}
+
 func buildLorawanDownlink(payload []byte) (*types.DownlinkMessage, *pb_broker.DownlinkMessage) {
 	appDown := &types.DownlinkMessage{
 		DevID:      "devid",
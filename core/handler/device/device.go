@@ -19,9 +19,24 @@ type AppNonce [3]byte
 
 // Options for the device
 type Options struct {
-	ActivationConstraints string `json:"activation_constraints,omitempty"` // Activation Constraints (public/local/private)
-	DisableFCntCheck      bool   `json:"disable_fcnt_check,omitemtpy"`     // Disable Frame counter check (insecure)
-	Uses32BitFCnt         bool   `json:"uses_32_bit_fcnt,omitemtpy"`       // Use 32-bit Frame counters
+	ActivationConstraints string                 `json:"activation_constraints,omitempty"` // Activation Constraints (public/local/private)
+	DisableFCntCheck      bool                   `json:"disable_fcnt_check,omitemtpy"`     // Disable Frame counter check (insecure)
+	Uses32BitFCnt         bool                   `json:"uses_32_bit_fcnt,omitemtpy"`       // Use 32-bit Frame counters
+	Class                 pb_lorawan.DeviceClass `json:"class,omitempty"`                  // Device Class (A/B/C)
+	BeaconFrequency       uint32                 `json:"beacon_frequency,omitempty"`       // Beacon frequency of Class B device (Hz)
+	PingSlotFrequency     uint32                 `json:"ping_slot_frequency,omitempty"`    // Ping slot frequency of Class B device (Hz)
+	PingSlotDataRate      string                 `json:"ping_slot_data_rate,omitempty"`    // Ping slot data rate of Class B device
+	MACVersion            pb_lorawan.MACVersion  `json:"mac_version,omitempty"`            // LoRaWAN MAC version (1.0/1.1)
+	ADRAlgorithm          string                 `json:"adr_algorithm,omitempty"`          // ADR algorithm (default/conservative/fixed)
+	ADRDataRate           string                 `json:"adr_data_rate,omitempty"`          // Data rate for the fixed ADR algorithm
+}
+
+// PayloadFunctions are the payload functions of a device
+type PayloadFunctions struct {
+	Decoder   string `json:"decoder,omitempty"`   // Decoder overrides the payload format and the Decoder, Converter and Validator of the application
+	Converter string `json:"converter,omitempty"` // Converter is only used together with Decoder
+	Validator string `json:"validator,omitempty"` // Validator is only used together with Decoder
+	Encoder   string `json:"encoder,omitempty"`   // Encoder overrides the payload format and the Encoder of the application
 }
 
 // Device contains the state of a device
@@ -41,17 +56,40 @@ type Device struct {
 
 	Options Options `redis:"options"`
 
+	// PayloadFunctions are the payload functions of the device that take precedence over
+	// the payload functions of the application, it is nil if the device has none
+	PayloadFunctions *PayloadFunctions `redis:"payload_functions"`
+
 	AppKey        types.AppKey `redis:"app_key"`
+	NwkKey        types.NwkKey `redis:"nwk_key"` // Only for LoRaWAN 1.1 devices
 	UsedDevNonces []DevNonce   `redis:"used_dev_nonces"`
 	UsedAppNonces []AppNonce   `redis:"used_app_nonces"`
 
-	DevAddr types.DevAddr `redis:"dev_addr"`
-	NwkSKey types.NwkSKey `redis:"nwk_s_key"`
-	AppSKey types.AppSKey `redis:"app_s_key"`
-	FCntUp  uint32        `redis:"f_cnt_up"` // Only used to detect retries
+	// JoinNonce is the last JoinNonce that was used for a LoRaWAN 1.1 device, the JoinNonce is a counter
+	JoinNonce uint32 `redis:"join_nonce"`
+	// RJCount1 is the next expected RJcount1 of RejoinRequests of type 1 of a LoRaWAN 1.1 device
+	RJCount1 uint16 `redis:"rj_count_1"`
+
+	DevAddr     types.DevAddr     `redis:"dev_addr"`
+	NwkSKey     types.NwkSKey     `redis:"nwk_s_key"`       // The FNwkSIntKey for LoRaWAN 1.1 devices
+	SNwkSIntKey types.SNwkSIntKey `redis:"s_nwk_s_int_key"` // Only for LoRaWAN 1.1 devices
+	NwkSEncKey  types.NwkSEncKey  `redis:"nwk_s_enc_key"`   // Only for LoRaWAN 1.1 devices
+	AppSKey     types.AppSKey     `redis:"app_s_key"`
+	FCntUp      uint32            `redis:"f_cnt_up"` // Only used to detect retries
+
+	// FCntDown is the next downlink frame counter, as reported by the NetworkServer. It is only
+	// used for downlink messages that are not a response to an uplink message (Class B/C). For
+	// LoRaWAN 1.1 devices this is the AFCntDown.
+	FCntDown uint32 `redis:"f_cnt_down"`
 
 	CurrentDownlink *types.DownlinkMessage `redis:"current_downlink"`
 
+	// CurrentDownlinkSentAt is the time at which the current confirmed downlink was first sent,
+	// it is zero if it was not sent yet
+	CurrentDownlinkSentAt time.Time `redis:"current_downlink_sent_at"`
+	// CurrentDownlinkRetries is the number of times that the current confirmed downlink was sent again
+	CurrentDownlinkRetries int `redis:"current_downlink_retries"`
+
 	CreatedAt time.Time `redis:"created_at"`
 	UpdatedAt time.Time `redis:"updated_at"`
 }
@@ -92,6 +130,21 @@ func (d Device) ChangedFields() (changed []string) {
 	return
 }
 
+// SetCurrentDownlink sets the current downlink and resets the retry state of the previous one
+func (d *Device) SetCurrentDownlink(msg *types.DownlinkMessage) {
+	d.CurrentDownlink = msg
+	d.CurrentDownlinkSentAt = time.Time{}
+	d.CurrentDownlinkRetries = 0
+}
+
+// GetPayloadFunctions returns the payload functions of the device, it is nil-safe
+func (d *Device) GetPayloadFunctions() *PayloadFunctions {
+	if d == nil {
+		return nil
+	}
+	return d.PayloadFunctions
+}
+
 // GetLoRaWAN returns a LoRaWAN Device proto
 func (d Device) GetLoRaWAN() *pb_lorawan.Device {
 	dev := &pb_lorawan.Device{
@@ -104,6 +157,22 @@ func (d Device) GetLoRaWAN() *pb_lorawan.Device {
 		DisableFCntCheck:      d.Options.DisableFCntCheck,
 		Uses32BitFCnt:         d.Options.Uses32BitFCnt,
 		ActivationConstraints: d.Options.ActivationConstraints,
+		DeviceClass:           d.Options.Class,
+		BeaconFrequency:       d.Options.BeaconFrequency,
+		PingSlotFrequency:     d.Options.PingSlotFrequency,
+		PingSlotDataRate:      d.Options.PingSlotDataRate,
+		MacVersion:            d.Options.MACVersion,
+		AdrAlgorithm:          d.Options.ADRAlgorithm,
+		AdrDataRate:           d.Options.ADRDataRate,
+	}
+	if d.Options.MACVersion == pb_lorawan.MACVersion_MAC_V1_1 {
+		dev.SNwkSIntKey = &d.SNwkSIntKey
+		dev.NwkSEncKey = &d.NwkSEncKey
 	}
 	return dev
 }
+
+// IsLoRaWAN11 returns true if the device uses LoRaWAN 1.1
+func (d *Device) IsLoRaWAN11() bool {
+	return d.Options.MACVersion == pb_lorawan.MACVersion_MAC_V1_1
+}
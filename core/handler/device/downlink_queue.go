@@ -9,6 +9,7 @@ import (
 
 	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
 
 // DownlinkQueue stores the Downlink queue
@@ -18,6 +19,9 @@ type DownlinkQueue interface {
 	Replace(msg *types.DownlinkMessage) error
 	PushFirst(msg *types.DownlinkMessage) error
 	PushLast(msg *types.DownlinkMessage) error
+	List() ([]*types.DownlinkMessage, error)
+	Delete(index int) error
+	Clear() error
 }
 
 // RedisDownlinkQueue implements the downlink queue in Redis
@@ -77,3 +81,45 @@ func (s *RedisDownlinkQueue) PushLast(msg *types.DownlinkMessage) error {
 	}
 	return s.queues.AddEnd(s.key(), string(qd))
 }
+
+// List the messages in the downlink queue, without removing them
+func (s *RedisDownlinkQueue) List() ([]*types.DownlinkMessage, error) {
+	qd, err := s.queues.Get(s.key())
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]*types.DownlinkMessage, 0, len(qd))
+	for _, item := range qd {
+		msg := new(types.DownlinkMessage)
+		if err := json.Unmarshal([]byte(item), msg); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// Delete the message at the given index from the downlink queue
+func (s *RedisDownlinkQueue) Delete(index int) error {
+	qd, err := s.queues.Get(s.key())
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(qd) {
+		return errors.NewErrNotFound(fmt.Sprintf("%s:%d", s.key(), index))
+	}
+	removed, err := s.queues.Remove(s.key(), qd[index])
+	if err != nil {
+		return err
+	}
+	if !removed {
+		// The message was sent in the meantime
+		return errors.NewErrNotFound(fmt.Sprintf("%s:%d", s.key(), index))
+	}
+	return nil
+}
+
+// Clear the downlink queue
+func (s *RedisDownlinkQueue) Clear() error {
+	return s.queues.Delete(s.key())
+}
@@ -80,4 +80,43 @@ func TestDownlinkQueue(t *testing.T) {
 		a.So(next.PayloadRaw, ShouldResemble, []byte{0xaa, 0xbc})
 	}
 
+	{
+		for _, payload := range [][]byte{{0x01}, {0x02}, {0x03}} {
+			err := s.PushLast(&types.DownlinkMessage{
+				PayloadRaw: payload,
+			})
+			a.So(err, ShouldBeNil)
+		}
+	}
+
+	{
+		list, err := s.List()
+		a.So(err, ShouldBeNil)
+		a.So(list, ShouldHaveLength, 3)
+		a.So(list[1].PayloadRaw, ShouldResemble, []byte{0x02})
+	}
+
+	{
+		err := s.Delete(1)
+		a.So(err, ShouldBeNil)
+		err = s.Delete(2)
+		a.So(err, ShouldNotBeNil)
+	}
+
+	{
+		list, err := s.List()
+		a.So(err, ShouldBeNil)
+		a.So(list, ShouldHaveLength, 2)
+		a.So(list[0].PayloadRaw, ShouldResemble, []byte{0x01})
+		a.So(list[1].PayloadRaw, ShouldResemble, []byte{0x03})
+	}
+
+	{
+		err := s.Clear()
+		a.So(err, ShouldBeNil)
+		length, err := s.Length()
+		a.So(err, ShouldBeNil)
+		a.So(length, ShouldEqual, 0)
+	}
+
 }
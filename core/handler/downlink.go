@@ -4,20 +4,76 @@
 package handler
 
 import (
+	"fmt"
 	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/random"
 )
 
 func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error) {
+	return h.enqueueDownlink(appDownlink, false)
+}
+
+// getDownlinkQueue returns the confirmed downlink of the device that waits for an acknowledgment, if any, and the
+// downlink messages in the queue of the device
+func (h *handler) getDownlinkQueue(appID, devID string) (current *types.DownlinkMessage, queued []*types.DownlinkMessage, err error) {
+	dev, err := h.devices.Get(appID, devID)
+	if err != nil {
+		return nil, nil, err
+	}
+	queue, err := h.devices.DownlinkQueue(appID, devID)
+	if err != nil {
+		return nil, nil, err
+	}
+	queued, err = queue.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	return dev.CurrentDownlink, queued, nil
+}
+
+// clearDownlinkQueue deletes the downlink messages in the queue of the device and the confirmed downlink that waits
+// for an acknowledgment
+func (h *handler) clearDownlinkQueue(appID, devID string) error {
+	dev, err := h.devices.Get(appID, devID)
+	if err != nil {
+		return err
+	}
+	queue, err := h.devices.DownlinkQueue(appID, devID)
+	if err != nil {
+		return err
+	}
+	if err := queue.Clear(); err != nil {
+		return err
+	}
+	if dev.CurrentDownlink == nil {
+		return nil
+	}
+	dev.StartUpdate()
+	dev.SetCurrentDownlink(nil)
+	return h.devices.Set(dev)
+}
+
+// enqueueDownlink enqueues the downlink. If keepConfirmed is true, a confirmed downlink that is waiting for an
+// acknowledgement is not replaced by the downlink.
+func (h *handler) enqueueDownlink(appDownlink *types.DownlinkMessage, keepConfirmed bool) (err error) {
 	appID, devID := appDownlink.AppID, appDownlink.DevID
+
+	if appDownlink.CorrelationID == "" {
+		appDownlink.CorrelationID = random.String(16)
+	}
+
 	ctx := h.Ctx.WithFields(ttnlog.Fields{
-		"AppID": appID,
-		"DevID": devID,
+		"AppID":         appID,
+		"DevID":         devID,
+		"CorrelationID": appDownlink.CorrelationID,
 	})
 
 	start := time.Now()
@@ -38,15 +94,16 @@ func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error
 
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.DownlinkErrorEvent,
 				Data: types.DownlinkEventData{
 					ErrorEventData: types.ErrorEventData{Error: err.Error()},
+					CorrelationID:  appDownlink.CorrelationID,
 					Message:        appDownlink,
 				},
-			}
+			})
 		}
 	}()
 
@@ -62,9 +119,15 @@ func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error
 	schedule := appDownlink.Schedule
 	appDownlink.Schedule = ""
 
+	if err = appDownlink.SetExpiry(time.Now()); err != nil {
+		return errors.NewErrInvalidArgument("TTL", err.Error())
+	}
+
 	switch schedule {
 	case types.ScheduleReplace, "": // Empty string for default
-		dev.CurrentDownlink = nil
+		if !keepConfirmed || dev.CurrentDownlink == nil || !dev.CurrentDownlink.Confirmed {
+			dev.SetCurrentDownlink(nil)
+		}
 		err = queue.Replace(appDownlink)
 	case types.ScheduleFirst:
 		err = queue.PushFirst(appDownlink)
@@ -82,18 +145,56 @@ func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error
 		return err
 	}
 
-	h.mqttEvent <- &types.DeviceEvent{
+	h.publishEvent(&types.DeviceEvent{
 		AppID: appID,
 		DevID: devID,
 		Event: types.DownlinkScheduledEvent,
 		Data: types.DownlinkEventData{
-			Message: appDownlink,
+			CorrelationID: appDownlink.CorrelationID,
+			Message:       appDownlink,
 		},
+	})
+
+	switch dev.Options.Class {
+	case pb_lorawan.DeviceClass_CLASS_B, pb_lorawan.DeviceClass_CLASS_C:
+		// Errors are emitted as downlink error events by HandleDownlink
+		h.sendClassCDownlink(appID, devID)
 	}
 
 	return nil
 }
 
+// nextDownlink takes the next message that has not expired from the downlink queue. Expired
+// messages are dropped from the queue with a down/expired event.
+func (h *handler) nextDownlink(appID, devID string, queue device.DownlinkQueue) (*types.DownlinkMessage, error) {
+	for {
+		next, err := queue.Next()
+		if err != nil || next == nil {
+			return next, err
+		}
+		if !next.Expired(time.Now()) {
+			return next, nil
+		}
+		h.publishDownlinkExpired(appID, devID, next)
+	}
+}
+
+func (h *handler) publishDownlinkExpired(appID, devID string, msg *types.DownlinkMessage) {
+	h.Ctx.WithFields(ttnlog.Fields{
+		"AppID": appID,
+		"DevID": devID,
+	}).Debug("Dropped expired downlink")
+	h.publishEvent(&types.DeviceEvent{
+		AppID: appID,
+		DevID: devID,
+		Event: types.DownlinkExpiredEvent,
+		Data: types.DownlinkEventData{
+			CorrelationID: msg.CorrelationID,
+			Message:       msg,
+		},
+	})
+}
+
 func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *pb_broker.DownlinkMessage) (err error) {
 	appID, devID := appDownlink.AppID, appDownlink.DevID
 
@@ -103,18 +204,22 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 		"AppEUI": downlink.AppEui,
 		"DevEUI": downlink.DevEui,
 	})
+	if appDownlink.CorrelationID != "" {
+		ctx = ctx.WithField("CorrelationID", appDownlink.CorrelationID)
+	}
 
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.DownlinkErrorEvent,
 				Data: types.DownlinkEventData{
 					ErrorEventData: types.ErrorEventData{Error: err.Error()},
+					CorrelationID:  appDownlink.CorrelationID,
 					Message:        appDownlink,
 				},
-			}
+			})
 			ctx.WithError(err).Warn("Could not handle downlink")
 			downlink.Trace = downlink.Trace.WithEvent(trace.DropEvent, "reason", err)
 		}
@@ -135,6 +240,18 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 		}
 	}()
 
+	// The NetworkServer uses the ADR settings of the application for devices that have none of their own
+	if app, err := h.applications.Get(appID); err == nil {
+		downlink.AppAdrAlgorithm, downlink.AppAdrDataRate = app.ADRAlgorithm, app.ADRDataRate
+	} else if errors.GetErrType(err) != errors.NotFound {
+		return err
+	}
+
+	// Keep track of the FCntDown of the NetworkServer for downlink that is not a response to an uplink
+	if lorawan := downlink.GetDownlinkOption().GetProtocolConfig().GetLorawan(); lorawan != nil {
+		dev.FCntDown = lorawan.FCnt
+	}
+
 	// Get Processors
 	processors := []DownlinkProcessor{
 		h.ConvertFieldsDown,
@@ -142,7 +259,11 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 	}
 
 	ctx.WithField("NumProcessors", len(processors)).Debug("Running Downlink Processors")
-	downlink.Trace = downlink.Trace.WithEvent("process downlink")
+	if appDownlink.CorrelationID != "" {
+		downlink.Trace = downlink.Trace.WithEvent("process downlink", trace.CorrelationIDKey, appDownlink.CorrelationID)
+	} else {
+		downlink.Trace = downlink.Trace.WithEvent("process downlink")
+	}
 
 	// Run Processors
 	for _, processor := range processors {
@@ -164,34 +285,92 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 
 	downlink.Trace = downlink.Trace.WithEvent(trace.ForwardEvent, "broker", h.ttnBrokerID)
 
+	// The NetworkServer only reuses the FCnt for a confirmed downlink that was sent before
+	downlink.Retransmission = appDownlink.Confirmed && dev.CurrentDownlink != nil && !dev.CurrentDownlinkSentAt.IsZero()
+
 	h.downlink <- downlink
 
-	downlinkConfig := types.DownlinkEventConfigInfo{}
+	// The NetworkServer uses the same FCnt for confirmed downlink until it is acknowledged
+	if !appDownlink.Confirmed {
+		dev.FCntDown++
+	}
 
-	if downlink.DownlinkOption.ProtocolConfig != nil {
-		if lorawan := downlink.DownlinkOption.ProtocolConfig.GetLorawan(); lorawan != nil {
-			downlinkConfig.Modulation = lorawan.Modulation.String()
-			downlinkConfig.DataRate = lorawan.DataRate
-			downlinkConfig.BitRate = uint(lorawan.BitRate)
-			downlinkConfig.FCnt = uint(lorawan.FCnt)
+	var retries int
+	if appDownlink.Confirmed && dev.CurrentDownlink != nil {
+		if dev.CurrentDownlinkSentAt.IsZero() {
+			dev.CurrentDownlinkSentAt = time.Now()
+		} else {
+			dev.CurrentDownlinkRetries++
 		}
+		retries = dev.CurrentDownlinkRetries
 	}
-	if gateway := downlink.DownlinkOption.GatewayConfig; gateway != nil {
-		downlinkConfig.Frequency = uint(downlink.DownlinkOption.GatewayConfig.Frequency)
-		downlinkConfig.Power = int(downlink.DownlinkOption.GatewayConfig.Power)
+
+	downlinkConfig := types.DownlinkEventConfigInfo{}
+
+	// The DownlinkOption of Class B/C downlink is selected by the NetworkServer, so it is not known here
+	if lorawan := downlink.GetDownlinkOption().GetProtocolConfig().GetLorawan(); lorawan != nil {
+		downlinkConfig.Modulation = lorawan.Modulation.String()
+		downlinkConfig.DataRate = lorawan.DataRate
+		downlinkConfig.BitRate = uint(lorawan.BitRate)
+		downlinkConfig.FCnt = uint(lorawan.FCnt)
+	}
+	if gateway := downlink.GetDownlinkOption().GetGatewayConfig(); gateway != nil {
+		downlinkConfig.Frequency = uint(gateway.Frequency)
+		downlinkConfig.Power = int(gateway.Power)
 	}
 
-	h.mqttEvent <- &types.DeviceEvent{
+	h.publishEvent(&types.DeviceEvent{
 		AppID: appDownlink.AppID,
 		DevID: appDownlink.DevID,
 		Event: types.DownlinkSentEvent,
 		Data: types.DownlinkEventData{
-			Payload:   downlink.Payload,
-			Message:   appDownlink,
-			GatewayID: downlink.DownlinkOption.GatewayId,
-			Config:    downlinkConfig,
+			CorrelationID: appDownlink.CorrelationID,
+			Payload:       downlink.Payload,
+			Message:       appDownlink,
+			GatewayID:     downlink.GetDownlinkOption().GetGatewayId(),
+			Config:        downlinkConfig,
+			Retries:       retries,
 		},
+	})
+
+	return nil
+}
+
+func (h *handler) HandleTxAck(ack *pb_broker.TxAcknowledgment) error {
+	ctx := h.Ctx.WithFields(ttnlog.Fields{
+		"AppID":     ack.AppId,
+		"DevID":     ack.DevId,
+		"GatewayID": ack.GatewayId,
+	})
+
+	ack.Trace = ack.Trace.WithEvent(trace.ReceiveEvent)
+	correlationID := ack.Trace.GetCorrelationID()
+	if correlationID != "" {
+		ctx = ctx.WithField("CorrelationID", correlationID)
+	}
+
+	if ack.Error == "" {
+		ctx.Debug("Gateway transmitted downlink")
+		return nil
+	}
+
+	ctx.WithField("Error", ack.Error).Warn("Gateway could not transmit downlink")
+
+	// Downlink to multicast groups is not related to a single device
+	if ack.DevId == "" {
+		return nil
 	}
 
+	h.publishEvent(&types.DeviceEvent{
+		AppID: ack.AppId,
+		DevID: ack.DevId,
+		Event: types.DownlinkErrorEvent,
+		Data: types.DownlinkEventData{
+			ErrorEventData: types.ErrorEventData{Error: fmt.Sprintf("Gateway could not transmit downlink: %s", ack.Error)},
+			CorrelationID:  correlationID,
+			GatewayID:      ack.GatewayId,
+		},
+	})
+
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
@@ -23,7 +24,7 @@ func TestEnqueueDownlink(t *testing.T) {
 	h := &handler{
 		Component: &component.Component{Ctx: GetLogger(t, "TestEnqueueDownlink")},
 		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-enqueue-downlink"),
-		mqttEvent: make(chan *types.DeviceEvent, 10),
+		appEvent:  make(chan *types.DeviceEvent, 10),
 	}
 	err := h.EnqueueDownlink(&types.DownlinkMessage{
 		AppID: appID,
@@ -52,16 +53,24 @@ func TestEnqueueDownlink(t *testing.T) {
 	a.So(qLen, ShouldEqual, 1)
 	dev, _ = h.devices.Get(appID, devID)
 	a.So(dev.CurrentDownlink, ShouldNotBeNil)
+	evt := <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkScheduledEvent)
+	a.So(evt.Data.(types.DownlinkEventData).CorrelationID, ShouldNotBeEmpty)
 
 	err = h.EnqueueDownlink(&types.DownlinkMessage{
-		AppID:      appID,
-		DevID:      devID,
-		PayloadRaw: []byte{0x02},
-		Schedule:   "first",
+		AppID:         appID,
+		DevID:         devID,
+		PayloadRaw:    []byte{0x02},
+		Schedule:      "first",
+		CorrelationID: "correlation",
 	})
 	a.So(err, ShouldBeNil)
+	evt = <-h.appEvent
+	a.So(evt.Data.(types.DownlinkEventData).CorrelationID, ShouldEqual, "correlation")
 	qLen, _ = queue.Length()
 	a.So(qLen, ShouldEqual, 2)
+	list, _ := queue.List()
+	a.So(list[0].CorrelationID, ShouldEqual, "correlation")
 	dev, _ = h.devices.Get(appID, devID)
 	a.So(dev.CurrentDownlink, ShouldNotBeNil)
 
@@ -93,6 +102,70 @@ func TestEnqueueDownlink(t *testing.T) {
 	a.So(downlink.PayloadFields, ShouldHaveLength, 3)
 }
 
+func TestExpiringDownlink(t *testing.T) {
+	a := New(t)
+	appID := "app1"
+	devID := "dev1"
+	h := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestExpiringDownlink")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-expiring-downlink"),
+		appEvent:  make(chan *types.DeviceEvent, 10),
+	}
+	h.devices.Set(&device.Device{AppID: appID, DevID: devID})
+	defer func() {
+		h.devices.Delete(appID, devID)
+	}()
+	queue, _ := h.devices.DownlinkQueue(appID, devID)
+
+	err := h.EnqueueDownlink(&types.DownlinkMessage{
+		AppID:      appID,
+		DevID:      devID,
+		PayloadRaw: []byte{0x01},
+		TTL:        "invalid",
+	})
+	a.So(err, ShouldNotBeNil)
+	a.So((<-h.appEvent).Event, ShouldEqual, types.DownlinkErrorEvent)
+
+	err = h.EnqueueDownlink(&types.DownlinkMessage{
+		AppID:      appID,
+		DevID:      devID,
+		PayloadRaw: []byte{0x01},
+		TTL:        "10ms",
+	})
+	a.So(err, ShouldBeNil)
+	a.So((<-h.appEvent).Event, ShouldEqual, types.DownlinkScheduledEvent)
+
+	err = h.EnqueueDownlink(&types.DownlinkMessage{
+		AppID:      appID,
+		DevID:      devID,
+		PayloadRaw: []byte{0x02},
+		TTL:        "1h",
+		Schedule:   "last",
+	})
+	a.So(err, ShouldBeNil)
+	a.So((<-h.appEvent).Event, ShouldEqual, types.DownlinkScheduledEvent)
+
+	list, _ := queue.List()
+	a.So(list, ShouldHaveLength, 2)
+	a.So(list[0].TTL, ShouldBeEmpty)
+	a.So(list[0].Expires, ShouldNotBeNil)
+
+	<-time.After(20 * time.Millisecond)
+
+	next, err := h.nextDownlink(appID, devID, queue)
+	a.So(err, ShouldBeNil)
+	a.So(next, ShouldNotBeNil)
+	a.So(next.PayloadRaw, ShouldResemble, []byte{0x02})
+
+	evt := <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkExpiredEvent)
+	a.So(evt.Data.(types.DownlinkEventData).Message.PayloadRaw, ShouldResemble, []byte{0x01})
+
+	next, err = h.nextDownlink(appID, devID, queue)
+	a.So(err, ShouldBeNil)
+	a.So(next, ShouldBeNil)
+}
+
 func TestHandleDownlink(t *testing.T) {
 	a := New(t)
 	var err error
@@ -106,7 +179,7 @@ func TestHandleDownlink(t *testing.T) {
 		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-downlink"),
 		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-enqueue-downlink"),
 		downlink:     make(chan *pb_broker.DownlinkMessage),
-		mqttEvent:    make(chan *types.DeviceEvent, 10),
+		appEvent:     make(chan *types.DeviceEvent, 10),
 	}
 	h.InitStatus()
 	// Neither payload nor Fields provided : ERROR
@@ -141,12 +214,14 @@ func TestHandleDownlink(t *testing.T) {
 	go func() {
 		dl := <-h.downlink
 		a.So(dl.Payload, ShouldNotBeEmpty)
+		a.So(dl.Trace.GetCorrelationID(), ShouldEqual, "correlation")
 		wg.Done()
 	}()
 	err = h.HandleDownlink(&types.DownlinkMessage{
-		AppID:      appID,
-		DevID:      devID,
-		PayloadRaw: []byte{0xAA, 0xBC},
+		AppID:         appID,
+		DevID:         devID,
+		CorrelationID: "correlation",
+		PayloadRaw:    []byte{0xAA, 0xBC},
 	}, &pb_broker.DownlinkMessage{
 		AppEui:         &appEUI,
 		DevEui:         &devEUI,
@@ -200,4 +275,112 @@ func TestHandleDownlink(t *testing.T) {
 	})
 	a.So(err, ShouldBeNil)
 	wg.WaitFor(100 * time.Millisecond)
+
+	// A confirmed downlink that was sent before is a retransmission
+	confirmed := &types.DownlinkMessage{AppID: appID, DevID: devID, Confirmed: true, PayloadRaw: []byte{0xAA, 0xBC}}
+	h.devices.Set(&device.Device{
+		AppID:                 appID,
+		DevID:                 devID,
+		CurrentDownlink:       confirmed,
+		CurrentDownlinkSentAt: time.Now(),
+	})
+	wg.Add(1)
+	go func() {
+		dl := <-h.downlink
+		a.So(dl.Retransmission, ShouldBeTrue)
+		wg.Done()
+	}()
+	err = h.HandleDownlink(confirmed, &pb_broker.DownlinkMessage{
+		AppEui:         &appEUI,
+		DevEui:         &devEUI,
+		Payload:        []byte{96, 4, 3, 2, 1, 0, 1, 0, 1, 0, 0, 0, 0},
+		DownlinkOption: &pb_broker.DownlinkOption{},
+	})
+	a.So(err, ShouldBeNil)
+	wg.WaitFor(100 * time.Millisecond)
+
+	// A new confirmed downlink is not a retransmission
+	dev, _ := h.devices.Get(appID, devID)
+	dev.SetCurrentDownlink(confirmed)
+	h.devices.Set(dev)
+	wg.Add(1)
+	go func() {
+		dl := <-h.downlink
+		a.So(dl.Retransmission, ShouldBeFalse)
+		wg.Done()
+	}()
+	err = h.HandleDownlink(confirmed, &pb_broker.DownlinkMessage{
+		AppEui:         &appEUI,
+		DevEui:         &devEUI,
+		Payload:        []byte{96, 4, 3, 2, 1, 0, 1, 0, 1, 0, 0, 0, 0},
+		DownlinkOption: &pb_broker.DownlinkOption{},
+	})
+	a.So(err, ShouldBeNil)
+	wg.WaitFor(100 * time.Millisecond)
+}
+
+func TestHandleTxAck(t *testing.T) {
+	a := New(t)
+	h := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleTxAck")},
+		appEvent:  make(chan *types.DeviceEvent, 10),
+	}
+
+	// Successful transmissions do not result in an event
+	err := h.HandleTxAck(&pb_broker.TxAcknowledgment{AppId: "app1", DevId: "dev1", GatewayId: "gtw1"})
+	a.So(err, ShouldBeNil)
+	a.So(h.appEvent, ShouldBeEmpty)
+
+	err = h.HandleTxAck(&pb_broker.TxAcknowledgment{
+		AppId:     "app1",
+		DevId:     "dev1",
+		GatewayId: "gtw1",
+		Error:     "TOO_LATE",
+		Trace:     new(trace.Trace).WithEvent("process downlink", trace.CorrelationIDKey, "correlation-id"),
+	})
+	a.So(err, ShouldBeNil)
+	evt := <-h.appEvent
+	a.So(evt.Event, ShouldEqual, types.DownlinkErrorEvent)
+	a.So(evt.DevID, ShouldEqual, "dev1")
+	data := evt.Data.(types.DownlinkEventData)
+	a.So(data.Error, ShouldContainSubstring, "TOO_LATE")
+	a.So(data.CorrelationID, ShouldEqual, "correlation-id")
+	a.So(data.GatewayID, ShouldEqual, "gtw1")
+}
+
+func TestClearDownlinkQueue(t *testing.T) {
+	a := New(t)
+	appID := "app1"
+	devID := "dev1"
+	h := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestClearDownlinkQueue")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-clear-downlink-queue"),
+	}
+	h.devices.Set(&device.Device{
+		AppID:           appID,
+		DevID:           devID,
+		CurrentDownlink: &types.DownlinkMessage{FPort: 1, Confirmed: true, PayloadRaw: []byte{0x01}},
+	})
+	defer func() {
+		h.devices.Delete(appID, devID)
+	}()
+	queue, _ := h.devices.DownlinkQueue(appID, devID)
+	queue.PushLast(&types.DownlinkMessage{FPort: 2, PayloadRaw: []byte{0x02}})
+
+	// The confirmed downlink that waits for an acknowledgment is listed separately
+	current, queued, err := h.getDownlinkQueue(appID, devID)
+	a.So(err, ShouldBeNil)
+	a.So(current, ShouldNotBeNil)
+	a.So(current.PayloadRaw, ShouldResemble, []byte{0x01})
+	a.So(queued, ShouldHaveLength, 1)
+	a.So(queued[0].PayloadRaw, ShouldResemble, []byte{0x02})
+
+	// Clearing the queue also clears the confirmed downlink
+	a.So(h.clearDownlinkQueue(appID, devID), ShouldBeNil)
+	current, queued, err = h.getDownlinkQueue(appID, devID)
+	a.So(err, ShouldBeNil)
+	a.So(current, ShouldBeNil)
+	a.So(queued, ShouldBeEmpty)
+	dev, _ := h.devices.Get(appID, devID)
+	a.So(dev.CurrentDownlink, ShouldBeNil)
 }
@@ -7,36 +7,77 @@ import (
 	"encoding/json"
 
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/functions"
+	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 )
 
+// usesPayloadFunctions returns true if the payload format uses the payload functions of the application
+func usesPayloadFunctions(format string) bool {
+	return format == "" || format == application.PayloadFormatCustom
+}
+
 // DryUplink converts the uplink message payload by running the payload
 // functions that are provided in the DryUplinkMessage, without actually going to the network.
 // This is helpful for testing the payload functions without having to save them.
 func (h *handlerManager) DryUplink(ctx context.Context, in *pb.DryUplinkMessage) (*pb.DryUplinkResult, error) {
 	app := in.App
+	if app == nil {
+		app = &pb.Application{}
+	}
+	devFunctions := devicePayloadFunctions(in.DevicePayloadFunctions)
 
 	logger := functions.NewEntryLogger()
 
 	flds := ""
 	valid := true
-	if app != nil && app.Decoder != "" {
-		functions := &UplinkFunctions{
+	down := ""
+	if app.Decoder != "" || !usesPayloadFunctions(app.PayloadFormat) || (devFunctions != nil && devFunctions.Decoder != "") {
+		var uplink map[string]interface{}
+		if app.ExposeMetadata {
+			var err error
+			uplink, err = uplinkMetadata(&types.UplinkMessage{
+				AppID: app.AppId,
+				FPort: uint8(in.Port),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		processor, err := uplinkPayloadProcessor(app.PayloadFormat, &UplinkFunctions{
 			Decoder:   app.Decoder,
 			Converter: app.Converter,
 			Validator: app.Validator,
+			Uplink:    uplink,
 			Logger:    logger,
+		}, devFunctions)
+		if err != nil {
+			return nil, err
 		}
 
-		fields, val, err := functions.Process(in.Payload, uint8(in.Port))
+		fields, val, err := processor.Process(in.Payload, uint8(in.Port))
 		if err != nil {
 			return nil, err
 		}
 
 		valid = val
 
+		// The downlink is only reported, not enqueued
+		downlink, err := extractDownlink(app.AppId, "", fields)
+		if err != nil {
+			return nil, err
+		}
+		if downlink != nil {
+			marshalled, err := json.Marshal(downlink)
+			if err != nil {
+				return nil, err
+			}
+			down = string(marshalled)
+		}
+
 		marshalled, err := json.Marshal(fields)
 		if err != nil {
 			return nil, err
@@ -46,10 +87,11 @@ func (h *handlerManager) DryUplink(ctx context.Context, in *pb.DryUplinkMessage)
 	}
 
 	return &pb.DryUplinkResult{
-		Payload: in.Payload,
-		Fields:  flds,
-		Valid:   valid,
-		Logs:    logger.Logs,
+		Payload:  in.Payload,
+		Fields:   flds,
+		Valid:    valid,
+		Logs:     logger.Logs,
+		Downlink: down,
 	}, nil
 }
 
@@ -72,24 +114,32 @@ func (h *handlerManager) DryDownlink(ctx context.Context, in *pb.DryDownlinkMess
 		return nil, errors.NewErrInvalidArgument("Downlink", "Neither Fields nor Payload provided")
 	}
 
-	if app == nil || app.Encoder == "" {
+	if app == nil {
+		app = &pb.Application{}
+	}
+	devFunctions := devicePayloadFunctions(in.DevicePayloadFunctions)
+
+	if app.Encoder == "" && usesPayloadFunctions(app.PayloadFormat) && (devFunctions == nil || devFunctions.Encoder == "") {
 		return nil, errors.NewErrInvalidArgument("Encoder", "Not specified")
 	}
 
 	logger := functions.NewEntryLogger()
 
-	functions := &DownlinkFunctions{
+	processor, err := downlinkPayloadProcessor(app.PayloadFormat, &DownlinkFunctions{
 		Encoder: app.Encoder,
 		Logger:  logger,
+	}, devFunctions)
+	if err != nil {
+		return nil, err
 	}
 
 	var parsed map[string]interface{}
-	err := json.Unmarshal([]byte(in.Fields), &parsed)
+	err = json.Unmarshal([]byte(in.Fields), &parsed)
 	if err != nil {
 		return nil, errors.NewErrInvalidArgument("Fields", err.Error())
 	}
 
-	payload, _, err := functions.Process(parsed, uint8(in.Port))
+	payload, _, err := processor.Process(parsed, uint8(in.Port))
 	if err != nil {
 		return nil, err
 	}
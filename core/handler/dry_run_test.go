@@ -136,6 +136,110 @@ func TestDryUplinkEmptyApp(t *testing.T) {
 	a.So(store.Count("delete"), ShouldEqual, 0)
 }
 
+func TestDryUplinkCayenneLPP(t *testing.T) {
+	a := New(t)
+
+	store := newCountingStore(application.NewRedisApplicationStore(GetRedisClient(), "handler-test-dry-uplink"))
+	h := &handler{
+		applications: store,
+	}
+	m := &handlerManager{handler: h}
+
+	dryUplinkMessage := &pb.DryUplinkMessage{
+		Payload: []byte{0x01, 0x67, 0x01, 0x10},
+		App: &pb.Application{
+			AppId:         "DryUplinkCayenneLPP",
+			PayloadFormat: "cayennelpp",
+		},
+	}
+
+	res, err := m.DryUplink(context.TODO(), dryUplinkMessage)
+	a.So(err, ShouldBeNil)
+
+	a.So(res.Payload, ShouldResemble, dryUplinkMessage.Payload)
+	a.So(res.Fields, ShouldEqual, `{"temperature_1":27.2}`)
+	a.So(res.Valid, ShouldBeTrue)
+	a.So(res.Logs, ShouldBeEmpty)
+
+	dryUplinkMessage.App.PayloadFormat = "unknown"
+	_, err = m.DryUplink(context.TODO(), dryUplinkMessage)
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestDryUplinkDeviceFunctions(t *testing.T) {
+	a := New(t)
+
+	store := newCountingStore(application.NewRedisApplicationStore(GetRedisClient(), "handler-test-dry-uplink"))
+	h := &handler{
+		applications: store,
+	}
+	m := &handlerManager{handler: h}
+
+	dryUplinkMessage := &pb.DryUplinkMessage{
+		Payload: []byte{11, 22, 33},
+		App: &pb.Application{
+			AppId:   "DryUplinkDeviceFunctions",
+			Decoder: `function Decoder (bytes) { return { application: true }}`,
+		},
+		DevicePayloadFunctions: &pb.PayloadFunctions{
+			Decoder: `function Decoder (bytes) {
+				console.log("device")
+				return { length: bytes.length }}`,
+		},
+	}
+
+	res, err := m.DryUplink(context.TODO(), dryUplinkMessage)
+	a.So(err, ShouldBeNil)
+
+	a.So(res.Fields, ShouldEqual, `{"length":3}`)
+	a.So(res.Valid, ShouldBeTrue)
+	a.So(res.Logs, ShouldResemble, []*pb.LogEntry{
+		&pb.LogEntry{
+			Function: "Decoder",
+			Fields:   []string{`"device"`},
+		},
+	})
+
+	// Without Application
+	dryUplinkMessage.App = nil
+	res, err = m.DryUplink(context.TODO(), dryUplinkMessage)
+	a.So(err, ShouldBeNil)
+	a.So(res.Fields, ShouldEqual, `{"length":3}`)
+}
+
+func TestDryUplinkDownlink(t *testing.T) {
+	a := New(t)
+
+	store := newCountingStore(application.NewRedisApplicationStore(GetRedisClient(), "handler-test-dry-uplink"))
+	h := &handler{
+		applications: store,
+	}
+	m := &handlerManager{handler: h}
+
+	dryUplinkMessage := &pb.DryUplinkMessage{
+		Payload: []byte{11, 22, 33},
+		Port:    5,
+		App: &pb.Application{
+			AppId:          "DryUplinkDownlink",
+			ExposeMetadata: true,
+			Decoder: `function Decoder (bytes, port, uplink) {
+				return { port: uplink.port, _downlink: { port: port, payload_raw: [ bytes[0] ] } }}`,
+		},
+	}
+
+	res, err := m.DryUplink(context.TODO(), dryUplinkMessage)
+	a.So(err, ShouldBeNil)
+
+	a.So(res.Fields, ShouldEqual, `{"port":5}`)
+	a.So(res.Downlink, ShouldEqual, `{"app_id":"DryUplinkDownlink","port":5,"payload_raw":"Cw=="}`)
+
+	// make sure no calls to app store were made
+	a.So(store.Count("list"), ShouldEqual, 0)
+	a.So(store.Count("get"), ShouldEqual, 0)
+	a.So(store.Count("set"), ShouldEqual, 0)
+	a.So(store.Count("delete"), ShouldEqual, 0)
+}
+
 func TestDryDownlinkFields(t *testing.T) {
 	a := New(t)
 
@@ -174,6 +278,29 @@ func TestDryDownlinkFields(t *testing.T) {
 	a.So(store.Count("delete"), ShouldEqual, 0)
 }
 
+func TestDryDownlinkCayenneLPP(t *testing.T) {
+	a := New(t)
+
+	store := newCountingStore(application.NewRedisApplicationStore(GetRedisClient(), "handler-test-dry-downlink"))
+	h := &handler{
+		applications: store,
+	}
+	m := &handlerManager{handler: h}
+
+	msg := &pb.DryDownlinkMessage{
+		Fields: `{ "value_1": -1 }`,
+		App: &pb.Application{
+			PayloadFormat: "cayennelpp",
+		},
+	}
+
+	res, err := m.DryDownlink(context.TODO(), msg)
+	a.So(err, ShouldBeNil)
+
+	a.So(res.Payload, ShouldResemble, []byte{0x01, 0xff, 0x9c})
+	a.So(res.Logs, ShouldBeEmpty)
+}
+
 func TestDryDownlinkPayload(t *testing.T) {
 	a := New(t)
 
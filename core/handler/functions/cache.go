@@ -0,0 +1,236 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/robertkrimen/otto"
+)
+
+// Quota limits the resources that are used for the payload functions of an application. Otto can not limit the memory
+// that a VM allocates, so there is no memory quota: MaxCodeSize only limits the size of the source code.
+type Quota struct {
+	// Timeout is the maximum time that a single payload function is allowed to run
+	Timeout time.Duration
+	// MaxCodeSize is the maximum total size (in bytes) of the compiled code that is cached for the application
+	MaxCodeSize int
+	// MaxVMs is the maximum number of idle VMs that are kept for the application
+	MaxVMs int
+}
+
+// DefaultQuota is used for applications that do not have a quota of their own
+var DefaultQuota = Quota{
+	Timeout:     100 * time.Millisecond,
+	MaxCodeSize: 256 * 1024,
+	MaxVMs:      4,
+}
+
+// Cache runs payload functions in pooled VMs, using scripts that are compiled once
+// and cached by application and hash of the code.
+type Cache struct {
+	mu     sync.Mutex
+	quotas map[string]Quota
+	apps   map[string]*appCache
+}
+
+type appCache struct {
+	mu       sync.Mutex
+	scripts  map[[sha256.Size]byte]*otto.Script
+	codeSize int
+	vms      chan *vm
+}
+
+// vm is an otto VM that sends console.log calls to the logger of the current run
+type vm struct {
+	otto   *otto.Otto
+	logger Logger
+	reset  otto.Value
+}
+
+// resetGlobals returns a function that removes the globals that were added after it was created. Globals that can not
+// be deleted, such as the ones that are declared with var, are set to undefined.
+const resetGlobals = `(function (global) {
+	var getNames = Object.getOwnPropertyNames;
+	var keep = Object.create(null);
+	getNames(global).forEach(function (name) { keep[name] = true; });
+	return function () {
+		var names = getNames(global);
+		for (var i = 0; i < names.length; i++) {
+			if (keep[names[i]] !== true) {
+				if (!delete global[names[i]]) {
+					global[names[i]] = undefined;
+				}
+			}
+		}
+	};
+})(this)`
+
+func newVM() *vm {
+	v := &vm{otto: otto.New(), logger: Ignore}
+	v.otto.Set("__log", func(call otto.FunctionCall) otto.Value {
+		v.logger.Log(call)
+		return otto.UndefinedValue()
+	})
+	v.otto.Run("console.log = __log")
+	v.reset, _ = v.otto.Run(resetGlobals)
+	return v
+}
+
+// NewCache returns a new, empty Cache
+func NewCache() *Cache {
+	return &Cache{
+		quotas: make(map[string]Quota),
+		apps:   make(map[string]*appCache),
+	}
+}
+
+// ParseQuota parses the quota of an application from the format <app-id>:<timeout>:<max-code-size>:<max-vms>,
+// for example my-app:200ms:65536:2
+func ParseQuota(str string) (appID string, quota Quota, err error) {
+	parts := strings.Split(str, ":")
+	if len(parts) != 4 || parts[0] == "" {
+		return "", quota, errors.NewErrInvalidArgument("Quota", fmt.Sprintf("%s is not in the format <app-id>:<timeout>:<max-code-size>:<max-vms>", str))
+	}
+	appID = parts[0]
+	if quota.Timeout, err = time.ParseDuration(parts[1]); err != nil {
+		return "", quota, errors.NewErrInvalidArgument("Quota", fmt.Sprintf("invalid timeout %s", parts[1]))
+	}
+	if quota.MaxCodeSize, err = strconv.Atoi(parts[2]); err != nil {
+		return "", quota, errors.NewErrInvalidArgument("Quota", fmt.Sprintf("invalid max code size %s", parts[2]))
+	}
+	if quota.MaxVMs, err = strconv.Atoi(parts[3]); err != nil {
+		return "", quota, errors.NewErrInvalidArgument("Quota", fmt.Sprintf("invalid max VMs %s", parts[3]))
+	}
+	return appID, quota, nil
+}
+
+// SetQuota sets the quota of the application, it replaces DefaultQuota for that application
+func (c *Cache) SetQuota(appID string, quota Quota) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.quotas[appID] = quota
+	delete(c.apps, appID)
+}
+
+// Quota returns the quota of the application
+func (c *Cache) Quota(appID string) Quota {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if quota, ok := c.quotas[appID]; ok {
+		return quota
+	}
+	return DefaultQuota
+}
+
+// Invalidate removes the compiled scripts and idle VMs of the application, it does nothing on a nil Cache
+func (c *Cache) Invalidate(appID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.apps, appID)
+}
+
+func (c *Cache) getApp(appID string) (*appCache, Quota) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	quota, ok := c.quotas[appID]
+	if !ok {
+		quota = DefaultQuota
+	}
+	app, ok := c.apps[appID]
+	if !ok {
+		app = &appCache{
+			scripts: make(map[[sha256.Size]byte]*otto.Script),
+			vms:     make(chan *vm, quota.MaxVMs),
+		}
+		c.apps[appID] = app
+	}
+	return app, quota
+}
+
+// script returns the compiled code, compiling it if it is not in the cache yet. If the
+// cached code would exceed the quota, the other scripts of the application are evicted.
+func (a *appCache) script(name, code string, quota Quota) (*otto.Script, error) {
+	if quota.MaxCodeSize > 0 && len(code) > quota.MaxCodeSize {
+		return nil, errors.NewErrInvalidArgument(name, fmt.Sprintf("code size exceeds the quota of %d bytes", quota.MaxCodeSize))
+	}
+
+	hash := sha256.Sum256([]byte(code))
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if script, ok := a.scripts[hash]; ok {
+		return script, nil
+	}
+
+	script, err := otto.New().Compile(name, code)
+	if err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
+	}
+
+	if quota.MaxCodeSize > 0 && a.codeSize+len(code) > quota.MaxCodeSize {
+		a.scripts = make(map[[sha256.Size]byte]*otto.Script)
+		a.codeSize = 0
+	}
+	a.scripts[hash] = script
+	a.codeSize += len(code)
+
+	return script, nil
+}
+
+func (a *appCache) getVM() *vm {
+	select {
+	case v := <-a.vms:
+		return v
+	default:
+		return newVM()
+	}
+}
+
+// putVM returns the VM to the pool after it removes the globals of the last run, such as the environment
+func (a *appCache) putVM(v *vm) {
+	v.logger = Ignore
+	if _, err := v.reset.Call(otto.UndefinedValue()); err != nil {
+		return
+	}
+	select {
+	case a.vms <- v:
+	default:
+	}
+}
+
+// Run runs the code of the application with the given environment. The code is compiled only
+// once and runs in a VM that is reused for the same application. The returned value belongs to
+// that VM, so it can only be used until release is called, which returns the VM to the pool.
+func (c *Cache) Run(appID, name, code string, env map[string]interface{}, logger Logger) (val otto.Value, release func(), err error) {
+	app, quota := c.getApp(appID)
+
+	script, err := app.script(name, code, quota)
+	if err != nil {
+		return otto.Value{}, func() {}, err
+	}
+
+	if logger == nil {
+		logger = Ignore
+	}
+
+	v := app.getVM()
+	v.logger = logger
+
+	val, reusable, err := run(v.otto, name, script, env, quota.Timeout, logger)
+	if !reusable {
+		return val, func() {}, err
+	}
+	return val, func() { app.putVM(v) }, err
+}
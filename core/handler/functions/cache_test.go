@@ -0,0 +1,191 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/robertkrimen/otto"
+
+	. "github.com/smartystreets/assertions"
+)
+
+const cacheTestCode = `
+	function Decoder (bytes, port) {
+		console.log("decoding", port)
+		return { length: bytes.length };
+	}
+	Decoder(payload, port)
+`
+
+func TestCacheRun(t *testing.T) {
+	a := New(t)
+
+	c := NewCache()
+
+	for i := 0; i < 3; i++ {
+		logger := NewEntryLogger()
+		val, release, err := c.Run("app", "Decoder", cacheTestCode, map[string]interface{}{
+			"payload": []byte{1, 2, 3},
+			"port":    i,
+		}, logger)
+		a.So(err, ShouldBeNil)
+		e, _ := val.Export()
+		release()
+		a.So(e, ShouldResemble, map[string]interface{}{"length": 3})
+		a.So(logger.Logs, ShouldHaveLength, 1)
+		a.So(logger.Logs[0].Fields[0], ShouldEqual, `"decoding"`)
+	}
+
+	app, _ := c.getApp("app")
+	a.So(app.scripts, ShouldHaveLength, 1)
+	a.So(app.vms, ShouldHaveLength, 1)
+
+	// Other code for the same application
+	_, release, err := c.Run("app", "Converter", "1 + 1", nil, nil)
+	a.So(err, ShouldBeNil)
+	release()
+	a.So(app.scripts, ShouldHaveLength, 2)
+
+	// Invalid code
+	_, _, err = c.Run("app", "Validator", "derp(", nil, nil)
+	a.So(err, ShouldNotBeNil)
+
+	// Thrown error
+	_, release, err = c.Run("app", "Validator", `throw new Error("expected")`, nil, nil)
+	a.So(err, ShouldNotBeNil)
+	release()
+}
+
+func TestCacheGlobals(t *testing.T) {
+	a := New(t)
+
+	c := NewCache()
+	c.SetQuota("app", Quota{Timeout: time.Second, MaxVMs: 1})
+
+	_, release, err := c.Run("app", "Decoder", "var leak = secret; implicit = 2", map[string]interface{}{"secret": "device-1"}, nil)
+	a.So(err, ShouldBeNil)
+	release()
+
+	// Globals and the environment of earlier runs are not visible in the reused VM
+	val, release, err := c.Run("app", "Decoder", "[typeof secret, typeof leak, typeof implicit, typeof console.log].join()", nil, nil)
+	a.So(err, ShouldBeNil)
+	types, _ := val.ToString()
+	release()
+	a.So(types, ShouldEqual, "undefined,undefined,undefined,function")
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	a := New(t)
+
+	c := NewCache()
+
+	_, release, err := c.Run("app", "test", "1 + 1", nil, nil)
+	a.So(err, ShouldBeNil)
+	release()
+
+	c.Invalidate("app")
+	a.So(c.apps, ShouldNotContainKey, "app")
+
+	var nilCache *Cache
+	nilCache.Invalidate("app")
+}
+
+func TestCacheQuota(t *testing.T) {
+	a := New(t)
+
+	c := NewCache()
+	c.SetQuota("app", Quota{
+		Timeout:     50 * time.Millisecond,
+		MaxCodeSize: 32,
+		MaxVMs:      1,
+	})
+	a.So(c.Quota("app").MaxCodeSize, ShouldEqual, 32)
+	a.So(c.Quota("other"), ShouldResemble, DefaultQuota)
+
+	// Time quota
+	start := time.Now()
+	_, release, err := c.Run("app", "test", "while (true) {}", nil, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(time.Since(start), ShouldBeLessThan, time.Second)
+	release()
+
+	// The interrupted VM is not reused
+	app, _ := c.getApp("app")
+	a.So(app.vms, ShouldBeEmpty)
+
+	// Code size quota
+	_, _, err = c.Run("app", "test", strings.Repeat(" ", 33), nil, nil)
+	a.So(err, ShouldNotBeNil)
+
+	// Scripts are evicted when the quota is exceeded
+	for _, code := range []string{"1 + 1 ", "2 + 2 ", "3 + 3 ", "4 + 4 ", "5 + 5 ", "6 + 6 "} {
+		_, release, err := c.Run("app", "test", code, nil, nil)
+		a.So(err, ShouldBeNil)
+		release()
+	}
+	a.So(app.codeSize, ShouldBeLessThanOrEqualTo, 32)
+}
+
+func TestParseQuota(t *testing.T) {
+	a := New(t)
+
+	appID, quota, err := ParseQuota("my-app:200ms:65536:2")
+	a.So(err, ShouldBeNil)
+	a.So(appID, ShouldEqual, "my-app")
+	a.So(quota, ShouldResemble, Quota{Timeout: 200 * time.Millisecond, MaxCodeSize: 65536, MaxVMs: 2})
+
+	for _, str := range []string{"", "my-app", ":200ms:65536:2", "my-app:200:65536:2", "my-app:200ms:big:2", "my-app:200ms:65536:many"} {
+		_, _, err = ParseQuota(str)
+		a.So(err, ShouldNotBeNil)
+	}
+}
+
+func TestJSONIn(t *testing.T) {
+	a := New(t)
+
+	logger := NewEntryLogger()
+	c := NewCache()
+	_, release, err := c.Run("app", "test", `console.log({ foo: [1, 2] }, "bar", 42)`, nil, logger)
+	a.So(err, ShouldBeNil)
+	release()
+	a.So(logger.Logs, ShouldResemble, []*pb_handler.LogEntry{
+		&pb_handler.LogEntry{
+			Function: "test",
+			Fields:   []string{`{"foo":[1,2]}`, `"bar"`, "42"},
+		},
+	})
+}
+
+var benchmarkEnv = map[string]interface{}{
+	"payload": []byte{1, 2, 3, 4, 5, 6, 7, 8},
+	"port":    1,
+}
+
+func BenchmarkRunCode(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		RunCode("Decoder", cacheTestCode, benchmarkEnv, time.Second, NewEntryLogger())
+	}
+}
+
+func BenchmarkCacheRun(b *testing.B) {
+	c := NewCache()
+	for n := 0; n < b.N; n++ {
+		_, release, _ := c.Run("app", "Decoder", cacheTestCode, benchmarkEnv, NewEntryLogger())
+		release()
+	}
+}
+
+func BenchmarkJSONIn(b *testing.B) {
+	vm := otto.New()
+	v, _ := vm.ToValue("foo")
+	var r string
+	for n := 0; n < b.N; n++ {
+		r = jsonIn(vm, v)
+	}
+	result = r
+}
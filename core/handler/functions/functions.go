@@ -16,15 +16,9 @@ var errTimeOutExceeded = errors.NewErrInternal("Code has been running to long")
 func RunCode(name, code string, env map[string]interface{}, timeout time.Duration, logger Logger) (val otto.Value, err error) {
 	vm := otto.New()
 
-	// load the environment
-	for key, val := range env {
-		vm.Set(key, val)
-	}
-
 	if logger == nil {
 		logger = Ignore
 	}
-	logger.Enter(name)
 
 	vm.Set("__log", func(call otto.FunctionCall) otto.Value {
 		logger.Log(call)
@@ -32,12 +26,36 @@ func RunCode(name, code string, env map[string]interface{}, timeout time.Duratio
 	})
 	vm.Run("console.log = __log")
 
+	val, _, err = run(vm, name, code, env, timeout, logger)
+	return val, err
+}
+
+// run runs the code (a string or a compiled *otto.Script) in the VM. If the execution is
+// interrupted, the VM should not be reused, which is indicated by reusable.
+func run(vm *otto.Otto, name string, src interface{}, env map[string]interface{}, timeout time.Duration, logger Logger) (val otto.Value, reusable bool, err error) {
+	// load the environment
+	for key, val := range env {
+		vm.Set(key, val)
+	}
+
+	logger.Enter(name)
+
 	start := time.Now()
 
+	vm.Interrupt = make(chan func(), 1)
+
+	timer := time.AfterFunc(timeout, func() {
+		vm.Interrupt <- func() {
+			panic(errTimeOutExceeded)
+		}
+	})
+
 	defer func() {
 		duration := time.Since(start)
 		if caught := recover(); caught != nil {
+			timer.Stop()
 			val = otto.Value{}
+			reusable = false
 			if caught == errTimeOutExceeded {
 				err = errors.NewErrInternal(fmt.Sprintf("Interrupted javascript execution for %s after %v", name, duration))
 				return
@@ -47,19 +65,14 @@ func RunCode(name, code string, env map[string]interface{}, timeout time.Duratio
 		}
 	}()
 
-	vm.Interrupt = make(chan func(), 1)
+	val, err = vm.Run(src)
 
-	go func() {
-		time.Sleep(timeout)
-		vm.Interrupt <- func() {
-			panic(errTimeOutExceeded)
-		}
-	}()
+	// If the timer already fired, an interrupt may still be pending
+	reusable = timer.Stop()
 
-	val, err = vm.Run(code)
 	if err != nil {
-		return val, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
+		return val, reusable, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
 	}
 
-	return val, nil
+	return val, reusable, nil
 }
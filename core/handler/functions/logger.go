@@ -40,10 +40,27 @@ func JSON(val otto.Value) string {
 	return res.String()
 }
 
+// jsonIn stringifies a value inside of the vm that it belongs to, which is much
+// faster than JSON, because it does not create a new vm
+func jsonIn(vm *otto.Otto, val otto.Value) string {
+	if vm == nil {
+		return JSON(val)
+	}
+	json, err := vm.Get("JSON")
+	if err != nil || !json.IsObject() {
+		return JSON(val)
+	}
+	res, err := json.Object().Call("stringify", val)
+	if err != nil {
+		return JSON(val)
+	}
+	return res.String()
+}
+
 func (c *EntryLogger) Log(call otto.FunctionCall) {
 	fields := []string{}
 	for _, field := range call.ArgumentList {
-		fields = append(fields, JSON(field))
+		fields = append(fields, jsonIn(call.Otto, field))
 	}
 
 	c.Logs = append(c.Logs, &pb_handler.LogEntry{
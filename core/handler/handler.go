@@ -4,17 +4,17 @@
 package handler
 
 import (
-	"fmt"
+	"net/http"
 
-	"github.com/TheThingsNetwork/ttn/amqp"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
 	pb_monitor "github.com/TheThingsNetwork/ttn/api/monitor"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/handler/functions"
+	"github.com/TheThingsNetwork/ttn/core/handler/multicast"
 	"github.com/TheThingsNetwork/ttn/core/types"
-	"github.com/TheThingsNetwork/ttn/mqtt"
 	"google.golang.org/grpc"
 	"gopkg.in/redis.v5"
 )
@@ -26,20 +26,37 @@ type Handler interface {
 
 	WithMQTT(username, password string, brokers ...string) Handler
 	WithAMQP(username, password, host, exchange string) Handler
+	WithHTTP(downlinkBaseURL string) Handler
+	WithIntegration(integration Integration) Handler
+	WithFunctionQuota(appID string, quota functions.Quota) Handler
 
 	HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) error
 	HandleActivationChallenge(challenge *pb_broker.ActivationChallengeRequest) (*pb_broker.ActivationChallengeResponse, error)
 	HandleActivation(activation *pb_broker.DeduplicatedDeviceActivationRequest) (*pb.DeviceActivationResponse, error)
+	HandleTxAck(ack *pb_broker.TxAcknowledgment) error
 	EnqueueDownlink(appDownlink *types.DownlinkMessage) error
+
+	HTTPDownlinkHandler(next http.Handler) http.Handler
 }
 
 // NewRedisHandler creates a new Redis-backed Handler
 func NewRedisHandler(client *redis.Client, ttnBrokerID string) Handler {
 	return &handler{
-		devices:      device.NewRedisDeviceStore(client, "handler"),
-		applications: application.NewRedisApplicationStore(client, "handler"),
-		ttnBrokerID:  ttnBrokerID,
+		devices:       device.NewRedisDeviceStore(client, "handler"),
+		applications:  application.NewRedisApplicationStore(client, "handler"),
+		multicast:     multicast.NewRedisGroupStore(client, "handler"),
+		functionCache: functions.NewCache(),
+		ttnBrokerID:   ttnBrokerID,
+	}
+}
+
+// WithFunctionQuota sets the quota of the payload functions of the application, replacing functions.DefaultQuota
+func (h *handler) WithFunctionQuota(appID string, quota functions.Quota) Handler {
+	if h.functionCache == nil {
+		h.functionCache = functions.NewCache()
 	}
+	h.functionCache.SetQuota(appID, quota)
+	return h
 }
 
 type handler struct {
@@ -47,6 +64,10 @@ type handler struct {
 
 	devices      device.Store
 	applications application.Store
+	multicast    multicast.Store
+
+	// functionCache contains the compiled payload functions, if it is nil, payload functions are compiled for every message
+	functionCache *functions.Cache
 
 	ttnBrokerID      string
 	ttnBrokerConn    *grpc.ClientConn
@@ -55,21 +76,9 @@ type handler struct {
 
 	downlink chan *pb_broker.DownlinkMessage
 
-	mqttClient   mqtt.Client
-	mqttUsername string
-	mqttPassword string
-	mqttBrokers  []string
-	mqttEnabled  bool
-	mqttUp       chan *types.UplinkMessage
-	mqttEvent    chan *types.DeviceEvent
-
-	amqpClient   amqp.Client
-	amqpUsername string
-	amqpPassword string
-	amqpHost     string
-	amqpExchange string
-	amqpEnabled  bool
-	amqpUp       chan *types.UplinkMessage
+	integrations []*integration
+	appUp        chan *types.UplinkMessage
+	appEvent     chan *types.DeviceEvent
 
 	status        *status
 	monitorStream pb_monitor.GenericStream
@@ -80,23 +89,6 @@ var (
 	AMQPDownlinkQueue = "ttn-handler-downlink"
 )
 
-func (h *handler) WithMQTT(username, password string, brokers ...string) Handler {
-	h.mqttUsername = username
-	h.mqttPassword = password
-	h.mqttBrokers = brokers
-	h.mqttEnabled = true
-	return h
-}
-
-func (h *handler) WithAMQP(username, password, host, exchange string) Handler {
-	h.amqpUsername = username
-	h.amqpPassword = password
-	h.amqpHost = host
-	h.amqpExchange = exchange
-	h.amqpEnabled = true
-	return h
-}
-
 func (h *handler) Init(c *component.Component) error {
 	h.Component = c
 	h.InitStatus()
@@ -110,22 +102,9 @@ func (h *handler) Init(c *component.Component) error {
 		return err
 	}
 
-	if h.mqttEnabled {
-		var brokers []string
-		for _, broker := range h.mqttBrokers {
-			brokers = append(brokers, fmt.Sprintf("tcp://%s", broker))
-		}
-		err = h.HandleMQTT(h.mqttUsername, h.mqttPassword, brokers...)
-		if err != nil {
-			return err
-		}
-	}
-
-	if h.amqpEnabled {
-		err = h.HandleAMQP(h.amqpUsername, h.amqpPassword, h.amqpHost, h.amqpExchange, AMQPDownlinkQueue)
-		if err != nil {
-			return err
-		}
+	err = h.openIntegrations()
+	if err != nil {
+		return err
 	}
 
 	err = h.associateBroker()
@@ -142,12 +121,7 @@ func (h *handler) Init(c *component.Component) error {
 }
 
 func (h *handler) Shutdown() {
-	if h.mqttEnabled {
-		h.mqttClient.Disconnect()
-	}
-	if h.amqpEnabled {
-		h.amqpClient.Disconnect()
-	}
+	h.closeIntegrations()
 }
 
 func (h *handler) associateBroker() error {
@@ -0,0 +1,223 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/backoff"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// HTTPTimeout indicates how long we should wait for an HTTP request
+var HTTPTimeout = 5 * time.Second
+
+// HTTPRetries indicates how many times a failed HTTP request is retried
+var HTTPRetries = 3
+
+// HTTPBackoff is the backoff configuration for retrying HTTP requests
+var HTTPBackoff = backoff.Config{
+	MaxDelay:  10 * time.Second,
+	BaseDelay: 200 * time.Millisecond,
+	Factor:    1.6,
+	Jitter:    0.2,
+}
+
+// HTTPDownlinkPath is the path where the HTTP integration accepts downlink messages
+const HTTPDownlinkPath = "/downlink/"
+
+// HTTPMaxDownlinkSize is the maximum size of the body of a downlink message that is posted to the HTTP integration
+var HTTPMaxDownlinkSize int64 = 64 * 1024
+
+// HTTPIntegrationName is the name of the HTTP integration
+const HTTPIntegrationName = "http"
+
+// NewHTTPIntegration returns a new integration that posts messages to the URLs that are configured in the
+// application. The downlinkBaseURL is used to build the downlink URLs that are included in uplink messages.
+func NewHTTPIntegration(downlinkBaseURL string) Integration {
+	return &httpIntegration{
+		client:          &http.Client{Timeout: HTTPTimeout},
+		downlinkBaseURL: strings.TrimSuffix(downlinkBaseURL, "/"),
+	}
+}
+
+type httpIntegration struct {
+	client          *http.Client
+	downlinkBaseURL string
+	handler         IntegrationHandler
+	ctx             ttnlog.Interface
+}
+
+func (h *handler) WithHTTP(downlinkBaseURL string) Handler {
+	return h.WithIntegration(NewHTTPIntegration(downlinkBaseURL))
+}
+
+func (i *httpIntegration) Name() string {
+	return HTTPIntegrationName
+}
+
+func (i *httpIntegration) Open(handler IntegrationHandler) error {
+	i.handler = handler
+	i.ctx = handler.Ctx().WithField("Protocol", "HTTP")
+	return nil
+}
+
+func (i *httpIntegration) Close() {}
+
+func (i *httpIntegration) HandleUplink(app *application.Application, up *types.UplinkMessage) error {
+	if app == nil || app.HTTPIntegration == nil || app.HTTPIntegration.UplinkURL == "" {
+		return nil
+	}
+	msg := *up
+	msg.DownlinkURL = i.downlinkURL(app.HTTPIntegration, up.AppID, up.DevID)
+	go i.post(i.ctx.WithFields(ttnlog.Fields{
+		"DevID": up.DevID,
+		"AppID": up.AppID,
+	}), app.HTTPIntegration.UplinkURL, app.HTTPIntegration.Headers, msg)
+	return nil
+}
+
+func (i *httpIntegration) HandleActivation(app *application.Application, event *types.DeviceEvent) error {
+	if app == nil || app.HTTPIntegration == nil || app.HTTPIntegration.ActivationURL == "" {
+		return nil
+	}
+	go i.post(i.ctx.WithFields(ttnlog.Fields{
+		"DevID": event.DevID,
+		"AppID": event.AppID,
+		"Event": event.Event,
+	}), app.HTTPIntegration.ActivationURL, app.HTTPIntegration.Headers, event)
+	return nil
+}
+
+// HandleEvent posts downlink events, other events are not posted
+func (i *httpIntegration) HandleEvent(app *application.Application, event *types.DeviceEvent) error {
+	if app == nil || app.HTTPIntegration == nil || app.HTTPIntegration.EventURL == "" {
+		return nil
+	}
+	if !strings.HasPrefix(string(event.Event), "down/") {
+		return nil
+	}
+	go i.post(i.ctx.WithFields(ttnlog.Fields{
+		"DevID": event.DevID,
+		"AppID": event.AppID,
+		"Event": event.Event,
+	}), app.HTTPIntegration.EventURL, app.HTTPIntegration.Headers, event)
+	return nil
+}
+
+// downlinkURL returns the signed URL that the application can use to schedule downlink for the device
+func (i *httpIntegration) downlinkURL(integration *application.HTTPIntegration, appID, devID string) string {
+	if i.downlinkBaseURL == "" || integration.DownlinkKey == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s%s%s/%s?key=%s", i.downlinkBaseURL, HTTPDownlinkPath, appID, devID, integration.DownlinkSignature(appID, devID))
+}
+
+func (i *httpIntegration) post(ctx ttnlog.Interface, url string, headers map[string]string, msg interface{}) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		ctx.WithError(err).Warn("Could not marshal HTTP request")
+		return
+	}
+	for retries := 0; ; retries++ {
+		err = i.do(url, headers, body)
+		if err == nil {
+			ctx.Debug("Posted to HTTP integration")
+			return
+		}
+		if errors.GetErrType(err) == errors.InvalidArgument || retries >= HTTPRetries {
+			break
+		}
+		time.Sleep(HTTPBackoff.Backoff(retries))
+	}
+	ctx.WithError(err).WithField("URL", url).Warn("Could not post to HTTP integration")
+}
+
+func (i *httpIntegration) do(url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.NewErrInvalidArgument("URL", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	switch {
+	case res.StatusCode >= 500:
+		return fmt.Errorf("HTTP integration returned %s", res.Status)
+	case res.StatusCode >= 400:
+		// Client errors are not retried
+		return errors.NewErrInvalidArgument("HTTP request", res.Status)
+	}
+	return nil
+}
+
+// HTTPDownlinkHandler handles downlink messages that are posted to the signed downlink URL of the HTTP integration
+// and passes all other requests to next
+func (h *handler) HTTPDownlinkHandler(next http.Handler) http.Handler {
+	i, ok := h.getIntegration(HTTPIntegrationName).(*httpIntegration)
+	if !ok {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, HTTPDownlinkPath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != "POST" {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, HTTPDownlinkPath), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			http.NotFound(w, r)
+			return
+		}
+		appID, devID := parts[0], parts[1]
+
+		app, err := i.handler.GetApplication(appID)
+		if err != nil || app.HTTPIntegration == nil || !app.HTTPIntegration.ValidDownlinkSignature(appID, devID, r.URL.Query().Get("key")) {
+			http.Error(w, "Invalid downlink key", http.StatusForbidden)
+			return
+		}
+
+		var downlink types.DownlinkMessage
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, HTTPMaxDownlinkSize)).Decode(&downlink); err != nil {
+			http.Error(w, "Invalid downlink message", http.StatusBadRequest)
+			return
+		}
+		downlink.AppID = appID
+		downlink.DevID = devID
+
+		if err := i.handler.EnqueueDownlink(&downlink); err != nil {
+			switch errors.GetErrType(err) {
+			case errors.PermissionDenied:
+				http.Error(w, err.Error(), http.StatusForbidden)
+			case errors.NotFound:
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case errors.InvalidArgument:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
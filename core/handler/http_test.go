@@ -0,0 +1,168 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHandleHTTP(t *testing.T) {
+	a := New(t)
+
+	appID := "handler-http-app1"
+	devID := "handler-http-dev1"
+
+	requests := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		requests <- r
+		bodies <- buf.Bytes()
+	}))
+	defer server.Close()
+
+	h := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleHTTP")},
+	}
+	app := &application.Application{
+		AppID: appID,
+		HTTPIntegration: &application.HTTPIntegration{
+			UplinkURL:   server.URL + "/uplink",
+			EventURL:    server.URL + "/events",
+			Headers:     map[string]string{"Authorization": "key secret"},
+			DownlinkKey: "downlink-key",
+		},
+	}
+
+	i := NewHTTPIntegration("http://localhost:8084/")
+	err := i.Open(&integrationHandler{handler: h, name: i.Name(), ctx: h.Ctx})
+	a.So(err, ShouldBeNil)
+
+	err = i.HandleUplink(app, &types.UplinkMessage{
+		AppID:      appID,
+		DevID:      devID,
+		PayloadRaw: []byte{0xaa, 0xbc},
+	})
+	a.So(err, ShouldBeNil)
+
+	select {
+	case req := <-requests:
+		a.So(req.URL.Path, ShouldEqual, "/uplink")
+		a.So(req.Header.Get("Authorization"), ShouldEqual, "key secret")
+		a.So(req.Header.Get("Content-Type"), ShouldEqual, "application/json")
+		var up types.UplinkMessage
+		a.So(json.Unmarshal(<-bodies, &up), ShouldBeNil)
+		a.So(up.PayloadRaw, ShouldResemble, []byte{0xaa, 0xbc})
+		a.So(up.DownlinkURL, ShouldStartWith, "http://localhost:8084/downlink/handler-http-app1/handler-http-dev1?key=")
+	case <-time.After(time.Second):
+		t.Fatal("Did not receive uplink")
+	}
+
+	// Activations are not posted because there is no ActivationURL, uplink events are never posted
+	i.HandleActivation(app, &types.DeviceEvent{AppID: appID, DevID: devID, Event: types.ActivationEvent})
+	i.HandleEvent(app, &types.DeviceEvent{AppID: appID, DevID: devID, Event: types.UplinkErrorEvent})
+	i.HandleEvent(app, &types.DeviceEvent{AppID: appID, DevID: devID, Event: types.DownlinkSentEvent})
+
+	select {
+	case req := <-requests:
+		a.So(req.URL.Path, ShouldEqual, "/events")
+		var event types.DeviceEvent
+		a.So(json.Unmarshal(<-bodies, &event), ShouldBeNil)
+		a.So(event.Event, ShouldEqual, types.DownlinkSentEvent)
+		a.So(event.DevID, ShouldEqual, devID)
+	case <-time.After(time.Second):
+		t.Fatal("Did not receive event")
+	}
+
+	// Messages of applications without HTTP integration are not posted
+	i.HandleUplink(nil, &types.UplinkMessage{AppID: "handler-http-app2", DevID: devID})
+	i.HandleUplink(&application.Application{AppID: "handler-http-app2"}, &types.UplinkMessage{AppID: "handler-http-app2", DevID: devID})
+
+	select {
+	case <-requests:
+		t.Fatal("Received unexpected request")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHTTPDownlinkHandler(t *testing.T) {
+	a := New(t)
+
+	appID := "handler-http-app1"
+	devID := "handler-http-dev1"
+
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHTTPDownlinkHandler")},
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-http-downlink"),
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-http-downlink"),
+		appEvent:     make(chan *types.DeviceEvent, 10),
+	}
+	h.WithHTTP("http://localhost:8084")
+	h.integrations[0].Open(&integrationHandler{handler: h, name: HTTPIntegrationName, ctx: h.Ctx})
+	integration := &application.HTTPIntegration{DownlinkKey: "downlink-key"}
+	h.applications.Set(&application.Application{
+		AppID:           appID,
+		HTTPIntegration: integration,
+	})
+	h.devices.Set(&device.Device{
+		AppID: appID,
+		DevID: devID,
+	})
+	defer func() {
+		h.applications.Delete(appID)
+		h.devices.Delete(appID, devID)
+	}()
+
+	var nextCalled bool
+	handler := h.HTTPDownlinkHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+	}))
+
+	do := func(method, path string, body string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	do("GET", "/applications/"+appID, "")
+	a.So(nextCalled, ShouldBeTrue)
+
+	key := integration.DownlinkSignature(appID, devID)
+
+	a.So(do("GET", "/downlink/"+appID+"/"+devID+"?key="+key, ""), ShouldEqual, http.StatusMethodNotAllowed)
+	a.So(do("POST", "/downlink/"+appID+"/"+devID+"?key=invalid", `{"port":1,"payload_raw":"AQ=="}`), ShouldEqual, http.StatusForbidden)
+	a.So(do("POST", "/downlink/"+appID+"/"+devID+"?key="+key, `invalid`), ShouldEqual, http.StatusBadRequest)
+	a.So(do("POST", "/downlink/"+appID+"/"+devID+"?key="+key, `{"port":1,"payload_raw":"`+strings.Repeat("A", int(HTTPMaxDownlinkSize))+`"}`), ShouldEqual, http.StatusBadRequest)
+
+	a.So(do("POST", "/downlink/"+appID+"/"+devID+"?key="+key, `{"port":1,"payload_raw":"AQ=="}`), ShouldEqual, http.StatusAccepted)
+	queue, _ := h.devices.DownlinkQueue(appID, devID)
+	next, _ := queue.Next()
+	a.So(next, ShouldNotBeNil)
+	a.So(next.PayloadRaw, ShouldResemble, []byte{0x01})
+
+	otherKey := integration.DownlinkSignature(appID, "handler-http-dev2")
+	a.So(do("POST", "/downlink/"+appID+"/handler-http-dev2?key="+otherKey, `{"port":1,"payload_raw":"AQ=="}`), ShouldEqual, http.StatusNotFound)
+
+	h.applications.Set(&application.Application{
+		AppID:                appID,
+		HTTPIntegration:      integration,
+		DisabledIntegrations: []string{HTTPIntegrationName},
+	})
+	a.So(do("POST", "/downlink/"+appID+"/"+devID+"?key="+key, `{"port":1,"payload_raw":"AQ=="}`), ShouldEqual, http.StatusForbidden)
+}
@@ -0,0 +1,258 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"fmt"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/rcrowley/go-metrics"
+)
+
+// IntegrationBufferSize indicates the size of the buffers for messages and events that are passed to integrations. Every
+// integration has its own buffers, so that a slow integration does not delay the others. Messages and events for an
+// integration are dropped when its buffer is full.
+var IntegrationBufferSize = 10
+
+// Integration connects applications to the Handler. An integration receives the
+// uplink messages, activations and events of applications and can act as a
+// source of downlink messages.
+type Integration interface {
+	// Name returns the unique name of the integration. Applications use this
+	// name to enable or disable the integration.
+	Name() string
+
+	// Open opens the integration. Downlink messages that the integration
+	// receives should be passed to handler.EnqueueDownlink
+	Open(handler IntegrationHandler) error
+
+	// Close closes the integration
+	Close()
+
+	// HandleUplink handles an uplink message. The app is nil if the
+	// application could not be found.
+	HandleUplink(app *application.Application, msg *types.UplinkMessage) error
+
+	// HandleActivation handles an activation event. The app is nil if the
+	// application could not be found.
+	HandleActivation(app *application.Application, event *types.DeviceEvent) error
+
+	// HandleEvent handles all other events. The app is nil if the application
+	// could not be found.
+	HandleEvent(app *application.Application, event *types.DeviceEvent) error
+}
+
+// IntegrationHandler is the part of the Handler that is available to integrations
+type IntegrationHandler interface {
+	Ctx() ttnlog.Interface
+	GetApplication(appID string) (*application.Application, error)
+	EnqueueDownlink(appDownlink *types.DownlinkMessage) error
+}
+
+type integrationHandler struct {
+	handler *handler
+	name    string
+	ctx     ttnlog.Interface
+}
+
+func (i *integrationHandler) Ctx() ttnlog.Interface {
+	return i.ctx
+}
+
+func (i *integrationHandler) GetApplication(appID string) (*application.Application, error) {
+	return i.handler.applications.Get(appID)
+}
+
+func (i *integrationHandler) EnqueueDownlink(appDownlink *types.DownlinkMessage) error {
+	app, err := i.handler.applications.Get(appDownlink.AppID)
+	if err != nil {
+		return err
+	}
+	if !app.IntegrationEnabled(i.name) {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("Integration %s is disabled for application %s", i.name, appDownlink.AppID))
+	}
+	return i.handler.EnqueueDownlink(appDownlink)
+}
+
+type integration struct {
+	Integration
+	uplinkQueue chan integrationUplink
+	eventQueue  chan integrationEvent
+	uplink      metrics.Meter
+	activations metrics.Meter
+	events      metrics.Meter
+	errors      metrics.Meter
+}
+
+type integrationUplink struct {
+	app *application.Application
+	up  *types.UplinkMessage
+}
+
+type integrationEvent struct {
+	app   *application.Application
+	event *types.DeviceEvent
+}
+
+func (h *handler) WithIntegration(i Integration) Handler {
+	h.integrations = append(h.integrations, &integration{
+		Integration: i,
+		uplinkQueue: make(chan integrationUplink, IntegrationBufferSize),
+		eventQueue:  make(chan integrationEvent, IntegrationBufferSize),
+		uplink:      metrics.NewMeter(),
+		activations: metrics.NewMeter(),
+		events:      metrics.NewMeter(),
+		errors:      metrics.NewMeter(),
+	})
+	return h
+}
+
+func (h *handler) getIntegration(name string) Integration {
+	for _, i := range h.integrations {
+		if i.Name() == name {
+			return i.Integration
+		}
+	}
+	return nil
+}
+
+func (h *handler) openIntegrations() error {
+	h.appUp = make(chan *types.UplinkMessage, IntegrationBufferSize)
+	h.appEvent = make(chan *types.DeviceEvent, IntegrationBufferSize)
+
+	for _, i := range h.integrations {
+		err := i.Open(&integrationHandler{
+			handler: h,
+			name:    i.Name(),
+			ctx:     h.Ctx.WithField("Integration", i.Name()),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Could not open %s integration", i.Name())
+		}
+	}
+
+	for _, i := range h.integrations {
+		go func(i *integration) {
+			for msg := range i.uplinkQueue {
+				h.handleIntegrationUplink(i, msg.app, msg.up)
+			}
+		}(i)
+		go func(i *integration) {
+			for msg := range i.eventQueue {
+				h.handleIntegrationEvent(i, msg.app, msg.event)
+			}
+		}(i)
+	}
+
+	go func() {
+		for up := range h.appUp {
+			h.dispatchUplink(up)
+		}
+	}()
+
+	go func() {
+		for event := range h.appEvent {
+			h.dispatchEvent(event)
+		}
+	}()
+
+	return nil
+}
+
+func (h *handler) closeIntegrations() {
+	for _, i := range h.integrations {
+		i.Close()
+	}
+}
+
+// getIntegrationApp returns the application for the integrations, or nil if it could not be found
+func (h *handler) getIntegrationApp(appID string) *application.Application {
+	app, err := h.applications.Get(appID)
+	if err != nil {
+		return nil
+	}
+	return app
+}
+
+func (h *handler) dispatchUplink(up *types.UplinkMessage) {
+	app := h.getIntegrationApp(up.AppID)
+	for _, i := range h.integrations {
+		if !app.IntegrationEnabled(i.Name()) {
+			continue
+		}
+		select {
+		case i.uplinkQueue <- integrationUplink{app: app, up: up}:
+		default:
+			i.errors.Mark(1)
+			h.Ctx.WithFields(ttnlog.Fields{
+				"Integration": i.Name(),
+				"AppID":       up.AppID,
+				"DevID":       up.DevID,
+			}).Warn("Integration buffer full, dropping uplink")
+		}
+	}
+}
+
+func (h *handler) handleIntegrationUplink(i *integration, app *application.Application, up *types.UplinkMessage) {
+	i.uplink.Mark(1)
+	if err := i.HandleUplink(app, up); err != nil {
+		i.errors.Mark(1)
+		h.Ctx.WithFields(ttnlog.Fields{
+			"Integration": i.Name(),
+			"AppID":       up.AppID,
+			"DevID":       up.DevID,
+		}).WithError(err).Warn("Could not handle uplink in integration")
+	}
+}
+
+func (h *handler) dispatchEvent(event *types.DeviceEvent) {
+	app := h.getIntegrationApp(event.AppID)
+	for _, i := range h.integrations {
+		if !app.IntegrationEnabled(i.Name()) {
+			continue
+		}
+		select {
+		case i.eventQueue <- integrationEvent{app: app, event: event}:
+		default:
+			i.errors.Mark(1)
+			h.Ctx.WithFields(ttnlog.Fields{
+				"Integration": i.Name(),
+				"AppID":       event.AppID,
+				"DevID":       event.DevID,
+				"Event":       event.Event,
+			}).Warn("Integration buffer full, dropping event")
+		}
+	}
+}
+
+func (h *handler) handleIntegrationEvent(i *integration, app *application.Application, event *types.DeviceEvent) {
+	var err error
+	if event.Event == types.ActivationEvent {
+		i.activations.Mark(1)
+		err = i.HandleActivation(app, event)
+	} else {
+		i.events.Mark(1)
+		err = i.HandleEvent(app, event)
+	}
+	if err != nil {
+		i.errors.Mark(1)
+		h.Ctx.WithFields(ttnlog.Fields{
+			"Integration": i.Name(),
+			"AppID":       event.AppID,
+			"DevID":       event.DevID,
+			"Event":       event.Event,
+		}).WithError(err).Warn("Could not handle event in integration")
+	}
+}
+
+func (h *handler) publishUplink(up *types.UplinkMessage) {
+	h.appUp <- up
+}
+
+func (h *handler) publishEvent(event *types.DeviceEvent) {
+	h.appEvent <- event
+}
@@ -0,0 +1,175 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+type testIntegration struct {
+	name        string
+	err         error
+	handler     IntegrationHandler
+	uplink      chan *types.UplinkMessage
+	activations chan *types.DeviceEvent
+	events      chan *types.DeviceEvent
+}
+
+func newTestIntegration(name string) *testIntegration {
+	return &testIntegration{
+		name:        name,
+		uplink:      make(chan *types.UplinkMessage, 10),
+		activations: make(chan *types.DeviceEvent, 10),
+		events:      make(chan *types.DeviceEvent, 10),
+	}
+}
+
+func (i *testIntegration) Name() string { return i.name }
+
+func (i *testIntegration) Open(handler IntegrationHandler) error {
+	i.handler = handler
+	return nil
+}
+
+func (i *testIntegration) Close() {}
+
+func (i *testIntegration) HandleUplink(_ *application.Application, msg *types.UplinkMessage) error {
+	i.uplink <- msg
+	return i.err
+}
+
+func (i *testIntegration) HandleActivation(_ *application.Application, event *types.DeviceEvent) error {
+	i.activations <- event
+	return i.err
+}
+
+func (i *testIntegration) HandleEvent(_ *application.Application, event *types.DeviceEvent) error {
+	i.events <- event
+	return i.err
+}
+
+func TestIntegrations(t *testing.T) {
+	a := New(t)
+
+	appID := "handler-integrations-app1"
+	devID := "handler-integrations-dev1"
+
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestIntegrations")},
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-integrations"),
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-integrations"),
+	}
+	h.applications.Set(&application.Application{
+		AppID:                appID,
+		DisabledIntegrations: []string{"disabled"},
+	})
+	h.devices.Set(&device.Device{
+		AppID: appID,
+		DevID: devID,
+	})
+	defer func() {
+		h.applications.Delete(appID)
+		h.devices.Delete(appID, devID)
+	}()
+
+	enabled := newTestIntegration("enabled")
+	enabled.err = errors.New("integration error")
+	disabled := newTestIntegration("disabled")
+	h.WithIntegration(disabled).WithIntegration(enabled)
+
+	err := h.openIntegrations()
+	a.So(err, ShouldBeNil)
+	defer h.closeIntegrations()
+
+	h.publishUplink(&types.UplinkMessage{AppID: appID, DevID: devID})
+	select {
+	case up := <-enabled.uplink:
+		a.So(up.DevID, ShouldEqual, devID)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Enabled integration did not receive uplink")
+	}
+
+	h.publishEvent(&types.DeviceEvent{AppID: appID, DevID: devID, Event: types.ActivationEvent})
+	h.publishEvent(&types.DeviceEvent{AppID: appID, DevID: devID, Event: types.DownlinkSentEvent})
+	select {
+	case event := <-enabled.activations:
+		a.So(event.Event, ShouldEqual, types.ActivationEvent)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Enabled integration did not receive activation")
+	}
+	select {
+	case event := <-enabled.events:
+		a.So(event.Event, ShouldEqual, types.DownlinkSentEvent)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Enabled integration did not receive event")
+	}
+
+	<-time.After(50 * time.Millisecond)
+
+	a.So(disabled.uplink, ShouldBeEmpty)
+	a.So(disabled.activations, ShouldBeEmpty)
+	a.So(disabled.events, ShouldBeEmpty)
+
+	a.So(h.integrations[1].errors.Count(), ShouldEqual, 3)
+
+	// Downlink from enabled integration
+	err = enabled.handler.EnqueueDownlink(&types.DownlinkMessage{AppID: appID, DevID: devID, PayloadRaw: []byte{0x01}})
+	a.So(err, ShouldBeNil)
+
+	// Downlink from disabled integration
+	err = disabled.handler.EnqueueDownlink(&types.DownlinkMessage{AppID: appID, DevID: devID, PayloadRaw: []byte{0x01}})
+	a.So(err, ShouldNotBeNil)
+}
+
+type blockingIntegration struct {
+	*testIntegration
+	unblock chan struct{}
+}
+
+func (i *blockingIntegration) HandleUplink(app *application.Application, msg *types.UplinkMessage) error {
+	<-i.unblock
+	return i.testIntegration.HandleUplink(app, msg)
+}
+
+func TestIntegrationsBlocking(t *testing.T) {
+	a := New(t)
+
+	appID := "handler-integrations-app2"
+
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestIntegrationsBlocking")},
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-integrations"),
+	}
+	h.applications.Set(&application.Application{AppID: appID})
+	defer h.applications.Delete(appID)
+
+	blocking := &blockingIntegration{newTestIntegration("blocking"), make(chan struct{})}
+	defer close(blocking.unblock)
+	other := newTestIntegration("other")
+	h.WithIntegration(blocking).WithIntegration(other)
+
+	err := h.openIntegrations()
+	a.So(err, ShouldBeNil)
+	defer h.closeIntegrations()
+
+	// A blocked integration does not delay the other integrations, even if its buffer is full
+	for n := 0; n < IntegrationBufferSize+2; n++ {
+		h.publishUplink(&types.UplinkMessage{AppID: appID, DevID: "dev"})
+		select {
+		case <-other.uplink:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Integration did not receive uplink")
+		}
+	}
+	a.So(h.integrations[0].errors.Count(), ShouldBeGreaterThan, 0)
+}
@@ -0,0 +1,176 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"fmt"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/otaa"
+	"github.com/brocaar/lorawan"
+)
+
+// maxJoinNonce is the maximum JoinNonce of LoRaWAN 1.1 devices, the JoinNonce is a 24 bit counter
+const maxJoinNonce = 1<<24 - 1
+
+// activationMICKey returns the key that is used for the MIC of a JoinRequest or RejoinRequest of a LoRaWAN 1.1
+// device: the NwkKey for JoinRequests, the JSIntKey for RejoinRequests of type 1 and the SNwkSIntKey for
+// RejoinRequests of type 0 and 2
+func activationMICKey(dev *device.Device, payload []byte) (types.AES128Key, error) {
+	if dev.NwkKey.IsEmpty() {
+		return types.AES128Key{}, errors.NewErrNotFound(fmt.Sprintf("NwkKey for device %s", dev.DevID))
+	}
+	if !pb_lorawan.IsRejoinRequest(payload) {
+		return types.AES128Key(dev.NwkKey), nil
+	}
+	if len(payload) > 1 && payload[1] == 1 {
+		jsIntKey, _ := otaa.CalculateJoinServerKeys(dev.NwkKey, dev.DevEUI)
+		return jsIntKey, nil
+	}
+	return types.AES128Key(dev.SNwkSIntKey), nil
+}
+
+// handleLoRaWAN11ActivationChallenge sets the MIC of the JoinRequest or RejoinRequest of a LoRaWAN 1.1 device
+func handleLoRaWAN11ActivationChallenge(dev *device.Device, payload []byte) ([]byte, error) {
+	if len(payload) <= 4 {
+		return nil, errors.NewErrInvalidArgument("Activation", "payload too short")
+	}
+	key, err := activationMICKey(dev, payload)
+	if err != nil {
+		return nil, err
+	}
+	mic := otaa.CalculateJoinRequestMIC(key, payload[:len(payload)-4])
+	bytes := make([]byte, len(payload))
+	copy(bytes, payload[:len(payload)-4])
+	copy(bytes[len(payload)-4:], mic[:])
+	return bytes, nil
+}
+
+// handleLoRaWAN11Activation handles the JoinRequest or RejoinRequest of a LoRaWAN 1.1 device
+func (h *handler) handleLoRaWAN11Activation(ctx ttnlog.Interface, activation *pb_broker.DeduplicatedDeviceActivationRequest, dev *device.Device) (*pb.DeviceActivationResponse, error) {
+	payload := activation.Payload
+	if len(payload) <= 4 {
+		return nil, errors.NewErrInvalidArgument("Activation", "payload too short")
+	}
+
+	// Validate MIC
+	key, err := activationMICKey(dev, payload)
+	if err != nil {
+		return nil, err
+	}
+	activation.Trace = activation.Trace.WithEvent(trace.CheckMICEvent)
+	if mic := otaa.CalculateJoinRequestMIC(key, payload[:len(payload)-4]); string(mic[:]) != string(payload[len(payload)-4:]) {
+		return nil, errors.NewErrNotFound("MIC does not match device")
+	}
+
+	// The join-accept in response to a JoinRequest is encrypted with the NwkKey, in response to a RejoinRequest
+	// it is encrypted with the JSEncKey
+	jsIntKey, jsEncKey := otaa.CalculateJoinServerKeys(dev.NwkKey, dev.DevEUI)
+	encKey := types.AES128Key(dev.NwkKey)
+	joinReqType := byte(otaa.JoinRequestType)
+	var devNonce [2]byte
+	var rejoin *pb_lorawan.RejoinRequest
+	if pb_lorawan.IsRejoinRequest(payload) {
+		rejoin = new(pb_lorawan.RejoinRequest)
+		if err := rejoin.UnmarshalBinary(payload); err != nil {
+			return nil, err
+		}
+		if rejoin.RejoinType == 1 && rejoin.RJCount < dev.RJCount1 {
+			return nil, errors.NewErrInvalidArgument("Activation RJcount1", "not high enough")
+		}
+		encKey = jsEncKey
+		joinReqType = rejoin.RejoinType
+		devNonce = [2]byte{byte(rejoin.RJCount >> 8), byte(rejoin.RJCount)}
+	} else {
+		var reqPHY lorawan.PHYPayload
+		if err := reqPHY.UnmarshalBinary(payload); err != nil {
+			return nil, err
+		}
+		reqMAC, ok := reqPHY.MACPayload.(*lorawan.JoinRequestPayload)
+		if !ok {
+			return nil, errors.NewErrInvalidArgument("Activation", "does not contain a JoinRequestPayload")
+		}
+		for _, usedNonce := range dev.UsedDevNonces {
+			if usedNonce == device.DevNonce(reqMAC.DevNonce) {
+				return nil, errors.NewErrInvalidArgument("Activation DevNonce", "already used")
+			}
+		}
+		devNonce = reqMAC.DevNonce
+	}
+
+	if dev.JoinNonce >= maxJoinNonce {
+		return nil, errors.NewErrInvalidArgument("Activation JoinNonce", "no JoinNonces left, the NwkKey should be changed")
+	}
+
+	ctx.Debug("Accepting LoRaWAN 1.1 Join Request")
+	activation.Trace = activation.Trace.WithEvent(trace.AcceptEvent, "mac-version", "1.1")
+
+	resPHY, joinAccept, err := unmarshalJoinAcceptTemplate(activation.ResponseTemplate.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	h.publishActivation(ctx, activation, dev, types.DevAddr(joinAccept.DevAddr))
+
+	// The JoinNonce is a counter
+	joinNonce := dev.JoinNonce + 1
+	joinAccept.AppNonce = [3]byte{byte(joinNonce >> 16), byte(joinNonce >> 8), byte(joinNonce)}
+
+	// Calculate session keys
+	fNwkSIntKey, sNwkSIntKey, nwkSEncKey, appSKey, err := otaa.CalculateLoRaWAN11SessionKeys(dev.NwkKey, dev.AppKey, joinAccept.AppNonce, dev.AppEUI, devNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update Device
+	dev.StartUpdate()
+	dev.DevAddr = types.DevAddr(joinAccept.DevAddr)
+	dev.AppSKey = appSKey
+	dev.NwkSKey = fNwkSIntKey
+	dev.SNwkSIntKey = sNwkSIntKey
+	dev.NwkSEncKey = nwkSEncKey
+	dev.FCntDown = 0 // The frame counters are reset in the new session, also after a rejoin
+	dev.JoinNonce = joinNonce
+	if rejoin == nil {
+		dev.UsedDevNonces = append(dev.UsedDevNonces, devNonce)
+	} else if rejoin.RejoinType == 1 {
+		dev.RJCount1 = rejoin.RJCount + 1
+	}
+	if err := h.devices.Set(dev); err != nil {
+		return nil, err
+	}
+
+	resBytes, err := resPHY.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := otaa.SetOptNeg(resBytes); err != nil {
+		return nil, err
+	}
+	mic := otaa.CalculateJoinAcceptMIC(jsIntKey, joinReqType, dev.AppEUI, devNonce, resBytes[:len(resBytes)-4])
+	copy(resBytes[len(resBytes)-4:], mic[:])
+	resBytes, err = otaa.EncryptJoinAccept(encKey, resBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := activation.ActivationMetadata
+	metadata.GetLorawan().NwkSKey = &dev.NwkSKey
+	metadata.GetLorawan().SNwkSIntKey = &dev.SNwkSIntKey
+	metadata.GetLorawan().NwkSEncKey = &dev.NwkSEncKey
+	metadata.GetLorawan().DevAddr = &dev.DevAddr
+	return &pb.DeviceActivationResponse{
+		Payload:            resBytes,
+		DownlinkOption:     activation.ResponseTemplate.DownlinkOption,
+		ActivationMetadata: metadata,
+		Trace:              activation.Trace,
+	}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/otaa"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHandleLoRaWAN11Activation(t *testing.T) {
+	a := New(t)
+
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHandleLoRaWAN11Activation")},
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-activation-1-1"),
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-activation-1-1"),
+	}
+	h.InitStatus()
+	h.appEvent = make(chan *types.DeviceEvent, 10)
+
+	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	appID := appEUI.String()
+	devEUI := types.DevEUI{1, 2, 3, 4, 5, 6, 7, 9}
+	devID := devEUI.String()
+	devAddr := types.DevAddr{1, 2, 3, 4}
+
+	appKey := types.AppKey{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	nwkKey := types.NwkKey{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	h.applications.Set(&application.Application{AppID: appID})
+	defer h.applications.Delete(appID)
+
+	h.devices.Set(&device.Device{
+		AppID:   appID,
+		DevID:   devID,
+		AppEUI:  appEUI,
+		DevEUI:  devEUI,
+		AppKey:  appKey,
+		NwkKey:  nwkKey,
+		Options: device.Options{MACVersion: pb_lorawan.MACVersion_MAC_V1_1},
+	})
+	defer h.devices.Delete(appID, devID)
+
+	responsePHY := lorawan.PHYPayload{
+		MHDR:       lorawan.MHDR{MType: lorawan.JoinAccept, Major: lorawan.LoRaWANR1},
+		MACPayload: &lorawan.JoinAcceptPayload{},
+	}
+	templateBytes, _ := responsePHY.MarshalBinary()
+	activation := func(payload []byte) *pb_broker.DeduplicatedDeviceActivationRequest {
+		return &pb_broker.DeduplicatedDeviceActivationRequest{
+			Payload: payload,
+			AppEui:  &appEUI,
+			AppId:   appID,
+			DevEui:  &devEUI,
+			DevId:   devID,
+			ActivationMetadata: &pb_protocol.ActivationMetadata{Protocol: &pb_protocol.ActivationMetadata_Lorawan{
+				Lorawan: &pb_lorawan.ActivationMetadata{DevAddr: &devAddr},
+			}},
+			ResponseTemplate: &pb_broker.DeviceActivationResponse{Payload: templateBytes},
+		}
+	}
+
+	requestPHY := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{MType: lorawan.JoinRequest, Major: lorawan.LoRaWANR1},
+		MACPayload: &lorawan.JoinRequestPayload{
+			AppEUI:   lorawan.EUI64(appEUI),
+			DevEUI:   lorawan.EUI64(devEUI),
+			DevNonce: [2]byte{0, 1},
+		},
+	}
+
+	// The MIC of JoinRequests of LoRaWAN 1.1 devices is computed with the NwkKey
+	requestPHY.SetMIC(lorawan.AES128Key(appKey))
+	requestBytes, _ := requestPHY.MarshalBinary()
+	_, err := h.HandleActivation(activation(requestBytes))
+	a.So(err, ShouldNotBeNil)
+
+	requestPHY.SetMIC(lorawan.AES128Key(nwkKey))
+	requestBytes, _ = requestPHY.MarshalBinary()
+
+	challenge, err := h.HandleActivationChallenge(&pb_broker.ActivationChallengeRequest{
+		Payload: append(append([]byte{}, requestBytes[:len(requestBytes)-4]...), 0, 0, 0, 0),
+		AppId:   appID,
+		DevId:   devID,
+	})
+	a.So(err, ShouldBeNil)
+	a.So(challenge.Payload, ShouldResemble, requestBytes)
+
+	res, err := h.HandleActivation(activation(requestBytes))
+	a.So(err, ShouldBeNil)
+	a.So(res, ShouldNotBeNil)
+
+	// The join-accept is encrypted with the NwkKey
+	joinAccept, err := otaa.DecryptJoinAccept(types.AES128Key(nwkKey), res.Payload)
+	a.So(err, ShouldBeNil)
+	a.So(joinAccept[1:4], ShouldResemble, []byte{1, 0, 0}) // JoinNonce 1 (LSB-first)
+	a.So(joinAccept[11]&0x80, ShouldEqual, 0x80)           // OptNeg
+	jsIntKey, _ := otaa.CalculateJoinServerKeys(nwkKey, devEUI)
+	mic := otaa.CalculateJoinAcceptMIC(jsIntKey, otaa.JoinRequestType, appEUI, [2]byte{0, 1}, joinAccept[:len(joinAccept)-4])
+	a.So(joinAccept[len(joinAccept)-4:], ShouldResemble, mic[:])
+
+	dev, err := h.devices.Get(appID, devID)
+	a.So(err, ShouldBeNil)
+	a.So(dev.JoinNonce, ShouldEqual, 1)
+	a.So(dev.SNwkSIntKey.IsEmpty(), ShouldBeFalse)
+	a.So(dev.NwkSEncKey.IsEmpty(), ShouldBeFalse)
+	a.So(res.ActivationMetadata.GetLorawan().SNwkSIntKey, ShouldResemble, &dev.SNwkSIntKey)
+
+	// Same DevNonce used twice
+	_, err = h.HandleActivation(activation(requestBytes))
+	a.So(err, ShouldNotBeNil)
+
+	// Class C device that sent downlink in the current session
+	dev.StartUpdate()
+	dev.FCntDown = 42
+	dev.Options.Class = pb_lorawan.DeviceClass_CLASS_C
+	h.devices.Set(dev)
+
+	// RejoinRequest of type 1
+	rejoin := pb_lorawan.RejoinRequest{RejoinType: 1, JoinEUI: appEUI, DevEUI: devEUI, RJCount: 5}
+	rejoin.SetMIC(jsIntKey)
+	rejoinBytes, _ := rejoin.MarshalBinary()
+	res, err = h.HandleActivation(activation(rejoinBytes))
+	a.So(err, ShouldBeNil)
+
+	// The join-accept is encrypted with the JSEncKey
+	_, jsEncKey := otaa.CalculateJoinServerKeys(nwkKey, devEUI)
+	joinAccept, _ = otaa.DecryptJoinAccept(jsEncKey, res.Payload)
+	mic = otaa.CalculateJoinAcceptMIC(jsIntKey, 1, appEUI, [2]byte{0, 5}, joinAccept[:len(joinAccept)-4])
+	a.So(joinAccept[len(joinAccept)-4:], ShouldResemble, mic[:])
+
+	dev, _ = h.devices.Get(appID, devID)
+	a.So(dev.JoinNonce, ShouldEqual, 2)
+	a.So(dev.RJCount1, ShouldEqual, 6)
+	a.So(dev.FCntDown, ShouldEqual, 0)
+
+	// The Class C downlink uses the frame counter of the new session
+	h.downlink = make(chan *pb_broker.DownlinkMessage, 1)
+	err = h.EnqueueDownlink(&types.DownlinkMessage{AppID: appID, DevID: devID, PayloadRaw: []byte{0xAA, 0xBC}})
+	a.So(err, ShouldBeNil)
+	a.So(h.downlink, ShouldHaveLength, 1)
+	downlink := <-h.downlink
+	var phy lorawan.PHYPayload
+	a.So(phy.UnmarshalBinary(downlink.Payload), ShouldBeNil)
+	a.So(phy.MACPayload.(*lorawan.MACPayload).FHDR.FCnt, ShouldEqual, 0)
+
+	// RJcount1 used twice
+	_, err = h.HandleActivation(activation(rejoinBytes))
+	a.So(err, ShouldNotBeNil)
+}
@@ -4,6 +4,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/handler/multicast"
 	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
@@ -73,6 +75,49 @@ func (h *handlerManager) validateTTNAuthAppContext(ctx context.Context, appID st
 	return ctx, claims, nil
 }
 
+// devicePayloadFunctions returns the payload functions of a device, it returns nil if no function is set
+func devicePayloadFunctions(in *pb.PayloadFunctions) *device.PayloadFunctions {
+	if in == nil || (in.Decoder == "" && in.Converter == "" && in.Validator == "" && in.Encoder == "") {
+		return nil
+	}
+	return &device.PayloadFunctions{
+		Decoder:   in.Decoder,
+		Converter: in.Converter,
+		Validator: in.Validator,
+		Encoder:   in.Encoder,
+	}
+}
+
+func pbPayloadFunctions(in *device.PayloadFunctions) *pb.PayloadFunctions {
+	if in == nil {
+		return nil
+	}
+	return &pb.PayloadFunctions{
+		Decoder:   in.Decoder,
+		Converter: in.Converter,
+		Validator: in.Validator,
+		Encoder:   in.Encoder,
+	}
+}
+
+// setApplicationADR sets the ADR settings of the application. They are only changed if they are set, and the data
+// rate is cleared when the algorithm is changed to one that is not fixed.
+func setApplicationADR(app *application.Application, in *pb.Application) error {
+	if in.AdrAlgorithm != "" {
+		app.ADRAlgorithm = in.AdrAlgorithm
+		if in.AdrAlgorithm != "fixed" {
+			app.ADRDataRate = ""
+		}
+	}
+	if in.AdrDataRate != "" {
+		app.ADRDataRate = in.AdrDataRate
+	}
+	if app.ADRAlgorithm == "fixed" && app.ADRDataRate == "" {
+		return errors.NewErrInvalidArgument("AdrDataRate", "required for the fixed ADR algorithm")
+	}
+	return nil
+}
+
 func (h *handlerManager) GetDevice(ctx context.Context, in *pb.DeviceIdentifier) (*pb.Device, error) {
 	if err := in.Validate(); err != nil {
 		return nil, errors.Wrap(err, "Invalid Device Identifier")
@@ -87,7 +132,8 @@ func (h *handlerManager) GetDevice(ctx context.Context, in *pb.DeviceIdentifier)
 		return nil, err
 	}
 
-	if _, err := h.handler.applications.Get(in.AppId); err != nil {
+	app, err := h.handler.applications.Get(in.AppId)
+	if err != nil {
 		return nil, errors.Wrap(err, "Application not registered to this Handler")
 	}
 
@@ -112,10 +158,24 @@ func (h *handlerManager) GetDevice(ctx context.Context, in *pb.DeviceIdentifier)
 			DisableFCntCheck:      dev.Options.DisableFCntCheck,
 			Uses32BitFCnt:         dev.Options.Uses32BitFCnt,
 			ActivationConstraints: dev.Options.ActivationConstraints,
+			DeviceClass:           dev.Options.Class,
+			BeaconFrequency:       dev.Options.BeaconFrequency,
+			PingSlotFrequency:     dev.Options.PingSlotFrequency,
+			PingSlotDataRate:      dev.Options.PingSlotDataRate,
+			MacVersion:            dev.Options.MACVersion,
+			AdrAlgorithm:          dev.Options.ADRAlgorithm,
+			AdrDataRate:           dev.Options.ADRDataRate,
 		}},
 		Latitude:  dev.Latitude,
 		Longitude: dev.Longitude,
 		Altitude:  dev.Altitude,
+
+		PayloadFunctions: pbPayloadFunctions(dev.PayloadFunctions),
+	}
+	if dev.IsLoRaWAN11() {
+		pbDev.GetLorawanDevice().NwkKey = &dev.NwkKey
+		pbDev.GetLorawanDevice().SNwkSIntKey = &dev.SNwkSIntKey
+		pbDev.GetLorawanDevice().NwkSEncKey = &dev.NwkSEncKey
 	}
 
 	nsDev, err := h.deviceManager.GetDevice(ctx, &pb_lorawan.DeviceIdentifier{
@@ -141,7 +201,15 @@ func (h *handlerManager) GetDevice(ctx context.Context, in *pb.DeviceIdentifier)
 
 	pbDev.GetLorawanDevice().FCntUp = nsDev.FCntUp
 	pbDev.GetLorawanDevice().FCntDown = nsDev.FCntDown
+	pbDev.GetLorawanDevice().AFCntDown = nsDev.AFCntDown
+	pbDev.GetLorawanDevice().MacSettings = nsDev.MacSettings
 	pbDev.GetLorawanDevice().LastSeen = nsDev.LastSeen
+	pbDev.GetLorawanDevice().AdrState = nsDev.AdrState
+	// The NetworkServer uses the ADR algorithm of the application for devices that have none
+	if adrState := nsDev.AdrState; adrState != nil && dev.Options.ADRAlgorithm == "" && app.ADRAlgorithm != "" {
+		adrState.Algorithm = app.ADRAlgorithm
+	}
+	pbDev.GetLorawanDevice().DeviceStatus = nsDev.DeviceStatus
 
 	return pbDev, nil
 }
@@ -213,6 +281,13 @@ func (h *handlerManager) SetDevice(ctx context.Context, in *pb.Device) (*empty.E
 		DisableFCntCheck:      lorawan.DisableFCntCheck,
 		Uses32BitFCnt:         lorawan.Uses32BitFCnt,
 		ActivationConstraints: lorawan.ActivationConstraints,
+		Class:                 lorawan.DeviceClass,
+		BeaconFrequency:       lorawan.BeaconFrequency,
+		PingSlotFrequency:     lorawan.PingSlotFrequency,
+		PingSlotDataRate:      lorawan.PingSlotDataRate,
+		MACVersion:            lorawan.MacVersion,
+		ADRAlgorithm:          lorawan.AdrAlgorithm,
+		ADRDataRate:           lorawan.AdrDataRate,
 	}
 	if dev.Options.ActivationConstraints == "" {
 		dev.Options.ActivationConstraints = "local"
@@ -235,15 +310,41 @@ func (h *handlerManager) SetDevice(ctx context.Context, in *pb.Device) (*empty.E
 		}
 		dev.AppKey = *lorawan.AppKey
 	}
+	if lorawan.NwkKey != nil {
+		if dev.NwkKey != *lorawan.NwkKey { // When the NwkKey of an existing device is changed
+			dev.UsedDevNonces = []device.DevNonce{}
+			dev.JoinNonce = 0
+			dev.RJCount1 = 0
+		}
+		dev.NwkKey = *lorawan.NwkKey
+	}
+	if lorawan.SNwkSIntKey != nil {
+		dev.SNwkSIntKey = *lorawan.SNwkSIntKey
+	}
+	if lorawan.NwkSEncKey != nil {
+		dev.NwkSEncKey = *lorawan.NwkSEncKey
+	}
 
 	dev.Latitude = in.Latitude
 	dev.Longitude = in.Longitude
 	dev.Altitude = in.Altitude
 
+	// The payload functions are only changed if they are set, and cleared if they are set without functions
+	if in.PayloadFunctions != nil {
+		dev.PayloadFunctions = devicePayloadFunctions(in.PayloadFunctions)
+	}
+
 	// Update the device in the Broker (NetworkServer)
 	nsUpdated := dev.GetLoRaWAN()
 	nsUpdated.FCntUp = lorawan.FCntUp
 	nsUpdated.FCntDown = lorawan.FCntDown
+	nsUpdated.AFCntDown = lorawan.AFCntDown
+	nsUpdated.MacSettings = lorawan.MacSettings // The NetworkServer keeps the MAC settings if they are not set
+	if dev.IsLoRaWAN11() {
+		dev.FCntDown = lorawan.AFCntDown
+	} else {
+		dev.FCntDown = lorawan.FCntDown
+	}
 
 	_, err = h.deviceManager.SetDevice(ctx, nsUpdated)
 	if err != nil {
@@ -255,12 +356,12 @@ func (h *handlerManager) SetDevice(ctx context.Context, in *pb.Device) (*empty.E
 		return nil, err
 	}
 
-	h.handler.mqttEvent <- &types.DeviceEvent{
+	h.handler.publishEvent(&types.DeviceEvent{
 		AppID: dev.AppID,
 		DevID: dev.DevID,
 		Event: eventType,
 		Data:  nil, // Don't send potentially sensitive details over MQTT
-	}
+	})
 
 	return &empty.Empty{}, nil
 }
@@ -294,11 +395,114 @@ func (h *handlerManager) DeleteDevice(ctx context.Context, in *pb.DeviceIdentifi
 	if err != nil {
 		return nil, err
 	}
-	h.handler.mqttEvent <- &types.DeviceEvent{
+	h.handler.publishEvent(&types.DeviceEvent{
 		AppID: in.AppId,
 		DevID: in.DevId,
 		Event: types.DeleteEvent,
+	})
+	return &empty.Empty{}, nil
+}
+
+// getDownlinkQueue validates the request and returns the downlink queue of the device
+func (h *handlerManager) getDownlinkQueue(ctx context.Context, appID, devID string) (device.DownlinkQueue, error) {
+	_, claims, err := h.validateTTNAuthAppContext(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAppRights(claims, appID, rights.Devices)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.handler.applications.Get(appID); err != nil {
+		return nil, errors.Wrap(err, "Application not registered to this Handler")
 	}
+
+	if _, err := h.handler.devices.Get(appID, devID); err != nil {
+		return nil, err
+	}
+
+	return h.handler.devices.DownlinkQueue(appID, devID)
+}
+
+func (h *handlerManager) GetDownlinkQueue(ctx context.Context, in *pb.DeviceIdentifier) (*pb.DownlinkQueue, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Device Identifier")
+	}
+	if _, err := h.getDownlinkQueue(ctx, in.AppId, in.DevId); err != nil {
+		return nil, err
+	}
+
+	current, msgs, err := h.handler.getDownlinkQueue(in.AppId, in.DevId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.DownlinkQueue{Downlinks: make([]*pb.QueuedDownlink, 0, len(msgs))}
+	if current != nil {
+		if res.Current, err = pbQueuedDownlink(current); err != nil {
+			return nil, err
+		}
+	}
+	for _, msg := range msgs {
+		downlink, err := pbQueuedDownlink(msg)
+		if err != nil {
+			return nil, err
+		}
+		res.Downlinks = append(res.Downlinks, downlink)
+	}
+
+	return res, nil
+}
+
+func pbQueuedDownlink(msg *types.DownlinkMessage) (*pb.QueuedDownlink, error) {
+	downlink := &pb.QueuedDownlink{
+		Port:          uint32(msg.FPort),
+		Confirmed:     msg.Confirmed,
+		PayloadRaw:    msg.PayloadRaw,
+		CorrelationId: msg.CorrelationID,
+	}
+	if msg.PayloadFields != nil {
+		fields, err := json.Marshal(msg.PayloadFields)
+		if err != nil {
+			return nil, err
+		}
+		downlink.PayloadFields = string(fields)
+	}
+	if msg.Expires != nil {
+		downlink.Expires = time.Time(*msg.Expires).UnixNano()
+	}
+	return downlink, nil
+}
+
+func (h *handlerManager) DeleteQueuedDownlink(ctx context.Context, in *pb.QueuedDownlinkIdentifier) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Downlink Identifier")
+	}
+	queue, err := h.getDownlinkQueue(ctx, in.AppId, in.DevId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := queue.Delete(int(in.Index)); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (h *handlerManager) ClearDownlinkQueue(ctx context.Context, in *pb.DeviceIdentifier) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Device Identifier")
+	}
+	if _, err := h.getDownlinkQueue(ctx, in.AppId, in.DevId); err != nil {
+		return nil, err
+	}
+
+	if err := h.handler.clearDownlinkQueue(in.AppId, in.DevId); err != nil {
+		return nil, err
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -364,6 +568,173 @@ func (h *handlerManager) GetDevicesForApplication(ctx context.Context, in *pb.Ap
 	return res, nil
 }
 
+// validateMulticastContext validates the request and checks that the application is registered to this Handler
+func (h *handlerManager) validateMulticastContext(ctx context.Context, appID string) (context.Context, error) {
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, appID)
+	if err != nil {
+		return ctx, err
+	}
+	err = checkAppRights(claims, appID, rights.Devices)
+	if err != nil {
+		return ctx, err
+	}
+
+	if _, err := h.handler.applications.Get(appID); err != nil {
+		return ctx, errors.Wrap(err, "Application not registered to this Handler")
+	}
+
+	return ctx, nil
+}
+
+func pbMulticastGroup(group *multicast.Group) *pb.MulticastGroup {
+	return &pb.MulticastGroup{
+		AppId:       group.AppID,
+		GroupId:     group.GroupID,
+		Description: group.Description,
+		DevAddr:     &group.DevAddr,
+		NwkSKey:     &group.NwkSKey,
+		AppSKey:     &group.AppSKey,
+		FCntDown:    group.FCntDown,
+		GatewayIds:  group.GatewayIDs,
+	}
+}
+
+func (h *handlerManager) GetMulticastGroup(ctx context.Context, in *pb.MulticastGroupIdentifier) (*pb.MulticastGroup, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Multicast Group Identifier")
+	}
+	if _, err := h.validateMulticastContext(ctx, in.AppId); err != nil {
+		return nil, err
+	}
+
+	group, err := h.handler.multicast.Get(in.AppId, in.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	return pbMulticastGroup(group), nil
+}
+
+func (h *handlerManager) SetMulticastGroup(ctx context.Context, in *pb.MulticastGroup) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Multicast Group")
+	}
+	if _, err := h.validateMulticastContext(ctx, in.AppId); err != nil {
+		return nil, err
+	}
+
+	group, err := h.handler.multicast.Get(in.AppId, in.GroupId)
+	if err != nil && errors.GetErrType(err) != errors.NotFound {
+		return nil, err
+	}
+
+	if group != nil {
+		group.StartUpdate()
+	} else {
+		group = &multicast.Group{
+			AppID:   in.AppId,
+			GroupID: in.GroupId,
+		}
+	}
+
+	group.Description = in.Description
+	group.DevAddr = *in.DevAddr
+	group.NwkSKey = *in.NwkSKey
+	group.AppSKey = *in.AppSKey
+	group.FCntDown = in.FCntDown
+	group.GatewayIDs = in.GatewayIds
+
+	err = h.handler.multicast.Set(group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (h *handlerManager) DeleteMulticastGroup(ctx context.Context, in *pb.MulticastGroupIdentifier) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Multicast Group Identifier")
+	}
+	if _, err := h.validateMulticastContext(ctx, in.AppId); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.handler.multicast.Get(in.AppId, in.GroupId); err != nil {
+		return nil, err
+	}
+
+	err := h.handler.multicast.Delete(in.AppId, in.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (h *handlerManager) GetMulticastGroupsForApplication(ctx context.Context, in *pb.ApplicationIdentifier) (*pb.MulticastGroupList, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Application Identifier")
+	}
+	ctx, err := h.validateMulticastContext(ctx, in.AppId)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := api.LimitAndOffsetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &storage.ListOptions{Limit: limit, Offset: offset}
+	groups, err := h.handler.multicast.ListForApp(in.AppId, opts)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.MulticastGroupList{Groups: []*pb.MulticastGroup{}}
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		res.Groups = append(res.Groups, pbMulticastGroup(group))
+	}
+
+	total, selected := opts.GetTotalAndSelected()
+	header := metadata.Pairs(
+		"total", strconv.FormatUint(total, 10),
+		"selected", strconv.FormatUint(selected, 10),
+	)
+	grpc.SendHeader(ctx, header)
+
+	return res, nil
+}
+
+func (h *handlerManager) SendMulticastDownlink(ctx context.Context, in *pb.MulticastDownlinkMessage) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Multicast Downlink")
+	}
+	if _, err := h.validateMulticastContext(ctx, in.AppId); err != nil {
+		return nil, err
+	}
+
+	appDownlink := &types.DownlinkMessage{
+		FPort:      uint8(in.Port),
+		PayloadRaw: in.PayloadRaw,
+	}
+	if in.PayloadFields != "" {
+		if err := json.Unmarshal([]byte(in.PayloadFields), &appDownlink.PayloadFields); err != nil {
+			return nil, errors.NewErrInvalidArgument("PayloadFields", err.Error())
+		}
+	}
+
+	err := h.handler.HandleMulticastDownlink(in.AppId, in.GroupId, appDownlink)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (h *handlerManager) GetApplication(ctx context.Context, in *pb.ApplicationIdentifier) (*pb.Application, error) {
 	if err := in.Validate(); err != nil {
 		return nil, errors.NewErrInvalidArgument("Application Identifier", err.Error())
@@ -381,13 +752,31 @@ func (h *handlerManager) GetApplication(ctx context.Context, in *pb.ApplicationI
 		return nil, err
 	}
 
-	return &pb.Application{
+	pbApp := &pb.Application{
 		AppId:     app.AppID,
 		Decoder:   app.Decoder,
 		Converter: app.Converter,
 		Validator: app.Validator,
 		Encoder:   app.Encoder,
-	}, nil
+
+		PayloadFormat:        app.PayloadFormat,
+		ExposeMetadata:       app.ExposeMetadata,
+		DisabledIntegrations: app.DisabledIntegrations,
+
+		AdrAlgorithm: app.ADRAlgorithm,
+		AdrDataRate:  app.ADRDataRate,
+	}
+
+	if app.HTTPIntegration != nil {
+		pbApp.HttpIntegration = &pb.HTTPIntegration{
+			UplinkUrl:     app.HTTPIntegration.UplinkURL,
+			ActivationUrl: app.HTTPIntegration.ActivationURL,
+			EventUrl:      app.HTTPIntegration.EventURL,
+			Headers:       app.HTTPIntegration.Headers,
+		}
+	}
+
+	return pbApp, nil
 }
 
 func (h *handlerManager) RegisterApplication(ctx context.Context, in *pb.ApplicationIdentifier) (*empty.Empty, error) {
@@ -458,12 +847,39 @@ func (h *handlerManager) SetApplication(ctx context.Context, in *pb.Application)
 	app.Converter = in.Converter
 	app.Validator = in.Validator
 	app.Encoder = in.Encoder
+	app.PayloadFormat = in.PayloadFormat
+	app.ExposeMetadata = in.ExposeMetadata
+	app.DisabledIntegrations = in.DisabledIntegrations
+
+	if err := setApplicationADR(app, in); err != nil {
+		return nil, err
+	}
+
+	// The HTTP integration is only changed if it is set, and disabled if it is set without URLs
+	if in.HttpIntegration != nil && in.HttpIntegration.UplinkUrl == "" && in.HttpIntegration.ActivationUrl == "" && in.HttpIntegration.EventUrl == "" {
+		app.HTTPIntegration = nil
+	} else if in.HttpIntegration != nil {
+		integration := &application.HTTPIntegration{
+			UplinkURL:     in.HttpIntegration.UplinkUrl,
+			ActivationURL: in.HttpIntegration.ActivationUrl,
+			EventURL:      in.HttpIntegration.EventUrl,
+			Headers:       in.HttpIntegration.Headers,
+		}
+		if app.HTTPIntegration != nil && app.HTTPIntegration.DownlinkKey != "" {
+			integration.DownlinkKey = app.HTTPIntegration.DownlinkKey
+		} else if err := integration.GenerateDownlinkKey(); err != nil {
+			return nil, err
+		}
+		app.HTTPIntegration = integration
+	}
 
 	err = h.handler.applications.Set(app)
 	if err != nil {
 		return nil, err
 	}
 
+	h.handler.functionCache.Invalidate(app.AppID)
+
 	return &empty.Empty{}, nil
 }
 
@@ -501,12 +917,26 @@ func (h *handlerManager) DeleteApplication(ctx context.Context, in *pb.Applicati
 		}
 	}
 
+	// Get and delete all multicast groups for this application
+	groups, err := h.handler.multicast.ListForApp(in.AppId, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		err = h.handler.multicast.Delete(group.AppID, group.GroupID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Delete the Application
 	err = h.handler.applications.Delete(in.AppId)
 	if err != nil {
 		return nil, err
 	}
 
+	h.handler.functionCache.Invalidate(in.AppId)
+
 	token, _ := api.TokenFromContext(ctx)
 	err = h.handler.Discovery.RemoveAppID(in.AppId, token)
 	if err != nil {
@@ -0,0 +1,37 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSetApplicationADR(t *testing.T) {
+	a := New(t)
+	app := &application.Application{AppID: "app"}
+
+	a.So(setApplicationADR(app, &pb.Application{AppId: "app", AdrAlgorithm: "fixed"}), ShouldNotBeNil)
+
+	a.So(setApplicationADR(app, &pb.Application{AppId: "app", AdrAlgorithm: "fixed", AdrDataRate: "SF10BW125"}), ShouldBeNil)
+	a.So(app.ADRAlgorithm, ShouldEqual, "fixed")
+	a.So(app.ADRDataRate, ShouldEqual, "SF10BW125")
+
+	// Omitted settings are not changed
+	a.So(setApplicationADR(app, &pb.Application{AppId: "app"}), ShouldBeNil)
+	a.So(app.ADRAlgorithm, ShouldEqual, "fixed")
+	a.So(app.ADRDataRate, ShouldEqual, "SF10BW125")
+
+	a.So(setApplicationADR(app, &pb.Application{AppId: "app", AdrDataRate: "SF9BW125"}), ShouldBeNil)
+	a.So(app.ADRAlgorithm, ShouldEqual, "fixed")
+	a.So(app.ADRDataRate, ShouldEqual, "SF9BW125")
+
+	// The data rate is cleared when the algorithm is not fixed
+	a.So(setApplicationADR(app, &pb.Application{AppId: "app", AdrAlgorithm: "conservative"}), ShouldBeNil)
+	a.So(app.ADRAlgorithm, ShouldEqual, "conservative")
+	a.So(app.ADRDataRate, ShouldBeEmpty)
+}
@@ -4,9 +4,11 @@
 package handler
 
 import (
+	"fmt"
 	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/mqtt"
 )
@@ -14,88 +16,104 @@ import (
 // MQTTTimeout indicates how long we should wait for an MQTT publish
 var MQTTTimeout = 2 * time.Second
 
-// MQTTBufferSize indicates the size for uplink channel buffers
-var MQTTBufferSize = 10
+// MQTTIntegrationName is the name of the MQTT integration
+const MQTTIntegrationName = "mqtt"
 
-func (h *handler) HandleMQTT(username, password string, mqttBrokers ...string) error {
-	h.mqttClient = mqtt.NewClient(h.Ctx, "ttnhdl", username, password, mqttBrokers...)
+// NewMQTTIntegration returns a new integration that publishes to and subscribes on the given MQTT brokers
+func NewMQTTIntegration(username, password string, brokers ...string) Integration {
+	return &mqttIntegration{
+		username: username,
+		password: password,
+		brokers:  brokers,
+	}
+}
+
+type mqttIntegration struct {
+	username string
+	password string
+	brokers  []string
+	client   mqtt.Client
+	ctx      ttnlog.Interface
+}
+
+func (h *handler) WithMQTT(username, password string, brokers ...string) Handler {
+	return h.WithIntegration(NewMQTTIntegration(username, password, brokers...))
+}
+
+func (i *mqttIntegration) Name() string {
+	return MQTTIntegrationName
+}
+
+func (i *mqttIntegration) Open(handler IntegrationHandler) error {
+	var brokers []string
+	for _, broker := range i.brokers {
+		brokers = append(brokers, fmt.Sprintf("tcp://%s", broker))
+	}
+
+	i.ctx = handler.Ctx().WithField("Protocol", "MQTT")
+	i.client = mqtt.NewClient(handler.Ctx(), "ttnhdl", i.username, i.password, brokers...)
 
-	err := h.mqttClient.Connect()
+	err := i.client.Connect()
 	if err != nil {
 		return err
 	}
 
-	h.mqttUp = make(chan *types.UplinkMessage, MQTTBufferSize)
-	h.mqttEvent = make(chan *types.DeviceEvent, MQTTBufferSize)
-
-	token := h.mqttClient.SubscribeDownlink(func(client mqtt.Client, appID string, devID string, msg types.DownlinkMessage) {
+	token := i.client.SubscribeDownlink(func(client mqtt.Client, appID string, devID string, msg types.DownlinkMessage) {
 		down := &msg
 		down.DevID = devID
 		down.AppID = appID
-		go h.EnqueueDownlink(down)
+		go handler.EnqueueDownlink(down)
 	})
 	token.Wait()
 	if token.Error() != nil {
-		return err
+		return token.Error()
 	}
 
-	ctx := h.Ctx.WithField("Protocol", "MQTT")
+	return nil
+}
+
+func (i *mqttIntegration) Close() {
+	i.client.Disconnect()
+}
 
+func (i *mqttIntegration) wait(token mqtt.Token, what string) {
 	go func() {
-		for up := range h.mqttUp {
-			ctx.WithFields(ttnlog.Fields{
-				"DevID": up.DevID,
-				"AppID": up.AppID,
-			}).Debug("Publish Uplink")
-			upToken := h.mqttClient.PublishUplink(*up)
-			go func() {
-				if upToken.WaitTimeout(MQTTTimeout) {
-					if upToken.Error() != nil {
-						ctx.WithError(upToken.Error()).Warn("Could not publish Uplink")
-					}
-				} else {
-					ctx.Warn("Uplink publish timeout")
-				}
-			}()
-			if len(up.PayloadFields) > 0 {
-				fieldsToken := h.mqttClient.PublishUplinkFields(up.AppID, up.DevID, up.PayloadFields)
-				go func() {
-					if fieldsToken.WaitTimeout(MQTTTimeout) {
-						if fieldsToken.Error() != nil {
-							ctx.WithError(fieldsToken.Error()).Warn("Could not publish Uplink Fields")
-						}
-					} else {
-						ctx.Warn("Uplink Fields publish timeout")
-					}
-				}()
+		if token.WaitTimeout(MQTTTimeout) {
+			if token.Error() != nil {
+				i.ctx.WithError(token.Error()).Warnf("Could not publish %s", what)
 			}
+		} else {
+			i.ctx.Warnf("%s publish timeout", what)
 		}
 	}()
+}
 
-	go func() {
-		for event := range h.mqttEvent {
-			h.Ctx.WithFields(ttnlog.Fields{
-				"DevID": event.DevID,
-				"AppID": event.AppID,
-				"Event": event.Event,
-			}).Debug("Publish Event")
-			var token mqtt.Token
-			if event.DevID == "" {
-				token = h.mqttClient.PublishAppEvent(event.AppID, event.Event, event.Data)
-			} else {
-				token = h.mqttClient.PublishDeviceEvent(event.AppID, event.DevID, event.Event, event.Data)
-			}
-			go func() {
-				if token.WaitTimeout(MQTTTimeout) {
-					if token.Error() != nil {
-						h.Ctx.WithError(token.Error()).Warn("Could not publish Event")
-					}
-				} else {
-					h.Ctx.Warn("Event publish timeout")
-				}
-			}()
-		}
-	}()
+func (i *mqttIntegration) HandleUplink(_ *application.Application, up *types.UplinkMessage) error {
+	i.ctx.WithFields(ttnlog.Fields{
+		"DevID": up.DevID,
+		"AppID": up.AppID,
+	}).Debug("Publish Uplink")
+	i.wait(i.client.PublishUplink(*up), "Uplink")
+	if len(up.PayloadFields) > 0 {
+		i.wait(i.client.PublishUplinkFields(up.AppID, up.DevID, up.PayloadFields), "Uplink Fields")
+	}
+	return nil
+}
 
+func (i *mqttIntegration) HandleActivation(app *application.Application, event *types.DeviceEvent) error {
+	return i.HandleEvent(app, event)
+}
+
+func (i *mqttIntegration) HandleEvent(_ *application.Application, event *types.DeviceEvent) error {
+	i.ctx.WithFields(ttnlog.Fields{
+		"DevID": event.DevID,
+		"AppID": event.AppID,
+		"Event": event.Event,
+	}).Debug("Publish Event")
+	if event.DevID == "" {
+		i.wait(i.client.PublishAppEvent(event.AppID, event.Event, event.Data), "Event")
+	} else {
+		i.wait(i.client.PublishDeviceEvent(event.AppID, event.DevID, event.Event, event.Data), "Event")
+	}
 	return nil
 }
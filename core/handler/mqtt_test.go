@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/mqtt"
@@ -31,18 +32,25 @@ func TestHandleMQTT(t *testing.T) {
 	appID := "handler-mqtt-app1"
 	devID := "handler-mqtt-dev1"
 	h := &handler{
-		Component: &component.Component{Ctx: GetLogger(t, "TestHandleMQTT")},
-		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-mqtt"),
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHandleMQTT")},
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-mqtt"),
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-handle-mqtt"),
 	}
+	h.applications.Set(&application.Application{
+		AppID: appID,
+	})
 	h.devices.Set(&device.Device{
 		AppID: appID,
 		DevID: devID,
 	})
 	defer func() {
+		h.applications.Delete(appID)
 		h.devices.Delete(appID, devID)
 	}()
-	err = h.HandleMQTT("", "", fmt.Sprintf("tcp://%s", host))
+	i := NewMQTTIntegration("", "", host)
+	err = i.Open(&integrationHandler{handler: h, name: i.Name(), ctx: h.Ctx})
 	a.So(err, ShouldBeNil)
+	defer i.Close()
 
 	c.PublishDownlink(types.DownlinkMessage{
 		AppID:      appID,
@@ -62,14 +70,14 @@ func TestHandleMQTT(t *testing.T) {
 		wg.Done()
 	}).Wait()
 
-	h.mqttUp <- &types.UplinkMessage{
+	i.HandleUplink(nil, &types.UplinkMessage{
 		DevID:      devID,
 		AppID:      appID,
 		PayloadRaw: []byte{0xAA, 0xBC},
 		PayloadFields: map[string]interface{}{
 			"field": "value",
 		},
-	}
+	})
 
 	wg.Add(1)
 	c.SubscribeDeviceActivations(appID, devID, func(client mqtt.Client, r_appID string, r_devID string, req types.Activation) {
@@ -78,11 +86,11 @@ func TestHandleMQTT(t *testing.T) {
 		wg.Done()
 	}).Wait()
 
-	h.mqttEvent <- &types.DeviceEvent{
+	i.HandleActivation(nil, &types.DeviceEvent{
 		DevID: devID,
 		AppID: appID,
 		Event: types.ActivationEvent,
-	}
+	})
 
 	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
 }
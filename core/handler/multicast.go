@@ -0,0 +1,107 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/pointer"
+	"github.com/TheThingsNetwork/ttn/utils/random"
+	"github.com/brocaar/lorawan"
+)
+
+// HandleMulticastDownlink encrypts the downlink message once with the session of the multicast group and
+// sends it to the NetworkServer, that schedules it on the gateways of the group.
+func (h *handler) HandleMulticastDownlink(appID, groupID string, appDownlink *types.DownlinkMessage) (err error) {
+	if appDownlink.CorrelationID == "" {
+		appDownlink.CorrelationID = random.String(16)
+	}
+
+	ctx := h.Ctx.WithFields(ttnlog.Fields{
+		"AppID":         appID,
+		"GroupID":       groupID,
+		"CorrelationID": appDownlink.CorrelationID,
+	})
+
+	start := time.Now()
+	defer func() {
+		if err != nil {
+			ctx.WithError(err).Warn("Could not handle multicast downlink")
+		} else {
+			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled multicast downlink")
+		}
+	}()
+
+	group, err := h.multicast.Get(appID, groupID)
+	if err != nil {
+		return err
+	}
+
+	// Encode the fields with the payload format or encoder of the application
+	appDownlink.AppID = appID
+	if err = h.ConvertFieldsDown(ctx, appDownlink, nil, nil); err != nil {
+		return err
+	}
+
+	fPort := appDownlink.FPort
+	if fPort == 0 {
+		fPort = 1
+	}
+
+	// The FCntDown is incremented in the store, so that concurrent downlinks never use the same FCntDown
+	fCntDown, err := h.multicast.NextFCntDown(appID, groupID)
+	if err != nil {
+		return err
+	}
+
+	phyPayload := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr(group.DevAddr),
+				FCnt:    fCntDown,
+			},
+			FPort:      pointer.Uint8(fPort),
+			FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: appDownlink.PayloadRaw}},
+		},
+	}
+	if err = phyPayload.EncryptFRMPayload(lorawan.AES128Key(group.AppSKey)); err != nil {
+		return err
+	}
+	if err = phyPayload.SetMIC(lorawan.AES128Key(group.NwkSKey)); err != nil {
+		return err
+	}
+	payload, err := phyPayload.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	downlink := &pb_broker.DownlinkMessage{
+		Payload: payload,
+		AppId:   appID,
+		Multicast: &pb_broker.Multicast{
+			GroupId:    groupID,
+			GatewayIds: group.GatewayIDs,
+		},
+	}
+	downlink.UnmarshalPayload()
+	downlink.Trace = downlink.Trace.WithEvent("process downlink", trace.CorrelationIDKey, appDownlink.CorrelationID)
+
+	h.status.downlink.Mark(1)
+
+	ctx.WithField("NumGateways", len(group.GatewayIDs)).Debug("Send multicast downlink")
+
+	downlink.Trace = downlink.Trace.WithEvent(trace.ForwardEvent, "broker", h.ttnBrokerID)
+
+	h.downlink <- downlink
+
+	return nil
+}
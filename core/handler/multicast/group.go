@@ -0,0 +1,70 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package multicast
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/fatih/structs"
+)
+
+const currentDBVersion = "2.4.1"
+
+// Group contains the state of a multicast group. All devices in the group share the
+// same session, so that a downlink message has to be encrypted only once.
+type Group struct {
+	old *Group
+
+	AppID       string        `redis:"app_id"`
+	GroupID     string        `redis:"group_id"`
+	Description string        `redis:"description"`
+	DevAddr     types.DevAddr `redis:"dev_addr"`
+	NwkSKey     types.NwkSKey `redis:"nwk_s_key"`
+	AppSKey     types.AppSKey `redis:"app_s_key"`
+	FCntDown    uint32        `redis:"f_cnt_down"`
+
+	// GatewayIDs contains the IDs of the gateways that transmit the downlink messages of the group
+	GatewayIDs []string `redis:"gateway_ids"`
+
+	CreatedAt time.Time `redis:"created_at"`
+	UpdatedAt time.Time `redis:"updated_at"`
+}
+
+// StartUpdate stores the state of the group
+func (g *Group) StartUpdate() {
+	old := *g
+	g.old = &old
+}
+
+// DBVersion of the model
+func (g *Group) DBVersion() string {
+	return currentDBVersion
+}
+
+// ChangedFields returns the names of the changed fields since the last call to StartUpdate
+func (g Group) ChangedFields() (changed []string) {
+	new := structs.New(g)
+	fields := new.Names()
+	if g.old == nil {
+		return fields
+	}
+	old := structs.New(*g.old)
+
+	for _, field := range new.Fields() {
+		if !field.IsExported() || field.Name() == "old" {
+			continue
+		}
+		if !reflect.DeepEqual(field.Value(), old.Field(field.Name()).Value()) {
+			changed = append(changed, field.Name())
+		}
+	}
+
+	if len(changed) == 1 && changed[0] == "UpdatedAt" {
+		return []string{}
+	}
+
+	return
+}
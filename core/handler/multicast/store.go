@@ -0,0 +1,96 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package multicast
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"gopkg.in/redis.v5"
+)
+
+// Store interface for multicast Groups
+type Store interface {
+	ListForApp(appID string, opts *storage.ListOptions) ([]*Group, error)
+	Get(appID, groupID string) (*Group, error)
+	Set(new *Group, properties ...string) (err error)
+	NextFCntDown(appID, groupID string) (uint32, error)
+	Delete(appID, groupID string) error
+}
+
+const defaultRedisPrefix = "handler"
+const redisGroupPrefix = "multicast"
+
+// NewRedisGroupStore creates a new Redis-based multicast Group store
+// if an empty prefix is passed, a default prefix will be used.
+func NewRedisGroupStore(client *redis.Client, prefix string) Store {
+	if prefix == "" {
+		prefix = defaultRedisPrefix
+	}
+	store := storage.NewRedisMapStore(client, prefix+":"+redisGroupPrefix)
+	store.SetBase(Group{}, "")
+	return &RedisGroupStore{
+		store: store,
+	}
+}
+
+// RedisGroupStore stores multicast Groups in Redis.
+// - Groups are stored as a Hash
+type RedisGroupStore struct {
+	store *storage.RedisMapStore
+}
+
+// ListForApp lists all multicast groups for a specific Application
+func (s *RedisGroupStore) ListForApp(appID string, opts *storage.ListOptions) ([]*Group, error) {
+	groupsI, err := s.store.List(fmt.Sprintf("%s:*", appID), opts)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*Group, len(groupsI))
+	for i, groupI := range groupsI {
+		if group, ok := groupI.(Group); ok {
+			groups[i] = &group
+		}
+	}
+	return groups, nil
+}
+
+// Get a specific multicast Group
+func (s *RedisGroupStore) Get(appID, groupID string) (*Group, error) {
+	groupI, err := s.store.Get(fmt.Sprintf("%s:%s", appID, groupID))
+	if err != nil {
+		return nil, err
+	}
+	if group, ok := groupI.(Group); ok {
+		return &group, nil
+	}
+	return nil, errors.New("Database did not return a Group")
+}
+
+// Set a new multicast Group or update an existing one
+func (s *RedisGroupStore) Set(new *Group, properties ...string) (err error) {
+	now := time.Now()
+	new.UpdatedAt = now
+	if new.old == nil {
+		new.CreatedAt = now
+	}
+	return s.store.Set(fmt.Sprintf("%s:%s", new.AppID, new.GroupID), *new, properties...)
+}
+
+// NextFCntDown atomically increments the FCntDown of a multicast Group and returns the FCntDown that has to be
+// used for the next downlink message
+func (s *RedisGroupStore) NextFCntDown(appID, groupID string) (uint32, error) {
+	fCntDown, err := s.store.IncrBy(fmt.Sprintf("%s:%s", appID, groupID), "f_cnt_down", 1)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(fCntDown - 1), nil
+}
+
+// Delete a multicast Group
+func (s *RedisGroupStore) Delete(appID, groupID string) error {
+	return s.store.Delete(fmt.Sprintf("%s:%s", appID, groupID))
+}
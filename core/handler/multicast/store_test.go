@@ -0,0 +1,101 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package multicast
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestGroupStore(t *testing.T) {
+	a := New(t)
+
+	NewRedisGroupStore(GetRedisClient(), "")
+
+	s := NewRedisGroupStore(GetRedisClient(), "handler-test-multicast-store")
+
+	// Get non-existing
+	group, err := s.Get("AppID-1", "GroupID-1")
+	a.So(err, ShouldNotBeNil)
+	a.So(group, ShouldBeNil)
+
+	groups, err := s.ListForApp("AppID-1", nil)
+	a.So(err, ShouldBeNil)
+	a.So(groups, ShouldHaveLength, 0)
+
+	// Create
+	err = s.Set(&Group{
+		AppID:      "AppID-1",
+		GroupID:    "GroupID-1",
+		DevAddr:    types.DevAddr{1, 2, 3, 4},
+		GatewayIDs: []string{"gtw1", "gtw2"},
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s.Delete("AppID-1", "GroupID-1")
+	}()
+
+	// Get existing
+	group, err = s.Get("AppID-1", "GroupID-1")
+	a.So(err, ShouldBeNil)
+	a.So(group, ShouldNotBeNil)
+	a.So(group.DevAddr, ShouldEqual, types.DevAddr{1, 2, 3, 4})
+	a.So(group.GatewayIDs, ShouldResemble, []string{"gtw1", "gtw2"})
+
+	// Update
+	group.StartUpdate()
+	group.FCntDown = 42
+	err = s.Set(group)
+	a.So(err, ShouldBeNil)
+
+	group, err = s.Get("AppID-1", "GroupID-1")
+	a.So(err, ShouldBeNil)
+	a.So(group.FCntDown, ShouldEqual, 42)
+
+	// Next FCntDown
+	var wg sync.WaitGroup
+	fCnts := make(chan uint32, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fCnt, err := s.NextFCntDown("AppID-1", "GroupID-1")
+			a.So(err, ShouldBeNil)
+			fCnts <- fCnt
+		}()
+	}
+	wg.Wait()
+	close(fCnts)
+	seen := make(map[uint32]bool)
+	for fCnt := range fCnts {
+		a.So(fCnt, ShouldBeBetweenOrEqual, 42, 51)
+		a.So(seen[fCnt], ShouldBeFalse)
+		seen[fCnt] = true
+	}
+
+	group, err = s.Get("AppID-1", "GroupID-1")
+	a.So(err, ShouldBeNil)
+	a.So(group.FCntDown, ShouldEqual, 52)
+
+	_, err = s.NextFCntDown("AppID-1", "GroupID-2")
+	a.So(err, ShouldNotBeNil)
+
+	// List
+	groups, err = s.ListForApp("AppID-1", nil)
+	a.So(err, ShouldBeNil)
+	a.So(groups, ShouldHaveLength, 1)
+
+	// Delete
+	err = s.Delete("AppID-1", "GroupID-1")
+	a.So(err, ShouldBeNil)
+
+	// Get deleted
+	group, err = s.Get("AppID-1", "GroupID-1")
+	a.So(err, ShouldNotBeNil)
+	a.So(group, ShouldBeNil)
+}
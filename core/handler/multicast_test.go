@@ -0,0 +1,78 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/multicast"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHandleMulticastDownlink(t *testing.T) {
+	a := New(t)
+	appID := "app1"
+	groupID := "group1"
+	nwkSKey := types.NwkSKey{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	appSKey := types.AppSKey{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHandleMulticastDownlink")},
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-multicast-downlink"),
+		multicast:    multicast.NewRedisGroupStore(GetRedisClient(), "handler-test-multicast-downlink"),
+		downlink:     make(chan *pb_broker.DownlinkMessage, 1),
+	}
+	h.InitStatus()
+
+	// Group not found
+	err := h.HandleMulticastDownlink(appID, groupID, &types.DownlinkMessage{PayloadRaw: []byte{0xAA}})
+	a.So(err, ShouldNotBeNil)
+
+	h.multicast.Set(&multicast.Group{
+		AppID:      appID,
+		GroupID:    groupID,
+		DevAddr:    types.DevAddr{1, 2, 3, 4},
+		NwkSKey:    nwkSKey,
+		AppSKey:    appSKey,
+		FCntDown:   42,
+		GatewayIDs: []string{"gtw1", "gtw2"},
+	})
+	defer func() {
+		h.multicast.Delete(appID, groupID)
+	}()
+
+	err = h.HandleMulticastDownlink(appID, groupID, &types.DownlinkMessage{
+		FPort:      2,
+		PayloadRaw: []byte{0xAA, 0xBC},
+	})
+	a.So(err, ShouldBeNil)
+
+	dl := <-h.downlink
+	a.So(dl.AppId, ShouldEqual, appID)
+	a.So(dl.DevId, ShouldBeEmpty)
+	a.So(dl.Multicast.GroupId, ShouldEqual, groupID)
+	a.So(dl.Multicast.GatewayIds, ShouldResemble, []string{"gtw1", "gtw2"})
+	a.So(dl.Trace.GetCorrelationID(), ShouldNotBeEmpty)
+
+	var phy lorawan.PHYPayload
+	a.So(phy.UnmarshalBinary(dl.Payload), ShouldBeNil)
+	ok, err := phy.ValidateMIC(lorawan.AES128Key(nwkSKey))
+	a.So(err, ShouldBeNil)
+	a.So(ok, ShouldBeTrue)
+	a.So(phy.DecryptFRMPayload(lorawan.AES128Key(appSKey)), ShouldBeNil)
+	macPayload := phy.MACPayload.(*lorawan.MACPayload)
+	a.So(macPayload.FHDR.DevAddr, ShouldEqual, lorawan.DevAddr{1, 2, 3, 4})
+	a.So(macPayload.FHDR.FCnt, ShouldEqual, 42)
+	a.So(*macPayload.FPort, ShouldEqual, 2)
+	a.So(macPayload.FRMPayload[0].(*lorawan.DataPayload).Bytes, ShouldResemble, []byte{0xAA, 0xBC})
+
+	// The FCntDown of the group is incremented
+	group, _ := h.multicast.Get(appID, groupID)
+	a.So(group.FCntDown, ShouldEqual, 43)
+}
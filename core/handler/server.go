@@ -7,6 +7,7 @@ import (
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
 )
@@ -45,6 +46,20 @@ func (h *handlerRPC) Activate(ctx context.Context, activation *pb_broker.Dedupli
 	return res, nil
 }
 
+func (h *handlerRPC) TxAck(ctx context.Context, ack *pb_broker.TxAcknowledgment) (*empty.Empty, error) {
+	_, err := h.handler.ValidateNetworkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ack.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid TxAcknowledgment")
+	}
+	if err := h.handler.HandleTxAck(ack); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 // RegisterRPC registers this handler as a HandlerServer (github.com/TheThingsNetwork/ttn/api/handler)
 func (h *handler) RegisterRPC(s *grpc.Server) {
 	server := &handlerRPC{h}
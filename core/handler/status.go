@@ -49,5 +49,23 @@ func (h *handler) GetStatus() *pb.Status {
 		Rate5:  float32(activations.Rate5()),
 		Rate15: float32(activations.Rate15()),
 	}
+	for _, i := range h.integrations {
+		status.Integrations = append(status.Integrations, &pb.IntegrationStatus{
+			Name:        i.Name(),
+			Uplink:      meterRates(i.uplink),
+			Activations: meterRates(i.activations),
+			Events:      meterRates(i.events),
+			Errors:      meterRates(i.errors),
+		})
+	}
 	return status
 }
+
+func meterRates(meter metrics.Meter) *api.Rates {
+	snapshot := meter.Snapshot()
+	return &api.Rates{
+		Rate1:  float32(snapshot.Rate1()),
+		Rate5:  float32(snapshot.Rate5()),
+		Rate15: float32(snapshot.Rate15()),
+	}
+}
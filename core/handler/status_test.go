@@ -18,4 +18,10 @@ func TestStatus(t *testing.T) {
 	a.So(h.status, ShouldNotBeNil)
 	status := h.GetStatus()
 	a.So(status.Uplink.Rate1, ShouldEqual, 0)
+	a.So(status.Integrations, ShouldBeEmpty)
+	h.WithIntegration(&testIntegration{name: "test"})
+	status = h.GetStatus()
+	a.So(status.Integrations, ShouldHaveLength, 1)
+	a.So(status.Integrations[0].Name, ShouldEqual, "test")
+	a.So(status.Integrations[0].Uplink.Rate1, ShouldEqual, 0)
 }
@@ -6,9 +6,11 @@ package handler
 import (
 	"time"
 
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	"github.com/TheThingsNetwork/ttn/api/fields"
 	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 )
 
@@ -21,12 +23,12 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 	start := time.Now()
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.UplinkErrorEvent,
 				Data:  types.ErrorEventData{Error: err.Error()},
-			}
+			})
 			ctx.WithError(err).Warn("Could not handle uplink")
 			uplink.Trace = uplink.Trace.WithEvent(trace.DropEvent, "reason", err)
 		} else {
@@ -52,11 +54,17 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 		DevID: devID,
 	}
 
+	// Downlink that is returned by the payload functions
+	var decodedDownlink *types.DownlinkMessage
+
 	// Get Uplink Processors
 	processors := []UplinkProcessor{
 		h.ConvertFromLoRaWAN,
 		h.ConvertMetadata,
-		h.ConvertFieldsUp,
+		func(ctx ttnlog.Interface, ttnUp *pb_broker.DeduplicatedUplinkMessage, appUp *types.UplinkMessage, dev *device.Device) (err error) {
+			decodedDownlink, err = h.convertFieldsUp(ctx, ttnUp, appUp, dev)
+			return err
+		},
 	}
 
 	ctx.WithField("NumProcessors", len(processors)).Debug("Running Uplink Processors")
@@ -73,18 +81,32 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 		}
 	}
 
+	if dev.CurrentDownlink != nil && dev.CurrentDownlink.Expired(time.Now()) {
+		h.publishDownlinkExpired(appID, devID, dev.CurrentDownlink)
+		dev.SetCurrentDownlink(nil)
+	}
+
 	err = h.devices.Set(dev)
 	if err != nil {
 		return err
 	}
 	dev.StartUpdate()
 
-	// Publish Uplink
-	h.mqttUp <- appUplink
-	if h.amqpEnabled {
-		h.amqpUp <- appUplink
+	// Enqueueing updates the device, so the device has to be saved before and loaded again after. The downlink of the
+	// payload functions does not replace a confirmed downlink that is waiting for an acknowledgement.
+	if decodedDownlink != nil {
+		// Errors are emitted as downlink error events by enqueueDownlink
+		h.enqueueDownlink(decodedDownlink, true)
+		dev, err = h.devices.Get(appID, devID)
+		if err != nil {
+			return err
+		}
+		dev.StartUpdate()
 	}
 
+	// Publish Uplink
+	h.publishUplink(appUplink)
+
 	noDownlinkErrEvent := &types.DeviceEvent{
 		AppID: appID,
 		DevID: devID,
@@ -102,13 +124,13 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 
 		if len, _ := queue.Length(); len > 0 {
 			if uplink.ResponseTemplate != nil {
-				next, err := queue.Next()
+				next, err := h.nextDownlink(appID, devID, queue)
 				if err != nil {
 					return err
 				}
-				dev.CurrentDownlink = next
+				dev.SetCurrentDownlink(next)
 			} else {
-				h.mqttEvent <- noDownlinkErrEvent
+				h.publishEvent(noDownlinkErrEvent)
 				return nil
 			}
 		}
@@ -116,7 +138,7 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 
 	if uplink.ResponseTemplate == nil {
 		if dev.CurrentDownlink != nil {
-			h.mqttEvent <- noDownlinkErrEvent
+			h.publishEvent(noDownlinkErrEvent)
 		}
 		return nil
 	}
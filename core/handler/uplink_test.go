@@ -48,8 +48,8 @@ func TestHandleUplink(t *testing.T) {
 	defer func() {
 		h.applications.Delete(appID)
 	}()
-	h.mqttUp = make(chan *types.UplinkMessage)
-	h.mqttEvent = make(chan *types.DeviceEvent, 10)
+	h.appUp = make(chan *types.UplinkMessage)
+	h.appEvent = make(chan *types.DeviceEvent, 10)
 	h.downlink = make(chan *pb_broker.DownlinkMessage)
 
 	uplink, _ := buildLorawanUplink([]byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x00, 0x01, 0x00, 0x0A, 0x4D, 0xDA, 0x23, 0x99, 0x61, 0xD4})
@@ -70,7 +70,7 @@ func TestHandleUplink(t *testing.T) {
 	// Test Uplink, no downlink option available
 	wg.Add(1)
 	go func() {
-		<-h.mqttUp
+		<-h.appUp
 		wg.Done()
 	}()
 	err = h.HandleUplink(uplink)
@@ -82,7 +82,7 @@ func TestHandleUplink(t *testing.T) {
 	// Test Uplink, no downlink needed
 	wg.Add(1)
 	go func() {
-		<-h.mqttUp
+		<-h.appUp
 		wg.Done()
 	}()
 	downlink.Payload = downlinkEmpty
@@ -93,7 +93,7 @@ func TestHandleUplink(t *testing.T) {
 	// Test Uplink, ACK downlink needed
 	wg.Add(2)
 	go func() {
-		<-h.mqttUp
+		<-h.appUp
 		wg.Done()
 	}()
 	go func() {
@@ -108,7 +108,7 @@ func TestHandleUplink(t *testing.T) {
 	// Test Uplink, MAC downlink needed
 	wg.Add(2)
 	go func() {
-		<-h.mqttUp
+		<-h.appUp
 		wg.Done()
 	}()
 	go func() {
@@ -127,7 +127,7 @@ func TestHandleUplink(t *testing.T) {
 	h.devices.Set(dev)
 	wg.Add(2)
 	go func() {
-		<-h.mqttUp
+		<-h.appUp
 		wg.Done()
 	}()
 	go func() {
@@ -154,7 +154,7 @@ func TestHandleUplink(t *testing.T) {
 	h.devices.Set(dev)
 	wg.Add(2)
 	go func() {
-		<-h.mqttUp
+		<-h.appUp
 		wg.Done()
 	}()
 	go func() {
@@ -174,3 +174,52 @@ func TestHandleUplink(t *testing.T) {
 	a.So(dev.CurrentDownlink, ShouldNotBeNil)
 	a.So(dev.CurrentDownlink.PayloadRaw, ShouldResemble, []byte{0xaa, 0xbc})
 }
+
+func TestHandleUplinkDecodedDownlink(t *testing.T) {
+	a := New(t)
+	appID := "appid"
+	devID := "devid"
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHandleUplinkDecodedDownlink")},
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-uplink-decoded-downlink"),
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-handle-uplink-decoded-downlink"),
+	}
+	h.InitStatus()
+	dev := &device.Device{
+		AppID:           appID,
+		DevID:           devID,
+		CurrentDownlink: &types.DownlinkMessage{PayloadRaw: []byte{0xaa, 0xbc}, Confirmed: true},
+	}
+	h.devices.Set(dev)
+	defer func() {
+		h.devices.Delete(appID, devID)
+	}()
+	h.applications.Set(&application.Application{
+		AppID: appID,
+		Decoder: `function Decoder (bytes, port) {
+			return { _downlink: { port: 2, payload_raw: [ 1, 2 ] } };
+		}`,
+	})
+	defer func() {
+		h.applications.Delete(appID)
+	}()
+	h.appUp = make(chan *types.UplinkMessage, 1)
+	h.appEvent = make(chan *types.DeviceEvent, 10)
+
+	uplink, _ := buildLorawanUplink([]byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x00, 0x01, 0x00, 0x0A, 0x4D, 0xDA, 0x23, 0x99, 0x61, 0xD4})
+	err := h.HandleUplink(uplink)
+	a.So(err, ShouldBeNil)
+	<-h.appUp
+
+	// The confirmed downlink that is waiting for an acknowledgement is not replaced
+	dev, _ = h.devices.Get(appID, devID)
+	a.So(dev.CurrentDownlink, ShouldNotBeNil)
+	a.So(dev.CurrentDownlink.PayloadRaw, ShouldResemble, []byte{0xaa, 0xbc})
+
+	// The downlink of the decoder is enqueued
+	queue, _ := h.devices.DownlinkQueue(appID, devID)
+	next, _ := queue.Next()
+	a.So(next, ShouldNotBeNil)
+	a.So(next.FPort, ShouldEqual, 2)
+	a.So(next.PayloadRaw, ShouldResemble, []byte{1, 2})
+}
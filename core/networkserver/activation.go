@@ -11,18 +11,16 @@ import (
 	"github.com/TheThingsNetwork/go-utils/pseudorandom"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/otaa"
 	"github.com/brocaar/lorawan"
 )
 
 func (n *networkServer) getDevAddr(constraints ...string) (types.DevAddr, error) {
-	// Generate random DevAddr bytes
-	var devAddr types.DevAddr
-	pseudorandom.FillBytes(devAddr[:])
-
 	// Get a random prefix that matches the constraints
 	prefixes := n.GetPrefixesFor(constraints...)
 	if len(prefixes) == 0 {
@@ -32,17 +30,36 @@ func (n *networkServer) getDevAddr(constraints ...string) (types.DevAddr, error)
 	// Select a prefix
 	prefix := prefixes[pseudorandom.Intn(len(prefixes))]
 
-	// Apply the prefix
-	devAddr = devAddr.WithPrefix(prefix)
+	// Generate a random start address
+	var start types.DevAddr
+	pseudorandom.FillBytes(start[:])
+
+	// Select the least used DevAddr with the prefix, so that the Broker has to check the MIC of as few devices as
+	// possible
+	devAddr, used, err := n.devices.LeastUsedAddress(prefix, start)
+	if err != nil {
+		return types.DevAddr{}, err
+	}
+
+	if used > 0 && n.status != nil {
+		n.status.devAddrCollisions.Mark(1)
+	}
 
 	return devAddr, nil
 }
 
 func (n *networkServer) HandlePrepareActivation(activation *pb_broker.DeduplicatedDeviceActivationRequest) (*pb_broker.DeduplicatedDeviceActivationRequest, error) {
-	if activation.AppEui == nil || activation.DevEui == nil {
-		return nil, errors.NewErrInvalidArgument("Activation", "missing AppEUI or DevEUI")
+	var dev *device.Device
+	var rejoin *pb_lorawan.RejoinRequest
+	var err error
+	if pb_lorawan.IsRejoinRequest(activation.Payload) {
+		dev, rejoin, err = n.getRejoinDevice(activation)
+	} else {
+		if activation.AppEui == nil || activation.DevEui == nil {
+			return nil, errors.NewErrInvalidArgument("Activation", "missing AppEUI or DevEUI")
+		}
+		dev, err = n.devices.Get(*activation.AppEui, *activation.DevEui)
 	}
-	dev, err := n.devices.Get(*activation.AppEui, *activation.DevEui)
 	if err != nil {
 		return nil, err
 	}
@@ -67,11 +84,21 @@ func (n *networkServer) HandlePrepareActivation(activation *pb_broker.Deduplicat
 		return nil, errors.NewErrInvalidArgument("Activation", "missing LoRaWAN metadata")
 	}
 
-	// Allocate a  device address
-	activation.Trace = activation.Trace.WithEvent("allocate devaddr")
-	devAddr, err := n.getDevAddr(activationConstraints...)
-	if err != nil {
-		return nil, err
+	// The AppEUI is not known to the Router for RejoinRequests of type 0 and 2
+	if lorawanMeta.AppEui == nil {
+		lorawanMeta.AppEui = &dev.AppEUI
+	}
+
+	// Allocate a  device address, RejoinRequests of type 2 only renew the session keys
+	var devAddr types.DevAddr
+	if rejoin != nil && rejoin.RejoinType == 2 {
+		devAddr = dev.DevAddr
+	} else {
+		activation.Trace = activation.Trace.WithEvent("allocate devaddr")
+		devAddr, err = n.getDevAddr(activationConstraints...)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Set the DevAddr in the Activation Metadata
@@ -103,6 +130,13 @@ func (n *networkServer) HandlePrepareActivation(activation *pb_broker.Deduplicat
 	if err != nil {
 		return nil, err
 	}
+
+	// Indicate to LoRaWAN 1.1 devices that the network supports LoRaWAN 1.1
+	if dev.IsLoRaWAN11() {
+		if err := otaa.SetOptNeg(phyBytes); err != nil {
+			return nil, err
+		}
+	}
 	activation.ResponseTemplate.Payload = phyBytes
 
 	return activation, nil
@@ -131,13 +165,20 @@ func (n *networkServer) HandleActivate(activation *pb_handler.DeviceActivationRe
 	dev.UpdatedAt = time.Now()
 	dev.DevAddr = *lorawan.DevAddr
 	dev.NwkSKey = *lorawan.NwkSKey
+	if lorawan.SNwkSIntKey != nil && lorawan.NwkSEncKey != nil {
+		dev.SNwkSIntKey = *lorawan.SNwkSIntKey
+		dev.NwkSEncKey = *lorawan.NwkSEncKey
+	}
 	dev.FCntUp = 0
-	dev.FCntDown = 0
-	dev.ADR = device.ADRSettings{Band: dev.ADR.Band, Margin: dev.ADR.Margin}
+	dev.NFCntDown = 0
+	dev.AFCntDown = 0
+	dev.RJCount0 = 0
+	dev.ResetADR()
 
-	if band := meta.GetLorawan().GetFrequencyPlan().String(); band != "" {
-		dev.ADR.Band = band
-	}
+	// The device starts the new session with the defaults of the frequency plan
+	queueMACSettings(dev, device.MACSettings{})
+
+	dev.ADR.Band = frequencyPlanName(lorawan.FrequencyPlanName, lorawan.FrequencyPlan)
 
 	err = n.devices.Set(dev)
 	if err != nil {
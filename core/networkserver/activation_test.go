@@ -137,3 +137,79 @@ func TestHandleActivate(t *testing.T) {
 	})
 	a.So(err, ShouldBeNil)
 }
+
+func TestGetDevAddr(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		prefixes: map[types.DevAddrPrefix][]string{
+			types.DevAddrPrefix{DevAddr: [4]byte{0x26, 0x00, 0x00, 0x00}, Length: 31}: []string{"otaa"},
+			types.DevAddrPrefix{DevAddr: [4]byte{0x26, 0x00, 0x01, 0x00}, Length: 32}: []string{"abp"},
+		},
+		devices: device.NewRedisDeviceStore(GetRedisClient(), "test-get-devaddr"),
+	}
+	ns.InitStatus()
+	defer func() {
+		keys, _ := GetRedisClient().Keys("*test-get-devaddr*").Result()
+		for _, key := range keys {
+			GetRedisClient().Del(key).Result()
+		}
+	}()
+
+	_, err := ns.getDevAddr("otaa", "abp")
+	a.So(err, ShouldNotBeNil)
+
+	// The free DevAddr is preferred over the one that is already in use
+	a.So(ns.devices.Set(&device.Device{
+		AppEUI:  types.AppEUI(getEUI(0, 0, 0, 0, 0, 0, 4, 1)),
+		DevEUI:  types.DevEUI(getEUI(0, 0, 0, 0, 0, 0, 4, 1)),
+		DevAddr: getDevAddr(0x26, 0x00, 0x00, 0x00),
+	}), ShouldBeNil)
+	for i := 0; i < 10; i++ {
+		devAddr, err := ns.getDevAddr("otaa")
+		a.So(err, ShouldBeNil)
+		a.So(devAddr, ShouldEqual, getDevAddr(0x26, 0x00, 0x00, 0x01))
+	}
+	a.So(ns.status.devAddrCollisions.Count(), ShouldEqual, 0)
+
+	// A collision is counted if all DevAddrs are in use
+	a.So(ns.devices.Set(&device.Device{
+		AppEUI:  types.AppEUI(getEUI(0, 0, 0, 0, 0, 0, 4, 2)),
+		DevEUI:  types.DevEUI(getEUI(0, 0, 0, 0, 0, 0, 4, 2)),
+		DevAddr: getDevAddr(0x26, 0x00, 0x01, 0x00),
+	}), ShouldBeNil)
+	devAddr, err := ns.getDevAddr("abp")
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, getDevAddr(0x26, 0x00, 0x01, 0x00))
+	a.So(ns.status.devAddrCollisions.Count(), ShouldEqual, 1)
+
+	// The free DevAddrs of a prefix that is almost full are found
+	ns.prefixes[types.DevAddrPrefix{DevAddr: [4]byte{0x26, 0x00, 0x02, 0x00}, Length: 24}] = []string{"small"}
+	setDevice := func(i int, addr byte) {
+		a.So(ns.devices.Set(&device.Device{
+			AppEUI:  types.AppEUI(getEUI(0, 0, 0, 0, 0, 5, byte(i>>8), byte(i))),
+			DevEUI:  types.DevEUI(getEUI(0, 0, 0, 0, 0, 5, byte(i>>8), byte(i))),
+			DevAddr: getDevAddr(0x26, 0x00, 0x02, addr),
+		}), ShouldBeNil)
+	}
+	for i := 0; i < 250; i++ {
+		setDevice(i, byte(i))
+	}
+	for i := 0; i < 20; i++ {
+		devAddr, err := ns.getDevAddr("small")
+		a.So(err, ShouldBeNil)
+		a.So(devAddr[3], ShouldBeGreaterThanOrEqualTo, 250)
+	}
+	a.So(ns.status.devAddrCollisions.Count(), ShouldEqual, 1)
+
+	// The least used DevAddr is selected if all DevAddrs of the prefix are in use
+	for i := 250; i < 256; i++ {
+		setDevice(i, byte(i))
+	}
+	for i := 256; i < 511; i++ {
+		setDevice(i, byte(i-255))
+	}
+	devAddr, err = ns.getDevAddr("small")
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, getDevAddr(0x26, 0x00, 0x02, 0x00))
+	a.So(ns.status.devAddrCollisions.Count(), ShouldEqual, 2)
+}
@@ -29,6 +29,17 @@ func maxSNR(frames []*device.Frame) float32 {
 	return max
 }
 
+func averageSNR(frames []*device.Frame) float32 {
+	if len(frames) == 0 {
+		return 0
+	}
+	var sum float32
+	for _, frame := range frames {
+		sum += frame.SNR
+	}
+	return sum / float32(len(frames))
+}
+
 func lossPercentage(frames []*device.Frame) int {
 	if len(frames) == 0 {
 		return 0
@@ -38,6 +49,26 @@ func lossPercentage(frames []*device.Frame) int {
 	return int(math.Floor((float64(loss) / float64(sentPackets) * 100) + .5))
 }
 
+// frequencyPlanName returns the name of the frequency plan that the gateway reported, which can be a custom frequency
+// plan. The LoRaWAN frequency plan is used if the gateway did not report a name that is known to the NetworkServer.
+func frequencyPlanName(name string, frequencyPlan pb_lorawan.FrequencyPlan) string {
+	if name != "" {
+		if _, err := band.Get(name); err == nil {
+			return name
+		}
+	}
+	return frequencyPlan.String()
+}
+
+// linkADRReqLength returns the length of the LinkADRReqs that are sent to the device
+func linkADRReqLength(dev *device.Device) int {
+	fp, err := band.Get(dev.ADR.Band)
+	if err != nil {
+		return 5
+	}
+	return 5 * len(fp.ChMaskBlocks(0))
+}
+
 func (n *networkServer) handleUplinkADR(message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) error {
 	lorawanUplinkMac := message.GetMessage().GetLorawan().GetMacPayload()
 	lorawanDownlinkMac := message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload()
@@ -56,7 +87,8 @@ func (n *networkServer) handleUplinkADR(message *pb_broker.DeduplicatedUplinkMes
 			n.Ctx.WithError(err).Error("Could not push frame for device")
 		}
 		if dev.ADR.Band == "" {
-			dev.ADR.Band = message.GetProtocolMetadata().GetLorawan().GetFrequencyPlan().String()
+			lorawan := message.GetProtocolMetadata().GetLorawan()
+			dev.ADR.Band = frequencyPlanName(lorawan.GetFrequencyPlanName(), lorawan.GetFrequencyPlan())
 		}
 
 		dataRate := message.GetProtocolMetadata().GetLorawan().GetDataRate()
@@ -91,17 +123,6 @@ func (n *networkServer) handleDownlinkADR(message *pb_broker.DownlinkMessage, de
 		return nil
 	}
 
-	history, err := n.devices.Frames(dev.AppEUI, dev.DevEUI)
-
-	frames, err := history.Get()
-	if err != nil {
-		return err
-	}
-	if len(frames) < device.FramesHistorySize {
-		return nil
-	}
-
-	frames = frames[:device.FramesHistorySize]
 	// Check settings
 	if dev.ADR.DataRate == "" {
 		return nil
@@ -123,8 +144,36 @@ func (n *networkServer) handleDownlinkADR(message *pb_broker.DownlinkMessage, de
 		dev.ADR.NbTrans = 1
 	}
 
+	// Devices without an ADR algorithm or fixed data rate use the ones of the application, that the Handler adds to
+	// the downlink. The ADR algorithms read the fixed data rate from the device, so it is only set while they run.
+	algorithmName := dev.ADR.Algorithm
+	if algorithmName == "" {
+		algorithmName = message.AppAdrAlgorithm
+	}
+	algorithm, err := GetADRAlgorithm(algorithmName)
+	if err != nil && dev.ADR.Algorithm == "" {
+		n.Ctx.WithField("Algorithm", algorithmName).Warn("Unknown ADR algorithm of application, using default")
+		algorithm, err = GetADRAlgorithm("")
+	}
+	if err != nil {
+		return err
+	}
+	if dev.ADR.FixedDataRate == "" && message.AppAdrDataRate != "" {
+		dev.ADR.FixedDataRate = message.AppAdrDataRate
+		defer func() { dev.ADR.FixedDataRate = "" }()
+	}
+
+	history, err := n.devices.Frames(dev.AppEUI, dev.DevEUI)
+	if err != nil {
+		return err
+	}
+	frames, err := history.Get()
+	if err != nil {
+		return err
+	}
+
 	// Calculate ADR settings
-	dataRate, txPower, err := fp.ADRSettings(dev.ADR.DataRate, dev.ADR.TxPower, maxSNR(frames), float32(dev.ADR.Margin))
+	dataRate, txPower, nbTrans, err := algorithm.ADRSettings(&fp, dev, frames)
 	if err == band.ErrADRUnavailable {
 		return nil
 	}
@@ -140,62 +189,40 @@ func (n *networkServer) handleDownlinkADR(message *pb_broker.DownlinkMessage, de
 		powerIdx, _ = fp.GetTxPowerIndexFor(fp.DefaultTXPower)
 	}
 
-	var nbTrans = dev.ADR.NbTrans
-	if dev.ADR.DataRate == dataRate && dev.ADR.TxPower == txPower && !dev.Options.DisableFCntCheck {
-		lossPercentage := lossPercentage(frames)
-		switch {
-		case lossPercentage <= 5:
-			nbTrans--
-		case lossPercentage <= 10:
-			// don't change
-		case lossPercentage <= 30:
-			nbTrans++
-		default:
-			nbTrans += 2
-		}
-		if nbTrans < 1 {
-			nbTrans = 1
-		}
-		if nbTrans > 3 {
-			nbTrans = 3
-		}
-	}
-
 	if dev.ADR.DataRate == dataRate && dev.ADR.TxPower == txPower && dev.ADR.NbTrans == nbTrans {
 		return nil
 	}
 	dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans = dataRate, txPower, nbTrans
 
-	// Set MAC command
+	// Set MAC commands, frequency plans with sub-bands need a block of LinkADRReqs for the channel mask, of which
+	// the device uses the data rate, TX power and NbTrans of the last one
 	lorawanDownlinkMac := message.GetMessage().GetLorawan().GetMacPayload()
-	response := &lorawan.LinkADRReqPayload{
-		DataRate: uint8(drIdx),
-		TXPower:  uint8(powerIdx),
-		Redundancy: lorawan.Redundancy{
-			ChMaskCntl: 0, // Different for US/AU
-			NbRep:      uint8(dev.ADR.NbTrans),
-		},
-	}
-	for i, ch := range fp.UplinkChannels { // Different for US/AU
-		for _, dr := range ch.DataRates {
-			if dr == drIdx {
-				response.ChMask[i] = true
-			}
+	var linkADRReqs []pb_lorawan.MACCommand
+	for _, block := range fp.ChMaskBlocks(drIdx) {
+		response := &lorawan.LinkADRReqPayload{
+			DataRate: uint8(drIdx),
+			TXPower:  uint8(powerIdx),
+			ChMask:   block.ChMask,
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: block.ChMaskCntl,
+				NbRep:      uint8(dev.ADR.NbTrans),
+			},
 		}
+		responsePayload, _ := response.MarshalBinary()
+		linkADRReqs = append(linkADRReqs, pb_lorawan.MACCommand{
+			Cid:     uint32(lorawan.LinkADRReq),
+			Payload: responsePayload,
+		})
 	}
-	responsePayload, _ := response.MarshalBinary()
 
 	// Remove LinkADRReq if already added
-	fOpts := make([]pb_lorawan.MACCommand, 0, len(lorawanDownlinkMac.FOpts)+1)
+	fOpts := make([]pb_lorawan.MACCommand, 0, len(lorawanDownlinkMac.FOpts)+len(linkADRReqs))
 	for _, existing := range lorawanDownlinkMac.FOpts {
 		if existing.Cid != uint32(lorawan.LinkADRReq) {
 			fOpts = append(fOpts, existing)
 		}
 	}
-	fOpts = append(fOpts, pb_lorawan.MACCommand{
-		Cid:     uint32(lorawan.LinkADRReq),
-		Payload: responsePayload,
-	})
+	fOpts = append(fOpts, linkADRReqs...)
 
 	lorawanDownlinkMac.FOpts = fOpts
 
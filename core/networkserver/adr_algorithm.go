@@ -0,0 +1,176 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"fmt"
+
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// ADRAlgorithm calculates the settings of devices that use ADR
+type ADRAlgorithm interface {
+	// ADRSettings returns the data rate, TX power and number of transmissions that the device should use, based on
+	// the current ADR settings of the device and the history of its uplink frames (newest first). The current
+	// settings are returned if they should not be changed. band.ErrADRUnavailable is returned if the frequency plan
+	// does not support ADR.
+	ADRSettings(fp *band.FrequencyPlan, dev *device.Device, frames []*device.Frame) (dataRate string, txPower int, nbTrans int, err error)
+}
+
+// Names of the ADR algorithms
+const (
+	ADRAlgorithmDefault      = "default"
+	ADRAlgorithmConservative = "conservative"
+	ADRAlgorithmFixed        = "fixed"
+)
+
+var adrAlgorithms = map[string]ADRAlgorithm{
+	ADRAlgorithmDefault:      defaultADR{},
+	ADRAlgorithmConservative: conservativeADR{},
+	ADRAlgorithmFixed:        fixedADR{},
+}
+
+// RegisterADRAlgorithm registers an ADRAlgorithm, so that it can be selected for devices by its name. This should
+// be called before the NetworkServer is started.
+func RegisterADRAlgorithm(name string, algorithm ADRAlgorithm) {
+	adrAlgorithms[name] = algorithm
+}
+
+// GetADRAlgorithm returns the ADRAlgorithm with the given name, or the default ADRAlgorithm if the name is empty
+func GetADRAlgorithm(name string) (ADRAlgorithm, error) {
+	if name == "" {
+		name = ADRAlgorithmDefault
+	}
+	if algorithm, ok := adrAlgorithms[name]; ok {
+		return algorithm, nil
+	}
+	return nil, errors.NewErrNotFound(fmt.Sprintf("ADR algorithm %s", name))
+}
+
+// adrLossThresholds are the packet loss percentages up to which the number of transmissions is decreased, kept
+// and increased by one. Above the last threshold, the number of transmissions is increased by two.
+type adrLossThresholds [3]int
+
+// nbTrans returns the number of transmissions (1-3) for the given packet loss percentage
+func (t adrLossThresholds) nbTrans(nbTrans int, loss int) int {
+	switch {
+	case loss <= t[0]:
+		nbTrans--
+	case loss <= t[1]:
+		// don't change
+	case loss <= t[2]:
+		nbTrans++
+	default:
+		nbTrans += 2
+	}
+	if nbTrans < 1 {
+		nbTrans = 1
+	}
+	if nbTrans > 3 {
+		nbTrans = 3
+	}
+	return nbTrans
+}
+
+// defaultADR uses the maximum SNR of the last frames. The number of transmissions is only changed if the data rate
+// and TX power stay the same.
+type defaultADR struct{}
+
+var defaultADRLossThresholds = adrLossThresholds{5, 10, 30}
+
+func (defaultADR) ADRSettings(fp *band.FrequencyPlan, dev *device.Device, frames []*device.Frame) (string, int, int, error) {
+	if len(frames) < device.FramesHistorySize {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, nil
+	}
+	frames = frames[:device.FramesHistorySize]
+
+	dataRate, txPower, err := fp.ADRSettings(dev.ADR.DataRate, dev.ADR.TxPower, maxSNR(frames), float32(dev.ADR.Margin))
+	if err != nil {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, err
+	}
+
+	nbTrans := dev.ADR.NbTrans
+	if dev.ADR.DataRate == dataRate && dev.ADR.TxPower == txPower && !dev.Options.DisableFCntCheck {
+		nbTrans = defaultADRLossThresholds.nbTrans(nbTrans, lossPercentage(frames))
+	}
+
+	return dataRate, txPower, nbTrans, nil
+}
+
+// conservativeADR uses the average SNR of the last frames. It does not increase the data rate or decrease the TX
+// power of devices that lose more than conservativeADRMaxLoss percent of their frames, and it increases the number
+// of transmissions at a lower packet loss than the default algorithm.
+type conservativeADR struct{}
+
+var conservativeADRLossThresholds = adrLossThresholds{1, 5, 15}
+
+const conservativeADRMaxLoss = 10
+
+func (conservativeADR) ADRSettings(fp *band.FrequencyPlan, dev *device.Device, frames []*device.Frame) (string, int, int, error) {
+	if len(frames) < device.FramesHistorySize {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, nil
+	}
+	frames = frames[:device.FramesHistorySize]
+
+	dataRate, txPower, err := fp.ADRSettings(dev.ADR.DataRate, dev.ADR.TxPower, averageSNR(frames), float32(dev.ADR.Margin))
+	if err != nil {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, err
+	}
+
+	// The packet loss can not be determined if the frame counters of the device are reset
+	if dev.Options.DisableFCntCheck {
+		return dataRate, txPower, dev.ADR.NbTrans, nil
+	}
+
+	loss := lossPercentage(frames)
+	if loss > conservativeADRMaxLoss {
+		currentIdx, err := fp.GetDataRateIndexFor(dev.ADR.DataRate)
+		if err != nil {
+			return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, err
+		}
+		desiredIdx, err := fp.GetDataRateIndexFor(dataRate)
+		if err != nil {
+			return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, err
+		}
+		if desiredIdx > currentIdx {
+			dataRate = dev.ADR.DataRate
+		}
+		if txPower < dev.ADR.TxPower {
+			txPower = dev.ADR.TxPower
+		}
+	}
+
+	return dataRate, txPower, conservativeADRLossThresholds.nbTrans(dev.ADR.NbTrans, loss), nil
+}
+
+// fixedADR tells devices to use the data rate that is configured for the device, with the default TX power of the
+// frequency plan. The number of transmissions is not changed.
+type fixedADR struct{}
+
+func (fixedADR) ADRSettings(fp *band.FrequencyPlan, dev *device.Device, frames []*device.Frame) (string, int, int, error) {
+	if fp.ADR == nil {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, band.ErrADRUnavailable
+	}
+	if dev.ADR.FixedDataRate == "" {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, errors.NewErrInvalidArgument("ADR", "no data rate for fixed ADR algorithm")
+	}
+	if err := validateFixedDataRate(fp, dev.ADR.FixedDataRate); err != nil {
+		return dev.ADR.DataRate, dev.ADR.TxPower, dev.ADR.NbTrans, err
+	}
+	return dev.ADR.FixedDataRate, fp.DefaultTXPower, dev.ADR.NbTrans, nil
+}
+
+// validateFixedDataRate returns an error if the data rate can not be used for ADR in the frequency plan
+func validateFixedDataRate(fp *band.FrequencyPlan, dataRate string) error {
+	drIdx, err := fp.GetDataRateIndexFor(dataRate)
+	if err != nil {
+		return errors.NewErrInvalidArgument("ADR", fmt.Sprintf("data rate %s is not in the frequency plan", dataRate))
+	}
+	if fp.ADR != nil && (drIdx < fp.ADR.MinDataRate || drIdx > fp.ADR.MaxDataRate) {
+		return errors.NewErrInvalidArgument("ADR", fmt.Sprintf("data rate %s can not be used for ADR in the frequency plan", dataRate))
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	. "github.com/smartystreets/assertions"
+)
+
+func adrFrames(snr float32, fCnts ...uint32) []*device.Frame {
+	frames := make([]*device.Frame, 0, len(fCnts))
+	for i := len(fCnts) - 1; i >= 0; i-- {
+		frames = append(frames, &device.Frame{FCnt: fCnts[i], SNR: snr, GatewayCount: 1})
+	}
+	return frames
+}
+
+func adrFCnts(first, last uint32) (fCnts []uint32) {
+	for fCnt := first; fCnt <= last; fCnt++ {
+		fCnts = append(fCnts, fCnt)
+	}
+	return
+}
+
+func TestGetADRAlgorithm(t *testing.T) {
+	a := New(t)
+
+	algorithm, err := GetADRAlgorithm("")
+	a.So(err, ShouldBeNil)
+	a.So(algorithm, ShouldResemble, defaultADR{})
+
+	algorithm, err = GetADRAlgorithm(ADRAlgorithmConservative)
+	a.So(err, ShouldBeNil)
+	a.So(algorithm, ShouldResemble, conservativeADR{})
+
+	_, err = GetADRAlgorithm("unknown")
+	a.So(err, ShouldNotBeNil)
+
+	RegisterADRAlgorithm("unknown", fixedADR{})
+	defer delete(adrAlgorithms, "unknown")
+	_, err = GetADRAlgorithm("unknown")
+	a.So(err, ShouldBeNil)
+}
+
+func TestADRLossThresholds(t *testing.T) {
+	a := New(t)
+	thresholds := adrLossThresholds{5, 10, 30}
+	a.So(thresholds.nbTrans(1, 0), ShouldEqual, 1)
+	a.So(thresholds.nbTrans(2, 5), ShouldEqual, 1)
+	a.So(thresholds.nbTrans(2, 10), ShouldEqual, 2)
+	a.So(thresholds.nbTrans(2, 30), ShouldEqual, 3)
+	a.So(thresholds.nbTrans(1, 31), ShouldEqual, 3)
+	a.So(thresholds.nbTrans(3, 50), ShouldEqual, 3)
+}
+
+func TestDefaultADR(t *testing.T) {
+	a := New(t)
+	fp, _ := band.Get("EU_863_870")
+	dev := &device.Device{ADR: device.ADRSettings{DataRate: "SF8BW125", TxPower: 14, NbTrans: 1, Margin: 15}}
+
+	// Not enough frames
+	dataRate, txPower, nbTrans, err := defaultADR{}.ADRSettings(&fp, dev, adrFrames(10, adrFCnts(0, 5)...))
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF8BW125")
+	a.So(txPower, ShouldEqual, 14)
+	a.So(nbTrans, ShouldEqual, 1)
+
+	// The maximum SNR is used
+	frames := adrFrames(0, adrFCnts(0, 19)...)
+	frames[3].SNR = 10
+	dataRate, txPower, nbTrans, err = defaultADR{}.ADRSettings(&fp, dev, frames)
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF7BW125")
+	a.So(txPower, ShouldEqual, 14)
+	a.So(nbTrans, ShouldEqual, 1)
+
+	// No ADR in China
+	fp, _ = band.Get("CN_470_510")
+	_, _, _, err = defaultADR{}.ADRSettings(&fp, dev, frames)
+	a.So(err, ShouldEqual, band.ErrADRUnavailable)
+}
+
+func TestConservativeADR(t *testing.T) {
+	a := New(t)
+	fp, _ := band.Get("EU_863_870")
+	dev := &device.Device{ADR: device.ADRSettings{DataRate: "SF8BW125", TxPower: 14, NbTrans: 1, Margin: 15}}
+
+	// The average SNR is used
+	frames := adrFrames(0, adrFCnts(0, 19)...)
+	frames[3].SNR = 10
+	dataRate, txPower, nbTrans, err := conservativeADR{}.ADRSettings(&fp, dev, frames)
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF8BW125")
+	a.So(txPower, ShouldEqual, 14)
+	a.So(nbTrans, ShouldEqual, 1)
+
+	dataRate, txPower, nbTrans, err = conservativeADR{}.ADRSettings(&fp, dev, adrFrames(10, adrFCnts(0, 19)...))
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF7BW125")
+	a.So(txPower, ShouldEqual, 14)
+	a.So(nbTrans, ShouldEqual, 1)
+
+	// 4 of 24 frames lost: the data rate is not increased and the number of transmissions is increased
+	dataRate, txPower, nbTrans, err = conservativeADR{}.ADRSettings(&fp, dev, adrFrames(10, append(adrFCnts(0, 18), 23)...))
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF8BW125")
+	a.So(txPower, ShouldEqual, 14)
+	a.So(nbTrans, ShouldEqual, 3)
+
+	// 2 of 22 frames lost
+	dataRate, _, nbTrans, err = conservativeADR{}.ADRSettings(&fp, dev, adrFrames(10, append(adrFCnts(0, 18), 21)...))
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF7BW125")
+	a.So(nbTrans, ShouldEqual, 2)
+
+	// The packet loss is ignored if the frame counter check is disabled
+	dev.Options.DisableFCntCheck = true
+	dataRate, _, nbTrans, err = conservativeADR{}.ADRSettings(&fp, dev, adrFrames(10, append(adrFCnts(0, 18), 23)...))
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF7BW125")
+	a.So(nbTrans, ShouldEqual, 1)
+}
+
+func TestFixedADR(t *testing.T) {
+	a := New(t)
+	fp, _ := band.Get("EU_863_870")
+	dev := &device.Device{ADR: device.ADRSettings{DataRate: "SF7BW125", TxPower: 5, NbTrans: 2}}
+
+	_, _, _, err := fixedADR{}.ADRSettings(&fp, dev, nil)
+	a.So(err, ShouldNotBeNil)
+
+	dev.ADR.FixedDataRate = "SF10BW125"
+	dataRate, txPower, nbTrans, err := fixedADR{}.ADRSettings(&fp, dev, nil)
+	a.So(err, ShouldBeNil)
+	a.So(dataRate, ShouldEqual, "SF10BW125")
+	a.So(txPower, ShouldEqual, fp.DefaultTXPower)
+	a.So(nbTrans, ShouldEqual, 2)
+
+	// Data rates that can not be used for ADR in the frequency plan
+	dev.ADR.FixedDataRate = "SF8BW500"
+	_, _, _, err = fixedADR{}.ADRSettings(&fp, dev, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(validateFixedDataRate(&fp, "SF7BW250"), ShouldNotBeNil)
+	a.So(validateFixedDataRate(&fp, "SF12BW125"), ShouldBeNil)
+
+	fp, _ = band.Get("CN_470_510")
+	_, _, _, err = fixedADR{}.ADRSettings(&fp, dev, nil)
+	a.So(err, ShouldEqual, band.ErrADRUnavailable)
+}
@@ -13,6 +13,8 @@ import (
 	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
 	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
@@ -79,6 +81,18 @@ func TestLossPercentage(t *testing.T) {
 	a.So(lossPercentage(buildFrames(1, 2, 3, 6, 7, 8, 9, 12, 13, 14)), ShouldEqual, 29) // 4/14 missing
 }
 
+func TestFrequencyPlanName(t *testing.T) {
+	a := New(t)
+	fp, _ := band.Get("EU_863_870")
+	a.So(band.Register("EU_863_870_TEST_NS", fp), ShouldBeNil)
+
+	a.So(frequencyPlanName("", pb_lorawan.FrequencyPlan_EU_863_870), ShouldEqual, "EU_863_870")
+	a.So(frequencyPlanName("EU_863_870_TEST_NS", pb_lorawan.FrequencyPlan_EU_863_870), ShouldEqual, "EU_863_870_TEST_NS")
+
+	// Frequency plans that are unknown to the NetworkServer
+	a.So(frequencyPlanName("EU_863_870_UNKNOWN", pb_lorawan.FrequencyPlan_EU_863_870), ShouldEqual, "EU_863_870")
+}
+
 func TestHandleUplinkADR(t *testing.T) {
 	a := New(t)
 	ns := &networkServer{
@@ -107,6 +121,7 @@ func TestHandleUplinkADR(t *testing.T) {
 		frames, _ := history.Get()
 		a.So(frames, ShouldHaveLength, 1)
 		a.So(dev.ADR.DataRate, ShouldEqual, "SF8BW125")
+		a.So(dev.ADR.Band, ShouldEqual, "EU_863_870")
 	}
 
 	// Resetting ADR to false should empty the frames
@@ -199,8 +214,9 @@ func TestHandleDownlinkADR(t *testing.T) {
 	dev.ADR.Band = "INVALID"
 	shouldReturnError()
 
-	dev.ADR.Band = "US_902_928"
+	dev.ADR.Band = "CN_470_510"
 	nothingShouldHappen()
+	dev.ADR.TxPower = 0
 
 	dev.ADR.Band = "EU_863_870"
 
@@ -262,3 +278,120 @@ func TestHandleDownlinkADR(t *testing.T) {
 	shouldReturnError()
 
 }
+
+func TestHandleDownlinkADRSubBands(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		devices: device.NewRedisDeviceStore(GetRedisClient(), "ns-test-handle-downlink-adr-sub-bands"),
+	}
+	ns.InitStatus()
+
+	defer func() {
+		keys, _ := GetRedisClient().Keys("*ns-test-handle-downlink-adr-sub-bands*").Result()
+		for _, key := range keys {
+			GetRedisClient().Del(key).Result()
+		}
+	}()
+
+	appEUI := types.AppEUI([8]byte{1})
+	devEUI := types.DevEUI([8]byte{1})
+	history, _ := ns.devices.Frames(appEUI, devEUI)
+	for i := 0; i < 20; i++ {
+		history.Push(&device.Frame{SNR: 10, GatewayCount: 3, FCnt: uint32(i)})
+	}
+
+	for _, name := range []string{"US_902_928", "AU_915_928"} {
+		dev := &device.Device{AppEUI: appEUI, DevEUI: devEUI}
+		dev.ADR.SendReq = true
+		dev.ADR.DataRate = "SF8BW125"
+		dev.ADR.Band = name
+
+		message := adrInitDownlinkMessage()
+		err := ns.handleDownlinkADR(message, dev)
+		a.So(err, ShouldBeNil)
+		a.So(dev.ADR.DataRate, ShouldEqual, "SF7BW125")
+
+		// A LinkADRReq that disables all 125 kHz channels except channel 65, and one that enables channels 8-15
+		fOpts := message.Message.GetLorawan().GetMacPayload().FOpts
+		a.So(fOpts, ShouldHaveLength, 3)
+		payloads := make([]*lorawan.LinkADRReqPayload, 2)
+		for i, fOpt := range fOpts[1:] {
+			a.So(fOpt.Cid, ShouldEqual, lorawan.LinkADRReq)
+			payloads[i] = new(lorawan.LinkADRReqPayload)
+			payloads[i].UnmarshalBinary(fOpt.Payload)
+		}
+		a.So(payloads[0].Redundancy.ChMaskCntl, ShouldEqual, 7)
+		a.So(payloads[0].ChMask[1], ShouldBeTrue)
+		a.So(payloads[1].Redundancy.ChMaskCntl, ShouldEqual, 0)
+		for i := 0; i < 16; i++ {
+			a.So(payloads[1].ChMask[i], ShouldEqual, i >= 8)
+		}
+
+		fp, _ := band.Get(name)
+		drIdx, _ := fp.GetDataRateIndexFor("SF7BW125")
+		powerIdx, _ := fp.GetTxPowerIndexFor(fp.DefaultTXPower)
+		for _, payload := range payloads {
+			a.So(payload.DataRate, ShouldEqual, drIdx)
+			a.So(payload.TXPower, ShouldEqual, powerIdx)
+			a.So(payload.Redundancy.NbRep, ShouldEqual, 1)
+		}
+
+		// Room is left for the LinkADRReqs
+		a.So(linkADRReqLength(dev), ShouldEqual, 10)
+	}
+}
+
+func TestHandleDownlinkADRApplication(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleDownlinkADRApplication")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "ns-test-handle-downlink-adr-application"),
+	}
+	ns.InitStatus()
+
+	defer func() {
+		keys, _ := GetRedisClient().Keys("*ns-test-handle-downlink-adr-application*").Result()
+		for _, key := range keys {
+			GetRedisClient().Del(key).Result()
+		}
+	}()
+
+	appEUI := types.AppEUI([8]byte{1})
+	devEUI := types.DevEUI([8]byte{1})
+	history, _ := ns.devices.Frames(appEUI, devEUI)
+	for i := 0; i < 20; i++ {
+		history.Push(&device.Frame{SNR: 10, GatewayCount: 3, FCnt: uint32(i)})
+	}
+
+	linkADRReqDataRate := func(algorithm, fixedDataRate, appAlgorithm, appDataRate string) uint8 {
+		dev := &device.Device{AppEUI: appEUI, DevEUI: devEUI}
+		dev.ADR.SendReq = true
+		dev.ADR.DataRate = "SF8BW125"
+		dev.ADR.Band = "EU_863_870"
+		dev.ADR.Algorithm, dev.ADR.FixedDataRate = algorithm, fixedDataRate
+
+		message := adrInitDownlinkMessage()
+		message.AppAdrAlgorithm, message.AppAdrDataRate = appAlgorithm, appDataRate
+		err := ns.handleDownlinkADR(message, dev)
+		a.So(err, ShouldBeNil)
+		a.So(dev.ADR.FixedDataRate, ShouldEqual, fixedDataRate) // The data rate of the application is not stored
+		fOpts := message.Message.GetLorawan().GetMacPayload().FOpts
+		if !a.So(fOpts, ShouldHaveLength, 2) {
+			return 0
+		}
+		payload := new(lorawan.LinkADRReqPayload)
+		payload.UnmarshalBinary(fOpts[1].Payload)
+		return payload.DataRate
+	}
+
+	// The ADR settings of the application are used for devices without ADR settings
+	a.So(linkADRReqDataRate("", "", "fixed", "SF10BW125"), ShouldEqual, 2)
+	a.So(linkADRReqDataRate("fixed", "", "default", "SF10BW125"), ShouldEqual, 2)
+
+	// The ADR settings of the device take precedence
+	a.So(linkADRReqDataRate("default", "", "fixed", "SF10BW125"), ShouldEqual, 5)
+	a.So(linkADRReqDataRate("fixed", "SF9BW125", "fixed", "SF10BW125"), ShouldEqual, 3)
+
+	// The default algorithm is used if the algorithm of the application does not exist
+	a.So(linkADRReqDataRate("", "", "unknown", ""), ShouldEqual, 5)
+}
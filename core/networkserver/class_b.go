@@ -0,0 +1,186 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/utils/classb"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// MAC commands for Class B devices
+const (
+	deviceTimeReq      = 0x0D
+	deviceTimeAns      = 0x0D
+	pingSlotInfoReq    = 0x10
+	pingSlotInfoAns    = 0x10
+	pingSlotChannelReq = 0x11
+	pingSlotChannelAns = 0x11
+	beaconFreqReq      = 0x13
+	beaconFreqAns      = 0x13
+)
+
+// handleClassBMAC handles the Class B MAC commands in an uplink message
+func (n *networkServer) handleClassBMAC(cmd pb_lorawan.MACCommand, message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) {
+	lorawanDownlinkMac := message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload()
+	switch cmd.Cid {
+	case pingSlotInfoReq:
+		if len(cmd.Payload) != 1 {
+			break
+		}
+		dev.ClassB.PingNb = classb.PingNb(cmd.Payload[0])
+		lorawanDownlinkMac.FOpts = append(lorawanDownlinkMac.FOpts, pb_lorawan.MACCommand{
+			Cid: pingSlotInfoAns,
+		})
+		message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "ping-slot-info", "ping-nb", dev.ClassB.PingNb)
+	case deviceTimeReq:
+		gpsTime := classb.GPSTime(uplinkTime(message))
+		responsePayload := make([]byte, 5)
+		binary.LittleEndian.PutUint32(responsePayload, uint32(gpsTime/time.Second))
+		responsePayload[4] = uint8((gpsTime % time.Second) * 256 / time.Second) // Fractional seconds in 1/256 s
+		lorawanDownlinkMac.FOpts = append(lorawanDownlinkMac.FOpts, pb_lorawan.MACCommand{
+			Cid:     deviceTimeAns,
+			Payload: responsePayload,
+		})
+		message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "device-time")
+	case beaconFreqAns:
+		if len(cmd.Payload) != 1 {
+			break
+		}
+		frequencyAck := cmd.Payload[0]&0x01 == 0x01
+		message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "beacon-freq", "frequency-ack", frequencyAck)
+		dev.ClassB.SendBeaconFreqReq = false
+		if !frequencyAck {
+			n.Ctx.WithFields(log.Fields{
+				"AppID": dev.AppID,
+				"DevID": dev.DevID,
+			}).Warn("Negative BeaconFreqAns")
+		}
+	}
+}
+
+// uplinkTime returns the time at which an uplink message was received, as indicated by the gateways that have
+// a (GPS) time, or the server time otherwise
+func uplinkTime(message *pb_broker.DeduplicatedUplinkMessage) time.Time {
+	for _, gateway := range message.GetGatewayMetadata() {
+		if gateway.Time != 0 {
+			return time.Unix(0, gateway.Time)
+		}
+	}
+	if message.ServerTime != 0 {
+		return time.Unix(0, message.ServerTime)
+	}
+	return time.Now()
+}
+
+// newPingSlotChannelReq returns the payload of a PingSlotChannelReq, a frequency of zero means the default frequency
+func newPingSlotChannelReq(frequency uint32, dataRate uint8) []byte {
+	payload := make([]byte, 4)
+	putFrequency(payload, frequency)
+	payload[3] = dataRate & 0x0F
+	return payload
+}
+
+// handleDownlinkClassBMAC adds a BeaconFreqReq to the downlink message if the beacon frequency of a Class B
+// device was changed, and queues a PingSlotChannelReq if the ping slot channel of the device was changed
+func (n *networkServer) handleDownlinkClassBMAC(message *pb_broker.DownlinkMessage, dev *device.Device) {
+	if dev.Options.Class != pb_lorawan.DeviceClass_CLASS_B {
+		return
+	}
+	lorawanDownlinkMac := message.GetMessage().GetLorawan().GetMacPayload()
+	if lorawanDownlinkMac == nil {
+		return
+	}
+	if dev.ClassB.SendPingSlotChannelReq {
+		n.queuePingSlotChannelReq(message, dev)
+	}
+	if !dev.ClassB.SendBeaconFreqReq {
+		return
+	}
+	payload := make([]byte, 4)
+	binary.LittleEndian.PutUint32(payload, dev.Options.BeaconFrequency/100) // Frequency in units of 100 Hz
+	lorawanDownlinkMac.FOpts = append(lorawanDownlinkMac.FOpts, pb_lorawan.MACCommand{
+		Cid:     beaconFreqReq,
+		Payload: payload[:3],
+	})
+	message.Trace = message.Trace.WithEvent("set beacon frequency", "frequency", dev.Options.BeaconFrequency)
+}
+
+// queuePingSlotChannelReq queues a PingSlotChannelReq with the ping slot channel in the options of the device. The
+// device keeps using its current ping slot channel until it accepts the request.
+func (n *networkServer) queuePingSlotChannelReq(message *pb_broker.DownlinkMessage, dev *device.Device) {
+	if dev.ADR.Band == "" {
+		return // The request is sent when the frequency plan of the device is known
+	}
+	fp, err := band.Get(dev.ADR.Band)
+	if err != nil {
+		return
+	}
+	dataRate := fp.PingSlotDataRate
+	if dev.Options.PingSlotDataRate != "" {
+		if dataRate, err = fp.GetDataRateIndexFor(dev.Options.PingSlotDataRate); err != nil {
+			n.Ctx.WithFields(log.Fields{
+				"AppID":    dev.AppID,
+				"DevID":    dev.DevID,
+				"DataRate": dev.Options.PingSlotDataRate,
+			}).Warn("Ping slot data rate is not in frequency plan of device")
+			dev.ClassB.SendPingSlotChannelReq = false
+			return
+		}
+	}
+	queueMACCommand(dev, pingSlotChannelReq, newPingSlotChannelReq(dev.Options.PingSlotFrequency, uint8(dataRate)))
+	dev.ClassB.SendPingSlotChannelReq = false
+	message.Trace = message.Trace.WithEvent("set ping slot channel", "frequency", dev.Options.PingSlotFrequency, "data-rate", dataRate)
+}
+
+// buildClassBDownlinkOption builds the DownlinkOption for a downlink message to a Class B device. The message is
+// sent on the ping slot channel in the first available ping slot of the device, by the gateway that last received
+// the device.
+func (n *networkServer) buildClassBDownlinkOption(dev *device.Device) (*pb_broker.DownlinkOption, error) {
+	if dev.Options.Class != pb_lorawan.DeviceClass_CLASS_B {
+		return nil, errors.NewErrInvalidArgument("Downlink", "device is not a Class B device")
+	}
+	option, err := n.buildRX2DownlinkOption(dev)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ping slot channel is the default of the frequency plan, unless the device accepted a PingSlotChannelReq
+	fp, err := band.Get(dev.ADR.Band)
+	if err != nil {
+		return nil, err
+	}
+	frequency := uint64(fp.PingSlotFrequency)
+	if dev.ClassB.PingSlotFrequency != 0 {
+		frequency = uint64(dev.ClassB.PingSlotFrequency)
+	}
+	if frequency != 0 {
+		option.GatewayConfig.Frequency = frequency
+	}
+	dataRate := dev.ClassB.PingSlotDataRate
+	if dataRate == "" {
+		if dataRate, err = fp.GetDataRateStringForIndex(fp.PingSlotDataRate); err != nil {
+			return nil, err
+		}
+	}
+	option.GetProtocolConfig().GetLorawan().DataRate = dataRate
+
+	pingNb := dev.ClassB.PingNb
+	if pingNb == 0 {
+		pingNb = 1 // The default if the device did not send a PingSlotInfoReq
+	}
+	option.GetProtocolConfig().GetLorawan().PingSlot = &pb_lorawan.PingSlot{
+		DevAddr: &dev.DevAddr,
+		PingNb:  uint32(pingNb),
+	}
+	return option, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/utils/classb"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHandleClassBMAC(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleClassBMAC")},
+	}
+	dev := &device.Device{Options: device.Options{Class: pb_lorawan.DeviceClass_CLASS_B}}
+
+	// PingSlotInfoReq
+	message := adrInitUplinkMessage()
+	ns.handleClassBMAC(pb_lorawan.MACCommand{Cid: pingSlotInfoReq, Payload: []byte{0x05}}, message, dev)
+	a.So(dev.ClassB.PingNb, ShouldEqual, 4)
+	fOpts := message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload().FOpts
+	a.So(fOpts, ShouldHaveLength, 1)
+	a.So(fOpts[0].Cid, ShouldEqual, pingSlotInfoAns)
+
+	// DeviceTimeReq
+	message = adrInitUplinkMessage()
+	uplinkTime := time.Date(2017, 6, 1, 12, 0, 0, 500000000, time.UTC)
+	message.GatewayMetadata[0].Time = uplinkTime.UnixNano()
+	ns.handleClassBMAC(pb_lorawan.MACCommand{Cid: deviceTimeReq}, message, dev)
+	fOpts = message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload().FOpts
+	a.So(fOpts, ShouldHaveLength, 1)
+	a.So(fOpts[0].Cid, ShouldEqual, deviceTimeAns)
+	a.So(fOpts[0].Payload, ShouldHaveLength, 5)
+	a.So(binary.LittleEndian.Uint32(fOpts[0].Payload), ShouldEqual, uint32(classb.GPSTime(uplinkTime)/time.Second))
+	a.So(fOpts[0].Payload[4], ShouldEqual, 128)
+
+	// BeaconFreqAns
+	dev.ClassB.SendBeaconFreqReq = true
+	message = adrInitUplinkMessage()
+	ns.handleClassBMAC(pb_lorawan.MACCommand{Cid: beaconFreqAns, Payload: []byte{0x01}}, message, dev)
+	a.So(dev.ClassB.SendBeaconFreqReq, ShouldBeFalse)
+}
+
+func TestHandleDownlinkClassBMAC(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+	dev := &device.Device{
+		Options: device.Options{Class: pb_lorawan.DeviceClass_CLASS_B, BeaconFrequency: 869525000},
+	}
+
+	message := adrInitDownlinkMessage()
+	ns.handleDownlinkClassBMAC(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FOpts, ShouldHaveLength, 1)
+
+	dev.ClassB.SendBeaconFreqReq = true
+	ns.handleDownlinkClassBMAC(message, dev)
+	fOpts := message.Message.GetLorawan().GetMacPayload().FOpts
+	a.So(fOpts, ShouldHaveLength, 2)
+	a.So(fOpts[1].Cid, ShouldEqual, beaconFreqReq)
+	a.So(fOpts[1].Payload, ShouldResemble, []byte{0xD2, 0xAD, 0x84}) // 8695250 in units of 100 Hz
+
+	// PingSlotChannelReq
+	dev.ADR.Band = "EU_863_870"
+	dev.Options.PingSlotFrequency = 868500000
+	dev.Options.PingSlotDataRate = "SF12BW125"
+	dev.ClassB.SendPingSlotChannelReq = true
+	ns.handleDownlinkClassBMAC(message, dev)
+	a.So(dev.ClassB.SendPingSlotChannelReq, ShouldBeFalse)
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 1)
+	a.So(dev.PendingMACCommands[0].CID, ShouldEqual, pingSlotChannelReq)
+	a.So(dev.PendingMACCommands[0].Payload, ShouldResemble, []byte{0xC8, 0x85, 0x84, 0x00}) // 8685000 in units of 100 Hz, DR0
+
+	// PingSlotChannelAns
+	ns.handleMACAns(pb_lorawan.MACCommand{Cid: pingSlotChannelAns, Payload: []byte{0x03}}, adrInitUplinkMessage(), dev)
+	a.So(dev.PendingMACCommands, ShouldBeEmpty)
+	a.So(dev.ClassB.PingSlotFrequency, ShouldEqual, 868500000)
+	a.So(dev.ClassB.PingSlotDataRate, ShouldEqual, "SF12BW125")
+}
+
+func TestBuildClassBDownlinkOption(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+
+	dev := &device.Device{
+		DevAddr:       getDevAddr(1, 2, 3, 4),
+		NFCntDown:     42,
+		LastGatewayID: "gtw",
+		ADR:           device.ADRSettings{Band: "EU_863_870"},
+	}
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "router:schedule"})
+
+	// Class A
+	_, err := ns.buildClassBDownlinkOption(dev)
+	a.So(err, ShouldNotBeNil)
+
+	dev.Options.Class = pb_lorawan.DeviceClass_CLASS_B
+	option, err := ns.buildClassBDownlinkOption(dev)
+	a.So(err, ShouldBeNil)
+	a.So(option.GatewayId, ShouldEqual, "gtw")
+	a.So(option.Identifier, ShouldEqual, "router:")
+	a.So(option.ProtocolConfig.GetLorawan().FCnt, ShouldEqual, 42)
+	pingSlot := option.ProtocolConfig.GetLorawan().PingSlot
+	a.So(pingSlot, ShouldNotBeNil)
+	a.So(*pingSlot.DevAddr, ShouldEqual, dev.DevAddr)
+	a.So(pingSlot.PingNb, ShouldEqual, 1)
+
+	// Default ping slot channel of the frequency plan
+	a.So(option.GatewayConfig.Frequency, ShouldEqual, 869525000)
+	a.So(option.ProtocolConfig.GetLorawan().DataRate, ShouldEqual, "SF9BW125")
+
+	dev.ClassB.PingNb = 16
+	option, err = ns.buildClassBDownlinkOption(dev)
+	a.So(err, ShouldBeNil)
+	a.So(option.ProtocolConfig.GetLorawan().PingSlot.PingNb, ShouldEqual, 16)
+
+	// Ping slot channel that was accepted in a PingSlotChannelAns
+	dev.ClassB.PingSlotFrequency = 868500000
+	dev.ClassB.PingSlotDataRate = "SF12BW125"
+	option, err = ns.buildClassBDownlinkOption(dev)
+	a.So(err, ShouldBeNil)
+	a.So(option.GatewayConfig.Frequency, ShouldEqual, 868500000)
+	a.So(option.ProtocolConfig.GetLorawan().DataRate, ShouldEqual, "SF12BW125")
+}
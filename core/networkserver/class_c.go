@@ -0,0 +1,112 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"fmt"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// setClassCGateway stores the gateway and frequency plan that are used for downlink to Class C devices
+func (n *networkServer) setClassCGateway(message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) {
+	if option := message.GetResponseTemplate().GetDownlinkOption(); option != nil {
+		dev.LastGatewayID = option.GatewayId
+	}
+	if lorawan := message.GetProtocolMetadata().GetLorawan(); lorawan != nil && dev.ADR.Band == "" {
+		dev.ADR.Band = frequencyPlanName(lorawan.FrequencyPlanName, lorawan.FrequencyPlan)
+	}
+}
+
+// maxFCntDownGap is the maximum number of frame counters that the FCnt of a downlink message that is not a response
+// to an uplink message can be ahead of the FCntDown of the device
+const maxFCntDownGap = 16384
+
+// adoptDownlinkFCnt sets the FCntDown of the device to the FCnt of a downlink message that is not a response to an
+// uplink message. The Handler already encrypted the payload with its own FCntDown, which is ahead of the FCntDown of
+// the NetworkServer if earlier downlink messages of the Handler were not sent. Lower frame counters are not accepted,
+// because the device may already have received them.
+func adoptDownlinkFCnt(message *pb_broker.DownlinkMessage, dev *device.Device) error {
+	lorawanDownlinkMsg := message.GetMessage().GetLorawan()
+	lorawanDownlinkMac := lorawanDownlinkMsg.GetMacPayload()
+	fPort := lorawanDownlinkMac.FPort
+
+	// The message only contains the 16 least significant bits of the FCnt
+	current := dev.FCntDown(fPort)
+	fCnt := current&^0xFFFF | lorawanDownlinkMac.FCnt&0xFFFF
+	if fCnt < current {
+		fCnt += 0x10000
+	}
+	if fCnt-current > maxFCntDownGap {
+		return errors.NewErrInvalidArgument("Downlink", "FCnt does not match device")
+	}
+
+	confirmed := dev.ConfirmedDownlink
+	if confirmed != nil && dev.SharesFCntDown(confirmed.FPort, fPort) && confirmed.FCnt == fCnt {
+		if !lorawanDownlinkMsg.IsConfirmed() || !message.Retransmission {
+			return errors.NewErrInvalidArgument("Downlink", "FCnt is used by a confirmed downlink that was not acknowledged")
+		}
+		return nil
+	}
+
+	if fCnt != current {
+		message.Trace = message.Trace.WithEvent("skip fcnt down", "fcnt", current, "next", fCnt)
+		dev.SetFCntDown(fPort, fCnt)
+	}
+	return nil
+}
+
+// buildClassCDownlinkOption builds the DownlinkOption for a downlink message to a Class C device. The message is sent
+// in RX2 of the frequency plan of the device, as soon as possible, by the gateway that last received the device.
+func (n *networkServer) buildClassCDownlinkOption(dev *device.Device) (*pb_broker.DownlinkOption, error) {
+	if dev.Options.Class != pb_lorawan.DeviceClass_CLASS_C {
+		return nil, errors.NewErrInvalidArgument("Downlink", "device is not a Class C device")
+	}
+	return n.buildRX2DownlinkOption(dev)
+}
+
+// buildRX2DownlinkOption builds a DownlinkOption for the RX2 channel of the frequency plan of the device, on the
+// gateway that last received the device. The Router sends the message as soon as possible.
+func (n *networkServer) buildRX2DownlinkOption(dev *device.Device) (*pb_broker.DownlinkOption, error) {
+	if dev.LastGatewayID == "" {
+		return nil, errors.NewErrInvalidArgument("Downlink", "device was not seen by a gateway that can send downlink")
+	}
+	routerID, ok := n.getGatewayRouter(dev.LastGatewayID)
+	if !ok {
+		return nil, errors.NewErrInvalidArgument("Downlink", fmt.Sprintf("unknown Router for gateway %s", dev.LastGatewayID))
+	}
+	if dev.ADR.Band == "" {
+		return nil, errors.NewErrInvalidArgument("Downlink", "unknown frequency plan for device")
+	}
+	fp, err := band.Get(dev.ADR.Band)
+	if err != nil {
+		return nil, err
+	}
+	dataRate, err := fp.GetDataRateStringForIndex(fp.RX2DataRate)
+	if err != nil {
+		return nil, err
+	}
+	return &pb_broker.DownlinkOption{
+		GatewayId:  dev.LastGatewayID,
+		Identifier: fmt.Sprintf("%s:", routerID), // An empty schedule identifier means as soon as possible
+		ProtocolConfig: &pb_protocol.TxConfiguration{Protocol: &pb_protocol.TxConfiguration_Lorawan{Lorawan: &pb_lorawan.TxConfiguration{
+			Modulation: pb_lorawan.Modulation_LORA,
+			DataRate:   dataRate,
+			CodingRate: "4/5",
+			FCnt:       dev.FCntDown(1), // The Handler sends application downlink
+		}}},
+		GatewayConfig: &pb_gateway.TxConfiguration{
+			RfChain:               0,
+			PolarizationInversion: true,
+			Frequency:             uint64(fp.RX2Frequency),
+			Power:                 int32(fp.DefaultTXPower),
+		},
+	}, nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+	"time"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSetClassCGateway(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+
+	dev := &device.Device{}
+	ns.setClassCGateway(&pb_broker.DeduplicatedUplinkMessage{}, dev)
+	a.So(dev.LastGatewayID, ShouldBeEmpty)
+	a.So(dev.ADR.Band, ShouldBeEmpty)
+
+	ns.setClassCGateway(&pb_broker.DeduplicatedUplinkMessage{
+		ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{Lorawan: &pb_lorawan.Metadata{
+			FrequencyPlan: pb_lorawan.FrequencyPlan_EU_863_870,
+		}}},
+		ResponseTemplate: &pb_broker.DownlinkMessage{
+			DownlinkOption: &pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "router:schedule"},
+		},
+	}, dev)
+	a.So(dev.LastGatewayID, ShouldEqual, "gtw")
+	a.So(dev.ADR.Band, ShouldEqual, "EU_863_870")
+}
+
+func TestBuildClassCDownlinkOption(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+
+	dev := &device.Device{NFCntDown: 42}
+
+	// Class A
+	_, err := ns.buildClassCDownlinkOption(dev)
+	a.So(err, ShouldNotBeNil)
+
+	// Not seen
+	dev.Options.Class = pb_lorawan.DeviceClass_CLASS_C
+	_, err = ns.buildClassCDownlinkOption(dev)
+	a.So(err, ShouldNotBeNil)
+
+	// Unknown Router
+	dev.LastGatewayID = "gtw"
+	_, err = ns.buildClassCDownlinkOption(dev)
+	a.So(err, ShouldNotBeNil)
+
+	// Unknown frequency plan
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "router:schedule"})
+	_, err = ns.buildClassCDownlinkOption(dev)
+	a.So(err, ShouldNotBeNil)
+
+	dev.ADR.Band = "EU_863_870"
+	option, err := ns.buildClassCDownlinkOption(dev)
+	a.So(err, ShouldBeNil)
+	a.So(option.GatewayId, ShouldEqual, "gtw")
+	a.So(option.Identifier, ShouldEqual, "router:")
+	a.So(option.GatewayConfig.Frequency, ShouldEqual, 869525000)
+	a.So(option.ProtocolConfig.GetLorawan().DataRate, ShouldEqual, "SF12BW125")
+	a.So(option.ProtocolConfig.GetLorawan().FCnt, ShouldEqual, 42)
+}
+
+func TestHandleClassCDownlink(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		devices: device.NewRedisDeviceStore(GetRedisClient(), "test-handle-class-c-downlink"),
+	}
+	ns.InitStatus()
+
+	appEUI := types.AppEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8))
+	devEUI := types.DevEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8))
+	devAddr := getDevAddr(1, 2, 3, 4)
+
+	ns.devices.Set(&device.Device{
+		DevAddr:       devAddr,
+		AppEUI:        appEUI,
+		DevEUI:        devEUI,
+		AppID:         "appid",
+		DevID:         "devid",
+		NFCntDown:     0x10002,
+		LastGatewayID: "gtw",
+		Options:       device.Options{Class: pb_lorawan.DeviceClass_CLASS_C},
+		ADR:           device.ADRSettings{Band: "EU_863_870"},
+	})
+	defer func() {
+		ns.devices.Delete(appEUI, devEUI)
+	}()
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "router:schedule"})
+
+	newMessage := func(fCnt uint32) *pb_broker.DownlinkMessage {
+		fPort := uint8(3)
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataDown,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FPort: &fPort,
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr(devAddr),
+					FCnt:    fCnt,
+				},
+			},
+		}
+		bytes, _ := phy.MarshalBinary()
+		return &pb_broker.DownlinkMessage{
+			AppEui:  &appEUI,
+			DevEui:  &devEUI,
+			AppId:   "appid",
+			DevId:   "devid",
+			Payload: bytes,
+		}
+	}
+
+	// FCnt of the Handler does not match
+	_, err := ns.HandleDownlink(newMessage(1))
+	a.So(err, ShouldNotBeNil)
+
+	res, err := ns.HandleDownlink(newMessage(2))
+	a.So(err, ShouldBeNil)
+	a.So(res.DownlinkOption, ShouldNotBeNil)
+	a.So(res.DownlinkOption.GatewayId, ShouldEqual, "gtw")
+	a.So(res.DownlinkOption.Identifier, ShouldEqual, "router:")
+
+	dev, _ := ns.devices.Get(appEUI, devEUI)
+	a.So(dev.NFCntDown, ShouldEqual, 0x10003)
+
+	// The Handler is ahead if earlier downlink was not sent
+	res, err = ns.HandleDownlink(newMessage(5))
+	a.So(err, ShouldBeNil)
+	a.So(res.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 0x10005)
+
+	dev, _ = ns.devices.Get(appEUI, devEUI)
+	a.So(dev.NFCntDown, ShouldEqual, 0x10006)
+
+	// Frame counters that were already used are not accepted
+	_, err = ns.HandleDownlink(newMessage(4))
+	a.So(err, ShouldNotBeNil)
+
+	// The FCnt of a confirmed downlink that was not acknowledged is not reused
+	dev.StartUpdate()
+	dev.ConfirmedDownlink = &device.ConfirmedDownlink{FCnt: 0x10006, FPort: 3, SentAt: time.Now()}
+	ns.devices.Set(dev)
+	_, err = ns.HandleDownlink(newMessage(6))
+	a.So(err, ShouldNotBeNil)
+
+	res, err = ns.HandleDownlink(newMessage(7))
+	a.So(err, ShouldBeNil)
+	dev, _ = ns.devices.Get(appEUI, devEUI)
+	a.So(dev.ConfirmedDownlink, ShouldBeNil)
+	a.So(dev.NFCntDown, ShouldEqual, 0x10008)
+}
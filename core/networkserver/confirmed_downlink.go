@@ -0,0 +1,109 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"time"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+)
+
+// ConfirmedDownlinkMaxRetries is the maximum number of times that a confirmed downlink is sent again with the same
+// FCnt if it is not acknowledged. A negative value means that there is no maximum.
+var ConfirmedDownlinkMaxRetries = 8
+
+// ConfirmedDownlinkTimeout is the time after the first transmission of a confirmed downlink after which the FCnt
+// is no longer reused if it is not acknowledged. Zero means that there is no timeout.
+var ConfirmedDownlinkTimeout = 24 * time.Hour
+
+// confirmedDownlinkExpired returns true if the confirmed downlink reached the maximum retries or timeout
+func confirmedDownlinkExpired(confirmed *device.ConfirmedDownlink) bool {
+	if ConfirmedDownlinkMaxRetries >= 0 && confirmed.Retries >= ConfirmedDownlinkMaxRetries {
+		return true
+	}
+	if ConfirmedDownlinkTimeout > 0 && time.Since(confirmed.SentAt) > ConfirmedDownlinkTimeout {
+		return true
+	}
+	return false
+}
+
+// handleUplinkConfirmedDownlink updates the state of the confirmed downlink of the device with the acknowledgement
+// in the uplink message. The state is added to the LoRaWAN metadata of the uplink message, so that the Handler knows
+// whether its confirmed downlink was acknowledged.
+func (n *networkServer) handleUplinkConfirmedDownlink(message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) {
+	confirmed := dev.ConfirmedDownlink
+	if confirmed == nil {
+		return
+	}
+
+	lorawanUplinkMac := message.GetMessage().GetLorawan().GetMacPayload()
+	state := pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING
+	switch {
+	case lorawanUplinkMac != nil && lorawanUplinkMac.Ack:
+		state = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_ACK
+		message.Trace = message.Trace.WithEvent("ack confirmed downlink", "fcnt", confirmed.FCnt, "retries", confirmed.Retries)
+	case confirmedDownlinkExpired(confirmed):
+		state = pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT
+		message.Trace = message.Trace.WithEvent("drop confirmed downlink", "fcnt", confirmed.FCnt, "retries", confirmed.Retries)
+	}
+
+	if state != pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING {
+		dev.ConfirmedDownlink = nil
+		if dev.FCntDown(confirmed.FPort) == confirmed.FCnt {
+			dev.IncrementFCntDown(confirmed.FPort)
+		}
+	}
+
+	if lorawan := message.GetProtocolMetadata().GetLorawan(); lorawan != nil {
+		lorawan.ConfirmedDownlink = state
+		lorawan.ConfirmedFCntDown = confirmed.FCnt
+	}
+}
+
+// setDownlinkFCnt sets the full FCnt of the downlink message and increments the FCntDown of the device. The FCntDown
+// is not incremented for confirmed downlink, so that retransmissions of the confirmed downlink use the same FCnt
+// until it is acknowledged. Only messages that are marked as retransmission reuse the FCnt, other messages get a new
+// FCnt.
+func (n *networkServer) setDownlinkFCnt(message *pb_broker.DownlinkMessage, dev *device.Device) {
+	lorawanDownlinkMsg := message.GetMessage().GetLorawan()
+	lorawanDownlinkMac := lorawanDownlinkMsg.GetMacPayload()
+	fPort := lorawanDownlinkMac.FPort
+	confirmed := dev.ConfirmedDownlink
+	if confirmed != nil && !dev.SharesFCntDown(confirmed.FPort, fPort) {
+		confirmed = nil // The confirmed downlink uses another frame counter
+	}
+
+	if lorawanDownlinkMsg.IsConfirmed() && confirmed != nil && message.Retransmission {
+		// Retransmission of the confirmed downlink, the confirmed downlink is copied, so that the changes are
+		// detected when the device is saved
+		retransmission := *confirmed
+		retransmission.Retries++
+		dev.ConfirmedDownlink = &retransmission
+		lorawanDownlinkMac.FCnt = confirmed.FCnt
+		message.Trace = message.Trace.WithEvent("retransmit confirmed downlink", "fcnt", confirmed.FCnt, "retries", retransmission.Retries)
+		return
+	}
+
+	// A new downlink replaces the confirmed downlink that was not acknowledged, the FCnt of the confirmed downlink
+	// is skipped, as the device may have received it
+	if confirmed != nil {
+		dev.ConfirmedDownlink = nil
+		if dev.FCntDown(fPort) == confirmed.FCnt {
+			dev.IncrementFCntDown(fPort)
+		}
+		message.Trace = message.Trace.WithEvent("drop confirmed downlink", "fcnt", confirmed.FCnt, "retries", confirmed.Retries)
+	}
+	lorawanDownlinkMac.FCnt = dev.FCntDown(fPort)
+	if lorawanDownlinkMsg.IsConfirmed() {
+		dev.ConfirmedDownlink = &device.ConfirmedDownlink{
+			FCnt:   lorawanDownlinkMac.FCnt,
+			FPort:  fPort,
+			SentAt: time.Now(),
+		}
+	} else {
+		dev.IncrementFCntDown(fPort)
+	}
+}
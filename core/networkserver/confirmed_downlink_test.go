@@ -0,0 +1,129 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+	"time"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSetDownlinkFCnt(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+	dev := &device.Device{NFCntDown: 5}
+
+	downlink := func(confirmed bool) *pb_broker.DownlinkMessage {
+		message := adrInitDownlinkMessage()
+		message.Message.GetLorawan().GetMacPayload().FPort = 1
+		if confirmed {
+			message.Message.GetLorawan().MType = pb_lorawan.MType_CONFIRMED_DOWN
+		}
+		return message
+	}
+	retransmission := func() *pb_broker.DownlinkMessage {
+		message := downlink(true)
+		message.Retransmission = true
+		return message
+	}
+
+	// Unconfirmed downlink
+	message := downlink(false)
+	ns.setDownlinkFCnt(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 5)
+	a.So(dev.NFCntDown, ShouldEqual, 6)
+	a.So(dev.ConfirmedDownlink, ShouldBeNil)
+
+	// Confirmed downlink
+	message = downlink(true)
+	ns.setDownlinkFCnt(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 6)
+	a.So(dev.NFCntDown, ShouldEqual, 6)
+	a.So(dev.ConfirmedDownlink, ShouldNotBeNil)
+	a.So(dev.ConfirmedDownlink.FCnt, ShouldEqual, 6)
+
+	// Retransmissions use the same FCnt
+	message = retransmission()
+	ns.setDownlinkFCnt(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 6)
+	a.So(dev.NFCntDown, ShouldEqual, 6)
+	a.So(dev.ConfirmedDownlink.Retries, ShouldEqual, 1)
+
+	// A new confirmed downlink drops the confirmed downlink and skips its FCnt
+	message = downlink(true)
+	ns.setDownlinkFCnt(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 7)
+	a.So(dev.NFCntDown, ShouldEqual, 7)
+	a.So(dev.ConfirmedDownlink.FCnt, ShouldEqual, 7)
+	a.So(dev.ConfirmedDownlink.Retries, ShouldEqual, 0)
+
+	// An unconfirmed downlink drops the confirmed downlink and skips its FCnt
+	message = downlink(false)
+	ns.setDownlinkFCnt(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 8)
+	a.So(dev.NFCntDown, ShouldEqual, 9)
+	a.So(dev.ConfirmedDownlink, ShouldBeNil)
+
+	// A retransmission without pending confirmed downlink gets a new FCnt
+	message = retransmission()
+	ns.setDownlinkFCnt(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FCnt, ShouldEqual, 9)
+	a.So(dev.ConfirmedDownlink.FCnt, ShouldEqual, 9)
+
+	// LoRaWAN 1.1 devices use another frame counter for MAC-only downlink
+	dev = &device.Device{Options: device.Options{MACVersion: pb_lorawan.MACVersion_MAC_V1_1}}
+	ns.setDownlinkFCnt(downlink(true), dev)
+	message = adrInitDownlinkMessage()
+	ns.setDownlinkFCnt(message, dev)
+	a.So(dev.ConfirmedDownlink, ShouldNotBeNil)
+	a.So(dev.AFCntDown, ShouldEqual, 0)
+	a.So(dev.NFCntDown, ShouldEqual, 1)
+}
+
+func TestHandleUplinkConfirmedDownlink(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+
+	// Nothing pending
+	dev := &device.Device{NFCntDown: 6}
+	message := adrInitUplinkMessage()
+	ns.handleUplinkConfirmedDownlink(message, dev)
+	a.So(message.GetProtocolMetadata().GetLorawan().ConfirmedDownlink, ShouldEqual, pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_NONE)
+
+	// Not acknowledged
+	dev.ConfirmedDownlink = &device.ConfirmedDownlink{FCnt: 6, SentAt: time.Now()}
+	message = adrInitUplinkMessage()
+	ns.handleUplinkConfirmedDownlink(message, dev)
+	a.So(message.GetProtocolMetadata().GetLorawan().ConfirmedDownlink, ShouldEqual, pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_PENDING)
+	a.So(message.GetProtocolMetadata().GetLorawan().ConfirmedFCntDown, ShouldEqual, 6)
+	a.So(dev.ConfirmedDownlink, ShouldNotBeNil)
+	a.So(dev.NFCntDown, ShouldEqual, 6)
+
+	// Acknowledged
+	message = adrInitUplinkMessage()
+	message.Message.GetLorawan().GetMacPayload().Ack = true
+	ns.handleUplinkConfirmedDownlink(message, dev)
+	a.So(message.GetProtocolMetadata().GetLorawan().ConfirmedDownlink, ShouldEqual, pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_ACK)
+	a.So(dev.ConfirmedDownlink, ShouldBeNil)
+	a.So(dev.NFCntDown, ShouldEqual, 7)
+
+	// Retries exhausted
+	dev.ConfirmedDownlink = &device.ConfirmedDownlink{FCnt: 7, SentAt: time.Now(), Retries: ConfirmedDownlinkMaxRetries}
+	message = adrInitUplinkMessage()
+	ns.handleUplinkConfirmedDownlink(message, dev)
+	a.So(message.GetProtocolMetadata().GetLorawan().ConfirmedDownlink, ShouldEqual, pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT)
+	a.So(dev.ConfirmedDownlink, ShouldBeNil)
+	a.So(dev.NFCntDown, ShouldEqual, 8)
+
+	// Timeout
+	dev.ConfirmedDownlink = &device.ConfirmedDownlink{FCnt: 8, SentAt: time.Now().Add(-1 * ConfirmedDownlinkTimeout).Add(-1 * time.Second)}
+	message = adrInitUplinkMessage()
+	ns.handleUplinkConfirmedDownlink(message, dev)
+	a.So(message.GetProtocolMetadata().GetLorawan().ConfirmedDownlink, ShouldEqual, pb_lorawan.ConfirmedDownlinkState_CONFIRMED_DOWNLINK_TIMEOUT)
+	a.So(dev.NFCntDown, ShouldEqual, 9)
+}
@@ -0,0 +1,167 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"gopkg.in/redis.v5"
+)
+
+// The DevAddr usage index consists of a Sorted Set for each number of devices that use a DevAddr. The Sorted Set of
+// level n contains the DevAddrs that are used by at least n devices, with the DevAddr as score, so that the DevAddrs
+// of a prefix can be selected by their range.
+const redisDevAddrUsagePrefix = "dev_addr_usage"
+const redisDevAddrUsageIndexedKey = "dev_addr_usage_indexed"
+
+// devAddrUsageBatchSize is the number of DevAddrs that are read from the index at once
+const devAddrUsageBatchSize = 1000
+
+func devAddrScore(devAddr types.DevAddr) uint64 {
+	return uint64(binary.BigEndian.Uint32(devAddr[:]))
+}
+
+func (s *RedisDeviceStore) devAddrUsageKey(level int) string {
+	return fmt.Sprintf("%s:%s:%d", s.prefix, redisDevAddrUsagePrefix, level)
+}
+
+// addToDevAddrIndex adds the device key to the index of the DevAddr and updates the usage of the DevAddr
+func (s *RedisDeviceStore) addToDevAddrIndex(devAddr types.DevAddr, key string) error {
+	if err := s.devAddrIndex.Add(devAddr.String(), key); err != nil {
+		return err
+	}
+	count, err := s.devAddrIndex.Size(devAddr.String())
+	if err != nil {
+		return err
+	}
+	return s.client.ZAdd(s.devAddrUsageKey(count), redis.Z{Score: float64(devAddrScore(devAddr)), Member: devAddr.String()}).Err()
+}
+
+// removeFromDevAddrIndex removes the device key from the index of the DevAddr and updates the usage of the DevAddr
+func (s *RedisDeviceStore) removeFromDevAddrIndex(devAddr types.DevAddr, key string) error {
+	contains, err := s.devAddrIndex.Contains(devAddr.String(), key)
+	if err != nil || !contains {
+		return err
+	}
+	count, err := s.devAddrIndex.Size(devAddr.String())
+	if err != nil {
+		return err
+	}
+	if err := s.devAddrIndex.Remove(devAddr.String(), key); err != nil {
+		return err
+	}
+	return s.client.ZRem(s.devAddrUsageKey(count), devAddr.String()).Err()
+}
+
+// indexDevAddrUsage builds the DevAddr usage index from the DevAddr index, if that was not done before
+func (s *RedisDeviceStore) indexDevAddrUsage() error {
+	s.devAddrUsageMu.Lock()
+	defer s.devAddrUsageMu.Unlock()
+	if s.devAddrUsageIndexed {
+		return nil
+	}
+	indexedKey := fmt.Sprintf("%s:%s", s.prefix, redisDevAddrUsageIndexedKey)
+	indexed, err := s.client.Exists(indexedKey).Result()
+	if err != nil {
+		return err
+	}
+	if !indexed {
+		devAddrPrefix := fmt.Sprintf("%s:%s:", s.prefix, redisDevAddrPrefix)
+		for offset := uint64(0); ; offset += devAddrUsageBatchSize {
+			opts := &storage.ListOptions{Limit: devAddrUsageBatchSize, Offset: offset}
+			index, err := s.devAddrIndex.List("", opts)
+			if err != nil {
+				return err
+			}
+			for key, devices := range index {
+				devAddr, err := types.ParseDevAddr(strings.TrimPrefix(key, devAddrPrefix))
+				if err != nil {
+					continue
+				}
+				for level := 1; level <= len(devices); level++ {
+					if err := s.client.ZAdd(s.devAddrUsageKey(level), redis.Z{Score: float64(devAddrScore(devAddr)), Member: devAddr.String()}).Err(); err != nil {
+						return err
+					}
+				}
+			}
+			if total, _ := opts.GetTotalAndSelected(); offset+devAddrUsageBatchSize >= total {
+				break
+			}
+		}
+		if err := s.client.Set(indexedKey, "1", 0).Err(); err != nil {
+			return err
+		}
+	}
+	s.devAddrUsageIndexed = true
+	return nil
+}
+
+// LeastUsedAddress returns the DevAddr with the prefix that is used by the fewest devices, along with the number of
+// devices that use it. Of the least used DevAddrs, the first one from start is returned, wrapping around at the end
+// of the prefix.
+func (s *RedisDeviceStore) LeastUsedAddress(prefix types.DevAddrPrefix, start types.DevAddr) (types.DevAddr, int, error) {
+	if err := s.indexDevAddrUsage(); err != nil {
+		return types.DevAddr{}, 0, err
+	}
+	first := devAddrScore(prefix.DevAddr.Mask(prefix.Length))
+	size := uint64(1) << uint(32-prefix.Length)
+	last := first + size - 1
+	from := devAddrScore(start.WithPrefix(prefix))
+
+	for count := 0; ; count++ {
+		// The DevAddrs that are not in the next level are used by count devices
+		key := s.devAddrUsageKey(count + 1)
+		used, err := s.client.ZCount(key, fmt.Sprint(first), fmt.Sprint(last)).Result()
+		if err != nil {
+			return types.DevAddr{}, 0, err
+		}
+		if uint64(used) >= size {
+			continue
+		}
+		score, found, err := s.firstNotInDevAddrUsage(key, from, last)
+		if err == nil && !found && from > first {
+			score, found, err = s.firstNotInDevAddrUsage(key, first, from-1)
+		}
+		if err != nil {
+			return types.DevAddr{}, 0, err
+		}
+		if !found {
+			return types.DevAddr{}, 0, errors.New(fmt.Sprintf("DevAddr usage of prefix %s changed while it was read", prefix))
+		}
+		var devAddr types.DevAddr
+		binary.BigEndian.PutUint32(devAddr[:], uint32(score))
+		return devAddr, count, nil
+	}
+}
+
+// firstNotInDevAddrUsage returns the first DevAddr score in the range that is not in the level of the usage index
+func (s *RedisDeviceStore) firstNotInDevAddrUsage(key string, min, max uint64) (uint64, bool, error) {
+	candidate := min
+	for candidate <= max {
+		members, err := s.client.ZRangeByScoreWithScores(key, redis.ZRangeBy{
+			Min:   fmt.Sprint(candidate),
+			Max:   fmt.Sprint(max),
+			Count: devAddrUsageBatchSize,
+		}).Result()
+		if err != nil {
+			return 0, false, err
+		}
+		for _, member := range members {
+			score := uint64(member.Score)
+			if score > candidate {
+				return candidate, true, nil
+			}
+			candidate = score + 1
+		}
+		if len(members) < devAddrUsageBatchSize {
+			break
+		}
+	}
+	return candidate, candidate <= max, nil
+}
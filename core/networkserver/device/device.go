@@ -7,34 +7,95 @@ import (
 	"reflect"
 	"time"
 
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/fatih/structs"
 )
 
-const currentDBVersion = "2.4.1"
+const currentDBVersion = "2.5.0"
 
 // Options for the specified device
 type Options struct {
-	ActivationConstraints string `json:"activation_constraints,omitempty"` // Activation Constraints (public/local/private)
-	DisableFCntCheck      bool   `json:"disable_fcnt_check,omitemtpy"`     // Disable Frame counter check (insecure)
-	Uses32BitFCnt         bool   `json:"uses_32_bit_fcnt,omitemtpy"`       // Use 32-bit Frame counters
+	ActivationConstraints string                 `json:"activation_constraints,omitempty"` // Activation Constraints (public/local/private)
+	DisableFCntCheck      bool                   `json:"disable_fcnt_check,omitemtpy"`     // Disable Frame counter check (insecure)
+	Uses32BitFCnt         bool                   `json:"uses_32_bit_fcnt,omitemtpy"`       // Use 32-bit Frame counters
+	Class                 pb_lorawan.DeviceClass `json:"class,omitempty"`                  // Device Class (A/B/C)
+	BeaconFrequency       uint32                 `json:"beacon_frequency,omitempty"`       // Beacon frequency of Class B device (Hz)
+	PingSlotFrequency     uint32                 `json:"ping_slot_frequency,omitempty"`    // Ping slot frequency of Class B device (Hz)
+	PingSlotDataRate      string                 `json:"ping_slot_data_rate,omitempty"`    // Ping slot data rate of Class B device
+	MACVersion            pb_lorawan.MACVersion  `json:"mac_version,omitempty"`            // LoRaWAN MAC version (1.0/1.1)
+	MACSettings           MACSettings            `json:"mac_settings"`                     // Settings that are sent with MAC commands
+}
+
+// MACSettings are the settings that the NetworkServer sends to the device with MAC commands when they are changed
+type MACSettings struct {
+	MaxDutyCycle      uint8        `json:"max_duty_cycle,omitempty"`      // DutyCycleReq
+	RX1Delay          uint8        `json:"rx1_delay,omitempty"`           // RXTimingSetupReq
+	RX1DROffset       uint8        `json:"rx1_dr_offset,omitempty"`       // RXParamSetupReq
+	RX2DataRate       uint8        `json:"rx2_data_rate,omitempty"`       // RXParamSetupReq
+	RX2Frequency      uint32       `json:"rx2_frequency,omitempty"`       // RXParamSetupReq
+	Channels          []MACChannel `json:"channels,omitempty"`            // NewChannelReq and DlChannelReq
+	UplinkDwellTime   bool         `json:"uplink_dwell_time,omitempty"`   // TxParamSetupReq
+	DownlinkDwellTime bool         `json:"downlink_dwell_time,omitempty"` // TxParamSetupReq
+	MaxEIRP           uint8        `json:"max_eirp,omitempty"`            // TxParamSetupReq
+}
+
+// MACChannel is a channel of the device, a zero frequency disables the channel
+type MACChannel struct {
+	Index             uint8  `json:"index"`
+	Frequency         uint32 `json:"frequency,omitempty"`
+	MinDataRate       uint8  `json:"min_data_rate,omitempty"`
+	MaxDataRate       uint8  `json:"max_data_rate,omitempty"`
+	DownlinkFrequency uint32 `json:"downlink_frequency,omitempty"` // zero for the uplink frequency
+}
+
+// Channel returns the channel with the index, or nil if the device has no such channel
+func (s MACSettings) Channel(index uint8) *MACChannel {
+	for i, channel := range s.Channels {
+		if channel.Index == index {
+			return &s.Channels[i]
+		}
+	}
+	return nil
 }
 
 // Device contains the state of a device
 type Device struct {
 	old *Device
 
-	DevEUI   types.DevEUI  `redis:"dev_eui"`
-	AppEUI   types.AppEUI  `redis:"app_eui"`
-	AppID    string        `redis:"app_id"`
-	DevID    string        `redis:"dev_id"`
-	DevAddr  types.DevAddr `redis:"dev_addr"`
-	NwkSKey  types.NwkSKey `redis:"nwk_s_key"`
-	FCntUp   uint32        `redis:"f_cnt_up"`
-	FCntDown uint32        `redis:"f_cnt_down"`
-	LastSeen time.Time     `redis:"last_seen"`
-	Options  Options       `redis:"options"`
-	ADR      ADRSettings   `redis:"adr,include"`
+	DevEUI    types.DevEUI  `redis:"dev_eui"`
+	AppEUI    types.AppEUI  `redis:"app_eui"`
+	AppID     string        `redis:"app_id"`
+	DevID     string        `redis:"dev_id"`
+	DevAddr   types.DevAddr `redis:"dev_addr"`
+	NwkSKey   types.NwkSKey `redis:"nwk_s_key"` // The FNwkSIntKey of LoRaWAN 1.1 devices
+	FCntUp    uint32        `redis:"f_cnt_up"`
+	NFCntDown uint32        `redis:"n_f_cnt_down"` // The FCntDown of LoRaWAN 1.0 devices
+	AFCntDown uint32        `redis:"a_f_cnt_down"` // Only used by LoRaWAN 1.1 devices
+	LastSeen  time.Time     `redis:"last_seen"`
+	Options   Options       `redis:"options"`
+	ADR       ADRSettings   `redis:"adr,include"`
+
+	// Session keys of LoRaWAN 1.1 devices
+	SNwkSIntKey types.SNwkSIntKey `redis:"s_nwk_s_int_key"`
+	NwkSEncKey  types.NwkSEncKey  `redis:"nwk_s_enc_key"`
+
+	// RJCount0 is the next expected RJcount0 of the RejoinRequests of type 0 and 2 of a LoRaWAN 1.1 device
+	RJCount0 uint16 `redis:"rj_count_0"`
+
+	// LastGatewayID is the gateway that can best reach the device, based on the last uplink message
+	LastGatewayID string `redis:"last_gateway_id"`
+
+	ClassB ClassBSettings `redis:"class_b,include"`
+
+	// MAC commands that were sent to the device, but were not answered yet
+	PendingMACCommands []MACCommand `redis:"pending_mac_commands"`
+
+	// The confirmed downlink that was sent to the device, but was not acknowledged yet
+	ConfirmedDownlink *ConfirmedDownlink `redis:"confirmed_downlink"`
+
+	// Status of the device, as reported in the last DevStatusAns
+	Status DeviceStatus `redis:"status,include"`
 
 	CreatedAt time.Time `redis:"created_at"`
 	UpdatedAt time.Time `redis:"updated_at"`
@@ -45,6 +106,11 @@ type ADRSettings struct {
 	Band   string `redis:"band"`
 	Margin int    `redis:"margin"`
 
+	// The ADR algorithm that is used for the device, the default algorithm is used if it is empty
+	Algorithm string `redis:"algorithm,omitempty"`
+	// The data rate that the device is told to use by the fixed ADR algorithm
+	FixedDataRate string `redis:"fixed_data_rate,omitempty"`
+
 	// Indicates whether the NetworkServer should send a LinkADRReq when possible
 	SendReq bool `redis:"send_req,omitempty"`
 	Failed  int  `redis:"failed,omitempty"` // number of failed ADR attempts
@@ -55,6 +121,93 @@ type ADRSettings struct {
 	NbTrans  int    `redis:"nb_trans,omitempty"`
 }
 
+// ClassBSettings contains the state of the ping slots of a Class B device
+type ClassBSettings struct {
+	// The number of ping slots per beacon period, as indicated by the device in a PingSlotInfoReq
+	PingNb int `redis:"ping_nb,omitempty"`
+
+	// Indicates whether the NetworkServer should send a BeaconFreqReq when possible
+	SendBeaconFreqReq bool `redis:"send_beacon_freq_req,omitempty"`
+
+	// Indicates whether the NetworkServer should send a PingSlotChannelReq when possible
+	SendPingSlotChannelReq bool `redis:"send_ping_slot_channel_req,omitempty"`
+
+	// The ping slot channel that the device accepted in a PingSlotChannelAns, the defaults of the frequency plan
+	// are used if they are empty
+	PingSlotFrequency uint32 `redis:"ping_slot_frequency,omitempty"`
+	PingSlotDataRate  string `redis:"ping_slot_data_rate,omitempty"`
+}
+
+// MACCommand is a MAC command that is sent to the device until it is answered
+type MACCommand struct {
+	CID     uint32 `json:"cid"`
+	Payload []byte `json:"payload,omitempty"`
+	Sent    int    `json:"sent,omitempty"` // number of times the command was sent
+}
+
+// ConfirmedDownlink is a confirmed downlink message that is sent to the device until it is acknowledged. The
+// FCntDown is only incremented after the acknowledgement, so that retransmissions use the same FCnt.
+type ConfirmedDownlink struct {
+	FCnt    uint32    `json:"f_cnt"`
+	FPort   int32     `json:"f_port,omitempty"`
+	SentAt  time.Time `json:"sent_at"`           // time of the first transmission
+	Retries int       `json:"retries,omitempty"` // number of retransmissions
+}
+
+// DeviceStatus contains the battery level and demodulation margin of a device
+type DeviceStatus struct {
+	Battery   int       `redis:"battery,omitempty"` // 0: external power source, 1-254: battery level, 255: unknown
+	Margin    int       `redis:"margin,omitempty"`  // demodulation margin (dB) of the last DevStatusReq
+	UpdatedAt time.Time `redis:"updated_at,omitempty"`
+}
+
+// ResetADR resets the ADR state of the device, the band, margin and ADR algorithm are kept
+func (d *Device) ResetADR() {
+	d.ADR = ADRSettings{
+		Band:          d.ADR.Band,
+		Margin:        d.ADR.Margin,
+		Algorithm:     d.ADR.Algorithm,
+		FixedDataRate: d.ADR.FixedDataRate,
+	}
+}
+
+// IsLoRaWAN11 returns true if the device is a LoRaWAN 1.1 device
+func (d *Device) IsLoRaWAN11() bool {
+	return d.Options.MACVersion == pb_lorawan.MACVersion_MAC_V1_1
+}
+
+// FCntDown returns the next downlink frame counter for a downlink message with the FPort. LoRaWAN 1.1 devices use
+// the AFCntDown for downlink with an FPort greater than 0, and the NFCntDown otherwise.
+func (d *Device) FCntDown(fPort int32) uint32 {
+	if d.IsLoRaWAN11() && fPort > 0 {
+		return d.AFCntDown
+	}
+	return d.NFCntDown
+}
+
+// IncrementFCntDown increments the downlink frame counter for a downlink message with the FPort
+func (d *Device) IncrementFCntDown(fPort int32) {
+	if d.IsLoRaWAN11() && fPort > 0 {
+		d.AFCntDown++
+		return
+	}
+	d.NFCntDown++
+}
+
+// SetFCntDown sets the downlink frame counter for a downlink message with the FPort
+func (d *Device) SetFCntDown(fPort int32, fCnt uint32) {
+	if d.IsLoRaWAN11() && fPort > 0 {
+		d.AFCntDown = fCnt
+		return
+	}
+	d.NFCntDown = fCnt
+}
+
+// SharesFCntDown returns true if downlink messages with the FPorts use the same downlink frame counter
+func (d *Device) SharesFCntDown(fPortA, fPortB int32) bool {
+	return !d.IsLoRaWAN11() || (fPortA > 0) == (fPortB > 0)
+}
+
 // StartUpdate stores the state of the device
 func (d *Device) StartUpdate() {
 	old := *d
@@ -6,6 +6,7 @@ package device
 import (
 	"testing"
 
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	. "github.com/smartystreets/assertions"
 )
 
@@ -29,3 +30,48 @@ func TestDeviceChangedFields(t *testing.T) {
 	a.So(device.ChangedFields(), ShouldHaveLength, 1)
 	a.So(device.ChangedFields(), ShouldContain, "DevID")
 }
+
+func TestDeviceFCntDown(t *testing.T) {
+	a := New(t)
+
+	// LoRaWAN 1.0 devices use one counter
+	device := &Device{NFCntDown: 10, AFCntDown: 20}
+	a.So(device.SharesFCntDown(0, 1), ShouldBeTrue)
+	a.So(device.FCntDown(0), ShouldEqual, 10)
+	a.So(device.FCntDown(1), ShouldEqual, 10)
+	device.IncrementFCntDown(1)
+	a.So(device.NFCntDown, ShouldEqual, 11)
+
+	// LoRaWAN 1.1 devices use the AFCntDown for FPort > 0
+	device.Options.MACVersion = pb_lorawan.MACVersion_MAC_V1_1
+	a.So(device.SharesFCntDown(0, 1), ShouldBeFalse)
+	a.So(device.SharesFCntDown(1, 2), ShouldBeTrue)
+	a.So(device.FCntDown(-1), ShouldEqual, 11)
+	a.So(device.FCntDown(0), ShouldEqual, 11)
+	a.So(device.FCntDown(1), ShouldEqual, 20)
+	device.IncrementFCntDown(1)
+	a.So(device.AFCntDown, ShouldEqual, 21)
+	device.IncrementFCntDown(0)
+	a.So(device.NFCntDown, ShouldEqual, 12)
+}
+
+func TestDeviceResetADR(t *testing.T) {
+	a := New(t)
+	device := &Device{ADR: ADRSettings{
+		Band:          "EU_863_870",
+		Margin:        10,
+		Algorithm:     "fixed",
+		FixedDataRate: "SF9BW125",
+		SendReq:       true,
+		DataRate:      "SF7BW125",
+		TxPower:       14,
+		NbTrans:       2,
+	}}
+	device.ResetADR()
+	a.So(device.ADR, ShouldResemble, ADRSettings{
+		Band:          "EU_863_870",
+		Margin:        10,
+		Algorithm:     "fixed",
+		FixedDataRate: "SF9BW125",
+	})
+}
@@ -0,0 +1,25 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	redis "gopkg.in/redis.v5"
+)
+
+// SplitFCntDown migration from 2.4.1 to 2.5.0: the FCntDown is split into the NFCntDown and AFCntDown of
+// LoRaWAN 1.1, existing devices are LoRaWAN 1.0 devices that only use the NFCntDown
+func SplitFCntDown(prefix string) storage.MigrateFunction {
+	return func(client *redis.Client, key string, obj map[string]string) (string, map[string]string, error) {
+		if fCntDown, ok := obj["f_cnt_down"]; ok {
+			delete(obj, "f_cnt_down")
+			obj["n_f_cnt_down"] = fCntDown
+		}
+		return "2.5.0", obj, nil
+	}
+}
+
+func init() {
+	deviceMigrations["2.4.1"] = SplitFCntDown
+}
@@ -5,6 +5,7 @@ package device
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device/migrate"
@@ -18,6 +19,9 @@ import (
 type Store interface {
 	List(opts *storage.ListOptions) ([]*Device, error)
 	ListForAddress(devAddr types.DevAddr) ([]*Device, error)
+	CountForAddress(devAddr types.DevAddr) (int, error)
+	LeastUsedAddress(prefix types.DevAddrPrefix, start types.DevAddr) (types.DevAddr, int, error)
+	ListForDevEUI(devEUI types.DevEUI) ([]*Device, error)
 	Get(appEUI types.AppEUI, devEUI types.DevEUI) (*Device, error)
 	Set(new *Device, properties ...string) (err error)
 	Delete(appEUI types.AppEUI, devEUI types.DevEUI) error
@@ -53,12 +57,16 @@ func NewRedisDeviceStore(client *redis.Client, prefix string) Store {
 // RedisDeviceStore stores Devices in Redis.
 // - Devices are stored as a Hash
 // - DevAddr mappings are indexed in a Set
+// - DevAddr usage is indexed in Sorted Sets
 type RedisDeviceStore struct {
 	client       *redis.Client
 	prefix       string
 	store        *storage.RedisMapStore
 	frameStore   *storage.RedisQueueStore
 	devAddrIndex *storage.RedisSetStore
+
+	devAddrUsageMu      sync.Mutex
+	devAddrUsageIndexed bool
 }
 
 // List all Devices
@@ -98,6 +106,26 @@ func (s *RedisDeviceStore) ListForAddress(devAddr types.DevAddr) ([]*Device, err
 	return devices, nil
 }
 
+// CountForAddress returns the number of devices that use a specific DevAddr
+func (s *RedisDeviceStore) CountForAddress(devAddr types.DevAddr) (int, error) {
+	return s.devAddrIndex.Size(devAddr.String())
+}
+
+// ListForDevEUI lists all devices with a specific DevEUI
+func (s *RedisDeviceStore) ListForDevEUI(devEUI types.DevEUI) ([]*Device, error) {
+	devicesI, err := s.store.List(fmt.Sprintf("*:%s", devEUI), nil)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]*Device, len(devicesI))
+	for i, deviceI := range devicesI {
+		if device, ok := deviceI.(Device); ok {
+			devices[i] = &device
+		}
+	}
+	return devices, nil
+}
+
 // Get a specific Device
 func (s *RedisDeviceStore) Get(appEUI types.AppEUI, devEUI types.DevEUI) (*Device, error) {
 	deviceI, err := s.store.Get(fmt.Sprintf("%s:%s", appEUI, devEUI))
@@ -118,7 +146,7 @@ func (s *RedisDeviceStore) Set(new *Device, properties ...string) (err error) {
 	if old != nil {
 		addrChanged = new.DevAddr != old.DevAddr || new.DevEUI != old.DevEUI || new.AppEUI != old.AppEUI
 		if addrChanged {
-			if err := s.devAddrIndex.Remove(old.DevAddr.String(), fmt.Sprintf("%s:%s", old.AppEUI, old.DevEUI)); err != nil {
+			if err := s.removeFromDevAddrIndex(old.DevAddr, fmt.Sprintf("%s:%s", old.AppEUI, old.DevEUI)); err != nil {
 				return err
 			}
 		}
@@ -136,7 +164,7 @@ func (s *RedisDeviceStore) Set(new *Device, properties ...string) (err error) {
 	}
 
 	if (new.old == nil || addrChanged) && !new.DevAddr.IsEmpty() {
-		if err := s.devAddrIndex.Add(new.DevAddr.String(), key); err != nil {
+		if err := s.addToDevAddrIndex(new.DevAddr, key); err != nil {
 			return err
 		}
 	}
@@ -159,7 +187,7 @@ func (s *RedisDeviceStore) Delete(appEUI types.AppEUI, devEUI types.DevEUI) erro
 	}
 
 	if !device.DevAddr.IsEmpty() {
-		if err := s.devAddrIndex.Remove(device.DevAddr.String(), key); err != nil {
+		if err := s.removeFromDevAddrIndex(device.DevAddr, key); err != nil {
 			return err
 		}
 	}
@@ -55,6 +55,13 @@ func TestDeviceStore(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(res, ShouldHaveLength, 0)
 
+	count, err := s.CountForAddress(types.DevAddr{0, 0, 0, 1})
+	a.So(err, ShouldBeNil)
+	a.So(count, ShouldEqual, 2)
+	count, err = s.CountForAddress(types.DevAddr{0, 0, 0, 2})
+	a.So(err, ShouldBeNil)
+	a.So(count, ShouldEqual, 0)
+
 	// Existing Device, New DevAddr
 	err = s.Set(&Device{
 		old: &Device{
@@ -118,4 +125,97 @@ func TestDeviceStore(t *testing.T) {
 	res, err = s.ListForAddress(types.DevAddr{0, 0, 0, 3})
 	a.So(err, ShouldBeNil)
 	a.So(res, ShouldHaveLength, 1)
+
+	res, err = s.ListForDevEUI(types.DevEUI{0, 0, 0, 0, 0, 0, 0, 2})
+	a.So(err, ShouldBeNil)
+	a.So(res, ShouldHaveLength, 1)
+	res, err = s.ListForDevEUI(types.DevEUI{0, 0, 0, 0, 0, 0, 0, 1})
+	a.So(err, ShouldBeNil)
+	a.So(res, ShouldHaveLength, 0)
+}
+
+func TestDeviceStoreMigrate(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	s := NewRedisDeviceStore(client, "networkserver-test-device-store-migrate")
+
+	// A device of version 2.4.1
+	key := "networkserver-test-device-store-migrate:device:0000000000000001:0000000000000001"
+	err := client.HMSet(key, map[string]string{
+		"_version":   "2.4.1",
+		"app_eui":    "0000000000000001",
+		"dev_eui":    "0000000000000001",
+		"f_cnt_down": "42",
+	}).Err()
+	a.So(err, ShouldBeNil)
+	defer client.Del(key)
+
+	dev, err := s.Get(types.AppEUI{0, 0, 0, 0, 0, 0, 0, 1}, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 1})
+	a.So(err, ShouldBeNil)
+	a.So(dev.NFCntDown, ShouldEqual, 42)
+	a.So(dev.AFCntDown, ShouldEqual, 0)
+	a.So(dev.IsLoRaWAN11(), ShouldBeFalse)
+
+	fields, err := client.HGetAll(key).Result()
+	a.So(err, ShouldBeNil)
+	a.So(fields["_version"], ShouldEqual, "2.5.0")
+	a.So(fields, ShouldNotContainKey, "f_cnt_down")
+}
+
+func TestDeviceStoreLeastUsedAddress(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	defer func() {
+		keys, _ := client.Keys("*networkserver-test-least-used-address*").Result()
+		for _, key := range keys {
+			client.Del(key).Result()
+		}
+	}()
+
+	// A device that was indexed before the DevAddr usage index existed
+	client.SAdd("networkserver-test-least-used-address:dev_addr:26000000", "0000000000000001:0000000000000001").Result()
+
+	s := NewRedisDeviceStore(client, "networkserver-test-least-used-address")
+	prefix := types.DevAddrPrefix{DevAddr: types.DevAddr{0x26, 0, 0, 0}, Length: 30}
+
+	devAddr, used, err := s.LeastUsedAddress(prefix, types.DevAddr{0, 0, 0, 0})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, types.DevAddr{0x26, 0, 0, 1})
+	a.So(used, ShouldEqual, 0)
+
+	// The search wraps around at the end of the prefix
+	for i := byte(1); i < 4; i++ {
+		a.So(s.Set(&Device{
+			DevAddr: types.DevAddr{0x26, 0, 0, i},
+			DevEUI:  types.DevEUI{0, 0, 0, 0, 0, 0, 0, i},
+			AppEUI:  types.AppEUI{0, 0, 0, 0, 0, 0, 0, 2},
+		}), ShouldBeNil)
+	}
+	a.So(s.Set(&Device{
+		DevAddr: types.DevAddr{0x26, 0, 0, 2},
+		DevEUI:  types.DevEUI{0, 0, 0, 0, 0, 0, 1, 2},
+		AppEUI:  types.AppEUI{0, 0, 0, 0, 0, 0, 0, 2},
+	}), ShouldBeNil)
+	devAddr, used, err = s.LeastUsedAddress(prefix, types.DevAddr{0, 0, 0, 3})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, types.DevAddr{0x26, 0, 0, 3})
+	a.So(used, ShouldEqual, 1)
+	devAddr, used, err = s.LeastUsedAddress(prefix, types.DevAddr{0, 0, 0, 2})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, types.DevAddr{0x26, 0, 0, 3})
+	a.So(used, ShouldEqual, 1)
+
+	// Deleted devices no longer use the DevAddr
+	a.So(s.Delete(types.AppEUI{0, 0, 0, 0, 0, 0, 0, 2}, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 2}), ShouldBeNil)
+	devAddr, used, err = s.LeastUsedAddress(prefix, types.DevAddr{0, 0, 0, 3})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, types.DevAddr{0x26, 0, 0, 3})
+	a.So(used, ShouldEqual, 1)
+	a.So(s.Delete(types.AppEUI{0, 0, 0, 0, 0, 0, 0, 2}, types.DevEUI{0, 0, 0, 0, 0, 0, 1, 2}), ShouldBeNil)
+	devAddr, used, err = s.LeastUsedAddress(prefix, types.DevAddr{0, 0, 0, 3})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, types.DevAddr{0x26, 0, 0, 2})
+	a.So(used, ShouldEqual, 0)
 }
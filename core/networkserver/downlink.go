@@ -4,7 +4,10 @@
 package networkserver
 
 import (
+	"strings"
+
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/brocaar/lorawan"
@@ -22,6 +25,10 @@ func (n *networkServer) HandleDownlink(message *pb_broker.DownlinkMessage) (*pb_
 
 	n.status.downlink.Mark(1)
 
+	if message.Multicast != nil {
+		return n.handleMulticastDownlink(message)
+	}
+
 	// Get Device
 	dev, err := n.devices.Get(*message.AppEui, *message.DevEui)
 	if err != nil {
@@ -49,21 +56,45 @@ func (n *networkServer) HandleDownlink(message *pb_broker.DownlinkMessage) (*pb_
 		return nil, errors.NewErrInvalidArgument("Downlink", "DevAddr does not match device")
 	}
 
+	// Downlink that is not a response to an uplink message
+	if message.DownlinkOption == nil {
+		if err = adoptDownlinkFCnt(message, dev); err != nil {
+			return nil, err
+		}
+		switch dev.Options.Class {
+		case pb_lorawan.DeviceClass_CLASS_B:
+			message.DownlinkOption, err = n.buildClassBDownlinkOption(dev)
+		default:
+			message.DownlinkOption, err = n.buildClassCDownlinkOption(dev)
+		}
+		if err != nil {
+			return nil, err
+		}
+		message.Trace = message.Trace.WithEvent("schedule class "+strings.ToLower(strings.TrimPrefix(dev.Options.Class.String(), "CLASS_")), "gateway", message.DownlinkOption.GatewayId)
+	}
+
 	err = n.handleDownlinkMAC(message, dev)
 	if err != nil {
 		return nil, err
 	}
 
-	lorawanDownlinkMac.FCnt = dev.FCntDown // Use full 32-bit FCnt for setting MIC
-	dev.FCntDown++                         // TODO: For confirmed downlink, FCntDown should be incremented AFTER ACK
+	n.setDownlinkFCnt(message, dev) // Use full 32-bit FCnt for setting MIC
 
 	phyPayload := message.Message.GetLorawan().PHYPayload()
-	phyPayload.SetMIC(lorawan.AES128Key(dev.NwkSKey))
-	bytes, err := phyPayload.MarshalBinary()
+	var bytes []byte
+	if dev.IsLoRaWAN11() {
+		bytes, err = marshalLoRaWAN11Downlink(phyPayload, dev)
+	} else {
+		phyPayload.SetMIC(lorawan.AES128Key(dev.NwkSKey))
+		bytes, err = phyPayload.MarshalBinary()
+	}
 	if err != nil {
 		return nil, err
 	}
 	message.Payload = bytes
 
+	// Only MAC commands that are actually sent count as a retry
+	message.Trace = markMACCommandsSent(message.Message.GetLorawan().GetMacPayload().FOpts, dev, message.Trace)
+
 	return message, nil
 }
@@ -12,5 +12,7 @@ func (n *networkServer) handleDownlinkMAC(message *pb_broker.DownlinkMessage, de
 	if err := n.handleDownlinkADR(message, dev); err != nil {
 		return err
 	}
+	n.handleDownlinkClassBMAC(message, dev)
+	n.handleDownlinkMACCommands(message, dev)
 	return nil
 }
@@ -98,6 +98,10 @@ func TestHandleDownlink(t *testing.T) {
 	a.So(phyPayload.MIC, ShouldNotEqual, [4]byte{0, 0, 0, 0}) // MIC should be set, we'll check it with actual examples in the integration test
 
 	dev, _ := ns.devices.Get(appEUI, devEUI)
-	a.So(dev.FCntDown, ShouldEqual, 1)
+	a.So(dev.NFCntDown, ShouldEqual, 1)
 
+	// The DevStatusReq was sent
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 1)
+	a.So(dev.PendingMACCommands[0].CID, ShouldEqual, lorawan.DevStatusReq)
+	a.So(dev.PendingMACCommands[0].Sent, ShouldEqual, 1)
 }
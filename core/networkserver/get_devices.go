@@ -15,6 +15,10 @@ func (n *networkServer) HandleGetDevices(req *pb.DevicesRequest) (*pb.DevicesRes
 		return nil, err
 	}
 
+	if n.status != nil {
+		n.status.devicesPerAddress.Update(int64(len(devices)))
+	}
+
 	// Return all devices with DevAddr with FCnt <= fCnt or Security off
 
 	res := &pb.DevicesResponse{
@@ -35,6 +39,7 @@ func (n *networkServer) HandleGetDevices(req *pb.DevicesRequest) (*pb.DevicesRes
 			FCntUp:           device.FCntUp,
 			Uses32BitFCnt:    device.Options.Uses32BitFCnt,
 			DisableFCntCheck: device.Options.DisableFCntCheck,
+			MacVersion:       device.Options.MACVersion,
 		}
 		if device.Options.DisableFCntCheck {
 			res.Results = append(res.Results, dev)
@@ -0,0 +1,244 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"fmt"
+
+	"github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/frame"
+	"github.com/brocaar/lorawan"
+)
+
+// MAC commands of LoRaWAN 1.1
+const (
+	resetInd  = 0x01
+	resetConf = 0x01
+	rekeyInd  = 0x0B
+	rekeyConf = 0x0B
+)
+
+// servedMinorVersion is the minor version of LoRaWAN 1.x that is served by the NetworkServer
+const servedMinorVersion = 1
+
+// Offsets of the FCtrl and FOpts in a data frame
+const (
+	fCtrlOffset = 5
+	fOptsOffset = 8
+)
+
+// uplinkMACCommandLengths contains the payload length of the MAC commands that devices send
+var uplinkMACCommandLengths = map[uint32]int{
+	resetInd:                         1,
+	uint32(lorawan.LinkCheckReq):     0,
+	uint32(lorawan.LinkADRAns):       1,
+	uint32(lorawan.DutyCycleAns):     0,
+	uint32(lorawan.RXParamSetupAns):  1,
+	uint32(lorawan.DevStatusAns):     2,
+	uint32(lorawan.NewChannelAns):    1,
+	uint32(lorawan.RXTimingSetupAns): 0,
+	txParamSetupReq:                  0,
+	dlChannelReq:                     1,
+	rekeyInd:                         1,
+	deviceTimeReq:                    0,
+	pingSlotInfoReq:                  1,
+	beaconFreqAns:                    1,
+}
+
+// parseUplinkMACCommands parses the (decrypted) FOpts of an uplink message. Parsing stops at the first unknown
+// MAC command, because the length of its payload is unknown.
+func parseUplinkMACCommands(fOpts []byte) (cmds []pb_lorawan.MACCommand) {
+	for len(fOpts) > 0 {
+		cid := uint32(fOpts[0])
+		length, ok := uplinkMACCommandLengths[cid]
+		if !ok || len(fOpts) < 1+length {
+			break
+		}
+		cmd := pb_lorawan.MACCommand{Cid: cid}
+		if length > 0 {
+			cmd.Payload = append([]byte{}, fOpts[1:1+length]...)
+		}
+		cmds = append(cmds, cmd)
+		fOpts = fOpts[1+length:]
+	}
+	return
+}
+
+// decryptUplinkFOpts decrypts the MAC commands in the FOpts of an uplink message of a LoRaWAN 1.1 device
+func decryptUplinkFOpts(payload []byte, fCnt uint32, dev *device.Device) ([]pb_lorawan.MACCommand, error) {
+	decrypted := append([]byte{}, payload...)
+	if err := frame.EncryptFOpts(dev.NwkSEncKey, true, fCnt, decrypted); err != nil {
+		return nil, err
+	}
+	fOptsLen := int(decrypted[fCtrlOffset] & 0x0F)
+	return parseUplinkMACCommands(decrypted[fOptsOffset : fOptsOffset+fOptsLen]), nil
+}
+
+// uplinkChannel returns the data rate index and channel index of an uplink message in its frequency plan
+func uplinkChannel(message *pb_broker.DeduplicatedUplinkMessage) (txDr, txCh uint8, err error) {
+	lorawanMetadata := message.GetProtocolMetadata().GetLorawan()
+	fp, err := band.Get(frequencyPlanName(lorawanMetadata.GetFrequencyPlanName(), lorawanMetadata.GetFrequencyPlan()))
+	if err != nil {
+		return 0, 0, err
+	}
+	drIdx, err := fp.GetDataRateIndexFor(lorawanMetadata.GetDataRate())
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(message.GetGatewayMetadata()) == 0 {
+		return 0, 0, errors.NewErrInvalidArgument("Uplink", "does not contain gateway metadata")
+	}
+	frequency := message.GetGatewayMetadata()[0].Frequency
+	for i, ch := range fp.UplinkChannels {
+		if uint64(ch.Frequency) == frequency {
+			return uint8(drIdx), uint8(i), nil
+		}
+	}
+	return 0, 0, errors.NewErrNotFound(fmt.Sprintf("uplink channel on %d Hz", frequency))
+}
+
+// validateUplinkMIC validates the full MIC of an uplink message of a LoRaWAN 1.1 device. The Broker only validates
+// the part of the MIC that is computed with the FNwkSIntKey, the other part is computed with the SNwkSIntKey.
+func validateUplinkMIC(message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) error {
+	lorawanUplinkMac := message.GetMessage().GetLorawan().GetMacPayload()
+	txDr, txCh, err := uplinkChannel(message)
+	if err != nil {
+		return err
+	}
+	var confFCnt uint16
+	if lorawanUplinkMac.Ack && dev.ConfirmedDownlink != nil {
+		confFCnt = uint16(dev.ConfirmedDownlink.FCnt) // The FCnt of the confirmed downlink that is acknowledged
+	}
+	if !frame.ValidateUplinkMIC(dev.NwkSKey, dev.SNwkSIntKey, confFCnt, txDr, txCh, dev.DevAddr, lorawanUplinkMac.FCnt, message.Payload) {
+		return errors.NewErrInvalidArgument("Uplink", "invalid MIC")
+	}
+	return nil
+}
+
+// marshalLoRaWAN11Downlink marshals a downlink message to a LoRaWAN 1.1 device: the MAC commands in the FOpts are
+// encrypted with the NwkSEncKey and the MIC is computed with the SNwkSIntKey
+func marshalLoRaWAN11Downlink(phyPayload lorawan.PHYPayload, dev *device.Device) ([]byte, error) {
+	macPayload, ok := phyPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return nil, errors.NewErrInvalidArgument("Downlink", "does not contain a MAC payload")
+	}
+	bytes, err := phyPayload.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := frame.EncryptFOpts(dev.NwkSEncKey, false, macPayload.FHDR.FCnt, bytes); err != nil {
+		return nil, err
+	}
+	var confFCnt uint16
+	if macPayload.FHDR.FCtrl.ACK {
+		confFCnt = uint16(dev.FCntUp) // The FCnt of the confirmed uplink that is acknowledged
+	}
+	mic := frame.CalculateDownlinkMIC(dev.SNwkSIntKey, confFCnt, dev.DevAddr, macPayload.FHDR.FCnt, bytes[:len(bytes)-4])
+	copy(bytes[len(bytes)-4:], mic[:])
+	return bytes, nil
+}
+
+// handleLoRaWAN11MAC handles the ResetInd and RekeyInd of LoRaWAN 1.1 devices, it returns false if the command is
+// not a LoRaWAN 1.1 MAC command. Devices repeat these commands until they receive the confirmation.
+func (n *networkServer) handleLoRaWAN11MAC(cmd pb_lorawan.MACCommand, message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) bool {
+	if !dev.IsLoRaWAN11() || (cmd.Cid != resetInd && cmd.Cid != rekeyInd) {
+		return false
+	}
+	if len(cmd.Payload) != 1 {
+		return true
+	}
+	minorVersion := cmd.Payload[0] & 0x0F
+	if minorVersion > servedMinorVersion {
+		minorVersion = servedMinorVersion
+	}
+	lorawanDownlinkMac := message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload()
+	switch cmd.Cid {
+	case resetInd:
+		// The (ABP) device was reset, so the NetworkServer resets the state of the device
+		dev.NFCntDown = 0
+		dev.AFCntDown = 0
+		dev.ResetADR()
+		dev.PendingMACCommands = nil
+		dev.ConfirmedDownlink = nil
+		queueMACSettings(dev, device.MACSettings{})
+		lorawanDownlinkMac.FOpts = append(lorawanDownlinkMac.FOpts, pb_lorawan.MACCommand{
+			Cid:     resetConf,
+			Payload: []byte{minorVersion},
+		})
+		message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "reset", "minor", minorVersion)
+		n.Ctx.WithFields(log.Fields{
+			"AppID": dev.AppID,
+			"DevID": dev.DevID,
+		}).Info("Device was reset")
+	case rekeyInd:
+		lorawanDownlinkMac.FOpts = append(lorawanDownlinkMac.FOpts, pb_lorawan.MACCommand{
+			Cid:     rekeyConf,
+			Payload: []byte{minorVersion},
+		})
+		message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "rekey", "minor", minorVersion)
+	}
+	return true
+}
+
+// getRejoinDevice returns the device that sent a RejoinRequest. RejoinRequests of type 0 and 2 do not contain the
+// JoinEUI (AppEUI), so the device is found by its DevEUI and the MIC, which is computed with the SNwkSIntKey. The MIC
+// of RejoinRequests of type 1 is validated by the Handler.
+func (n *networkServer) getRejoinDevice(activation *pb_broker.DeduplicatedDeviceActivationRequest) (*device.Device, *pb_lorawan.RejoinRequest, error) {
+	var rejoin pb_lorawan.RejoinRequest
+	if err := rejoin.UnmarshalBinary(activation.Payload); err != nil {
+		return nil, nil, err
+	}
+
+	if rejoin.RejoinType == 1 {
+		dev, err := n.devices.Get(rejoin.JoinEUI, rejoin.DevEUI)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !dev.IsLoRaWAN11() {
+			return nil, nil, errors.NewErrInvalidArgument("Activation", "RejoinRequest of device that does not support LoRaWAN 1.1")
+		}
+		return dev, &rejoin, nil
+	}
+
+	if rejoin.NetID != n.netID {
+		return nil, nil, errors.NewErrInvalidArgument("Activation", "RejoinRequest for a different NetID")
+	}
+	devices, err := n.devices.ListForDevEUI(rejoin.DevEUI)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dev *device.Device
+	for _, candidate := range devices {
+		if candidate == nil || !candidate.IsLoRaWAN11() {
+			continue
+		}
+		if rejoin.ValidateMIC(types.AES128Key(candidate.SNwkSIntKey)) == nil {
+			dev = candidate
+			break
+		}
+	}
+	if dev == nil {
+		return nil, nil, errors.NewErrNotFound("device that validates MIC")
+	}
+	if rejoin.RJCount < dev.RJCount0 {
+		return nil, nil, errors.NewErrInvalidArgument("Activation RJcount0", "not high enough")
+	}
+
+	dev.StartUpdate()
+	dev.RJCount0 = rejoin.RJCount + 1
+	if err := n.devices.Set(dev); err != nil {
+		return nil, nil, err
+	}
+
+	activation.AppEui = &dev.AppEUI
+	activation.DevEui = &dev.DevEUI
+	return dev, &rejoin, nil
+}
@@ -0,0 +1,194 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/frame"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestParseUplinkMACCommands(t *testing.T) {
+	a := New(t)
+	cmds := parseUplinkMACCommands([]byte{resetInd, 0x01, byte(lorawan.DevStatusAns), 0xFE, 0x3F, byte(lorawan.LinkCheckReq)})
+	a.So(cmds, ShouldHaveLength, 3)
+	a.So(cmds[0].Payload, ShouldResemble, []byte{0x01})
+	a.So(cmds[1].Payload, ShouldResemble, []byte{0xFE, 0x3F})
+	a.So(cmds[2].Cid, ShouldEqual, lorawan.LinkCheckReq)
+
+	// Parsing stops at unknown commands
+	a.So(parseUplinkMACCommands([]byte{0x7F, 0x01, resetInd, 0x01}), ShouldBeEmpty)
+}
+
+func TestDecryptUplinkFOpts(t *testing.T) {
+	a := New(t)
+	dev := &device.Device{NwkSEncKey: types.NwkSEncKey{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}
+
+	// MHDR | DevAddr | FCtrl (FOptsLen 2) | FCnt | FOpts (RekeyInd) | MIC
+	payload := []byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x02, 0x05, 0x00, rekeyInd, 0x01, 0x00, 0x00, 0x00, 0x00}
+	frame.EncryptFOpts(dev.NwkSEncKey, true, 5, payload)
+	a.So(payload[8:10], ShouldNotResemble, []byte{rekeyInd, 0x01})
+
+	cmds, err := decryptUplinkFOpts(payload, 5, dev)
+	a.So(err, ShouldBeNil)
+	a.So(cmds, ShouldResemble, []pb_lorawan.MACCommand{{Cid: rekeyInd, Payload: []byte{0x01}}})
+}
+
+func TestHandleLoRaWAN11MAC(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleLoRaWAN11MAC")},
+	}
+	dev := &device.Device{
+		NFCntDown: 10,
+		AFCntDown: 20,
+		ADR:       device.ADRSettings{Band: "EU_863_870", SendReq: true},
+	}
+
+	// LoRaWAN 1.0
+	message := adrInitUplinkMessage()
+	a.So(ns.handleLoRaWAN11MAC(pb_lorawan.MACCommand{Cid: resetInd, Payload: []byte{0x01}}, message, dev), ShouldBeFalse)
+
+	dev.Options.MACVersion = pb_lorawan.MACVersion_MAC_V1_1
+	a.So(ns.handleLoRaWAN11MAC(pb_lorawan.MACCommand{Cid: uint32(lorawan.DevStatusAns)}, message, dev), ShouldBeFalse)
+
+	// ResetInd
+	a.So(ns.handleLoRaWAN11MAC(pb_lorawan.MACCommand{Cid: resetInd, Payload: []byte{0x02}}, message, dev), ShouldBeTrue)
+	a.So(dev.NFCntDown, ShouldEqual, 0)
+	a.So(dev.AFCntDown, ShouldEqual, 0)
+	a.So(dev.ADR.SendReq, ShouldBeFalse)
+	a.So(dev.ADR.Band, ShouldEqual, "EU_863_870")
+	fOpts := message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload().FOpts
+	a.So(fOpts, ShouldResemble, []pb_lorawan.MACCommand{{Cid: resetConf, Payload: []byte{servedMinorVersion}}})
+
+	// RekeyInd
+	message = adrInitUplinkMessage()
+	a.So(ns.handleLoRaWAN11MAC(pb_lorawan.MACCommand{Cid: rekeyInd, Payload: []byte{0x01}}, message, dev), ShouldBeTrue)
+	fOpts = message.GetResponseTemplate().GetMessage().GetLorawan().GetMacPayload().FOpts
+	a.So(fOpts, ShouldResemble, []pb_lorawan.MACCommand{{Cid: rekeyConf, Payload: []byte{0x01}}})
+}
+
+func TestGetRejoinDevice(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestGetRejoinDevice")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "ns-test-get-rejoin-device"),
+		netID:     [3]byte{0x00, 0x00, 0x13},
+	}
+
+	dev := &device.Device{
+		AppEUI:      types.AppEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8)),
+		DevEUI:      types.DevEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8)),
+		SNwkSIntKey: types.SNwkSIntKey{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		Options:     device.Options{MACVersion: pb_lorawan.MACVersion_MAC_V1_1},
+	}
+	a.So(ns.devices.Set(dev), ShouldBeNil)
+	defer ns.devices.Delete(dev.AppEUI, dev.DevEUI)
+
+	rejoinActivation := func(rejoin pb_lorawan.RejoinRequest) *pb_broker.DeduplicatedDeviceActivationRequest {
+		rejoin.SetMIC(types.AES128Key(dev.SNwkSIntKey))
+		payload, _ := rejoin.MarshalBinary()
+		return &pb_broker.DeduplicatedDeviceActivationRequest{Payload: payload, DevEui: &dev.DevEUI}
+	}
+
+	// Other NetID
+	_, _, err := ns.getRejoinDevice(rejoinActivation(pb_lorawan.RejoinRequest{DevEUI: dev.DevEUI, NetID: [3]byte{0x00, 0x00, 0x14}}))
+	a.So(err, ShouldNotBeNil)
+
+	activation := rejoinActivation(pb_lorawan.RejoinRequest{DevEUI: dev.DevEUI, NetID: ns.netID, RJCount: 3})
+	found, rejoin, err := ns.getRejoinDevice(activation)
+	a.So(err, ShouldBeNil)
+	a.So(found.AppEUI, ShouldEqual, dev.AppEUI)
+	a.So(rejoin.RJCount, ShouldEqual, 3)
+	a.So(*activation.AppEui, ShouldEqual, dev.AppEUI)
+
+	// RJcount0 used twice
+	_, _, err = ns.getRejoinDevice(rejoinActivation(pb_lorawan.RejoinRequest{DevEUI: dev.DevEUI, NetID: ns.netID, RJCount: 3}))
+	a.So(err, ShouldNotBeNil)
+
+	// Wrong MIC
+	activation = rejoinActivation(pb_lorawan.RejoinRequest{DevEUI: dev.DevEUI, NetID: ns.netID, RJCount: 4})
+	activation.Payload[len(activation.Payload)-1]++
+	_, _, err = ns.getRejoinDevice(activation)
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestHandleUplinkLoRaWAN11MIC(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleUplinkLoRaWAN11MIC")},
+		devices:   device.NewRedisDeviceStore(GetRedisClient(), "ns-test-handle-uplink-lorawan-1-1-mic"),
+	}
+	ns.InitStatus()
+
+	dev := &device.Device{
+		AppEUI:      types.AppEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8)),
+		DevEUI:      types.DevEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8)),
+		DevAddr:     getDevAddr(1, 2, 3, 4),
+		NwkSKey:     types.NwkSKey{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		SNwkSIntKey: types.SNwkSIntKey{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+		Options:     device.Options{MACVersion: pb_lorawan.MACVersion_MAC_V1_1},
+	}
+	a.So(ns.devices.Set(dev), ShouldBeNil)
+	defer ns.devices.Delete(dev.AppEUI, dev.DevEUI)
+
+	fPort := uint8(1)
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{MType: lorawan.UnconfirmedDataUp, Major: lorawan.LoRaWANR1},
+		MACPayload: &lorawan.MACPayload{
+			FHDR:  lorawan.FHDR{DevAddr: lorawan.DevAddr(dev.DevAddr), FCnt: 1},
+			FPort: &fPort,
+		},
+	}
+	payload, _ := phy.MarshalBinary()
+	msgLen := len(payload) - 4
+
+	// SF7BW125 is DR5 and 868.1 MHz is channel 0 in EU_863_870
+	fMIC := frame.CalculateUplinkFMIC(dev.NwkSKey, dev.DevAddr, 1, payload[:msgLen])
+	sMIC := frame.CalculateUplinkSMIC(dev.SNwkSIntKey, 0, 5, 0, dev.DevAddr, 1, payload[:msgLen])
+	uplink := func(mic []byte) *pb_broker.DeduplicatedUplinkMessage {
+		payload := append(append([]byte{}, payload[:msgLen]...), mic...)
+		return &pb_broker.DeduplicatedUplinkMessage{
+			AppEui:           &dev.AppEUI,
+			DevEui:           &dev.DevEUI,
+			Payload:          payload,
+			ResponseTemplate: &pb_broker.DownlinkMessage{DownlinkOption: &pb_broker.DownlinkOption{}},
+			GatewayMetadata:  []*pb_gateway.RxMetadata{{Frequency: 868100000}},
+			ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{
+				Lorawan: &pb_lorawan.Metadata{
+					DataRate:      "SF7BW125",
+					FrequencyPlan: pb_lorawan.FrequencyPlan_EU_863_870,
+				},
+			}},
+		}
+	}
+
+	// The part of the MIC that is computed with the FNwkSIntKey is valid, but the part that is computed with the
+	// SNwkSIntKey is not
+	_, err := ns.HandleUplink(uplink([]byte{sMIC[0] ^ 0xFF, sMIC[1], fMIC[0], fMIC[1]}))
+	a.So(err, ShouldNotBeNil)
+	dev, _ = ns.devices.Get(dev.AppEUI, dev.DevEUI)
+	a.So(dev.FCntUp, ShouldEqual, 0)
+
+	// Wrong channel
+	wrongChannel := uplink([]byte{sMIC[0], sMIC[1], fMIC[0], fMIC[1]})
+	wrongChannel.GatewayMetadata[0].Frequency = 868300000
+	_, err = ns.HandleUplink(wrongChannel)
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ns.HandleUplink(uplink([]byte{sMIC[0], sMIC[1], fMIC[0], fMIC[1]}))
+	a.So(err, ShouldBeNil)
+	dev, _ = ns.devices.Get(dev.AppEUI, dev.DevEUI)
+	a.So(dev.FCntUp, ShouldEqual, 1)
+}
@@ -0,0 +1,373 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/brocaar/lorawan"
+)
+
+// MAC commands of LoRaWAN 1.0.2 that are not defined by the lorawan package, the answers have the same CID
+const (
+	txParamSetupReq = 0x09
+	dlChannelReq    = 0x0A
+)
+
+// maxFOptsLength is the maximum length of the MAC commands in the FOpts field
+const maxFOptsLength = 15
+
+// MACCommandMaxRetries is the maximum number of times that a MAC command is sent again if it is not answered.
+// A negative value means that there is no maximum.
+var MACCommandMaxRetries = 8
+
+// DevStatusInterval is the interval at which the NetworkServer requests the status of devices. The DevStatusReq is
+// only added to downlink messages that are sent anyway.
+var DevStatusInterval = 24 * time.Hour
+
+// macCommand describes a MAC command that the NetworkServer sends to devices, and the answer of the devices
+type macCommand struct {
+	// The name of the command in trace events
+	name string
+	// The length of the payload of the answer
+	ansLength int
+	// handleAns handles the answer to the request, it returns the fields for the trace event and whether the
+	// device accepted the request
+	handleAns func(dev *device.Device, req, ans []byte) (fields []interface{}, ok bool)
+	// multiple indicates that multiple requests with this CID (but a different first byte) can be pending
+	multiple bool
+}
+
+// acceptAns is used for answers that have no payload
+func acceptAns(dev *device.Device, req, ans []byte) ([]interface{}, bool) {
+	return nil, true
+}
+
+// macCommands is the registry of MAC commands that the NetworkServer can send to devices
+var macCommands = map[uint32]macCommand{
+	uint32(lorawan.DutyCycleReq): {
+		name:      "duty-cycle",
+		handleAns: acceptAns,
+	},
+	uint32(lorawan.RXParamSetupReq): {
+		name:      "rx-param-setup",
+		ansLength: 1,
+		handleAns: func(dev *device.Device, req, ans []byte) ([]interface{}, bool) {
+			rx1DROffsetAck, rx2DataRateAck, channelAck := ans[0]&0x04 != 0, ans[0]&0x02 != 0, ans[0]&0x01 != 0
+			return []interface{}{
+				"rx1-dr-offset-ack", rx1DROffsetAck,
+				"rx2-data-rate-ack", rx2DataRateAck,
+				"channel-ack", channelAck,
+			}, rx1DROffsetAck && rx2DataRateAck && channelAck
+		},
+	},
+	uint32(lorawan.DevStatusReq): {
+		name:      "dev-status",
+		ansLength: 2,
+		handleAns: func(dev *device.Device, req, ans []byte) ([]interface{}, bool) {
+			margin := int(ans[1] & 0x3F)
+			if margin >= 32 {
+				margin -= 64 // 6 bit signed integer
+			}
+			dev.Status = device.DeviceStatus{
+				Battery:   int(ans[0]),
+				Margin:    margin,
+				UpdatedAt: time.Now(),
+			}
+			return []interface{}{"battery", dev.Status.Battery, "margin", dev.Status.Margin}, true
+		},
+	},
+	uint32(lorawan.NewChannelReq): {
+		name:      "new-channel",
+		ansLength: 1,
+		multiple:  true,
+		handleAns: func(dev *device.Device, req, ans []byte) ([]interface{}, bool) {
+			dataRateRangeOk, frequencyOk := ans[0]&0x02 != 0, ans[0]&0x01 != 0
+			return []interface{}{
+				"channel", req[0],
+				"data-rate-range-ok", dataRateRangeOk,
+				"frequency-ok", frequencyOk,
+			}, dataRateRangeOk && frequencyOk
+		},
+	},
+	uint32(lorawan.RXTimingSetupReq): {
+		name:      "rx-timing-setup",
+		handleAns: acceptAns,
+	},
+	txParamSetupReq: {
+		name:      "tx-param-setup",
+		handleAns: acceptAns,
+	},
+	pingSlotChannelReq: {
+		name:      "ping-slot-channel",
+		ansLength: 1,
+		handleAns: func(dev *device.Device, req, ans []byte) ([]interface{}, bool) {
+			dataRateOk, frequencyOk := ans[0]&0x02 != 0, ans[0]&0x01 != 0
+			if dataRateOk && frequencyOk {
+				dev.ClassB.PingSlotFrequency = dev.Options.PingSlotFrequency
+				dev.ClassB.PingSlotDataRate = dev.Options.PingSlotDataRate
+			}
+			return []interface{}{
+				"data-rate-ok", dataRateOk,
+				"frequency-ok", frequencyOk,
+			}, dataRateOk && frequencyOk
+		},
+	},
+	dlChannelReq: {
+		name:      "dl-channel",
+		ansLength: 1,
+		multiple:  true,
+		handleAns: func(dev *device.Device, req, ans []byte) ([]interface{}, bool) {
+			uplinkFrequencyExists, frequencyOk := ans[0]&0x02 != 0, ans[0]&0x01 != 0
+			return []interface{}{
+				"channel", req[0],
+				"uplink-frequency-exists", uplinkFrequencyExists,
+				"frequency-ok", frequencyOk,
+			}, uplinkFrequencyExists && frequencyOk
+		},
+	},
+}
+
+// putFrequency puts a frequency (Hz) in the 3 byte format of MAC commands
+func putFrequency(b []byte, frequency uint32) {
+	freq := make([]byte, 4)
+	binary.LittleEndian.PutUint32(freq, frequency/100)
+	copy(b, freq[:3])
+}
+
+// newDutyCycleReq returns the payload of a DutyCycleReq, the maximum duty cycle is 1/2^maxDCycle
+func newDutyCycleReq(maxDCycle uint8) []byte {
+	return []byte{maxDCycle & 0x0F}
+}
+
+// newRXParamSetupReq returns the payload of a RXParamSetupReq
+func newRXParamSetupReq(rx1DROffset, rx2DataRate uint8, rx2Frequency uint32) []byte {
+	payload := make([]byte, 4)
+	payload[0] = (rx1DROffset&0x07)<<4 | rx2DataRate&0x0F
+	putFrequency(payload[1:], rx2Frequency)
+	return payload
+}
+
+// newNewChannelReq returns the payload of a NewChannelReq, a frequency of zero disables the channel
+func newNewChannelReq(chIndex uint8, frequency uint32, minDR, maxDR uint8) []byte {
+	payload := make([]byte, 5)
+	payload[0] = chIndex
+	putFrequency(payload[1:], frequency)
+	payload[4] = (maxDR&0x0F)<<4 | minDR&0x0F
+	return payload
+}
+
+// newRXTimingSetupReq returns the payload of a RXTimingSetupReq, the delay is in seconds
+func newRXTimingSetupReq(delay uint8) []byte {
+	return []byte{delay & 0x0F}
+}
+
+// newTxParamSetupReq returns the payload of a TxParamSetupReq, maxEIRP is the index of the maximum EIRP
+func newTxParamSetupReq(downlinkDwellTime, uplinkDwellTime bool, maxEIRP uint8) []byte {
+	payload := maxEIRP & 0x0F
+	if downlinkDwellTime {
+		payload |= 0x20
+	}
+	if uplinkDwellTime {
+		payload |= 0x10
+	}
+	return []byte{payload}
+}
+
+// newDlChannelReq returns the payload of a DlChannelReq
+func newDlChannelReq(chIndex uint8, frequency uint32) []byte {
+	payload := make([]byte, 4)
+	payload[0] = chIndex
+	putFrequency(payload[1:], frequency)
+	return payload
+}
+
+// queueMACCommand adds a MAC command to the pending MAC commands of the device, it replaces a pending command
+// for the same CID (and channel)
+func queueMACCommand(dev *device.Device, cid uint32, payload []byte) {
+	command := macCommands[cid]
+	// The pending commands are copied, so that the changes are detected when the device is saved
+	pending := make([]device.MACCommand, 0, len(dev.PendingMACCommands)+1)
+	for _, existing := range dev.PendingMACCommands {
+		if existing.CID == cid && (!command.multiple || sameChannel(existing.Payload, payload)) {
+			continue
+		}
+		pending = append(pending, existing)
+	}
+	dev.PendingMACCommands = append(pending, device.MACCommand{CID: cid, Payload: payload})
+}
+
+func sameChannel(a, b []byte) bool {
+	return len(a) > 0 && len(b) > 0 && a[0] == b[0]
+}
+
+// queueMACSettings queues the MAC commands for the MAC settings of the device that are different from the old
+// settings. Channels that are no longer in the settings are disabled.
+func queueMACSettings(dev *device.Device, old device.MACSettings) {
+	settings := dev.Options.MACSettings
+	if settings.MaxDutyCycle != old.MaxDutyCycle {
+		queueMACCommand(dev, uint32(lorawan.DutyCycleReq), newDutyCycleReq(settings.MaxDutyCycle))
+	}
+	if settings.RX1Delay != old.RX1Delay {
+		queueMACCommand(dev, uint32(lorawan.RXTimingSetupReq), newRXTimingSetupReq(settings.RX1Delay))
+	}
+	if settings.RX2Frequency != 0 && (settings.RX1DROffset != old.RX1DROffset || settings.RX2DataRate != old.RX2DataRate || settings.RX2Frequency != old.RX2Frequency) {
+		queueMACCommand(dev, uint32(lorawan.RXParamSetupReq), newRXParamSetupReq(settings.RX1DROffset, settings.RX2DataRate, settings.RX2Frequency))
+	}
+	for _, oldChannel := range old.Channels {
+		if settings.Channel(oldChannel.Index) == nil && oldChannel.Frequency != 0 {
+			queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(oldChannel.Index, 0, 0, 0))
+		}
+	}
+	for _, channel := range settings.Channels {
+		oldChannel := old.Channel(channel.Index)
+		if oldChannel == nil {
+			oldChannel = &device.MACChannel{Index: channel.Index}
+		}
+		newChannel := channel.Frequency != oldChannel.Frequency || channel.MinDataRate != oldChannel.MinDataRate || channel.MaxDataRate != oldChannel.MaxDataRate
+		if newChannel {
+			queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(channel.Index, channel.Frequency, channel.MinDataRate, channel.MaxDataRate))
+		}
+		// A NewChannelReq sets the downlink frequency of the channel to the uplink frequency
+		switch {
+		case channel.Frequency == 0:
+		case channel.DownlinkFrequency != 0 && (newChannel || channel.DownlinkFrequency != oldChannel.DownlinkFrequency):
+			queueMACCommand(dev, dlChannelReq, newDlChannelReq(channel.Index, channel.DownlinkFrequency))
+		case channel.DownlinkFrequency == 0 && oldChannel.DownlinkFrequency != 0 && !newChannel:
+			queueMACCommand(dev, dlChannelReq, newDlChannelReq(channel.Index, channel.Frequency))
+		}
+	}
+	if settings.UplinkDwellTime != old.UplinkDwellTime || settings.DownlinkDwellTime != old.DownlinkDwellTime || settings.MaxEIRP != old.MaxEIRP {
+		queueMACCommand(dev, txParamSetupReq, newTxParamSetupReq(settings.DownlinkDwellTime, settings.UplinkDwellTime, settings.MaxEIRP))
+	}
+}
+
+// hasPendingMACCommand returns true if a MAC command with the CID is pending
+func hasPendingMACCommand(dev *device.Device, cid uint32) bool {
+	for _, pending := range dev.PendingMACCommands {
+		if pending.CID == cid {
+			return true
+		}
+	}
+	return false
+}
+
+// handleMACAns handles the answer to a pending MAC command, it returns false if the command is not in the registry
+func (n *networkServer) handleMACAns(cmd pb_lorawan.MACCommand, message *pb_broker.DeduplicatedUplinkMessage, dev *device.Device) bool {
+	command, ok := macCommands[cmd.Cid]
+	if !ok {
+		return false
+	}
+	if len(cmd.Payload) != command.ansLength {
+		return true
+	}
+
+	// Answers are in the same order as the requests
+	for i, pending := range dev.PendingMACCommands {
+		if pending.CID != cmd.Cid {
+			continue
+		}
+		remaining := make([]device.MACCommand, 0, len(dev.PendingMACCommands)-1)
+		remaining = append(remaining, dev.PendingMACCommands[:i]...)
+		dev.PendingMACCommands = append(remaining, dev.PendingMACCommands[i+1:]...)
+		fields, ok := command.handleAns(dev, pending.Payload, cmd.Payload)
+		message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, append([]interface{}{macCMD, command.name}, fields...)...)
+		if !ok {
+			n.Ctx.WithFields(log.Fields{
+				"AppID":   dev.AppID,
+				"DevID":   dev.DevID,
+				"Command": command.name,
+			}).Warn("Negative MAC command answer")
+		}
+		return true
+	}
+
+	// Some answers are repeated by the device until it receives a downlink message
+	message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, command.name, "pending", false)
+	return true
+}
+
+// addPendingMACCommands adds the pending MAC commands of the device that fit in the FOpts of the downlink message,
+// and drops MAC commands that were sent too often. The commands are only counted as sent by markMACCommandsSent.
+func addPendingMACCommands(fOpts []pb_lorawan.MACCommand, dev *device.Device, t *trace.Trace) ([]pb_lorawan.MACCommand, *trace.Trace) {
+	if len(dev.PendingMACCommands) == 0 {
+		return fOpts, t
+	}
+
+	length := 0
+	hasLinkADRReq := false
+	for _, existing := range fOpts {
+		length += 1 + len(existing.Payload)
+		if existing.Cid == uint32(lorawan.LinkADRReq) {
+			hasLinkADRReq = true
+		}
+	}
+	if dev.ADR.SendReq && !hasLinkADRReq {
+		length += linkADRReqLength(dev) // Leave room for the LinkADRReqs
+	}
+
+	pending := make([]device.MACCommand, 0, len(dev.PendingMACCommands))
+	for _, cmd := range dev.PendingMACCommands {
+		name := macCommands[cmd.CID].name
+		if MACCommandMaxRetries >= 0 && cmd.Sent > MACCommandMaxRetries {
+			t = t.WithEvent("drop mac command", macCMD, name, "sent", cmd.Sent)
+			continue
+		}
+		if !containsMACCommand(fOpts, cmd) && length+1+len(cmd.Payload) <= maxFOptsLength {
+			fOpts = append(fOpts, pb_lorawan.MACCommand{Cid: cmd.CID, Payload: cmd.Payload})
+			length += 1 + len(cmd.Payload)
+		}
+		pending = append(pending, cmd)
+	}
+	dev.PendingMACCommands = pending
+
+	return fOpts, t
+}
+
+// markMACCommandsSent counts the pending MAC commands of the device that are in the FOpts of a downlink message
+// that is emitted
+func markMACCommandsSent(fOpts []pb_lorawan.MACCommand, dev *device.Device, t *trace.Trace) *trace.Trace {
+	if len(dev.PendingMACCommands) == 0 {
+		return t
+	}
+	// The pending commands are copied, so that the changes are detected when the device is saved
+	pending := make([]device.MACCommand, 0, len(dev.PendingMACCommands))
+	for _, cmd := range dev.PendingMACCommands {
+		if containsMACCommand(fOpts, cmd) {
+			cmd.Sent++
+			t = t.WithEvent("send mac command", macCMD, macCommands[cmd.CID].name, "sent", cmd.Sent)
+		}
+		pending = append(pending, cmd)
+	}
+	dev.PendingMACCommands = pending
+	return t
+}
+
+func containsMACCommand(fOpts []pb_lorawan.MACCommand, cmd device.MACCommand) bool {
+	for _, existing := range fOpts {
+		if existing.Cid == cmd.CID && string(existing.Payload) == string(cmd.Payload) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleDownlinkMACCommands adds the pending MAC commands to a downlink message, and requests the status of the
+// device if it was not updated recently
+func (n *networkServer) handleDownlinkMACCommands(message *pb_broker.DownlinkMessage, dev *device.Device) {
+	lorawanDownlinkMac := message.GetMessage().GetLorawan().GetMacPayload()
+	if lorawanDownlinkMac == nil {
+		return
+	}
+	if DevStatusInterval > 0 && time.Since(dev.Status.UpdatedAt) > DevStatusInterval && !hasPendingMACCommand(dev, uint32(lorawan.DevStatusReq)) {
+		queueMACCommand(dev, uint32(lorawan.DevStatusReq), nil)
+	}
+	lorawanDownlinkMac.FOpts, message.Trace = addPendingMACCommands(lorawanDownlinkMac.FOpts, dev, message.Trace)
+}
@@ -0,0 +1,248 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestMACCommandPayloads(t *testing.T) {
+	a := New(t)
+	a.So(newDutyCycleReq(4), ShouldResemble, []byte{0x04})
+	a.So(newRXParamSetupReq(1, 3, 869525000), ShouldResemble, []byte{0x13, 0xD2, 0xAD, 0x84})
+	a.So(newNewChannelReq(3, 867100000, 0, 5), ShouldResemble, []byte{0x03, 0x18, 0x4F, 0x84, 0x50})
+	a.So(newRXTimingSetupReq(5), ShouldResemble, []byte{0x05})
+	a.So(newTxParamSetupReq(true, false, 5), ShouldResemble, []byte{0x25})
+	a.So(newDlChannelReq(3, 867100000), ShouldResemble, []byte{0x03, 0x18, 0x4F, 0x84})
+}
+
+func TestQueueMACCommand(t *testing.T) {
+	a := New(t)
+	dev := &device.Device{}
+
+	// Multiple requests can be pending for different channels
+	queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(3, 867100000, 0, 5))
+	queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(4, 867300000, 0, 5))
+	queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(3, 867500000, 0, 5))
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 2)
+	a.So(dev.PendingMACCommands[0].Payload[0], ShouldEqual, 4)
+	a.So(dev.PendingMACCommands[1].Payload, ShouldResemble, newNewChannelReq(3, 867500000, 0, 5))
+
+	dev = &device.Device{}
+
+	queueMACCommand(dev, pingSlotChannelReq, newPingSlotChannelReq(869525000, 3))
+	queueMACCommand(dev, uint32(lorawan.DevStatusReq), nil)
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 2)
+
+	// Replace the pending command
+	dev.PendingMACCommands[0].Sent = 2
+	queueMACCommand(dev, pingSlotChannelReq, newPingSlotChannelReq(869525000, 0))
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 2)
+	a.So(dev.PendingMACCommands[1].Payload, ShouldResemble, []byte{0xD2, 0xAD, 0x84, 0x00})
+	a.So(dev.PendingMACCommands[1].Sent, ShouldEqual, 0)
+
+	a.So(hasPendingMACCommand(dev, pingSlotChannelReq), ShouldBeTrue)
+	a.So(hasPendingMACCommand(dev, uint32(lorawan.LinkADRReq)), ShouldBeFalse)
+}
+
+func TestAddPendingMACCommands(t *testing.T) {
+	a := New(t)
+	dev := &device.Device{}
+
+	// Nothing pending
+	fOpts, _ := addPendingMACCommands(nil, dev, nil)
+	a.So(fOpts, ShouldBeEmpty)
+
+	queueMACCommand(dev, uint32(lorawan.DevStatusReq), nil)
+	queueMACCommand(dev, pingSlotChannelReq, newPingSlotChannelReq(869525000, 3))
+
+	// Only 15 bytes fit in the FOpts
+	fOpts, _ = addPendingMACCommands([]pb_lorawan.MACCommand{
+		{Cid: uint32(lorawan.LinkCheckAns), Payload: []byte{0x07, 0x01}},
+		{Cid: uint32(lorawan.LinkCheckAns), Payload: []byte{0x07, 0x02}},
+		{Cid: uint32(lorawan.LinkCheckAns), Payload: []byte{0x07, 0x03}},
+		{Cid: uint32(lorawan.LinkCheckAns), Payload: []byte{0x07, 0x04}},
+	}, dev, nil)
+	a.So(fOpts, ShouldHaveLength, 5)
+	a.So(fOpts[4].Cid, ShouldEqual, lorawan.DevStatusReq)
+
+	// Adding the commands does not count as sending them
+	a.So(dev.PendingMACCommands[0].Sent, ShouldEqual, 0)
+	trace := markMACCommandsSent(fOpts, dev, nil)
+	a.So(trace, ShouldNotBeNil)
+	a.So(dev.PendingMACCommands[0].Sent, ShouldEqual, 1)
+	a.So(dev.PendingMACCommands[1].Sent, ShouldEqual, 0)
+
+	// Commands that are already in the FOpts are not added again
+	fOpts, _ = addPendingMACCommands(fOpts, dev, nil)
+	a.So(fOpts, ShouldHaveLength, 5)
+
+	// Commands are dropped when they were sent too often
+	dev = &device.Device{}
+	queueMACCommand(dev, uint32(lorawan.DevStatusReq), nil)
+	for i := 0; i <= MACCommandMaxRetries; i++ {
+		fOpts, _ = addPendingMACCommands(nil, dev, nil)
+		markMACCommandsSent(fOpts, dev, nil)
+	}
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 1)
+	addPendingMACCommands(nil, dev, nil)
+	a.So(dev.PendingMACCommands, ShouldBeEmpty)
+}
+
+func TestHandleMACAns(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleMACAns")},
+	}
+	dev := &device.Device{}
+	a.So(deviceStatus(dev), ShouldBeNil)
+
+	// Not in the registry
+	message := adrInitUplinkMessage()
+	a.So(ns.handleMACAns(pb_lorawan.MACCommand{Cid: uint32(lorawan.LinkCheckReq)}, message, dev), ShouldBeFalse)
+
+	// Not pending
+	a.So(ns.handleMACAns(pb_lorawan.MACCommand{Cid: uint32(lorawan.DevStatusAns), Payload: []byte{0xFE, 0x3F}}, message, dev), ShouldBeTrue)
+	a.So(dev.Status.UpdatedAt.IsZero(), ShouldBeTrue)
+
+	queueMACCommand(dev, uint32(lorawan.DevStatusReq), nil)
+	queueMACCommand(dev, pingSlotChannelReq, newPingSlotChannelReq(869525000, 3))
+
+	a.So(ns.handleMACAns(pb_lorawan.MACCommand{Cid: uint32(lorawan.DevStatusAns), Payload: []byte{0xFE, 0x3F}}, message, dev), ShouldBeTrue)
+	a.So(dev.Status.Battery, ShouldEqual, 254)
+	a.So(dev.Status.Margin, ShouldEqual, -1)
+	a.So(time.Since(dev.Status.UpdatedAt), ShouldBeLessThan, time.Second)
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 1)
+	a.So(dev.PendingMACCommands[0].CID, ShouldEqual, pingSlotChannelReq)
+
+	// The status is returned with the device
+	status := deviceStatus(dev)
+	a.So(status, ShouldNotBeNil)
+	a.So(status.Battery, ShouldEqual, 254)
+	a.So(status.Margin, ShouldEqual, -1)
+	a.So(status.UpdatedAt, ShouldEqual, dev.Status.UpdatedAt.UnixNano())
+}
+
+func TestHandleMACAnswers(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{Ctx: GetLogger(t, "TestHandleMACAnswers")},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		cid    uint32
+		req    []byte
+		accept []byte
+		reject []byte
+	}{
+		{"DutyCycle", uint32(lorawan.DutyCycleReq), newDutyCycleReq(4), []byte{}, nil},
+		{"RXParamSetup", uint32(lorawan.RXParamSetupReq), newRXParamSetupReq(1, 3, 869525000), []byte{0x07}, []byte{0x06}},
+		{"NewChannel", uint32(lorawan.NewChannelReq), newNewChannelReq(3, 867100000, 0, 5), []byte{0x03}, []byte{0x02}},
+		{"RXTimingSetup", uint32(lorawan.RXTimingSetupReq), newRXTimingSetupReq(5), []byte{}, nil},
+		{"TxParamSetup", txParamSetupReq, newTxParamSetupReq(true, false, 5), []byte{}, nil},
+		{"DlChannel", dlChannelReq, newDlChannelReq(3, 869525000), []byte{0x03}, []byte{0x01}},
+	} {
+		command, ok := macCommands[tt.cid]
+		a.So(ok, ShouldBeTrue)
+
+		// The answer removes the pending request
+		dev := &device.Device{}
+		queueMACCommand(dev, tt.cid, tt.req)
+		queueMACCommand(dev, uint32(lorawan.DevStatusReq), nil)
+		message := adrInitUplinkMessage()
+		a.So(ns.handleMACAns(pb_lorawan.MACCommand{Cid: tt.cid, Payload: tt.accept}, message, dev), ShouldBeTrue)
+		a.So(dev.PendingMACCommands, ShouldHaveLength, 1)
+		a.So(dev.PendingMACCommands[0].CID, ShouldEqual, lorawan.DevStatusReq)
+
+		// Answers with the wrong length are ignored
+		queueMACCommand(dev, tt.cid, tt.req)
+		a.So(ns.handleMACAns(pb_lorawan.MACCommand{Cid: tt.cid, Payload: []byte{0x01, 0x02}}, message, dev), ShouldBeTrue)
+		a.So(hasPendingMACCommand(dev, tt.cid), ShouldBeTrue)
+
+		_, ok = command.handleAns(dev, tt.req, tt.accept)
+		a.So(ok, ShouldBeTrue)
+		if tt.reject != nil {
+			_, ok = command.handleAns(dev, tt.req, tt.reject)
+			a.So(ok, ShouldBeFalse)
+		}
+	}
+
+	// Answers are matched to the first pending request
+	dev := &device.Device{}
+	queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(3, 867100000, 0, 5))
+	queueMACCommand(dev, uint32(lorawan.NewChannelReq), newNewChannelReq(4, 867300000, 0, 5))
+	a.So(ns.handleMACAns(pb_lorawan.MACCommand{Cid: uint32(lorawan.NewChannelAns), Payload: []byte{0x03}}, adrInitUplinkMessage(), dev), ShouldBeTrue)
+	a.So(dev.PendingMACCommands, ShouldHaveLength, 1)
+	a.So(dev.PendingMACCommands[0].Payload[0], ShouldEqual, 4)
+}
+
+func TestQueueMACSettings(t *testing.T) {
+	a := New(t)
+	dev := &device.Device{}
+
+	dev.Options.MACSettings = device.MACSettings{
+		MaxDutyCycle: 4,
+		RX1Delay:     5,
+		RX2DataRate:  3,
+		RX2Frequency: 869525000,
+		Channels: []device.MACChannel{
+			{Index: 3, Frequency: 867100000, MaxDataRate: 5},
+			{Index: 4, Frequency: 867300000, MaxDataRate: 5, DownlinkFrequency: 869525000},
+		},
+		DownlinkDwellTime: true,
+		MaxEIRP:           5,
+	}
+	queueMACSettings(dev, device.MACSettings{})
+	a.So(dev.PendingMACCommands, ShouldResemble, []device.MACCommand{
+		{CID: uint32(lorawan.DutyCycleReq), Payload: newDutyCycleReq(4)},
+		{CID: uint32(lorawan.RXTimingSetupReq), Payload: newRXTimingSetupReq(5)},
+		{CID: uint32(lorawan.RXParamSetupReq), Payload: newRXParamSetupReq(0, 3, 869525000)},
+		{CID: uint32(lorawan.NewChannelReq), Payload: newNewChannelReq(3, 867100000, 0, 5)},
+		{CID: uint32(lorawan.NewChannelReq), Payload: newNewChannelReq(4, 867300000, 0, 5)},
+		{CID: dlChannelReq, Payload: newDlChannelReq(4, 869525000)},
+		{CID: txParamSetupReq, Payload: newTxParamSetupReq(true, false, 5)},
+	})
+
+	// Only changes are sent, removed channels are disabled
+	old := dev.Options.MACSettings
+	dev = &device.Device{}
+	dev.Options.MACSettings = old
+	dev.Options.MACSettings.RX1Delay = 1
+	dev.Options.MACSettings.Channels = []device.MACChannel{
+		{Index: 4, Frequency: 867300000, MaxDataRate: 5},
+	}
+	queueMACSettings(dev, old)
+	a.So(dev.PendingMACCommands, ShouldResemble, []device.MACCommand{
+		{CID: uint32(lorawan.RXTimingSetupReq), Payload: newRXTimingSetupReq(1)},
+		{CID: uint32(lorawan.NewChannelReq), Payload: newNewChannelReq(3, 0, 0, 0)},
+		{CID: dlChannelReq, Payload: newDlChannelReq(4, 867300000)},
+	})
+}
+
+func TestHandleDownlinkMACCommands(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+	dev := &device.Device{}
+
+	message := adrInitDownlinkMessage()
+	ns.handleDownlinkMACCommands(message, dev)
+	fOpts := message.Message.GetLorawan().GetMacPayload().FOpts
+	a.So(fOpts, ShouldHaveLength, 2)
+	a.So(fOpts[1].Cid, ShouldEqual, lorawan.DevStatusReq)
+
+	// The status was updated recently
+	dev = &device.Device{Status: device.DeviceStatus{UpdatedAt: time.Now()}}
+	message = adrInitDownlinkMessage()
+	ns.handleDownlinkMACCommands(message, dev)
+	a.So(message.Message.GetLorawan().GetMacPayload().FOpts, ShouldHaveLength, 1)
+}
@@ -5,6 +5,7 @@ package networkserver
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/TheThingsNetwork/go-account-lib/claims"
@@ -12,6 +13,7 @@ import (
 	pb "github.com/TheThingsNetwork/ttn/api/networkserver"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
+	"github.com/TheThingsNetwork/ttn/core/band"
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
@@ -61,24 +63,143 @@ func (n *networkServerManager) GetDevice(ctx context.Context, in *pb_lorawan.Dev
 		return nil, err
 	}
 
+	return devicePB(dev), nil
+}
+
+// devicePB returns the device as pb_lorawan.Device
+func devicePB(dev *device.Device) *pb_lorawan.Device {
 	lastSeen := time.Unix(0, 0)
 	if !dev.LastSeen.IsZero() {
 		lastSeen = dev.LastSeen
 	}
 
 	return &pb_lorawan.Device{
-		AppId:            dev.AppID,
-		AppEui:           &dev.AppEUI,
-		DevId:            dev.DevID,
-		DevEui:           &dev.DevEUI,
-		DevAddr:          &dev.DevAddr,
-		NwkSKey:          &dev.NwkSKey,
-		FCntUp:           dev.FCntUp,
-		FCntDown:         dev.FCntDown,
-		DisableFCntCheck: dev.Options.DisableFCntCheck,
-		Uses32BitFCnt:    dev.Options.Uses32BitFCnt,
-		LastSeen:         lastSeen.UnixNano(),
-	}, nil
+		AppId:             dev.AppID,
+		AppEui:            &dev.AppEUI,
+		DevId:             dev.DevID,
+		DevEui:            &dev.DevEUI,
+		DevAddr:           &dev.DevAddr,
+		NwkSKey:           &dev.NwkSKey,
+		FCntUp:            dev.FCntUp,
+		FCntDown:          dev.NFCntDown,
+		AFCntDown:         dev.AFCntDown,
+		DisableFCntCheck:  dev.Options.DisableFCntCheck,
+		Uses32BitFCnt:     dev.Options.Uses32BitFCnt,
+		DeviceClass:       dev.Options.Class,
+		BeaconFrequency:   dev.Options.BeaconFrequency,
+		PingSlotFrequency: dev.Options.PingSlotFrequency,
+		PingSlotDataRate:  dev.Options.PingSlotDataRate,
+		MacVersion:        dev.Options.MACVersion,
+		SNwkSIntKey:       &dev.SNwkSIntKey,
+		NwkSEncKey:        &dev.NwkSEncKey,
+		LastSeen:          lastSeen.UnixNano(),
+		AdrAlgorithm:      dev.ADR.Algorithm,
+		AdrDataRate:       dev.ADR.FixedDataRate,
+		AdrState:          adrState(dev),
+		DeviceStatus:      deviceStatus(dev),
+		MacSettings:       macSettingsPB(dev.Options.MACSettings),
+	}
+}
+
+// macSettingsPB returns the MAC settings of the device as pb_lorawan.MACSettings
+func macSettingsPB(settings device.MACSettings) *pb_lorawan.MACSettings {
+	pb := &pb_lorawan.MACSettings{
+		MaxDutyCycle:      uint32(settings.MaxDutyCycle),
+		Rx1Delay:          uint32(settings.RX1Delay),
+		Rx1DrOffset:       uint32(settings.RX1DROffset),
+		Rx2DataRate:       uint32(settings.RX2DataRate),
+		Rx2Frequency:      settings.RX2Frequency,
+		UplinkDwellTime:   settings.UplinkDwellTime,
+		DownlinkDwellTime: settings.DownlinkDwellTime,
+		MaxEirp:           uint32(settings.MaxEIRP),
+	}
+	for _, channel := range settings.Channels {
+		pb.Channels = append(pb.Channels, &pb_lorawan.MACChannel{
+			Index:             uint32(channel.Index),
+			Frequency:         channel.Frequency,
+			MinDataRate:       uint32(channel.MinDataRate),
+			MaxDataRate:       uint32(channel.MaxDataRate),
+			DownlinkFrequency: channel.DownlinkFrequency,
+		})
+	}
+	return pb
+}
+
+// deviceMACSettings validates the MAC settings and returns them as device.MACSettings
+func deviceMACSettings(in *pb_lorawan.MACSettings) (settings device.MACSettings, err error) {
+	if in.MaxDutyCycle > 15 {
+		return settings, errors.NewErrInvalidArgument("MaxDutyCycle", "must be at most 15")
+	}
+	if in.Rx1Delay > 15 {
+		return settings, errors.NewErrInvalidArgument("Rx1Delay", "must be at most 15")
+	}
+	if in.Rx1DrOffset > 7 {
+		return settings, errors.NewErrInvalidArgument("Rx1DrOffset", "must be at most 7")
+	}
+	if in.Rx2DataRate > 15 {
+		return settings, errors.NewErrInvalidArgument("Rx2DataRate", "must be at most 15")
+	}
+	if in.MaxEirp > 15 {
+		return settings, errors.NewErrInvalidArgument("MaxEirp", "must be at most 15")
+	}
+	settings = device.MACSettings{
+		MaxDutyCycle:      uint8(in.MaxDutyCycle),
+		RX1Delay:          uint8(in.Rx1Delay),
+		RX1DROffset:       uint8(in.Rx1DrOffset),
+		RX2DataRate:       uint8(in.Rx2DataRate),
+		RX2Frequency:      in.Rx2Frequency,
+		UplinkDwellTime:   in.UplinkDwellTime,
+		DownlinkDwellTime: in.DownlinkDwellTime,
+		MaxEIRP:           uint8(in.MaxEirp),
+	}
+	for _, channel := range in.Channels {
+		if channel.Index > 15 {
+			return settings, errors.NewErrInvalidArgument("Channels", "index must be at most 15")
+		}
+		if channel.MinDataRate > channel.MaxDataRate || channel.MaxDataRate > 15 {
+			return settings, errors.NewErrInvalidArgument("Channels", "invalid data rate range")
+		}
+		if settings.Channel(uint8(channel.Index)) != nil {
+			return settings, errors.NewErrInvalidArgument("Channels", fmt.Sprintf("channel %d is set more than once", channel.Index))
+		}
+		settings.Channels = append(settings.Channels, device.MACChannel{
+			Index:             uint8(channel.Index),
+			Frequency:         channel.Frequency,
+			MinDataRate:       uint8(channel.MinDataRate),
+			MaxDataRate:       uint8(channel.MaxDataRate),
+			DownlinkFrequency: channel.DownlinkFrequency,
+		})
+	}
+	return settings, nil
+}
+
+// deviceStatus returns the last status that the device reported, or nil if the device did not report its status
+func deviceStatus(dev *device.Device) *pb_lorawan.DeviceStatus {
+	if dev.Status.UpdatedAt.IsZero() {
+		return nil
+	}
+	return &pb_lorawan.DeviceStatus{
+		Battery:   uint32(dev.Status.Battery),
+		Margin:    int32(dev.Status.Margin),
+		UpdatedAt: dev.Status.UpdatedAt.UnixNano(),
+	}
+}
+
+// adrState returns the ADR decisions for the device
+func adrState(dev *device.Device) *pb_lorawan.ADRState {
+	algorithm := dev.ADR.Algorithm
+	if algorithm == "" {
+		algorithm = ADRAlgorithmDefault
+	}
+	return &pb_lorawan.ADRState{
+		Algorithm: algorithm,
+		Margin:    int32(dev.ADR.Margin),
+		DataRate:  dev.ADR.DataRate,
+		TxPower:   int32(dev.ADR.TxPower),
+		NbTrans:   uint32(dev.ADR.NbTrans),
+		Pending:   dev.ADR.SendReq,
+		Failed:    uint32(dev.ADR.Failed),
+	}
 }
 
 func (n *networkServerManager) SetDevice(ctx context.Context, in *pb_lorawan.Device) (*empty.Empty, error) {
@@ -95,6 +216,28 @@ func (n *networkServerManager) SetDevice(ctx context.Context, in *pb_lorawan.Dev
 		return nil, err
 	}
 
+	if _, err := GetADRAlgorithm(in.AdrAlgorithm); err != nil {
+		return nil, errors.NewErrInvalidArgument("AdrAlgorithm", err.Error())
+	}
+	if in.AdrAlgorithm == ADRAlgorithmFixed && in.AdrDataRate == "" {
+		return nil, errors.NewErrInvalidArgument("AdrDataRate", "can not be empty for the fixed ADR algorithm")
+	}
+
+	if in.AdrDataRate != "" {
+		if _, err := types.ParseDataRate(in.AdrDataRate); err != nil {
+			return nil, errors.NewErrInvalidArgument("AdrDataRate", err.Error())
+		}
+	}
+
+	var macSettings *device.MACSettings
+	if in.MacSettings != nil {
+		settings, err := deviceMACSettings(in.MacSettings)
+		if err != nil {
+			return nil, err
+		}
+		macSettings = &settings
+	}
+
 	dev, err := n.getDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: in.AppEui, DevEui: in.DevEui})
 	if err != nil && errors.GetErrType(err) != errors.NotFound {
 		return nil, err
@@ -106,23 +249,62 @@ func (n *networkServerManager) SetDevice(ctx context.Context, in *pb_lorawan.Dev
 		dev.StartUpdate()
 	}
 
+	// The frequency plan of the device is only known after its first uplink message with ADR
+	if in.AdrDataRate != "" && dev.ADR.Band != "" {
+		if fp, err := band.Get(dev.ADR.Band); err == nil {
+			if err := validateFixedDataRate(&fp, in.AdrDataRate); err != nil {
+				return nil, errors.NewErrInvalidArgument("AdrDataRate", err.Error())
+			}
+		}
+	}
+
 	dev.AppID = in.AppId
 	dev.AppEUI = *in.AppEui
 	dev.DevID = in.DevId
 	dev.DevEUI = *in.DevEui
 	dev.FCntUp = in.FCntUp
-	dev.FCntDown = in.FCntDown
-	dev.ADR = device.ADRSettings{Band: dev.ADR.Band, Margin: dev.ADR.Margin}
+	dev.NFCntDown = in.FCntDown
+	dev.AFCntDown = in.AFCntDown
+	dev.ADR.Algorithm = in.AdrAlgorithm
+	dev.ADR.FixedDataRate = in.AdrDataRate
+	dev.ResetADR()
+
+	// Class B devices are told to use a different beacon frequency with a BeaconFreqReq
+	if dev.Options.BeaconFrequency != in.BeaconFrequency {
+		dev.ClassB.SendBeaconFreqReq = true
+	}
+
+	// Class B devices are told to use a different ping slot channel with a PingSlotChannelReq
+	if dev.Options.PingSlotFrequency != in.PingSlotFrequency || dev.Options.PingSlotDataRate != in.PingSlotDataRate {
+		dev.ClassB.SendPingSlotChannelReq = true
+	}
 
 	dev.Options = device.Options{
 		DisableFCntCheck:      in.DisableFCntCheck,
 		Uses32BitFCnt:         in.Uses32BitFCnt,
 		ActivationConstraints: in.ActivationConstraints,
+		Class:                 in.DeviceClass,
+		BeaconFrequency:       in.BeaconFrequency,
+		PingSlotFrequency:     in.PingSlotFrequency,
+		PingSlotDataRate:      in.PingSlotDataRate,
+		MACVersion:            in.MacVersion,
+		MACSettings:           dev.Options.MACSettings,
+	}
+
+	// The MAC settings are only changed if they are set, the changes are sent to the device with MAC commands
+	if macSettings != nil {
+		oldMACSettings := dev.Options.MACSettings
+		dev.Options.MACSettings = *macSettings
+		queueMACSettings(dev, oldMACSettings)
 	}
 
 	if in.NwkSKey != nil && in.DevAddr != nil {
 		dev.DevAddr = *in.DevAddr
 		dev.NwkSKey = *in.NwkSKey
+		if in.SNwkSIntKey != nil && in.NwkSEncKey != nil {
+			dev.SNwkSIntKey = *in.SNwkSIntKey
+			dev.NwkSEncKey = *in.NwkSEncKey
+		}
 	}
 
 	err = n.networkServer.devices.Set(dev)
@@ -191,6 +373,40 @@ func (n *networkServerManager) GetStatus(ctx context.Context, in *pb.StatusReque
 	return status, nil
 }
 
+// validateSessionsContext validates that the context contains a token that was issued by this NetworkServer (see
+// ttn networkserver authorize), as sessions of all applications can be imported and exported
+func (n *networkServerManager) validateSessionsContext(ctx context.Context) error {
+	rpc := &networkServerRPC{networkServer: n.networkServer}
+	return rpc.ValidateContext(ctx)
+}
+
+func (n *networkServerManager) ImportSessions(stream pb.NetworkServerManager_ImportSessionsServer) error {
+	if err := n.validateSessionsContext(stream.Context()); err != nil {
+		return err
+	}
+	var imported uint32
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&pb.ImportSessionsResponse{Imported: imported})
+		}
+		if err != nil {
+			return err
+		}
+		if err := n.networkServer.importSession(in); err != nil {
+			return errors.Wrapf(err, "Could not import session of device %s (%d sessions imported)", in.DevId, imported)
+		}
+		imported++
+	}
+}
+
+func (n *networkServerManager) ExportSessions(in *pb.ExportSessionsRequest, stream pb.NetworkServerManager_ExportSessionsServer) error {
+	if err := n.validateSessionsContext(stream.Context()); err != nil {
+		return err
+	}
+	return n.networkServer.exportSessions(in.AppId, stream.Send)
+}
+
 // RegisterManager registers this networkserver as a NetworkServerManagerServer (github.com/TheThingsNetwork/ttn/api/networkserver)
 func (n *networkServer) RegisterManager(s *grpc.Server) {
 	server := &networkServerManager{networkServer: n}
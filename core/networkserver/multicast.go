@@ -0,0 +1,88 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"fmt"
+	"strings"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// setGatewayRouter stores the Router that a gateway is connected to, based on the DownlinkOption of an uplink message
+func (n *networkServer) setGatewayRouter(option *pb_broker.DownlinkOption) {
+	if option == nil || option.GatewayId == "" {
+		return
+	}
+	id := strings.Split(option.Identifier, ":")
+	if len(id) != 2 {
+		return
+	}
+	n.gatewayRoutersLock.Lock()
+	defer n.gatewayRoutersLock.Unlock()
+	if n.gatewayRouters == nil {
+		n.gatewayRouters = make(map[string]string)
+	}
+	if n.gatewayRouters[option.GatewayId] == id[0] {
+		return
+	}
+	n.gatewayRouters[option.GatewayId] = id[0]
+	if n.gatewayRouterStore != nil {
+		if err := n.gatewayRouterStore.Set(option.GatewayId, id[0]); err != nil {
+			n.Ctx.WithError(err).WithField("GatewayID", option.GatewayId).Warn("Could not store Router of gateway")
+		}
+	}
+}
+
+// getGatewayRouter returns the Router that a gateway was last seen on
+func (n *networkServer) getGatewayRouter(gatewayID string) (routerID string, ok bool) {
+	n.gatewayRoutersLock.RLock()
+	routerID, ok = n.gatewayRouters[gatewayID]
+	n.gatewayRoutersLock.RUnlock()
+	if ok || n.gatewayRouterStore == nil {
+		return
+	}
+
+	// The gateway may have been seen before a restart or by another NetworkServer instance
+	routerID, err := n.gatewayRouterStore.Get(gatewayID)
+	if err != nil {
+		if errors.GetErrType(err) != errors.NotFound {
+			n.Ctx.WithError(err).WithField("GatewayID", gatewayID).Warn("Could not get Router of gateway")
+		}
+		return "", false
+	}
+	n.gatewayRoutersLock.Lock()
+	defer n.gatewayRoutersLock.Unlock()
+	if n.gatewayRouters == nil {
+		n.gatewayRouters = make(map[string]string)
+	}
+	n.gatewayRouters[gatewayID] = routerID
+	return routerID, true
+}
+
+// handleMulticastDownlink selects the DownlinkOptions for a downlink message to a multicast group. The message
+// is already encrypted by the Handler, and is sent in RX2 as soon as possible on the gateways of the group.
+func (n *networkServer) handleMulticastDownlink(message *pb_broker.DownlinkMessage) (*pb_broker.DownlinkMessage, error) {
+	message.Multicast.DownlinkOptions = nil
+	for _, gatewayID := range message.Multicast.GatewayIds {
+		routerID, ok := n.getGatewayRouter(gatewayID)
+		if !ok {
+			n.Ctx.WithField("GatewayID", gatewayID).Warn("Unknown Router for multicast gateway")
+			continue
+		}
+		message.Multicast.DownlinkOptions = append(message.Multicast.DownlinkOptions, &pb_broker.DownlinkOption{
+			GatewayId:  gatewayID,
+			Identifier: fmt.Sprintf("%s:", routerID),
+		})
+	}
+
+	if len(message.Multicast.DownlinkOptions) == 0 {
+		return nil, errors.NewErrInvalidArgument("Multicast", "none of the gateways was seen by the NetworkServer")
+	}
+
+	message.Trace = message.Trace.WithEvent("schedule multicast", "gateways", len(message.Multicast.DownlinkOptions))
+
+	return message, nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSetGatewayRouter(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{}
+
+	ns.setGatewayRouter(nil)
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "invalid"})
+	_, ok := ns.getGatewayRouter("gtw")
+	a.So(ok, ShouldBeFalse)
+
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "router:schedule"})
+	routerID, ok := ns.getGatewayRouter("gtw")
+	a.So(ok, ShouldBeTrue)
+	a.So(routerID, ShouldEqual, "router")
+
+	// Stored in Redis
+	store := storage.NewRedisKVStore(GetRedisClient(), "ns-test-gateway-router")
+	defer store.Delete("gtw")
+	ns = &networkServer{
+		Component:          &component.Component{Ctx: GetLogger(t, "TestSetGatewayRouter")},
+		gatewayRouterStore: store,
+	}
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw", Identifier: "router:schedule"})
+
+	ns = &networkServer{
+		Component:          &component.Component{Ctx: GetLogger(t, "TestSetGatewayRouter")},
+		gatewayRouterStore: store,
+	}
+	routerID, ok = ns.getGatewayRouter("gtw")
+	a.So(ok, ShouldBeTrue)
+	a.So(routerID, ShouldEqual, "router")
+	_, ok = ns.getGatewayRouter("other-gtw")
+	a.So(ok, ShouldBeFalse)
+}
+
+func TestHandleMulticastDownlink(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		Component: &component.Component{
+			Ctx: GetLogger(t, "TestHandleMulticastDownlink"),
+		},
+	}
+	ns.InitStatus()
+
+	devAddr := getDevAddr(1, 2, 3, 4)
+	fPort := uint8(3)
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FPort: &fPort,
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr(devAddr),
+				FCnt:    42,
+			},
+		},
+	}
+	bytes, _ := phy.MarshalBinary()
+
+	message := &pb_broker.DownlinkMessage{
+		AppId:   "app",
+		Payload: bytes,
+		Multicast: &pb_broker.Multicast{
+			GroupId:    "group",
+			GatewayIds: []string{"gtw1", "gtw2"},
+		},
+	}
+
+	// No known gateways
+	_, err := ns.HandleDownlink(message)
+	a.So(err, ShouldNotBeNil)
+
+	ns.setGatewayRouter(&pb_broker.DownlinkOption{GatewayId: "gtw2", Identifier: "router:schedule"})
+
+	res, err := ns.HandleDownlink(message)
+	a.So(err, ShouldBeNil)
+	a.So(res.Payload, ShouldResemble, bytes)
+	a.So(res.Multicast.DownlinkOptions, ShouldHaveLength, 1)
+	a.So(res.Multicast.DownlinkOptions[0].GatewayId, ShouldEqual, "gtw2")
+	a.So(res.Multicast.DownlinkOptions[0].Identifier, ShouldEqual, "router:")
+}
@@ -4,11 +4,14 @@
 package networkserver
 
 import (
+	"sync"
+
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
 	pb "github.com/TheThingsNetwork/ttn/api/networkserver"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"gopkg.in/redis.v5"
@@ -32,8 +35,9 @@ type NetworkServer interface {
 // NewRedisNetworkServer creates a new Redis-backed NetworkServer
 func NewRedisNetworkServer(client *redis.Client, netID int) NetworkServer {
 	ns := &networkServer{
-		devices:  device.NewRedisDeviceStore(client, "ns"),
-		prefixes: map[types.DevAddrPrefix][]string{},
+		devices:            device.NewRedisDeviceStore(client, "ns"),
+		prefixes:           map[types.DevAddrPrefix][]string{},
+		gatewayRouterStore: storage.NewRedisKVStore(client, "ns:gateway-router"),
 	}
 	ns.netID = [3]byte{byte(netID >> 16), byte(netID >> 8), byte(netID)}
 	return ns
@@ -45,6 +49,10 @@ type networkServer struct {
 	netID    [3]byte
 	prefixes map[types.DevAddrPrefix][]string
 	status   *status
+
+	gatewayRouters     map[string]string
+	gatewayRoutersLock sync.RWMutex
+	gatewayRouterStore *storage.RedisKVStore
 }
 
 func (n *networkServer) UsePrefix(prefix types.DevAddrPrefix, usage []string) error {
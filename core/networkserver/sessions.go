@@ -0,0 +1,135 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// importSession stores the session of a device that was exported from this or another network server. An existing
+// device is replaced. The ADR state of the session is kept, so that the device does not have to be reconfigured.
+func (n *networkServer) importSession(in *pb_lorawan.Device) error {
+	if err := in.Validate(); err != nil {
+		return errors.Wrap(err, "Invalid Device")
+	}
+	if in.DevAddr == nil || in.DevAddr.IsEmpty() {
+		return errors.NewErrInvalidArgument("DevAddr", "can not be empty")
+	}
+	if in.NwkSKey == nil || in.NwkSKey.IsEmpty() {
+		return errors.NewErrInvalidArgument("NwkSKey", "can not be empty")
+	}
+	if _, err := GetADRAlgorithm(in.AdrAlgorithm); err != nil {
+		return errors.NewErrInvalidArgument("AdrAlgorithm", err.Error())
+	}
+	var macSettings device.MACSettings
+	if in.MacSettings != nil {
+		var err error
+		if macSettings, err = deviceMACSettings(in.MacSettings); err != nil {
+			return err
+		}
+	}
+
+	dev, err := n.devices.Get(*in.AppEui, *in.DevEui)
+	if err != nil && errors.GetErrType(err) != errors.NotFound {
+		return err
+	}
+	if dev == nil {
+		dev = new(device.Device)
+	} else {
+		dev.StartUpdate()
+	}
+
+	dev.AppID = in.AppId
+	dev.AppEUI = *in.AppEui
+	dev.DevID = in.DevId
+	dev.DevEUI = *in.DevEui
+	dev.DevAddr = *in.DevAddr
+	dev.NwkSKey = *in.NwkSKey
+	dev.FCntUp = in.FCntUp
+	dev.NFCntDown = in.FCntDown
+	dev.AFCntDown = in.AFCntDown
+	dev.Options = device.Options{
+		DisableFCntCheck:      in.DisableFCntCheck,
+		Uses32BitFCnt:         in.Uses32BitFCnt,
+		ActivationConstraints: in.ActivationConstraints,
+		Class:                 in.DeviceClass,
+		BeaconFrequency:       in.BeaconFrequency,
+		PingSlotFrequency:     in.PingSlotFrequency,
+		PingSlotDataRate:      in.PingSlotDataRate,
+		MACVersion:            in.MacVersion,
+		MACSettings:           macSettings, // The device already uses the settings of the previous network server
+	}
+	if in.SNwkSIntKey != nil && in.NwkSEncKey != nil {
+		dev.SNwkSIntKey = *in.SNwkSIntKey
+		dev.NwkSEncKey = *in.NwkSEncKey
+	} else {
+		dev.SNwkSIntKey, dev.NwkSEncKey = types.SNwkSIntKey{}, types.NwkSEncKey{}
+	}
+	if in.LastSeen > 0 {
+		dev.LastSeen = time.Unix(0, in.LastSeen)
+	}
+
+	dev.ADR = device.ADRSettings{
+		Algorithm:     in.AdrAlgorithm,
+		FixedDataRate: in.AdrDataRate,
+	}
+	if state := in.AdrState; state != nil {
+		dev.ADR.Margin = int(state.Margin)
+		dev.ADR.DataRate = state.DataRate
+		dev.ADR.TxPower = int(state.TxPower)
+		dev.ADR.NbTrans = int(state.NbTrans)
+	}
+
+	// The state of the MAC layer belongs to the previous network server
+	dev.PendingMACCommands = nil
+	dev.ConfirmedDownlink = nil
+	dev.ClassB = device.ClassBSettings{}
+
+	if err := n.devices.Set(dev); err != nil {
+		return err
+	}
+
+	frames, err := n.devices.Frames(dev.AppEUI, dev.DevEUI)
+	if err != nil {
+		return err
+	}
+	return frames.Clear()
+}
+
+// exportSessionsBatchSize is the number of devices that exportSessions loads from the store at once
+const exportSessionsBatchSize = 100
+
+// exportSessions calls export for the session of each device (of the application if appID is not empty). Devices
+// without a session are skipped. The devices are loaded in batches, so that not all of them are in memory at once.
+func (n *networkServer) exportSessions(appID string, export func(*pb_lorawan.Device) error) error {
+	for offset := uint64(0); ; offset += exportSessionsBatchSize {
+		opts := &storage.ListOptions{Limit: exportSessionsBatchSize, Offset: offset}
+		devices, err := n.devices.List(opts)
+		if err != nil {
+			return err
+		}
+		for _, dev := range devices {
+			if dev == nil || dev.DevAddr.IsEmpty() {
+				continue
+			}
+			if appID != "" && dev.AppID != appID {
+				continue
+			}
+			session := devicePB(dev)
+			session.ActivationConstraints = dev.Options.ActivationConstraints
+			if err := export(session); err != nil {
+				return err
+			}
+		}
+		if total, _ := opts.GetTotalAndSelected(); offset+exportSessionsBatchSize >= total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"testing"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestImportExportSessions(t *testing.T) {
+	a := New(t)
+	ns := &networkServer{
+		devices: device.NewRedisDeviceStore(GetRedisClient(), "ns-test-import-export-sessions"),
+	}
+	defer func() {
+		keys, _ := GetRedisClient().Keys("*ns-test-import-export-sessions*").Result()
+		for _, key := range keys {
+			GetRedisClient().Del(key).Result()
+		}
+	}()
+
+	appEUI := types.AppEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8))
+	devEUI := types.DevEUI(getEUI(1, 2, 3, 4, 5, 6, 7, 8))
+	devAddr := types.DevAddr{0x26, 0x01, 0x02, 0x03}
+	nwkSKey := types.NwkSKey{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	session := &pb_lorawan.Device{
+		AppId:        "app",
+		AppEui:       &appEUI,
+		DevId:        "dev",
+		DevEui:       &devEUI,
+		DevAddr:      &devAddr,
+		NwkSKey:      &nwkSKey,
+		FCntUp:       42,
+		FCntDown:     7,
+		AdrAlgorithm: ADRAlgorithmConservative,
+		AdrState: &pb_lorawan.ADRState{
+			Margin:   10,
+			DataRate: "SF9BW125",
+			TxPower:  14,
+			NbTrans:  2,
+		},
+	}
+
+	// A session needs a DevAddr and NwkSKey
+	a.So(ns.importSession(&pb_lorawan.Device{AppId: "app", AppEui: &appEUI, DevId: "dev", DevEui: &devEUI}), ShouldNotBeNil)
+
+	// The session is stored with its ADR state
+	a.So(ns.importSession(session), ShouldBeNil)
+	dev, err := ns.devices.Get(appEUI, devEUI)
+	a.So(err, ShouldBeNil)
+	a.So(dev.DevAddr, ShouldEqual, devAddr)
+	a.So(dev.NwkSKey, ShouldEqual, nwkSKey)
+	a.So(dev.FCntUp, ShouldEqual, 42)
+	a.So(dev.NFCntDown, ShouldEqual, 7)
+	a.So(dev.ADR.Algorithm, ShouldEqual, ADRAlgorithmConservative)
+	a.So(dev.ADR.DataRate, ShouldEqual, "SF9BW125")
+	a.So(dev.ADR.TxPower, ShouldEqual, 14)
+	a.So(dev.ADR.NbTrans, ShouldEqual, 2)
+	a.So(dev.ADR.Margin, ShouldEqual, 10)
+
+	devices, err := ns.devices.ListForAddress(devAddr)
+	a.So(err, ShouldBeNil)
+	a.So(devices, ShouldHaveLength, 1)
+
+	// Importing the session again replaces the device, and the MAC state is dropped
+	dev.PendingMACCommands = []device.MACCommand{{CID: 0x03}}
+	a.So(ns.devices.Set(dev), ShouldBeNil)
+	session.FCntUp = 43
+	a.So(ns.importSession(session), ShouldBeNil)
+	dev, _ = ns.devices.Get(appEUI, devEUI)
+	a.So(dev.FCntUp, ShouldEqual, 43)
+	a.So(dev.PendingMACCommands, ShouldBeEmpty)
+
+	// Devices without a session are not exported
+	otherDevEUI := types.DevEUI(getEUI(2, 2, 3, 4, 5, 6, 7, 8))
+	a.So(ns.devices.Set(&device.Device{AppEUI: appEUI, DevEUI: otherDevEUI, AppID: "app", DevID: "other"}), ShouldBeNil)
+
+	var exported []*pb_lorawan.Device
+	export := func(session *pb_lorawan.Device) error {
+		exported = append(exported, session)
+		return nil
+	}
+	a.So(ns.exportSessions("", export), ShouldBeNil)
+	a.So(exported, ShouldHaveLength, 1)
+	a.So(exported[0].DevId, ShouldEqual, "dev")
+	a.So(*exported[0].DevAddr, ShouldEqual, devAddr)
+	a.So(exported[0].FCntUp, ShouldEqual, 43)
+	a.So(exported[0].AdrState.DataRate, ShouldEqual, "SF9BW125")
+
+	exported = nil
+	a.So(ns.exportSessions("other-app", export), ShouldBeNil)
+	a.So(exported, ShouldBeEmpty)
+
+	// Sessions are exported in batches
+	for i := 0; i < exportSessionsBatchSize+10; i++ {
+		batchDevEUI := types.DevEUI(getEUI(3, 2, 3, 4, 5, 6, byte(i/256), byte(i)))
+		a.So(ns.devices.Set(&device.Device{AppEUI: appEUI, DevEUI: batchDevEUI, AppID: "batch-app", DevID: "batch", DevAddr: devAddr}), ShouldBeNil)
+	}
+	exported = nil
+	a.So(ns.exportSessions("batch-app", export), ShouldBeNil)
+	a.So(exported, ShouldHaveLength, exportSessionsBatchSize+10)
+}
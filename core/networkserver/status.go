@@ -11,16 +11,20 @@ import (
 )
 
 type status struct {
-	uplink      metrics.Meter
-	downlink    metrics.Meter
-	activations metrics.Meter
+	uplink            metrics.Meter
+	downlink          metrics.Meter
+	activations       metrics.Meter
+	devicesPerAddress metrics.Histogram
+	devAddrCollisions metrics.Meter
 }
 
 func (n *networkServer) InitStatus() {
 	n.status = &status{
-		uplink:      metrics.NewMeter(),
-		downlink:    metrics.NewMeter(),
-		activations: metrics.NewMeter(),
+		uplink:            metrics.NewMeter(),
+		downlink:          metrics.NewMeter(),
+		activations:       metrics.NewMeter(),
+		devicesPerAddress: metrics.NewHistogram(metrics.NewUniformSample(512)),
+		devAddrCollisions: metrics.NewMeter(),
 	}
 }
 
@@ -49,5 +53,23 @@ func (n *networkServer) GetStatus() *pb.Status {
 		Rate5:  float32(activations.Rate5()),
 		Rate15: float32(activations.Rate15()),
 	}
+	devicesPerAddress := n.status.devicesPerAddress.Snapshot().Percentiles([]float64{0.01, 0.05, 0.10, 0.25, 0.50, 0.75, 0.90, 0.95, 0.99})
+	status.DevicesPerAddress = &api.Percentiles{
+		Percentile1:  float32(devicesPerAddress[0]),
+		Percentile5:  float32(devicesPerAddress[1]),
+		Percentile10: float32(devicesPerAddress[2]),
+		Percentile25: float32(devicesPerAddress[3]),
+		Percentile50: float32(devicesPerAddress[4]),
+		Percentile75: float32(devicesPerAddress[5]),
+		Percentile90: float32(devicesPerAddress[6]),
+		Percentile95: float32(devicesPerAddress[7]),
+		Percentile99: float32(devicesPerAddress[8]),
+	}
+	devAddrCollisions := n.status.devAddrCollisions.Snapshot()
+	status.DevAddrCollisions = &api.Rates{
+		Rate1:  float32(devAddrCollisions.Rate1()),
+		Rate5:  float32(devAddrCollisions.Rate5()),
+		Rate15: float32(devAddrCollisions.Rate15()),
+	}
 	return status
 }
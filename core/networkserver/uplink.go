@@ -29,6 +29,13 @@ func (n *networkServer) HandleUplink(message *pb_broker.DeduplicatedUplinkMessag
 		return nil, err
 	}
 
+	// The MIC of LoRaWAN 1.1 devices is validated before the state of the device is updated
+	if dev.IsLoRaWAN11() {
+		if err = validateUplinkMIC(message, dev); err != nil {
+			return nil, err
+		}
+	}
+
 	message.Trace = message.Trace.WithEvent(trace.UpdateStateEvent)
 
 	dev.StartUpdate()
@@ -42,25 +49,40 @@ func (n *networkServer) HandleUplink(message *pb_broker.DeduplicatedUplinkMessag
 		}
 	}()
 
+	// The MAC commands in the FOpts of LoRaWAN 1.1 devices are encrypted
+	if dev.IsLoRaWAN11() && len(lorawanUplinkMac.FOpts) > 0 {
+		lorawanUplinkMac.FOpts, err = decryptUplinkFOpts(message.Payload, lorawanUplinkMac.FCnt, dev)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	dev.FCntUp = lorawanUplinkMac.FCnt
 	dev.LastSeen = time.Now()
 
+	n.handleUplinkConfirmedDownlink(message, dev)
+
+	n.setGatewayRouter(message.ResponseTemplate.GetDownlinkOption())
+	n.setClassCGateway(message, dev)
+
 	// Prepare Downlink
 	message.InitResponseTemplate()
 	lorawanDownlinkMsg := message.ResponseTemplate.Message.InitLoRaWAN()
 	lorawanDownlinkMac := lorawanDownlinkMsg.InitDownlink()
 	lorawanDownlinkMac.FPort = lorawanUplinkMac.FPort
 	lorawanDownlinkMac.DevAddr = lorawanUplinkMac.DevAddr
-	lorawanDownlinkMac.FCnt = dev.FCntDown
-	if lorawan := message.ResponseTemplate.GetDownlinkOption().GetProtocolConfig().GetLorawan(); lorawan != nil {
-		lorawan.FCnt = dev.FCntDown
-	}
 
 	err = n.handleUplinkMAC(message, dev)
 	if err != nil {
 		return nil, err
 	}
 
+	// The FCnt is set after handling the MAC commands, because a ResetInd resets the FCntDown
+	lorawanDownlinkMac.FCnt = dev.FCntDown(lorawanDownlinkMac.FPort)
+	if lorawan := message.ResponseTemplate.GetDownlinkOption().GetProtocolConfig().GetLorawan(); lorawan != nil {
+		lorawan.FCnt = dev.FCntDown(lorawanDownlinkMac.FPort)
+	}
+
 	message.ResponseTemplate.Payload, err = lorawanDownlinkMsg.PHYPayload().MarshalBinary()
 	if err != nil {
 		return nil, err
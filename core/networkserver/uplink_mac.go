@@ -72,9 +72,15 @@ func (n *networkServer) handleUplinkMAC(message *pb_broker.DeduplicatedUplinkMes
 					Warn("Negative LinkADRAns")
 			}
 		default:
+			if !n.handleMACAns(cmd, message, dev) && !n.handleLoRaWAN11MAC(cmd, message, dev) {
+				n.handleClassBMAC(cmd, message, dev)
+			}
 		}
 	}
 
+	// Pending MAC commands are sent again until they are answered
+	lorawanDownlinkMac.FOpts, message.Trace = addPendingMACCommands(lorawanDownlinkMac.FOpts, dev, message.Trace)
+
 	// We can't send MAC on port 0; send them on port 1
 	if len(lorawanDownlinkMac.FOpts) != 0 && lorawanDownlinkMac.FPort == 0 {
 		lorawanDownlinkMac.FPort = 1
@@ -100,7 +100,8 @@ func (r *router) HandleActivation(gatewayID string, activation *pb.DeviceActivat
 		return nil, err
 	}
 	lorawan := request.ActivationMetadata.GetLorawan()
-	lorawan.FrequencyPlan = pb_lorawan.FrequencyPlan(pb_lorawan.FrequencyPlan_value[region])
+	lorawan.FrequencyPlan = pb_lorawan.FrequencyPlan(pb_lorawan.FrequencyPlan_value[band.Region])
+	lorawan.FrequencyPlanName = region
 	lorawan.Rx1DrOffset = 0
 	lorawan.Rx2Dr = uint32(band.RX2DataRate)
 	lorawan.RxDelay = uint32(band.ReceiveDelay1.Seconds())
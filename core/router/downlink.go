@@ -56,7 +56,12 @@ func (r *router) UnsubscribeDownlink(gatewayID string, subscriptionID string) er
 	return nil
 }
 
-func (r *router) HandleDownlink(downlink *pb_broker.DownlinkMessage) (err error) {
+func (r *router) HandleDownlink(downlink *pb_broker.DownlinkMessage) error {
+	return r.handleDownlink("", downlink)
+}
+
+// handleDownlink handles a downlink message from the Broker with the given ID
+func (r *router) handleDownlink(brokerID string, downlink *pb_broker.DownlinkMessage) (err error) {
 	var gateway *gateway.Gateway
 	defer func() {
 		if err != nil {
@@ -71,6 +76,18 @@ func (r *router) HandleDownlink(downlink *pb_broker.DownlinkMessage) (err error)
 	downlink.Trace = downlink.Trace.WithEvent(trace.ReceiveEvent)
 
 	option := downlink.DownlinkOption
+	gateway = r.getGateway(option.GatewayId)
+
+	// Downlink that is not a response to an uplink message (such as multicast) is sent in RX2 as soon as possible
+	if option.GatewayConfig == nil {
+		status, _ := gateway.Status.Get() // This just returns empty if non-existing
+		frequencyPlan, err := band.Get(status.FrequencyPlan)
+		if err != nil {
+			return errors.NewErrInvalidArgument("Downlink", fmt.Sprintf("unknown frequency plan for gateway %s", option.GatewayId))
+		}
+		defaultOption := r.buildDownlinkOption(gateway.ID, frequencyPlan)
+		option.ProtocolConfig, option.GatewayConfig = defaultOption.ProtocolConfig, defaultOption.GatewayConfig
+	}
 
 	downlinkMessage := &pb.DownlinkMessage{
 		Payload:               downlink.Payload,
@@ -84,8 +101,13 @@ func (r *router) HandleDownlink(downlink *pb_broker.DownlinkMessage) (err error)
 		identifier = strings.TrimPrefix(option.Identifier, fmt.Sprintf("%s:", r.Component.Identity.Id))
 	}
 
-	gateway = r.getGateway(downlink.DownlinkOption.GatewayId)
-	return gateway.HandleDownlink(identifier, downlinkMessage)
+	if err = gateway.HandleDownlink(identifier, downlinkMessage); err != nil {
+		return err
+	}
+
+	r.addPendingDownlink(gateway.ID, downlinkMessage.ScheduleId, brokerID, downlink)
+
+	return nil
 }
 
 // buildDownlinkOption builds a DownlinkOption with default values
@@ -125,7 +147,7 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 	if err != nil {
 		return // We can't handle this frequency plan
 	}
-	if frequencyPlan == "EU_863_870" && isActivation {
+	if band.Region == "EU_863_870" && isActivation {
 		band.RX2DataRate = 0
 	}
 
@@ -137,7 +159,7 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 	// Configuration for RX2
 	buildRX2 := func() (*pb_broker.DownlinkOption, error) {
 		option := r.buildDownlinkOption(gateway.ID, band)
-		if frequencyPlan == "EU_863_870" {
+		if band.Region == "EU_863_870" {
 			option.GatewayConfig.Power = 27 // The EU RX2 frequency allows up to 27dBm
 		}
 		if isActivation {
@@ -190,7 +212,7 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 		options = append(options, option)
 	}
 
-	computeDownlinkScores(gateway, uplink, options)
+	options = computeDownlinkScores(gateway, uplink, options)
 
 	for _, option := range options {
 		// Add router ID to downlink option
@@ -198,7 +220,7 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 			option.Identifier = fmt.Sprintf("%s:%s", r.Component.Identity.Id, option.Identifier)
 		}
 
-		// Filter all illegal options
+		// Filter all options that are not feasible
 		if option.Score < 1000 {
 			downlinkOptions = append(downlinkOptions, option)
 		}
@@ -207,17 +229,26 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 	return
 }
 
+// minDownlinkSize is the size of the smallest LoRaWAN downlink (MHDR, FHDR and MIC)
+const minDownlinkSize = 1 + 7 + 4
+
 // Calculating the score for each downlink option; lower is better, 0 is best
 // If a score is over 1000, it may should not be used as feasible option.
+// Options that are invalid or that exceed the regulatory limits are dropped, the others are returned.
 // TODO: The weights of these parameters should be optimized. I'm sure someone
 // can do some computer simulations to find the right values.
-func computeDownlinkScores(gateway *gateway.Gateway, uplink *pb.UplinkMessage, options []*pb_broker.DownlinkOption) {
+func computeDownlinkScores(gateway *gateway.Gateway, uplink *pb.UplinkMessage, options []*pb_broker.DownlinkOption) (legal []*pb_broker.DownlinkOption) {
 	gatewayStatus, _ := gateway.Status.Get() // This just returns empty if non-existing
 
 	frequencyPlan := gatewayStatus.FrequencyPlan
 	if frequencyPlan == "" {
 		frequencyPlan = band.Guess(uplink.GatewayMetadata.Frequency)
 	}
+	// Without a known frequency plan there are no regulatory limits to check
+	txPolicy := new(band.TxPolicy)
+	if fp, err := band.Get(frequencyPlan); err == nil && fp.TxPolicy != nil {
+		txPolicy = fp.TxPolicy
+	}
 
 	gatewayRx, _ := gateway.Utilization.Get()
 	for _, option := range options {
@@ -225,7 +256,6 @@ func computeDownlinkScores(gateway *gateway.Gateway, uplink *pb.UplinkMessage, o
 		// Invalid if no LoRaWAN
 		lorawan := option.GetProtocolConfig().GetLorawan()
 		if lorawan == nil {
-			option.Score = 1000
 			continue
 		}
 
@@ -250,7 +280,16 @@ func computeDownlinkScores(gateway *gateway.Gateway, uplink *pb.UplinkMessage, o
 
 		// Invalid if time is zero
 		if time == 0 {
-			option.Score = 1000
+			continue
+		}
+
+		// Illegal if the downlink exceeds the regulatory limits of the frequency plan. The dwell time is checked for
+		// the smallest downlink, as the dwell time of the actual downlink is checked when it is scheduled.
+		freq := option.GatewayConfig.Frequency
+		if err := txPolicy.CheckDutyCycle(freq, time, gateway.Airtime); err != nil {
+			continue
+		}
+		if minTime, err := band.ComputeAirtime(minDownlinkSize, lorawan); err != nil || txPolicy.CheckDwellTime(minTime) != nil {
 			continue
 		}
 
@@ -266,39 +305,23 @@ func computeDownlinkScores(gateway *gateway.Gateway, uplink *pb.UplinkMessage, o
 			signalScore += math.Min(float64(uplink.GatewayMetadata.Rssi*-0.1), 10)
 		}
 
-		utilizationScore := 0.0 // Between 0 and 40 (lower is better) will be over 100 if forbidden
+		utilizationScore := 0.0 // Between 0 and 60 (lower is better)
 		{
 			// Avoid gateways that do more Rx
 			utilizationScore += math.Min(gatewayRx*50, 20) / 2 // 40% utilization = 10 (max)
 
 			// Avoid busy channels
-			freq := option.GatewayConfig.Frequency
 			channelRx, channelTx := gateway.Utilization.GetChannel(freq)
 			utilizationScore += math.Min((channelTx+channelRx)*200, 20) / 2 // 10% utilization = 10 (max)
 
-			// European Duty Cycle
-			if frequencyPlan == "EU_863_870" {
-				var duty float64
-				switch {
-				case freq >= 863000000 && freq < 868000000:
-					duty = 0.01 // g 863.0 – 868.0 MHz 1%
-				case freq >= 868000000 && freq < 868600000:
-					duty = 0.01 // g1 868.0 – 868.6 MHz 1%
-				case freq >= 868700000 && freq < 869200000:
-					duty = 0.001 // g2 868.7 – 869.2 MHz 0.1%
-				case freq >= 869400000 && freq < 869650000:
-					duty = 0.1 // g3 869.4 – 869.65 MHz 10%
-				case freq >= 869700000 && freq < 870000000:
-					duty = 0.01 // g4 869.7 – 870.0 MHz 1%
-				default:
-					utilizationScore += 100 // Transmissions on this frequency are forbidden
-				}
-				if channelTx > duty {
-					utilizationScore += 100 // Transmissions on this frequency are forbidden
-				}
-				if duty > 0 {
-					utilizationScore += math.Min(time.Seconds()/duty/100, 20) // Impact on duty-cycle (in order to prefer RX2 for SF9BW125)
-				}
+			// Regulatory limits of the frequency plan
+			if dutyCycleBand, _ := txPolicy.GetDutyCycleBand(freq); dutyCycleBand.DutyCycle < 1 {
+				utilizationScore += math.Min(time.Seconds()/dutyCycleBand.DutyCycle/100, 20) // Impact on duty-cycle (in order to prefer RX2 for SF9BW125)
+			}
+
+			// Busy channels are likely to fail listen-before-talk
+			if txPolicy.ListenBeforeTalk {
+				utilizationScore += math.Min(channelRx*200, 20)
 			}
 		}
 
@@ -314,5 +337,7 @@ func computeDownlinkScores(gateway *gateway.Gateway, uplink *pb.UplinkMessage, o
 		}
 
 		option.Score = uint32((timeScore + signalScore + utilizationScore + scheduleScore) * 10)
+		legal = append(legal, option)
 	}
+	return legal
 }
@@ -65,6 +65,40 @@ func TestHandleDownlink(t *testing.T) {
 	a.So(err, ShouldBeNil)
 }
 
+func TestHandleDownlinkASAP(t *testing.T) {
+	a := New(t)
+
+	logger := GetLogger(t, "TestHandleDownlinkASAP")
+	r := &router{
+		Component: &component.Component{
+			Ctx:     logger,
+			Monitor: monitor.NewClient(monitor.DefaultClientConfig),
+		},
+		gateways: map[string]*gateway.Gateway{},
+	}
+	r.InitStatus()
+
+	gtwID := "eui-0102030405060708"
+	downlink := &pb_broker.DownlinkMessage{
+		Payload: []byte{},
+		DownlinkOption: &pb_broker.DownlinkOption{
+			GatewayId: gtwID,
+		},
+	}
+
+	// Unknown frequency plan
+	err := r.HandleDownlink(downlink)
+	a.So(err, ShouldNotBeNil)
+
+	gtw := r.getGateway(gtwID)
+	gtw.Status.Update(&pb_gateway.Status{FrequencyPlan: "EU_863_870"})
+	gtw.Schedule.Sync(0)
+
+	err = r.HandleDownlink(downlink)
+	a.So(err, ShouldBeNil)
+	a.So(downlink.DownlinkOption.GatewayConfig.Frequency, ShouldEqual, 869525000)
+}
+
 func TestSubscribeUnsubscribeDownlink(t *testing.T) {
 	a := New(t)
 
@@ -447,13 +481,29 @@ func TestComputeDownlinkScores(t *testing.T) {
 	// European Duty-cycle Enforcement
 	testSubject = newReferenceUplink()
 	testSubjectgtw = newReferenceGateway(t, "EU_863_870")
+	testSubjectgtw.Airtime.Add(868300000, 36*time.Second) // 1% of an hour in the 868.0 – 868.6 MHz band
+	options = r.buildDownlinkOptions(testSubject, false, testSubjectgtw)
+	a.So(options, ShouldHaveLength, 1) // RX1 Removed
+	a.So(options[0].GatewayConfig.Frequency, ShouldNotEqual, 868100000)
+	testSubjectgtw.Airtime.Add(869525000, 360*time.Second) // 10% of an hour in the 869.4 – 869.65 MHz band
+	options = r.buildDownlinkOptions(testSubject, false, testSubjectgtw)
+	a.So(options, ShouldBeEmpty) // RX2 Removed
+
+	// European Duty-cycle Enforcement - RX1 over budget and RX2 busy
+	testSubject = newReferenceUplink()
+	rx2Score := r.buildDownlinkOptions(testSubject, false, newReferenceGateway(t, "EU_863_870"))[0].Score
+	testSubjectgtw = newReferenceGateway(t, "EU_863_870")
+	testSubjectgtw.Airtime.Add(868100000, 36*time.Second)
+	rx2Downlink := newReferenceDownlink()
+	rx2Downlink.GatewayConfiguration.Frequency = 869525000
 	for i := 0; i < 5; i++ {
-		testSubjectgtw.Utilization.AddTx(newReferenceDownlink())
+		testSubjectgtw.Utilization.AddTx(rx2Downlink)
 	}
 	testSubjectgtw.Utilization.Tick()
 	options = r.buildDownlinkOptions(testSubject, false, testSubjectgtw)
 	a.So(options, ShouldHaveLength, 1) // RX1 Removed
-	a.So(options[0].GatewayConfig.Frequency, ShouldNotEqual, 868100000)
+	a.So(options[0].GatewayConfig.Frequency, ShouldEqual, 869525000)
+	a.So(options[0].Score, ShouldBeGreaterThan, rx2Score)
 
 	// European Duty-cycle Preferences - Prefer RX1 for low SF
 	testSubject = newReferenceUplink()
@@ -0,0 +1,42 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/gateway"
+)
+
+// SimulatedClock simulates the clocks of a gateway for testing: the timestamp counter of the concentrator, that
+// started when the gateway booted, and the (GPS) time of the gateway
+type SimulatedClock struct {
+	// BootTime is the time (of the server) at which the timestamp counter of the concentrator started
+	BootTime time.Time
+	// Drift is the difference between the time of the gateway and the time of the server
+	Drift time.Duration
+}
+
+// NewSimulatedClock returns a SimulatedClock for a gateway that booted uptime ago
+func NewSimulatedClock(uptime time.Duration) *SimulatedClock {
+	return &SimulatedClock{BootTime: time.Now().Add(-1 * uptime)}
+}
+
+// Timestamp returns the timestamp (in microseconds) of the concentrator at time t of the server
+func (c *SimulatedClock) Timestamp(t time.Time) uint32 {
+	return uint32(t.Sub(c.BootTime) / time.Microsecond)
+}
+
+// Time returns the time of the gateway at time t of the server
+func (c *SimulatedClock) Time(t time.Time) time.Time {
+	return t.Add(c.Drift)
+}
+
+// RxMetadata returns gateway metadata with the timestamp and time of the gateway at time t of the server
+func (c *SimulatedClock) RxMetadata(t time.Time) *pb.RxMetadata {
+	return &pb.RxMetadata{
+		Timestamp: c.Timestamp(t),
+		Time:      c.Time(t).UnixNano(),
+	}
+}
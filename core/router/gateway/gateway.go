@@ -4,6 +4,7 @@
 package gateway
 
 import (
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 	pb_monitor "github.com/TheThingsNetwork/ttn/api/monitor"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/storage"
 )
 
 // NewGateway creates a new in-memory Gateway structure
@@ -22,6 +25,7 @@ func NewGateway(ctx ttnlog.Interface, id string) *Gateway {
 		ID:          id,
 		Status:      NewStatusStore(),
 		Utilization: NewUtilization(),
+		Airtime:     band.NewAirtime(),
 		Schedule:    NewSchedule(ctx),
 		Ctx:         ctx,
 	}
@@ -34,6 +38,7 @@ type Gateway struct {
 	ID          string
 	Status      StatusStore
 	Utilization Utilization
+	Airtime     *band.Airtime
 	Schedule    Schedule
 	LastSeen    time.Time
 
@@ -45,6 +50,8 @@ type Gateway struct {
 	MonitorStream pb_monitor.GenericStream
 
 	Ctx ttnlog.Interface
+
+	lastSeenStore *storage.RedisKVStore // Optional, see NewRedisGateway
 }
 
 func (g *Gateway) SetAuth(token string, authenticated bool) {
@@ -65,8 +72,20 @@ func (g *Gateway) SetAuth(token string, authenticated bool) {
 	}
 }
 
+// IsAuthenticated returns true if the gateway was authenticated with a valid token
+func (g *Gateway) IsAuthenticated() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.authenticated
+}
+
 func (g *Gateway) updateLastSeen() {
 	g.LastSeen = time.Now()
+	if g.lastSeenStore != nil {
+		if err := g.lastSeenStore.Set(g.ID, strconv.FormatInt(g.LastSeen.UnixNano(), 10)); err != nil {
+			g.Ctx.WithError(err).Warn("Could not store last seen time")
+		}
+	}
 }
 
 func (g *Gateway) HandleStatus(status *pb.Status) (err error) {
@@ -85,6 +104,9 @@ func (g *Gateway) HandleUplink(uplink *pb_router.UplinkMessage) (err error) {
 		return err
 	}
 	g.Schedule.Sync(uplink.GatewayMetadata.Timestamp)
+	if uplink.GatewayMetadata.Time != 0 {
+		g.Schedule.SyncTime(uplink.GatewayMetadata.Timestamp, time.Unix(0, uplink.GatewayMetadata.Time))
+	}
 	g.updateLastSeen()
 
 	status, err := g.Status.Get()
@@ -94,9 +116,10 @@ func (g *Gateway) HandleUplink(uplink *pb_router.UplinkMessage) (err error) {
 			uplink.GatewayMetadata.Gps = status.GetGps()
 		}
 		// Inject Gateway frequency plan
-		if frequencyPlan, ok := pb_lorawan.FrequencyPlan_value[status.FrequencyPlan]; ok {
+		if frequencyPlan, ok := pb_lorawan.FrequencyPlan_value[band.Region(status.FrequencyPlan)]; ok {
 			if lorawan := uplink.GetProtocolMetadata().GetLorawan(); lorawan != nil {
 				lorawan.FrequencyPlan = pb_lorawan.FrequencyPlan(frequencyPlan)
+				lorawan.FrequencyPlanName = status.FrequencyPlan
 			}
 		}
 	}
@@ -109,12 +132,75 @@ func (g *Gateway) HandleUplink(uplink *pb_router.UplinkMessage) (err error) {
 	return nil
 }
 
+// HandleDownlink schedules the downlink on the option with the given identifier, an empty identifier schedules it as soon as possible
+// or, for Class B devices, in the first available ping slot
 func (g *Gateway) HandleDownlink(identifier string, downlink *pb_router.DownlinkMessage) (err error) {
 	ctx := g.Ctx.WithField("Identifier", identifier).WithFields(fields.Get(downlink))
-	if err = g.Schedule.Schedule(identifier, downlink); err != nil {
+	airtime, err := g.checkTxPolicy(downlink)
+	if err != nil {
+		ctx.WithError(err).Warn("Downlink exceeds regulatory limits")
+		return err
+	}
+	if identifier == "" && downlink.GetProtocolConfiguration().GetLorawan().GetPingSlot() != nil {
+		err = g.Schedule.SchedulePingSlot(downlink)
+	} else if identifier == "" {
+		err = g.Schedule.ScheduleASAP(downlink)
+	} else {
+		err = g.Schedule.Schedule(identifier, downlink)
+	}
+	if err != nil {
 		ctx.WithError(err).Warn("Could not schedule downlink")
 		return err
 	}
+	// The airtime is used as soon as the downlink is scheduled, so that the limits also hold for gateways that do not
+	// acknowledge transmissions. It is also kept for gateways without a known frequency plan, as their frequency plan
+	// may become known later.
+	if airtime > 0 {
+		g.Airtime.Add(downlink.GatewayConfiguration.Frequency, airtime)
+	}
 	ctx.Debug("Scheduled downlink")
 	return nil
 }
+
+// frequencyPlan returns the frequency plan of the gateway, which is guessed from the frequency if the gateway did not
+// send it in its status
+func (g *Gateway) frequencyPlan(frequency uint64) (band.FrequencyPlan, error) {
+	status, _ := g.Status.Get() // This just returns empty if non-existing
+	name := status.FrequencyPlan
+	if name == "" {
+		name = band.Guess(frequency)
+	}
+	return band.Get(name)
+}
+
+// refundAirtime removes the airtime of a downlink that was not transmitted
+func (g *Gateway) refundAirtime(downlink *pb_router.DownlinkMessage) {
+	lorawan := downlink.GetProtocolConfiguration().GetLorawan()
+	if lorawan == nil || downlink.GatewayConfiguration == nil {
+		return
+	}
+	if airtime, err := band.ComputeAirtime(uint(len(downlink.Payload)), lorawan); err == nil {
+		g.Airtime.Remove(downlink.GatewayConfiguration.Frequency, airtime)
+	}
+}
+
+// checkTxPolicy checks the downlink against the regulatory limits of the frequency plan of the gateway and returns
+// the airtime of the downlink. There are no limits if the frequency plan of the gateway is unknown.
+func (g *Gateway) checkTxPolicy(downlink *pb_router.DownlinkMessage) (time.Duration, error) {
+	lorawan := downlink.GetProtocolConfiguration().GetLorawan()
+	if lorawan == nil || downlink.GatewayConfiguration == nil {
+		return 0, nil
+	}
+	airtime, err := band.ComputeAirtime(uint(len(downlink.Payload)), lorawan)
+	if err != nil {
+		return 0, err
+	}
+	frequencyPlan, err := g.frequencyPlan(downlink.GatewayConfiguration.Frequency)
+	if err != nil || frequencyPlan.TxPolicy == nil {
+		return airtime, nil
+	}
+	if err := frequencyPlan.TxPolicy.Check(downlink.GatewayConfiguration.Frequency, airtime, g.Airtime); err != nil {
+		return 0, err
+	}
+	return airtime, nil
+}
@@ -5,7 +5,11 @@ package gateway
 
 import (
 	"testing"
+	"time"
 
+	pb "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/band"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
 )
@@ -15,3 +19,73 @@ func TestNewGateway(t *testing.T) {
 	gtw := NewGateway(GetLogger(t, "TestNewGateway"), "eui-0102030405060708")
 	a.So(gtw, ShouldNotBeNil)
 }
+
+func TestHandleUplinkFrequencyPlan(t *testing.T) {
+	a := New(t)
+	gtw := NewGateway(GetLogger(t, "TestHandleUplinkFrequencyPlan"), "eui-0102030405060708")
+	gtw.Status.Update(&pb.Status{FrequencyPlan: "US_902_928"})
+
+	uplink := buildUplink(904300000)
+	a.So(gtw.HandleUplink(uplink), ShouldBeNil)
+	lorawan := uplink.GetProtocolMetadata().GetLorawan()
+	a.So(lorawan.FrequencyPlan, ShouldEqual, pb_lorawan.FrequencyPlan_US_902_928)
+	a.So(lorawan.FrequencyPlanName, ShouldEqual, "US_902_928")
+}
+
+func TestHandleDownlinkTxPolicy(t *testing.T) {
+	a := New(t)
+	gtw := NewGateway(GetLogger(t, "TestHandleDownlinkTxPolicy"), "eui-0102030405060708")
+	gtw.Status.Update(&pb.Status{FrequencyPlan: "EU_863_870"})
+	gtw.Schedule.Sync(0)
+
+	g3 := band.DutyCycleBand{MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 0.1}
+
+	// Transmissions in the alarm band are not allowed
+	downlink := buildDownlink(869300000)
+	id, _ := gtw.Schedule.GetOption(1000000, 50000)
+	a.So(gtw.HandleDownlink(id, downlink), ShouldNotBeNil)
+	a.So(gtw.Airtime.Get(g3), ShouldEqual, 0)
+
+	// The airtime of scheduled downlink is used
+	downlink = buildDownlink(869525000)
+	id, _ = gtw.Schedule.GetOption(2000000, 50000)
+	a.So(gtw.HandleDownlink(id, downlink), ShouldBeNil)
+	a.So(gtw.Airtime.Get(g3), ShouldBeGreaterThan, 0)
+
+	// The airtime of downlink that was not transmitted is refunded
+	_, err := gtw.Schedule.TxAck(id, false)
+	a.So(err, ShouldBeNil)
+	a.So(gtw.Airtime.Get(g3), ShouldEqual, 0)
+
+	// Downlink that exceeds the duty-cycle is not allowed
+	gtw.Airtime.Add(869525000, 360*time.Second)
+	downlink = buildDownlink(869525000)
+	id, _ = gtw.Schedule.GetOption(3000000, 50000)
+	a.So(gtw.HandleDownlink(id, downlink), ShouldNotBeNil)
+}
+
+func TestHandleDownlinkTxPolicyWithoutStatus(t *testing.T) {
+	a := New(t)
+	gtw := NewGateway(GetLogger(t, "TestHandleDownlinkTxPolicyWithoutStatus"), "eui-0102030405060708")
+	gtw.Schedule.Sync(0)
+
+	g1 := band.DutyCycleBand{MinFrequency: 868000000, MaxFrequency: 868600000, DutyCycle: 0.01}
+	g3 := band.DutyCycleBand{MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 0.1}
+
+	// The frequency plan is guessed from the frequency of the downlink
+	downlink := buildDownlink(868100000)
+	id, _ := gtw.Schedule.GetOption(1000000, 50000)
+	a.So(gtw.HandleDownlink(id, downlink), ShouldBeNil)
+	a.So(gtw.Airtime.Get(g1), ShouldBeGreaterThan, 0)
+
+	gtw.Airtime.Add(868100000, 36*time.Second)
+	downlink = buildDownlink(868100000)
+	id, _ = gtw.Schedule.GetOption(2000000, 50000)
+	a.So(gtw.HandleDownlink(id, downlink), ShouldNotBeNil)
+
+	// The airtime is also used if the frequency plan can not be guessed
+	downlink = buildDownlink(869525000)
+	id, _ = gtw.Schedule.GetOption(3000000, 50000)
+	a.So(gtw.HandleDownlink(id, downlink), ShouldBeNil)
+	a.So(gtw.Airtime.Get(g3), ShouldBeGreaterThan, 0)
+}
@@ -0,0 +1,234 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/gogo/protobuf/proto"
+	"github.com/rcrowley/go-metrics"
+	"gopkg.in/redis.v5"
+)
+
+const (
+	redisStatusPrefix      = "gateway-status"
+	redisUtilizationPrefix = "gateway-utilization"
+	redisLastSeenPrefix    = "gateway-last-seen"
+)
+
+// RedisStatusCacheTTL is the time after which the status of a Redis-backed gateway is read from Redis again
+var RedisStatusCacheTTL = 10 * time.Second
+
+// NewRedisGateway creates a new Gateway structure that stores its status, utilization and the time it was last seen in
+// Redis, so that they survive restarts. The status is read through a short cache, so that Routers that use the same
+// Redis database see each other's status updates. The utilization and the time the gateway was last seen are only
+// restored when the gateway is created, so they are not kept in sync between Routers.
+func NewRedisGateway(ctx ttnlog.Interface, id string, client *redis.Client, prefix string) *Gateway {
+	gtw := NewGateway(ctx, id)
+	gtw.Status = NewRedisStatusStore(client, prefix, id)
+	gtw.Utilization = NewRedisUtilization(client, prefix, id)
+	gtw.lastSeenStore = storage.NewRedisKVStore(client, prefix+":"+redisLastSeenPrefix)
+	gtw.LastSeen, _ = GetRedisLastSeen(client, prefix, id)
+	return gtw
+}
+
+// GetRedisLastSeen returns the time that the gateway with the given ID was last seen, or the zero time if it was not
+// seen
+func GetRedisLastSeen(client *redis.Client, prefix string, id string) (time.Time, error) {
+	lastSeen, err := storage.NewRedisKVStore(client, prefix+":"+redisLastSeenPrefix).Get(id)
+	if errors.GetErrType(err) == errors.NotFound {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	nanos, err := strconv.ParseInt(lastSeen, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
+
+// DeleteRedisGateways deletes the state of the gateways that were last seen before the given time from Redis and
+// returns their IDs. The gateways with the IDs in keep are not deleted.
+func DeleteRedisGateways(client *redis.Client, prefix string, lastSeenBefore time.Time, keep ...string) (deleted []string, err error) {
+	lastSeen, err := storage.NewRedisKVStore(client, prefix+":"+redisLastSeenPrefix).List("", nil)
+	if err != nil {
+		return nil, err
+	}
+	stores := []*storage.RedisStore{
+		storage.NewRedisStore(client, prefix+":"+redisStatusPrefix),
+		storage.NewRedisStore(client, prefix+":"+redisUtilizationPrefix),
+		storage.NewRedisStore(client, prefix+":"+redisLastSeenPrefix),
+	}
+	kept := make(map[string]bool)
+	for _, id := range keep {
+		kept[id] = true
+	}
+	for id, nanos := range lastSeen {
+		if kept[id] {
+			continue
+		}
+		if nanos, err := strconv.ParseInt(nanos, 10, 64); err == nil && !time.Unix(0, nanos).Before(lastSeenBefore) {
+			continue
+		}
+		for _, store := range stores {
+			if err := store.Delete(id); err != nil {
+				return deleted, err
+			}
+		}
+		deleted = append(deleted, id)
+	}
+	return deleted, nil
+}
+
+// NewRedisStatusStore creates a new Redis-backed status store for the gateway with the given ID
+func NewRedisStatusStore(client *redis.Client, prefix string, id string) StatusStore {
+	return &redisStatusStore{
+		store: storage.NewRedisKVStore(client, prefix+":"+redisStatusPrefix),
+		id:    id,
+	}
+}
+
+// redisStatusStore keeps the last status in memory for RedisStatusCacheTTL
+type redisStatusStore struct {
+	sync.RWMutex
+	lastStatus *pb_gateway.Status
+	lastRead   time.Time
+	store      *storage.RedisKVStore
+	id         string
+}
+
+func (s *redisStatusStore) Update(status *pb_gateway.Status) error {
+	data, err := proto.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Set(s.id, string(data)); err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.lastStatus, s.lastRead = status, time.Now()
+	return nil
+}
+
+func (s *redisStatusStore) Get() (*pb_gateway.Status, error) {
+	s.RLock()
+	lastStatus, lastRead := s.lastStatus, s.lastRead
+	s.RUnlock()
+	if lastStatus != nil && time.Since(lastRead) < RedisStatusCacheTTL {
+		return lastStatus, nil
+	}
+	status := new(pb_gateway.Status)
+	data, err := s.store.Get(s.id)
+	switch {
+	case errors.GetErrType(err) == errors.NotFound:
+	case err != nil:
+		return nil, err
+	default:
+		if err := proto.Unmarshal([]byte(data), status); err != nil {
+			return nil, err
+		}
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.lastStatus, s.lastRead = status, time.Now()
+	return status, nil
+}
+
+// NewRedisUtilization creates a new Utilization for the gateway with the given ID that is saved to Redis on every Tick
+// and restored from Redis when it is created. It is not reloaded afterwards, so it should not be shared by Routers.
+func NewRedisUtilization(client *redis.Client, prefix string, id string) Utilization {
+	u := &redisUtilization{
+		utilization: NewUtilization().(*utilization),
+		store:       storage.NewRedisKVStore(client, prefix+":"+redisUtilizationPrefix),
+		id:          id,
+	}
+	u.load() // The utilization starts from zero if it was not stored before
+	return u
+}
+
+type redisUtilization struct {
+	*utilization
+	store *storage.RedisKVStore
+	id    string
+}
+
+// utilizationRates contains the rates of the moving averages of a utilization
+type utilizationRates struct {
+	Rx        float64            `json:"rx"`
+	Tx        float64            `json:"tx"`
+	ChannelRx map[uint64]float64 `json:"channel_rx,omitempty"`
+	ChannelTx map[uint64]float64 `json:"channel_tx,omitempty"`
+}
+
+// restoreEWMA creates a new one-minute moving average with the given rate. As the utilization is ticked every 5 seconds,
+// the rate is restored by updating it with the total of 5 seconds before the first tick.
+func restoreEWMA(rate float64) metrics.EWMA {
+	ewma := metrics.NewEWMA1()
+	ewma.Update(int64(rate * 5))
+	ewma.Tick()
+	return ewma
+}
+
+func (u *redisUtilization) load() error {
+	data, err := u.store.Get(u.id)
+	if err != nil {
+		return err
+	}
+	var rates utilizationRates
+	if err := json.Unmarshal([]byte(data), &rates); err != nil {
+		return err
+	}
+	u.overallRx = restoreEWMA(rates.Rx)
+	u.overallTx = restoreEWMA(rates.Tx)
+	u.channelRxLock.Lock()
+	for frequency, rate := range rates.ChannelRx {
+		u.channelRx[frequency] = restoreEWMA(rate)
+	}
+	u.channelRxLock.Unlock()
+	u.channelTxLock.Lock()
+	for frequency, rate := range rates.ChannelTx {
+		u.channelTx[frequency] = restoreEWMA(rate)
+	}
+	u.channelTxLock.Unlock()
+	return nil
+}
+
+func (u *redisUtilization) save() error {
+	rates := utilizationRates{
+		Rx:        u.overallRx.Rate(),
+		Tx:        u.overallTx.Rate(),
+		ChannelRx: make(map[uint64]float64),
+		ChannelTx: make(map[uint64]float64),
+	}
+	u.channelRxLock.RLock()
+	for frequency, ewma := range u.channelRx {
+		rates.ChannelRx[frequency] = ewma.Rate()
+	}
+	u.channelRxLock.RUnlock()
+	u.channelTxLock.RLock()
+	for frequency, ewma := range u.channelTx {
+		rates.ChannelTx[frequency] = ewma.Rate()
+	}
+	u.channelTxLock.RUnlock()
+	data, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	return u.store.Set(u.id, string(data))
+}
+
+func (u *redisUtilization) Tick() {
+	u.utilization.Tick()
+	u.save() // The utilization is only restored on a best-effort basis
+}
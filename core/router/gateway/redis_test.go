@@ -0,0 +1,115 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestRedisStatusStore(t *testing.T) {
+	a := New(t)
+	client := GetRedisClient()
+	id := "eui-0102030405060708"
+	defer client.Del("test-redis-status:gateway-status:" + id)
+
+	store := NewRedisStatusStore(client, "test-redis-status", id)
+
+	// Get non-existing
+	status, err := store.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status, ShouldNotBeNil)
+	a.So(*status, ShouldResemble, pb_gateway.Status{})
+
+	// Update
+	err = store.Update(&pb_gateway.Status{Description: "Fake Gateway"})
+	a.So(err, ShouldBeNil)
+
+	// Get from a new store
+	other := NewRedisStatusStore(client, "test-redis-status", id)
+	status, err = other.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldEqual, "Fake Gateway")
+
+	// Updates from other stores are read after the cache expires
+	defer func(ttl time.Duration) { RedisStatusCacheTTL = ttl }(RedisStatusCacheTTL)
+	RedisStatusCacheTTL = 10 * time.Millisecond
+	a.So(other.Update(&pb_gateway.Status{Description: "Updated Gateway"}), ShouldBeNil)
+	time.Sleep(20 * time.Millisecond)
+	status, err = store.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldEqual, "Updated Gateway")
+}
+
+func TestRedisUtilization(t *testing.T) {
+	a := New(t)
+	client := GetRedisClient()
+	id := "eui-0102030405060708"
+	defer client.Del("test-redis-utilization:gateway-utilization:" + id)
+
+	u := NewRedisUtilization(client, "test-redis-utilization", id)
+	a.So(u.AddRx(buildUplink(868100000)), ShouldBeNil)
+	a.So(u.AddTx(buildDownlink(869525000)), ShouldBeNil)
+	u.Tick()
+	rx, tx := u.Get()
+
+	// Restore in a new utilization
+	restored := NewRedisUtilization(client, "test-redis-utilization", id)
+	restoredRx, restoredTx := restored.Get()
+	a.So(restoredRx, ShouldAlmostEqual, rx)
+	a.So(restoredTx, ShouldAlmostEqual, tx)
+	channelRx, _ := restored.GetChannel(868100000)
+	a.So(channelRx, ShouldAlmostEqual, rx)
+	_, channelTx := restored.GetChannel(869525000)
+	a.So(channelTx, ShouldAlmostEqual, tx)
+}
+
+func TestRedisGateway(t *testing.T) {
+	a := New(t)
+	client := GetRedisClient()
+	id := "eui-0102030405060708"
+	defer DeleteRedisGateways(client, "test-redis-gateway", time.Now().Add(time.Hour))
+
+	gtw := NewRedisGateway(GetLogger(t, "TestRedisGateway"), id, client, "test-redis-gateway")
+	a.So(gtw.LastSeen.IsZero(), ShouldBeTrue)
+	lastSeen, err := GetRedisLastSeen(client, "test-redis-gateway", id)
+	a.So(err, ShouldBeNil)
+	a.So(lastSeen.IsZero(), ShouldBeTrue)
+	a.So(gtw.HandleStatus(&pb_gateway.Status{Description: "Fake Gateway"}), ShouldBeNil)
+
+	// Restore in a new gateway
+	restored := NewRedisGateway(GetLogger(t, "TestRedisGateway"), id, client, "test-redis-gateway")
+	a.So(restored.LastSeen.Equal(gtw.LastSeen), ShouldBeTrue)
+	lastSeen, err = GetRedisLastSeen(client, "test-redis-gateway", id)
+	a.So(err, ShouldBeNil)
+	a.So(lastSeen.Equal(gtw.LastSeen), ShouldBeTrue)
+	status, err := restored.Status.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldEqual, "Fake Gateway")
+
+	// Gateways that were seen recently are kept
+	deleted, err := DeleteRedisGateways(client, "test-redis-gateway", gtw.LastSeen.Add(-1*time.Minute))
+	a.So(err, ShouldBeNil)
+	a.So(deleted, ShouldBeEmpty)
+
+	// Gateways that are kept are not deleted
+	deleted, err = DeleteRedisGateways(client, "test-redis-gateway", gtw.LastSeen.Add(time.Minute), id)
+	a.So(err, ShouldBeNil)
+	a.So(deleted, ShouldBeEmpty)
+
+	// Gateways that were not seen recently are deleted
+	deleted, err = DeleteRedisGateways(client, "test-redis-gateway", gtw.LastSeen.Add(time.Minute))
+	a.So(err, ShouldBeNil)
+	a.So(deleted, ShouldResemble, []string{id})
+
+	restored = NewRedisGateway(GetLogger(t, "TestRedisGateway"), id, client, "test-redis-gateway")
+	a.So(restored.LastSeen.IsZero(), ShouldBeTrue)
+	status, err = restored.Status.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldBeEmpty)
+}
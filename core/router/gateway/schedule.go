@@ -10,8 +10,10 @@ import (
 	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	router_pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/utils/classb"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/TheThingsNetwork/ttn/utils/random"
 	"github.com/TheThingsNetwork/ttn/utils/toa"
@@ -22,14 +24,24 @@ type Schedule interface {
 	fmt.GoStringer
 	// Synchronize the schedule with the gateway timestamp (in microseconds)
 	Sync(timestamp uint32)
+	// Synchronize the schedule with the (GPS) time of the gateway at the timestamp (in microseconds), after Sync
+	SyncTime(timestamp uint32, t time.Time)
 	// Get an "option" on a transmission slot at timestamp for the maximum duration of length (both in microseconds)
 	GetOption(timestamp uint32, length uint32) (id string, score uint)
 	// Schedule a transmission on a slot
 	Schedule(id string, downlink *router_pb.DownlinkMessage) error
+	// Schedule a transmission as soon as possible, this sets the timestamp of the downlink
+	ScheduleASAP(downlink *router_pb.DownlinkMessage) error
+	// Schedule a transmission in the first available ping slot of a Class B device, this sets the timestamp of the downlink
+	SchedulePingSlot(downlink *router_pb.DownlinkMessage) error
+	// Handle the acknowledgment of a transmission by the gateway, this returns the acknowledged downlink
+	TxAck(id string, success bool) (*router_pb.DownlinkMessage, error)
 	// Subscribe to downlink messages
 	Subscribe(subscriptionID string) <-chan *router_pb.DownlinkMessage
 	// Whether the gateway has active downlink
 	IsActive() bool
+	// The IDs of the downlink subscriptions
+	Subscriptions() []string
 	// Stop the subscription
 	Stop(subscriptionID string)
 }
@@ -69,12 +81,15 @@ type scheduledItem struct {
 	length     uint32
 	score      uint
 	payload    *router_pb.DownlinkMessage
+	sent       int32 // accessed atomically, as it is set while the schedule is read-locked
+	acked      bool
 }
 
 type schedule struct {
 	sync.RWMutex
 	ctx                       ttnlog.Interface
 	offset                    int64
+	timeOffset                int64
 	items                     map[string]*scheduledItem
 	downlink                  chan *router_pb.DownlinkMessage
 	downlinkSubscriptionsLock sync.RWMutex
@@ -95,6 +110,9 @@ func (s *schedule) GoString() (str string) {
 // TODO: Make configurable
 var Deadline = 800 * time.Millisecond
 
+// ASAPDelay is the time (in addition to the Deadline) between scheduling a downlink as soon as possible and its transmission
+var ASAPDelay = 500 * time.Millisecond
+
 const uintmax = 1 << 32
 
 // getConflicts walks over the schedule and returns the number of conflicts.
@@ -137,11 +155,49 @@ func (s *schedule) realtime(timestamp uint32) (t time.Time) {
 	return
 }
 
+// timestamp gets the gateway timestamp (in microseconds) for a time. Time
+// should first be syncronized using func Sync()
+func (s *schedule) timestamp(t time.Time) uint32 {
+	offset := atomic.LoadInt64(&s.offset)
+	return uint32((t.UnixNano() - offset) / 1000)
+}
+
+// airtime returns the time on air of the downlink (in microseconds)
+func airtime(downlink *router_pb.DownlinkMessage) (length uint32, ok bool) {
+	lorawan := downlink.GetProtocolConfiguration().GetLorawan()
+	if lorawan == nil {
+		return 0, false
+	}
+	var time time.Duration
+	if lorawan.Modulation == pb_lorawan.Modulation_LORA {
+		// Calculate max ToA
+		time, _ = toa.ComputeLoRa(
+			uint(len(downlink.Payload)),
+			lorawan.DataRate,
+			lorawan.CodingRate,
+		)
+	}
+	if lorawan.Modulation == pb_lorawan.Modulation_FSK {
+		// Calculate max ToA
+		time, _ = toa.ComputeFSK(
+			uint(len(downlink.Payload)),
+			int(lorawan.BitRate),
+		)
+	}
+	return uint32(time / 1000), true
+}
+
 // see interface
 func (s *schedule) Sync(timestamp uint32) {
 	atomic.StoreInt64(&s.offset, time.Now().UnixNano()-int64(timestamp)*1000)
 }
 
+// see interface
+func (s *schedule) SyncTime(timestamp uint32, t time.Time) {
+	offset := atomic.LoadInt64(&s.offset)
+	atomic.StoreInt64(&s.timeOffset, t.UnixNano()-(int64(timestamp)*1000+offset))
+}
+
 // see interface
 func (s *schedule) GetOption(timestamp uint32, length uint32) (id string, score uint) {
 	id = random.String(32)
@@ -166,26 +222,11 @@ func (s *schedule) Schedule(id string, downlink *router_pb.DownlinkMessage) erro
 	s.Lock()
 	defer s.Unlock()
 	if item, ok := s.items[id]; ok {
+		downlink.ScheduleId = id
 		item.payload = downlink
 
-		if lorawan := downlink.GetProtocolConfiguration().GetLorawan(); lorawan != nil {
-			var time time.Duration
-			if lorawan.Modulation == pb_lorawan.Modulation_LORA {
-				// Calculate max ToA
-				time, _ = toa.ComputeLoRa(
-					uint(len(downlink.Payload)),
-					lorawan.DataRate,
-					lorawan.CodingRate,
-				)
-			}
-			if lorawan.Modulation == pb_lorawan.Modulation_FSK {
-				// Calculate max ToA
-				time, _ = toa.ComputeFSK(
-					uint(len(downlink.Payload)),
-					int(lorawan.BitRate),
-				)
-			}
-			item.length = uint32(time / 1000)
+		if length, ok := airtime(downlink); ok {
+			item.length = length
 		}
 
 		if time.Now().Before(item.deadlineAt) {
@@ -199,7 +240,7 @@ func (s *schedule) Schedule(id string, downlink *router_pb.DownlinkMessage) erro
 				defer s.RUnlock()
 				if s.downlink != nil {
 					ctx.Debug("Send Downlink")
-					s.downlink <- item.payload
+					s.send(item)
 				}
 			}()
 		} else {
@@ -210,7 +251,7 @@ func (s *schedule) Schedule(id string, downlink *router_pb.DownlinkMessage) erro
 					overdue := time.Now().Sub(item.deadlineAt)
 					if overdue < Deadline {
 						ctx.WithField("Overdue", overdue).Debug("Send Downlink")
-						s.downlink <- item.payload
+						s.send(item)
 					} else {
 						ctx.WithField("Overdue", overdue).Warn("Discard Late Downlink")
 					}
@@ -225,6 +266,109 @@ func (s *schedule) Schedule(id string, downlink *router_pb.DownlinkMessage) erro
 	return errors.NewErrNotFound(id)
 }
 
+// send sends the downlink of the item to the subscribers and adds it to the TX utilization of the gateway. The schedule
+// should be (read-)locked.
+func (s *schedule) send(item *scheduledItem) {
+	s.downlink <- item.payload
+	atomic.StoreInt32(&item.sent, 1)
+	if s.gateway != nil && s.gateway.Utilization != nil {
+		s.gateway.Utilization.AddTx(item.payload) // FIXME: Issue #420
+	}
+}
+
+// see interface
+func (s *schedule) ScheduleASAP(downlink *router_pb.DownlinkMessage) error {
+	if atomic.LoadInt64(&s.offset) == 0 {
+		return errors.NewErrInternal("Gateway time is not synchronized")
+	}
+
+	length, _ := airtime(downlink)
+	timestamp := s.timestamp(time.Now().Add(Deadline + ASAPDelay))
+
+	// Move past transmissions that are already scheduled
+	for i := 0; i < 10 && s.getConflicts(timestamp, length) >= 100; i++ {
+		timestamp += uint32(ASAPDelay / time.Microsecond)
+	}
+
+	if downlink.GatewayConfiguration == nil {
+		downlink.GatewayConfiguration = new(pb_gateway.TxConfiguration)
+	}
+	downlink.GatewayConfiguration.Timestamp = timestamp
+
+	id, _ := s.GetOption(timestamp, length)
+	return s.Schedule(id, downlink)
+}
+
+// PingSlotAttempts is the maximum number of ping slots that are considered when scheduling a downlink in a ping slot
+var PingSlotAttempts = 10
+
+// see interface
+func (s *schedule) SchedulePingSlot(downlink *router_pb.DownlinkMessage) error {
+	pingSlot := downlink.GetProtocolConfiguration().GetLorawan().GetPingSlot()
+	if pingSlot == nil || pingSlot.DevAddr == nil {
+		return errors.NewErrInvalidArgument("Downlink", "does not contain ping slot configuration")
+	}
+	if atomic.LoadInt64(&s.offset) == 0 {
+		return errors.NewErrInternal("Gateway time is not synchronized")
+	}
+	timeOffset := atomic.LoadInt64(&s.timeOffset)
+	if timeOffset == 0 {
+		return errors.NewErrInternal("Gateway does not have GPS time")
+	}
+
+	length, _ := airtime(downlink)
+
+	// The ping slots are at the (GPS) time of the gateway
+	slot := time.Now().Add(time.Duration(timeOffset) + Deadline + ASAPDelay)
+	var timestamp uint32
+	for i := 0; ; i++ {
+		if i == PingSlotAttempts {
+			return errors.NewErrInternal("No ping slot available")
+		}
+		var err error
+		slot, err = classb.NextPingSlot(*pingSlot.DevAddr, int(pingSlot.PingNb), slot)
+		if err != nil {
+			return err
+		}
+		timestamp = s.timestamp(slot.Add(-1 * time.Duration(timeOffset)))
+		// Move past transmissions that are already scheduled
+		if s.getConflicts(timestamp, length) < 100 {
+			break
+		}
+	}
+
+	if downlink.GatewayConfiguration == nil {
+		downlink.GatewayConfiguration = new(pb_gateway.TxConfiguration)
+	}
+	downlink.GatewayConfiguration.Timestamp = timestamp
+
+	id, _ := s.GetOption(timestamp, length)
+	return s.Schedule(id, downlink)
+}
+
+// see interface
+func (s *schedule) TxAck(id string, success bool) (*router_pb.DownlinkMessage, error) {
+	s.Lock()
+	defer s.Unlock()
+	item, ok := s.items[id]
+	if !ok || item.payload == nil || item.acked {
+		return nil, errors.NewErrNotFound(id)
+	}
+	if !success {
+		// The slot, the airtime and the utilization are free again if the gateway did not transmit the downlink
+		delete(s.items, id)
+		if s.gateway != nil && s.gateway.Airtime != nil {
+			s.gateway.refundAirtime(item.payload)
+		}
+		if s.gateway != nil && s.gateway.Utilization != nil && atomic.LoadInt32(&item.sent) == 1 {
+			s.gateway.Utilization.RemoveTx(item.payload)
+		}
+		return item.payload, nil
+	}
+	item.acked = true
+	return item.payload, nil
+}
+
 func (s *schedule) Stop(subscriptionID string) {
 	s.downlinkSubscriptionsLock.Lock()
 	defer s.downlinkSubscriptionsLock.Unlock()
@@ -246,9 +390,6 @@ func (s *schedule) Subscribe(subscriptionID string) <-chan *router_pb.DownlinkMe
 		s.downlink = make(chan *router_pb.DownlinkMessage)
 		go func() {
 			for downlink := range s.downlink {
-				if s.gateway != nil && s.gateway.Utilization != nil {
-					s.gateway.Utilization.AddTx(downlink) // FIXME: Issue #420
-				}
 				s.downlinkSubscriptionsLock.RLock()
 				for _, ch := range s.downlinkSubscriptions {
 					select {
@@ -279,3 +420,13 @@ func (s *schedule) IsActive() bool {
 	defer s.RUnlock()
 	return s.downlink != nil
 }
+
+func (s *schedule) Subscriptions() []string {
+	s.downlinkSubscriptionsLock.RLock()
+	defer s.downlinkSubscriptionsLock.RUnlock()
+	subscriptionIDs := make([]string, 0, len(s.downlinkSubscriptions))
+	for subscriptionID := range s.downlinkSubscriptions {
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+	return subscriptionIDs
+}
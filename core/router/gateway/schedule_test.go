@@ -8,7 +8,11 @@ import (
 	"testing"
 	"time"
 
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	router_pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/classb"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
 )
@@ -118,6 +122,88 @@ func TestScheduleSchedule(t *testing.T) {
 	a.So(conflicts, ShouldEqual, 100)
 }
 
+func TestScheduleScheduleASAP(t *testing.T) {
+	a := New(t)
+	s := NewSchedule(GetLogger(t, "TestScheduleScheduleASAP")).(*schedule)
+
+	// Not synchronized
+	err := s.ScheduleASAP(&router_pb.DownlinkMessage{})
+	a.So(err, ShouldNotBeNil)
+
+	s.Sync(0)
+
+	downlink1 := &router_pb.DownlinkMessage{}
+	err = s.ScheduleASAP(downlink1)
+	a.So(err, ShouldBeNil)
+	a.So(downlink1.GatewayConfiguration.Timestamp, ShouldAlmostEqual, uint32((Deadline+ASAPDelay)/time.Microsecond), 1000)
+
+	_, conflicts := s.GetOption(downlink1.GatewayConfiguration.Timestamp-50, 100)
+	a.So(conflicts, ShouldEqual, 100)
+}
+
+func TestScheduleSyncTime(t *testing.T) {
+	a := New(t)
+	s := &schedule{}
+	clock := NewSimulatedClock(time.Hour)
+	clock.Drift = 2 * time.Second
+
+	now := time.Now()
+	s.Sync(clock.Timestamp(now))
+	s.SyncTime(clock.Timestamp(now), clock.Time(now))
+	a.So(s.timeOffset, ShouldAlmostEqual, int64(clock.Drift), almostEqual)
+}
+
+func TestScheduleSchedulePingSlot(t *testing.T) {
+	a := New(t)
+	s := NewSchedule(GetLogger(t, "TestScheduleSchedulePingSlot")).(*schedule)
+	clock := NewSimulatedClock(time.Hour)
+	clock.Drift = 2 * time.Second
+
+	devAddr := types.DevAddr{0x26, 0x01, 0x12, 0x34}
+	newDownlink := func() *router_pb.DownlinkMessage {
+		return &router_pb.DownlinkMessage{
+			Payload: make([]byte, 20),
+			ProtocolConfiguration: &pb_protocol.TxConfiguration{Protocol: &pb_protocol.TxConfiguration_Lorawan{Lorawan: &pb_lorawan.TxConfiguration{
+				Modulation: pb_lorawan.Modulation_LORA,
+				DataRate:   "SF9BW125",
+				CodingRate: "4/5",
+				PingSlot:   &pb_lorawan.PingSlot{DevAddr: &devAddr, PingNb: 128},
+			}}},
+		}
+	}
+
+	// No ping slot
+	err := s.SchedulePingSlot(&router_pb.DownlinkMessage{})
+	a.So(err, ShouldNotBeNil)
+
+	// Not synchronized
+	err = s.SchedulePingSlot(newDownlink())
+	a.So(err, ShouldNotBeNil)
+
+	// No GPS time
+	now := time.Now()
+	s.Sync(clock.Timestamp(now))
+	err = s.SchedulePingSlot(newDownlink())
+	a.So(err, ShouldNotBeNil)
+
+	s.SyncTime(clock.Timestamp(now), clock.Time(now))
+
+	downlink1 := newDownlink()
+	err = s.SchedulePingSlot(downlink1)
+	a.So(err, ShouldBeNil)
+
+	// The downlink is scheduled at the gateway timestamp of the first ping slot after the deadline
+	expected, _ := classb.NextPingSlot(devAddr, 128, clock.Time(time.Now()).Add(Deadline+ASAPDelay))
+	a.So(downlink1.GatewayConfiguration.Timestamp, ShouldAlmostEqual, clock.Timestamp(expected.Add(-1*clock.Drift)), 1000)
+
+	// The next downlink is scheduled in the next ping slot
+	downlink2 := newDownlink()
+	err = s.SchedulePingSlot(downlink2)
+	a.So(err, ShouldBeNil)
+	expected, _ = classb.NextPingSlot(devAddr, 128, expected)
+	a.So(downlink2.GatewayConfiguration.Timestamp, ShouldAlmostEqual, clock.Timestamp(expected.Add(-1*clock.Drift)), 1000)
+}
+
 func TestScheduleSubscribe(t *testing.T) {
 	a := New(t)
 	s := NewSchedule(GetLogger(t, "TestScheduleSubscribe")).(*schedule)
@@ -158,3 +244,40 @@ func TestScheduleSubscribe(t *testing.T) {
 	<-time.After(500 * time.Millisecond)
 
 }
+
+func TestScheduleTxAck(t *testing.T) {
+	a := New(t)
+	gtw := NewGateway(GetLogger(t, "TestScheduleTxAck"), "test")
+	s := gtw.Schedule.(*schedule)
+	s.Sync(0)
+
+	_, err := s.TxAck("unknown", true)
+	a.So(err, ShouldNotBeNil)
+
+	sent := s.Subscribe("test")
+	defer s.Stop("test")
+
+	// Failed transmissions do not count towards the utilization and free the slot
+	downlink := buildDownlink(8680000000)
+	id, _ := s.GetOption(1000000, 50)
+	a.So(s.Schedule(id, downlink), ShouldBeNil)
+	a.So(downlink.ScheduleId, ShouldEqual, id)
+	a.So(<-sent, ShouldEqual, downlink)
+	acked, err := s.TxAck(id, false)
+	a.So(err, ShouldBeNil)
+	a.So(acked, ShouldEqual, downlink)
+	a.So(s.getConflicts(1000000, 50), ShouldEqual, 0)
+
+	// Transmissions count towards the utilization when they are sent, and acknowledgments do not count again
+	downlink = buildDownlink(8680000000)
+	id, _ = s.GetOption(2000000, 50)
+	a.So(s.Schedule(id, downlink), ShouldBeNil)
+	a.So(<-sent, ShouldEqual, downlink)
+	_, err = s.TxAck(id, true)
+	a.So(err, ShouldBeNil)
+	_, err = s.TxAck(id, true)
+	a.So(err, ShouldNotBeNil)
+	gtw.Utilization.Tick()
+	_, tx := gtw.Utilization.Get()
+	a.So(tx, ShouldAlmostEqual, 0.041216/5.0) // only the successful transmission
+}
@@ -5,6 +5,7 @@ package gateway
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -22,6 +23,8 @@ type Utilization interface {
 	AddRx(uplink *pb_router.UplinkMessage) error
 	// AddRx updates the utilization for transmitting a downlink message
 	AddTx(downlink *pb_router.DownlinkMessage) error
+	// RemoveTx undoes AddTx for a downlink message that the gateway did not transmit
+	RemoveTx(downlink *pb_router.DownlinkMessage) error
 	// Get returns the overall rx and tx utilization for the gateway. If the gateway has multiple channels, the values will be 0 <= value < numChannels
 	Get() (rx float64, tx float64)
 	// GetChannel returns the rx and tx utilization for the given channel. The values will be 0 <= value < 1
@@ -95,6 +98,15 @@ func (u *utilization) AddRx(uplink *pb_router.UplinkMessage) error {
 }
 
 func (u *utilization) AddTx(downlink *pb_router.DownlinkMessage) error {
+	return u.updateTx(downlink, 1)
+}
+
+func (u *utilization) RemoveTx(downlink *pb_router.DownlinkMessage) error {
+	return u.updateTx(downlink, -1)
+}
+
+// updateTx adds the airtime of the downlink message times sign to the TX utilization
+func (u *utilization) updateTx(downlink *pb_router.DownlinkMessage, sign int64) error {
 	var t time.Duration
 	var err error
 	if lorawan := downlink.ProtocolConfiguration.GetLorawan(); lorawan != nil {
@@ -114,14 +126,14 @@ func (u *utilization) AddTx(downlink *pb_router.DownlinkMessage) error {
 	if t == 0 {
 		return nil
 	}
-	u.overallTx.Update(int64(t) / 1000)
+	u.overallTx.Update(sign * int64(t) / 1000)
 	frequency := downlink.GatewayConfiguration.Frequency
 	u.channelTxLock.Lock()
 	defer u.channelTxLock.Unlock()
 	if _, ok := u.channelTx[frequency]; !ok {
 		u.channelTx[frequency] = metrics.NewEWMA1()
 	}
-	u.channelTx[frequency].Update(int64(t) / 1000)
+	u.channelTx[frequency].Update(sign * int64(t) / 1000)
 	return nil
 }
 
@@ -140,8 +152,9 @@ func (u *utilization) Tick() {
 	u.channelTxLock.RUnlock()
 }
 
+// The TX rates can become slightly negative when a downlink is removed after the clock ticked, so they are at least 0
 func (u *utilization) Get() (float64, float64) {
-	return u.overallRx.Snapshot().Rate() * 1000.0 / float64(time.Second), u.overallTx.Snapshot().Rate() * 1000.0 / float64(time.Second)
+	return u.overallRx.Snapshot().Rate() * 1000.0 / float64(time.Second), math.Max(0, u.overallTx.Snapshot().Rate()*1000.0/float64(time.Second))
 }
 
 func (u *utilization) GetChannel(frequency uint64) (rx float64, tx float64) {
@@ -152,7 +165,7 @@ func (u *utilization) GetChannel(frequency uint64) (rx float64, tx float64) {
 	u.channelRxLock.RUnlock()
 	u.channelTxLock.RLock()
 	if channel, ok := u.channelTx[frequency]; ok {
-		tx = channel.Snapshot().Rate() * 1000.0 / float64(time.Second)
+		tx = math.Max(0, channel.Snapshot().Rate()*1000.0/float64(time.Second))
 	}
 	u.channelTxLock.RUnlock()
 	return
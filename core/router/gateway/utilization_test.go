@@ -92,4 +92,16 @@ func TestTxUtilization(t *testing.T) {
 	rx, tx = u.Get()
 	a.So(rx, ShouldAlmostEqual, 0)
 	a.So(tx, ShouldAlmostEqual, 0.082432/5.0) // two times 41 ms per second
+
+	// Removed downlink does not count
+	err = u.AddTx(buildDownlink(8680000000))
+	a.So(err, ShouldBeNil)
+	err = u.RemoveTx(buildDownlink(8680000000))
+	a.So(err, ShouldBeNil)
+	u.Tick() // 5 seconds later
+	rx, tx = u.GetChannel(8682000000)
+	a.So(tx, ShouldBeLessThan, 0.041216/5.0) // decaying, but no new transmissions
+	a.So(tx, ShouldBeGreaterThan, 0)
+	_, tx2 := u.GetChannel(8680000000)
+	a.So(tx2, ShouldAlmostEqual, tx)
 }
@@ -7,6 +7,8 @@ import (
 	"fmt"
 
 	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/router/gateway"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
@@ -27,6 +29,10 @@ func (r *routerManager) GatewayStatus(ctx context.Context, in *pb.GatewayStatusR
 	r.router.gatewaysLock.RLock()
 	gtw, ok := r.router.gateways[in.GatewayId]
 	r.router.gatewaysLock.RUnlock()
+	if !ok && r.router.redis != nil {
+		// The gateway may have been seen before a restart or by another Router
+		return r.redisGatewayStatus(in.GatewayId)
+	}
 	if !ok {
 		return nil, errors.NewErrNotFound(fmt.Sprintf("Gateway %s", in.GatewayId))
 	}
@@ -34,9 +40,40 @@ func (r *routerManager) GatewayStatus(ctx context.Context, in *pb.GatewayStatusR
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GatewayStatusResponse{
+	res := &pb.GatewayStatusResponse{
 		LastSeen: gtw.LastSeen.UnixNano(),
 		Status:   status,
+	}
+	if frequencyPlan, err := band.Get(status.FrequencyPlan); err == nil && frequencyPlan.TxPolicy != nil {
+		for _, budget := range frequencyPlan.TxPolicy.GetDutyCycleBudgets(gtw.Airtime) {
+			res.DutyCycleBudgets = append(res.DutyCycleBudgets, &pb.GatewayStatusResponse_DutyCycleBudget{
+				MinFrequency: budget.MinFrequency,
+				MaxFrequency: budget.MaxFrequency,
+				DutyCycle:    float32(budget.DutyCycle),
+				Remaining:    budget.Remaining.Nanoseconds(),
+			})
+		}
+	}
+	return res, nil
+}
+
+// redisGatewayStatus reads the status of a gateway that is not active on this Router from Redis, without adding the
+// gateway to the Router. The duty-cycle budgets are not known, as the airtime of a gateway is not stored.
+func (r *routerManager) redisGatewayStatus(gatewayID string) (*pb.GatewayStatusResponse, error) {
+	lastSeen, err := gateway.GetRedisLastSeen(r.router.redis, "router", gatewayID)
+	if err != nil {
+		return nil, err
+	}
+	if lastSeen.IsZero() {
+		return nil, errors.NewErrNotFound(fmt.Sprintf("Gateway %s", gatewayID))
+	}
+	status, err := gateway.NewRedisStatusStore(r.router.redis, "router", gatewayID).Get()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GatewayStatusResponse{
+		LastSeen: lastSeen.UnixNano(),
+		Status:   status,
 	}, nil
 }
 
@@ -0,0 +1,162 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"sync"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/api/ratelimit"
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/mqtt"
+)
+
+// MQTTTimeout indicates how long we should wait for an MQTT publish
+var MQTTTimeout = 2 * time.Second
+
+// mqttSubscriptionID is the ID of the downlink subscription of gateways that are connected over MQTT
+const mqttSubscriptionID = "mqtt"
+
+type mqttBridge struct {
+	router *router
+	client mqtt.Client
+	ctx    ttnlog.Interface
+
+	uplinkRate *ratelimit.Registry
+	statusRate *ratelimit.Registry
+
+	mu       sync.RWMutex
+	gateways map[string]bool
+}
+
+// ServeMQTT accepts gateways that publish to the MQTT broker of the given client. A gateway first publishes a connect
+// message with its token to <gateway-id>/connect, then publishes uplink and status messages to <gateway-id>/up and
+// <gateway-id>/status. Downlink messages are published to <gateway-id>/down until the gateway publishes a disconnect
+// message with its token to <gateway-id>/disconnect. The gateway acknowledges downlink messages on <gateway-id>/ack.
+//
+// The token is only checked on connect and disconnect, so the broker should only allow a gateway to publish to and
+// subscribe to its own topics (see the README of the mqtt package).
+func (r *router) ServeMQTT(client mqtt.Client) error {
+	b := &mqttBridge{
+		router:     r,
+		client:     client,
+		ctx:        r.Ctx.WithField("Bridge", "MQTT"),
+		uplinkRate: ratelimit.NewRegistry(1500, time.Minute), // See RegisterRPC
+		statusRate: ratelimit.NewRegistry(10, time.Minute),
+		gateways:   make(map[string]bool),
+	}
+	for _, token := range []mqtt.Token{
+		client.SubscribeGatewayConnect(b.handleConnect),
+		client.SubscribeGatewayDisconnect(b.handleDisconnect),
+		client.SubscribeGatewayUplink(b.handleUplink),
+		client.SubscribeGatewayStatus(b.handleStatus),
+		client.SubscribeGatewayTxAck(b.handleTxAck),
+	} {
+		token.Wait()
+		if err := token.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *mqttBridge) isConnected(gatewayID string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.gateways[gatewayID]
+}
+
+func (b *mqttBridge) handleConnect(_ mqtt.Client, gatewayID string, msg mqtt.GatewayConnectMessage) {
+	ctx := b.ctx.WithField("GatewayID", gatewayID)
+	if _, err := b.router.authenticateGateway(gatewayID, msg.Token); err != nil {
+		ctx.WithError(err).Warn("Could not connect gateway")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.gateways[gatewayID] {
+		return
+	}
+	downlink, err := b.router.SubscribeDownlink(gatewayID, mqttSubscriptionID)
+	if err != nil {
+		ctx.WithError(err).Warn("Could not subscribe to downlink")
+		return
+	}
+	b.gateways[gatewayID] = true
+	go func() {
+		for message := range downlink {
+			token := b.client.PublishGatewayDownlink(gatewayID, message)
+			if token.WaitTimeout(MQTTTimeout) && token.Error() != nil {
+				ctx.WithError(token.Error()).Warn("Could not publish downlink")
+			}
+		}
+	}()
+	ctx.Info("Gateway connected")
+}
+
+func (b *mqttBridge) handleDisconnect(_ mqtt.Client, gatewayID string, msg mqtt.GatewayDisconnectMessage) {
+	if _, err := b.router.authenticateGateway(gatewayID, msg.Token); err != nil {
+		b.ctx.WithField("GatewayID", gatewayID).WithError(err).Warn("Could not disconnect gateway")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.gateways[gatewayID] {
+		return
+	}
+	b.router.UnsubscribeDownlink(gatewayID, mqttSubscriptionID)
+	delete(b.gateways, gatewayID)
+	b.ctx.WithField("GatewayID", gatewayID).Info("Gateway disconnected")
+}
+
+func (b *mqttBridge) handleUplink(_ mqtt.Client, gatewayID string, uplink *pb.UplinkMessage) {
+	ctx := b.ctx.WithField("GatewayID", gatewayID)
+	if !b.isConnected(gatewayID) {
+		ctx.Warn("Dropping uplink of gateway that is not connected")
+		return
+	}
+	if err := uplink.Validate(); err != nil {
+		ctx.WithError(err).Warn("Invalid uplink")
+		return
+	}
+	if b.uplinkRate.Limit(gatewayID) {
+		ctx.Warn("Gateway reached uplink rate limit")
+		return
+	}
+	go b.router.HandleUplink(gatewayID, uplink)
+}
+
+func (b *mqttBridge) handleStatus(_ mqtt.Client, gatewayID string, status *pb_gateway.Status) {
+	ctx := b.ctx.WithField("GatewayID", gatewayID)
+	if !b.isConnected(gatewayID) {
+		ctx.Warn("Dropping status of gateway that is not connected")
+		return
+	}
+	if err := status.Validate(); err != nil {
+		ctx.WithError(err).Warn("Invalid status")
+		return
+	}
+	if b.statusRate.Limit(gatewayID) {
+		ctx.Warn("Gateway reached status rate limit")
+		return
+	}
+	go b.router.HandleGatewayStatus(gatewayID, status)
+}
+
+func (b *mqttBridge) handleTxAck(_ mqtt.Client, gatewayID string, ack *pb.TxAcknowledgment) {
+	ctx := b.ctx.WithField("GatewayID", gatewayID)
+	if !b.isConnected(gatewayID) {
+		ctx.Warn("Dropping tx ack of gateway that is not connected")
+		return
+	}
+	if err := ack.Validate(); err != nil {
+		ctx.WithError(err).Warn("Invalid tx ack")
+		return
+	}
+	go b.router.HandleTxAck(gatewayID, ack)
+}
@@ -0,0 +1,103 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/mqtt"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+	"github.com/spf13/viper"
+)
+
+func TestServeMQTT(t *testing.T) {
+	host := os.Getenv("MQTT_ADDRESS")
+	if host == "" {
+		host = "localhost:1883"
+	}
+
+	a := New(t)
+
+	viper.Set("router.skip-verify-gateway-token", true)
+	defer viper.Set("router.skip-verify-gateway-token", false)
+
+	r := getTestRouter(t)
+	r.Component.Identity = &pb_discovery.Announcement{}
+
+	client := mqtt.NewClient(GetLogger(t, "TestServeMQTT"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	a.So(client.Connect(), ShouldBeNil)
+	defer client.Disconnect()
+	a.So(r.ServeMQTT(client), ShouldBeNil)
+
+	gtw := mqtt.NewClient(GetLogger(t, "TestServeMQTT"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	a.So(gtw.Connect(), ShouldBeNil)
+	defer gtw.Disconnect()
+
+	gtwID := "router-test-serve-mqtt"
+
+	// Status of gateways that did not connect is dropped
+	gtw.PublishGatewayStatus(gtwID, &pb_gateway.Status{Description: "Not Connected"}).Wait()
+	<-time.After(50 * time.Millisecond)
+	status, err := r.getGateway(gtwID).Status.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldBeEmpty)
+
+	var wg WaitGroup
+	wg.Add(1)
+	gtw.SubscribeGatewayDownlink(gtwID, func(client mqtt.Client, gatewayID string, msg *pb.DownlinkMessage) {
+		a.So(gatewayID, ShouldEqual, gtwID)
+		a.So(msg.Payload, ShouldResemble, []byte{1, 2, 3})
+		wg.Done()
+	}).Wait()
+
+	gtw.PublishGatewayConnect(gtwID, mqtt.GatewayConnectMessage{}).Wait()
+	<-time.After(50 * time.Millisecond)
+
+	gtw.PublishGatewayStatus(gtwID, &pb_gateway.Status{Description: "Connected"}).Wait()
+	<-time.After(50 * time.Millisecond)
+	status, err = r.getGateway(gtwID).Status.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldEqual, "Connected")
+	a.So(status.GatewayTrusted, ShouldBeFalse)
+
+	// Downlink is published to the gateway
+	gateway := r.getGateway(gtwID)
+	gateway.Schedule.Sync(0)
+	id, _ := gateway.Schedule.GetOption(1000000, 50)
+	err = gateway.Schedule.Schedule(id, &pb.DownlinkMessage{
+		Payload: []byte{1, 2, 3},
+		ProtocolConfiguration: &pb_protocol.TxConfiguration{Protocol: &pb_protocol.TxConfiguration_Lorawan{Lorawan: &pb_lorawan.TxConfiguration{
+			Modulation: pb_lorawan.Modulation_LORA,
+			DataRate:   "SF7BW125",
+			CodingRate: "4/5",
+		}}},
+		GatewayConfiguration: &pb_gateway.TxConfiguration{
+			Timestamp: 1000000,
+			Frequency: 868100000,
+			Power:     14,
+		},
+	})
+	a.So(err, ShouldBeNil)
+	a.So(wg.WaitFor(2*time.Second), ShouldBeNil)
+
+	// Disconnect without a valid token is ignored
+	viper.Set("router.skip-verify-gateway-token", false)
+	gtw.PublishGatewayDisconnect(gtwID, mqtt.GatewayDisconnectMessage{}).Wait()
+	<-time.After(50 * time.Millisecond)
+	a.So(gateway.Schedule.IsActive(), ShouldBeTrue)
+	viper.Set("router.skip-verify-gateway-token", true)
+
+	gtw.PublishGatewayDisconnect(gtwID, mqtt.GatewayDisconnectMessage{}).Wait()
+	<-time.After(50 * time.Millisecond)
+	a.So(gateway.Schedule.IsActive(), ShouldBeFalse)
+}
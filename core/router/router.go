@@ -4,6 +4,7 @@
 package router
 
 import (
+	"net"
 	"sync"
 	"time"
 
@@ -15,6 +16,9 @@ import (
 	pb "github.com/TheThingsNetwork/ttn/api/router"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/router/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/mqtt"
+	"gopkg.in/redis.v5"
 )
 
 // Router component
@@ -28,12 +32,20 @@ type Router interface {
 	HandleUplink(gatewayID string, uplink *pb.UplinkMessage) error
 	// Handle a downlink message
 	HandleDownlink(message *pb_broker.DownlinkMessage) error
+	// Handle the acknowledgment of a downlink message by a gateway
+	HandleTxAck(gatewayID string, ack *pb.TxAcknowledgment) error
 	// Subscribe to downlink messages
 	SubscribeDownlink(gatewayID string, subscriptionID string) (<-chan *pb.DownlinkMessage, error)
 	// Unsubscribe from downlink messages
 	UnsubscribeDownlink(gatewayID string, subscriptionID string) error
 	// Handle a device activation
 	HandleActivation(gatewayID string, activation *pb.DeviceActivationRequest) (*pb.DeviceActivationResponse, error)
+	// Serve gateways that use the UDP protocol of the Semtech packet forwarder
+	ServeUDP(conn net.PacketConn, allowedEUIs ...types.EUI64) error
+	// Serve gateways that publish to an MQTT broker
+	ServeMQTT(client mqtt.Client) error
+	// Remove gateways that were not seen for the given duration, 0 keeps all gateways
+	SetGatewayExpiry(expiry time.Duration)
 
 	getGateway(gatewayID string) *gateway.Gateway
 }
@@ -54,6 +66,15 @@ func NewRouter() Router {
 	}
 }
 
+// NewRedisRouter creates a new Router that stores the state of gateways in Redis
+func NewRedisRouter(client *redis.Client) Router {
+	return &router{
+		gateways: make(map[string]*gateway.Gateway),
+		brokers:  make(map[string]*broker),
+		redis:    client,
+	}
+}
+
 type router struct {
 	*component.Component
 	gateways     map[string]*gateway.Gateway
@@ -61,6 +82,12 @@ type router struct {
 	brokers      map[string]*broker
 	brokersLock  sync.RWMutex
 	status       *status
+
+	redis         *redis.Client
+	gatewayExpiry time.Duration
+
+	pendingDownlinks     map[string]*pendingDownlink
+	pendingDownlinksLock sync.Mutex
 }
 
 func (r *router) tickGateways() {
@@ -71,6 +98,44 @@ func (r *router) tickGateways() {
 	}
 }
 
+func (r *router) SetGatewayExpiry(expiry time.Duration) {
+	r.gatewayExpiry = expiry
+}
+
+// expireGateways removes gateways that were not seen since the gateway expiry, that do not have active downlink and that
+// did not transmit in the duty-cycle window
+func (r *router) expireGateways() {
+	if r.gatewayExpiry == 0 {
+		return
+	}
+	lastSeenBefore := time.Now().Add(-1 * r.gatewayExpiry)
+
+	var keep []string
+	r.gatewaysLock.Lock()
+	for id, gtw := range r.gateways {
+		if gtw.LastSeen.Before(lastSeenBefore) && !gtw.Schedule.IsActive() && gtw.Airtime.IsEmpty() {
+			if gtw.MonitorStream != nil {
+				gtw.MonitorStream.Close()
+			}
+			delete(r.gateways, id)
+			continue
+		}
+		keep = append(keep, id)
+	}
+	r.gatewaysLock.Unlock()
+
+	if r.redis != nil {
+		// The status of the gateways that are kept contains the frequency plan that the airtime is checked against
+		deleted, err := gateway.DeleteRedisGateways(r.redis, "router", lastSeenBefore, keep...)
+		if err != nil {
+			r.Ctx.WithError(err).Warn("Could not delete expired gateways")
+		}
+		if len(deleted) > 0 {
+			r.Ctx.WithField("NumGateways", len(deleted)).Info("Deleted expired gateways")
+		}
+	}
+}
+
 func (r *router) Init(c *component.Component) error {
 	r.Component = c
 	r.InitStatus()
@@ -87,6 +152,13 @@ func (r *router) Init(c *component.Component) error {
 	go func() {
 		for range time.Tick(5 * time.Second) {
 			r.tickGateways()
+			r.expirePendingDownlinks()
+		}
+	}()
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			r.expireGateways()
 		}
 	}()
 	r.Component.SetStatus(component.StatusHealthy)
@@ -117,7 +189,11 @@ func (r *router) getGateway(id string) *gateway.Gateway {
 
 	gtw, ok = r.gateways[id]
 	if !ok {
-		gtw = gateway.NewGateway(r.Ctx, id)
+		if r.redis != nil {
+			gtw = gateway.NewRedisGateway(r.Ctx, id, r.redis, "router")
+		} else {
+			gtw = gateway.NewGateway(r.Ctx, id)
+		}
 		gtw.Monitor = r.Component.Monitor
 
 		r.gateways[id] = gtw
@@ -170,7 +246,7 @@ func (r *router) getBroker(brokerAnnouncement *pb_discovery.Announcement) (*brok
 					brk.association.Uplink(message)
 				case message, ok := <-brk.association.Downlink():
 					if ok {
-						go r.HandleDownlink(message)
+						go r.handleDownlink(brokerAnnouncement.Id, message)
 					}
 				}
 			}
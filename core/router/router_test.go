@@ -3,8 +3,39 @@
 
 package router
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/assertions"
+)
 
 func TestRouterIntegration(t *testing.T) {
 
 }
+
+func TestExpireGateways(t *testing.T) {
+	a := New(t)
+
+	r := getTestRouter(t)
+
+	seen := r.getGateway("eui-0102030405060708")
+	seen.LastSeen = time.Now()
+	notSeen := r.getGateway("eui-0807060504030201")
+	notSeen.LastSeen = time.Now().Add(-1 * time.Hour)
+
+	// Gateways are kept without expiry
+	r.expireGateways()
+	a.So(r.gateways, ShouldContainKey, "eui-0807060504030201")
+
+	// Gateways that transmitted in the duty-cycle window are kept
+	notSeen.Airtime.Add(868100000, time.Second)
+	r.SetGatewayExpiry(time.Minute)
+	r.expireGateways()
+	a.So(r.gateways, ShouldContainKey, "eui-0807060504030201")
+
+	notSeen.Airtime.Remove(868100000, time.Second)
+	r.expireGateways()
+	a.So(r.gateways, ShouldContainKey, "eui-0102030405060708")
+	a.So(r.gateways, ShouldNotContainKey, "eui-0807060504030201")
+}
@@ -0,0 +1,132 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package semtech
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// UplinkMessage converts the received packet to an uplink message
+func (rxpk RXPK) UplinkMessage() (*pb_router.UplinkMessage, error) {
+	if rxpk.Stat == -1 {
+		return nil, errors.NewErrInvalidArgument("RXPK", "CRC error")
+	}
+
+	payload, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(rxpk.Data, "="))
+	if err != nil {
+		return nil, errors.NewErrInvalidArgument("RXPK Data", err.Error())
+	}
+
+	lorawan := new(pb_lorawan.Metadata)
+	switch rxpk.Modu {
+	case "LORA":
+		lorawan.Modulation = pb_lorawan.Modulation_LORA
+		lorawan.DataRate = rxpk.DatR.LoRa
+		lorawan.CodingRate = rxpk.CodR
+	case "FSK":
+		lorawan.Modulation = pb_lorawan.Modulation_FSK
+		lorawan.BitRate = rxpk.DatR.FSK
+	default:
+		return nil, errors.NewErrInvalidArgument("RXPK Modulation", fmt.Sprintf("%s is not supported", rxpk.Modu))
+	}
+
+	gateway := &pb_gateway.RxMetadata{
+		Timestamp: rxpk.Tmst,
+		RfChain:   rxpk.RFCh,
+		Channel:   rxpk.Chan,
+		Frequency: parseFrequency(rxpk.Freq),
+		Rssi:      rxpk.RSSI,
+		Snr:       rxpk.LSNR,
+	}
+	if rxpk.Time != nil {
+		gateway.Time = time.Time(*rxpk.Time).UnixNano()
+	}
+	for _, rsig := range rxpk.RSig {
+		antenna := &pb_gateway.RxMetadata_Antenna{
+			Antenna: rsig.Ant,
+			Channel: rsig.Chan,
+			Rssi:    rsig.RSSIC,
+			Snr:     rsig.LSNR,
+		}
+		if rsig.ETime != "" {
+			antenna.EncryptedTime, _ = base64.StdEncoding.DecodeString(rsig.ETime)
+		}
+		gateway.Antennas = append(gateway.Antennas, antenna)
+	}
+	if len(gateway.Antennas) > 0 && gateway.Rssi == 0 {
+		gateway.Rssi, gateway.Snr = gateway.Antennas[0].Rssi, gateway.Antennas[0].Snr
+	}
+
+	return &pb_router.UplinkMessage{
+		Payload:          payload,
+		ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{Lorawan: lorawan}},
+		GatewayMetadata:  gateway,
+	}, nil
+}
+
+// GatewayStatus converts the status of the gateway to a gateway status message
+func (stat Stat) GatewayStatus() *pb_gateway.Status {
+	status := &pb_gateway.Status{
+		Time:         time.Time(stat.Time).UnixNano(),
+		Platform:     stat.Pfrm,
+		ContactEmail: stat.Mail,
+		Description:  stat.Desc,
+		RxIn:         stat.RXNb,
+		RxOk:         stat.RXOK,
+		TxIn:         stat.DWNb,
+		TxOk:         stat.TXNb,
+	}
+	if stat.Lati != nil && stat.Long != nil {
+		status.Gps = &pb_gateway.GPSMetadata{
+			Latitude:  float32(*stat.Lati),
+			Longitude: float32(*stat.Long),
+		}
+		if stat.Alti != nil {
+			status.Gps.Altitude = *stat.Alti
+		}
+	}
+	return status
+}
+
+// NewTXPK converts the downlink message to a packet to transmit
+func NewTXPK(downlink *pb_router.DownlinkMessage) (*TXPK, error) {
+	gateway := downlink.GetGatewayConfiguration()
+	if gateway == nil {
+		return nil, errors.NewErrInvalidArgument("Downlink", "missing gateway configuration")
+	}
+	lorawan := downlink.GetProtocolConfiguration().GetLorawan()
+	if lorawan == nil {
+		return nil, errors.NewErrInvalidArgument("Downlink", "missing LoRaWAN configuration")
+	}
+
+	txpk := &TXPK{
+		Tmst: gateway.Timestamp,
+		Freq: formatFrequency(gateway.Frequency),
+		RFCh: gateway.RfChain,
+		Powe: gateway.Power,
+		IPol: gateway.PolarizationInversion,
+		Size: uint32(len(downlink.Payload)),
+		Data: base64.StdEncoding.EncodeToString(downlink.Payload),
+	}
+	switch lorawan.Modulation {
+	case pb_lorawan.Modulation_LORA:
+		txpk.Modu = "LORA"
+		txpk.DatR.LoRa = lorawan.DataRate
+		txpk.CodR = lorawan.CodingRate
+	case pb_lorawan.Modulation_FSK:
+		txpk.Modu = "FSK"
+		txpk.DatR.FSK = lorawan.BitRate
+		txpk.FDev = gateway.FrequencyDeviation
+	}
+	return txpk, nil
+}
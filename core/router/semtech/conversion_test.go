@@ -0,0 +1,152 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package semtech
+
+import (
+	"testing"
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestRXPKUplinkMessage(t *testing.T) {
+	a := New(t)
+
+	rxTime := CompactTime(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	rxpk := RXPK{
+		Time: &rxTime,
+		Tmst: 1000,
+		Freq: 868.1,
+		Chan: 1,
+		RFCh: 1,
+		Stat: 1,
+		Modu: "LORA",
+		DatR: DataRate{LoRa: "SF7BW125"},
+		CodR: "4/5",
+		RSSI: -35,
+		LSNR: 5.5,
+		Size: 3,
+		Data: "AQID",
+	}
+
+	uplink, err := rxpk.UplinkMessage()
+	a.So(err, ShouldBeNil)
+	a.So(uplink.Payload, ShouldResemble, []byte{1, 2, 3})
+	a.So(uplink.GatewayMetadata.Timestamp, ShouldEqual, 1000)
+	a.So(uplink.GatewayMetadata.Time, ShouldEqual, time.Time(rxTime).UnixNano())
+	a.So(uplink.GatewayMetadata.Frequency, ShouldEqual, 868100000)
+	a.So(uplink.GatewayMetadata.Channel, ShouldEqual, 1)
+	a.So(uplink.GatewayMetadata.RfChain, ShouldEqual, 1)
+	a.So(uplink.GatewayMetadata.Rssi, ShouldEqual, -35)
+	a.So(uplink.GatewayMetadata.Snr, ShouldEqual, 5.5)
+	lorawan := uplink.ProtocolMetadata.GetLorawan()
+	a.So(lorawan.Modulation, ShouldEqual, pb_lorawan.Modulation_LORA)
+	a.So(lorawan.DataRate, ShouldEqual, "SF7BW125")
+	a.So(lorawan.CodingRate, ShouldEqual, "4/5")
+
+	// Padding is optional
+	rxpk.Data = "AQI="
+	uplink, err = rxpk.UplinkMessage()
+	a.So(err, ShouldBeNil)
+	a.So(uplink.Payload, ShouldResemble, []byte{1, 2})
+
+	// Antennas
+	rxpk.RSSI, rxpk.LSNR = 0, 0
+	rxpk.RSig = []RSig{{Ant: 0, Chan: 1, RSSIC: -40, LSNR: 7}, {Ant: 1, Chan: 1, RSSIC: -45, LSNR: 6}}
+	uplink, err = rxpk.UplinkMessage()
+	a.So(err, ShouldBeNil)
+	a.So(uplink.GatewayMetadata.Antennas, ShouldHaveLength, 2)
+	a.So(uplink.GatewayMetadata.Rssi, ShouldEqual, -40)
+	a.So(uplink.GatewayMetadata.Snr, ShouldEqual, 7)
+
+	// FSK
+	fsk := RXPK{Stat: 1, Modu: "FSK", DatR: DataRate{FSK: 50000}, Freq: 868.8, Data: "AQID"}
+	uplink, err = fsk.UplinkMessage()
+	a.So(err, ShouldBeNil)
+	a.So(uplink.ProtocolMetadata.GetLorawan().Modulation, ShouldEqual, pb_lorawan.Modulation_FSK)
+	a.So(uplink.ProtocolMetadata.GetLorawan().BitRate, ShouldEqual, 50000)
+
+	// Invalid packets
+	for _, invalid := range []RXPK{
+		{Stat: -1, Modu: "LORA", Data: "AQID"},
+		{Stat: 1, Modu: "LORA", Data: "%%%"},
+		{Stat: 1, Modu: "OOK", Data: "AQID"},
+	} {
+		_, err = invalid.UplinkMessage()
+		a.So(err, ShouldNotBeNil)
+	}
+}
+
+func TestStatGatewayStatus(t *testing.T) {
+	a := New(t)
+
+	lati, long, alti := 52.5, 4.75, int32(3)
+	stat := Stat{
+		Time: ExpandedTime(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)),
+		Lati: &lati,
+		Long: &long,
+		Alti: &alti,
+		RXNb: 10,
+		RXOK: 9,
+		DWNb: 3,
+		TXNb: 2,
+		Pfrm: "Kerlink",
+		Mail: "gateway@example.com",
+		Desc: "Test",
+	}
+	status := stat.GatewayStatus()
+	a.So(status.Time, ShouldEqual, time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC).UnixNano())
+	a.So(status.Gps, ShouldResemble, &pb_gateway.GPSMetadata{Latitude: 52.5, Longitude: 4.75, Altitude: 3})
+	a.So(status.RxIn, ShouldEqual, 10)
+	a.So(status.RxOk, ShouldEqual, 9)
+	a.So(status.TxIn, ShouldEqual, 3)
+	a.So(status.TxOk, ShouldEqual, 2)
+	a.So(status.Platform, ShouldEqual, "Kerlink")
+	a.So(status.ContactEmail, ShouldEqual, "gateway@example.com")
+	a.So(status.Description, ShouldEqual, "Test")
+
+	stat.Long = nil
+	a.So(stat.GatewayStatus().Gps, ShouldBeNil)
+}
+
+func TestNewTXPK(t *testing.T) {
+	a := New(t)
+
+	_, err := NewTXPK(&pb_router.DownlinkMessage{})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = NewTXPK(&pb_router.DownlinkMessage{GatewayConfiguration: &pb_gateway.TxConfiguration{}})
+	a.So(err, ShouldNotBeNil)
+
+	downlink := &pb_router.DownlinkMessage{
+		Payload: []byte{1, 2, 3},
+		ProtocolConfiguration: &pb_protocol.TxConfiguration{Protocol: &pb_protocol.TxConfiguration_Lorawan{Lorawan: &pb_lorawan.TxConfiguration{
+			Modulation: pb_lorawan.Modulation_LORA,
+			DataRate:   "SF9BW125",
+			CodingRate: "4/5",
+		}}},
+		GatewayConfiguration: &pb_gateway.TxConfiguration{
+			Timestamp:             2000000,
+			RfChain:               0,
+			Frequency:             869525000,
+			Power:                 27,
+			PolarizationInversion: true,
+		},
+	}
+	txpk, err := NewTXPK(downlink)
+	a.So(err, ShouldBeNil)
+	a.So(txpk.Tmst, ShouldEqual, 2000000)
+	a.So(txpk.Freq, ShouldEqual, 869.525)
+	a.So(txpk.Powe, ShouldEqual, 27)
+	a.So(txpk.IPol, ShouldBeTrue)
+	a.So(txpk.Modu, ShouldEqual, "LORA")
+	a.So(txpk.DatR.LoRa, ShouldEqual, "SF9BW125")
+	a.So(txpk.CodR, ShouldEqual, "4/5")
+	a.So(txpk.Size, ShouldEqual, 3)
+	a.So(txpk.Data, ShouldEqual, "AQID")
+}
@@ -0,0 +1,315 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package semtech implements the UDP protocol of the Semtech packet forwarder
+//
+// See https://github.com/Lora-net/packet_forwarder/blob/master/PROTOCOL.TXT
+package semtech
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// PacketType is the type of a packet
+type PacketType byte
+
+// Packet types of the Semtech protocol
+const (
+	PushData PacketType = 0x00
+	PushAck  PacketType = 0x01
+	PullData PacketType = 0x02
+	PullResp PacketType = 0x03
+	PullAck  PacketType = 0x04
+	TxAck    PacketType = 0x05
+)
+
+// String implements the Stringer interface
+func (t PacketType) String() string {
+	switch t {
+	case PushData:
+		return "PUSH_DATA"
+	case PushAck:
+		return "PUSH_ACK"
+	case PullData:
+		return "PULL_DATA"
+	case PullResp:
+		return "PULL_RESP"
+	case PullAck:
+		return "PULL_ACK"
+	case TxAck:
+		return "TX_ACK"
+	}
+	return fmt.Sprintf("0x%02x", byte(t))
+}
+
+// hasGatewayEUI returns true if packets of this type contain the EUI of the gateway
+func (t PacketType) hasGatewayEUI() bool {
+	return t == PushData || t == PullData || t == TxAck
+}
+
+// hasPayload returns true if packets of this type can contain a JSON payload
+func (t PacketType) hasPayload() bool {
+	return t == PushData || t == PullResp || t == TxAck
+}
+
+// Supported protocol versions
+const (
+	Version1 = 0x01
+	Version2 = 0x02
+)
+
+// Packet is a packet of the Semtech protocol
+type Packet struct {
+	Version    byte
+	Token      [2]byte
+	Type       PacketType
+	GatewayEUI types.EUI64
+	Payload    *Payload
+}
+
+// Payload is the JSON payload of a packet
+type Payload struct {
+	RXPK    []RXPK   `json:"rxpk,omitempty"`
+	Stat    *Stat    `json:"stat,omitempty"`
+	TXPK    *TXPK    `json:"txpk,omitempty"`
+	TXPKAck *TXPKAck `json:"txpk_ack,omitempty"`
+}
+
+// Ack returns the acknowledgement of a PUSH_DATA or PULL_DATA packet
+func (p Packet) Ack() (*Packet, error) {
+	ack := &Packet{Version: p.Version, Token: p.Token}
+	switch p.Type {
+	case PushData:
+		ack.Type = PushAck
+	case PullData:
+		ack.Type = PullAck
+	default:
+		return nil, errors.NewErrInvalidArgument("Packet Type", fmt.Sprintf("%s can not be acknowledged", p.Type))
+	}
+	return ack, nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface
+func (p Packet) MarshalBinary() ([]byte, error) {
+	if p.Version != Version1 && p.Version != Version2 {
+		return nil, errors.NewErrInvalidArgument("Version", fmt.Sprintf("%d is not supported", p.Version))
+	}
+	data := []byte{p.Version, p.Token[0], p.Token[1], byte(p.Type)}
+	if p.Type.hasGatewayEUI() {
+		data = append(data, p.GatewayEUI[:]...)
+	}
+	if p.Payload != nil && p.Type.hasPayload() {
+		payload, err := json.Marshal(p.Payload)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, payload...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (p *Packet) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errors.NewErrInvalidArgument("Packet", "too short")
+	}
+	p.Version, p.Token, p.Type = data[0], [2]byte{data[1], data[2]}, PacketType(data[3])
+	if p.Version != Version1 && p.Version != Version2 {
+		return errors.NewErrInvalidArgument("Version", fmt.Sprintf("%d is not supported", p.Version))
+	}
+	if p.Type > TxAck {
+		return errors.NewErrInvalidArgument("Packet Type", fmt.Sprintf("%s is not supported", p.Type))
+	}
+	data = data[4:]
+	if p.Type.hasGatewayEUI() {
+		if len(data) < 8 {
+			return errors.NewErrInvalidArgument("Packet", "missing gateway EUI")
+		}
+		copy(p.GatewayEUI[:], data[:8])
+		data = data[8:]
+	}
+	p.Payload = nil
+	if len(data) > 0 && p.Type.hasPayload() {
+		p.Payload = new(Payload)
+		if err := json.Unmarshal(data, p.Payload); err != nil {
+			return errors.NewErrInvalidArgument("Payload", err.Error())
+		}
+	}
+	return nil
+}
+
+// RXPK contains a received packet
+type RXPK struct {
+	Time *CompactTime `json:"time,omitempty"` // UTC time of pkt RX, us precision, ISO 8601 'compact' format
+	Tmms *int64       `json:"tmms,omitempty"` // GPS time of pkt RX, number of milliseconds since 06.Jan.1980
+	Tmst uint32       `json:"tmst"`           // Internal timestamp of "RX finished" event (32b unsigned)
+	Freq float64      `json:"freq"`           // RX central frequency in MHz (unsigned float, Hz precision)
+	Chan uint32       `json:"chan"`           // Concentrator "IF" channel used for RX (unsigned integer)
+	RFCh uint32       `json:"rfch"`           // Concentrator "RF chain" used for RX (unsigned integer)
+	Stat int32        `json:"stat"`           // CRC status: 1 = OK, -1 = fail, 0 = no CRC
+	Modu string       `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR DataRate     `json:"datr"`           // LoRa datarate identifier (eg. SF12BW500) or FSK datarate (unsigned, in bps)
+	CodR string       `json:"codr,omitempty"` // LoRa ECC coding rate identifier
+	RSSI float32      `json:"rssi"`           // RSSI in dBm (signed integer, 1 dB precision)
+	LSNR float32      `json:"lsnr,omitempty"` // Lora SNR ratio in dB (signed float, 0.1 dB precision)
+	Size uint32       `json:"size"`           // RF packet payload size in bytes (unsigned integer)
+	Data string       `json:"data"`           // Base64 encoded RF packet payload, padded
+	RSig []RSig       `json:"rsig,omitempty"` // Received signal information, per antenna (version 2)
+}
+
+// RSig contains the signal information of a received packet on one antenna
+type RSig struct {
+	Ant   uint32  `json:"ant"`             // Antenna number on which signal has been received
+	Chan  uint32  `json:"chan"`            // Concentrator "IF" channel used for RX (unsigned integer)
+	RSSIC float32 `json:"rssic"`           // RSSI in dBm of the channel (signed integer, 1 dB precision)
+	LSNR  float32 `json:"lsnr,omitempty"`  // Lora SNR ratio in dB (signed float, 0.1 dB precision)
+	ETime string  `json:"etime,omitempty"` // Encrypted timestamp, ns precision
+}
+
+// Stat contains the status of the gateway
+type Stat struct {
+	Time ExpandedTime `json:"time"`           // UTC 'system' time of the gateway, ISO 8601 'expanded' format
+	Lati *float64     `json:"lati,omitempty"` // GPS latitude of the gateway in degree (float, N is +)
+	Long *float64     `json:"long,omitempty"` // GPS longitude of the gateway in degree (float, E is +)
+	Alti *int32       `json:"alti,omitempty"` // GPS altitude of the gateway in meter RX (integer)
+	RXNb uint32       `json:"rxnb"`           // Number of radio packets received (unsigned integer)
+	RXOK uint32       `json:"rxok"`           // Number of radio packets received with a valid PHY CRC
+	RXFW uint32       `json:"rxfw"`           // Number of radio packets forwarded (unsigned integer)
+	ACKR float64      `json:"ackr"`           // Percentage of upstream datagrams that were acknowledged
+	DWNb uint32       `json:"dwnb"`           // Number of downlink datagrams received (unsigned integer)
+	TXNb uint32       `json:"txnb"`           // Number of packets emitted (unsigned integer)
+
+	// Extensions of The Things Network
+	Pfrm string `json:"pfrm,omitempty"` // Platform of the gateway
+	Mail string `json:"mail,omitempty"` // Contact email address of the owner of the gateway
+	Desc string `json:"desc,omitempty"` // Description of the gateway
+}
+
+// TXPK contains a packet to transmit
+type TXPK struct {
+	Imme bool     `json:"imme"`           // Send packet immediately (will ignore tmst & time)
+	Tmst uint32   `json:"tmst,omitempty"` // Send packet on a certain timestamp value (will ignore time)
+	Tmms *int64   `json:"tmms,omitempty"` // Send packet at a certain GPS time (GPS synchronization required)
+	Freq float64  `json:"freq"`           // TX central frequency in MHz (unsigned float, Hz precision)
+	RFCh uint32   `json:"rfch"`           // Concentrator "RF chain" used for TX (unsigned integer)
+	Powe int32    `json:"powe"`           // TX output power in dBm (unsigned integer, dBm precision)
+	Modu string   `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR DataRate `json:"datr"`           // LoRa datarate identifier (eg. SF12BW500) or FSK datarate (unsigned, in bps)
+	CodR string   `json:"codr,omitempty"` // LoRa ECC coding rate identifier
+	FDev uint32   `json:"fdev,omitempty"` // FSK frequency deviation (unsigned integer, in Hz)
+	IPol bool     `json:"ipol"`           // Lora modulation polarization inversion
+	Prea uint32   `json:"prea,omitempty"` // RF preamble size (unsigned integer)
+	Size uint32   `json:"size"`           // RF packet payload size in bytes (unsigned integer)
+	Data string   `json:"data"`           // Base64 encoded RF packet payload, padding optional
+	NCRC bool     `json:"ncrc,omitempty"` // If true, disable the CRC of the physical layer (optional)
+}
+
+// TXPKAck contains the result of a transmission request
+type TXPKAck struct {
+	Error string `json:"error,omitempty"` // Indication about success or type of failure that occured for downlink request
+}
+
+// TXPKAckNone is the error of a TXPKAck of a successful transmission request
+const TXPKAckNone = "NONE"
+
+// DataRate is a LoRa data rate identifier (LoRa) or a bit rate (FSK)
+type DataRate struct {
+	LoRa string
+	FSK  uint32
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (d DataRate) MarshalJSON() ([]byte, error) {
+	if d.LoRa != "" {
+		return json.Marshal(d.LoRa)
+	}
+	return json.Marshal(d.FSK)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (d *DataRate) UnmarshalJSON(data []byte) error {
+	*d = DataRate{}
+	if strings.HasPrefix(string(data), `"`) {
+		return json.Unmarshal(data, &d.LoRa)
+	}
+	return json.Unmarshal(data, &d.FSK)
+}
+
+// CompactTime is a time in the ISO 8601 'compact' format
+type CompactTime time.Time
+
+// MarshalJSON implements the json.Marshaler interface
+func (t CompactTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(t).UTC().Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *CompactTime) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return err
+	}
+	*t = CompactTime(parsed)
+	return nil
+}
+
+// ExpandedTime is a time in the ISO 8601 'expanded' format
+type ExpandedTime time.Time
+
+const expandedTimeFormat = "2006-01-02 15:04:05 MST"
+
+// MarshalJSON implements the json.Marshaler interface
+func (t ExpandedTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(t).UTC().Format(expandedTimeFormat))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *ExpandedTime) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(expandedTimeFormat, str)
+	if err != nil {
+		// Some packet forwarders send the time without time zone
+		parsed, err = time.Parse("2006-01-02 15:04:05", str)
+		if err != nil {
+			return err
+		}
+	}
+	*t = ExpandedTime(parsed)
+	return nil
+}
+
+// GatewayID returns the ID of the gateway with the given EUI
+func GatewayID(eui types.EUI64) string {
+	return "eui-" + strings.ToLower(eui.String())
+}
+
+// ParseGatewayID returns the EUI of the gateway with the given ID
+func ParseGatewayID(gatewayID string) (types.EUI64, error) {
+	if !strings.HasPrefix(gatewayID, "eui-") {
+		return types.EUI64{}, errors.NewErrInvalidArgument("Gateway ID", "does not start with eui-")
+	}
+	return types.ParseEUI64(strings.TrimPrefix(gatewayID, "eui-"))
+}
+
+// formatFrequency formats a frequency in Hz as MHz
+func formatFrequency(frequency uint64) float64 {
+	return float64(frequency) / 1000000
+}
+
+// parseFrequency parses a frequency in MHz as Hz
+func parseFrequency(frequency float64) uint64 {
+	return uint64(frequency*1000000 + 0.5)
+}
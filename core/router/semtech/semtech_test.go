@@ -0,0 +1,117 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package semtech
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestPacketType(t *testing.T) {
+	a := New(t)
+	a.So(PushData.String(), ShouldEqual, "PUSH_DATA")
+	a.So(TxAck.String(), ShouldEqual, "TX_ACK")
+	a.So(PacketType(0x10).String(), ShouldEqual, "0x10")
+}
+
+func TestUnmarshalPacket(t *testing.T) {
+	a := New(t)
+
+	var packet Packet
+
+	a.So(packet.UnmarshalBinary([]byte{0x02, 0x01}), ShouldNotBeNil)
+	a.So(packet.UnmarshalBinary([]byte{0x03, 0x01, 0x02, 0x02, 1, 2, 3, 4, 5, 6, 7, 8}), ShouldNotBeNil)
+	a.So(packet.UnmarshalBinary([]byte{0x02, 0x01, 0x02, 0x06}), ShouldNotBeNil)
+	a.So(packet.UnmarshalBinary([]byte{0x02, 0x01, 0x02, 0x02, 1, 2, 3}), ShouldNotBeNil)
+
+	// PULL_DATA
+	err := packet.UnmarshalBinary([]byte{0x02, 0x01, 0x02, 0x02, 1, 2, 3, 4, 5, 6, 7, 8})
+	a.So(err, ShouldBeNil)
+	a.So(packet.Version, ShouldEqual, Version2)
+	a.So(packet.Token, ShouldEqual, [2]byte{0x01, 0x02})
+	a.So(packet.Type, ShouldEqual, PullData)
+	a.So(packet.GatewayEUI, ShouldEqual, types.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+	a.So(packet.Payload, ShouldBeNil)
+
+	ack, err := packet.Ack()
+	a.So(err, ShouldBeNil)
+	data, err := ack.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(data, ShouldResemble, []byte{0x02, 0x01, 0x02, 0x04})
+
+	// PUSH_DATA
+	err = packet.UnmarshalBinary(append([]byte{0x02, 0x01, 0x02, 0x00, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{
+		"rxpk": [{"time":"2017-06-01T12:00:00.000001Z","tmst":3512348611,"chan":2,"rfch":0,"freq":868.500000,"stat":1,"modu":"LORA","datr":"SF7BW125","codr":"4/6","rssi":-35,"lsnr":5.1,"size":3,"data":"AQID"}],
+		"stat": {"time":"2017-06-01 12:00:00 GMT","lati":52.37,"long":4.89,"alti":3,"rxnb":2,"rxok":2,"rxfw":2,"ackr":100.0,"dwnb":1,"txnb":1,"desc":"Test"}
+	}`)...))
+	a.So(err, ShouldBeNil)
+	a.So(packet.Type, ShouldEqual, PushData)
+	a.So(packet.Payload.RXPK, ShouldHaveLength, 1)
+	rxpk := packet.Payload.RXPK[0]
+	a.So(time.Time(*rxpk.Time).Equal(time.Date(2017, 6, 1, 12, 0, 0, 1000, time.UTC)), ShouldBeTrue)
+	a.So(rxpk.Tmst, ShouldEqual, 3512348611)
+	a.So(rxpk.DatR.LoRa, ShouldEqual, "SF7BW125")
+	a.So(packet.Payload.Stat, ShouldNotBeNil)
+	a.So(time.Time(packet.Payload.Stat.Time).Equal(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)), ShouldBeTrue)
+	a.So(*packet.Payload.Stat.Lati, ShouldEqual, 52.37)
+	a.So(packet.Payload.Stat.Desc, ShouldEqual, "Test")
+
+	ack, err = packet.Ack()
+	a.So(err, ShouldBeNil)
+	a.So(ack.Type, ShouldEqual, PushAck)
+
+	// TX_ACK
+	err = packet.UnmarshalBinary(append([]byte{0x02, 0x01, 0x02, 0x05, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{"txpk_ack":{"error":"TOO_LATE"}}`)...))
+	a.So(err, ShouldBeNil)
+	a.So(packet.Payload.TXPKAck.Error, ShouldEqual, "TOO_LATE")
+	_, err = packet.Ack()
+	a.So(err, ShouldNotBeNil)
+
+	err = packet.UnmarshalBinary(append([]byte{0x02, 0x01, 0x02, 0x00, 1, 2, 3, 4, 5, 6, 7, 8}, []byte(`{"rxpk":`)...))
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestMarshalPacket(t *testing.T) {
+	a := New(t)
+
+	_, err := Packet{Version: 0x03, Type: PullResp}.MarshalBinary()
+	a.So(err, ShouldNotBeNil)
+
+	data, err := Packet{
+		Version: Version2,
+		Token:   [2]byte{0x01, 0x02},
+		Type:    PullResp,
+		Payload: &Payload{TXPK: &TXPK{
+			Tmst: 1000000,
+			Freq: 869.525,
+			Powe: 14,
+			Modu: "FSK",
+			DatR: DataRate{FSK: 50000},
+			Size: 1,
+			Data: "AQ==",
+		}},
+	}.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(data[:4], ShouldResemble, []byte{0x02, 0x01, 0x02, 0x03})
+	a.So(string(data[4:]), ShouldContainSubstring, `"datr":50000`)
+	a.So(string(data[4:]), ShouldContainSubstring, `"freq":869.525`)
+
+	var packet Packet
+	a.So(packet.UnmarshalBinary(data), ShouldBeNil)
+	a.So(packet.Payload.TXPK.DatR.FSK, ShouldEqual, 50000)
+	a.So(packet.Payload.TXPK.Tmst, ShouldEqual, 1000000)
+}
+
+func TestGatewayID(t *testing.T) {
+	a := New(t)
+	a.So(GatewayID(types.EUI64{0xAA, 2, 3, 4, 5, 6, 7, 8}), ShouldEqual, "eui-aa02030405060708")
+	eui, err := ParseGatewayID("eui-aa02030405060708")
+	a.So(err, ShouldBeNil)
+	a.So(eui, ShouldEqual, types.EUI64{0xAA, 2, 3, 4, 5, 6, 7, 8})
+	_, err = ParseGatewayID("my-gateway")
+	a.So(err, ShouldNotBeNil)
+}
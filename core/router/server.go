@@ -15,6 +15,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/core/router/gateway"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/TheThingsNetwork/ttn/utils/random"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/spf13/viper"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
@@ -35,16 +36,20 @@ func (r *routerRPC) gatewayFromMetadata(md metadata.MD) (gtw *gateway.Gateway, e
 	if err != nil {
 		return nil, err
 	}
+	token, _ := api.TokenFromMetadata(md)
+	return r.router.authenticateGateway(gatewayID, token)
+}
 
+// authenticateGateway validates the token of a gateway and returns the gateway
+func (r *router) authenticateGateway(gatewayID string, token string) (gtw *gateway.Gateway, err error) {
 	authErr := errors.NewErrPermissionDenied("Gateway not authenticated")
 	authenticated := false
-	token, _ := api.TokenFromMetadata(md)
 
 	if token != "" {
-		if r.router.TokenKeyProvider == nil {
+		if r.TokenKeyProvider == nil {
 			return nil, errors.NewErrInternal("No token provider configured")
 		}
-		claims, err := claims.FromGatewayToken(r.router.TokenKeyProvider, token)
+		claims, err := claims.FromGatewayToken(r.TokenKeyProvider, token)
 		if err != nil {
 			authErr = errors.NewErrPermissionDenied(fmt.Sprintf("Gateway token invalid: %s", err))
 		} else {
@@ -61,7 +66,7 @@ func (r *routerRPC) gatewayFromMetadata(md metadata.MD) (gtw *gateway.Gateway, e
 		return nil, authErr
 	}
 
-	gtw = r.router.getGateway(gatewayID)
+	gtw = r.getGateway(gatewayID)
 	gtw.SetAuth(token, authenticated)
 
 	return gtw, nil
@@ -141,6 +146,21 @@ func (r *routerRPC) Activate(ctx context.Context, req *pb.DeviceActivationReques
 	return r.router.HandleActivation(gateway.ID, req)
 }
 
+// TxAck implements RouterServer interface (github.com/TheThingsNetwork/ttn/api/router)
+func (r *routerRPC) TxAck(ctx context.Context, req *pb.TxAcknowledgment) (*empty.Empty, error) {
+	gateway, err := r.gatewayFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid TxAcknowledgment")
+	}
+	if err := r.router.HandleTxAck(gateway.ID, req); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 // RegisterRPC registers this router as a RouterServer (github.com/TheThingsNetwork/ttn/api/router)
 func (r *router) RegisterRPC(s *grpc.Server) {
 	server := &routerRPC{router: r}
@@ -0,0 +1,126 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"fmt"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// TxAckTimeout is the time after which the Router forgets about a downlink message that was not acknowledged by the gateway
+var TxAckTimeout = time.Minute
+
+// pendingDownlink is a downlink message from a Broker that waits for the TxAcknowledgment of the gateway
+type pendingDownlink struct {
+	brokerID string
+	downlink *pb_broker.DownlinkMessage
+	expires  time.Time
+}
+
+func pendingDownlinkKey(gatewayID string, scheduleID string) string {
+	return fmt.Sprintf("%s:%s", gatewayID, scheduleID)
+}
+
+func (r *router) addPendingDownlink(gatewayID string, scheduleID string, brokerID string, downlink *pb_broker.DownlinkMessage) {
+	if scheduleID == "" {
+		return
+	}
+	r.pendingDownlinksLock.Lock()
+	defer r.pendingDownlinksLock.Unlock()
+	if r.pendingDownlinks == nil {
+		r.pendingDownlinks = make(map[string]*pendingDownlink)
+	}
+	r.pendingDownlinks[pendingDownlinkKey(gatewayID, scheduleID)] = &pendingDownlink{
+		brokerID: brokerID,
+		downlink: downlink,
+		expires:  time.Now().Add(TxAckTimeout),
+	}
+}
+
+func (r *router) removePendingDownlink(gatewayID string, scheduleID string) *pendingDownlink {
+	r.pendingDownlinksLock.Lock()
+	defer r.pendingDownlinksLock.Unlock()
+	key := pendingDownlinkKey(gatewayID, scheduleID)
+	pending, ok := r.pendingDownlinks[key]
+	if !ok {
+		return nil
+	}
+	delete(r.pendingDownlinks, key)
+	return pending
+}
+
+func (r *router) expirePendingDownlinks() {
+	r.pendingDownlinksLock.Lock()
+	defer r.pendingDownlinksLock.Unlock()
+	now := time.Now()
+	for key, pending := range r.pendingDownlinks {
+		if now.After(pending.expires) {
+			delete(r.pendingDownlinks, key)
+		}
+	}
+}
+
+func (r *router) HandleTxAck(gatewayID string, ack *pb.TxAcknowledgment) (err error) {
+	ctx := r.Ctx.WithFields(ttnlog.Fields{
+		"GatewayID":  gatewayID,
+		"ScheduleID": ack.ScheduleId,
+	})
+	defer func() {
+		if err != nil {
+			ctx.WithError(err).Warn("Could not handle TxAcknowledgment")
+		}
+	}()
+
+	gateway := r.getGateway(gatewayID)
+	pending := r.removePendingDownlink(gatewayID, ack.ScheduleId)
+
+	downlink, err := gateway.Schedule.TxAck(ack.ScheduleId, ack.Error == "")
+	if err != nil {
+		return err
+	}
+
+	if ack.Error == "" {
+		ctx.Debug("Gateway transmitted downlink")
+		return nil
+	}
+
+	ctx.WithField("Error", ack.Error).Warn("Gateway could not transmit downlink")
+	downlink.Trace = downlink.Trace.WithEvent(trace.DropEvent, "reason", ack.Error)
+	if gateway.MonitorStream != nil {
+		gateway.MonitorStream.Send(downlink)
+	}
+
+	// Downlink that did not come from a Broker has nobody to report the failure to
+	if pending == nil || pending.brokerID == "" {
+		return nil
+	}
+
+	r.brokersLock.RLock()
+	broker, ok := r.brokers[pending.brokerID]
+	r.brokersLock.RUnlock()
+	if !ok {
+		return errors.NewErrNotFound(fmt.Sprintf("Broker %s", pending.brokerID))
+	}
+
+	_, err = broker.client.TxAck(r.Component.GetContext(""), &pb_broker.TxAcknowledgment{
+		DevEui:    pending.downlink.DevEui,
+		AppEui:    pending.downlink.AppEui,
+		AppId:     pending.downlink.AppId,
+		DevId:     pending.downlink.DevId,
+		GatewayId: gatewayID,
+		Error:     ack.Error,
+		Trace:     downlink.Trace.WithEvent(trace.ForwardEvent, "broker", pending.brokerID),
+	})
+	if err != nil {
+		return errors.Wrap(errors.FromGRPCError(err), "Broker did not handle TxAcknowledgment")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"testing"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHandleTxAck(t *testing.T) {
+	a := New(t)
+
+	r := getTestRouter(t)
+
+	gtwID := "eui-0102030405060708"
+	gtw := r.getGateway(gtwID)
+	gtw.Schedule.Sync(0)
+
+	scheduleDownlink := func(timestamp uint32) string {
+		id, _ := gtw.Schedule.GetOption(timestamp, 10*1000)
+		err := r.HandleDownlink(&pb_broker.DownlinkMessage{
+			Payload: []byte{},
+			DownlinkOption: &pb_broker.DownlinkOption{
+				GatewayId:      gtwID,
+				Identifier:     id,
+				ProtocolConfig: &pb_protocol.TxConfiguration{},
+				GatewayConfig:  &pb_gateway.TxConfiguration{},
+			},
+		})
+		a.So(err, ShouldBeNil)
+		a.So(r.pendingDownlinks, ShouldContainKey, pendingDownlinkKey(gtwID, id))
+		return id
+	}
+
+	// Unknown downlink
+	err := r.HandleTxAck(gtwID, &pb.TxAcknowledgment{ScheduleId: "unknown"})
+	a.So(err, ShouldNotBeNil)
+
+	// Successful transmission
+	id := scheduleDownlink(1000000)
+	err = r.HandleTxAck(gtwID, &pb.TxAcknowledgment{ScheduleId: id})
+	a.So(err, ShouldBeNil)
+	a.So(r.pendingDownlinks, ShouldNotContainKey, pendingDownlinkKey(gtwID, id))
+
+	// Failed transmission of downlink that did not come from a Broker
+	id = scheduleDownlink(2000000)
+	err = r.HandleTxAck(gtwID, &pb.TxAcknowledgment{ScheduleId: id, Error: "TOO_LATE"})
+	a.So(err, ShouldBeNil)
+	a.So(r.pendingDownlinks, ShouldNotContainKey, pendingDownlinkKey(gtwID, id))
+
+	// Acknowledging twice is not possible
+	err = r.HandleTxAck(gtwID, &pb.TxAcknowledgment{ScheduleId: id})
+	a.So(err, ShouldNotBeNil)
+
+	// Pending downlinks expire
+	id = scheduleDownlink(3000000)
+	r.pendingDownlinks[pendingDownlinkKey(gtwID, id)].expires = r.pendingDownlinks[pendingDownlinkKey(gtwID, id)].expires.Add(-2 * TxAckTimeout)
+	r.expirePendingDownlinks()
+	a.So(r.pendingDownlinks, ShouldNotContainKey, pendingDownlinkKey(gtwID, id))
+}
@@ -0,0 +1,330 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/go-utils/pseudorandom"
+	"github.com/TheThingsNetwork/ttn/api/ratelimit"
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/router/gateway"
+	"github.com/TheThingsNetwork/ttn/core/router/semtech"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// UDPPullTimeout is the time after the last PULL_DATA of a gateway after which its downlink subscription is stopped
+var UDPPullTimeout = time.Minute
+
+// udpSubscriptionID is the ID of the downlink subscription of gateways that are connected over UDP
+const udpSubscriptionID = "udp"
+
+// udpMaxPacketSize is the maximum size of a UDP packet
+const udpMaxPacketSize = 65507
+
+type udpGateway struct {
+	addr     net.Addr
+	version  byte
+	lastPull time.Time
+	// scheduleIDs maps the tokens of PULL_RESP packets to the schedule IDs of their downlink messages
+	scheduleIDs map[[2]byte]udpScheduleID
+}
+
+type udpScheduleID struct {
+	id   string
+	sent time.Time
+}
+
+type udpBridge struct {
+	router *router
+	conn   net.PacketConn
+	ctx    ttnlog.Interface
+
+	allowed map[types.EUI64]bool
+
+	uplinkRate *ratelimit.Registry
+	statusRate *ratelimit.Registry
+
+	mu       sync.Mutex
+	gateways map[types.EUI64]*udpGateway
+}
+
+// ServeUDP accepts gateways that use the UDP protocol of the Semtech packet forwarder on the given connection. If
+// allowedEUIs is not empty, only the gateways with these EUIs are accepted. As the protocol has no authentication, the
+// EUI in a packet can be spoofed, so gateways that connect over UDP are never trusted, and packets for gateways that
+// are authenticated or connected over another protocol are refused. The downlink address of a gateway is the address of
+// its first PULL_DATA, and PULL_DATA and TX_ACK from other addresses are ignored until the gateway stops pulling for
+// UDPPullTimeout. ServeUDP blocks until reading from the connection fails.
+func (r *router) ServeUDP(conn net.PacketConn, allowedEUIs ...types.EUI64) error {
+	b := &udpBridge{
+		router:     r,
+		conn:       conn,
+		ctx:        r.Ctx.WithField("Bridge", "UDP"),
+		allowed:    make(map[types.EUI64]bool),
+		uplinkRate: ratelimit.NewRegistry(1500, time.Minute), // See RegisterRPC
+		statusRate: ratelimit.NewRegistry(10, time.Minute),
+		gateways:   make(map[types.EUI64]*udpGateway),
+	}
+	for _, eui := range allowedEUIs {
+		b.allowed[eui] = true
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(UDPPullTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				b.stopAll()
+				return
+			case <-ticker.C:
+				b.stopInactive()
+			}
+		}
+	}()
+
+	buf := make([]byte, udpMaxPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		data := make([]byte, n)
+		copy(data, buf[:n])
+		b.handlePacket(addr, data)
+	}
+}
+
+func (b *udpBridge) handlePacket(addr net.Addr, data []byte) {
+	ctx := b.ctx.WithField("Address", addr)
+
+	var packet semtech.Packet
+	if err := packet.UnmarshalBinary(data); err != nil {
+		ctx.WithError(err).Debug("Could not read packet")
+		return
+	}
+
+	gatewayID := semtech.GatewayID(packet.GatewayEUI)
+	ctx = ctx.WithField("GatewayID", gatewayID).WithField("PacketType", packet.Type)
+
+	if len(b.allowed) > 0 && !b.allowed[packet.GatewayEUI] {
+		ctx.Warn("Gateway not allowed")
+		return
+	}
+
+	// Packets for gateways that are authenticated or connected over another protocol are spoofed
+	gtw := b.router.getGateway(gatewayID)
+	if usesOtherProtocol(gtw) {
+		ctx.Warn("Gateway is connected over another protocol")
+		b.stop(packet.GatewayEUI)
+		return
+	}
+
+	switch packet.Type {
+	case semtech.PushData:
+		b.ack(addr, packet)
+		gtw.SetAuth("", false)
+		if packet.Payload == nil {
+			return
+		}
+		for _, rxpk := range packet.Payload.RXPK {
+			uplink, err := rxpk.UplinkMessage()
+			if err != nil {
+				ctx.WithError(err).Debug("Could not convert rxpk")
+				continue
+			}
+			if b.uplinkRate.Limit(gatewayID) {
+				ctx.Warn("Gateway reached uplink rate limit")
+				continue
+			}
+			go b.router.HandleUplink(gatewayID, uplink)
+		}
+		if packet.Payload.Stat != nil {
+			if b.statusRate.Limit(gatewayID) {
+				ctx.Warn("Gateway reached status rate limit")
+				return
+			}
+			go b.router.HandleGatewayStatus(gatewayID, packet.Payload.Stat.GatewayStatus())
+		}
+	case semtech.PullData:
+		if !b.pull(packet.GatewayEUI, addr, packet.Version) {
+			ctx.Warn("Gateway is already connected from another address")
+			return
+		}
+		b.ack(addr, packet)
+		gtw.SetAuth("", false)
+	case semtech.TxAck:
+		b.txAck(addr, packet)
+	default:
+		ctx.Debug("Unexpected packet")
+	}
+}
+
+// usesOtherProtocol returns true if the gateway is authenticated or subscribed to downlink over another protocol than
+// UDP. A gateway that was authenticated is only considered to use UDP again after the Router forgets it.
+func usesOtherProtocol(gtw *gateway.Gateway) bool {
+	if gtw.IsAuthenticated() {
+		return true
+	}
+	for _, subscriptionID := range gtw.Schedule.Subscriptions() {
+		if subscriptionID != udpSubscriptionID {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *udpBridge) ack(addr net.Addr, packet semtech.Packet) {
+	ack, err := packet.Ack()
+	if err != nil {
+		return
+	}
+	b.send(addr, ack)
+}
+
+func (b *udpBridge) send(addr net.Addr, packet *semtech.Packet) {
+	data, err := packet.MarshalBinary()
+	if err == nil {
+		_, err = b.conn.WriteTo(data, addr)
+	}
+	if err != nil {
+		b.ctx.WithField("Address", addr).WithField("PacketType", packet.Type).WithError(err).Warn("Could not send packet")
+	}
+}
+
+// pull subscribes to downlink if the gateway was not yet subscribed, and keeps the subscription alive. It returns false
+// if the gateway is subscribed from another address.
+func (b *udpBridge) pull(eui types.EUI64, addr net.Addr, version byte) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gtw, ok := b.gateways[eui]; ok {
+		if gtw.addr.String() != addr.String() {
+			return false
+		}
+		gtw.version, gtw.lastPull = version, time.Now()
+		return true
+	}
+
+	gatewayID := semtech.GatewayID(eui)
+	downlink, err := b.router.SubscribeDownlink(gatewayID, udpSubscriptionID)
+	if err != nil {
+		b.ctx.WithField("GatewayID", gatewayID).WithError(err).Warn("Could not subscribe to downlink")
+		return true
+	}
+	b.gateways[eui] = &udpGateway{addr: addr, version: version, lastPull: time.Now(), scheduleIDs: make(map[[2]byte]udpScheduleID)}
+	go func() {
+		for message := range downlink {
+			b.sendDownlink(eui, message)
+		}
+	}()
+	return true
+}
+
+func (b *udpBridge) sendDownlink(eui types.EUI64, downlink *pb.DownlinkMessage) {
+	gatewayID := semtech.GatewayID(eui)
+	ctx := b.ctx.WithField("GatewayID", gatewayID)
+
+	txpk, err := semtech.NewTXPK(downlink)
+	if err != nil {
+		ctx.WithError(err).Warn("Could not convert downlink")
+		return
+	}
+
+	b.mu.Lock()
+	gtw, ok := b.gateways[eui]
+	if !ok {
+		b.mu.Unlock()
+		ctx.Warn("Could not send downlink (gateway not connected)")
+		return
+	}
+	packet := &semtech.Packet{
+		Version: gtw.version,
+		Type:    semtech.PullResp,
+		Payload: &semtech.Payload{TXPK: txpk},
+	}
+	if gtw.version != semtech.Version1 {
+		pseudorandom.FillBytes(packet.Token[:])
+		gtw.scheduleIDs[packet.Token] = udpScheduleID{id: downlink.ScheduleId, sent: time.Now()}
+	}
+	addr := gtw.addr
+	b.mu.Unlock()
+
+	b.send(addr, packet)
+
+	// Gateways that use the first version of the protocol do not send TX_ACK
+	if packet.Version == semtech.Version1 && downlink.ScheduleId != "" {
+		go b.router.HandleTxAck(gatewayID, &pb.TxAcknowledgment{ScheduleId: downlink.ScheduleId})
+	}
+}
+
+// txAck handles the TX_ACK of the gateway for the PULL_RESP with the same token
+func (b *udpBridge) txAck(addr net.Addr, packet semtech.Packet) {
+	gatewayID := semtech.GatewayID(packet.GatewayEUI)
+	ctx := b.ctx.WithField("GatewayID", gatewayID)
+
+	b.mu.Lock()
+	var scheduleID udpScheduleID
+	gtw, ok := b.gateways[packet.GatewayEUI]
+	if ok && gtw.addr.String() != addr.String() {
+		ok = false
+	}
+	if ok {
+		scheduleID, ok = gtw.scheduleIDs[packet.Token]
+		delete(gtw.scheduleIDs, packet.Token)
+	}
+	b.mu.Unlock()
+	if !ok || scheduleID.id == "" {
+		ctx.Debug("Received TX_ACK for unknown downlink")
+		return
+	}
+
+	ack := &pb.TxAcknowledgment{ScheduleId: scheduleID.id}
+	if packet.Payload != nil && packet.Payload.TXPKAck != nil && packet.Payload.TXPKAck.Error != semtech.TXPKAckNone {
+		ack.Error = packet.Payload.TXPKAck.Error
+	}
+	go b.router.HandleTxAck(gatewayID, ack)
+}
+
+// stopInactive stops the downlink subscriptions of gateways that did not send PULL_DATA recently and forgets about
+// downlink messages that were not acknowledged
+func (b *udpBridge) stopInactive() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for eui, gtw := range b.gateways {
+		if time.Since(gtw.lastPull) > UDPPullTimeout {
+			b.router.UnsubscribeDownlink(semtech.GatewayID(eui), udpSubscriptionID)
+			delete(b.gateways, eui)
+			continue
+		}
+		for token, scheduleID := range gtw.scheduleIDs {
+			if time.Since(scheduleID.sent) > TxAckTimeout {
+				delete(gtw.scheduleIDs, token)
+			}
+		}
+	}
+}
+
+// stop stops the downlink subscription of the gateway, if it is subscribed over UDP
+func (b *udpBridge) stop(eui types.EUI64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.gateways[eui]; ok {
+		b.router.UnsubscribeDownlink(semtech.GatewayID(eui), udpSubscriptionID)
+		delete(b.gateways, eui)
+	}
+}
+
+func (b *udpBridge) stopAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for eui := range b.gateways {
+		b.router.UnsubscribeDownlink(semtech.GatewayID(eui), udpSubscriptionID)
+		delete(b.gateways, eui)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/router/semtech"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestServeUDP(t *testing.T) {
+	a := New(t)
+
+	r := getTestRouter(t)
+	r.Component.Identity = &pb_discovery.Announcement{}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	a.So(err, ShouldBeNil)
+	defer conn.Close()
+
+	allowedEUI := types.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	go r.ServeUDP(conn, allowedEUI)
+
+	// The gateway is a local UDP stand-in
+	gtw, err := net.ListenPacket("udp", "127.0.0.1:0")
+	a.So(err, ShouldBeNil)
+	defer gtw.Close()
+
+	send := func(packet semtech.Packet) {
+		data, err := packet.MarshalBinary()
+		a.So(err, ShouldBeNil)
+		_, err = gtw.WriteTo(data, conn.LocalAddr())
+		a.So(err, ShouldBeNil)
+	}
+	receive := func(timeout time.Duration) (*semtech.Packet, error) {
+		buf := make([]byte, 65507)
+		gtw.SetReadDeadline(time.Now().Add(timeout))
+		n, _, err := gtw.ReadFrom(buf)
+		if err != nil {
+			return nil, err
+		}
+		packet := new(semtech.Packet)
+		return packet, packet.UnmarshalBinary(buf[:n])
+	}
+
+	// Gateways that are not allowed do not get an acknowledgement
+	send(semtech.Packet{Version: semtech.Version2, Token: [2]byte{0, 1}, Type: semtech.PullData, GatewayEUI: types.EUI64{8, 7, 6, 5, 4, 3, 2, 1}})
+	_, err = receive(100 * time.Millisecond)
+	a.So(err, ShouldNotBeNil)
+
+	// PULL_DATA
+	send(semtech.Packet{Version: semtech.Version2, Token: [2]byte{0, 2}, Type: semtech.PullData, GatewayEUI: allowedEUI})
+	ack, err := receive(time.Second)
+	a.So(err, ShouldBeNil)
+	a.So(ack.Type, ShouldEqual, semtech.PullAck)
+	a.So(ack.Token, ShouldEqual, [2]byte{0, 2})
+
+	// PULL_DATA with the same EUI from another address does not take over the downlink
+	spoofed, err := net.ListenPacket("udp", "127.0.0.1:0")
+	a.So(err, ShouldBeNil)
+	defer spoofed.Close()
+	data, err := (&semtech.Packet{Version: semtech.Version2, Token: [2]byte{0, 9}, Type: semtech.PullData, GatewayEUI: allowedEUI}).MarshalBinary()
+	a.So(err, ShouldBeNil)
+	_, err = spoofed.WriteTo(data, conn.LocalAddr())
+	a.So(err, ShouldBeNil)
+	spoofed.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err = spoofed.ReadFrom(make([]byte, 65507))
+	a.So(err, ShouldNotBeNil)
+
+	// PUSH_DATA with status
+	send(semtech.Packet{Version: semtech.Version2, Token: [2]byte{0, 3}, Type: semtech.PushData, GatewayEUI: allowedEUI, Payload: &semtech.Payload{
+		Stat: &semtech.Stat{Time: semtech.ExpandedTime(time.Now()), Desc: "UDP Gateway"},
+	}})
+	ack, err = receive(time.Second)
+	a.So(err, ShouldBeNil)
+	a.So(ack.Type, ShouldEqual, semtech.PushAck)
+	a.So(ack.Token, ShouldEqual, [2]byte{0, 3})
+
+	time.Sleep(50 * time.Millisecond)
+	gateway := r.getGateway("eui-0102030405060708")
+	status, err := gateway.Status.Get()
+	a.So(err, ShouldBeNil)
+	a.So(status.Description, ShouldEqual, "UDP Gateway")
+	a.So(status.GatewayTrusted, ShouldBeFalse)
+
+	// Downlink is sent as PULL_RESP
+	gateway.Schedule.Sync(0)
+	id, _ := gateway.Schedule.GetOption(1000000, 50)
+	err = gateway.Schedule.Schedule(id, &pb.DownlinkMessage{
+		Payload: []byte{1, 2, 3},
+		ProtocolConfiguration: &pb_protocol.TxConfiguration{Protocol: &pb_protocol.TxConfiguration_Lorawan{Lorawan: &pb_lorawan.TxConfiguration{
+			Modulation: pb_lorawan.Modulation_LORA,
+			DataRate:   "SF7BW125",
+			CodingRate: "4/5",
+		}}},
+		GatewayConfiguration: &pb_gateway.TxConfiguration{
+			Timestamp: 1000000,
+			Frequency: 868100000,
+			Power:     14,
+		},
+	})
+	a.So(err, ShouldBeNil)
+	resp, err := receive(2 * time.Second)
+	a.So(err, ShouldBeNil)
+	a.So(resp.Type, ShouldEqual, semtech.PullResp)
+	a.So(resp.Payload.TXPK.Tmst, ShouldEqual, 1000000)
+	a.So(resp.Payload.TXPK.Freq, ShouldEqual, 868.1)
+	a.So(resp.Payload.TXPK.Data, ShouldEqual, "AQID")
+
+	// TX_ACK with an error frees the slot of the downlink
+	send(semtech.Packet{Version: semtech.Version2, Token: resp.Token, Type: semtech.TxAck, GatewayEUI: allowedEUI, Payload: &semtech.Payload{
+		TXPKAck: &semtech.TXPKAck{Error: "TOO_LATE"},
+	}})
+	time.Sleep(50 * time.Millisecond)
+	_, err = gateway.Schedule.TxAck(id, true)
+	a.So(err, ShouldNotBeNil)
+
+	// Packets are refused when the gateway is connected over another protocol, and the UDP downlink is stopped
+	_, err = r.SubscribeDownlink("eui-0102030405060708", "other")
+	a.So(err, ShouldBeNil)
+	send(semtech.Packet{Version: semtech.Version2, Token: [2]byte{0, 4}, Type: semtech.PullData, GatewayEUI: allowedEUI})
+	_, err = receive(100 * time.Millisecond)
+	a.So(err, ShouldNotBeNil)
+	time.Sleep(50 * time.Millisecond)
+	a.So(gateway.Schedule.Subscriptions(), ShouldResemble, []string{"other"})
+	r.UnsubscribeDownlink("eui-0102030405060708", "other")
+
+	// Packets are refused when the gateway is authenticated
+	gateway.SetAuth("token", true)
+	send(semtech.Packet{Version: semtech.Version2, Token: [2]byte{0, 5}, Type: semtech.PushData, GatewayEUI: allowedEUI})
+	_, err = receive(100 * time.Millisecond)
+	a.So(err, ShouldNotBeNil)
+	a.So(gateway.IsAuthenticated(), ShouldBeTrue)
+}
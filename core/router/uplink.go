@@ -35,6 +35,32 @@ func (r *router) HandleUplink(gatewayID string, uplink *pb.UplinkMessage) (err e
 
 	uplink.Trace = uplink.Trace.WithEvent(trace.ReceiveEvent, "gateway", gatewayID)
 
+	// LoRaWAN 1.1: RejoinRequests can not be unmarshaled by the lorawan package
+	if pb_lorawan.IsRejoinRequest(uplink.Payload) {
+		var rejoin pb_lorawan.RejoinRequest
+		if err = rejoin.UnmarshalBinary(uplink.Payload); err != nil {
+			return err
+		}
+		devEUI := rejoin.DevEUI
+		var appEUI *types.AppEUI // Only RejoinRequests of type 1 contain the JoinEUI (AppEUI)
+		if rejoin.RejoinType == 1 {
+			appEUI = &rejoin.JoinEUI
+		}
+		ctx.WithFields(ttnlog.Fields{
+			"DevEUI":     devEUI,
+			"RejoinType": rejoin.RejoinType,
+		}).Debug("Handle Uplink as Rejoin")
+		r.HandleActivation(gatewayID, &pb.DeviceActivationRequest{
+			Payload:          uplink.Payload,
+			DevEui:           &devEUI,
+			AppEui:           appEUI,
+			ProtocolMetadata: uplink.ProtocolMetadata,
+			GatewayMetadata:  uplink.GatewayMetadata,
+			Trace:            uplink.Trace.WithEvent("handle uplink as rejoin"),
+		})
+		return nil
+	}
+
 	// LoRaWAN: Unmarshal
 	var phyPayload lorawan.PHYPayload
 	err = phyPayload.UnmarshalBinary(uplink.Payload)
@@ -266,3 +266,27 @@ func (s *RedisMapStore) Update(key string, value interface{}, properties ...stri
 	}
 	return nil
 }
+
+// incrByScript increments a property of a hash only if the hash exists
+const incrByScript = `if redis.call("EXISTS", KEYS[1]) == 0 then return nil end
+return redis.call("HINCRBY", KEYS[1], ARGV[1], ARGV[2])`
+
+// IncrBy atomically increments an integer property of an existing record, prepending the prefix to the key if necessary
+// This function returns the value after the increment, or an error if the record does not exist
+func (s *RedisMapStore) IncrBy(key string, property string, incr int64) (int64, error) {
+	if !strings.HasPrefix(key, s.prefix) {
+		key = s.prefix + key
+	}
+	res, err := s.client.Eval(incrByScript, []string{key}, property, incr).Result()
+	if err == redis.Nil {
+		return 0, errors.NewErrNotFound(key)
+	}
+	if err != nil {
+		return 0, err
+	}
+	value, ok := res.(int64)
+	if !ok {
+		return 0, errors.NewErrInternal("Redis did not return an integer")
+	}
+	return value, nil
+}
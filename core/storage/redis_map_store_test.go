@@ -192,6 +192,21 @@ func TestRedisMapStore(t *testing.T) {
 		a.So(name, ShouldEqual, "New Name")
 	}
 
+	// IncrBy
+	{
+		_, err := s.IncrBy("not-there", "counter", 1)
+		a.So(err, ShouldNotBeNil)
+		a.So(errors.GetErrType(err), ShouldEqual, errors.NotFound)
+
+		res, err := s.IncrBy("test", "counter", 1)
+		a.So(err, ShouldBeNil)
+		a.So(res, ShouldEqual, 1)
+
+		res, err = s.IncrBy("test", "counter", 2)
+		a.So(err, ShouldBeNil)
+		a.So(res, ShouldEqual, 3)
+	}
+
 	// Delete
 	{
 		err := s.Delete("test")
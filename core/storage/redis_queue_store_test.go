@@ -86,6 +86,18 @@ func TestRedisQueueStore(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(res, ShouldResemble, []string{"value1", "value3"})
 
+	removed, err := s.Remove("test", "value1")
+	a.So(err, ShouldBeNil)
+	a.So(removed, ShouldBeTrue)
+
+	removed, err = s.Remove("test", "value1")
+	a.So(err, ShouldBeNil)
+	a.So(removed, ShouldBeFalse)
+
+	res, err = s.Get("test")
+	a.So(err, ShouldBeNil)
+	a.So(res, ShouldResemble, []string{"value3"})
+
 	err = s.Delete("test")
 	a.So(err, ShouldBeNil)
 
@@ -108,6 +108,18 @@ func (s *RedisSetStore) Contains(key string, value string) (res bool, err error)
 	return res, err
 }
 
+// Size returns the number of values in the set, prepending the prefix to the key if necessary
+func (s *RedisSetStore) Size(key string) (int, error) {
+	if !strings.HasPrefix(key, s.prefix) {
+		key = s.prefix + key
+	}
+	size, err := s.client.SCard(key).Result()
+	if err != nil && err != redis.Nil {
+		return 0, err
+	}
+	return int(size), nil
+}
+
 // Add one or more values to the set, prepending the prefix to the key if necessary
 func (s *RedisSetStore) Add(key string, values ...string) error {
 	if !strings.HasPrefix(key, s.prefix) {
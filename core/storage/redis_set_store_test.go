@@ -26,6 +26,10 @@ func TestRedisSetStore(t *testing.T) {
 		contains, err := s.Contains("test", "value")
 		a.So(err, ShouldBeNil)
 		a.So(contains, ShouldBeFalse)
+
+		size, err := s.Size("test")
+		a.So(err, ShouldBeNil)
+		a.So(size, ShouldEqual, 0)
 	}
 
 	defer func() {
@@ -60,6 +64,10 @@ func TestRedisSetStore(t *testing.T) {
 		res, err := s.Get("test")
 		a.So(err, ShouldBeNil)
 		a.So(res, ShouldHaveLength, 2)
+
+		size, err := s.Size("test")
+		a.So(err, ShouldBeNil)
+		a.So(size, ShouldEqual, 2)
 	}
 
 	// Remove
@@ -3,6 +3,12 @@
 
 package types
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 // ScheduleType can be "replace" (default), "first", "last"
 type ScheduleType string
 
@@ -17,9 +23,35 @@ const (
 type DownlinkMessage struct {
 	AppID         string                 `json:"app_id,omitempty"`
 	DevID         string                 `json:"dev_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"` // generated by the handler if empty
 	FPort         uint8                  `json:"port"`
 	Confirmed     bool                   `json:"confirmed,omitempty"`
 	Schedule      ScheduleType           `json:"schedule,omitempty"` // allowed values: "replace" (default), "first", "last"
 	PayloadRaw    []byte                 `json:"payload_raw,omitempty"`
 	PayloadFields map[string]interface{} `json:"payload_fields,omitempty"`
+	TTL           string                 `json:"ttl,omitempty"`     // duration after scheduling that the message expires, for example "10m"
+	Expires       *JSONTime              `json:"expires,omitempty"` // time at which the message expires
+}
+
+// SetExpiry sets the expiry time of the message based on its TTL. The TTL is cleared.
+func (m *DownlinkMessage) SetExpiry(now time.Time) error {
+	if m.TTL == "" {
+		return nil
+	}
+	ttl, err := time.ParseDuration(m.TTL)
+	if err != nil || ttl <= 0 {
+		return errors.New(fmt.Sprintf("ttn/core: Invalid TTL %s", m.TTL))
+	}
+	expires := JSONTime(now.Add(ttl))
+	m.Expires = &expires
+	m.TTL = ""
+	return nil
+}
+
+// Expired returns true if the message has an expiry time that is not after now
+func (m *DownlinkMessage) Expired(now time.Time) bool {
+	if m.Expires == nil || time.Time(*m.Expires).IsZero() {
+		return false
+	}
+	return !now.Before(time.Time(*m.Expires))
 }
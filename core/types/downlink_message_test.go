@@ -0,0 +1,35 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestDownlinkMessageExpiry(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+
+	msg := &DownlinkMessage{}
+	a.So(msg.SetExpiry(now), ShouldBeNil)
+	a.So(msg.Expires, ShouldBeNil)
+	a.So(msg.Expired(now.Add(time.Hour)), ShouldBeFalse)
+
+	msg.TTL = "10m"
+	a.So(msg.SetExpiry(now), ShouldBeNil)
+	a.So(msg.TTL, ShouldBeEmpty)
+	a.So(msg.Expires, ShouldNotBeNil)
+	a.So(msg.Expired(now), ShouldBeFalse)
+	a.So(msg.Expired(now.Add(10*time.Minute)), ShouldBeTrue)
+
+	msg.TTL = "-1s"
+	a.So(msg.SetExpiry(now), ShouldNotBeNil)
+
+	msg.TTL = "tomorrow"
+	a.So(msg.SetExpiry(now), ShouldNotBeNil)
+}
@@ -14,6 +14,8 @@ const (
 	DownlinkSentEvent      EventType = "down/sent"
 	DownlinkErrorEvent     EventType = "down/errors"
 	DownlinkAckEvent       EventType = "down/acks"
+	DownlinkNackEvent      EventType = "down/nack"
+	DownlinkExpiredEvent   EventType = "down/expired"
 
 	ActivationEvent      EventType = "activations"
 	ActivationErrorEvent EventType = "activations/errors"
@@ -25,10 +27,10 @@ const (
 
 // DeviceEvent represents an application-layer event message for a device event
 type DeviceEvent struct {
-	AppID string
-	DevID string
-	Event EventType
-	Data  interface{}
+	AppID string      `json:"app_id,omitempty"`
+	DevID string      `json:"dev_id,omitempty"`
+	Event EventType   `json:"event"`
+	Data  interface{} `json:"data,omitempty"`
 }
 
 // ErrorEventData is added to error events
@@ -58,8 +60,10 @@ type DownlinkEventConfigInfo struct {
 // DownlinkEventData is added to downlink events
 type DownlinkEventData struct {
 	ErrorEventData
-	Payload   []byte                  `json:"payload,omitempty"`
-	Message   *DownlinkMessage        `json:"message,omitempty"`
-	GatewayID string                  `json:"gateway_id,omitempty"`
-	Config    DownlinkEventConfigInfo `json:"config,omitempty"`
+	CorrelationID string                  `json:"correlation_id,omitempty"`
+	Payload       []byte                  `json:"payload,omitempty"`
+	Message       *DownlinkMessage        `json:"message,omitempty"`
+	GatewayID     string                  `json:"gateway_id,omitempty"`
+	Config        DownlinkEventConfigInfo `json:"config,omitempty"`
+	Retries       int                     `json:"retries,omitempty"` // number of times that a confirmed message was sent again
 }
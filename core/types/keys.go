@@ -16,8 +16,18 @@ type AES128Key [16]byte
 type AppKey AES128Key
 
 // NwkSKey (Network Session Key) is used for LoRaWAN MIC calculation.
+// For LoRaWAN 1.1 devices, this is the FNwkSIntKey (Forwarding Network Session Integrity Key).
 type NwkSKey AES128Key
 
+// NwkKey (Network Key) is used for LoRaWAN 1.1 OTAA.
+type NwkKey AES128Key
+
+// SNwkSIntKey (Serving Network Session Integrity Key) is used for LoRaWAN 1.1 MIC calculation.
+type SNwkSIntKey AES128Key
+
+// NwkSEncKey (Network Session Encryption Key) is used for LoRaWAN 1.1 MAC command encryption.
+type NwkSEncKey AES128Key
+
 // AppSKey (Application Session Key) is used for LoRaWAN payload encryption.
 type AppSKey AES128Key
 
@@ -335,6 +345,243 @@ func (key *NwkSKey) Unmarshal(data []byte) error {
 	return key.UnmarshalBinary(data)
 }
 
+// ParseNwkKey parses a 64-bit hex-encoded string to an NwkKey
+func ParseNwkKey(input string) (key NwkKey, err error) {
+	aes128key, err := ParseAES128Key(input)
+	if err != nil {
+		return
+	}
+	key = NwkKey(aes128key)
+	return
+}
+
+// Bytes returns the NwkKey as a byte slice
+func (key NwkKey) Bytes() []byte {
+	return AES128Key(key).Bytes()
+}
+
+// String implements the Stringer interface.
+func (key NwkKey) String() string {
+	return AES128Key(key).String()
+}
+
+// GoString implements the GoStringer interface.
+func (key NwkKey) GoString() string {
+	return key.String()
+}
+
+// MarshalText implements the TextMarshaler interface.
+func (key NwkKey) MarshalText() ([]byte, error) {
+	return AES128Key(key).MarshalText()
+}
+
+// UnmarshalText implements the TextUnmarshaler interface.
+func (key *NwkKey) UnmarshalText(data []byte) error {
+	e := AES128Key(*key)
+	err := e.UnmarshalText(data)
+	if err != nil {
+		return err
+	}
+	*key = NwkKey(e)
+	return nil
+}
+
+// MarshalBinary implements the BinaryMarshaler interface.
+func (key NwkKey) MarshalBinary() ([]byte, error) {
+	return AES128Key(key).MarshalBinary()
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface.
+func (key *NwkKey) UnmarshalBinary(data []byte) error {
+	e := AES128Key(*key)
+	err := e.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	*key = NwkKey(e)
+	return nil
+}
+
+// MarshalTo is used by Protobuf
+func (key *NwkKey) MarshalTo(b []byte) (int, error) {
+	copy(b, key.Bytes())
+	return 16, nil
+}
+
+// Size is used by Protobuf
+func (key *NwkKey) Size() int {
+	return 16
+}
+
+// Marshal implements the Marshaler interface.
+func (key NwkKey) Marshal() ([]byte, error) {
+	return key.MarshalBinary()
+}
+
+// Unmarshal implements the Unmarshaler interface.
+func (key *NwkKey) Unmarshal(data []byte) error {
+	*key = [16]byte{} // Reset the receiver
+	return key.UnmarshalBinary(data)
+}
+
+// ParseSNwkSIntKey parses a 64-bit hex-encoded string to an SNwkSIntKey
+func ParseSNwkSIntKey(input string) (key SNwkSIntKey, err error) {
+	aes128key, err := ParseAES128Key(input)
+	if err != nil {
+		return
+	}
+	key = SNwkSIntKey(aes128key)
+	return
+}
+
+// Bytes returns the SNwkSIntKey as a byte slice
+func (key SNwkSIntKey) Bytes() []byte {
+	return AES128Key(key).Bytes()
+}
+
+// String implements the Stringer interface.
+func (key SNwkSIntKey) String() string {
+	return AES128Key(key).String()
+}
+
+// GoString implements the GoStringer interface.
+func (key SNwkSIntKey) GoString() string {
+	return key.String()
+}
+
+// MarshalText implements the TextMarshaler interface.
+func (key SNwkSIntKey) MarshalText() ([]byte, error) {
+	return AES128Key(key).MarshalText()
+}
+
+// UnmarshalText implements the TextUnmarshaler interface.
+func (key *SNwkSIntKey) UnmarshalText(data []byte) error {
+	e := AES128Key(*key)
+	err := e.UnmarshalText(data)
+	if err != nil {
+		return err
+	}
+	*key = SNwkSIntKey(e)
+	return nil
+}
+
+// MarshalBinary implements the BinaryMarshaler interface.
+func (key SNwkSIntKey) MarshalBinary() ([]byte, error) {
+	return AES128Key(key).MarshalBinary()
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface.
+func (key *SNwkSIntKey) UnmarshalBinary(data []byte) error {
+	e := AES128Key(*key)
+	err := e.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	*key = SNwkSIntKey(e)
+	return nil
+}
+
+// MarshalTo is used by Protobuf
+func (key *SNwkSIntKey) MarshalTo(b []byte) (int, error) {
+	copy(b, key.Bytes())
+	return 16, nil
+}
+
+// Size is used by Protobuf
+func (key *SNwkSIntKey) Size() int {
+	return 16
+}
+
+// Marshal implements the Marshaler interface.
+func (key SNwkSIntKey) Marshal() ([]byte, error) {
+	return key.MarshalBinary()
+}
+
+// Unmarshal implements the Unmarshaler interface.
+func (key *SNwkSIntKey) Unmarshal(data []byte) error {
+	*key = [16]byte{} // Reset the receiver
+	return key.UnmarshalBinary(data)
+}
+
+// ParseNwkSEncKey parses a 64-bit hex-encoded string to an NwkSEncKey
+func ParseNwkSEncKey(input string) (key NwkSEncKey, err error) {
+	aes128key, err := ParseAES128Key(input)
+	if err != nil {
+		return
+	}
+	key = NwkSEncKey(aes128key)
+	return
+}
+
+// Bytes returns the NwkSEncKey as a byte slice
+func (key NwkSEncKey) Bytes() []byte {
+	return AES128Key(key).Bytes()
+}
+
+// String implements the Stringer interface.
+func (key NwkSEncKey) String() string {
+	return AES128Key(key).String()
+}
+
+// GoString implements the GoStringer interface.
+func (key NwkSEncKey) GoString() string {
+	return key.String()
+}
+
+// MarshalText implements the TextMarshaler interface.
+func (key NwkSEncKey) MarshalText() ([]byte, error) {
+	return AES128Key(key).MarshalText()
+}
+
+// UnmarshalText implements the TextUnmarshaler interface.
+func (key *NwkSEncKey) UnmarshalText(data []byte) error {
+	e := AES128Key(*key)
+	err := e.UnmarshalText(data)
+	if err != nil {
+		return err
+	}
+	*key = NwkSEncKey(e)
+	return nil
+}
+
+// MarshalBinary implements the BinaryMarshaler interface.
+func (key NwkSEncKey) MarshalBinary() ([]byte, error) {
+	return AES128Key(key).MarshalBinary()
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface.
+func (key *NwkSEncKey) UnmarshalBinary(data []byte) error {
+	e := AES128Key(*key)
+	err := e.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	*key = NwkSEncKey(e)
+	return nil
+}
+
+// MarshalTo is used by Protobuf
+func (key *NwkSEncKey) MarshalTo(b []byte) (int, error) {
+	copy(b, key.Bytes())
+	return 16, nil
+}
+
+// Size is used by Protobuf
+func (key *NwkSEncKey) Size() int {
+	return 16
+}
+
+// Marshal implements the Marshaler interface.
+func (key NwkSEncKey) Marshal() ([]byte, error) {
+	return key.MarshalBinary()
+}
+
+// Unmarshal implements the Unmarshaler interface.
+func (key *NwkSEncKey) Unmarshal(data []byte) error {
+	*key = [16]byte{} // Reset the receiver
+	return key.UnmarshalBinary(data)
+}
+
 var emptyAES AES128Key
 
 func (key AES128Key) IsEmpty() bool {
@@ -352,3 +599,15 @@ func (key AppSKey) IsEmpty() bool {
 func (key NwkSKey) IsEmpty() bool {
 	return AES128Key(key).IsEmpty()
 }
+
+func (key NwkKey) IsEmpty() bool {
+	return AES128Key(key).IsEmpty()
+}
+
+func (key SNwkSIntKey) IsEmpty() bool {
+	return AES128Key(key).IsEmpty()
+}
+
+func (key NwkSEncKey) IsEmpty() bool {
+	return AES128Key(key).IsEmpty()
+}
@@ -213,6 +213,210 @@ func TestNwkSKey(t *testing.T) {
 	a.So(key.IsEmpty(), ShouldBeFalse)
 }
 
+func TestNwkKey(t *testing.T) {
+	a := New(t)
+
+	// Setup
+	key := NwkKey{1, 2, 3, 4, 5, 6, 7, 8, 249, 250, 251, 252, 253, 254, 255, 0}
+	str := "0102030405060708F9FAFBFCFDFEFF00"
+	bin := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0xf9, 0xfa, 0xfb, 0xfc, 0xfd, 0xfe, 0xff, 0x00}
+
+	// Bytes
+	a.So(key.Bytes(), ShouldResemble, bin)
+
+	// String
+	a.So(key.String(), ShouldEqual, str)
+
+	// MarshalText
+	mtOut, err := key.MarshalText()
+	a.So(err, ShouldBeNil)
+	a.So(mtOut, ShouldResemble, []byte(str))
+
+	// MarshalBinary
+	mbOut, err := key.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(mbOut, ShouldResemble, bin)
+
+	// Marshal
+	mOut, err := key.Marshal()
+	a.So(err, ShouldBeNil)
+	a.So(mOut, ShouldResemble, bin)
+
+	// MarshalTo
+	bOut := make([]byte, 16)
+	_, err = key.MarshalTo(bOut)
+	a.So(err, ShouldBeNil)
+	a.So(bOut, ShouldResemble, bin)
+
+	// Size
+	s := key.Size()
+	a.So(s, ShouldEqual, 16)
+
+	// Parse
+	pOut, err := ParseNwkKey(str)
+	a.So(err, ShouldBeNil)
+	a.So(pOut, ShouldEqual, key)
+
+	// UnmarshalText
+	utOut := &NwkKey{}
+	err = utOut.UnmarshalText([]byte(str))
+	a.So(err, ShouldBeNil)
+	a.So(*utOut, ShouldEqual, key)
+
+	// UnmarshalBinary
+	ubOut := &NwkKey{}
+	err = ubOut.UnmarshalBinary(bin)
+	a.So(err, ShouldBeNil)
+	a.So(*ubOut, ShouldEqual, key)
+
+	// Unmarshal
+	uOut := &NwkKey{}
+	err = uOut.Unmarshal(bin)
+	a.So(err, ShouldBeNil)
+	a.So(*uOut, ShouldEqual, key)
+
+	// IsEmpty
+	var empty NwkKey
+	a.So(empty.IsEmpty(), ShouldBeTrue)
+	a.So(key.IsEmpty(), ShouldBeFalse)
+}
+
+func TestSNwkSIntKey(t *testing.T) {
+	a := New(t)
+
+	// Setup
+	key := SNwkSIntKey{1, 2, 3, 4, 5, 6, 7, 8, 249, 250, 251, 252, 253, 254, 255, 0}
+	str := "0102030405060708F9FAFBFCFDFEFF00"
+	bin := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0xf9, 0xfa, 0xfb, 0xfc, 0xfd, 0xfe, 0xff, 0x00}
+
+	// Bytes
+	a.So(key.Bytes(), ShouldResemble, bin)
+
+	// String
+	a.So(key.String(), ShouldEqual, str)
+
+	// MarshalText
+	mtOut, err := key.MarshalText()
+	a.So(err, ShouldBeNil)
+	a.So(mtOut, ShouldResemble, []byte(str))
+
+	// MarshalBinary
+	mbOut, err := key.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(mbOut, ShouldResemble, bin)
+
+	// Marshal
+	mOut, err := key.Marshal()
+	a.So(err, ShouldBeNil)
+	a.So(mOut, ShouldResemble, bin)
+
+	// MarshalTo
+	bOut := make([]byte, 16)
+	_, err = key.MarshalTo(bOut)
+	a.So(err, ShouldBeNil)
+	a.So(bOut, ShouldResemble, bin)
+
+	// Size
+	s := key.Size()
+	a.So(s, ShouldEqual, 16)
+
+	// Parse
+	pOut, err := ParseSNwkSIntKey(str)
+	a.So(err, ShouldBeNil)
+	a.So(pOut, ShouldEqual, key)
+
+	// UnmarshalText
+	utOut := &SNwkSIntKey{}
+	err = utOut.UnmarshalText([]byte(str))
+	a.So(err, ShouldBeNil)
+	a.So(*utOut, ShouldEqual, key)
+
+	// UnmarshalBinary
+	ubOut := &SNwkSIntKey{}
+	err = ubOut.UnmarshalBinary(bin)
+	a.So(err, ShouldBeNil)
+	a.So(*ubOut, ShouldEqual, key)
+
+	// Unmarshal
+	uOut := &SNwkSIntKey{}
+	err = uOut.Unmarshal(bin)
+	a.So(err, ShouldBeNil)
+	a.So(*uOut, ShouldEqual, key)
+
+	// IsEmpty
+	var empty SNwkSIntKey
+	a.So(empty.IsEmpty(), ShouldBeTrue)
+	a.So(key.IsEmpty(), ShouldBeFalse)
+}
+
+func TestNwkSEncKey(t *testing.T) {
+	a := New(t)
+
+	// Setup
+	key := NwkSEncKey{1, 2, 3, 4, 5, 6, 7, 8, 249, 250, 251, 252, 253, 254, 255, 0}
+	str := "0102030405060708F9FAFBFCFDFEFF00"
+	bin := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0xf9, 0xfa, 0xfb, 0xfc, 0xfd, 0xfe, 0xff, 0x00}
+
+	// Bytes
+	a.So(key.Bytes(), ShouldResemble, bin)
+
+	// String
+	a.So(key.String(), ShouldEqual, str)
+
+	// MarshalText
+	mtOut, err := key.MarshalText()
+	a.So(err, ShouldBeNil)
+	a.So(mtOut, ShouldResemble, []byte(str))
+
+	// MarshalBinary
+	mbOut, err := key.MarshalBinary()
+	a.So(err, ShouldBeNil)
+	a.So(mbOut, ShouldResemble, bin)
+
+	// Marshal
+	mOut, err := key.Marshal()
+	a.So(err, ShouldBeNil)
+	a.So(mOut, ShouldResemble, bin)
+
+	// MarshalTo
+	bOut := make([]byte, 16)
+	_, err = key.MarshalTo(bOut)
+	a.So(err, ShouldBeNil)
+	a.So(bOut, ShouldResemble, bin)
+
+	// Size
+	s := key.Size()
+	a.So(s, ShouldEqual, 16)
+
+	// Parse
+	pOut, err := ParseNwkSEncKey(str)
+	a.So(err, ShouldBeNil)
+	a.So(pOut, ShouldEqual, key)
+
+	// UnmarshalText
+	utOut := &NwkSEncKey{}
+	err = utOut.UnmarshalText([]byte(str))
+	a.So(err, ShouldBeNil)
+	a.So(*utOut, ShouldEqual, key)
+
+	// UnmarshalBinary
+	ubOut := &NwkSEncKey{}
+	err = ubOut.UnmarshalBinary(bin)
+	a.So(err, ShouldBeNil)
+	a.So(*ubOut, ShouldEqual, key)
+
+	// Unmarshal
+	uOut := &NwkSEncKey{}
+	err = uOut.Unmarshal(bin)
+	a.So(err, ShouldBeNil)
+	a.So(*uOut, ShouldEqual, key)
+
+	// IsEmpty
+	var empty NwkSEncKey
+	a.So(empty.IsEmpty(), ShouldBeTrue)
+	a.So(key.IsEmpty(), ShouldBeFalse)
+}
+
 func TestAppSKey(t *testing.T) {
 	a := New(t)
 
@@ -15,4 +15,5 @@ type UplinkMessage struct {
 	PayloadRaw     []byte                 `json:"payload_raw"`
 	PayloadFields  map[string]interface{} `json:"payload_fields,omitempty"`
 	Metadata       Metadata               `json:"metadata,omitempty"`
+	DownlinkURL    string                 `json:"downlink_url,omitempty"`
 }
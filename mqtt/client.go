@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/TheThingsNetwork/go-utils/log"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/random"
 	MQTT "github.com/eclipse/paho.mqtt.golang"
@@ -65,6 +67,26 @@ type Client interface {
 	UnsubscribeDeviceActivations(appID string, devID string) Token
 	UnsubscribeAppActivations(appID string) Token
 	UnsubscribeActivations() Token
+
+	// Gateway pub/sub
+	PublishGatewayConnect(gatewayID string, msg GatewayConnectMessage) Token
+	PublishGatewayDisconnect(gatewayID string, msg GatewayDisconnectMessage) Token
+	PublishGatewayUplink(gatewayID string, msg *pb_router.UplinkMessage) Token
+	PublishGatewayStatus(gatewayID string, msg *pb_gateway.Status) Token
+	PublishGatewayDownlink(gatewayID string, msg *pb_router.DownlinkMessage) Token
+	PublishGatewayTxAck(gatewayID string, msg *pb_router.TxAcknowledgment) Token
+	SubscribeGatewayConnect(handler GatewayConnectHandler) Token
+	SubscribeGatewayDisconnect(handler GatewayDisconnectHandler) Token
+	SubscribeGatewayUplink(handler GatewayUplinkHandler) Token
+	SubscribeGatewayStatus(handler GatewayStatusHandler) Token
+	SubscribeGatewayDownlink(gatewayID string, handler GatewayDownlinkHandler) Token
+	SubscribeGatewayTxAck(handler GatewayTxAckHandler) Token
+	UnsubscribeGatewayConnect() Token
+	UnsubscribeGatewayDisconnect() Token
+	UnsubscribeGatewayUplink() Token
+	UnsubscribeGatewayStatus() Token
+	UnsubscribeGatewayDownlink(gatewayID string) Token
+	UnsubscribeGatewayTxAck() Token
 }
 
 // Token is returned on asyncronous functions
@@ -0,0 +1,215 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gogo/protobuf/proto"
+)
+
+// GatewayConnectMessage is published by a gateway when it connects
+type GatewayConnectMessage struct {
+	Token string `json:"token,omitempty"`
+}
+
+// GatewayDisconnectMessage is published by a gateway when it disconnects
+type GatewayDisconnectMessage struct {
+	Token string `json:"token,omitempty"`
+}
+
+// GatewayConnectHandler is called when a gateway connects
+type GatewayConnectHandler func(client Client, gatewayID string, msg GatewayConnectMessage)
+
+// GatewayDisconnectHandler is called when a gateway disconnects
+type GatewayDisconnectHandler func(client Client, gatewayID string, msg GatewayDisconnectMessage)
+
+// GatewayUplinkHandler is called for uplink messages from gateways
+type GatewayUplinkHandler func(client Client, gatewayID string, msg *pb_router.UplinkMessage)
+
+// GatewayStatusHandler is called for status messages from gateways
+type GatewayStatusHandler func(client Client, gatewayID string, msg *pb_gateway.Status)
+
+// GatewayDownlinkHandler is called for downlink messages to gateways
+type GatewayDownlinkHandler func(client Client, gatewayID string, msg *pb_router.DownlinkMessage)
+
+// GatewayTxAckHandler is called for acknowledgments of downlink messages from gateways
+type GatewayTxAckHandler func(client Client, gatewayID string, msg *pb_router.TxAcknowledgment)
+
+func (c *DefaultClient) publishGatewayProto(gatewayID string, topicType GatewayTopicType, msg proto.Message) Token {
+	topic := GatewayTopic{gatewayID, topicType}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return &simpleToken{fmt.Errorf("Unable to marshal the message payload")}
+	}
+	return c.publish(topic.String(), payload)
+}
+
+func (c *DefaultClient) subscribeGateway(gatewayID string, topicType GatewayTopicType, handler func(gatewayID string, payload []byte)) Token {
+	topic := GatewayTopic{gatewayID, topicType}
+	return c.subscribe(topic.String(), func(mqtt MQTT.Client, msg MQTT.Message) {
+		// Determine the actual topic
+		topic, err := ParseGatewayTopic(msg.Topic())
+		if err != nil {
+			c.ctx.Warnf("Received message on invalid gateway topic: %s", msg.Topic())
+			return
+		}
+		handler(topic.GatewayID, msg.Payload())
+	})
+}
+
+func (c *DefaultClient) unsubscribeGateway(gatewayID string, topicType GatewayTopicType) Token {
+	topic := GatewayTopic{gatewayID, topicType}
+	return c.unsubscribe(topic.String())
+}
+
+// PublishGatewayConnect publishes a connect message of a gateway
+func (c *DefaultClient) PublishGatewayConnect(gatewayID string, msg GatewayConnectMessage) Token {
+	topic := GatewayTopic{gatewayID, GatewayConnect}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return &simpleToken{fmt.Errorf("Unable to marshal the message payload")}
+	}
+	return c.publish(topic.String(), payload)
+}
+
+// PublishGatewayDisconnect publishes a disconnect message of a gateway
+func (c *DefaultClient) PublishGatewayDisconnect(gatewayID string, msg GatewayDisconnectMessage) Token {
+	topic := GatewayTopic{gatewayID, GatewayDisconnect}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return &simpleToken{fmt.Errorf("Unable to marshal the message payload")}
+	}
+	return c.publish(topic.String(), payload)
+}
+
+// PublishGatewayUplink publishes an uplink message of a gateway
+func (c *DefaultClient) PublishGatewayUplink(gatewayID string, msg *pb_router.UplinkMessage) Token {
+	return c.publishGatewayProto(gatewayID, GatewayUplink, msg)
+}
+
+// PublishGatewayStatus publishes a status message of a gateway
+func (c *DefaultClient) PublishGatewayStatus(gatewayID string, msg *pb_gateway.Status) Token {
+	return c.publishGatewayProto(gatewayID, GatewayStatus, msg)
+}
+
+// PublishGatewayDownlink publishes a downlink message to a gateway
+func (c *DefaultClient) PublishGatewayDownlink(gatewayID string, msg *pb_router.DownlinkMessage) Token {
+	return c.publishGatewayProto(gatewayID, GatewayDownlink, msg)
+}
+
+// PublishGatewayTxAck publishes an acknowledgment of a downlink message of a gateway
+func (c *DefaultClient) PublishGatewayTxAck(gatewayID string, msg *pb_router.TxAcknowledgment) Token {
+	return c.publishGatewayProto(gatewayID, GatewayTxAck, msg)
+}
+
+// SubscribeGatewayConnect subscribes to connect messages of all gateways
+func (c *DefaultClient) SubscribeGatewayConnect(handler GatewayConnectHandler) Token {
+	return c.subscribeGateway("", GatewayConnect, func(gatewayID string, payload []byte) {
+		var msg GatewayConnectMessage
+		if len(payload) > 0 {
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				c.ctx.Warnf("Could not unmarshal gateway connect (%s).", err.Error())
+				return
+			}
+		}
+		handler(c, gatewayID, msg)
+	})
+}
+
+// SubscribeGatewayDisconnect subscribes to disconnect messages of all gateways
+func (c *DefaultClient) SubscribeGatewayDisconnect(handler GatewayDisconnectHandler) Token {
+	return c.subscribeGateway("", GatewayDisconnect, func(gatewayID string, payload []byte) {
+		var msg GatewayDisconnectMessage
+		if len(payload) > 0 {
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				c.ctx.Warnf("Could not unmarshal gateway disconnect (%s).", err.Error())
+				return
+			}
+		}
+		handler(c, gatewayID, msg)
+	})
+}
+
+// SubscribeGatewayUplink subscribes to uplink messages of all gateways
+func (c *DefaultClient) SubscribeGatewayUplink(handler GatewayUplinkHandler) Token {
+	return c.subscribeGateway("", GatewayUplink, func(gatewayID string, payload []byte) {
+		msg := new(pb_router.UplinkMessage)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			c.ctx.Warnf("Could not unmarshal gateway uplink (%s).", err.Error())
+			return
+		}
+		handler(c, gatewayID, msg)
+	})
+}
+
+// SubscribeGatewayStatus subscribes to status messages of all gateways
+func (c *DefaultClient) SubscribeGatewayStatus(handler GatewayStatusHandler) Token {
+	return c.subscribeGateway("", GatewayStatus, func(gatewayID string, payload []byte) {
+		msg := new(pb_gateway.Status)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			c.ctx.Warnf("Could not unmarshal gateway status (%s).", err.Error())
+			return
+		}
+		handler(c, gatewayID, msg)
+	})
+}
+
+// SubscribeGatewayDownlink subscribes to downlink messages to the given gateway
+func (c *DefaultClient) SubscribeGatewayDownlink(gatewayID string, handler GatewayDownlinkHandler) Token {
+	return c.subscribeGateway(gatewayID, GatewayDownlink, func(gatewayID string, payload []byte) {
+		msg := new(pb_router.DownlinkMessage)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			c.ctx.Warnf("Could not unmarshal gateway downlink (%s).", err.Error())
+			return
+		}
+		handler(c, gatewayID, msg)
+	})
+}
+
+// SubscribeGatewayTxAck subscribes to acknowledgments of downlink messages of all gateways
+func (c *DefaultClient) SubscribeGatewayTxAck(handler GatewayTxAckHandler) Token {
+	return c.subscribeGateway("", GatewayTxAck, func(gatewayID string, payload []byte) {
+		msg := new(pb_router.TxAcknowledgment)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			c.ctx.Warnf("Could not unmarshal gateway tx ack (%s).", err.Error())
+			return
+		}
+		handler(c, gatewayID, msg)
+	})
+}
+
+// UnsubscribeGatewayConnect unsubscribes from connect messages of all gateways
+func (c *DefaultClient) UnsubscribeGatewayConnect() Token {
+	return c.unsubscribeGateway("", GatewayConnect)
+}
+
+// UnsubscribeGatewayDisconnect unsubscribes from disconnect messages of all gateways
+func (c *DefaultClient) UnsubscribeGatewayDisconnect() Token {
+	return c.unsubscribeGateway("", GatewayDisconnect)
+}
+
+// UnsubscribeGatewayUplink unsubscribes from uplink messages of all gateways
+func (c *DefaultClient) UnsubscribeGatewayUplink() Token {
+	return c.unsubscribeGateway("", GatewayUplink)
+}
+
+// UnsubscribeGatewayStatus unsubscribes from status messages of all gateways
+func (c *DefaultClient) UnsubscribeGatewayStatus() Token {
+	return c.unsubscribeGateway("", GatewayStatus)
+}
+
+// UnsubscribeGatewayDownlink unsubscribes from downlink messages to the given gateway
+func (c *DefaultClient) UnsubscribeGatewayDownlink(gatewayID string) Token {
+	return c.unsubscribeGateway(gatewayID, GatewayDownlink)
+}
+
+// UnsubscribeGatewayTxAck unsubscribes from acknowledgments of downlink messages of all gateways
+func (c *DefaultClient) UnsubscribeGatewayTxAck() Token {
+	return c.unsubscribeGateway("", GatewayTxAck)
+}
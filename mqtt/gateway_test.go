@@ -0,0 +1,126 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package mqtt
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+// Gateway pub/sub
+
+func TestPubSubGatewayConnect(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "Test"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	c.Connect()
+	defer c.Disconnect()
+
+	var wg WaitGroup
+	wg.Add(2)
+
+	subToken := c.SubscribeGatewayConnect(func(client Client, gatewayID string, msg GatewayConnectMessage) {
+		a.So(gatewayID, ShouldEqual, "test-gateway-connect")
+		a.So(msg.Token, ShouldEqual, "token")
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+	subToken = c.SubscribeGatewayDisconnect(func(client Client, gatewayID string, msg GatewayDisconnectMessage) {
+		a.So(gatewayID, ShouldEqual, "test-gateway-connect")
+		a.So(msg.Token, ShouldEqual, "token")
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+
+	waitForOK(c.PublishGatewayConnect("test-gateway-connect", GatewayConnectMessage{Token: "token"}), a)
+	waitForOK(c.PublishGatewayDisconnect("test-gateway-connect", GatewayDisconnectMessage{Token: "token"}), a)
+
+	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
+
+	waitForOK(c.UnsubscribeGatewayConnect(), a)
+	waitForOK(c.UnsubscribeGatewayDisconnect(), a)
+}
+
+func TestPubSubGatewayUplinkStatus(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "Test"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	c.Connect()
+	defer c.Disconnect()
+
+	var wg WaitGroup
+	wg.Add(2)
+
+	subToken := c.SubscribeGatewayUplink(func(client Client, gatewayID string, msg *pb_router.UplinkMessage) {
+		a.So(gatewayID, ShouldEqual, "test-gateway-uplink")
+		a.So(msg.Payload, ShouldResemble, []byte{1, 2, 3})
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+	subToken = c.SubscribeGatewayStatus(func(client Client, gatewayID string, msg *pb_gateway.Status) {
+		a.So(gatewayID, ShouldEqual, "test-gateway-uplink")
+		a.So(msg.Description, ShouldEqual, "Test")
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+
+	waitForOK(c.PublishGatewayUplink("test-gateway-uplink", &pb_router.UplinkMessage{Payload: []byte{1, 2, 3}}), a)
+	waitForOK(c.PublishGatewayStatus("test-gateway-uplink", &pb_gateway.Status{Description: "Test"}), a)
+
+	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
+
+	waitForOK(c.UnsubscribeGatewayUplink(), a)
+	waitForOK(c.UnsubscribeGatewayStatus(), a)
+}
+
+func TestPubSubGatewayDownlink(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "Test"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	c.Connect()
+	defer c.Disconnect()
+
+	var wg WaitGroup
+	wg.Add(1)
+
+	subToken := c.SubscribeGatewayDownlink("test-gateway-downlink", func(client Client, gatewayID string, msg *pb_router.DownlinkMessage) {
+		a.So(gatewayID, ShouldEqual, "test-gateway-downlink")
+		a.So(msg.Payload, ShouldResemble, []byte{1, 2, 3})
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+
+	waitForOK(c.PublishGatewayDownlink("test-gateway-downlink", &pb_router.DownlinkMessage{Payload: []byte{1, 2, 3}}), a)
+
+	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
+
+	waitForOK(c.UnsubscribeGatewayDownlink("test-gateway-downlink"), a)
+}
+
+func TestPubSubGatewayTxAck(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "Test"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	c.Connect()
+	defer c.Disconnect()
+
+	var wg WaitGroup
+	wg.Add(1)
+
+	subToken := c.SubscribeGatewayTxAck(func(client Client, gatewayID string, msg *pb_router.TxAcknowledgment) {
+		a.So(gatewayID, ShouldEqual, "test-gateway-tx-ack")
+		a.So(msg.ScheduleId, ShouldEqual, "schedule-id")
+		a.So(msg.Error, ShouldEqual, "TOO_LATE")
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+
+	waitForOK(c.PublishGatewayTxAck("test-gateway-tx-ack", &pb_router.TxAcknowledgment{ScheduleId: "schedule-id", Error: "TOO_LATE"}), a)
+
+	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
+
+	waitForOK(c.UnsubscribeGatewayTxAck(), a)
+}
@@ -122,3 +122,45 @@ func (t ApplicationTopic) String() string {
 	}
 	return topic
 }
+
+// GatewayTopicType represents the type of a gateway topic
+type GatewayTopicType string
+
+// Topic types for Gateways
+const (
+	GatewayConnect    GatewayTopicType = "connect"
+	GatewayDisconnect GatewayTopicType = "disconnect"
+	GatewayUplink     GatewayTopicType = "up"
+	GatewayStatus     GatewayTopicType = "status"
+	GatewayDownlink   GatewayTopicType = "down"
+	GatewayTxAck      GatewayTopicType = "ack"
+)
+
+// GatewayTopic represents an MQTT topic for gateways
+type GatewayTopic struct {
+	GatewayID string
+	Type      GatewayTopicType
+}
+
+// ParseGatewayTopic parses an MQTT gateway topic string to a GatewayTopic struct
+func ParseGatewayTopic(topic string) (*GatewayTopic, error) {
+	pattern := regexp.MustCompile("^([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\+)/(connect|disconnect|up|status|down|ack)$")
+	matches := pattern.FindStringSubmatch(topic)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("Invalid topic format")
+	}
+	var gatewayID string
+	if matches[1] != simpleWildcard {
+		gatewayID = matches[1]
+	}
+	return &GatewayTopic{gatewayID, GatewayTopicType(matches[2])}, nil
+}
+
+// String implements the Stringer interface
+func (t GatewayTopic) String() string {
+	gatewayID := simpleWildcard
+	if t.GatewayID != "" {
+		gatewayID = t.GatewayID
+	}
+	return fmt.Sprintf("%s/%s", gatewayID, t.Type)
+}
@@ -139,3 +139,34 @@ func TestAppTopicParseAndString(t *testing.T) {
 	}
 
 }
+
+func TestParseGatewayTopic(t *testing.T) {
+	a := New(t)
+
+	got, err := ParseGatewayTopic("eui-0102030405060708/up")
+	a.So(err, ShouldBeNil)
+	a.So(got, ShouldResemble, &GatewayTopic{GatewayID: "eui-0102030405060708", Type: GatewayUplink})
+
+	got, err = ParseGatewayTopic("+/status")
+	a.So(err, ShouldBeNil)
+	a.So(got, ShouldResemble, &GatewayTopic{Type: GatewayStatus})
+
+	got, err = ParseGatewayTopic("gateway-1/ack")
+	a.So(err, ShouldBeNil)
+	a.So(got, ShouldResemble, &GatewayTopic{GatewayID: "gateway-1", Type: GatewayTxAck})
+
+	_, err = ParseGatewayTopic("gateway:Invalid/up")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseGatewayTopic("gateway-1/emotions")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseGatewayTopic("appid-1/devices/devid-1/up")
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestGatewayTopicString(t *testing.T) {
+	a := New(t)
+	a.So(GatewayTopic{GatewayID: "gateway-1", Type: GatewayDownlink}.String(), ShouldEqual, "gateway-1/down")
+	a.So(GatewayTopic{Type: GatewayConnect}.String(), ShouldEqual, "+/connect")
+}
@@ -0,0 +1,63 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var applicationsHTTPCmd = &cobra.Command{
+	Use:   "http",
+	Short: "Show the HTTP integration",
+	Long: `ttnctl applications http shows the HTTP integration settings of the
+application. The HTTP integration posts uplink messages, activations and
+downlink events to the configured URLs.`,
+	Example: `$ ttnctl applications http
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Found Application
+
+  Uplink URL: https://example.com/ttn/uplink
+  Activation URL: https://example.com/ttn/activations
+  Event URL:
+  Headers:
+    Authorization: key secret
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 0, 0)
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		app, err := manager.GetApplication(appID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get application.")
+		}
+
+		ctx.Info("Found Application")
+
+		if app.HttpIntegration == nil {
+			ctx.Info("The HTTP integration is not enabled")
+			return
+		}
+
+		fmt.Println()
+		fmt.Printf("  Uplink URL: %s\n", app.HttpIntegration.UplinkUrl)
+		fmt.Printf("  Activation URL: %s\n", app.HttpIntegration.ActivationUrl)
+		fmt.Printf("  Event URL: %s\n", app.HttpIntegration.EventUrl)
+		fmt.Println("  Headers:")
+		for key, value := range app.HttpIntegration.Headers {
+			fmt.Printf("    %s: %s\n", key, value)
+		}
+	},
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsHTTPCmd)
+}
@@ -0,0 +1,60 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var applicationsHTTPDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Disable the HTTP integration",
+	Long:  `ttnctl applications http delete can be used to disable the HTTP integration of an application.`,
+	Example: `$ ttnctl applications http delete
+  INFO Using Application                        AppID=test
+Are you sure you want to disable the HTTP integration of application test?
+> yes
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Disabled HTTP integration                AppID=test
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 0, 0)
+
+		appID := util.GetAppID(ctx)
+
+		if !confirm(fmt.Sprintf("Are you sure you want to disable the HTTP integration of application %s?", appID)) {
+			ctx.Info("Not doing anything")
+			return
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		app, err := manager.GetApplication(appID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get existing application.")
+		}
+
+		app.HttpIntegration = &handler.HTTPIntegration{} // An integration without URLs disables it
+
+		err = manager.SetApplication(app)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not disable HTTP integration")
+		}
+
+		ctx.WithFields(log.Fields{
+			"AppID": appID,
+		}).Info("Disabled HTTP integration")
+	},
+}
+
+func init() {
+	applicationsHTTPCmd.AddCommand(applicationsHTTPDeleteCmd)
+}
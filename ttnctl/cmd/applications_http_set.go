@@ -0,0 +1,80 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var applicationsHTTPSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the HTTP integration",
+	Long: `ttnctl applications http set can be used to enable or update the HTTP
+integration of an application. Only the supplied settings are changed.`,
+	Example: `$ ttnctl applications http set --uplink-url https://example.com/ttn/uplink --header "Authorization: key secret"
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Updated HTTP integration                 AppID=test
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 0, 0)
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		app, err := manager.GetApplication(appID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get existing application.")
+		}
+
+		if app.HttpIntegration == nil {
+			app.HttpIntegration = &handler.HTTPIntegration{}
+		}
+
+		if cmd.Flags().Changed("uplink-url") {
+			app.HttpIntegration.UplinkUrl, _ = cmd.Flags().GetString("uplink-url")
+		}
+		if cmd.Flags().Changed("activation-url") {
+			app.HttpIntegration.ActivationUrl, _ = cmd.Flags().GetString("activation-url")
+		}
+		if cmd.Flags().Changed("event-url") {
+			app.HttpIntegration.EventUrl, _ = cmd.Flags().GetString("event-url")
+		}
+		if cmd.Flags().Changed("header") {
+			headers, _ := cmd.Flags().GetStringSlice("header")
+			app.HttpIntegration.Headers = make(map[string]string)
+			for _, header := range headers {
+				parts := strings.SplitN(header, ":", 2)
+				if len(parts) != 2 {
+					ctx.Fatalf("Invalid header %s, should be formatted as \"Key: Value\"", header)
+				}
+				app.HttpIntegration.Headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+			}
+		}
+
+		err = manager.SetApplication(app)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not update HTTP integration")
+		}
+
+		ctx.WithFields(log.Fields{
+			"AppID": appID,
+		}).Info("Updated HTTP integration")
+	},
+}
+
+func init() {
+	applicationsHTTPSetCmd.Flags().String("uplink-url", "", "URL that uplink messages are posted to")
+	applicationsHTTPSetCmd.Flags().String("activation-url", "", "URL that activations are posted to")
+	applicationsHTTPSetCmd.Flags().String("event-url", "", "URL that downlink events are posted to")
+	applicationsHTTPSetCmd.Flags().StringSlice("header", []string{}, "Header that is added to each request (\"Key: Value\"), replaces all existing headers")
+	applicationsHTTPCmd.AddCommand(applicationsHTTPSetCmd)
+}
@@ -13,12 +13,13 @@ import (
 var applicationsPayloadFunctionsCmd = &cobra.Command{
 	Use:   "pf",
 	Short: "Show the payload functions",
-	Long: `ttnctl applications pf shows the payload functions for decoding,
-converting and validating binary payload.`,
+	Long: `ttnctl applications pf shows the payload format and the payload functions
+for decoding, converting and validating binary payload.`,
 	Example: `$ ttnctl applications pf
   INFO Discovering Handler...
   INFO Connecting with Handler...
   INFO Found Application
+  INFO Payload format: custom
   INFO Decoder function
 function Decoder(bytes, port) {
   var decoded = {};
@@ -46,6 +47,15 @@ function Decoder(bytes, port) {
 
 		ctx.Info("Found Application")
 
+		format := app.PayloadFormat
+		if format == "" {
+			format = "custom"
+		}
+		ctx.Infof("Payload format: %s", format)
+		if format != "custom" {
+			ctx.Info("The payload functions are not used with this payload format")
+		}
+
 		if app.Decoder != "" {
 			ctx.Info("Decoder function")
 			fmt.Println(app.Decoder)
@@ -0,0 +1,60 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var applicationsPayloadFormatCmd = &cobra.Command{
+	Use:   "format [custom/cayennelpp]",
+	Short: "Set the payload format of an application",
+	Long: `ttnctl applications pf format can be used to set the payload format of an application.
+With the custom format, the payload functions of the application are used. With the
+cayennelpp format, payload is encoded and decoded in the Cayenne Low Power Payload
+format and the payload functions are not used.`,
+	Example: `$ ttnctl applications pf format cayennelpp
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Updated payload format                   AppID=test Format=cayennelpp
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		format := args[0]
+		switch format {
+		case "custom", "cayennelpp":
+		default:
+			ctx.Fatalf("Payload format %s does not exist", format)
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		app, err := manager.GetApplication(appID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get existing application.")
+		}
+
+		app.PayloadFormat = format
+
+		err = manager.SetApplication(app)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not update payload format")
+		}
+
+		ctx.WithFields(log.Fields{
+			"AppID":  appID,
+			"Format": format,
+		}).Info("Updated payload format")
+	},
+}
+
+func init() {
+	applicationsPayloadFunctionsCmd.AddCommand(applicationsPayloadFormatCmd)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/TheThingsNetwork/ttn/api"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/ttnctl/util"
 	"github.com/spf13/cobra"
 )
@@ -37,7 +38,15 @@ var devicesInfoCmd = &cobra.Command{
     NwkSKey: 3382A3066850293421ED8D392B9BF4DF
      FCntUp: 0
    FCntDown: 0
-    Options:
+    Options: FCntCheckEnabled, 32BitFCnt, ClassA, LoRaWAN1.0
+
+    ADR:
+
+  Algorithm: default
+  Data Rate: SF7BW125
+   TX Power: 14 dBm
+    NbTrans: 1
+     Margin: 15 dB
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		assertArgsLength(cmd, args, 1, 1)
@@ -88,11 +97,21 @@ var devicesInfoCmd = &cobra.Command{
 			fmt.Printf("     DevEUI: %s\n", formatBytes(lorawan.DevEui, byteFormat))
 			fmt.Printf("    DevAddr: %s\n", formatBytes(lorawan.DevAddr, byteFormat))
 			fmt.Printf("     AppKey: %s\n", formatBytes(lorawan.AppKey, byteFormat))
+			if lorawan.MacVersion == pb_lorawan.MACVersion_MAC_V1_1 {
+				fmt.Printf("     NwkKey: %s\n", formatBytes(lorawan.NwkKey, byteFormat))
+			}
 			fmt.Printf("    AppSKey: %s\n", formatBytes(lorawan.AppSKey, byteFormat))
 			fmt.Printf("    NwkSKey: %s\n", formatBytes(lorawan.NwkSKey, byteFormat))
+			if lorawan.MacVersion == pb_lorawan.MACVersion_MAC_V1_1 {
+				fmt.Printf("SNwkSIntKey: %s\n", formatBytes(lorawan.SNwkSIntKey, byteFormat))
+				fmt.Printf(" NwkSEncKey: %s\n", formatBytes(lorawan.NwkSEncKey, byteFormat))
+			}
 
 			fmt.Printf("     FCntUp: %d\n", lorawan.FCntUp)
 			fmt.Printf("   FCntDown: %d\n", lorawan.FCntDown)
+			if lorawan.MacVersion == pb_lorawan.MACVersion_MAC_V1_1 {
+				fmt.Printf("  AFCntDown: %d\n", lorawan.AFCntDown)
+			}
 			options := []string{}
 			if lorawan.DisableFCntCheck {
 				options = append(options, "FCntCheckDisabled")
@@ -104,7 +123,67 @@ var devicesInfoCmd = &cobra.Command{
 			} else {
 				options = append(options, "16BitFCnt")
 			}
+			options = append(options, "Class"+strings.TrimPrefix(lorawan.DeviceClass.String(), "CLASS_"))
+			options = append(options, "LoRaWAN"+strings.Replace(strings.TrimPrefix(lorawan.MacVersion.String(), "MAC_V"), "_", ".", 1))
 			fmt.Printf("    Options: %s\n", strings.Join(options, ", "))
+			if lorawan.BeaconFrequency != 0 {
+				fmt.Printf("     Beacon: %d Hz\n", lorawan.BeaconFrequency)
+			}
+			if lorawan.PingSlotFrequency != 0 {
+				fmt.Printf("  Ping Slot: %d Hz\n", lorawan.PingSlotFrequency)
+			}
+			if lorawan.PingSlotDataRate != "" {
+				fmt.Printf("  Ping Rate: %s\n", lorawan.PingSlotDataRate)
+			}
+			if adr := lorawan.AdrState; adr != nil {
+				fmt.Println()
+				fmt.Println("    ADR:")
+				fmt.Println()
+				fmt.Printf("  Algorithm: %s\n", adr.Algorithm)
+				if lorawan.AdrDataRate != "" {
+					fmt.Printf(" Fixed Rate: %s\n", lorawan.AdrDataRate)
+				}
+				fmt.Printf("  Data Rate: %s\n", adr.DataRate)
+				fmt.Printf("   TX Power: %d dBm\n", adr.TxPower)
+				fmt.Printf("    NbTrans: %d\n", adr.NbTrans)
+				fmt.Printf("     Margin: %d dB\n", adr.Margin)
+				if adr.Pending {
+					fmt.Println("     Status: LinkADRReq pending")
+				} else if adr.Failed > 0 {
+					fmt.Printf("     Status: %d LinkADRReqs rejected\n", adr.Failed)
+				}
+			}
+			if status := lorawan.DeviceStatus; status != nil {
+				fmt.Println()
+				fmt.Println("    Status:")
+				fmt.Println()
+				switch status.Battery {
+				case 0:
+					fmt.Println("    Battery: external power source")
+				case 255:
+					fmt.Println("    Battery: unknown")
+				default:
+					fmt.Printf("    Battery: %d/254\n", status.Battery)
+				}
+				fmt.Printf("     Margin: %d dB\n", status.Margin)
+				fmt.Printf("    Updated: %s\n", time.Unix(0, status.UpdatedAt))
+			}
+		}
+
+		if functions := dev.PayloadFunctions; functions != nil {
+			fmt.Println()
+			fmt.Println("    Payload Functions:")
+			fmt.Println()
+			for _, function := range []struct{ name, code string }{
+				{"Decoder", functions.Decoder},
+				{"Converter", functions.Converter},
+				{"Validator", functions.Validator},
+				{"Encoder", functions.Encoder},
+			} {
+				if function.code != "" {
+					fmt.Printf("  %s:\n%s\n", function.name, function.code)
+				}
+			}
 		}
 
 	},
@@ -0,0 +1,171 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+var devicesQueueCmd = &cobra.Command{
+	Use:   "queue [Device ID]",
+	Short: "Show the downlink queue of a device",
+	Long:  `ttnctl devices queue shows the downlink messages that are queued for a device.`,
+	Example: `$ ttnctl devices queue test
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+
+Index  	Port	Confirmed	Payload	Fields       	Expires
+current	3   	true     	01     	             	
+0      	1   	false    	AABC   	             	2017-06-13T15:38:56Z
+1      	2   	true     	       	{"led":"on"}
+
+  INFO Listed 2 downlink messages               AppID=test DevID=test
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		devID := args[0]
+		if !api.ValidID(devID) {
+			ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		queue, err := manager.GetDownlinkQueue(appID, devID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get downlink queue.")
+		}
+
+		table := uitable.New()
+		table.MaxColWidth = 70
+		table.AddRow("Index", "Port", "Confirmed", "Payload", "Fields", "Expires")
+		addRow := func(index interface{}, downlink *handler.QueuedDownlink) {
+			var payload, expires string
+			if len(downlink.PayloadRaw) > 0 {
+				payload = fmt.Sprintf("%X", downlink.PayloadRaw)
+			}
+			if downlink.Expires != 0 {
+				expires = time.Unix(0, downlink.Expires).UTC().Format(time.RFC3339)
+			}
+			table.AddRow(index, downlink.Port, downlink.Confirmed, payload, downlink.PayloadFields, expires)
+		}
+		if queue.Current != nil {
+			addRow("current", queue.Current) // The confirmed downlink that waits for an acknowledgment
+		}
+		for i, downlink := range queue.Downlinks {
+			addRow(i, downlink)
+		}
+
+		fmt.Println()
+		fmt.Println(table)
+		fmt.Println()
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID": appID,
+			"DevID": devID,
+		}).Infof("Listed %d downlink messages", len(queue.Downlinks))
+	},
+}
+
+var devicesQueueDeleteCmd = &cobra.Command{
+	Use:   "delete [Device ID] [Index]",
+	Short: "Delete a message from the downlink queue of a device",
+	Long:  `ttnctl devices queue delete deletes the message at the given index from the downlink queue of a device.`,
+	Example: `$ ttnctl devices queue delete test 1
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Deleted downlink message                 AppID=test DevID=test Index=1
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 2, 2)
+
+		devID := args[0]
+		if !api.ValidID(devID) {
+			ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+		}
+
+		index, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			ctx.WithError(err).Fatal("Invalid Index")
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		err = manager.DeleteQueuedDownlink(appID, devID, uint32(index))
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not delete downlink message.")
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID": appID,
+			"DevID": devID,
+			"Index": index,
+		}).Info("Deleted downlink message")
+	},
+}
+
+var devicesQueueClearCmd = &cobra.Command{
+	Use:   "clear [Device ID]",
+	Short: "Clear the downlink queue of a device",
+	Long:  `ttnctl devices queue clear deletes all messages from the downlink queue of a device, including the confirmed downlink message that waits for an acknowledgment.`,
+	Example: `$ ttnctl devices queue clear test
+  INFO Using Application                        AppID=test
+Are you sure you want to clear the downlink queue of device test?
+> yes
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Cleared downlink queue                   AppID=test DevID=test
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		devID := args[0]
+		if !api.ValidID(devID) {
+			ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+		}
+
+		appID := util.GetAppID(ctx)
+
+		if !confirm(fmt.Sprintf("Are you sure you want to clear the downlink queue of device %s?", devID)) {
+			ctx.Info("Not doing anything")
+			return
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		err := manager.ClearDownlinkQueue(appID, devID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not clear downlink queue.")
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID": appID,
+			"DevID": devID,
+		}).Info("Cleared downlink queue")
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesQueueCmd)
+	devicesQueueCmd.AddCommand(devicesQueueDeleteCmd)
+	devicesQueueCmd.AddCommand(devicesQueueClearCmd)
+}
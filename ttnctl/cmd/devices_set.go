@@ -4,10 +4,13 @@
 package cmd
 
 import (
+	"io/ioutil"
 	"os"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/ttnctl/util"
 	"github.com/spf13/cobra"
@@ -16,7 +19,8 @@ import (
 var devicesSetCmd = &cobra.Command{
 	Use:   "set [Device ID]",
 	Short: "Set properties of a device",
-	Long:  `ttnctl devices set can be used to set properties of a device.`,
+	Long: `ttnctl devices set can be used to set properties of a device.
+The payload functions of a device take precedence over the payload functions of the application.`,
 	Example: `$ ttnctl devices set test --fcnt-up 0 --fcnt-down 0
   INFO Using Application                        AppID=test
   INFO Discovering Handler...
@@ -112,6 +116,25 @@ var devicesSetCmd = &cobra.Command{
 			dev.GetLorawanDevice().AppKey = &key
 		}
 
+		if in, err := cmd.Flags().GetString("nwk-key"); err == nil && in != "" {
+			key, err := types.ParseNwkKey(in)
+			if err != nil {
+				ctx.Fatalf("Invalid NwkKey: %s", err)
+			}
+			dev.GetLorawanDevice().NwkKey = &key
+		}
+
+		if in, err := cmd.Flags().GetString("mac-version"); err == nil && in != "" {
+			switch in {
+			case "1.0":
+				dev.GetLorawanDevice().MacVersion = lorawan.MACVersion_MAC_V1_0
+			case "1.1":
+				dev.GetLorawanDevice().MacVersion = lorawan.MACVersion_MAC_V1_1
+			default:
+				ctx.Fatalf("Invalid MAC version: %s", in)
+			}
+		}
+
 		if in, err := cmd.Flags().GetInt("fcnt-up"); err == nil && in != -1 {
 			dev.GetLorawanDevice().FCntUp = uint32(in)
 		}
@@ -120,6 +143,10 @@ var devicesSetCmd = &cobra.Command{
 			dev.GetLorawanDevice().FCntDown = uint32(in)
 		}
 
+		if in, err := cmd.Flags().GetInt("a-fcnt-down"); err == nil && in != -1 {
+			dev.GetLorawanDevice().AFCntDown = uint32(in)
+		}
+
 		if in, err := cmd.Flags().GetBool("enable-fcnt-check"); err == nil && in {
 			dev.GetLorawanDevice().DisableFCntCheck = false
 		}
@@ -136,6 +163,106 @@ var devicesSetCmd = &cobra.Command{
 			dev.GetLorawanDevice().Uses32BitFCnt = false
 		}
 
+		if in, err := cmd.Flags().GetBool("class-a"); err == nil && in {
+			dev.GetLorawanDevice().DeviceClass = lorawan.DeviceClass_CLASS_A
+		}
+
+		if in, err := cmd.Flags().GetBool("class-b"); err == nil && in {
+			dev.GetLorawanDevice().DeviceClass = lorawan.DeviceClass_CLASS_B
+		}
+
+		if in, err := cmd.Flags().GetUint32("beacon-frequency"); err == nil && in != 0 {
+			dev.GetLorawanDevice().BeaconFrequency = in
+		}
+
+		if in, err := cmd.Flags().GetUint32("ping-slot-frequency"); err == nil && in != 0 {
+			dev.GetLorawanDevice().PingSlotFrequency = in
+		}
+
+		if in, err := cmd.Flags().GetString("ping-slot-data-rate"); err == nil && in != "" {
+			dev.GetLorawanDevice().PingSlotDataRate = in
+		}
+
+		if in, err := cmd.Flags().GetBool("class-c"); err == nil && in {
+			dev.GetLorawanDevice().DeviceClass = lorawan.DeviceClass_CLASS_C
+		}
+
+		if in, err := cmd.Flags().GetString("adr-algorithm"); err == nil && in != "" {
+			if in == "application" {
+				in = ""
+			}
+			dev.GetLorawanDevice().AdrAlgorithm = in
+		}
+
+		if in, err := cmd.Flags().GetString("adr-data-rate"); err == nil && in != "" {
+			if _, err := types.ParseDataRate(in); err != nil {
+				ctx.Fatalf("Invalid data rate: %s", err)
+			}
+			dev.GetLorawanDevice().AdrDataRate = in
+		}
+
+		macSettings := dev.GetLorawanDevice().MacSettings
+		if macSettings == nil {
+			macSettings = &lorawan.MACSettings{}
+		}
+
+		if in, err := cmd.Flags().GetInt("max-duty-cycle"); err == nil && in != -1 {
+			macSettings.MaxDutyCycle = uint32(in)
+		}
+
+		if in, err := cmd.Flags().GetInt("rx1-delay"); err == nil && in != -1 {
+			macSettings.Rx1Delay = uint32(in)
+		}
+
+		if in, err := cmd.Flags().GetInt("rx1-dr-offset"); err == nil && in != -1 {
+			macSettings.Rx1DrOffset = uint32(in)
+		}
+
+		if in, err := cmd.Flags().GetInt("rx2-data-rate"); err == nil && in != -1 {
+			macSettings.Rx2DataRate = uint32(in)
+		}
+
+		if in, err := cmd.Flags().GetUint32("rx2-frequency"); err == nil && in != 0 {
+			macSettings.Rx2Frequency = in
+		}
+
+		if in, err := cmd.Flags().GetStringSlice("channel"); err == nil {
+			for _, channel := range in {
+				parsed, err := util.ParseMACChannel(channel)
+				if err != nil {
+					ctx.WithError(err).Fatalf("Invalid channel: %s", channel)
+				}
+				channels := []*lorawan.MACChannel{parsed}
+				for _, existing := range macSettings.Channels {
+					if existing.Index != parsed.Index {
+						channels = append(channels, existing)
+					}
+				}
+				macSettings.Channels = channels
+			}
+		}
+
+		if in, err := cmd.Flags().GetString("dwell-time"); err == nil && in != "" {
+			switch in {
+			case "none":
+				macSettings.UplinkDwellTime, macSettings.DownlinkDwellTime = false, false
+			case "uplink":
+				macSettings.UplinkDwellTime, macSettings.DownlinkDwellTime = true, false
+			case "downlink":
+				macSettings.UplinkDwellTime, macSettings.DownlinkDwellTime = false, true
+			case "both":
+				macSettings.UplinkDwellTime, macSettings.DownlinkDwellTime = true, true
+			default:
+				ctx.Fatalf("Invalid dwell time: %s", in)
+			}
+		}
+
+		if in, err := cmd.Flags().GetInt("max-eirp"); err == nil && in != -1 {
+			macSettings.MaxEirp = uint32(in)
+		}
+
+		dev.GetLorawanDevice().MacSettings = macSettings
+
 		if in, err := cmd.Flags().GetFloat32("latitude"); err == nil && in != 0 {
 			dev.Latitude = in
 		}
@@ -152,6 +279,34 @@ var devicesSetCmd = &cobra.Command{
 			dev.Description = in
 		}
 
+		if in, err := cmd.Flags().GetBool("clear-payload-functions"); err == nil && in {
+			dev.PayloadFunctions = &handler.PayloadFunctions{} // Payload functions without functions clear them
+		}
+
+		for _, function := range []string{"decoder", "converter", "validator", "encoder"} {
+			file, err := cmd.Flags().GetString(function)
+			if err != nil || file == "" {
+				continue
+			}
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				ctx.WithError(err).Fatalf("Could not read %s file", function)
+			}
+			if dev.PayloadFunctions == nil {
+				dev.PayloadFunctions = &handler.PayloadFunctions{}
+			}
+			switch function {
+			case "decoder":
+				dev.PayloadFunctions.Decoder = string(content)
+			case "converter":
+				dev.PayloadFunctions.Converter = string(content)
+			case "validator":
+				dev.PayloadFunctions.Validator = string(content)
+			case "encoder":
+				dev.PayloadFunctions.Encoder = string(content)
+			}
+		}
+
 		err = manager.SetDevice(dev)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not update Device")
@@ -175,18 +330,46 @@ func init() {
 	devicesSetCmd.Flags().String("nwk-s-key", "", "Set NwkSKey")
 	devicesSetCmd.Flags().String("app-s-key", "", "Set AppSKey")
 	devicesSetCmd.Flags().String("app-key", "", "Set AppKey")
+	devicesSetCmd.Flags().String("nwk-key", "", "Set NwkKey (LoRaWAN 1.1)")
+	devicesSetCmd.Flags().String("mac-version", "", "Set the LoRaWAN MAC version of the device (1.0/1.1)")
 
 	devicesSetCmd.Flags().Int("fcnt-up", -1, "Set FCnt Up")
-	devicesSetCmd.Flags().Int("fcnt-down", -1, "Set FCnt Down")
+	devicesSetCmd.Flags().Int("fcnt-down", -1, "Set FCnt Down (NFCntDown for LoRaWAN 1.1)")
+	devicesSetCmd.Flags().Int("a-fcnt-down", -1, "Set AFCntDown (LoRaWAN 1.1)")
 
 	devicesSetCmd.Flags().Bool("disable-fcnt-check", false, "Disable FCnt check")
 	devicesSetCmd.Flags().Bool("enable-fcnt-check", false, "Enable FCnt check (default)")
 	devicesSetCmd.Flags().Bool("32-bit-fcnt", false, "Use 32 bit FCnt (default)")
 	devicesSetCmd.Flags().Bool("16-bit-fcnt", false, "Use 16 bit FCnt")
 
+	devicesSetCmd.Flags().Bool("class-a", false, "Set the device to Class A (default)")
+	devicesSetCmd.Flags().Bool("class-b", false, "Set the device to Class B, downlink is sent in the next ping slot")
+	devicesSetCmd.Flags().Uint32("beacon-frequency", 0, "Set the beacon frequency (Hz) of a Class B device")
+	devicesSetCmd.Flags().Uint32("ping-slot-frequency", 0, "Set the ping slot frequency (Hz) of a Class B device")
+	devicesSetCmd.Flags().String("ping-slot-data-rate", "", "Set the ping slot data rate (for example SF9BW125) of a Class B device")
+	devicesSetCmd.Flags().Bool("class-c", false, "Set the device to Class C, downlink is sent immediately")
+
+	devicesSetCmd.Flags().String("adr-algorithm", "", "Set the ADR algorithm of the device (default/conservative/fixed/application)")
+	devicesSetCmd.Flags().String("adr-data-rate", "", "Set the data rate of the device for the fixed ADR algorithm (for example SF9BW125)")
+
+	devicesSetCmd.Flags().Int("max-duty-cycle", -1, "Set the maximum duty cycle (1/2^max-duty-cycle) of the device, 0 for no limit")
+	devicesSetCmd.Flags().Int("rx1-delay", -1, "Set the delay (seconds) of the RX1 window of the device")
+	devicesSetCmd.Flags().Int("rx1-dr-offset", -1, "Set the offset between the uplink data rate and the RX1 data rate of the device")
+	devicesSetCmd.Flags().Int("rx2-data-rate", -1, "Set the data rate index of the RX2 window of the device")
+	devicesSetCmd.Flags().Uint32("rx2-frequency", 0, "Set the frequency (Hz) of the RX2 window of the device")
+	devicesSetCmd.Flags().StringSlice("channel", []string{}, "Set a channel of the device (index:frequency:min-max[:downlink frequency]), frequency 0 disables the channel")
+	devicesSetCmd.Flags().String("dwell-time", "", "Set the dwell time limits of the device (none/uplink/downlink/both)")
+	devicesSetCmd.Flags().Int("max-eirp", -1, "Set the index of the maximum EIRP of the device")
+
 	devicesSetCmd.Flags().Float32("latitude", 0, "Set latitude")
 	devicesSetCmd.Flags().Float32("longitude", 0, "Set longitude")
 	devicesSetCmd.Flags().Int32("altitude", 0, "Set altitude")
 
 	devicesSetCmd.Flags().String("description", "", "Set Description")
+
+	devicesSetCmd.Flags().String("decoder", "", "Set the decoder function of the device from a file")
+	devicesSetCmd.Flags().String("converter", "", "Set the converter function of the device from a file")
+	devicesSetCmd.Flags().String("validator", "", "Set the validator function of the device from a file")
+	devicesSetCmd.Flags().String("encoder", "", "Set the encoder function of the device from a file")
+	devicesSetCmd.Flags().Bool("clear-payload-functions", false, "Remove the payload functions of the device")
 }
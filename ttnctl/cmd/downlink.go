@@ -53,6 +53,11 @@ $ ttnctl downlink test --json '{"led":"on"}'
 			ctx.WithError(err).Fatal("Failed to read confirmed flag")
 		}
 
+		ttl, err := cmd.Flags().GetString("ttl")
+		if err != nil {
+			ctx.WithError(err).Fatal("Failed to read ttl flag")
+		}
+
 		accessKey, err := cmd.Flags().GetString("access-key")
 		if err != nil {
 			ctx.WithError(err).Fatal("Failed to read access-key flag")
@@ -66,6 +71,7 @@ $ ttnctl downlink test --json '{"led":"on"}'
 			DevID:     devID,
 			FPort:     uint8(fPort),
 			Confirmed: confirmed,
+			TTL:       ttl,
 		}
 
 		if args[1] == "" {
@@ -109,5 +115,6 @@ func init() {
 	downlinkCmd.Flags().Int("fport", 1, "FPort for downlink")
 	downlinkCmd.Flags().Bool("confirmed", false, "Confirmed downlink")
 	downlinkCmd.Flags().Bool("json", false, "Provide the payload as JSON")
+	downlinkCmd.Flags().String("ttl", "", "Drop the downlink if it was not sent within this time (for example 10m)")
 	downlinkCmd.Flags().String("access-key", "", "The access key to use")
 }
@@ -80,6 +80,13 @@ var gatewaysStatusCmd = &cobra.Command{
 		}())
 		printKV("Rx", fmt.Sprintf("(in: %d; ok: %d)", resp.Status.RxIn, resp.Status.RxOk))
 		printKV("Tx", fmt.Sprintf("(in: %d; ok: %d)", resp.Status.TxIn, resp.Status.TxOk))
+		for _, budget := range resp.DutyCycleBudgets {
+			printKV("Duty cycle budget", fmt.Sprintf("%s remaining on %.2f-%.2f MHz (%g%%)",
+				time.Duration(budget.Remaining),
+				float64(budget.MinFrequency)/1000000, float64(budget.MaxFrequency)/1000000,
+				budget.DutyCycle*100,
+			))
+		}
 		fmt.Println()
 	},
 }
@@ -0,0 +1,30 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var multicastCmd = &cobra.Command{
+	Use:   "multicast",
+	Short: "Manage multicast groups",
+	Long:  `ttnctl multicast can be used to manage multicast groups.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		RootCmd.PersistentPreRun(cmd, args)
+		util.GetAccount(ctx)
+		ctx.WithFields(ttnlog.Fields{
+			"AppID": util.GetAppID(ctx),
+		}).Info("Using Application")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(multicastCmd)
+	multicastCmd.PersistentFlags().String("app-id", "", "The app ID to use")
+	viper.BindPFlag("app-id", multicastCmd.PersistentFlags().Lookup("app-id"))
+}
@@ -0,0 +1,90 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var multicastDownlinkCmd = &cobra.Command{
+	Use:   "downlink [Group ID] [Payload]",
+	Short: "Send a downlink message to a multicast group",
+	Long: `ttnctl multicast downlink can be used to send a downlink message to a multicast group.
+The message is sent as soon as possible in RX2 by all gateways of the group.`,
+	Example: `$ ttnctl multicast downlink firmware aabc
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Sent multicast downlink                  AppID=test GroupID=firmware
+
+$ ttnctl multicast downlink firmware --json '{"led":"on"}'
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Sent multicast downlink                  AppID=test GroupID=firmware
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 2, 2)
+
+		appID := util.GetAppID(ctx)
+		ctx = ctx.WithField("AppID", appID)
+
+		groupID := args[0]
+		if !api.ValidID(groupID) {
+			ctx.Fatalf("Invalid Group ID")
+		}
+		ctx = ctx.WithField("GroupID", groupID)
+
+		jsonflag, err := cmd.Flags().GetBool("json")
+		if err != nil {
+			ctx.WithError(err).Fatal("Failed to read json flag")
+		}
+
+		fPort, err := cmd.Flags().GetInt("fport")
+		if err != nil {
+			ctx.WithError(err).Fatal("Failed to read fport flag")
+		}
+
+		message := &handler.MulticastDownlinkMessage{
+			AppId:   appID,
+			GroupId: groupID,
+			Port:    uint32(fPort),
+		}
+
+		if jsonflag {
+			var fields map[string]interface{}
+			if err := json.Unmarshal([]byte(args[1]), &fields); err != nil {
+				ctx.WithError(err).Fatal("Invalid json string")
+			}
+			message.PayloadFields = args[1]
+		} else {
+			payload, err := types.ParseHEX(args[1], len(args[1])/2)
+			if err != nil {
+				ctx.WithError(err).Fatal("Invalid Payload")
+			}
+			message.PayloadRaw = payload
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		if err := manager.SendMulticastDownlink(message); err != nil {
+			ctx.WithError(err).Fatal("Could not send multicast downlink")
+		}
+
+		ctx.Info("Sent multicast downlink")
+	},
+}
+
+func init() {
+	multicastCmd.AddCommand(multicastDownlinkCmd)
+	multicastDownlinkCmd.Flags().Int("fport", 1, "FPort for downlink")
+	multicastDownlinkCmd.Flags().Bool("json", false, "Provide the payload as JSON")
+}
@@ -0,0 +1,74 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var multicastInfoCmd = &cobra.Command{
+	Use:   "info [Group ID]",
+	Short: "Get information about a multicast group",
+	Long:  `ttnctl multicast info can be used to get information about a multicast group.`,
+	Example: `$ ttnctl multicast info firmware
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Found multicast group
+
+  Application ID: test
+        Group ID: firmware
+
+     DevAddr: 26001B3C
+     NwkSKey: 3382A3066850293421ED8D392B9BF4DF
+     AppSKey: D8DD37B4B709BA76C6FEC62CAD0CCE51
+    FCntDown: 12
+    Gateways: test-gateway
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		groupID := args[0]
+		if !api.ValidID(groupID) {
+			ctx.Fatalf("Invalid Group ID")
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		group, err := manager.GetMulticastGroup(appID, groupID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get multicast group.")
+		}
+
+		ctx.Info("Found multicast group")
+
+		fmt.Println()
+
+		fmt.Printf("  Application ID: %s\n", group.AppId)
+		fmt.Printf("        Group ID: %s\n", group.GroupId)
+		if group.Description != "" {
+			fmt.Printf("     Description: %s\n", group.Description)
+		}
+
+		fmt.Println()
+
+		fmt.Printf("     DevAddr: %s\n", group.DevAddr)
+		fmt.Printf("     NwkSKey: %s\n", group.NwkSKey)
+		fmt.Printf("     AppSKey: %s\n", group.AppSKey)
+		fmt.Printf("    FCntDown: %d\n", group.FCntDown)
+		fmt.Printf("    Gateways: %s\n", strings.Join(group.GatewayIds, ", "))
+	},
+}
+
+func init() {
+	multicastCmd.AddCommand(multicastInfoCmd)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+var multicastListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all multicast groups for the current application",
+	Long:    `ttnctl multicast list can be used to list all multicast groups for the current application.`,
+	Example: `$ ttnctl multicast list
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+
+GroupID 	DevAddr 	FCntDown	Gateways    	Description
+firmware	26001B3C	12      	test-gateway
+
+  INFO Listed 1 multicast groups                AppID=test
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 0, 0)
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		groups, err := manager.GetMulticastGroupsForApplication(appID, 0, 0)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get multicast groups.")
+		}
+
+		table := uitable.New()
+		table.MaxColWidth = 70
+		table.AddRow("GroupID", "DevAddr", "FCntDown", "Gateways", "Description")
+		for _, group := range groups {
+			table.AddRow(group.GroupId, group.DevAddr, group.FCntDown, crop(strings.Join(group.GatewayIds, ","), 40), crop(group.Description, 20))
+		}
+
+		fmt.Println()
+		fmt.Println(table)
+		fmt.Println()
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID": appID,
+		}).Infof("Listed %d multicast groups", len(groups))
+	},
+}
+
+func init() {
+	multicastCmd.AddCommand(multicastListCmd)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/go-utils/random"
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var multicastRegisterCmd = &cobra.Command{
+	Use:   "register [Group ID] [Gateway ID] [Gateway ID...]",
+	Short: "Register or update a multicast group",
+	Long: `ttnctl multicast register can be used to register or update a multicast group.
+The session keys are generated and the DevAddr is requested if they are not given.
+The devices of the group should be configured with the same session.`,
+	Example: `$ ttnctl multicast register firmware test-gateway
+  INFO Using Application                        AppID=test
+  INFO Generating random NwkSKey...
+  INFO Generating random AppSKey...
+  INFO Discovering Handler...                   Handler=ttn-handler-eu
+  INFO Connecting with Handler...               Handler=eu.thethings.network:1904
+  INFO Requesting DevAddr for multicast group...
+  INFO Registered multicast group               AppID=test AppSKey=D8DD37B4B709BA76C6FEC62CAD0CCE51 DevAddr=26001B3C GroupID=firmware NwkSKey=3382A3066850293421ED8D392B9BF4DF
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 2, len(args)) // At least one gateway
+
+		var err error
+
+		groupID := args[0]
+		if !api.ValidID(groupID) {
+			ctx.Fatalf("Invalid Group ID")
+		}
+
+		gatewayIDs := args[1:]
+		for _, gatewayID := range gatewayIDs {
+			if !api.ValidID(gatewayID) {
+				ctx.Fatalf("Invalid Gateway ID %s", gatewayID)
+			}
+		}
+
+		appID := util.GetAppID(ctx)
+
+		var nwkSKey types.NwkSKey
+		if in, _ := cmd.Flags().GetString("nwk-s-key"); in != "" {
+			nwkSKey, err = types.ParseNwkSKey(in)
+			if err != nil {
+				ctx.Fatalf("Invalid NwkSKey: %s", err)
+			}
+		} else {
+			ctx.Info("Generating random NwkSKey...")
+			random.FillBytes(nwkSKey[:])
+		}
+
+		var appSKey types.AppSKey
+		if in, _ := cmd.Flags().GetString("app-s-key"); in != "" {
+			appSKey, err = types.ParseAppSKey(in)
+			if err != nil {
+				ctx.Fatalf("Invalid AppSKey: %s", err)
+			}
+		} else {
+			ctx.Info("Generating random AppSKey...")
+			random.FillBytes(appSKey[:])
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		var devAddr types.DevAddr
+		if in, _ := cmd.Flags().GetString("dev-addr"); in != "" {
+			devAddr, err = types.ParseDevAddr(in)
+			if err != nil {
+				ctx.Fatalf("Invalid DevAddr: %s", err)
+			}
+		} else {
+			ctx.Info("Requesting DevAddr for multicast group...")
+			devAddr, err = manager.GetDevAddr("abp")
+			if err != nil {
+				ctx.WithError(err).Fatal("Could not request device address")
+			}
+		}
+
+		description, _ := cmd.Flags().GetString("description")
+
+		err = manager.SetMulticastGroup(&handler.MulticastGroup{
+			AppId:       appID,
+			GroupId:     groupID,
+			Description: description,
+			DevAddr:     &devAddr,
+			NwkSKey:     &nwkSKey,
+			AppSKey:     &appSKey,
+			GatewayIds:  gatewayIDs,
+		})
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not register multicast group")
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":   appID,
+			"GroupID": groupID,
+			"DevAddr": devAddr,
+			"NwkSKey": nwkSKey,
+			"AppSKey": appSKey,
+		}).Info("Registered multicast group")
+	},
+}
+
+func init() {
+	multicastCmd.AddCommand(multicastRegisterCmd)
+	multicastRegisterCmd.Flags().String("dev-addr", "", "The DevAddr of the group (requested if not given)")
+	multicastRegisterCmd.Flags().String("nwk-s-key", "", "The NwkSKey of the group (generated if not given)")
+	multicastRegisterCmd.Flags().String("app-s-key", "", "The AppSKey of the group (generated if not given)")
+	multicastRegisterCmd.Flags().String("description", "", "The description of the group")
+}
@@ -0,0 +1,45 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+)
+
+// ParseMACChannel parses a channel in the <index>:<frequency>:<min>-<max>[:<downlink frequency>] format
+func ParseMACChannel(channelStr string) (*lorawan.MACChannel, error) {
+	parts := strings.Split(channelStr, ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, errors.New("Channel should be on the <index>:<frequency>:<min>-<max>[:<downlink frequency>] format")
+	}
+	dataRates := strings.Split(parts[2], "-")
+	if len(dataRates) != 2 {
+		return nil, errors.New("Data rates of the channel should be on the <min>-<max> format")
+	}
+
+	values := []string{parts[0], parts[1], dataRates[0], dataRates[1]}
+	if len(parts) == 4 {
+		values = append(values, parts[3])
+	}
+	numbers := make([]uint32, 5)
+	for i, value := range values {
+		number, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = uint32(number)
+	}
+
+	return &lorawan.MACChannel{
+		Index:             numbers[0],
+		Frequency:         numbers[1],
+		MinDataRate:       numbers[2],
+		MaxDataRate:       numbers[3],
+		DownlinkFrequency: numbers[4],
+	}, nil
+}
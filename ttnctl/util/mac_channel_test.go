@@ -0,0 +1,32 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestParseMACChannel(t *testing.T) {
+	a := New(t)
+
+	parsed, err := ParseMACChannel("3:867100000:0-5")
+	a.So(err, ShouldBeNil)
+	a.So(parsed, ShouldResemble, &lorawan.MACChannel{Index: 3, Frequency: 867100000, MinDataRate: 0, MaxDataRate: 5})
+
+	parsed, err = ParseMACChannel("3:867100000:0-5:869525000")
+	a.So(err, ShouldBeNil)
+	a.So(parsed.DownlinkFrequency, ShouldEqual, 869525000)
+
+	_, err = ParseMACChannel("3:867100000")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseMACChannel("3:867100000:5")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseMACChannel("3:abc:0-5")
+	a.So(err, ShouldNotBeNil)
+}
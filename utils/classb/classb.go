@@ -0,0 +1,92 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package classb implements the timing of beacons and ping slots of LoRaWAN Class B devices
+package classb
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+const (
+	// BeaconPeriod is the time between two beacons
+	BeaconPeriod = 128 * time.Second
+	// BeaconReserved is the time after the start of the beacon that is reserved for the beacon itself
+	BeaconReserved = 2120 * time.Millisecond
+	// SlotLength is the length of a ping slot
+	SlotLength = 30 * time.Millisecond
+	// PingSlotCount is the number of ping slots in a beacon period
+	PingSlotCount = 4096
+)
+
+// GPSEpoch is the start of the GPS time
+var GPSEpoch = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// LeapSeconds is the number of leap seconds between GPS time and UTC
+var LeapSeconds = 18 * time.Second
+
+// GPSTime returns the time since the GPS epoch
+func GPSTime(t time.Time) time.Duration {
+	return t.Sub(GPSEpoch) + LeapSeconds
+}
+
+// FromGPSTime returns the time for the given time since the GPS epoch
+func FromGPSTime(gps time.Duration) time.Time {
+	return GPSEpoch.Add(gps - LeapSeconds).UTC()
+}
+
+// BeaconTime returns the GPS time of the start of the beacon period that contains t
+func BeaconTime(t time.Time) time.Duration {
+	gps := GPSTime(t)
+	return gps - gps%BeaconPeriod
+}
+
+// PingNb returns the number of ping slots in a beacon period for the periodicity in a PingSlotInfoReq
+func PingNb(periodicity uint8) int {
+	return 1 << (7 - periodicity&0x07)
+}
+
+// ValidPingNb returns true if the number of ping slots in a beacon period is valid
+func ValidPingNb(pingNb int) bool {
+	return pingNb >= 1 && pingNb <= 128 && pingNb&(pingNb-1) == 0
+}
+
+// PingPeriod returns the number of slots between two ping slots
+func PingPeriod(pingNb int) int {
+	return PingSlotCount / pingNb
+}
+
+// PingOffset returns the pseudo-random offset of the first ping slot of a device in the beacon period that starts
+// at the given beacon time
+func PingOffset(beaconTime time.Duration, devAddr types.DevAddr, pingPeriod int) int {
+	var in, out [16]byte
+	binary.LittleEndian.PutUint32(in[0:4], uint32(beaconTime/time.Second))
+	for i := range devAddr {
+		in[4+i] = devAddr[len(devAddr)-1-i] // DevAddr is little endian in this block
+	}
+	block, _ := aes.NewCipher(make([]byte, 16)) // Does not fail for a 16 byte key
+	block.Encrypt(out[:], in[:])
+	return (int(out[0]) + int(out[1])*256) % pingPeriod
+}
+
+// NextPingSlot returns the start of the first ping slot of a device after the given time
+func NextPingSlot(devAddr types.DevAddr, pingNb int, after time.Time) (time.Time, error) {
+	if !ValidPingNb(pingNb) {
+		return time.Time{}, errors.NewErrInvalidArgument("PingNb", "must be a power of two between 1 and 128")
+	}
+	pingPeriod := PingPeriod(pingNb)
+	for beaconTime := BeaconTime(after); ; beaconTime += BeaconPeriod {
+		pingOffset := PingOffset(beaconTime, devAddr, pingPeriod)
+		for slot := pingOffset; slot < PingSlotCount; slot += pingPeriod {
+			slotTime := FromGPSTime(beaconTime + BeaconReserved + time.Duration(slot)*SlotLength)
+			if slotTime.After(after) {
+				return slotTime, nil
+			}
+		}
+	}
+}
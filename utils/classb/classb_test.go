@@ -0,0 +1,77 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package classb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestGPSTime(t *testing.T) {
+	a := New(t)
+	a.So(GPSTime(GPSEpoch), ShouldEqual, LeapSeconds)
+	a.So(FromGPSTime(LeapSeconds), ShouldResemble, GPSEpoch)
+
+	now := time.Now().UTC()
+	a.So(FromGPSTime(GPSTime(now)).Equal(now), ShouldBeTrue)
+
+	beaconTime := BeaconTime(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	a.So(beaconTime%BeaconPeriod, ShouldEqual, 0)
+	a.So(beaconTime/time.Second, ShouldEqual, 1180353536)
+}
+
+func TestPingNb(t *testing.T) {
+	a := New(t)
+	a.So(PingNb(0), ShouldEqual, 128)
+	a.So(PingNb(7), ShouldEqual, 1)
+	a.So(PingPeriod(PingNb(0)), ShouldEqual, 32)
+	a.So(PingPeriod(PingNb(7)), ShouldEqual, 4096)
+
+	a.So(ValidPingNb(0), ShouldBeFalse)
+	a.So(ValidPingNb(3), ShouldBeFalse)
+	a.So(ValidPingNb(256), ShouldBeFalse)
+	for i := uint8(0); i < 8; i++ {
+		a.So(ValidPingNb(PingNb(i)), ShouldBeTrue)
+	}
+}
+
+func TestPingOffset(t *testing.T) {
+	a := New(t)
+	devAddr := types.DevAddr{0x26, 0x01, 0x12, 0x34}
+	beaconTime := 1180353536 * time.Second
+	a.So(PingOffset(beaconTime, devAddr, 4096), ShouldEqual, 2965)
+	a.So(PingOffset(beaconTime, devAddr, 32), ShouldEqual, 2965%32)
+
+	// The offset is different for each beacon period
+	a.So(PingOffset(beaconTime+BeaconPeriod, devAddr, 4096), ShouldNotEqual, 2965)
+}
+
+func TestNextPingSlot(t *testing.T) {
+	a := New(t)
+	devAddr := types.DevAddr{0x26, 0x01, 0x12, 0x34}
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := NextPingSlot(devAddr, 3, now)
+	a.So(err, ShouldNotBeNil)
+
+	slot, err := NextPingSlot(devAddr, 1, now)
+	a.So(err, ShouldBeNil)
+	a.So(slot, ShouldResemble, time.Date(2017, 6, 1, 12, 0, 9, 70000000, time.UTC))
+
+	// The next slot is in the next beacon period
+	next, err := NextPingSlot(devAddr, 1, slot)
+	a.So(err, ShouldBeNil)
+	a.So(BeaconTime(next), ShouldEqual, BeaconTime(slot)+BeaconPeriod)
+
+	// With 128 ping slots per beacon period, slots are 32 slots apart
+	slot, err = NextPingSlot(devAddr, 128, now)
+	a.So(err, ShouldBeNil)
+	a.So(slot.Sub(now), ShouldBeLessThanOrEqualTo, 32*SlotLength)
+	next, err = NextPingSlot(devAddr, 128, slot)
+	a.So(err, ShouldBeNil)
+	a.So(next.Sub(slot), ShouldEqual, 32*SlotLength)
+}
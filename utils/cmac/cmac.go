@@ -0,0 +1,71 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package cmac implements the AES-CMAC algorithm (RFC 4493) that is used for LoRaWAN MIC calculation
+package cmac
+
+import (
+	"crypto/aes"
+)
+
+const blockSize = aes.BlockSize
+
+// rb is the constant that is used for generating the subkeys
+const rb = 0x87
+
+// shift shifts the block one bit to the left, and applies rb if the most significant bit was set
+func shift(in [blockSize]byte) (out [blockSize]byte) {
+	for i := 0; i < blockSize-1; i++ {
+		out[i] = in[i]<<1 | in[i+1]>>7
+	}
+	out[blockSize-1] = in[blockSize-1] << 1
+	if in[0]&0x80 != 0 {
+		out[blockSize-1] ^= rb
+	}
+	return
+}
+
+// xor sets dst to dst XOR src
+func xor(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// Sum returns the AES-CMAC of the data with the key
+func Sum(key [16]byte, data []byte) (sum [blockSize]byte) {
+	block, _ := aes.NewCipher(key[:]) // A 16 byte key is always valid
+
+	// Generate subkeys
+	var l [blockSize]byte
+	block.Encrypt(l[:], l[:])
+	k1 := shift(l)
+	k2 := shift(k1)
+
+	n := (len(data) + blockSize - 1) / blockSize
+	complete := n > 0 && len(data)%blockSize == 0
+	if n == 0 {
+		n = 1
+	}
+
+	// Prepare the last block
+	var last [blockSize]byte
+	if complete {
+		copy(last[:], data[(n-1)*blockSize:])
+		xor(last[:], k1[:])
+	} else {
+		rest := data[(n-1)*blockSize:]
+		copy(last[:], rest)
+		last[len(rest)] = 0x80
+		xor(last[:], k2[:])
+	}
+
+	for i := 0; i < n-1; i++ {
+		xor(sum[:], data[i*blockSize:(i+1)*blockSize])
+		block.Encrypt(sum[:], sum[:])
+	}
+	xor(sum[:], last[:])
+	block.Encrypt(sum[:], sum[:])
+
+	return
+}
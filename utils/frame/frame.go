@@ -0,0 +1,123 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package frame implements the MIC calculation and MAC command encryption of LoRaWAN 1.1 data frames
+package frame
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/cmac"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// fHDROffset is the offset of the FHDR in a data frame
+const fHDROffset = 1
+
+// b0 returns the B0 block that is used for MIC calculation, msgLen is the length of the PHYPayload without MIC
+func b0(uplink bool, confFCnt uint16, devAddr types.DevAddr, fCnt uint32, msgLen int) []byte {
+	b := make([]byte, aes.BlockSize)
+	b[0] = 0x49
+	binary.LittleEndian.PutUint16(b[1:3], confFCnt)
+	if !uplink {
+		b[5] = 0x01
+	}
+	binary.LittleEndian.PutUint32(b[6:10], binary.BigEndian.Uint32(devAddr[:]))
+	binary.LittleEndian.PutUint32(b[10:14], fCnt)
+	b[15] = byte(msgLen)
+	return b
+}
+
+// b1 returns the B1 block that is used for the part of the MIC of an uplink frame that is computed with the
+// SNwkSIntKey, msgLen is the length of the PHYPayload without MIC
+func b1(confFCnt uint16, txDr, txCh uint8, devAddr types.DevAddr, fCnt uint32, msgLen int) []byte {
+	b := b0(true, confFCnt, devAddr, fCnt, msgLen)
+	b[3] = txDr
+	b[4] = txCh
+	return b
+}
+
+// CalculateUplinkFMIC calculates the part of the MIC of an uplink frame that is computed with the FNwkSIntKey,
+// which is the MIC of LoRaWAN 1.0. The last two bytes of the MIC of a LoRaWAN 1.1 frame are the first two bytes
+// of the FMIC. The payload is the PHYPayload without MIC, and the fCnt is the full 32 bit frame counter.
+func CalculateUplinkFMIC(fNwkSIntKey types.NwkSKey, devAddr types.DevAddr, fCnt uint32, payload []byte) (mic [4]byte) {
+	sum := cmac.Sum(fNwkSIntKey, append(b0(true, 0, devAddr, fCnt, len(payload)), payload...))
+	copy(mic[:], sum[:4])
+	return
+}
+
+// ValidateUplinkFMIC validates the part of the MIC of a LoRaWAN 1.1 uplink frame that is computed with the
+// FNwkSIntKey. The payload is the full PHYPayload, including the MIC.
+func ValidateUplinkFMIC(fNwkSIntKey types.NwkSKey, devAddr types.DevAddr, fCnt uint32, payload []byte) bool {
+	if len(payload) < 4 {
+		return false
+	}
+	fMIC := CalculateUplinkFMIC(fNwkSIntKey, devAddr, fCnt, payload[:len(payload)-4])
+	return fMIC[0] == payload[len(payload)-2] && fMIC[1] == payload[len(payload)-1]
+}
+
+// CalculateUplinkSMIC calculates the part of the MIC of a LoRaWAN 1.1 uplink frame that is computed with the
+// SNwkSIntKey. The first two bytes of the MIC are the first two bytes of the SMIC. The confFCnt is the frame counter
+// of the confirmed downlink that is acknowledged by the frame (if any), txDr and txCh are the data rate index and
+// channel index of the transmission, the fCnt is the full 32 bit frame counter and the payload is the PHYPayload
+// without MIC.
+func CalculateUplinkSMIC(sNwkSIntKey types.SNwkSIntKey, confFCnt uint16, txDr, txCh uint8, devAddr types.DevAddr, fCnt uint32, payload []byte) (mic [4]byte) {
+	sum := cmac.Sum(sNwkSIntKey, append(b1(confFCnt, txDr, txCh, devAddr, fCnt, len(payload)), payload...))
+	copy(mic[:], sum[:4])
+	return
+}
+
+// ValidateUplinkMIC validates the full MIC of a LoRaWAN 1.1 uplink frame. The payload is the full PHYPayload,
+// including the MIC.
+func ValidateUplinkMIC(fNwkSIntKey types.NwkSKey, sNwkSIntKey types.SNwkSIntKey, confFCnt uint16, txDr, txCh uint8, devAddr types.DevAddr, fCnt uint32, payload []byte) bool {
+	if !ValidateUplinkFMIC(fNwkSIntKey, devAddr, fCnt, payload) {
+		return false
+	}
+	sMIC := CalculateUplinkSMIC(sNwkSIntKey, confFCnt, txDr, txCh, devAddr, fCnt, payload[:len(payload)-4])
+	return sMIC[0] == payload[len(payload)-4] && sMIC[1] == payload[len(payload)-3]
+}
+
+// CalculateDownlinkMIC calculates the MIC of a LoRaWAN 1.1 downlink frame. The confFCnt is the frame counter of the
+// confirmed uplink that is acknowledged by the frame (if any), the fCnt is the full 32 bit frame counter and the
+// payload is the PHYPayload without MIC.
+func CalculateDownlinkMIC(sNwkSIntKey types.SNwkSIntKey, confFCnt uint16, devAddr types.DevAddr, fCnt uint32, payload []byte) (mic [4]byte) {
+	sum := cmac.Sum(sNwkSIntKey, append(b0(false, confFCnt, devAddr, fCnt, len(payload)), payload...))
+	copy(mic[:], sum[:4])
+	return
+}
+
+// EncryptFOpts encrypts (or decrypts) the MAC commands in the FOpts of a LoRaWAN 1.1 data frame in place. The
+// fCnt is the full 32 bit frame counter and the payload is the PHYPayload.
+func EncryptFOpts(nwkSEncKey types.NwkSEncKey, uplink bool, fCnt uint32, payload []byte) error {
+	if len(payload) < fHDROffset+7 {
+		return errors.NewErrInvalidArgument("Payload", "too short")
+	}
+	fOptsLen := int(payload[fHDROffset+4] & 0x0F)
+	if fOptsLen == 0 {
+		return nil
+	}
+	if len(payload) < fHDROffset+7+fOptsLen {
+		return errors.NewErrInvalidArgument("FOpts", "too short")
+	}
+
+	a := make([]byte, aes.BlockSize)
+	a[0] = 0x01
+	if !uplink {
+		a[5] = 0x01
+	}
+	copy(a[6:10], payload[fHDROffset:fHDROffset+4]) // DevAddr, LSB-first
+	binary.LittleEndian.PutUint32(a[10:14], fCnt)
+	a[15] = 0x01 // As corrected in the LoRaWAN 1.1 errata
+
+	block, _ := aes.NewCipher(nwkSEncKey[:])
+	s := make([]byte, aes.BlockSize)
+	block.Encrypt(s, a)
+
+	fOpts := payload[fHDROffset+7 : fHDROffset+7+fOptsLen]
+	for i := range fOpts {
+		fOpts[i] ^= s[i]
+	}
+	return nil
+}
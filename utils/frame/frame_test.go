@@ -0,0 +1,74 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package frame
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestUplinkFMIC(t *testing.T) {
+	a := New(t)
+	key := types.NwkSKey{0x44, 0x02, 0x42, 0x41, 0xED, 0x4C, 0xE9, 0xA6, 0x8C, 0x6A, 0x8B, 0xC0, 0x55, 0x23, 0x3F, 0xD3}
+	devAddr := types.DevAddr{0x49, 0xBE, 0x7D, 0xF1}
+	payload := []byte{0x40, 0xF1, 0x7D, 0xBE, 0x49, 0x00, 0x02, 0x00, 0x01, 0x95, 0x43, 0x78, 0x76, 0x2B, 0x11, 0xFF, 0x0D}
+
+	// The FMIC is the MIC of LoRaWAN 1.0
+	a.So(CalculateUplinkFMIC(key, devAddr, 2, payload[:13]), ShouldResemble, [4]byte{0x2B, 0x11, 0xFF, 0x0D})
+
+	// For LoRaWAN 1.1, only the last two bytes of the MIC are validated
+	copy(payload[13:], []byte{0x00, 0x00, 0x2B, 0x11})
+	a.So(ValidateUplinkFMIC(key, devAddr, 2, payload), ShouldBeTrue)
+	a.So(ValidateUplinkFMIC(key, devAddr, 3, payload), ShouldBeFalse)
+	a.So(ValidateUplinkFMIC(key, devAddr, 2, payload[:3]), ShouldBeFalse)
+}
+
+func TestDownlink(t *testing.T) {
+	a := New(t)
+	nwkSEncKey := types.NwkSEncKey{0xF6, 0x8A, 0xDE, 0x90, 0x0E, 0x9D, 0xE9, 0x5B, 0x49, 0x40, 0x65, 0x3D, 0xCD, 0x87, 0x03, 0xE9}
+	sNwkSIntKey := types.SNwkSIntKey{0x36, 0x64, 0xCC, 0x3E, 0xDD, 0x47, 0x0D, 0x2B, 0x3E, 0xAC, 0x37, 0x6D, 0xED, 0x89, 0xD9, 0x5A}
+	devAddr := types.DevAddr{0x49, 0xBE, 0x7D, 0xF1}
+
+	// Unconfirmed downlink with ACK and a DevStatusReq in the FOpts
+	payload := []byte{0x60, 0xF1, 0x7D, 0xBE, 0x49, 0x81, 0x05, 0x00, 0x06}
+
+	a.So(EncryptFOpts(nwkSEncKey, false, 5, payload), ShouldBeNil)
+	a.So(payload, ShouldResemble, []byte{0x60, 0xF1, 0x7D, 0xBE, 0x49, 0x81, 0x05, 0x00, 0xE3})
+
+	a.So(CalculateDownlinkMIC(sNwkSIntKey, 2, devAddr, 5, payload), ShouldResemble, [4]byte{0xF0, 0xA2, 0x1E, 0x07})
+
+	// Decrypt
+	a.So(EncryptFOpts(nwkSEncKey, false, 5, payload), ShouldBeNil)
+	a.So(payload[8], ShouldEqual, 0x06)
+
+	// Invalid
+	a.So(EncryptFOpts(nwkSEncKey, false, 5, payload[:5]), ShouldNotBeNil)
+	a.So(EncryptFOpts(nwkSEncKey, false, 5, payload[:8]), ShouldNotBeNil)
+}
+
+func TestUplinkMIC(t *testing.T) {
+	a := New(t)
+	fNwkSIntKey := types.NwkSKey{0x44, 0x02, 0x42, 0x41, 0xED, 0x4C, 0xE9, 0xA6, 0x8C, 0x6A, 0x8B, 0xC0, 0x55, 0x23, 0x3F, 0xD3}
+	sNwkSIntKey := types.SNwkSIntKey{0x36, 0x64, 0xCC, 0x3E, 0xDD, 0x47, 0x0D, 0x2B, 0x3E, 0xAC, 0x37, 0x6D, 0xED, 0x89, 0xD9, 0x5A}
+	devAddr := types.DevAddr{0x49, 0xBE, 0x7D, 0xF1}
+	payload := []byte{0x40, 0xF1, 0x7D, 0xBE, 0x49, 0x00, 0x02, 0x00, 0x01, 0x95, 0x43, 0x78, 0x76, 0x00, 0x00, 0x00, 0x00}
+
+	fMIC := CalculateUplinkFMIC(fNwkSIntKey, devAddr, 2, payload[:13])
+	sMIC := CalculateUplinkSMIC(sNwkSIntKey, 0, 5, 0, devAddr, 2, payload[:13])
+	a.So(sMIC, ShouldNotResemble, CalculateUplinkSMIC(sNwkSIntKey, 0, 5, 1, devAddr, 2, payload[:13]))
+	a.So(sMIC, ShouldNotResemble, CalculateUplinkSMIC(sNwkSIntKey, 0, 4, 0, devAddr, 2, payload[:13]))
+	a.So(sMIC, ShouldNotResemble, CalculateUplinkSMIC(sNwkSIntKey, 1, 5, 0, devAddr, 2, payload[:13]))
+
+	// The MIC is the first half of the SMIC followed by the first half of the FMIC
+	copy(payload[13:], []byte{sMIC[0], sMIC[1], fMIC[0], fMIC[1]})
+	a.So(ValidateUplinkMIC(fNwkSIntKey, sNwkSIntKey, 0, 5, 0, devAddr, 2, payload), ShouldBeTrue)
+	a.So(ValidateUplinkMIC(fNwkSIntKey, sNwkSIntKey, 0, 5, 1, devAddr, 2, payload), ShouldBeFalse)
+
+	// Only the FMIC is valid
+	payload[13]++
+	a.So(ValidateUplinkFMIC(fNwkSIntKey, devAddr, 2, payload), ShouldBeTrue)
+	a.So(ValidateUplinkMIC(fNwkSIntKey, sNwkSIntKey, 0, 5, 0, devAddr, 2, payload), ShouldBeFalse)
+}
@@ -0,0 +1,78 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package otaa
+
+import (
+	"crypto/aes"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/cmac"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// JoinRequestType is the JoinReqType of a join-accept in response to a JoinRequest. For join-accepts in response to
+// a RejoinRequest, the JoinReqType is the type of the RejoinRequest
+const JoinRequestType = 0xFF
+
+// dlSettingsIndex is the index of the DLSettings in a join-accept PHYPayload
+const dlSettingsIndex = 11
+
+// CalculateJoinRequestMIC calculates the MIC of a JoinRequest or RejoinRequest, the payload is the PHYPayload without MIC
+func CalculateJoinRequestMIC(key types.AES128Key, payload []byte) (mic [4]byte) {
+	sum := cmac.Sum(key, payload)
+	copy(mic[:], sum[:4])
+	return
+}
+
+// SetOptNeg sets the OptNeg bit in the DLSettings of a join-accept PHYPayload, which indicates to the device
+// that the network supports LoRaWAN 1.1
+func SetOptNeg(payload []byte) error {
+	if len(payload) <= dlSettingsIndex {
+		return errors.NewErrInvalidArgument("Join-accept", "too short")
+	}
+	payload[dlSettingsIndex] |= 0x80
+	return nil
+}
+
+// CalculateJoinAcceptMIC calculates the MIC of a LoRaWAN 1.1 join-accept, the payload is the PHYPayload without MIC.
+// The joinEUI and devNonce are those of the JoinRequest (MSB-first), for RejoinRequests the devNonce is the RJcount
+func CalculateJoinAcceptMIC(jsIntKey types.AES128Key, joinReqType byte, joinEUI types.AppEUI, devNonce [2]byte, payload []byte) (mic [4]byte) {
+	buf := make([]byte, 0, 11+len(payload))
+	buf = append(buf, joinReqType)
+	buf = append(buf, reverse(joinEUI[:])...)
+	buf = append(buf, reverse(devNonce[:])...)
+	buf = append(buf, payload...)
+	sum := cmac.Sum(jsIntKey, buf)
+	copy(mic[:], sum[:4])
+	return
+}
+
+// EncryptJoinAccept encrypts a join-accept PHYPayload (including the MIC) with the key
+func EncryptJoinAccept(key types.AES128Key, payload []byte) ([]byte, error) {
+	if len(payload) < 1+aes.BlockSize || (len(payload)-1)%aes.BlockSize != 0 {
+		return nil, errors.NewErrInvalidArgument("Join-accept", "invalid length")
+	}
+	block, _ := aes.NewCipher(key[:])
+	encrypted := make([]byte, len(payload))
+	encrypted[0] = payload[0] // The MHDR is not encrypted
+	for i := 1; i < len(payload); i += aes.BlockSize {
+		// The join-accept is encrypted with the AES decrypt operation, so that devices only need AES encrypt
+		block.Decrypt(encrypted[i:i+aes.BlockSize], payload[i:i+aes.BlockSize])
+	}
+	return encrypted, nil
+}
+
+// DecryptJoinAccept decrypts a join-accept PHYPayload that was encrypted with the key
+func DecryptJoinAccept(key types.AES128Key, payload []byte) ([]byte, error) {
+	if len(payload) < 1+aes.BlockSize || (len(payload)-1)%aes.BlockSize != 0 {
+		return nil, errors.NewErrInvalidArgument("Join-accept", "invalid length")
+	}
+	block, _ := aes.NewCipher(key[:])
+	decrypted := make([]byte, len(payload))
+	decrypted[0] = payload[0]
+	for i := 1; i < len(payload); i += aes.BlockSize {
+		block.Encrypt(decrypted[i:i+aes.BlockSize], payload[i:i+aes.BlockSize])
+	}
+	return decrypted, nil
+}
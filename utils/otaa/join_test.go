@@ -0,0 +1,48 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package otaa
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestCalculateJoinRequestMIC(t *testing.T) {
+	a := New(t)
+	appKey := types.AES128Key{0xB6, 0xB5, 0x3F, 0x4A, 0x16, 0x8A, 0x7A, 0x88, 0xBD, 0xF7, 0xEA, 0x13, 0x5C, 0xE9, 0xCF, 0xCA}
+	payload := []byte{0x00, 0xDC, 0x00, 0x00, 0xD0, 0x7E, 0xD5, 0xB3, 0x70, 0x1E, 0x6F, 0xED, 0xF5, 0x7C, 0xEE, 0xAF, 0x00, 0xC8, 0x86}
+	a.So(CalculateJoinRequestMIC(appKey, payload), ShouldResemble, [4]byte{0x03, 0x0A, 0xF2, 0xC9})
+}
+
+func TestLoRaWAN11JoinAccept(t *testing.T) {
+	a := New(t)
+
+	nwkKey := types.NwkKey{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}
+	jsIntKey := types.AES128Key{0x78, 0x57, 0xCF, 0x5C, 0x22, 0x14, 0x99, 0x4E, 0x22, 0xFE, 0xF8, 0x06, 0x5E, 0xB8, 0x73, 0x97}
+	joinEUI := types.AppEUI{0x70, 0xB3, 0xD5, 0x7E, 0xF0, 0x00, 0x00, 0x01}
+
+	// MHDR | JoinNonce | NetID | DevAddr | DLSettings | RxDelay | MIC
+	payload := []byte{0x20, 0x01, 0x00, 0x00, 0x13, 0x00, 0x00, 0x34, 0x12, 0x01, 0x26, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}
+
+	a.So(SetOptNeg(payload[:5]), ShouldNotBeNil)
+	a.So(SetOptNeg(payload), ShouldBeNil)
+	a.So(payload[11], ShouldEqual, 0x80)
+
+	mic := CalculateJoinAcceptMIC(jsIntKey, JoinRequestType, joinEUI, [2]byte{0x00, 0x01}, payload[:13])
+	a.So(mic, ShouldResemble, [4]byte{0x8D, 0x14, 0x01, 0x2F})
+	copy(payload[13:], mic[:])
+
+	encrypted, err := EncryptJoinAccept(types.AES128Key(nwkKey), payload)
+	a.So(err, ShouldBeNil)
+	a.So(encrypted, ShouldResemble, []byte{0x20, 0x5E, 0x36, 0x77, 0x94, 0x82, 0xB2, 0xDF, 0x34, 0x9D, 0x3C, 0x64, 0x1E, 0x46, 0x20, 0x6B, 0x7C})
+
+	decrypted, err := DecryptJoinAccept(types.AES128Key(nwkKey), encrypted)
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldResemble, payload)
+
+	_, err = EncryptJoinAccept(types.AES128Key(nwkKey), payload[:10])
+	a.So(err, ShouldNotBeNil)
+}
@@ -35,3 +35,46 @@ func reverse(in []byte) (out []byte) {
 	}
 	return
 }
+
+// CalculateLoRaWAN11SessionKeys calculates the FNwkSIntKey, SNwkSIntKey, NwkSEncKey and AppSKey of a LoRaWAN 1.1 device.
+// The FNwkSIntKey is returned as NwkSKey. For activations with a RejoinRequest, the devNonce is the RJcount.
+// All arguments are MSB-first
+func CalculateLoRaWAN11SessionKeys(nwkKey types.NwkKey, appKey types.AppKey, joinNonce [3]byte, joinEUI types.AppEUI, devNonce [2]byte) (fNwkSIntKey types.NwkSKey, sNwkSIntKey types.SNwkSIntKey, nwkSEncKey types.NwkSEncKey, appSKey types.AppSKey, err error) {
+
+	buf := make([]byte, 16)
+	copy(buf[1:4], reverse(joinNonce[:]))
+	copy(buf[4:12], reverse(joinEUI[:]))
+	copy(buf[12:14], reverse(devNonce[:]))
+
+	nwkBlock, _ := aes.NewCipher(nwkKey[:])
+	appBlock, _ := aes.NewCipher(appKey[:])
+
+	buf[0] = 0x1
+	nwkBlock.Encrypt(fNwkSIntKey[:], buf)
+	buf[0] = 0x2
+	appBlock.Encrypt(appSKey[:], buf)
+	buf[0] = 0x3
+	nwkBlock.Encrypt(sNwkSIntKey[:], buf)
+	buf[0] = 0x4
+	nwkBlock.Encrypt(nwkSEncKey[:], buf)
+
+	return
+}
+
+// CalculateJoinServerKeys calculates the JSIntKey and JSEncKey of a LoRaWAN 1.1 device. The JSIntKey is used for the MIC
+// of join-accepts and RejoinRequests of type 1, the JSEncKey is used for encrypting join-accepts in response to
+// RejoinRequests. All arguments are MSB-first
+func CalculateJoinServerKeys(nwkKey types.NwkKey, devEUI types.DevEUI) (jsIntKey types.AES128Key, jsEncKey types.AES128Key) {
+
+	buf := make([]byte, 16)
+	copy(buf[1:9], reverse(devEUI[:]))
+
+	block, _ := aes.NewCipher(nwkKey[:])
+
+	buf[0] = 0x5
+	block.Encrypt(jsEncKey[:], buf)
+	buf[0] = 0x6
+	block.Encrypt(jsIntKey[:], buf)
+
+	return
+}
@@ -29,3 +29,34 @@ func TestCalculateSessionKeys(t *testing.T) {
 	a.So(appSKey, ShouldResemble, expectedAppSKey)
 	a.So(nwkSKey, ShouldResemble, expectedNwkSKey)
 }
+
+func TestCalculateLoRaWAN11SessionKeys(t *testing.T) {
+	a := New(t)
+
+	// MSB first
+	nwkKey := types.NwkKey{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}
+	appKey := types.AppKey{0xBE, 0xC4, 0x99, 0xC6, 0x9E, 0x9C, 0x93, 0x9E, 0x41, 0x3B, 0x66, 0x39, 0x61, 0x63, 0x6C, 0x61}
+	joinNonce := [3]byte{0x00, 0x00, 0x01}
+	joinEUI := types.AppEUI{0x70, 0xB3, 0xD5, 0x7E, 0xF0, 0x00, 0x00, 0x01}
+	devNonce := [2]byte{0x00, 0x01}
+
+	fNwkSIntKey, sNwkSIntKey, nwkSEncKey, appSKey, err := CalculateLoRaWAN11SessionKeys(nwkKey, appKey, joinNonce, joinEUI, devNonce)
+	a.So(err, ShouldBeNil)
+
+	// MSB first
+	a.So(fNwkSIntKey, ShouldResemble, types.NwkSKey{0x31, 0x4A, 0xBF, 0x5A, 0x0C, 0x75, 0xB3, 0x67, 0xFC, 0x7A, 0x8A, 0xA3, 0x4B, 0x88, 0xC5, 0xE9})
+	a.So(sNwkSIntKey, ShouldResemble, types.SNwkSIntKey{0x36, 0x64, 0xCC, 0x3E, 0xDD, 0x47, 0x0D, 0x2B, 0x3E, 0xAC, 0x37, 0x6D, 0xED, 0x89, 0xD9, 0x5A})
+	a.So(nwkSEncKey, ShouldResemble, types.NwkSEncKey{0xF6, 0x8A, 0xDE, 0x90, 0x0E, 0x9D, 0xE9, 0x5B, 0x49, 0x40, 0x65, 0x3D, 0xCD, 0x87, 0x03, 0xE9})
+	a.So(appSKey, ShouldResemble, types.AppSKey{0x1C, 0x5E, 0xD8, 0x1A, 0x68, 0x1A, 0xC0, 0xCC, 0x22, 0x52, 0x39, 0x68, 0x93, 0xAD, 0x01, 0x97})
+}
+
+func TestCalculateJoinServerKeys(t *testing.T) {
+	a := New(t)
+
+	nwkKey := types.NwkKey{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}
+	devEUI := types.DevEUI{0x00, 0x04, 0xA3, 0x0B, 0x00, 0x1A, 0x2B, 0x3C}
+
+	jsIntKey, jsEncKey := CalculateJoinServerKeys(nwkKey, devEUI)
+	a.So(jsIntKey, ShouldResemble, types.AES128Key{0x78, 0x57, 0xCF, 0x5C, 0x22, 0x14, 0x99, 0x4E, 0x22, 0xFE, 0xF8, 0x06, 0x5E, 0xB8, 0x73, 0x97})
+	a.So(jsEncKey, ShouldResemble, types.AES128Key{0x17, 0xB1, 0x1E, 0x73, 0x75, 0x37, 0x36, 0x1E, 0x9E, 0x48, 0x53, 0x50, 0x39, 0x63, 0x50, 0xE5})
+}